@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// withDeadline runs handler under the default tool deadline when the request carries none (see
+// tools.WithToolDeadline), and reports how far a cancelled call got.
+func withDeadline[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+		ctx, cancel := tools.WithToolDeadline(ctx)
+		defer cancel()
+
+		res, out, err := handler(ctx, req, in)
+
+		return res, out, tools.CancellationError(ctx, err)
+	}
+}
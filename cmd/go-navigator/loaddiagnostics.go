@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// loadDiagnosticsMetaKey is the _meta key under which tool results carry load diagnostics.
+const loadDiagnosticsMetaKey = "loadDiagnostics"
+
+// attachLoadDiagnostics is a receiving middleware that collects the diagnostics of the package loads a
+// tool call performs (package errors, unmatched patterns, the go command log of slow loads) and attaches
+// them to the result's _meta when there are any.
+func attachLoadDiagnostics(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		ctx, diagnostics := tools.WithLoadDiagnostics(ctx)
+
+		res, err := next(ctx, method, req)
+
+		result, ok := res.(*mcp.CallToolResult)
+		if err != nil || !ok {
+			return res, err
+		}
+
+		if diags := diagnostics(); len(diags) > 0 {
+			if result.Meta == nil {
+				result.Meta = mcp.Meta{}
+			}
+
+			result.Meta[loadDiagnosticsMetaKey] = diags
+		}
+
+		return result, nil
+	}
+}
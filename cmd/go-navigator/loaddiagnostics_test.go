@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestAttachLoadDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module lang\n\ngo 1.22\n",
+		"lang.go": "package lang\n\nimport _ \"example.com/missing\"\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	cs := connect(t, newToolPolicy(false, "", ""))
+
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "listSymbols",
+		Arguments: map[string]any{"dir": dir, "package": "lang"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool error: %v", err)
+	}
+
+	diags, ok := res.Meta[loadDiagnosticsMetaKey].([]any)
+	if !ok || len(diags) == 0 {
+		t.Fatalf("expected load diagnostics in _meta, got %+v", res.Meta)
+	}
+
+	if text, _ := diags[0].(string); !strings.Contains(text, "example.com/missing") {
+		t.Errorf("expected a diagnostic naming the missing import, got %q", diags)
+	}
+}
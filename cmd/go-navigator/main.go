@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"os"
 	"os/signal"
 	"strings"
@@ -20,9 +21,95 @@ func main() {
 
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 
+	cacheDir := flag.String("cache-dir", "", "directory for the persistent cache of syntax-derived facts (disabled if empty)")
+	readOnly := flag.Bool("readonly", false, "reject calls to tools that modify files")
+	allowTools := flag.String("allow-tools", "", "comma-separated list of tools that may be called (all if empty)")
+	denyTools := flag.String("deny-tools", "", "comma-separated list of tools that are rejected")
+	preloadDir := flag.String("preload-dir", "", "module directory to load into the cache before serving requests")
+	auditLog := flag.String("audit-log", "", "JSONL file that records every file mutation (disabled if empty)")
+	ownersFile := flag.String("owners-file", "", "CODEOWNERS-format file resolving the owners of files reported by analysis tools (disabled if empty)")
+	loadTimeout := flag.Duration("load-timeout", 2*time.Minute, "maximum duration of a package load before it fails with LOAD_FAILED (0 disables the limit)")
+	maxCacheMB := flag.Int("max-cache-mb", 0, "estimated memory budget of the package cache in MiB; least recently used loads are evicted past it (0 disables the limit)")
+	toolTimeout := flag.Duration("tool-timeout", 90*time.Second, "deadline of a tool call whose request has none; exceeding it fails with CANCELLED (0 disables the limit)")
+	flag.Func("root", "module directory clients can address as name (name=path, repeatable); the only root is the default dir", registerRootFlag)
+	flag.Parse()
+
+	if err := tools.ConfigureLoadTimeout(*loadTimeout); err != nil {
+		log.Fatal().Err(err).Msg("invalid --load-timeout")
+	}
+
+	if err := tools.ConfigureToolTimeout(*toolTimeout); err != nil {
+		log.Fatal().Err(err).Msg("invalid --tool-timeout")
+	}
+
+	if err := tools.ConfigureCacheBudget(*maxCacheMB); err != nil {
+		log.Fatal().Err(err).Msg("invalid --max-cache-mb")
+	}
+
+	if *cacheDir != "" {
+		if err := tools.ConfigureDiskCache(*cacheDir); err != nil {
+			log.Warn().Err(err).Str("dir", *cacheDir).Msg("persistent cache disabled")
+		}
+	}
+
+	if *auditLog != "" {
+		if err := tools.ConfigureAuditLog(*auditLog); err != nil {
+			log.Fatal().Err(err).Str("path", *auditLog).Msg("cannot open audit log")
+		}
+	}
+
+	if *ownersFile != "" {
+		if err := tools.ConfigureOwnersFile(*ownersFile); err != nil {
+			log.Fatal().Err(err).Str("path", *ownersFile).Msg("cannot read owners file")
+		}
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	policy := newToolPolicy(*readOnly, *allowTools, *denyTools)
+	server := newServer(policy)
+
+	if unknown := policy.unknownTools(); len(unknown) > 0 {
+		log.Warn().Strs("tools", unknown).Msg("--allow-tools/--deny-tools name unknown tools")
+	}
+
+	err := tools.HealthCheck()
+	if err != nil {
+		log.Warn().Err(err).Msg("initial health check failed (non-fatal)")
+	} else {
+		log.Info().Msg("health check passed")
+	}
+
+	if *preloadDir != "" {
+		if err := tools.Preload(ctx, *preloadDir); err != nil {
+			log.Warn().Err(err).Str("dir", *preloadDir).Msg("preload failed (non-fatal)")
+		} else {
+			log.Info().Str("dir", *preloadDir).Msg("preload finished")
+		}
+	}
+
+	log.Info().Msg("🚀 go-navigator MCP server started (press Ctrl+C to stop)")
+
+	go func() {
+		err := server.Run(ctx, &mcp.StdioTransport{})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Fatal().Err(err).Msg("server terminated with error")
+		} else {
+			log.Info().Msg("server stopped cleanly")
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info().Msg("🛑 go-navigator MCP server stopped gracefully")
+
+	time.Sleep(200 * time.Millisecond)
+	os.Stderr.Sync()
+}
+
+// newServer creates the MCP server and registers all tools according to the given policy.
+// Failed tool calls return a structured {code, message, details} body (see structuredToolErrors).
+func newServer(policy *toolPolicy) *mcp.Server {
 	server := mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "go-navigator",
@@ -41,13 +128,15 @@ Capabilities
 
 Usage
 - Run tools from the Go module root (directory containing go.mod)
-- Pass "dir" to specify the analysis root
+- Pass "dir" to specify the analysis root, or "root" to name one registered with --root (listRoots lists them)
 - Prefer semantic analysis tools over text search for accuracy
             `),
 		},
 	)
 
-	mcp.AddTool[tools.ListPackagesInput, tools.ListPackagesOutput](server, &mcp.Tool{
+	server.AddReceivingMiddleware(structuredToolErrors, attachLoadDiagnostics)
+
+	addTool(server, policy, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint: true,
 		},
@@ -56,7 +145,7 @@ Usage
 		Title:       "List Packages",
 	}, tools.ListPackages)
 
-	mcp.AddTool[tools.ListSymbolsInput, tools.ListSymbolsOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "listSymbols",
 		Title: "List Symbols",
 		Annotations: &mcp.ToolAnnotations{
@@ -65,7 +154,7 @@ Usage
 		Description: tools.ListSymbolsDesc,
 	}, tools.ListSymbols)
 
-	mcp.AddTool[tools.FindDefinitionsInput, tools.FindDefinitionsOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getDefinitions",
 		Title: "Get Definitions",
 		Annotations: &mcp.ToolAnnotations{
@@ -74,7 +163,7 @@ Usage
 		Description: tools.GetDefinitionsDesc,
 	}, tools.FindDefinitions)
 
-	mcp.AddTool[tools.FindReferencesInput, tools.FindReferencesOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getReferences",
 		Title: "Get References",
 		Annotations: &mcp.ToolAnnotations{
@@ -83,7 +172,7 @@ Usage
 		Description: tools.GetReferencesDesc,
 	}, tools.FindReferences)
 
-	mcp.AddTool[tools.FindBestContextInput, tools.FindBestContextOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getSymbolContext",
 		Title: "Get Symbol Context",
 		Annotations: &mcp.ToolAnnotations{
@@ -92,7 +181,7 @@ Usage
 		Description: tools.GetSymbolContextDesc,
 	}, tools.FindBestContext)
 
-	mcp.AddTool[tools.RenameSymbolInput, tools.RenameSymbolOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "renameSymbol",
 		Title: "Rename Symbol",
 		Annotations: &mcp.ToolAnnotations{
@@ -101,7 +190,7 @@ Usage
 		Description: tools.RenameSymbolDesc,
 	}, tools.RenameSymbol)
 
-	mcp.AddTool[tools.ListImportsInput, tools.ListImportsOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "listImports",
 		Title: "List Imports",
 		Annotations: &mcp.ToolAnnotations{
@@ -110,7 +199,7 @@ Usage
 		Description: tools.ListImportsDesc,
 	}, tools.ListImports)
 
-	mcp.AddTool[tools.ListInterfacesInput, tools.ListInterfacesOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "listInterfaces",
 		Title: "List Interfaces",
 		Annotations: &mcp.ToolAnnotations{
@@ -119,7 +208,7 @@ Usage
 		Description: tools.ListInterfacesDesc,
 	}, tools.ListInterfaces)
 
-	mcp.AddTool[tools.AnalyzeComplexityInput, tools.AnalyzeComplexityOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getComplexityReport",
 		Title: "Get Complexity Report",
 		Annotations: &mcp.ToolAnnotations{
@@ -128,7 +217,7 @@ Usage
 		Description: tools.GetComplexityReportDesc,
 	}, tools.AnalyzeComplexity)
 
-	mcp.AddTool[tools.DeadCodeInput, tools.DeadCodeOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getDeadCodeReport",
 		Title: "Get Dead Code Report",
 		Annotations: &mcp.ToolAnnotations{
@@ -137,7 +226,7 @@ Usage
 		Description: tools.GetDeadCodeReportDesc,
 	}, tools.DeadCode)
 
-	mcp.AddTool[tools.AnalyzeDependenciesInput, tools.AnalyzeDependenciesOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getDependencyGraph",
 		Title: "Get Dependency Graph",
 		Annotations: &mcp.ToolAnnotations{
@@ -146,7 +235,7 @@ Usage
 		Description: tools.GetDependencyGraphDesc,
 	}, tools.AnalyzeDependencies)
 
-	mcp.AddTool[tools.FindImplementationsInput, tools.FindImplementationsOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getImplementations",
 		Title: "Get Implementations",
 		Annotations: &mcp.ToolAnnotations{
@@ -155,7 +244,7 @@ Usage
 		Description: tools.GetImplementationsDesc,
 	}, tools.FindImplementations)
 
-	mcp.AddTool[tools.ASTRewriteInput, tools.ASTRewriteOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "rewriteAst",
 		Title: "Rewrite AST (Semantic)",
 		Annotations: &mcp.ToolAnnotations{
@@ -164,7 +253,7 @@ Usage
 		Description: tools.RewriteAstDesc,
 	}, tools.ASTRewrite)
 
-	mcp.AddTool[tools.ReadFuncInput, tools.ReadFuncOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getFunctionSource",
 		Title: "Get Function Source",
 		Annotations: &mcp.ToolAnnotations{
@@ -173,7 +262,7 @@ Usage
 		Description: tools.GetFunctionSourceDesc,
 	}, tools.ReadFunc)
 
-	mcp.AddTool[tools.ReadGoFileInput, tools.ReadGoFileOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getFileInfo",
 		Title: "Get File Info",
 		Annotations: &mcp.ToolAnnotations{
@@ -182,7 +271,7 @@ Usage
 		Description: tools.GetFileInfoDesc,
 	}, tools.ReadGoFile)
 
-	mcp.AddTool[tools.ReadStructInput, tools.ReadStructOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getStructInfo",
 		Title: "Get Struct Info",
 		Annotations: &mcp.ToolAnnotations{
@@ -191,7 +280,7 @@ Usage
 		Description: tools.GetStructInfoDesc,
 	}, tools.ReadStruct)
 
-	mcp.AddTool[tools.ProjectSchemaInput, tools.ProjectSchemaOutput](server, &mcp.Tool{
+	addTool(server, policy, &mcp.Tool{
 		Name:  "getProjectSchema",
 		Title: "Get Project Schema",
 		Annotations: &mcp.ToolAnnotations{
@@ -201,27 +290,518 @@ Usage
 		Description: tools.GetProjectSchemaDesc,
 	}, tools.ProjectSchema)
 
-	err := tools.HealthCheck()
-	if err != nil {
-		log.Warn().Err(err).Msg("initial health check failed (non-fatal)")
-	} else {
-		log.Info().Msg("health check passed")
-	}
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzePurity",
+		Title: "Analyze Function Purity",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzePurityDesc,
+	}, tools.AnalyzePurity)
 
-	log.Info().Msg("🚀 go-navigator MCP server started (press Ctrl+C to stop)")
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeLogging",
+		Title: "Analyze Logging",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeLoggingDesc,
+	}, tools.AnalyzeLogging)
 
-	go func() {
-		err := server.Run(ctx, &mcp.StdioTransport{})
-		if err != nil && !errors.Is(err, context.Canceled) {
-			log.Fatal().Err(err).Msg("server terminated with error")
-		} else {
-			log.Info().Msg("server stopped cleanly")
-		}
-	}()
+	addTool(server, policy, &mcp.Tool{
+		Name:  "reorderDeclarations",
+		Title: "Reorder Declarations",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: false,
+		},
+		Description: tools.ReorderDeclarationsDesc,
+	}, tools.ReorderDeclarations)
 
-	<-ctx.Done()
-	log.Info().Msg("🛑 go-navigator MCP server stopped gracefully")
+	addTool(server, policy, &mcp.Tool{
+		Name:  "checkDeclarationOrder",
+		Title: "Check Declaration Order",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.CheckDeclarationOrderDesc,
+	}, tools.CheckDeclarationOrder)
 
-	time.Sleep(200 * time.Millisecond)
-	os.Stderr.Sync()
+	addTool(server, policy, &mcp.Tool{
+		Name:  "watchProject",
+		Title: "Watch Project",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.WatchProjectDesc,
+	}, tools.WatchProject)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "unwatchProject",
+		Title: "Unwatch Project",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.UnwatchProjectDesc,
+	}, tools.UnwatchProject)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findConstructions",
+		Title: "Find Constructions",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindConstructionsDesc,
+	}, tools.FindConstructions)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findUntestedSymbols",
+		Title: "Find Untested Symbols",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindUntestedSymbolsDesc,
+	}, tools.FindUntestedSymbols)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "getFingerprints",
+		Title: "Get Fingerprints",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.GetFingerprintsDesc,
+	}, tools.GetFingerprints)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "describeJSONShape",
+		Title: "Describe JSON Shape",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.DescribeJSONShapeDesc,
+	}, tools.DescribeJSONShape)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "explainImplements",
+		Title: "Explain Implements",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ExplainImplementsDesc,
+	}, tools.ExplainImplements)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "warmup",
+		Title: "Warmup",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.WarmupDesc,
+	}, tools.Warmup)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeAllocations",
+		Title: "Analyze Allocations",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeAllocationsDesc,
+	}, tools.AnalyzeAllocations)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "checkArchitecture",
+		Title: "Check Architecture Rules",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.CheckArchitectureDesc,
+	}, tools.CheckArchitecture)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "getTypeInfo",
+		Title: "Get Type Info",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.GetTypeInfoDesc,
+	}, tools.GetTypeInfo)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findSwallowedErrors",
+		Title: "Find Swallowed Errors",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindSwallowedErrorsDesc,
+	}, tools.FindSwallowedErrors)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "getAuditLog",
+		Title: "Get Audit Log",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.GetAuditLogDesc,
+	}, tools.GetAuditLog)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeExternalUsage",
+		Title: "Analyze External Usage",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeExternalUsageDesc,
+	}, tools.AnalyzeExternalUsage)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "checkLanguageLevel",
+		Title: "Check Language Level",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.CheckLanguageLevelDesc,
+	}, tools.CheckLanguageLevel)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "exportIndex",
+		Title: "Export Index",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: false,
+		},
+		Description: tools.ExportIndexDesc,
+	}, tools.ExportIndex)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "importIndex",
+		Title: "Import Index",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ImportIndexDesc,
+	}, tools.ImportIndex)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findMagicValues",
+		Title: "Find Magic Values",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindMagicValuesDesc,
+	}, tools.FindMagicValues)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "resolvePosition",
+		Title: "Resolve file:line positions",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ResolvePositionDesc,
+	}, tools.ResolvePosition)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "listGenerators",
+		Title: "List go:generate directives",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ListGeneratorsDesc,
+	}, tools.ListGenerators)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "suggestFileSplit",
+		Title: "Suggest file split",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.SuggestFileSplitDesc,
+	}, tools.SuggestFileSplit)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "applyFileSplit",
+		Title: "Apply file split",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: false,
+		},
+		Description: tools.ApplyFileSplitDesc,
+	}, tools.ApplyFileSplit)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "verifyBuild",
+		Title: "Verify Build",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.VerifyBuildDesc,
+	}, tools.VerifyBuild)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "navigateFile",
+		Title: "Navigate File",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.NavigateFileDesc,
+	}, tools.NavigateFile)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeConfigSurface",
+		Title: "Analyze Config Surface",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeConfigSurfaceDesc,
+	}, tools.AnalyzeConfigSurface)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "listRoots",
+		Title: "List Roots",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ListRootsDesc,
+	}, tools.ListRoots)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "previewDelete",
+		Title: "Preview Delete",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.PreviewDeleteDesc,
+	}, tools.PreviewDelete)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findOverexportedSymbols",
+		Title: "Find Overexported Symbols",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindOverexportedSymbolsDesc,
+	}, tools.FindOverexportedSymbols)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeFieldUsage",
+		Title: "Analyze Field Usage",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeFieldUsageDesc,
+	}, tools.AnalyzeFieldUsage)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "suggestParameterObjects",
+		Title: "Suggest Parameter Objects",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.SuggestParameterObjectsDesc,
+	}, tools.SuggestParameterObjects)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeDefers",
+		Title: "Analyze Defers",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeDefersDesc,
+	}, tools.AnalyzeDefers)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findTypeAssertions",
+		Title: "Find Type Assertions",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindTypeAssertionsDesc,
+	}, tools.FindTypeAssertions)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findInterfaceConversions",
+		Title: "Find Interface Conversions",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindInterfaceConversionsDesc,
+	}, tools.FindInterfaceConversions)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "findCallPath",
+		Title: "Find Call Path",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.FindCallPathDesc,
+	}, tools.FindCallPath)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeSQL",
+		Title: "Analyze SQL",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeSQLDesc,
+	}, tools.AnalyzeSQL)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "checkFileHeaders",
+		Title: "Check File Headers",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.CheckFileHeadersDesc,
+	}, tools.CheckFileHeaders)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeLifecycles",
+		Title: "Analyze Lifecycles",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeLifecyclesDesc,
+	}, tools.AnalyzeLifecycles)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "inspectNode",
+		Title: "Inspect Node",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.InspectNodeDesc,
+	}, tools.InspectNode)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "releaseReport",
+		Title: "Release Report",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ReleaseReportDesc,
+	}, tools.ReleaseReport)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeReceiverSemantics",
+		Title: "Analyze Receiver Semantics",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeReceiverSemanticsDesc,
+	}, tools.AnalyzeReceiverSemantics)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeImportWeight",
+		Title: "Analyze Import Weight",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeImportWeightDesc,
+	}, tools.AnalyzeImportWeight)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "resolvePackageDir",
+		Title: "Resolve Package Dir",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ResolvePackageDirDesc,
+	}, tools.ResolvePackageDir)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "checkTagConsistency",
+		Title: "Check Tag Consistency",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.CheckTagConsistencyDesc,
+	}, tools.CheckTagConsistency)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "suggestIdentifierRenames",
+		Title: "Suggest Identifier Renames",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.SuggestIdentifierRenamesDesc,
+	}, tools.SuggestIdentifierRenames)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "validateNewFile",
+		Title: "Validate New File",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ValidateNewFileDesc,
+	}, tools.ValidateNewFile)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeInterfaceCohesion",
+		Title: "Analyze Interface Cohesion",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeInterfaceCohesionDesc,
+	}, tools.AnalyzeInterfaceCohesion)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "applyMigrations",
+		Title: "Apply API Migrations",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: false,
+		},
+		Description: tools.ApplyMigrationsDesc,
+	}, tools.ApplyMigrations)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "updateFile",
+		Title: "Update File Incrementally",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: false,
+		},
+		Description: tools.UpdateFileDesc,
+	}, tools.UpdateFile)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeHTTPSurface",
+		Title: "Analyze HTTP Surface",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeHTTPSurfaceDesc,
+	}, tools.AnalyzeHTTPSurface)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "getModuleInfo",
+		Title: "Get Module Info",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.GetModuleInfoDesc,
+	}, tools.GetModuleInfo)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "explainTypeError",
+		Title: "Explain Type Error",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.ExplainTypeErrorDesc,
+	}, tools.ExplainTypeError)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "analyzeInitDependencies",
+		Title: "Analyze Init Dependencies",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.AnalyzeInitDependenciesDesc,
+	}, tools.AnalyzeInitDependencies)
+
+	addTool(server, policy, &mcp.Tool{
+		Name:  "getServerStatus",
+		Title: "Get Server Status",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		Description: tools.GetServerStatusDesc,
+	}, tools.GetServerStatus)
+
+	return server
 }
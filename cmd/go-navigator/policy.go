@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// toolPolicy decides which registered tools may be called.
+// Refused tools stay registered so clients get an explanation instead of an unknown-tool failure.
+type toolPolicy struct {
+	readOnly   bool
+	allow      map[string]struct{}
+	deny       map[string]struct{}
+	registered map[string]struct{}
+}
+
+// newToolPolicy builds a policy from the --readonly, --allow-tools and --deny-tools flag values.
+//
+// Parameters:
+//   - readOnly: reject tools that are not annotated as read-only
+//   - allow: comma-separated list of allowed tools; empty allows all
+//   - deny: comma-separated list of denied tools
+//
+// Returns:
+//   - the tool policy
+func newToolPolicy(readOnly bool, allow, deny string) *toolPolicy {
+	return &toolPolicy{
+		readOnly:   readOnly,
+		allow:      splitToolList(allow),
+		deny:       splitToolList(deny),
+		registered: make(map[string]struct{}),
+	}
+}
+
+// refusal returns the error reported for calls to the tool, or nil if the tool may be called.
+// Deny takes precedence over allow.
+func (p *toolPolicy) refusal(tool *mcp.Tool) error {
+	if _, ok := p.deny[tool.Name]; ok {
+		return fmt.Errorf("tool %q is disabled by --deny-tools", tool.Name)
+	}
+
+	if len(p.allow) > 0 {
+		if _, ok := p.allow[tool.Name]; !ok {
+			return fmt.Errorf("tool %q is disabled: not listed in --allow-tools", tool.Name)
+		}
+	}
+
+	if p.readOnly && (tool.Annotations == nil || !tool.Annotations.ReadOnlyHint) {
+		return fmt.Errorf("tool %q modifies files and is disabled by --readonly", tool.Name)
+	}
+
+	return nil
+}
+
+// unknownTools returns names from --allow-tools and --deny-tools that match no registered tool.
+func (p *toolPolicy) unknownTools() []string {
+	var unknown []string
+
+	for _, list := range []map[string]struct{}{p.allow, p.deny} {
+		for name := range list {
+			if _, ok := p.registered[name]; !ok {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+// addTool registers a tool whose handler is replaced by a TOOL_DENIED refusal when the policy disables it.
+// Tools taking a dir also accept a root (see withRoots), and every call runs under a deadline (see withDeadline).
+// Handler errors are recorded for structuredToolErrors.
+func addTool[In, Out any](server *mcp.Server, policy *toolPolicy, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	policy.registered[tool.Name] = struct{}{}
+
+	if dirField := dirFieldIndex(reflect.TypeFor[In]()); dirField >= 0 {
+		schema, err := rootInputSchema[In]()
+		if err != nil {
+			panic(fmt.Sprintf("tool %q: input schema: %v", tool.Name, err))
+		}
+
+		tool.InputSchema = schema
+		handler = withRoots(handler, dirField)
+	}
+
+	if err := policy.refusal(tool); err != nil {
+		refusal := tools.NewToolError(tools.CodeToolDenied, err)
+		handler = func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, Out, error) {
+			var zero Out
+
+			return nil, zero, refusal
+		}
+	}
+
+	mcp.AddTool(server, tool, recordToolError(withDeadline(handler)))
+}
+
+// splitToolList parses a comma-separated tool list, ignoring blanks.
+func splitToolList(list string) map[string]struct{} {
+	result := make(map[string]struct{})
+
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			result[name] = struct{}{}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func connect(t *testing.T, policy *toolPolicy) *mcp.ClientSession {
+	t.Helper()
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	ss, err := newServer(policy).Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = ss.Close() })
+
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = cs.Close() })
+
+	return cs
+}
+
+func callRefusal(t *testing.T, cs *mcp.ClientSession, name string, args map[string]any) string {
+	t.Helper()
+
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%s) protocol error: %v", name, err)
+	}
+
+	if !res.IsError {
+		return ""
+	}
+
+	var text strings.Builder
+
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			text.WriteString(tc.Text)
+		}
+	}
+
+	return text.String()
+}
+
+func TestToolPolicy_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	cs := connect(t, newToolPolicy(true, "", ""))
+
+	msg := callRefusal(t, cs, "renameSymbol", map[string]any{
+		"dir": "../..", "oldName": "ListPackages", "newName": "ListPkgs", "dryRun": true,
+	})
+	if !strings.Contains(msg, "--readonly") {
+		t.Errorf("expected renameSymbol to be refused by --readonly, got %q", msg)
+	}
+
+	if msg := callRefusal(t, cs, "getServerStatus", map[string]any{}); msg != "" {
+		t.Errorf("expected read-only tool to run, got error %q", msg)
+	}
+}
+
+func TestToolPolicy_AllowDeny(t *testing.T) {
+	t.Parallel()
+
+	cs := connect(t, newToolPolicy(false, "getServerStatus, listPackages", "listPackages"))
+
+	if msg := callRefusal(t, cs, "listPackages", map[string]any{"dir": "../.."}); !strings.Contains(msg, "--deny-tools") {
+		t.Errorf("expected deny to take precedence, got %q", msg)
+	}
+
+	if msg := callRefusal(t, cs, "listSymbols", map[string]any{"dir": "../..", "package": ""}); !strings.Contains(msg, "--allow-tools") {
+		t.Errorf("expected tool outside the allow list to be refused, got %q", msg)
+	}
+
+	if msg := callRefusal(t, cs, "getServerStatus", map[string]any{}); msg != "" {
+		t.Errorf("expected allowed tool to run, got error %q", msg)
+	}
+}
+
+func TestToolPolicy_UnknownTools(t *testing.T) {
+	t.Parallel()
+
+	policy := newToolPolicy(false, "listSymbols,noSuchTool", " ,alsoMissing")
+	newServer(policy)
+
+	want := []string{"alsoMissing", "noSuchTool"}
+	if got := policy.unknownTools(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected unknown tools %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// registerRootFlag handles one --root name=path flag.
+func registerRootFlag(value string) error {
+	name, path, ok := strings.Cut(value, "=")
+	if !ok || name == "" || path == "" {
+		return fmt.Errorf("expected name=path, got %q", value)
+	}
+
+	return tools.RegisterRoot(name, path)
+}
+
+// dirFieldIndex returns the index of the string field decoded from "dir" in a tool input struct, or -1.
+func dirFieldIndex(t reflect.Type) int {
+	if t.Kind() != reflect.Struct {
+		return -1
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == "dir" && f.Type.Kind() == reflect.String {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// rootInputSchema derives the input schema of a tool taking dir, adds the root field and makes dir
+// optional, as either can be omitted when the server has a single root.
+func rootInputSchema[In any]() (*jsonschema.Schema, error) {
+	schema, err := jsonschema.ForType(reflect.TypeFor[In](), &jsonschema.ForOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Properties["root"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Name of a root registered with --root (see listRoots), instead of dir",
+	}
+	schema.Required = slices.DeleteFunc(schema.Required, func(name string) bool { return name == "dir" })
+
+	return schema, nil
+}
+
+// withRoots resolves the dir of every call from its dir or root argument (see tools.ResolveDir) before
+// handler runs. Explicit directories are canonicalized, so the spellings of a checkout share one cache.
+func withRoots[In, Out any](handler mcp.ToolHandlerFor[In, Out], dirField int) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+		var args struct {
+			Dir  string `json:"dir"`
+			Root string `json:"root"`
+		}
+
+		if req != nil && req.Params != nil && len(req.Params.Arguments) > 0 {
+			if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+				var zero Out
+
+				return nil, zero, tools.NewToolError(tools.CodeInvalidInput, err)
+			}
+		}
+
+		dir, err := tools.ResolveDir(args.Dir, args.Root)
+		if err != nil {
+			var zero Out
+
+			return nil, zero, err
+		}
+
+		reflect.ValueOf(&in).Elem().Field(dirField).SetString(dir)
+
+		return handler(ctx, req, in)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestRoots_ResolveRootArgument(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module lang\n\ngo 1.22\n",
+		"lang.go": "package lang\n\nfunc Hello() string { return \"hi\" }\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	if err := registerRootFlag("roots-test=" + dir); err != nil {
+		t.Fatalf("registerRootFlag error: %v", err)
+	}
+
+	if err := registerRootFlag("no-path"); err == nil {
+		t.Fatal("expected an error for a flag without name=path")
+	}
+
+	cs := connect(t, newToolPolicy(false, "", ""))
+
+	list, err := cs.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools error: %v", err)
+	}
+
+	for _, tool := range list.Tools {
+		if tool.Name != "getFunctionSource" {
+			continue
+		}
+
+		data, _ := json.Marshal(tool.InputSchema)
+
+		var schema struct {
+			Properties map[string]any `json:"properties"`
+			Required   []string       `json:"required"`
+		}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			t.Fatalf("decode schema: %v", err)
+		}
+
+		if _, ok := schema.Properties["root"]; !ok || slices.Contains(schema.Required, "dir") {
+			t.Fatalf("expected an optional dir and a root property, got %s", data)
+		}
+	}
+
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "getFunctionSource",
+		Arguments: map[string]any{"root": "roots-test", "name": "Hello"},
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("CallTool with root failed: %v %+v", err, res)
+	}
+
+	var out tools.ReadFuncOutput
+
+	data, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(data, &out); err != nil || out.Function.File != "lang.go" {
+		t.Fatalf("expected Hello from lang.go, got %s (err %v)", data, err)
+	}
+
+	msg := callRefusal(t, cs, "getFunctionSource", map[string]any{"root": "unknown-root", "name": "Hello"})
+	if !strings.Contains(msg, "NOT_FOUND") || !strings.Contains(msg, "roots-test") {
+		t.Fatalf("expected NOT_FOUND listing the registered roots, got %q", msg)
+	}
+}
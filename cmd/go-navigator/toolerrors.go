@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// toolErrorKey is the context key of the slot in which a tool handler records its error.
+type toolErrorKey struct{}
+
+// recordToolError wraps a handler so that its error is stored in the slot installed by
+// structuredToolErrors; the SDK itself only keeps the error text.
+func recordToolError[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+		res, out, err := handler(ctx, req, in)
+		if slot, ok := ctx.Value(toolErrorKey{}).(*error); ok && err != nil {
+			*slot = err
+		}
+
+		return res, out, err
+	}
+}
+
+// structuredToolErrors is a receiving middleware that turns failed tool calls into a JSON body
+// {code, message, details} returned both as text content and as structured content.
+func structuredToolErrors(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		var failure error
+
+		res, err := next(context.WithValue(ctx, toolErrorKey{}, &failure), method, req)
+
+		result, ok := res.(*mcp.CallToolResult)
+		if err != nil || !ok || !result.IsError || failure == nil {
+			return res, err
+		}
+
+		body := tools.AsToolError(failure)
+
+		data, marshalErr := json.Marshal(body)
+		if marshalErr != nil {
+			return res, err
+		}
+
+		result.Content = []mcp.Content{&mcp.TextContent{Text: string(data)}}
+		result.StructuredContent = body
+
+		return result, nil
+	}
+}
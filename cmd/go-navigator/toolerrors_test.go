@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// callToolError calls a tool that is expected to fail and decodes the JSON error body from both the
+// text and the structured content.
+func callToolError(t *testing.T, cs *mcp.ClientSession, name string, args map[string]any) tools.ToolError {
+	t.Helper()
+
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%s) protocol error: %v", name, err)
+	}
+
+	if !res.IsError || len(res.Content) != 1 {
+		t.Fatalf("expected a single error content block, got %+v", res)
+	}
+
+	var body tools.ToolError
+
+	text, ok := res.Content[0].(*mcp.TextContent)
+	if !ok || json.Unmarshal([]byte(text.Text), &body) != nil {
+		t.Fatalf("expected a JSON error body, got %+v", res.Content[0])
+	}
+
+	data, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatalf("marshal structured content: %v", err)
+	}
+
+	var structured tools.ToolError
+	if err := json.Unmarshal(data, &structured); err != nil || structured.Code != body.Code || structured.Message != body.Message {
+		t.Errorf("expected structured content to match the text body %+v, got %s", body, data)
+	}
+
+	return body
+}
+
+func TestStructuredToolErrors(t *testing.T) {
+	t.Parallel()
+
+	cs := connect(t, newToolPolicy(false, "", "renameSymbol"))
+	dir := "../../internal/tools/testdata/sample"
+
+	body := callToolError(t, cs, "getFunctionSource", map[string]any{"dir": dir, "name": "NoSuchFunc"})
+	if body.Code != tools.CodeNotFound || body.Message != `function "NoSuchFunc" not found` {
+		t.Errorf("expected NOT_FOUND for a missing function, got %+v", body)
+	}
+
+	body = callToolError(t, cs, "getDependencyGraph", map[string]any{"dir": dir, "package": "nosuchpkg"})
+	if body.Code != tools.CodeNotFound || body.Details == nil || len(body.Details.Candidates) == 0 {
+		t.Errorf("expected NOT_FOUND with candidate packages, got %+v", body)
+	}
+
+	body = callToolError(t, cs, "renameSymbol", map[string]any{"dir": dir, "oldName": "Foo", "newName": "Bar", "dryRun": true})
+	if body.Code != tools.CodeToolDenied {
+		t.Errorf("expected TOOL_DENIED for a refused tool, got %+v", body)
+	}
+}
@@ -4,18 +4,18 @@ go 1.25
 
 require (
 	github.com/fsnotify/fsnotify v1.9.0
+	github.com/google/jsonschema-go v0.3.0
 	github.com/modelcontextprotocol/go-sdk v1.2.0
 	github.com/pmezard/go-difflib v1.0.0
 	github.com/rs/zerolog v1.34.0
+	golang.org/x/mod v0.31.0
 	golang.org/x/tools v0.40.0
 )
 
 require (
-	github.com/google/jsonschema-go v0.3.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
-	golang.org/x/mod v0.31.0 // indirect
 	golang.org/x/oauth2 v0.30.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
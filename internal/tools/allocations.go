@@ -0,0 +1,392 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// allocationPattern describes a syntactic allocation hotspot reported by AnalyzeAllocations.
+type allocationPattern struct {
+	name   string
+	weight int
+	hint   string
+}
+
+var (
+	allocReturnLocalPointer = allocationPattern{
+		name:   "return-local-pointer",
+		weight: 1,
+		hint:   "the value escapes to the heap; return it by value or let the caller provide storage",
+	}
+	allocAppendNoPrealloc = allocationPattern{
+		name:   "append-without-prealloc",
+		weight: 2,
+		hint:   "the slice grows by reallocation; preallocate with make([]T, 0, n) before the loop",
+	}
+	allocStringConcat = allocationPattern{
+		name:   "string-concat-in-loop",
+		weight: 3,
+		hint:   "each += copies the whole string; use strings.Builder or collect parts and strings.Join",
+	}
+	allocSprintfKey = allocationPattern{
+		name:   "sprintf-key",
+		weight: 2,
+		hint:   "fmt.Sprintf allocates and parses the format every call; use a struct key, strconv or strings.Builder",
+	}
+	allocBytesConversion = allocationPattern{
+		name:   "bytes-string-conversion",
+		weight: 1,
+		hint:   "the conversion copies the data each iteration; convert once outside the loop or keep one representation",
+	}
+)
+
+// AnalyzeAllocations reports allocation hotspots that are visible without escape analysis:
+// returned pointers to locals, appends to slices that were not preallocated, string concatenation,
+// fmt.Sprintf keys and []byte/string conversions inside loops. Findings are ranked by a score that
+// grows quadratically with loop depth.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional package filter
+//
+// Returns:
+//   - MCP tool call result
+//   - ranked findings with pattern, loop depth and remediation hint
+//   - error if an error occurred while loading packages
+func AnalyzeAllocations(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeAllocationsInput) (
+	*mcp.CallToolResult,
+	AnalyzeAllocationsOutput,
+	error,
+) {
+	start := logStart("AnalyzeAllocations", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := AnalyzeAllocationsOutput{ByPattern: make(map[string]int)}
+
+	defer func() { logEnd("AnalyzeAllocations", start, out.Total) }()
+
+	mode := loadModeSyntaxTypesNamed
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeAllocations")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			a := &allocationScanner{info: pkg.TypesInfo, prealloc: preallocatedSlices(pkg.TypesInfo, fd.Body)}
+			a.scan(fd.Body)
+
+			for _, f := range a.findings {
+				out.Findings = append(out.Findings, AllocationFinding{
+					Pattern:   f.pattern.name,
+					File:      relPath,
+					Line:      pkg.Fset.Position(f.pos).Line,
+					Function:  qualifiedFuncName(fd),
+					LoopDepth: f.depth,
+					Score:     f.pattern.weight * (f.depth + 1) * (f.depth + 1),
+					Hint:      f.pattern.hint,
+				})
+				out.ByPattern[f.pattern.name]++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.Slice(out.Findings, func(i, j int) bool {
+		a, b := out.Findings[i], out.Findings[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	out.Total = len(out.Findings)
+
+	return nil, out, nil
+}
+
+// allocationFinding is a pattern match at a position and loop depth.
+type allocationFinding struct {
+	pattern allocationPattern
+	pos     token.Pos
+	depth   int
+}
+
+// allocationScanner walks one function body tracking the loop depth of every node.
+type allocationScanner struct {
+	info     *types.Info
+	prealloc map[types.Object]bool
+	findings []allocationFinding
+}
+
+// scan walks n; nodes are inside a loop when they belong to a loop body, or to the condition and
+// post statement of a for loop, all of which run once per iteration.
+func (a *allocationScanner) scan(n ast.Node) {
+	var (
+		stack  []ast.Node
+		depths []int
+	)
+
+	ast.Inspect(n, func(node ast.Node) bool {
+		if node == nil {
+			stack, depths = stack[:len(stack)-1], depths[:len(depths)-1]
+
+			return true
+		}
+
+		depth := 0
+		if len(stack) > 0 {
+			depth = depths[len(depths)-1]
+			if iterates(stack[len(stack)-1], node) {
+				depth++
+			}
+		}
+
+		a.check(node, stack, depth)
+
+		stack, depths = append(stack, node), append(depths, depth)
+
+		return true
+	})
+}
+
+// iterates reports whether child is evaluated once per iteration of the loop parent.
+func iterates(parent, child ast.Node) bool {
+	switch p := parent.(type) {
+	case *ast.ForStmt:
+		return child == p.Body || child == p.Cond || child == p.Post
+	case *ast.RangeStmt:
+		return child == p.Body
+	}
+
+	return false
+}
+
+// check matches a node against the allocation patterns.
+func (a *allocationScanner) check(node ast.Node, stack []ast.Node, depth int) {
+	var parent ast.Node
+	if len(stack) > 0 {
+		parent = stack[len(stack)-1]
+	}
+
+	switch n := node.(type) {
+	case *ast.ReturnStmt:
+		for _, result := range n.Results {
+			if a.isAddressOfLocal(result) {
+				a.add(allocReturnLocalPointer, result.Pos(), depth)
+			}
+		}
+	case *ast.AssignStmt:
+		if depth > 0 && a.isStringConcat(n) {
+			a.add(allocStringConcat, n.Pos(), depth)
+		}
+	case *ast.CallExpr:
+		switch {
+		case depth > 0 && isBuiltinCall(a.info, n, "append") && len(n.Args) > 0:
+			if obj := identObject(a.info, n.Args[0]); obj != nil && isLocalVar(obj) && !a.prealloc[obj] {
+				a.add(allocAppendNoPrealloc, n.Pos(), depth)
+			}
+		case isPkgFuncCall(a.info, n, "fmt", "Sprintf"):
+			if index, ok := parent.(*ast.IndexExpr); (ok && index.Index == n) || depth > 0 {
+				a.add(allocSprintfKey, n.Pos(), depth)
+			}
+		case depth > 0 && a.isBytesStringConversion(n) && !isOptimizedConversionUse(parent, n):
+			a.add(allocBytesConversion, n.Pos(), depth)
+		}
+	}
+}
+
+func (a *allocationScanner) add(pattern allocationPattern, pos token.Pos, depth int) {
+	a.findings = append(a.findings, allocationFinding{pattern: pattern, pos: pos, depth: depth})
+}
+
+// isAddressOfLocal matches &T{...} and &v for a variable declared inside the function.
+func (a *allocationScanner) isAddressOfLocal(expr ast.Expr) bool {
+	unary, ok := ast.Unparen(expr).(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return false
+	}
+
+	if _, ok := ast.Unparen(unary.X).(*ast.CompositeLit); ok {
+		return true
+	}
+
+	obj := identObject(a.info, unary.X)
+
+	return obj != nil && isLocalVar(obj)
+}
+
+// isStringConcat matches s += x and s = s + x on strings.
+func (a *allocationScanner) isStringConcat(assign *ast.AssignStmt) bool {
+	if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || !isStringType(a.info.TypeOf(assign.Lhs[0])) {
+		return false
+	}
+
+	switch assign.Tok {
+	case token.ADD_ASSIGN:
+		return true
+	case token.ASSIGN:
+		bin, ok := ast.Unparen(assign.Rhs[0]).(*ast.BinaryExpr)
+		if !ok || bin.Op != token.ADD {
+			return false
+		}
+
+		lhs := identObject(a.info, assign.Lhs[0])
+
+		return lhs != nil && identObject(a.info, bin.X) == lhs
+	}
+
+	return false
+}
+
+// isBytesStringConversion matches []byte(s) and string(b).
+func (a *allocationScanner) isBytesStringConversion(call *ast.CallExpr) bool {
+	if len(call.Args) != 1 {
+		return false
+	}
+
+	tv, ok := a.info.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return false
+	}
+
+	to, from := tv.Type, a.info.TypeOf(call.Args[0])
+
+	return (isByteSlice(to) && isStringType(from)) || (isStringType(to) && isByteSlice(from))
+}
+
+// isOptimizedConversionUse reports conversions the compiler performs without allocating:
+// map index keys, comparison operands and range expressions.
+func isOptimizedConversionUse(parent ast.Node, conv *ast.CallExpr) bool {
+	switch p := parent.(type) {
+	case *ast.IndexExpr:
+		return p.Index == conv
+	case *ast.BinaryExpr:
+		switch p.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return true
+		}
+	case *ast.RangeStmt:
+		return p.X == conv
+	}
+
+	return false
+}
+
+// preallocatedSlices returns local slices assigned from make with a length or capacity other than
+// a literal 0 anywhere in the body.
+func preallocatedSlices(info *types.Info, body *ast.BlockStmt) map[types.Object]bool {
+	result := make(map[types.Object]bool)
+
+	record := func(lhs ast.Expr, rhs ast.Expr) {
+		call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+		if !ok || !isBuiltinCall(info, call, "make") || len(call.Args) < 2 {
+			return
+		}
+
+		size := call.Args[len(call.Args)-1]
+		if lit, ok := size.(*ast.BasicLit); ok && lit.Value == "0" {
+			return
+		}
+
+		if obj := identObject(info, lhs); obj != nil {
+			result[obj] = true
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.AssignStmt:
+			if len(s.Lhs) == len(s.Rhs) {
+				for i := range s.Lhs {
+					record(s.Lhs[i], s.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(s.Names) == len(s.Values) {
+				for i := range s.Names {
+					record(s.Names[i], s.Values[i])
+				}
+			}
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// identObject returns the object an identifier expression defines or uses, or nil for other expressions.
+func identObject(info *types.Info, expr ast.Expr) types.Object {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	return info.ObjectOf(ident)
+}
+
+// isLocalVar reports whether obj is a variable declared inside a function (not a package-level
+// variable or a struct field).
+func isLocalVar(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	if !ok || v.IsField() || v.Pkg() == nil {
+		return false
+	}
+
+	return v.Parent() != nil && v.Parent() != v.Pkg().Scope()
+}
+
+// isPkgFuncCall reports whether call statically calls pkgPath.name.
+func isPkgFuncCall(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := calledFunc(info, call)
+
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == pkgPath && fn.Name() == name
+}
+
+func isStringType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsString != 0
+}
+
+func isByteSlice(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	elem, ok := types.Unalias(slice.Elem()).(*types.Basic)
+
+	return ok && elem.Kind() == types.Byte
+}
@@ -0,0 +1,68 @@
+package tools_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestAnalyzeAllocations(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeAllocationsInput{Dir: testDir(), Package: "sample"}
+
+	_, out, err := tools.AnalyzeAllocations(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeAllocations error: %v", err)
+	}
+
+	var got []string
+
+	for _, f := range out.Findings {
+		if f.File != "alloc.go" {
+			continue
+		}
+
+		got = append(got, f.Function+":"+f.Pattern)
+
+		if f.Hint == "" {
+			t.Errorf("expected a remediation hint for %+v", f)
+		}
+	}
+
+	// Ranked by pattern weight × (loop depth + 1)²; conversions used as map keys or in comparisons are not reported.
+	want := []string{
+		"joinGrid:string-concat-in-loop",
+		"joinGrid:append-without-prealloc",
+		"indexByKey:sprintf-key",
+		"indexByKey:bytes-string-conversion",
+		"indexByKey:bytes-string-conversion",
+		"newAllocNode:return-local-pointer",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected findings %v, got %v", want, got)
+	}
+
+	for _, f := range out.Findings {
+		if f.Pattern == "string-concat-in-loop" && f.File == "alloc.go" && (f.LoopDepth != 2 || f.Score != 27) {
+			t.Errorf("expected concat at loop depth 2 with score 27, got %+v", f)
+		}
+	}
+
+	if out.Total != len(out.Findings) || out.ByPattern["bytes-string-conversion"] < 2 {
+		t.Errorf("unexpected totals: %d findings, byPattern %v", out.Total, out.ByPattern)
+	}
+}
+
+func TestAnalyzeAllocations_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeAllocationsInput{Dir: "/nonexistent/directory"}
+	if _, _, err := tools.AnalyzeAllocations(context.Background(), &mcp.CallToolRequest{}, in); err == nil {
+		t.Fatalf("expected error for non-existent directory")
+	}
+}
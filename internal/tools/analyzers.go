@@ -3,9 +3,14 @@ package tools
 import (
 	"context"
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"go/types"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -39,15 +44,38 @@ func DeadCode(ctx context.Context, req *mcp.CallToolRequest, input DeadCodeInput
 
 	defer func() { logEnd("DeadCode", start, len(out.Unused)) }()
 
+	ctx = withTestdata(ctx, input.IncludeTestdata)
+	out.SkippedTestdataPackages = skippedTestdataPackages(ctx, input.Dir)
+
 	mode := loadModeSyntaxTypesNamed
 
-	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "DeadCode")
+	owners, err := newOwnership(input.Dir, input.OwnersFile, input.GroupByOwner)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	pkgs, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "DeadCode")
 	if err != nil {
 		return fail(out, err)
 	}
 
 	exportedCount := 0
 	byKind := make(map[string]int)
+	targets := make(map[DeadSymbol]string)
+
+	// Exported symbols of internal packages are only reachable from the module itself,
+	// so they are dead when nothing in the loaded scope uses them.
+	checkInternal := input.CheckInternalExported == nil || *input.CheckInternalExported
+
+	var moduleUses map[string]struct{}
+	if checkInternal {
+		moduleUses = usedObjectKeys(pkgs)
+	}
+
+	var foreignUses map[string]struct{}
+	if input.IncludeWriteOnly {
+		foreignUses = foreignObjectKeys(pkgs)
+	}
 
 	for _, pkg := range filteredPkgs {
 		pkgKey := normalizePackagePath(pkg)
@@ -55,6 +83,10 @@ func DeadCode(ctx context.Context, req *mcp.CallToolRequest, input DeadCodeInput
 			pkgKey = pkg.Name
 		}
 
+		internalPkg := checkInternal && isInternalPackagePath(pkg.PkgPath)
+
+		suppressions := packageSuppressions(pkg)
+
 		used := make(map[types.Object]struct{}, len(pkg.TypesInfo.Uses))
 		for _, obj := range pkg.TypesInfo.Uses {
 			if obj != nil {
@@ -63,7 +95,12 @@ func DeadCode(ctx context.Context, req *mcp.CallToolRequest, input DeadCodeInput
 		}
 
 		for ident, obj := range pkg.TypesInfo.Defs {
-			if obj == nil || !isDeadCandidate(ident, obj) {
+			if obj == nil {
+				continue
+			}
+
+			internalExported := internalPkg && isInternalExportedCandidate(ident, obj)
+			if !internalExported && !isDeadCandidate(ident, obj) {
 				continue
 			}
 
@@ -71,27 +108,43 @@ func DeadCode(ctx context.Context, req *mcp.CallToolRequest, input DeadCodeInput
 				continue
 			}
 
+			if internalExported {
+				if _, ok := moduleUses[objectKey(obj)]; ok {
+					continue
+				}
+			}
+
 			// Check if the symbol is exported
 			isExported := ast.IsExported(ident.Name)
 
 			// Skip exported symbols if not requested
-			if isExported && !input.IncludeExported {
+			if isExported && !input.IncludeExported && !internalExported {
 				continue
 			}
 
 			pos := pkg.Fset.Position(ident.Pos())
 			rel := relativePath(input.Dir, pos.Filename)
 
+			if d, ok := suppressedBy(suppressions[ident], "getDeadCodeReport"); ok {
+				out.Suppressed = append(out.Suppressed, newSuppressedFinding(rel, pos.Line, ident.Name, d))
+
+				continue
+			}
+
 			symbol := DeadSymbol{
-				Name:       ident.Name,
-				Kind:       objStringKind(obj),
-				File:       rel,
-				Line:       pos.Line,
-				IsExported: isExported,
-				Package:    pkgKey,
+				Name:             ident.Name,
+				SymbolID:         symbolID(pkg.TypesInfo, obj),
+				Kind:             objStringKind(obj),
+				File:             rel,
+				Line:             pos.Line,
+				IsExported:       isExported,
+				InternalExported: internalExported,
+				Package:          pkgKey,
+				Owner:            owners.owner(rel),
 			}
 
 			out.Unused = append(out.Unused, symbol)
+			targets[symbol] = deadSymbolTarget(obj)
 
 			if isExported {
 				exportedCount++
@@ -100,22 +153,76 @@ func DeadCode(ctx context.Context, req *mcp.CallToolRequest, input DeadCodeInput
 			// Update aggregated counters
 			out.ByPackage[pkgKey]++
 			byKind[symbol.Kind]++
+			owners.count(symbol.Owner, 1)
+		}
+
+		if !input.IncludeWriteOnly {
+			continue
+		}
+
+		for _, wo := range writeOnlyVars(pkg, foreignUses) {
+			isExported := wo.obj.Exported()
+			internalExported := internalPkg && isExported && wo.obj.Parent() == wo.obj.Pkg().Scope()
+
+			if isExported && !input.IncludeExported && !internalExported {
+				continue
+			}
+
+			pos := pkg.Fset.Position(wo.ident.Pos())
+			rel := relativePath(input.Dir, pos.Filename)
+
+			if d, ok := suppressedBy(suppressions[wo.ident], "getDeadCodeReport"); ok {
+				out.Suppressed = append(out.Suppressed, newSuppressedFinding(rel, pos.Line, wo.ident.Name, d))
+
+				continue
+			}
+
+			symbol := DeadSymbol{
+				Name:             wo.ident.Name,
+				SymbolID:         symbolID(pkg.TypesInfo, wo.obj),
+				Kind:             deadKindWriteOnlyVar,
+				File:             rel,
+				Line:             pos.Line,
+				IsExported:       isExported,
+				InternalExported: internalExported,
+				Package:          pkgKey,
+				Owner:            owners.owner(rel),
+			}
+
+			// Deleting the variable would break its assignments, so no previewDelete hint is offered.
+			out.Unused = append(out.Unused, symbol)
+
+			if isExported {
+				exportedCount++
+			}
+
+			out.ByPackage[pkgKey]++
+			byKind[symbol.Kind]++
+			owners.count(symbol.Owner, 1)
 		}
 	}
 
+	sortSuppressedFindings(out.Suppressed)
+
 	out.TotalCount = len(out.Unused)
 	out.ExportedCount = exportedCount
 	out.ByKind = byKind
+	out.ByOwner = owners.counts()
 
 	if input.Limit > 0 && len(out.Unused) > input.Limit {
 		out.HasMore = true
 		out.Unused = out.Unused[:input.Limit]
 	}
 
+	if input.WithHints {
+		out.NextSteps = deadCodeHints(input.Dir, out.Unused, targets)
+	}
+
 	return nil, out, nil
 }
 
-// AnalyzeDependencies builds a graph of dependencies between internal packages (imports, cycles, fan-in/fan-out).
+// AnalyzeDependencies builds a graph of dependencies between internal packages (imports, cycles, fan-in/fan-out,
+// instability, layer depth and stable-dependencies violations).
 //
 // Parameters:
 //   - ctx: execution context
@@ -138,11 +245,19 @@ func AnalyzeDependencies(ctx context.Context, req *mcp.CallToolRequest, input An
 	out := AnalyzeDependenciesOutput{
 		Dependencies: []PackageDependency{},
 		Cycles:       [][]string{},
+		Violations:   []DependencyViolation{},
 	}
 
 	defer func() { logEnd("AnalyzeDependencies", start, len(out.Dependencies)) }()
 
-	mode := loadModeBasic | packages.NeedImports
+	if err := validateDependencySort(input.SortBy); err != nil {
+		return fail(out, err)
+	}
+
+	ctx = withTestdata(ctx, input.IncludeTestdata)
+	out.SkippedTestdataPackages = skippedTestdataPackages(ctx, input.Dir)
+
+	mode := loadModeImports
 
 	pkgs, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeDependencies")
 	if err != nil {
@@ -167,6 +282,23 @@ func AnalyzeDependencies(ctx context.Context, req *mcp.CallToolRequest, input An
 		}
 	}
 
+	// go/packages drops the import closing a cycle and reports the cycle as an error instead.
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			stack := importCycleStack(e.Msg)
+			for i := 1; i < len(stack); i++ {
+				if !slices.Contains(depGraph[stack[i-1]], stack[i]) {
+					depGraph[stack[i-1]] = append(depGraph[stack[i-1]], stack[i])
+				}
+			}
+		}
+	}
+
+	// Sorted edges make the reported cycles independent of map order.
+	for key := range depGraph {
+		slices.Sort(depGraph[key])
+	}
+
 	filteredKeys := make(map[string]struct{}, len(filteredPkgs))
 	for _, pkg := range filteredPkgs {
 		key := normalizePackagePath(pkg)
@@ -175,6 +307,14 @@ func AnalyzeDependencies(ctx context.Context, req *mcp.CallToolRequest, input An
 		}
 	}
 
+	internal := make(map[string]bool, len(pkgMap))
+	for key := range pkgMap {
+		internal[key] = true
+	}
+
+	metrics := computeDependencyMetrics(depGraph, internal)
+	out.Violations = stabilityViolations(depGraph, internal, filteredKeys, metrics)
+
 	for _, pkg := range filteredPkgs {
 		key := normalizePackagePath(pkg)
 
@@ -187,13 +327,19 @@ func AnalyzeDependencies(ctx context.Context, req *mcp.CallToolRequest, input An
 		fanInCount := fanIn[key]
 
 		out.Dependencies = append(out.Dependencies, PackageDependency{
-			Package: key,
-			Imports: imports,
-			FanIn:   fanInCount,
-			FanOut:  fanOut,
+			Package:     key,
+			Imports:     imports,
+			FanIn:       fanInCount,
+			FanOut:      fanOut,
+			Instability: metrics.instability[key],
+			LayerDepth:  metrics.depth[key],
 		})
 	}
 
+	if input.SortBy != "" {
+		sortDependencies(out.Dependencies, input.SortBy)
+	}
+
 	// Detect cycles using DFS
 	visited := make(map[string]bool)
 	recStack := make(map[string]bool)
@@ -256,25 +402,46 @@ func AnalyzeDependencies(ctx context.Context, req *mcp.CallToolRequest, input An
 		return false
 	}
 
-	for pkgPath := range pkgMap {
+	for _, pkgPath := range sortedKeys(pkgMap) {
 		if !visited[pkgPath] {
 			dfs(pkgPath)
 		}
 	}
 
+	if input.WithHints {
+		out.NextSteps = cycleHints(input.Dir, out.Cycles)
+	}
+
 	return nil, out, nil
 }
 
-// AnalyzeComplexity analyzes function metrics: lines of code, nesting depth, and cyclomatic complexity.
+// importCycleStack returns the import stack of a go list "import cycle not allowed" error, which starts
+// and ends with the same package, or nil for other errors.
+func importCycleStack(msg string) []string {
+	_, stack, ok := strings.Cut(msg, "import cycle not allowed: import stack: [")
+	if !ok {
+		return nil
+	}
+
+	stack, _, ok = strings.Cut(stack, "]")
+	if !ok {
+		return nil
+	}
+
+	return strings.Fields(stack)
+}
+
+// AnalyzeComplexity analyzes function metrics: lines of code, nesting depth, cyclomatic and cognitive complexity.
+// When input.Top is set, it returns a flat ranked list of the worst functions with module aggregates.
 //
 // Parameters:
 //   - ctx: execution context
 //   - req: MCP tool request
-//   - input: input data specifying the directory for analysis
+//   - input: input data specifying the directory for analysis and optional ranking
 //
 // Returns:
 //   - MCP tool call result
-//   - function complexity analysis result
+//   - function complexity analysis result (grouped by file or ranked)
 //   - error if an error occurred while loading packages
 func AnalyzeComplexity(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeComplexityInput) (
 	*mcp.CallToolResult,
@@ -287,32 +454,119 @@ func AnalyzeComplexity(ctx context.Context, _ *mcp.CallToolRequest, input Analyz
 	))
 	out := AnalyzeComplexityOutput{}
 
-	defer func() { logEnd("AnalyzeComplexity", start, len(out.Functions)) }()
+	defer func() { logEnd("AnalyzeComplexity", start, len(out.Functions)+len(out.Ranked)) }()
 
-	mode := loadModeSyntaxTypesNamed
+	ctx = withTestdata(ctx, input.IncludeTestdata)
+	out.SkippedTestdataPackages = skippedTestdataPackages(ctx, input.Dir)
+
+	metric, err := complexityMetric(input.SortBy)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if input.Order != "" && input.Order != "asc" && input.Order != "desc" {
+		return fail(out, invalidInput("invalid order %q: expected asc or desc", input.Order))
+	}
 
-	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeComplexity")
+	owners, err := newOwnership(input.Dir, input.OwnersFile, input.GroupByOwner)
 	if err != nil {
 		return fail(out, err)
 	}
 
+	mode := loadModeSyntaxTypesNamed
+
 	functions := make([]FunctionComplexity, 0)
 
+	// The persisted index keeps no type information, which recursion detection needs.
+	if index := persistedIndexFor(ctx, input.Dir, mode, "AnalyzeComplexity"); index != nil && !input.DetectRecursion {
+		indexed, err := filterIndexedPackages(index, input.Package)
+		if err != nil {
+			return fail(out, err)
+		}
+
+		var suppressed []SuppressedFinding
+
+		for _, pkg := range indexed {
+			for _, file := range pkg.files {
+				for _, fn := range file.facts.Functions {
+					fn.File = file.relPath
+					if fn.SymbolID != "" {
+						fn.SymbolID = pkg.path + strings.TrimPrefix(fn.SymbolID, file.facts.Package)
+					}
+					functions = append(functions, fn)
+				}
+
+				if input.Top > 0 && len(file.facts.Functions) > 0 {
+					suppressed = append(suppressed, indexedFuncSuppressions(input.Dir, file.relPath)...)
+				}
+			}
+		}
+
+		return nil, reportComplexity(out, functions, suppressed, metric, owners, input), nil
+	}
+
+	var filteredPkgs []*packages.Package
+
+	if input.DetectRecursion {
+		// A syntax-only fallback could not resolve calls, so recursion detection fails instead.
+		_, filteredPkgs, err = loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeComplexity")
+		if err != nil {
+			return fail(out, err)
+		}
+	} else {
+		var degraded *loadDegradation
+
+		_, filteredPkgs, degraded, err = loadFilteredPackagesWithFallback(ctx, input.Dir, mode, input.Package, "AnalyzeComplexity")
+		if err != nil {
+			return fail(out, err)
+		}
+
+		degraded.apply(&out.Degraded, &out.LoadError)
+	}
+
+	var suppressed []SuppressedFinding
+
+	recursion := make(map[*packages.Package]map[*types.Func]recursionInfo)
+	recursiveByPackage := make(map[string]int)
+
 	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if input.Top > 0 {
+			suppressed = append(suppressed, funcSuppressions(pkg.Fset, file, relPath, "getComplexityReport")...)
+		}
+
+		if input.DetectRecursion && pkg.TypesInfo == nil {
+			return errTypesNotLoaded
+		}
+
+		pkgRecursion, ok := recursion[pkg]
+		if input.DetectRecursion && !ok {
+			pkgRecursion = packageRecursion(pkg)
+			recursion[pkg] = pkgRecursion
+		}
+
 		ast.Inspect(file, func(n ast.Node) bool {
 			fd, ok := n.(*ast.FuncDecl)
 			if !ok || fd.Body == nil {
 				return true
 			}
 
-			pos := pkg.Fset.Position(fd.Pos())
-			lines, nesting, cyclomatic := computeFunctionMetrics(ctx, pkg.Fset, fd)
-			functions = append(functions, FunctionComplexity{
-				Name: fd.Name.Name, File: relPath, Line: pos.Line,
-				Lines: lines, Nesting: nesting, Cyclomatic: cyclomatic,
-			})
+			// The first entry is the declaration itself, the rest are its closures.
+			entries := declComplexity(ctx, pkg.Fset, normalizePackagePath(pkg), fd)
+			for i := range entries {
+				entries[i].File = relPath
+			}
 
-			return true
+			if input.DetectRecursion {
+				fn, _ := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+				if info, ok := pkgRecursion[fn]; ok {
+					entries[0].Recursive, entries[0].RecursionCycle = true, info.cycle
+					recursiveByPackage[normalizePackagePath(pkg)]++
+				}
+			}
+
+			functions = append(functions, entries...)
+
+			return false
 		})
 
 		return nil
@@ -320,17 +574,170 @@ func AnalyzeComplexity(ctx context.Context, _ *mcp.CallToolRequest, input Analyz
 		return fail(out, err)
 	}
 
-	out.Functions = groupFunctionComplexityByFile(functions)
+	out = reportComplexity(out, functions, suppressed, metric, owners, input)
+	if out.Summary != nil && len(recursiveByPackage) > 0 {
+		out.Summary.RecursiveByPackage = recursiveByPackage
+	}
 
 	return nil, out, nil
 }
 
+// reportComplexity fills the output with functions grouped by file, or ranked when input.Top is set.
+// Suppressed functions still count towards the summary but are left out of the ranking. Owners are
+// counted over all analyzed functions in both shapes.
+func reportComplexity(
+	out AnalyzeComplexityOutput,
+	functions []FunctionComplexity,
+	suppressed []SuppressedFinding,
+	metric func(FunctionComplexity) int,
+	owners *ownership,
+	input AnalyzeComplexityInput,
+) AnalyzeComplexityOutput {
+	if input.Top <= 0 {
+		out.Functions = groupFunctionComplexityByFile(functions)
+
+		for i := range out.Functions {
+			out.Functions[i].Owner = owners.owner(out.Functions[i].File)
+			owners.count(out.Functions[i].Owner, len(out.Functions[i].Functions))
+		}
+
+		out.ByOwner = owners.counts()
+
+		return out
+	}
+
+	out.Summary = summarizeComplexity(functions)
+
+	for _, fn := range functions {
+		owners.count(owners.owner(fn.File), 1)
+	}
+
+	out.ByOwner = owners.counts()
+
+	if len(suppressed) > 0 {
+		skip := make(map[string]struct{}, len(suppressed))
+		for _, s := range suppressed {
+			skip[s.File+":"+strconv.Itoa(s.Line)] = struct{}{}
+		}
+
+		functions = slices.DeleteFunc(slices.Clone(functions), func(f FunctionComplexity) bool {
+			_, ok := skip[f.File+":"+strconv.Itoa(f.Line)]
+
+			return ok
+		})
+
+		sortSuppressedFindings(suppressed)
+		out.Suppressed = suppressed
+	}
+
+	out.Ranked = rankFunctionComplexity(functions, metric, input.Order == "asc", input.Top)
+	for i := range out.Ranked {
+		out.Ranked[i].Owner = owners.owner(out.Ranked[i].File)
+	}
+
+	if input.WithHints {
+		out.NextSteps = complexityHints(input.Dir, out.Ranked, input)
+	}
+
+	return out
+}
+
+// indexedFuncSuppressions reads the suppressions of a file answered from the persisted index, which keeps
+// no comments. Only files containing a directive are parsed.
+func indexedFuncSuppressions(dir, relPath string) []SuppressedFinding {
+	path := filepath.Join(dir, filepath.FromSlash(relPath))
+
+	src, err := os.ReadFile(path)
+	if err != nil || !hasSuppressDirective(src) {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+	if file == nil {
+		logError("AnalyzeComplexity", err, "failed to parse "+relPath+" for suppressions")
+
+		return nil
+	}
+
+	return funcSuppressions(fset, file, relPath, "getComplexityReport")
+}
+
+// complexityMetric returns the metric accessor for a sortBy value; cyclomatic is the default.
+func complexityMetric(sortBy string) (func(FunctionComplexity) int, error) {
+	switch sortBy {
+	case "", "cyclomatic":
+		return func(f FunctionComplexity) int { return f.Cyclomatic }, nil
+	case "cognitive":
+		return func(f FunctionComplexity) int { return f.Cognitive }, nil
+	case "lines":
+		return func(f FunctionComplexity) int { return f.Lines }, nil
+	case "nesting":
+		return func(f FunctionComplexity) int { return f.Nesting }, nil
+	default:
+		return nil, invalidInput("invalid sortBy %q: expected cyclomatic, cognitive, lines or nesting", sortBy)
+	}
+}
+
+// rankFunctionComplexity sorts functions by metric and returns at most top entries.
+// Ties are broken by file and then by line regardless of the order.
+func rankFunctionComplexity(functions []FunctionComplexity, metric func(FunctionComplexity) int, asc bool, top int) []FunctionComplexity {
+	sort.Slice(functions, func(i, j int) bool {
+		a, b := metric(functions[i]), metric(functions[j])
+		if a != b {
+			if asc {
+				return a < b
+			}
+
+			return a > b
+		}
+
+		if functions[i].File != functions[j].File {
+			return functions[i].File < functions[j].File
+		}
+
+		return functions[i].Line < functions[j].Line
+	})
+
+	if len(functions) > top {
+		functions = functions[:top]
+	}
+
+	return functions
+}
+
+// summarizeComplexity computes module-wide aggregates over all analyzed functions.
+func summarizeComplexity(functions []FunctionComplexity) *ComplexitySummary {
+	summary := &ComplexitySummary{FunctionCount: len(functions)}
+	if len(functions) == 0 {
+		return summary
+	}
+
+	totalCyclomatic, totalCognitive := 0, 0
+
+	for _, f := range functions {
+		totalCyclomatic += f.Cyclomatic
+		totalCognitive += f.Cognitive
+		summary.TotalLines += f.Lines
+		summary.MaxCyclomatic = max(summary.MaxCyclomatic, f.Cyclomatic)
+		summary.MaxCognitive = max(summary.MaxCognitive, f.Cognitive)
+	}
+
+	summary.AverageCyclomatic = float64(totalCyclomatic) / float64(len(functions))
+	summary.AverageCognitive = float64(totalCognitive) / float64(len(functions))
+
+	return summary
+}
+
 type ComplexityVisitor struct {
 	Ctx        context.Context
 	Fset       *token.FileSet
 	Nesting    int
 	MaxNesting int
 	Cyclomatic int
+	// SkipFuncLits leaves the bodies of function literals out, as they are reported as closures of their own.
+	SkipFuncLits bool
 }
 
 func (v *ComplexityVisitor) Visit(n ast.Node) ast.Visitor {
@@ -339,6 +746,10 @@ func (v *ComplexityVisitor) Visit(n ast.Node) ast.Visitor {
 	}
 
 	switch n.(type) {
+	case *ast.FuncLit:
+		if v.SkipFuncLits {
+			return nil
+		}
 	case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt,
 		*ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
 		v.Nesting++
@@ -371,6 +782,128 @@ func (s *scopedVisitor) Visit(n ast.Node) ast.Visitor {
 	return s.parent.Visit(n)
 }
 
+// cognitiveCounter computes cognitive complexity: control-flow breaks cost 1 plus the current nesting level,
+// else branches, labeled jumps and each run of mixed logical operators cost 1.
+type cognitiveCounter struct {
+	score int
+	// skipFuncLits leaves the bodies of function literals out instead of scoring them one level deeper.
+	skipFuncLits bool
+}
+
+// walk visits the direct children of n at the given nesting level.
+func (c *cognitiveCounter) walk(n ast.Node, nesting int) {
+	ast.Inspect(n, func(child ast.Node) bool {
+		if child == n {
+			return true
+		}
+
+		if child != nil {
+			c.visit(child, nesting)
+		}
+
+		return false
+	})
+}
+
+func (c *cognitiveCounter) visit(n ast.Node, nesting int) {
+	switch s := n.(type) {
+	case *ast.IfStmt:
+		c.score += 1 + nesting
+		c.ifChain(s, nesting)
+	case *ast.ForStmt:
+		c.score += 1 + nesting
+		c.walkOptional(s.Init, nesting)
+		c.walkOptional(s.Cond, nesting)
+		c.walkOptional(s.Post, nesting)
+		c.walk(s.Body, nesting+1)
+	case *ast.RangeStmt:
+		c.score += 1 + nesting
+		c.visit(s.X, nesting)
+		c.walk(s.Body, nesting+1)
+	case *ast.SwitchStmt:
+		c.score += 1 + nesting
+		c.walkOptional(s.Init, nesting)
+		c.walkOptional(s.Tag, nesting)
+		c.walk(s.Body, nesting+1)
+	case *ast.TypeSwitchStmt:
+		c.score += 1 + nesting
+		c.walkOptional(s.Init, nesting)
+		c.walk(s.Body, nesting+1)
+	case *ast.SelectStmt:
+		c.score += 1 + nesting
+		c.walk(s.Body, nesting+1)
+	case *ast.FuncLit:
+		if !c.skipFuncLits {
+			c.walk(s.Body, nesting+1)
+		}
+	case *ast.BranchStmt:
+		if s.Label != nil {
+			c.score++
+		}
+	case *ast.BinaryExpr:
+		if s.Op != token.LAND && s.Op != token.LOR {
+			c.walk(s, nesting)
+
+			return
+		}
+
+		var (
+			ops    []token.Token
+			leaves []ast.Expr
+		)
+
+		flattenLogical(s, &ops, &leaves)
+
+		for i, op := range ops {
+			if i == 0 || op != ops[i-1] {
+				c.score++
+			}
+		}
+
+		for _, leaf := range leaves {
+			c.visit(leaf, nesting)
+		}
+	default:
+		c.walk(n, nesting)
+	}
+}
+
+// ifChain scores the condition, body and else branches of an if statement whose own increment is already counted.
+func (c *cognitiveCounter) ifChain(s *ast.IfStmt, nesting int) {
+	c.walkOptional(s.Init, nesting)
+	c.visit(s.Cond, nesting)
+	c.walk(s.Body, nesting+1)
+
+	switch e := s.Else.(type) {
+	case *ast.IfStmt:
+		c.score++
+		c.ifChain(e, nesting)
+	case *ast.BlockStmt:
+		c.score++
+		c.walk(e, nesting+1)
+	}
+}
+
+// walkOptional visits n when it is present.
+func (c *cognitiveCounter) walkOptional(n ast.Node, nesting int) {
+	if n != nil {
+		c.visit(n, nesting)
+	}
+}
+
+// flattenLogical collects the operators and operands of a chain of && / || expressions in source order.
+func flattenLogical(expr ast.Expr, ops *[]token.Token, leaves *[]ast.Expr) {
+	if be, ok := ast.Unparen(expr).(*ast.BinaryExpr); ok && (be.Op == token.LAND || be.Op == token.LOR) {
+		flattenLogical(be.X, ops, leaves)
+		*ops = append(*ops, be.Op)
+		flattenLogical(be.Y, ops, leaves)
+
+		return
+	}
+
+	*leaves = append(*leaves, expr)
+}
+
 // MetricsSummary aggregates general project information: package/struct/interface counts,
 // average cyclomatic complexity, unused code ratios.
 //
@@ -396,6 +929,9 @@ func MetricsSummary(ctx context.Context, req *mcp.CallToolRequest, input Metrics
 
 	defer func() { logEnd("MetricsSummary", start, 0) }()
 
+	ctx = withTestdata(ctx, input.IncludeTestdata)
+	out.SkippedTestdataPackages = skippedTestdataPackages(ctx, input.Dir)
+
 	mode := loadModeSyntaxTypesNamed
 
 	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "MetricsSummary")
@@ -433,6 +969,10 @@ func MetricsSummary(ctx context.Context, req *mcp.CallToolRequest, input Metrics
 				lines := strings.Split(string(content), "\n")
 				lineCount += len(lines)
 				fileCount++
+
+				if input.ExcludeHeaderComments {
+					lineCount -= headerLines(pkg.Fset, file)
+				}
 			}
 		}
 
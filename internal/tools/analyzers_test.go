@@ -153,13 +153,13 @@ func TestDeadCodeExtended(t *testing.T) {
 	exportedCount2 := 0
 
 	for _, unused := range out2.Unused {
-		if unused.IsExported {
+		if unused.IsExported && !unused.InternalExported {
 			exportedCount2++
 		}
 	}
 
 	if exportedCount2 > 0 {
-		t.Errorf("expected no exported symbols when IncludeExported=false, but found %d", exportedCount2)
+		t.Errorf("expected no public exported symbols when IncludeExported=false, but found %d", exportedCount2)
 	}
 
 	if out2.HasMore {
@@ -167,6 +167,101 @@ func TestDeadCodeExtended(t *testing.T) {
 	}
 }
 
+func TestDeadCode_InternalExported(t *testing.T) {
+	t.Parallel()
+
+	in := tools.DeadCodeInput{Dir: testDir()}
+
+	_, out, err := tools.DeadCode(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	found := map[string]tools.DeadSymbol{}
+	for _, d := range out.Unused {
+		found[d.Name] = d
+	}
+
+	reverse, ok := found["Reverse"]
+	if !ok {
+		t.Fatalf("expected unused internal exported func Reverse to be reported")
+	}
+
+	if !reverse.InternalExported || !reverse.IsExported {
+		t.Errorf("expected Reverse to be flagged internalExported, got %+v", reverse)
+	}
+
+	// Title is used from the root package, so it is alive.
+	if _, ok := found["Title"]; ok {
+		t.Errorf("did not expect Title (used outside its package) to be reported")
+	}
+
+	// Public API outside internal/ stays protected.
+	if _, ok := found["Label"]; ok {
+		t.Errorf("did not expect exported Label of a public package to be reported")
+	}
+
+	disabled := false
+	in.CheckInternalExported = &disabled
+
+	_, out, err = tools.DeadCode(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	for _, d := range out.Unused {
+		if d.InternalExported {
+			t.Errorf("expected no internalExported symbols when disabled, got %+v", d)
+		}
+	}
+}
+
+func TestDeadCode_InternalExportedSharesNameWithMember(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"internal/jobs/jobs.go": `package jobs
+
+type Worker struct {
+	Config string
+}
+
+func (w Worker) Run() string { return w.Config }
+
+func Run() {}
+
+var Config = "default"
+`,
+		"app/app.go": `package app
+
+import "lang/internal/jobs"
+
+func Start(w jobs.Worker) string {
+	_ = w.Config
+
+	return w.Run()
+}
+`,
+	})
+
+	_, out, err := tools.DeadCode(context.Background(), &mcp.CallToolRequest{}, tools.DeadCodeInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	found := map[string]bool{}
+	for _, d := range out.Unused {
+		found[d.Name] = d.InternalExported
+	}
+
+	// The uses of the method Run and the field Config do not keep the package-level Run and Config alive.
+	for _, name := range []string{"Run", "Config"} {
+		if !found[name] {
+			t.Errorf("expected unused internal exported %s to be reported, got %+v", name, out.Unused)
+		}
+	}
+}
+
 func TestDeadCode_WithInvalidDir(t *testing.T) {
 	t.Parallel()
 
@@ -413,6 +508,92 @@ func TestAnalyzeComplexity(t *testing.T) {
 	}
 }
 
+func TestAnalyzeComplexity_TopRanked(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeComplexityInput{Dir: testDir(), SortBy: "cognitive", Top: 3}
+
+	_, out, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	if len(out.Functions) != 0 {
+		t.Errorf("expected no per-file groups when top is set, got %d", len(out.Functions))
+	}
+
+	if len(out.Ranked) != 3 {
+		t.Fatalf("expected 3 ranked functions, got %d", len(out.Ranked))
+	}
+
+	for i := 1; i < len(out.Ranked); i++ {
+		if out.Ranked[i-1].Cognitive < out.Ranked[i].Cognitive {
+			t.Errorf("expected descending cognitive order, got %+v", out.Ranked)
+		}
+	}
+
+	if out.Summary == nil || out.Summary.FunctionCount <= len(out.Ranked) {
+		t.Fatalf("expected module summary over all functions, got %+v", out.Summary)
+	}
+
+	if out.Summary.MaxCognitive != out.Ranked[0].Cognitive {
+		t.Errorf("expected max cognitive %d to match top entry, got %d", out.Ranked[0].Cognitive, out.Summary.MaxCognitive)
+	}
+
+	in.Order = "asc"
+	in.Top = 2
+
+	_, out, err = tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	if len(out.Ranked) != 2 || out.Ranked[0].Cognitive != 0 {
+		t.Fatalf("expected simplest functions first, got %+v", out.Ranked)
+	}
+
+	a, b := out.Ranked[0], out.Ranked[1]
+	if a.Cognitive == b.Cognitive && (a.File > b.File || (a.File == b.File && a.Line > b.Line)) {
+		t.Errorf("expected ties to break by file then line, got %+v", out.Ranked)
+	}
+}
+
+func TestAnalyzeComplexity_Cognitive(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeComplexityInput{Dir: testDir()}
+
+	_, out, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	expected := map[string]int{"Simple": 0, "WithIf": 1, "WithLoopAndSwitch": 3}
+
+	for _, group := range out.Functions {
+		if group.File != "complex.go" {
+			continue
+		}
+
+		for _, fn := range group.Functions {
+			if want, ok := expected[fn.Name]; ok && fn.Cognitive != want {
+				t.Errorf("expected %s cognitive=%d, got %d", fn.Name, want, fn.Cognitive)
+			}
+		}
+	}
+}
+
+func TestAnalyzeComplexity_InvalidSortBy(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeComplexityInput{Dir: testDir(), SortBy: "size", Top: 1}
+
+	_, _, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatalf("expected error for unknown sortBy, got nil")
+	}
+}
+
 func TestAnalyzeComplexity_WithPackageFilter(t *testing.T) {
 	dir := projectRoot()
 	pkgPath := toolsPackagePath(t, dir)
@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// architectureRulesFile is read from the module root when checkArchitecture is called without rules.
+const architectureRulesFile = "go-navigator.rules.json"
+
+// architectureRulesSource is reported when the rules come from the tool input.
+const architectureRulesSource = "input"
+
+// CheckArchitecture checks the module-internal import graph against directory-level dependency rules
+// and reports every import spec that breaks one of them.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and the rules (or none, to read go-navigator.rules.json)
+//
+// Returns:
+//   - MCP tool call result
+//   - overall pass/fail, violations with file and line, and counts per rule
+//   - error if the rules are missing or invalid, or packages cannot be loaded
+func CheckArchitecture(ctx context.Context, _ *mcp.CallToolRequest, input CheckArchitectureInput) (
+	*mcp.CallToolResult,
+	CheckArchitectureOutput,
+	error,
+) {
+	start := logStart("CheckArchitecture", logFields(
+		input.Dir,
+		newLogField("rules", strconv.Itoa(len(input.Rules))),
+	))
+	out := CheckArchitectureOutput{}
+
+	defer func() { logEnd("CheckArchitecture", start, len(out.Violations)) }()
+
+	root := findModuleRoot(input.Dir)
+	if root == "" {
+		root = input.Dir
+	}
+
+	rules, source, err := architectureRules(input.Rules, root)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.RulesSource = source
+
+	mode := loadModeBasicSyntax
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
+	if err != nil {
+		logError("CheckArchitecture", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	// Only edges between packages of the module are checked; rule globs match their module-relative dirs.
+	internal := make(map[string]string, len(pkgs))
+	for _, pkg := range pkgs {
+		if len(pkg.CompiledGoFiles) > 0 {
+			internal[pkg.PkgPath] = relativePath(root, filepath.Dir(pkg.CompiledGoFiles[0]))
+		}
+	}
+
+	out.Rules = make([]ArchitectureRuleResult, len(rules))
+	edges := make([]map[string]bool, len(rules))
+
+	for i, rule := range rules {
+		out.Rules[i].From = rule.From
+		edges[i] = make(map[string]bool)
+	}
+
+	for _, pkg := range pkgs {
+		fromDir, ok := internal[pkg.PkgPath]
+		if !ok {
+			continue
+		}
+
+		for i, rule := range rules {
+			if matchPackageGlob(rule.From, pkg.PkgPath, fromDir) {
+				out.Rules[i].Packages++
+			}
+		}
+	}
+
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		fromDir, ok := internal[pkg.PkgPath]
+		if !ok {
+			return nil
+		}
+
+		for _, spec := range file.Imports {
+			imp, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+
+			toDir, ok := internal[imp]
+			if !ok {
+				continue
+			}
+
+			for i, rule := range rules {
+				if !matchPackageGlob(rule.From, pkg.PkgPath, fromDir) {
+					continue
+				}
+
+				edges[i][pkg.PkgPath+" "+imp] = true
+
+				reason := rule.violation(imp, toDir)
+				if reason == "" {
+					continue
+				}
+
+				out.Rules[i].Violations++
+				out.Violations = append(out.Violations, ArchitectureViolation{
+					Rule:    i,
+					From:    pkg.PkgPath,
+					Imports: imp,
+					File:    relPath,
+					Line:    pkg.Fset.Position(spec.Pos()).Line,
+					Reason:  reason,
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for i := range out.Rules {
+		out.Rules[i].Edges = len(edges[i])
+	}
+
+	sort.SliceStable(out.Violations, func(i, j int) bool {
+		a, b := out.Violations[i], out.Violations[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	out.Passed = len(out.Violations) == 0
+
+	return nil, out, nil
+}
+
+// violation returns why importing the package breaks the rule, or "" if the import is allowed.
+// Deny patterns win over allow patterns; an empty allow list allows everything not denied.
+func (r ArchitectureRule) violation(importPath, importDir string) string {
+	for _, pattern := range r.Deny {
+		if matchPackageGlob(pattern, importPath, importDir) {
+			return "denied by " + strconv.Quote(pattern)
+		}
+	}
+
+	if len(r.Allow) == 0 {
+		return ""
+	}
+
+	for _, pattern := range r.Allow {
+		if matchPackageGlob(pattern, importPath, importDir) {
+			return ""
+		}
+	}
+
+	return "not in allow list"
+}
+
+// architectureRules returns the input rules, or the rules from go-navigator.rules.json in the module
+// root when the input has none, together with their source. Patterns are validated up front.
+func architectureRules(rules []ArchitectureRule, root string) ([]ArchitectureRule, string, error) {
+	source := architectureRulesSource
+
+	if len(rules) == 0 {
+		file := filepath.Join(root, architectureRulesFile)
+
+		data, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", invalidInput("no rules given and %s not found in %s", architectureRulesFile, root)
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		var config struct {
+			Rules []ArchitectureRule `json:"rules"`
+		}
+
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, "", invalidInput("parse %s: %w", file, err)
+		}
+
+		if len(config.Rules) == 0 {
+			return nil, "", invalidInput("%s contains no rules", file)
+		}
+
+		rules, source = config.Rules, file
+	}
+
+	for i, rule := range rules {
+		if rule.From == "" {
+			return nil, "", invalidInput("rule %d: from is required", i)
+		}
+
+		patterns := append([]string{rule.From}, rule.Allow...)
+		for _, pattern := range append(patterns, rule.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, "", invalidInput("rule %d: invalid pattern %q: %w", i, pattern, err)
+			}
+		}
+	}
+
+	return rules, source, nil
+}
+
+// matchPackageGlob matches a pattern against a package's import path or its module-relative directory
+// ("." for the module root). Segments are matched with path.Match; a "**" segment matches any number
+// of segments, so "internal/handlers/**" covers the package and everything below it.
+func matchPackageGlob(pattern, importPath, dir string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(dir, "/")) ||
+		matchGlobSegments(strings.Split(pattern, "/"), strings.Split(importPath, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := range len(name) + 1 {
+			if matchGlobSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	ok, err := path.Match(pattern[0], name[0])
+
+	return err == nil && ok && matchGlobSegments(pattern[1:], name[1:])
+}
@@ -0,0 +1,97 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestCheckArchitecture_ReportsImportSpec(t *testing.T) {
+	t.Parallel()
+
+	in := tools.CheckArchitectureInput{
+		Dir: testDir(),
+		Rules: []tools.ArchitectureRule{
+			{From: ".", Deny: []string{"internal/**"}},
+			{From: "audit", Allow: []string{"internal/**"}},
+		},
+	}
+
+	_, out, err := tools.CheckArchitecture(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("CheckArchitecture error: %v", err)
+	}
+
+	if out.Passed || len(out.Violations) != 1 {
+		t.Fatalf("expected one violation, got %+v", out)
+	}
+
+	v := out.Violations[0]
+	if v.Rule != 0 || v.From != "sample" || v.Imports != "sample/internal/textutil" || v.File != "labels.go" || v.Line != 3 {
+		t.Errorf("unexpected violation %+v", v)
+	}
+
+	if out.RulesSource != "input" {
+		t.Errorf("expected rules from input, got %q", out.RulesSource)
+	}
+
+	if len(out.Rules) != 2 || out.Rules[0].Packages != 1 || out.Rules[0].Edges != 1 || out.Rules[0].Violations != 1 {
+		t.Errorf("unexpected counts for the root rule: %+v", out.Rules)
+	}
+
+	if out.Rules[1].Packages != 1 || out.Rules[1].Violations != 0 {
+		t.Errorf("unexpected counts for the audit rule: %+v", out.Rules[1])
+	}
+}
+
+func TestCheckArchitecture_AllowListAndRulesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy testdata: %v", err)
+	}
+
+	rules := `{"rules": [{"from": "sample", "allow": ["audit"]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "go-navigator.rules.json"), []byte(rules), 0o644); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+
+	_, out, err := tools.CheckArchitecture(context.Background(), &mcp.CallToolRequest{}, tools.CheckArchitectureInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("CheckArchitecture error: %v", err)
+	}
+
+	if out.RulesSource != filepath.Join(dir, "go-navigator.rules.json") {
+		t.Errorf("expected rules from the rules file, got %q", out.RulesSource)
+	}
+
+	if out.Passed || len(out.Violations) != 1 || out.Violations[0].Reason != "not in allow list" {
+		t.Errorf("expected the textutil import outside the allow list, got %+v", out.Violations)
+	}
+}
+
+func TestCheckArchitecture_WithoutRules(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.CheckArchitecture(context.Background(), &mcp.CallToolRequest{}, tools.CheckArchitectureInput{Dir: testDir()})
+	if err == nil {
+		t.Fatal("expected an error without rules and without a rules file")
+	}
+}
+
+func TestCheckArchitecture_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	in := tools.CheckArchitectureInput{Dir: testDir(), Rules: []tools.ArchitectureRule{{From: "[", Deny: []string{"x"}}}}
+
+	_, _, err := tools.CheckArchitecture(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatal("expected an error for a malformed pattern")
+	}
+}
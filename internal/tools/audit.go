@@ -0,0 +1,228 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// auditLog is the append-only JSONL record of file mutations; disabled while path is empty.
+var auditLog struct {
+	sync.Mutex
+
+	path string
+}
+
+// fileChange identifies the tool call behind a file mutation for the audit log.
+type fileChange struct {
+	tool  string
+	input any
+}
+
+// ConfigureAuditLog enables the audit log of file mutations at path. An empty path disables it.
+//
+// Parameters:
+//   - path: JSONL file that entries are appended to; created with its directory if it does not exist
+//
+// Returns:
+//   - error if the log file cannot be created
+func ConfigureAuditLog(path string) error {
+	if path != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+			return fmt.Errorf("failed to create audit log dir: %w", err)
+		}
+
+		f, err := os.OpenFile(abs, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		path = abs
+	}
+
+	auditLog.Lock()
+	auditLog.path = path
+	auditLog.Unlock()
+
+	return nil
+}
+
+// appendAuditEntry records the mutation of path from before to after. It syncs the entry to disk
+// before returning, so callers write it ahead of the change they record.
+func appendAuditEntry(change fileChange, path string, before, after []byte) error {
+	auditLog.Lock()
+	defer auditLog.Unlock()
+
+	if auditLog.path == "" {
+		return nil
+	}
+
+	input, err := json.Marshal(change.input)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit input: %w", err)
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	line, err := json.Marshal(AuditEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Tool:      change.tool,
+		Input:     string(input),
+		File:      filepath.ToSlash(path),
+		Before:    sha256Hex(before),
+		After:     sha256Hex(after),
+		Hunks:     countHunks(before, after),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	f, err := os.OpenFile(auditLog.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+
+	return f.Close()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// countHunks returns the number of hunks of the unified diff between before and after.
+func countHunks(before, after []byte) int {
+	a := difflib.SplitLines(string(normalizeLineEndings(before)))
+	b := difflib.SplitLines(string(normalizeLineEndings(after)))
+
+	return len(difflib.NewMatcher(a, b).GetGroupedOpCodes(3))
+}
+
+// GetAuditLog returns recent entries of the audit log of file mutations.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the maximum number of entries and an optional start timestamp
+//
+// Returns:
+//   - MCP tool call result
+//   - the most recent entries in the order they were written
+//   - error if the log cannot be read or the timestamp is malformed
+func GetAuditLog(ctx context.Context, _ *mcp.CallToolRequest, input GetAuditLogInput) (
+	*mcp.CallToolResult,
+	GetAuditLogOutput,
+	error,
+) {
+	start := logStart("GetAuditLog", logFields(
+		"",
+		newLogField("limit", strconv.Itoa(input.Limit)),
+		newLogField("sinceTimestamp", input.SinceTimestamp),
+	))
+	out := GetAuditLogOutput{Entries: []AuditEntry{}}
+
+	defer func() { logEnd("GetAuditLog", start, len(out.Entries)) }()
+
+	if input.Limit < 0 {
+		return nil, out, invalidInput("limit must be non-negative")
+	}
+
+	var since time.Time
+
+	if input.SinceTimestamp != "" {
+		t, err := time.Parse(time.RFC3339Nano, input.SinceTimestamp)
+		if err != nil {
+			return nil, out, invalidInput("invalid sinceTimestamp %q: expected RFC 3339", input.SinceTimestamp)
+		}
+
+		since = t
+	}
+
+	auditLog.Lock()
+	path := auditLog.path
+	auditLog.Unlock()
+
+	if path == "" {
+		return nil, out, nil
+	}
+
+	out.Enabled = true
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fail(out, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		if shouldStop(ctx) {
+			return fail(out, context.Canceled)
+		}
+
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A torn last line is left by a crash during the write; the change it describes was not applied.
+			continue
+		}
+
+		if !since.IsZero() {
+			if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err != nil || t.Before(since) {
+				continue
+			}
+		}
+
+		out.Entries = append(out.Entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fail(out, err)
+	}
+
+	out.Total = len(out.Entries)
+
+	if input.Limit > 0 && len(out.Entries) > input.Limit {
+		out.Entries = out.Entries[len(out.Entries)-input.Limit:]
+	}
+
+	return nil, out, nil
+}
@@ -0,0 +1,109 @@
+package tools_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestAuditLog_RecordsMutations(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	if err := tools.ConfigureAuditLog(logPath); err != nil {
+		t.Fatalf("ConfigureAuditLog error: %v", err)
+	}
+
+	t.Cleanup(func() { _ = tools.ConfigureAuditLog("") })
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copyDir error: %v", err)
+	}
+
+	before, err := os.ReadFile(filepath.Join(dir, "greeting.go"))
+	if err != nil {
+		t.Fatalf("read greeting.go: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Dry runs change nothing and are not recorded.
+	reorder := tools.ReorderDeclarationsInput{Dir: dir, File: "widget.go", DryRun: true}
+	if _, _, err := tools.ReorderDeclarations(ctx, &mcp.CallToolRequest{}, reorder); err != nil {
+		t.Fatalf("ReorderDeclarations (dry run) error: %v", err)
+	}
+
+	rename := tools.RenameSymbolInput{Dir: dir, OldName: "FormatGreeting", NewName: "RenderGreeting"}
+	if _, _, err := tools.RenameSymbol(ctx, &mcp.CallToolRequest{}, rename); err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	after, err := os.ReadFile(filepath.Join(dir, "greeting.go"))
+	if err != nil {
+		t.Fatalf("read greeting.go: %v", err)
+	}
+
+	_, out, err := tools.GetAuditLog(ctx, &mcp.CallToolRequest{}, tools.GetAuditLogInput{})
+	if err != nil {
+		t.Fatalf("GetAuditLog error: %v", err)
+	}
+
+	if !out.Enabled || out.Total != 1 || len(out.Entries) != 1 {
+		t.Fatalf("expected one entry for the single changed file, got %+v", out)
+	}
+
+	entry := out.Entries[0]
+	if entry.Tool != "renameSymbol" || !strings.HasSuffix(entry.File, "/greeting.go") || entry.Hunks != 1 {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	if !strings.Contains(entry.Input, `"oldName":"FormatGreeting"`) {
+		t.Errorf("expected the input summary to name the symbol, got %s", entry.Input)
+	}
+
+	if entry.Before != sha256Sum(before) || entry.After != sha256Sum(after) {
+		t.Errorf("expected hashes of the file before and after the rename, got %+v", entry)
+	}
+
+	_, recent, err := tools.GetAuditLog(ctx, &mcp.CallToolRequest{},
+		tools.GetAuditLogInput{SinceTimestamp: time.Now().Add(time.Hour).Format(time.RFC3339)})
+	if err != nil {
+		t.Fatalf("GetAuditLog (since) error: %v", err)
+	}
+
+	if recent.Total != 0 {
+		t.Errorf("expected no entries after sinceTimestamp, got %+v", recent.Entries)
+	}
+}
+
+func TestGetAuditLog_Disabled(t *testing.T) {
+	t.Parallel()
+
+	_, out, err := tools.GetAuditLog(context.Background(), &mcp.CallToolRequest{}, tools.GetAuditLogInput{})
+	if err != nil {
+		t.Fatalf("GetAuditLog error: %v", err)
+	}
+
+	if out.Enabled || len(out.Entries) != 0 {
+		t.Errorf("expected a disabled, empty audit log, got %+v", out)
+	}
+
+	if _, _, err := tools.GetAuditLog(context.Background(), &mcp.CallToolRequest{},
+		tools.GetAuditLogInput{SinceTimestamp: "yesterday"}); err == nil {
+		t.Error("expected an error for a malformed sinceTimestamp")
+	}
+}
+
+func sha256Sum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// knownOS and knownArch list the GOOS and GOARCH values that constrain a file through its name
+// (stat_linux.go, asm_linux_amd64.go), as in go/build.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+		"illumos": true, "ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true, "openbsd": true,
+		"plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true, "arm64be": true,
+		"loong64": true, "mips": true, "mipsle": true, "mips64": true, "mips64le": true, "mips64p32": true,
+		"mips64p32le": true, "ppc": true, "ppc64": true, "ppc64le": true, "riscv": true, "riscv64": true,
+		"s390": true, "s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+	}
+)
+
+// fileBuildConstraint returns the build constraint of a file: its //go:build expression combined with
+// the GOOS/GOARCH implied by its name, or "" for a file built everywhere.
+func fileBuildConstraint(filename string, file *ast.File) string {
+	var expr constraint.Expr
+
+	if file != nil {
+		for _, group := range file.Comments {
+			if group.Pos() >= file.Package {
+				break
+			}
+
+			for _, c := range group.List {
+				if !constraint.IsGoBuild(c.Text) {
+					continue
+				}
+
+				if x, err := constraint.Parse(c.Text); err == nil {
+					expr = andConstraint(expr, x)
+				}
+			}
+		}
+	}
+
+	expr = andConstraint(expr, filenameConstraint(filepath.Base(filename)))
+	if expr == nil {
+		return ""
+	}
+
+	return expr.String()
+}
+
+// fileBuildConstraintAt parses the header of the file at path and returns its build constraint.
+func fileBuildConstraintAt(path string) string {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return filenameConstraintString(filepath.Base(path))
+	}
+
+	return fileBuildConstraint(path, file)
+}
+
+// filenameConstraint returns the GOOS/GOARCH constraint implied by a file name, following the
+// *_GOOS, *_GOARCH and *_GOOS_GOARCH rules of go/build; nil when the name implies none.
+func filenameConstraint(name string) constraint.Expr {
+	name = strings.TrimSuffix(name, ".go")
+	name = strings.TrimSuffix(name, "_test")
+
+	i := strings.Index(name, "_")
+	if i < 0 {
+		return nil
+	}
+
+	parts := strings.Split(name[i:], "_")
+	n := len(parts)
+
+	if n >= 2 && knownOS[parts[n-2]] && knownArch[parts[n-1]] {
+		return &constraint.AndExpr{X: &constraint.TagExpr{Tag: parts[n-2]}, Y: &constraint.TagExpr{Tag: parts[n-1]}}
+	}
+
+	if knownOS[parts[n-1]] || knownArch[parts[n-1]] {
+		return &constraint.TagExpr{Tag: parts[n-1]}
+	}
+
+	return nil
+}
+
+// filenameConstraintString is filenameConstraint rendered as an expression, or "".
+func filenameConstraintString(name string) string {
+	if expr := filenameConstraint(name); expr != nil {
+		return expr.String()
+	}
+
+	return ""
+}
+
+// andConstraint joins two optional constraint expressions.
+func andConstraint(x, y constraint.Expr) constraint.Expr {
+	switch {
+	case x == nil:
+		return y
+	case y == nil:
+		return x
+	}
+
+	return &constraint.AndExpr{X: x, Y: y}
+}
+
+// declaredInIgnoredFiles reports whether ident of kind is declared in a file one of pkgs excludes on the
+// host platform.
+func declaredInIgnoredFiles(dir string, pkgs []*packages.Package, ident, kind string) bool {
+	var variants []locationRecord
+
+	for _, pkg := range pkgs {
+		appendConstrainedVariants(&variants, dir, pkg, ident, kind, "", snippetSpec{}, false)
+	}
+
+	return len(variants) > 0
+}
+
+// appendConstrainedVariants appends the top-level declarations of ident in the files pkg excludes on the
+// host platform (stat_windows.go when running on linux), so every platform variant of a symbol is reported.
+// withLSP adds the LSP location of each declared name.
+func appendConstrainedVariants(out *[]locationRecord, dir string, pkg *packages.Package, ident, kind, fileFilter string, snippets snippetSpec, withLSP bool) {
+	for _, path := range pkg.IgnoredFiles {
+		if !strings.HasSuffix(path, ".go") || !matchesFileFilter(relativePath(dir, path), fileFilter) {
+			continue
+		}
+
+		fset := token.NewFileSet()
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		bc := fileBuildConstraint(path, file)
+		if bc == "" {
+			continue
+		}
+
+		lines := getFileLinesFromPath(path)
+
+		for _, decl := range file.Decls {
+			owner := ""
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				owner = receiverName(fd)
+			}
+
+			for _, def := range topLevelNames(decl) {
+				if def.name.Name != ident || (kind != "" && def.kind != kind) {
+					continue
+				}
+
+				posn := fset.Position(def.name.Pos())
+
+				var loc *LSPLocation
+				if withLSP {
+					loc = lspLocationAt(posn, def.name.Name)
+				}
+
+				*out = append(*out, locationRecord{
+					File:            relativePath(dir, path),
+					Line:            posn.Line,
+					SymbolID:        syntaxSymbolID(normalizePackagePath(pkg), owner, def.name.Name, def.kind),
+					Snippet:         snippets.extract(lines, fset, file, def.name.Pos()),
+					BuildConstraint: bc,
+					LSPLocation:     loc,
+				})
+			}
+		}
+	}
+}
+
+// declaredName is a name introduced by a top-level declaration with its objStringKind.
+type declaredName struct {
+	name *ast.Ident
+	kind string
+}
+
+// topLevelNames returns the names a top-level declaration introduces.
+func topLevelNames(decl ast.Decl) []declaredName {
+	var names []declaredName
+
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		names = append(names, declaredName{name: d.Name, kind: "func"})
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, declaredName{name: s.Name, kind: "type"})
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					names = append(names, declaredName{name: name, kind: strings.ToLower(d.Tok.String())})
+				}
+			}
+		}
+	}
+
+	return names
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,7 +16,7 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
-func makeCacheKey(dir string, mode packages.LoadMode, includeTests bool) string {
+func makeCacheKey(dir string, mode packages.LoadMode, includeTests, includeTestdata bool) string {
 	h := sha256.New()
 	h.Write([]byte(dir))
 	h.Write([]byte("|"))
@@ -27,23 +29,75 @@ func makeCacheKey(dir string, mode packages.LoadMode, includeTests bool) string
 		h.Write([]byte("tests=0"))
 	}
 
+	if includeTestdata {
+		h.Write([]byte("|testdata=1"))
+	}
+
 	return hex.EncodeToString(h.Sum(nil))
 }
 
 // PackageCacheItem represents a cached package with its timestamp.
 type PackageCacheItem struct {
-	Packages      []*packages.Package
-	LastAccess    time.Time
-	FileModTime   map[string]time.Time
-	LastFileCheck time.Time     // Last time we checked file modification times
-	CheckValidFor time.Duration // Time for which file check is valid (e.g., 5 seconds)
+	Packages        []*packages.Package
+	LastAccess      time.Time
+	FileModTime     map[string]time.Time
+	LastFileCheck   time.Time     // Last time we checked file modification times
+	CheckValidFor   time.Duration // Time for which file check is valid (e.g., 5 seconds)
+	Mode            packages.LoadMode
+	IncludeTests    bool
+	IncludeTestdata bool // Testdata packages below Dir were loaded too (see withTestdata)
+	Dir             string
+	Hits            int   // Requests answered from this entry
+	Pinned          bool  // Pinned entries survive age-based cleanup (see Warmup)
+	Size            int64 // Estimated bytes held by the entry (see estimateLoadSize)
+	// Diagnostics - package errors, unmatched patterns and, for slow loads, the go command log of the load
+	Diagnostics []string
+}
+
+// describe returns a short human-readable label of the cached analysis, e.g. "syntaxTypesNamed+tests".
+func (item PackageCacheItem) describe() string {
+	name := loadModeName(item.Mode)
+	if item.IncludeTests {
+		name += "+tests"
+	}
+
+	if item.IncludeTestdata {
+		name += "+testdata"
+	}
+
+	return name
 }
 
 var packageCache = struct {
 	sync.RWMutex
 
-	pkgs map[string]PackageCacheItem
-}{pkgs: make(map[string]PackageCacheItem)}
+	pkgs   map[string]PackageCacheItem
+	hits   int
+	misses int
+	// evictions counts entries dropped to fit the cache budget and forcedCleanups the times the heap
+	// outgrew it during a load (see ConfigureCacheBudget).
+	evictions      int
+	forcedCleanups int
+	// generation counts file invalidations and invalidatedDirs holds the generation of the latest one per
+	// directory. A load that overlapped an invalidation of one of its directories is not cached, since it
+	// may have read files from before the change while recording modification times from after it.
+	// inFlight counts the loads and updates running per generation they started at; invalidations no
+	// longer newer than any of them are pruned (see pruneInvalidatedDirs).
+	generation      int
+	invalidatedDirs map[string]int
+	inFlight        map[int]int
+	// retired holds entries dropped by file changes for a while, for updateFile to patch instead of a
+	// reload, and patched the modification time each file had when updateFile patched the entries holding
+	// it (see incremental.go).
+	retired map[string]retiredEntry
+	patched map[string]time.Time
+}{
+	pkgs:            make(map[string]PackageCacheItem),
+	invalidatedDirs: make(map[string]int),
+	inFlight:        make(map[int]int),
+	retired:         make(map[string]retiredEntry),
+	patched:         make(map[string]time.Time),
+}
 
 func loadPackagesWithCache(ctx context.Context, dir string, mode packages.LoadMode) ([]*packages.Package, error) {
 	return loadPackagesWithCacheInternal(ctx, dir, mode, false)
@@ -53,60 +107,75 @@ func loadPackagesWithCacheIncludeTests(ctx context.Context, dir string, mode pac
 	return loadPackagesWithCacheInternal(ctx, dir, mode, true)
 }
 
-// loadPackagesWithCacheInternal loads Go packages and caches them by (dir, mode, includeTests),
-// automatically invalidating cache when any source file was modified.
+// loadPackagesWithCacheInternal loads Go packages and caches them by (canonical dir, mode, includeTests),
+// automatically invalidating cache when any source file was modified. A cached load with a stronger
+// mode answers weaker requests as well. Packages requested with a typed mode are guaranteed to carry
+// Types and TypesInfo; otherwise an errTypesNotLoaded error is returned.
 func loadPackagesWithCacheInternal(ctx context.Context, dir string, mode packages.LoadMode, includeTests bool) ([]*packages.Package, error) {
-	cacheKey := makeCacheKey(dir, mode, includeTests)
-
-	packageCache.RLock()
-	item, exists := packageCache.pkgs[cacheKey]
-	packageCache.RUnlock()
+	// Spellings of the same directory share one entry and report the same file names.
+	dir = CanonicalDir(dir)
+	testdata := includesTestdata(ctx)
+	cacheKey := makeCacheKey(dir, mode, includeTests, testdata)
 
-	if exists {
-		// Check if we should verify file modification times (e.g., only every 5 seconds)
-		shouldCheckFiles := time.Since(item.LastFileCheck) > item.CheckValidFor
-		modified := false
+	for _, key := range cacheKeysFor(dir, mode, includeTests, testdata) {
+		if item, ok := cachedPackages(key); ok {
+			reportLoadDiagnostics(ctx, item.Diagnostics)
 
-		if shouldCheckFiles {
-			modified = isPackageModified(item.FileModTime)
-			if !modified {
-				// Update the file check time
-				packageCache.Lock()
-
-				item.LastFileCheck = time.Now()
-				packageCache.pkgs[cacheKey] = item
-				packageCache.Unlock()
-			}
+			return item.Packages, checkLoadedTypes(item.Packages, mode)
 		}
+	}
 
-		if !shouldCheckFiles || !modified {
-			// Use cached data
-			packageCache.Lock()
+	// Concurrent identical requests share one load.
+	pkgs, diagnostics, shared, err := coalesceLoad(ctx, cacheKey, func() ([]*packages.Package, []string, error) {
+		return loadAndCachePackages(ctx, dir, mode, includeTests, testdata, cacheKey)
+	})
+	if shared {
+		reportLoadDiagnostics(ctx, diagnostics)
+	}
 
-			item.LastAccess = time.Now()
-			packageCache.pkgs[cacheKey] = item
-			packageCache.Unlock()
+	return pkgs, err
+}
 
-			return item.Packages, nil
-		}
-	}
+// loadAndCachePackages runs the load behind a cache miss of loadPackagesWithCacheInternal and stores it
+// under cacheKey unless one of its directories was invalidated meanwhile. It returns the packages and
+// the load diagnostics.
+func loadAndCachePackages(
+	ctx context.Context,
+	dir string,
+	mode packages.LoadMode,
+	includeTests, testdata bool,
+	cacheKey string,
+) (
+	[]*packages.Package,
+	[]string,
+	error,
+) {
+	packageCache.Lock()
+	packageCache.misses++
+	generation := packageCache.generation
+	packageCache.inFlight[generation]++
+	packageCache.Unlock()
+
+	defer releaseGeneration(generation)
 
 	// If cache is missing or outdated - reload
-	cfg := &packages.Config{
-		Mode:    mode,
-		Dir:     dir,
-		Context: ctx,
-		Tests:   includeTests,
-	}
+	stopWatch := watchHeapDuringLoad()
+	pkgs, diagnostics, err := loadPackagesUncached(ctx, dir, mode, includeTests, nil, loadPatterns(ctx, dir)...)
+	stopWatch()
 
-	pkgs, err := packages.Load(cfg, "./...")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if err := checkLoadedTypes(pkgs, mode); err != nil {
+		return nil, nil, err
 	}
 
 	// Save file modification times and add files to watcher
 	fileModTimes := make(map[string]time.Time)
 
+	var sourceBytes int64
+
 	for _, pkg := range pkgs {
 		// Track all directories that contain Go files
 		dirsAdded := make(map[string]bool)
@@ -114,6 +183,7 @@ func loadPackagesWithCacheInternal(ctx context.Context, dir string, mode package
 		for _, f := range pkg.CompiledGoFiles {
 			if st, err := os.Stat(f); err == nil {
 				fileModTimes[f] = st.ModTime()
+				sourceBytes += st.Size()
 				// Add file to watcher
 				_ = addFileToWatch(f, cacheKey)
 
@@ -128,16 +198,139 @@ func loadPackagesWithCacheInternal(ctx context.Context, dir string, mode package
 	}
 
 	packageCache.Lock()
+	defer packageCache.Unlock()
+
+	for file := range fileModTimes {
+		if packageCache.invalidatedDirs[filepath.Dir(file)] > generation {
+			return pkgs, diagnostics, nil
+		}
+	}
+
+	delete(packageCache.retired, cacheKey)
+
 	packageCache.pkgs[cacheKey] = PackageCacheItem{
-		Packages:      pkgs,
-		LastAccess:    time.Now(),
-		FileModTime:   fileModTimes,
-		LastFileCheck: time.Now(),
-		CheckValidFor: 5 * time.Second, // Only check file modification every 5 seconds
+		Packages:        pkgs,
+		LastAccess:      time.Now(),
+		FileModTime:     fileModTimes,
+		LastFileCheck:   time.Now(),
+		CheckValidFor:   5 * time.Second, // Only check file modification every 5 seconds
+		Mode:            mode,
+		IncludeTests:    includeTests,
+		IncludeTestdata: testdata,
+		Dir:             dir,
+		Size:            estimateLoadSize(mode, len(pkgs), sourceBytes),
+		Diagnostics:     diagnostics,
+	}
+
+	enforceCacheBudget(cacheKey)
+
+	return pkgs, diagnostics, nil
+}
+
+// loadPackagesUncached runs packages.Load for patterns under the load timeout, without consulting or
+// filling the package cache, and reports the load diagnostics to ctx. overlay, if any, replaces the
+// content of files by absolute path.
+func loadPackagesUncached(
+	ctx context.Context,
+	dir string,
+	mode packages.LoadMode,
+	includeTests bool,
+	overlay map[string][]byte,
+	patterns ...string,
+) (
+	[]*packages.Package,
+	[]string,
+	error,
+) {
+	dir = CanonicalDir(dir)
+	loadCtx := ctx
+
+	if timeout := time.Duration(loadTimeout.Load()); timeout > 0 {
+		var cancel context.CancelFunc
+
+		loadCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	driver := &driverLog{}
+
+	cfg := &packages.Config{
+		Mode:    mode,
+		Dir:     dir,
+		Context: loadCtx,
+		Tests:   includeTests,
+		Overlay: overlay,
+		Logf:    driver.logf,
+	}
+
+	loadStart := time.Now()
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, loadFailure(ctx, loadCtx, err, driver)
+	}
+
+	diagnostics := packageLoadDiagnostics(dir, patterns, pkgs, driver, time.Since(loadStart))
+	reportLoadDiagnostics(ctx, diagnostics)
+
+	return pkgs, diagnostics, nil
+}
+
+// cacheKeysFor returns the keys of cached loads that can answer (dir, mode, includeTests, includeTestdata):
+// the exact entry first, then entries loaded with a stronger mode.
+func cacheKeysFor(dir string, mode packages.LoadMode, includeTests, includeTestdata bool) []string {
+	exact := makeCacheKey(dir, mode, includeTests, includeTestdata)
+
+	packageCache.RLock()
+	defer packageCache.RUnlock()
+
+	var keys []string
+
+	if _, ok := packageCache.pkgs[exact]; ok {
+		keys = append(keys, exact)
+	}
+
+	for key, item := range packageCache.pkgs {
+		if key != exact && item.Dir == dir && item.IncludeTests == includeTests &&
+			item.IncludeTestdata == includeTestdata && item.Mode&mode == mode {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// cachedPackages returns a cache entry unless its files changed since it was loaded.
+func cachedPackages(cacheKey string) (PackageCacheItem, bool) {
+	packageCache.RLock()
+	item, exists := packageCache.pkgs[cacheKey]
+	packageCache.RUnlock()
+
+	if !exists {
+		return PackageCacheItem{}, false
 	}
+
+	// Check if we should verify file modification times (e.g., only every 5 seconds)
+	if time.Since(item.LastFileCheck) > item.CheckValidFor {
+		if isPackageModified(item.FileModTime) {
+			return PackageCacheItem{}, false
+		}
+
+		item.LastFileCheck = time.Now()
+	}
+
+	item.LastAccess = time.Now()
+	item.Hits++
+
+	packageCache.Lock()
+	if _, ok := packageCache.pkgs[cacheKey]; ok {
+		packageCache.pkgs[cacheKey] = item
+	}
+
+	packageCache.hits++
 	packageCache.Unlock()
 
-	return pkgs, nil
+	return item, true
 }
 
 // isPackageModified returns true if any file in the cached package set has changed.
@@ -161,11 +354,144 @@ func isPackageModified(stored map[string]time.Time) bool {
 	return false
 }
 
+// degradedSyntaxOnly marks responses built from an offline, syntax-only load.
+const degradedSyntaxOnly = "syntax-only"
+
+// moduleResolutionMarkers are error fragments produced by the go command when the module graph
+// or toolchain cannot be resolved, typically because the proxy is unreachable.
+var moduleResolutionMarkers = []string{
+	"dial tcp",
+	"no such host",
+	"connection refused",
+	"i/o timeout",
+	"proxy.golang.org",
+	"module lookup disabled",
+	"missing go.sum entry",
+	"verifying module",
+	"go: downloading",
+	"toolchain not available",
+	"gotoolchain",
+	"requires go >=",
+	"updates to go.mod needed",
+	"cannot find module providing package",
+}
+
+// loadDegradation records that packages were loaded by the syntax-only fallback.
+type loadDegradation struct {
+	mode string
+	err  error
+}
+
+// apply copies the degradation marker and the original load error into response fields; nil is a no-op.
+func (d *loadDegradation) apply(degraded, loadError *string) {
+	if d == nil {
+		return
+	}
+
+	*degraded = d.mode
+	*loadError = d.err.Error()
+}
+
+// isModuleResolutionError reports whether a load error was caused by module graph or network resolution.
+func isModuleResolutionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range moduleResolutionMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadPackagesWithFallback loads packages like loadPackagesWithCache, but when the load fails on module
+// resolution it retries offline with syntax only. Callers must not rely on type information when
+// the returned degradation is non-nil.
+func loadPackagesWithFallback(ctx context.Context, dir string, mode packages.LoadMode) ([]*packages.Package, *loadDegradation, error) {
+	pkgs, err := loadPackagesWithCache(ctx, dir, mode)
+	if err == nil || !isModuleResolutionError(err) {
+		return pkgs, nil, err
+	}
+
+	offline, offlineErr := loadPackagesOffline(ctx, dir)
+	if offlineErr != nil {
+		return nil, nil, err
+	}
+
+	return offline, &loadDegradation{mode: degradedSyntaxOnly, err: err}, nil
+}
+
+// loadPackagesOffline loads syntax of the module's own packages without resolving dependencies:
+// the go command runs against a temporary go.mod without requirements, with the proxy and
+// toolchain switching disabled. The project's go.mod and go.sum are never touched.
+func loadPackagesOffline(ctx context.Context, dir string) ([]*packages.Package, error) {
+	modRoot := findModuleRoot(dir)
+	if modRoot == "" {
+		return nil, fmt.Errorf("go.mod not found for %s", dir)
+	}
+
+	modulePath, _ := readGoModInfo(modRoot)
+	if modulePath == "" {
+		return nil, fmt.Errorf("module path not found in %s", filepath.Join(modRoot, "go.mod"))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "go-navigator-offline-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	modFile := filepath.Join(tmpDir, "go.mod")
+	if err := os.WriteFile(modFile, []byte("module "+modulePath+"\n"), 0o644); err != nil {
+		return nil, err
+	}
+
+	cfg := &packages.Config{
+		Mode:    loadModeOfflineSyntax,
+		Dir:     dir,
+		Context: ctx,
+		Env: append(os.Environ(),
+			"GOPROXY=off",
+			"GOSUMDB=off",
+			"GOTOOLCHAIN=local",
+			"GOWORK=off",
+			"GOFLAGS=-mod=mod -modfile="+modFile,
+		),
+	}
+
+	return packages.Load(cfg, loadPatterns(ctx, dir)...)
+}
+
+// findModuleRoot returns the closest directory at or above dir that contains go.mod.
+func findModuleRoot(dir string) string {
+	abs := CanonicalDir(dir)
+
+	for {
+		if _, err := os.Stat(filepath.Join(abs, "go.mod")); err == nil {
+			return abs
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+
+		abs = parent
+	}
+}
+
 // FileLinesCacheItem represents a cached file lines entry with timestamp.
 type FileLinesCacheItem struct {
 	Lines      []string
 	LastAccess time.Time
 	ModTime    time.Time
+	Size       int64
+	// Hash is the hex SHA-256 of the file content the lines were split from.
+	Hash string
 }
 
 var fileLinesCache = struct {
@@ -194,10 +520,19 @@ func cleanupCache(maxAge time.Duration) {
 
 	now := time.Now()
 	for key, item := range packageCache.pkgs {
-		if now.Sub(item.LastAccess) > maxAge {
+		if !item.Pinned && now.Sub(item.LastAccess) > maxAge {
 			delete(packageCache.pkgs, key)
 		}
 	}
+
+	for key, entry := range packageCache.retired {
+		if now.Sub(entry.retiredAt) > retiredEntryTTL {
+			delete(packageCache.retired, key)
+		}
+	}
+
+	pruneInvalidatedDirs(packageCache.invalidatedDirs, oldestGenerationInUse())
+	cleanupNegativeLookups()
 }
 
 // startCacheCleanup starts a background goroutine that periodically cleans up old cache entries.
@@ -225,7 +560,8 @@ func cleanupFileLinesCache(maxAge time.Duration) {
 	}
 }
 
-// startFileLinesCacheCleanup starts a background goroutine that periodically cleans up old file lines cache entries.
+// startFileLinesCacheCleanup starts a background goroutine that periodically cleans up old file lines cache
+// and navigation index entries.
 func startFileLinesCacheCleanup(interval time.Duration, maxAge time.Duration) {
 	go func() {
 		ticker := time.NewTicker(interval)
@@ -233,6 +569,8 @@ func startFileLinesCacheCleanup(interval time.Duration, maxAge time.Duration) {
 
 		for range ticker.C {
 			cleanupFileLinesCache(maxAge)
+			cleanupFileNavCache(maxAge)
+			cleanupCallGraphCache(maxAge)
 		}
 	}()
 }
@@ -326,52 +664,128 @@ func addFileToWatch(filePath string, cacheKey string) error {
 	return AddFileToWatch(filePath, cacheKey)
 }
 
-// invalidateCachesForFile invalidates all cache entries that depend on the specified file.
+// invalidateCachesForFile invalidates all cache entries that depend on the specified file
+// and notifies project watchers about the change.
 func invalidateCachesForFile(filePath string) {
+	// Events of a write whose entries updateFile already patched only drop the per-file caches.
+	if patchedByUpdate(filePath) {
+		dropFileCaches(filePath)
+		notifyProjectWatchers(filePath, nil)
+
+		return
+	}
+
+	// The key set is copied under the lock: loads running concurrently keep adding to it.
 	fileWatcher.RLock()
-	cacheKeys, exists := fileWatcher.fileToCacheKeys[filePath]
+	cacheKeys := make([]string, 0, len(fileWatcher.fileToCacheKeys[filePath]))
+	for cacheKey := range fileWatcher.fileToCacheKeys[filePath] {
+		cacheKeys = append(cacheKeys, cacheKey)
+	}
 	fileWatcher.RUnlock()
 
-	if exists {
+	var invalidated []string
+
+	if len(cacheKeys) > 0 {
 		// Invalidate package cache entries
 		packageCache.Lock()
 
-		for cacheKey := range cacheKeys {
-			delete(packageCache.pkgs, cacheKey)
+		for _, cacheKey := range cacheKeys {
+			if item, ok := packageCache.pkgs[cacheKey]; ok {
+				invalidated = append(invalidated, item.describe())
+				retireEntry(cacheKey, item)
+			}
 		}
 
 		packageCache.Unlock()
 	}
 
-	// Invalidate file lines cache entry for this specific file
-	fileLinesCache.Lock()
-	delete(fileLinesCache.data, filePath)
-	fileLinesCache.Unlock()
+	dropFileCaches(filePath)
 
 	// Also invalidate any package cache that might include this new file
 	// This handles the case where a new file is added to a directory/package
 	dir := filepath.Dir(filePath)
-	invalidatePackageCachesInDir(dir)
+	invalidated = append(invalidated, invalidatePackageCachesInDir(dir)...)
 	invalidateFileLinesCachesInDir(dir)
+	noteRetiredChange(filePath)
+
+	notifyProjectWatchers(filePath, invalidated)
+}
+
+// dropFileCaches drops the cached lines and navigation index of one file.
+func dropFileCaches(filePath string) {
+	fileLinesCache.Lock()
+	delete(fileLinesCache.data, filePath)
+	fileLinesCache.Unlock()
+
+	fileNavCache.Lock()
+	delete(fileNavCache.data, filePath)
+	fileNavCache.Unlock()
+}
+
+// releaseGeneration ends a load or update that started at generation.
+func releaseGeneration(generation int) {
+	packageCache.Lock()
+	defer packageCache.Unlock()
+
+	if packageCache.inFlight[generation]--; packageCache.inFlight[generation] <= 0 {
+		delete(packageCache.inFlight, generation)
+	}
+}
+
+// oldestGenerationInUse returns the oldest generation a running load or update, or a retired entry,
+// still compares invalidations against; the current generation when there is none. The caller holds the
+// packageCache lock.
+func oldestGenerationInUse() int {
+	oldest := packageCache.generation
+
+	for generation := range packageCache.inFlight {
+		oldest = min(oldest, generation)
+	}
+
+	for _, entry := range packageCache.retired {
+		oldest = min(oldest, entry.generation)
+	}
+
+	return oldest
 }
 
-// invalidatePackageCachesInDir invalidates all package caches for a specific directory.
-func invalidatePackageCachesInDir(dir string) {
+// pruneInvalidatedDirs drops the invalidations at or below oldest from dirs. Every check asks whether a
+// directory was invalidated after a generation of oldest or later, which they cannot be, so a dropped
+// directory reads the same.
+func pruneInvalidatedDirs(dirs map[string]int, oldest int) {
+	for dir, generation := range dirs {
+		if generation <= oldest {
+			delete(dirs, dir)
+		}
+	}
+}
+
+// invalidatePackageCachesInDir invalidates all package caches for a specific directory
+// and returns labels of the invalidated entries.
+func invalidatePackageCachesInDir(dir string) []string {
 	packageCache.Lock()
 	defer packageCache.Unlock()
 
+	packageCache.generation++
+	packageCache.invalidatedDirs[dir] = packageCache.generation
+
+	var invalidated []string
+
 	// Find and invalidate all cache entries that might be affected by changes in this directory
 	// The cache key includes the directory, so we need to find entries that contain this directory
 	for cacheKey, item := range packageCache.pkgs {
 		// Check if any of the cached files are in the specified directory
 		for file := range item.FileModTime {
 			if filepath.Dir(file) == dir {
-				delete(packageCache.pkgs, cacheKey)
+				invalidated = append(invalidated, item.describe())
+				retireEntry(cacheKey, item)
 
 				break
 			}
 		}
 	}
+
+	return invalidated
 }
 
 // invalidateFileLinesCachesInDir invalidates all file lines caches for files in a specific directory.
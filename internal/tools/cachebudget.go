@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Factors from the size of the source files of a load to the memory its cache entry holds: typed loads
+// keep syntax, objects and the types.Info maps of every package, syntax loads the ASTs only.
+const (
+	typedLoadSizeFactor  = 40
+	syntaxLoadSizeFactor = 10
+	// basicLoadEntrySize is the estimate per package of loads without syntax.
+	basicLoadEntrySize = 4 << 10
+)
+
+// heapSampleInterval is how often the heap is sampled while packages.Load runs under a budget.
+const heapSampleInterval = 250 * time.Millisecond
+
+// cacheBudget holds the budget of the package cache in bytes; zero disables it.
+var cacheBudget atomic.Int64
+
+// heapAlloc reports the bytes of allocated heap objects; tests replace it.
+var heapAlloc = func() uint64 {
+	var stats runtime.MemStats
+
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
+
+// ConfigureCacheBudget bounds the estimated memory of the package cache. When a load pushes the estimate
+// past it, least recently used entries are evicted, loads with type information before syntax-only ones
+// and pinned entries last. A heap growing past the budget while a load runs evicts every unpinned
+// entry. Zero disables the bound.
+//
+// Parameters:
+//   - megabytes: budget in MiB, 0 for none
+//
+// Returns:
+//   - error if megabytes is negative
+func ConfigureCacheBudget(megabytes int) error {
+	if megabytes < 0 {
+		return fmt.Errorf("cache budget must not be negative: %d MB", megabytes)
+	}
+
+	cacheBudget.Store(int64(megabytes) << 20)
+
+	return nil
+}
+
+// estimateLoadSize estimates the memory held by a cached load of packages with mode, from sourceBytes,
+// the total size of their source files.
+func estimateLoadSize(mode packages.LoadMode, pkgCount int, sourceBytes int64) int64 {
+	switch {
+	case mode&packages.NeedTypesInfo != 0:
+		return sourceBytes * typedLoadSizeFactor
+	case mode&packages.NeedSyntax != 0:
+		return sourceBytes * syntaxLoadSizeFactor
+	default:
+		return int64(pkgCount) * basicLoadEntrySize
+	}
+}
+
+// selectEvictions returns the keys of the entries of items to evict so that their estimated sizes add up
+// to at most budget, in eviction order: unpinned before pinned, typed loads before syntax-only ones, then
+// least recently used first. The entry under keep is never selected.
+func selectEvictions(items map[string]PackageCacheItem, budget int64, keep string) []string {
+	var (
+		total int64
+		keys  []string
+	)
+
+	for key, item := range items {
+		total += item.Size
+
+		if key != keep {
+			keys = append(keys, key)
+		}
+	}
+
+	if total <= budget {
+		return nil
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := items[keys[i]], items[keys[j]]
+		if a.Pinned != b.Pinned {
+			return !a.Pinned
+		}
+
+		if typedA, typedB := a.Mode&packages.NeedTypesInfo != 0, b.Mode&packages.NeedTypesInfo != 0; typedA != typedB {
+			return typedA
+		}
+
+		if !a.LastAccess.Equal(b.LastAccess) {
+			return a.LastAccess.Before(b.LastAccess)
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	var evict []string
+
+	for _, key := range keys {
+		if total <= budget {
+			break
+		}
+
+		evict = append(evict, key)
+		total -= items[key].Size
+	}
+
+	return evict
+}
+
+// enforceCacheBudget evicts entries of the package cache until its estimate fits the budget, sparing
+// keep, the entry just stored. The caller holds packageCache's lock.
+func enforceCacheBudget(keep string) {
+	if budget := cacheBudget.Load(); budget > 0 {
+		packageCache.evictions += evictOverBudget(packageCache.pkgs, budget, keep)
+	}
+}
+
+// evictOverBudget deletes the entries selectEvictions picks from items, and keep as well if it alone
+// exceeds budget, and returns the number of entries deleted.
+func evictOverBudget(items map[string]PackageCacheItem, budget int64, keep string) int {
+	evicted := 0
+
+	if item, ok := items[keep]; ok && item.Size > budget {
+		delete(items, keep)
+		evicted++
+	}
+
+	for _, key := range selectEvictions(items, budget, keep) {
+		delete(items, key)
+		evicted++
+	}
+
+	return evicted
+}
+
+// watchHeapDuringLoad samples the heap while a load runs, and once more when it ends, and the first time
+// it exceeds the cache budget evicts every unpinned cache entry and returns the memory to the OS. The
+// returned func stops sampling.
+func watchHeapDuringLoad() func() {
+	budget := cacheBudget.Load()
+	if budget <= 0 {
+		return func() {}
+	}
+
+	var triggered atomic.Bool
+
+	check := func() bool {
+		if heapAlloc() <= uint64(budget) || triggered.Swap(true) {
+			return false
+		}
+
+		forceCacheCleanup()
+
+		return true
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(heapSampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if check() {
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		check()
+	}
+}
+
+// forceCacheCleanup evicts every unpinned package cache entry and frees the memory they held.
+func forceCacheCleanup() {
+	packageCache.Lock()
+
+	for key, item := range packageCache.pkgs {
+		if !item.Pinned {
+			packageCache.evictions++
+			delete(packageCache.pkgs, key)
+		}
+	}
+
+	packageCache.forcedCleanups++
+	packageCache.Unlock()
+
+	debug.FreeOSMemory()
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestSelectEvictions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	items := map[string]PackageCacheItem{
+		"old-syntax": {Mode: loadModeBasicSyntax, Size: 100, LastAccess: now.Add(-3 * time.Minute)},
+		"old-typed":  {Mode: loadModeSyntaxTypes, Size: 100, LastAccess: now.Add(-2 * time.Minute)},
+		"new-typed":  {Mode: loadModeSyntaxTypes, Size: 100, LastAccess: now.Add(-time.Minute)},
+		"pinned":     {Mode: loadModeAll, Size: 100, LastAccess: now.Add(-time.Hour), Pinned: true},
+		"just-added": {Mode: loadModeSyntaxTypes, Size: 100, LastAccess: now},
+	}
+
+	for _, tc := range []struct {
+		budget int64
+		want   []string
+	}{
+		{500, nil},
+		{400, []string{"old-typed"}},
+		{200, []string{"old-typed", "new-typed", "old-syntax"}},
+		{0, []string{"old-typed", "new-typed", "old-syntax", "pinned"}},
+	} {
+		if got := selectEvictions(items, tc.budget, "just-added"); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("budget %d: evict %v, want %v", tc.budget, got, tc.want)
+		}
+	}
+}
+
+func TestEvictOverBudget_OversizedEntry(t *testing.T) {
+	t.Parallel()
+
+	items := map[string]PackageCacheItem{
+		"small": {Mode: loadModeBasicSyntax, Size: 10},
+		"huge":  {Mode: loadModeSyntaxTypes, Size: 1000},
+	}
+
+	if evicted := evictOverBudget(items, 100, "huge"); evicted != 1 || len(items) != 1 {
+		t.Errorf("evicted %d, left %v; want the oversized new entry dropped and small kept", evicted, items)
+	}
+}
+
+func TestEstimateLoadSize(t *testing.T) {
+	t.Parallel()
+
+	typed := estimateLoadSize(loadModeSyntaxTypes, 2, 1000)
+	syntax := estimateLoadSize(loadModeBasicSyntax, 2, 1000)
+	basic := estimateLoadSize(packages.NeedName|packages.NeedFiles, 2, 1000)
+
+	if typed <= syntax || syntax <= 0 || basic != 2*basicLoadEntrySize {
+		t.Errorf("estimates typed=%d syntax=%d basic=%d; want typed > syntax > 0 and basic per package", typed, syntax, basic)
+	}
+
+	if err := ConfigureCacheBudget(-1); err == nil {
+		t.Error("expected an error for a negative budget")
+	}
+}
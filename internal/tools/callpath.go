@@ -0,0 +1,430 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Defaults of findCallPath.
+const (
+	defaultCallPathDepth = 8
+	defaultCallPathCount = 3
+)
+
+// callGraphNode is a function or method declared with a body in the module.
+type callGraphNode struct {
+	fn    *types.Func
+	name  string
+	pkg   string
+	file  string
+	line  int
+	calls []callGraphEdge
+}
+
+// callGraphEdge is the first call of callee in a function. Dynamic edges are calls of an interface method
+// resolved to one of its in-module implementations.
+type callGraphEdge struct {
+	callee  string
+	line    int
+	dynamic bool
+}
+
+// callGraph is the static call graph of a module, keyed by funcKey.
+type callGraph struct {
+	pkgs       []*packages.Package
+	nodes      map[string]*callGraphNode
+	lastAccess time.Time
+}
+
+// callGraphCache holds one call graph per directory, rebuilt when the packages it was built from are no
+// longer the ones the package cache returns.
+var callGraphCache = struct {
+	sync.Mutex
+
+	byDir map[string]*callGraph
+}{
+	byDir: make(map[string]*callGraph),
+}
+
+// FindCallPath finds up to maxPaths shortest static call chains from one function to another, e.g. how
+// HandleRequest reaches SaveUser. Direct calls are resolved through the type information; a call of an
+// interface method continues into every in-module method implementing it and is marked dynamic. Calls
+// inside function literals belong to the enclosing declaration. An unreachable target is a result with
+// no paths, not an error.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the two functions and the depth and path limits
+//
+// Returns:
+//   - MCP tool call result
+//   - the call chains, each step with the line of the call into the next one
+//   - error if either function is not found or another error occurred
+func FindCallPath(ctx context.Context, _ *mcp.CallToolRequest, input FindCallPathInput) (
+	*mcp.CallToolResult,
+	FindCallPathOutput,
+	error,
+) {
+	start := logStart("FindCallPath", logFields(
+		input.Dir,
+		newLogField("from", input.From),
+		newLogField("to", input.To),
+	))
+	out := FindCallPathOutput{From: input.From, To: input.To, Paths: []CallPath{}}
+
+	defer func() { logEnd("FindCallPath", start, len(out.Paths)) }()
+
+	if input.From == "" || input.To == "" {
+		return fail(out, invalidInput("from and to are required"))
+	}
+
+	maxDepth := input.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultCallPathDepth
+	}
+
+	maxPaths := input.MaxPaths
+	if maxPaths <= 0 {
+		maxPaths = defaultCallPathCount
+	}
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeSyntaxTypesNamed)
+	if err != nil {
+		logError("FindCallPath", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	graph, err := callGraphFor(ctx, input.Dir, pkgs)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	sources := graph.lookup(input.From)
+	if len(sources) == 0 {
+		return fail(out, missingSymbol(pkgs, input.From, "func", "function %q not found", input.From))
+	}
+
+	targets := graph.lookup(input.To)
+	if len(targets) == 0 {
+		return fail(out, missingSymbol(pkgs, input.To, "func", "function %q not found", input.To))
+	}
+
+	for _, chain := range graph.shortestPaths(sources, targets, maxDepth, maxPaths) {
+		out.Paths = append(out.Paths, graph.callPath(chain))
+	}
+
+	out.Total = len(out.Paths)
+	if len(out.Paths) == 0 {
+		out.Message = fmt.Sprintf("no static path within depth %d", maxDepth)
+	}
+
+	return nil, out, nil
+}
+
+// callGraphFor returns the call graph of the module at dir built from pkgs, from the cache when pkgs are
+// the packages it was built from.
+func callGraphFor(ctx context.Context, dir string, pkgs []*packages.Package) (*callGraph, error) {
+	callGraphCache.Lock()
+	graph, ok := callGraphCache.byDir[dir]
+	if ok && slices.Equal(graph.pkgs, pkgs) {
+		graph.lastAccess = time.Now()
+		callGraphCache.Unlock()
+
+		return graph, nil
+	}
+	callGraphCache.Unlock()
+
+	graph, err := buildCallGraph(ctx, dir, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	callGraphCache.Lock()
+	callGraphCache.byDir[dir] = graph
+	callGraphCache.Unlock()
+
+	return graph, nil
+}
+
+// cleanupCallGraphCache removes call graphs not used within maxAge.
+func cleanupCallGraphCache(maxAge time.Duration) {
+	callGraphCache.Lock()
+	defer callGraphCache.Unlock()
+
+	now := time.Now()
+	for dir, graph := range callGraphCache.byDir {
+		if now.Sub(graph.lastAccess) > maxAge {
+			delete(callGraphCache.byDir, dir)
+		}
+	}
+}
+
+// buildCallGraph indexes the functions declared with a body in pkgs and their calls of one another.
+func buildCallGraph(ctx context.Context, dir string, pkgs []*packages.Package) (*callGraph, error) {
+	graph := &callGraph{pkgs: pkgs, nodes: make(map[string]*callGraphNode), lastAccess: time.Now()}
+	bodies := make(map[string]*ast.BlockStmt)
+	declaredIn := make(map[string]*packages.Package)
+	// methods indexes the concrete methods by name, for resolving interface calls.
+	methods := make(map[string][]*callGraphNode)
+
+	if err := walkPackageFiles(ctx, pkgs, dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			key := funcKey(fn)
+			node := &callGraphNode{
+				fn:   fn,
+				name: qualifiedFuncName(fd),
+				pkg:  pkg.PkgPath,
+				file: relPath,
+				line: pkg.Fset.Position(fd.Name.Pos()).Line,
+			}
+			graph.nodes[key] = node
+			bodies[key] = fd.Body
+			declaredIn[key] = pkg
+
+			if fd.Recv != nil {
+				methods[fn.Name()] = append(methods[fn.Name()], node)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for key, node := range graph.nodes {
+		pkg := declaredIn[key]
+		seen := make(map[callGraphEdge]struct{})
+
+		add := func(callee string, line int, dynamic bool) {
+			edge := callGraphEdge{callee: callee, dynamic: dynamic}
+			if _, ok := seen[edge]; ok {
+				return
+			}
+
+			seen[edge] = struct{}{}
+			edge.line = line
+			node.calls = append(node.calls, edge)
+		}
+
+		ast.Inspect(bodies[key], func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			callee := calledFunc(pkg.TypesInfo, call)
+			if callee == nil {
+				return true
+			}
+
+			line := pkg.Fset.Position(call.Lparen).Line
+
+			if iface := interfaceReceiver(callee); iface != nil {
+				for _, impl := range methods[callee.Name()] {
+					if implementsVia(impl.fn, iface) {
+						add(funcKey(impl.fn), line, true)
+					}
+				}
+
+				return true
+			}
+
+			if _, ok := graph.nodes[funcKey(callee)]; ok {
+				add(funcKey(callee), line, false)
+			}
+
+			return true
+		})
+
+		// Source order, with the implementations of one interface call by name, keeps results stable.
+		sort.SliceStable(node.calls, func(i, j int) bool {
+			if node.calls[i].line != node.calls[j].line {
+				return node.calls[i].line < node.calls[j].line
+			}
+
+			a, b := graph.nodes[node.calls[i].callee], graph.nodes[node.calls[j].callee]
+			if a.name != b.name {
+				return a.name < b.name
+			}
+
+			return a.pkg < b.pkg
+		})
+	}
+
+	return graph, nil
+}
+
+// interfaceReceiver returns the interface whose method fn is, or nil for functions and concrete methods.
+func interfaceReceiver(fn *types.Func) *types.Interface {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return nil
+	}
+
+	iface, _ := sig.Recv().Type().Underlying().(*types.Interface)
+
+	return iface
+}
+
+// implementsVia reports whether the receiver type of the concrete method fn, as a value or a pointer,
+// implements iface.
+func implementsVia(fn *types.Func, iface *types.Interface) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+
+	t := sig.Recv().Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	return types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface)
+}
+
+// lookup returns the keys of the functions named name ("Func" or "Type.Method"), sorted.
+func (g *callGraph) lookup(name string) []string {
+	var keys []string
+
+	for key, node := range g.nodes {
+		if node.name == name {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// callHop is a function of a call chain with the call into the next function; call is nil for the last.
+type callHop struct {
+	key  string
+	call *callGraphEdge
+}
+
+// shortestPaths returns up to maxPaths chains of the minimal length, at most maxDepth calls, from any of
+// sources to any of targets. A breadth-first search records for every function the calls reaching it on
+// a shortest path; chains are then walked back from the targets in that order.
+func (g *callGraph) shortestPaths(sources, targets []string, maxDepth, maxPaths int) [][]callHop {
+	type pred struct {
+		from string
+		call *callGraphEdge
+	}
+
+	isTarget := make(map[string]bool, len(targets))
+	for _, key := range targets {
+		isTarget[key] = true
+	}
+
+	dist := make(map[string]int)
+	preds := make(map[string][]pred)
+	frontier := slices.Clone(sources)
+
+	var reached []string
+
+	for _, key := range sources {
+		dist[key] = 0
+
+		if isTarget[key] {
+			reached = append(reached, key)
+		}
+	}
+
+	for depth := 1; len(reached) == 0 && depth <= maxDepth && len(frontier) > 0; depth++ {
+		var next []string
+
+		for _, from := range frontier {
+			calls := g.nodes[from].calls
+			for i := range calls {
+				callee := calls[i].callee
+
+				d, seen := dist[callee]
+				if !seen {
+					dist[callee] = depth
+					next = append(next, callee)
+
+					if isTarget[callee] {
+						reached = append(reached, callee)
+					}
+				} else if d != depth {
+					continue
+				}
+
+				preds[callee] = append(preds[callee], pred{from: from, call: &calls[i]})
+			}
+		}
+
+		frontier = next
+	}
+
+	var chains [][]callHop
+
+	// walk prepends the callers of chain[0] until a source is reached.
+	var walk func(chain []callHop)
+	walk = func(chain []callHop) {
+		if dist[chain[0].key] == 0 {
+			chains = append(chains, chain)
+
+			return
+		}
+
+		for _, p := range preds[chain[0].key] {
+			if len(chains) == maxPaths {
+				return
+			}
+
+			walk(append([]callHop{{key: p.from, call: p.call}}, chain...))
+		}
+	}
+
+	for _, key := range reached {
+		if len(chains) < maxPaths {
+			walk([]callHop{{key: key}})
+		}
+	}
+
+	return chains
+}
+
+// callPath renders a chain: every step but the last has the line of its call into the next step, the last
+// has its declaration line.
+func (g *callGraph) callPath(chain []callHop) CallPath {
+	path := CallPath{Length: len(chain) - 1, Steps: make([]CallPathStep, 0, len(chain))}
+
+	for _, hop := range chain {
+		node := g.nodes[hop.key]
+		step := CallPathStep{Function: node.name, Package: node.pkg, File: node.file, Line: node.line}
+
+		if hop.call != nil {
+			step.Line = hop.call.line
+			step.Dynamic = hop.call.dynamic
+			path.Dynamic = path.Dynamic || hop.call.dynamic
+		}
+
+		path.Steps = append(path.Steps, step)
+	}
+
+	return path
+}
@@ -0,0 +1,123 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const callPathSource = `package lang
+
+type Store interface{ Save(v int) error }
+
+type memStore struct{}
+
+func (memStore) Save(v int) error { return persist(v) }
+
+func persist(v int) error { return nil }
+
+func HandleRequest(s Store) error {
+	return validate(s, 1)
+}
+
+func validate(s Store, v int) error {
+	return store(s, v)
+}
+
+func store(s Store, v int) error {
+	return s.Save(v)
+}
+
+func Render() {
+	header()
+	footer()
+}
+
+func header() { write() }
+
+func footer() { write() }
+
+func write() {}
+`
+
+// renderCallPath renders a path as "fn:line -> fn:line ...", with a "*" after dynamic steps.
+func renderCallPath(path tools.CallPath) string {
+	steps := make([]string, 0, len(path.Steps))
+
+	for _, step := range path.Steps {
+		s := fmt.Sprintf("%s:%d", step.Function, step.Line)
+		if step.Dynamic {
+			s += "*"
+		}
+
+		steps = append(steps, s)
+	}
+
+	return strings.Join(steps, " -> ")
+}
+
+func TestFindCallPath(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": callPathSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	cases := []struct {
+		from, to string
+		maxDepth int
+		maxPaths int
+		want     []string
+	}{
+		// Three direct hops, then an interface call resolved to memStore.Save.
+		{"HandleRequest", "persist", 0, 0, []string{
+			"HandleRequest:12 -> validate:16 -> store:20* -> memStore.Save:7 -> persist:9",
+		}},
+		{"Render", "write", 0, 0, []string{
+			"Render:24 -> header:28 -> write:32",
+			"Render:25 -> footer:30 -> write:32",
+		}},
+		{"Render", "write", 0, 1, []string{"Render:24 -> header:28 -> write:32"}},
+		{"HandleRequest", "persist", 3, 0, nil},
+		{"persist", "HandleRequest", 0, 0, nil},
+	}
+
+	for _, tc := range cases {
+		in := tools.FindCallPathInput{Dir: dir, From: tc.from, To: tc.to, MaxDepth: tc.maxDepth, MaxPaths: tc.maxPaths}
+
+		_, out, err := tools.FindCallPath(ctx, req, in)
+		if err != nil {
+			t.Fatalf("FindCallPath(%s, %s): %v", tc.from, tc.to, err)
+		}
+
+		var got []string
+		for _, path := range out.Paths {
+			got = append(got, renderCallPath(path))
+		}
+
+		if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+			t.Errorf("%s -> %s (depth %d, paths %d) = %q, want %q", tc.from, tc.to, tc.maxDepth, tc.maxPaths, got, tc.want)
+		}
+
+		if len(tc.want) == 0 && !strings.Contains(out.Message, "no static path within depth") {
+			t.Errorf("%s -> %s: message = %q, want the unreachable note", tc.from, tc.to, out.Message)
+		}
+	}
+
+	_, out, err := tools.FindCallPath(ctx, req, tools.FindCallPathInput{Dir: dir, From: "HandleRequest", To: "persist"})
+	if err != nil || len(out.Paths) != 1 {
+		t.Fatalf("FindCallPath: %+v, %v", out, err)
+	}
+
+	if path := out.Paths[0]; path.Length != 4 || !path.Dynamic || path.Steps[0].File != "lang.go" || path.Steps[0].Package != "lang" {
+		t.Errorf("path = %+v, want 4 calls through an interface from lang.go", path)
+	}
+
+	_, _, err = tools.FindCallPath(ctx, req, tools.FindCallPathInput{Dir: dir, From: "HandleRequest", To: "missing"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound {
+		t.Errorf("expected NOT_FOUND for an unknown function, got %v", err)
+	}
+}
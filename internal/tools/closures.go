@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// declComplexity returns the complexity entries of a function declaration: the function itself, followed
+// by one entry per function literal in its body, named the way the runtime names closures (F.func1,
+// F.func2 in source order, F.func1.1 for a literal nested in F.func1). Every entry measures its own
+// statements only: the lines, nesting and branches of a literal count towards the literal, not towards
+// the function or literal enclosing it. The declaration's entry carries its symbol ID in the package pkgPath.
+func declComplexity(ctx context.Context, fset *token.FileSet, pkgPath string, fd *ast.FuncDecl) []FunctionComplexity {
+	if fd == nil || fd.Body == nil {
+		return nil
+	}
+
+	receiver := receiverName(fd)
+
+	fc := bodyComplexity(ctx, fset, fd, fd.Body)
+	fc.Name, fc.Receiver = fd.Name.Name, receiver
+	fc.SymbolID = syntaxSymbolID(pkgPath, receiver, fd.Name.Name, "func")
+
+	entries := []FunctionComplexity{fc}
+
+	var collect func(body *ast.BlockStmt, prefix string)
+
+	collect = func(body *ast.BlockStmt, prefix string) {
+		for i, lit := range directFuncLits(body) {
+			name := prefix + strconv.Itoa(i+1)
+
+			fc := bodyComplexity(ctx, fset, lit, lit.Body)
+			fc.Name, fc.Receiver, fc.Closure = name, receiver, true
+			entries = append(entries, fc)
+
+			collect(lit.Body, name+".")
+		}
+	}
+
+	collect(fd.Body, fd.Name.Name+".func")
+
+	return entries
+}
+
+// bodyComplexity measures the function node with the given body, leaving out the function literals it
+// contains. Their lines are subtracted from the span, so the line holding a literal still counts.
+func bodyComplexity(ctx context.Context, fset *token.FileSet, node ast.Node, body *ast.BlockStmt) FunctionComplexity {
+	spanLines := func(n ast.Node) int {
+		return max(0, fset.Position(n.End()).Line-fset.Position(n.Pos()).Line)
+	}
+
+	lines := spanLines(node)
+	for _, lit := range directFuncLits(body) {
+		lines -= spanLines(lit)
+	}
+
+	visitor := &ComplexityVisitor{Ctx: ctx, Fset: fset, Cyclomatic: 1, SkipFuncLits: true}
+	ast.Walk(visitor, body)
+
+	cognitive := &cognitiveCounter{skipFuncLits: true}
+	cognitive.walk(body, 0)
+
+	return FunctionComplexity{
+		Line:       fset.Position(node.Pos()).Line,
+		Lines:      lines,
+		Nesting:    visitor.MaxNesting,
+		Cyclomatic: visitor.Cyclomatic,
+		Cognitive:  cognitive.score,
+	}
+}
+
+// directFuncLits returns the function literals of body in source order, without those nested in another
+// literal.
+func directFuncLits(body *ast.BlockStmt) []*ast.FuncLit {
+	var lits []*ast.FuncLit
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			lits = append(lits, lit)
+
+			return false
+		}
+
+		return true
+	})
+
+	return lits
+}
@@ -0,0 +1,82 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const closuresSource = `package lang
+
+func Process(items []int) int {
+	total := 0
+
+	sum := func(xs []int) int {
+		n := 0
+		for _, x := range xs {
+			if x > 0 {
+				n += x
+			}
+		}
+
+		return n
+	}
+
+	check := func(x int) bool {
+		switch {
+		case x < 0:
+			return false
+		case x > 100:
+			return false
+		}
+
+		inner := func() bool { return x%2 == 0 }
+
+		return inner()
+	}
+
+	if check(len(items)) {
+		total = sum(items)
+	}
+
+	return total
+}
+`
+
+func TestAnalyzeComplexity_Closures(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": closuresSource})
+
+	_, out, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{}, tools.AnalyzeComplexityInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	if len(out.Functions) != 1 {
+		t.Fatalf("expected one file, got %+v", out.Functions)
+	}
+
+	want := []tools.FunctionComplexityInfo{
+		// The closures' branches and lines are not counted in Process: only its own if remains.
+		{Name: "Process", Line: 3, Lines: 12, Nesting: 1, Cyclomatic: 2, Cognitive: 1},
+		{Name: "Process.func1", Line: 6, Lines: 9, Nesting: 2, Cyclomatic: 3, Cognitive: 3, Closure: true},
+		{Name: "Process.func2", Line: 17, Lines: 11, Nesting: 1, Cyclomatic: 4, Cognitive: 1, Closure: true},
+		{Name: "Process.func2.1", Line: 25, Lines: 0, Nesting: 0, Cyclomatic: 1, Cognitive: 0, Closure: true},
+	}
+
+	got := out.Functions[0].Functions
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), got)
+	}
+
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Line != want[i].Line || got[i].Lines != want[i].Lines ||
+			got[i].Nesting != want[i].Nesting || got[i].Cyclomatic != want[i].Cyclomatic ||
+			got[i].Cognitive != want[i].Cognitive || got[i].Closure != want[i].Closure {
+			t.Errorf("entry %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadFlights holds the package loads in progress by cache key, so that concurrent requests for the
+// same load wait for the one running instead of starting their own. coalesced counts the requests
+// answered that way.
+var loadFlights = struct {
+	sync.Mutex
+
+	calls     map[string]*loadFlight
+	coalesced int
+}{calls: make(map[string]*loadFlight)}
+
+// loadFlight is one package load in progress; done is closed once its result is set.
+type loadFlight struct {
+	done        chan struct{}
+	pkgs        []*packages.Package
+	diagnostics []string
+	err         error
+}
+
+// errLoadAborted is the result of a load that ended without returning, e.g. by a panic.
+var errLoadAborted = errors.New("package load aborted")
+
+// coalesceLoad runs load for key unless a load for key is already in progress, in which case it waits
+// for that one and returns its result with shared set. A caller whose context ends stops waiting; a
+// shared load cancelled by the context of the caller that started it is run again for the callers
+// still waiting.
+func coalesceLoad(
+	ctx context.Context,
+	key string,
+	load func() ([]*packages.Package, []string, error),
+) (
+	[]*packages.Package,
+	[]string,
+	bool,
+	error,
+) {
+	loadFlights.Lock()
+
+	if flight, ok := loadFlights.calls[key]; ok {
+		loadFlights.coalesced++
+		loadFlights.Unlock()
+
+		select {
+		case <-flight.done:
+		case <-ctx.Done():
+			return nil, nil, true, NewToolError(CodeCancelled, ctx.Err())
+		}
+
+		if te := AsToolError(flight.err); te != nil && te.Code == CodeCancelled && ctx.Err() == nil {
+			return coalesceLoad(ctx, key, load)
+		}
+
+		return flight.pkgs, flight.diagnostics, true, flight.err
+	}
+
+	flight := &loadFlight{done: make(chan struct{}), err: errLoadAborted}
+	loadFlights.calls[key] = flight
+	loadFlights.Unlock()
+
+	defer func() {
+		loadFlights.Lock()
+		delete(loadFlights.calls, key)
+		loadFlights.Unlock()
+		close(flight.done)
+	}()
+
+	flight.pkgs, flight.diagnostics, flight.err = load()
+
+	return flight.pkgs, flight.diagnostics, false, flight.err
+}
@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// writeCoalesceModule writes a module without imports declaring the function A.
+func writeCoalesceModule(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"go.mod": "module coalesce\n\ngo 1.22\n",
+		"a.go":   "package coalesce\n\nfunc A() {}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+// Not parallel: the test counts the loads of the shared package cache.
+func TestCoalesceLoad_ConcurrentListSymbols(t *testing.T) {
+	dir := writeCoalesceModule(t)
+
+	packageCache.RLock()
+	misses := packageCache.misses
+	packageCache.RUnlock()
+
+	const calls = 8
+
+	var wg sync.WaitGroup
+
+	start := make(chan struct{})
+	errs := make(chan error, calls)
+
+	for range calls {
+		wg.Go(func() {
+			<-start
+
+			_, out, err := ListSymbols(context.Background(), &mcp.CallToolRequest{}, ListSymbolsInput{Dir: dir})
+			if err == nil && len(out.GroupedSymbols) != 1 {
+				err = errors.New("symbols not grouped under one package")
+			}
+
+			errs <- err
+		})
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("ListSymbols: %v", err)
+		}
+	}
+
+	packageCache.RLock()
+	loads := packageCache.misses - misses
+	packageCache.RUnlock()
+
+	if loads != 1 {
+		t.Errorf("%d concurrent ListSymbols ran %d loads, want 1", calls, loads)
+	}
+}
+
+// Not parallel: the test waits for the shared count of coalesced requests.
+func TestCoalesceLoad_SharesRunningLoad(t *testing.T) {
+	key := t.Name()
+
+	loadFlights.Lock()
+	coalesced := loadFlights.coalesced
+	loadFlights.Unlock()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	want := []*packages.Package{{ID: "p"}}
+
+	runs := 0
+	load := func() ([]*packages.Package, []string, error) {
+		runs++
+		close(started)
+		<-release
+
+		return want, []string{"diag"}, nil
+	}
+
+	type result struct {
+		pkgs   []*packages.Package
+		shared bool
+		err    error
+	}
+
+	leader := make(chan result, 1)
+
+	go func() {
+		pkgs, _, shared, err := coalesceLoad(context.Background(), key, load)
+		leader <- result{pkgs, shared, err}
+	}()
+
+	<-started
+
+	follower := make(chan result, 1)
+
+	go func() {
+		pkgs, diags, shared, err := coalesceLoad(context.Background(), key, func() ([]*packages.Package, []string, error) {
+			return nil, nil, errors.New("second load")
+		})
+		if len(diags) != 1 {
+			err = errors.New("diagnostics not shared")
+		}
+
+		follower <- result{pkgs, shared, err}
+	}()
+
+	// Cancelled waiters stop waiting without affecting the load.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, _, err := coalesceLoad(cancelled, key, load); AsToolError(err) == nil || AsToolError(err).Code != CodeCancelled {
+		t.Errorf("cancelled waiter: %v, want CANCELLED", err)
+	}
+
+	// Wait for the follower to join the load besides the cancelled waiter.
+	for {
+		loadFlights.Lock()
+		joined := loadFlights.coalesced - coalesced
+		loadFlights.Unlock()
+
+		if joined >= 2 {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+
+	if r := <-leader; r.err != nil || r.shared || r.pkgs[0] != want[0] {
+		t.Errorf("leader = %+v, want the packages, not shared", r)
+	}
+
+	if r := <-follower; r.err != nil || !r.shared || r.pkgs[0] != want[0] {
+		t.Errorf("follower = %+v, want the leader's packages, shared", r)
+	}
+
+	if runs != 1 {
+		t.Errorf("load ran %d times, want 1", runs)
+	}
+}
@@ -0,0 +1,317 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// unownedKey is the byOwner key counting the findings in files no rule assigns an owner to.
+const unownedKey = "(unowned)"
+
+// ownersFile is the CODEOWNERS file set by ConfigureOwnersFile; calls without an ownersFile input use it.
+var ownersFile struct {
+	sync.RWMutex
+
+	path string
+}
+
+// ConfigureOwnersFile sets the CODEOWNERS file the analysis tools resolve file owners from when a call
+// does not name one. An empty path disables ownership enrichment by default.
+//
+// Parameters:
+//   - path: CODEOWNERS-format file
+//
+// Returns:
+//   - error if the file cannot be read or has an invalid pattern
+func ConfigureOwnersFile(path string) error {
+	if path != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		if _, err := loadCodeOwners(abs); err != nil {
+			return err
+		}
+
+		path = abs
+	}
+
+	ownersFile.Lock()
+	ownersFile.path = path
+	ownersFile.Unlock()
+
+	return nil
+}
+
+// codeOwners is a parsed CODEOWNERS file. Patterns are relative to root: the directory of the file,
+// or the directory above it when the file is in .github, .gitlab or docs.
+type codeOwners struct {
+	root  string
+	rules []ownerRule
+}
+
+// ownerRule is one line of a CODEOWNERS file.
+type ownerRule struct {
+	// segments - slash-separated pattern elements; "**" matches any number of directories
+	segments []string
+	// dirOnly - the pattern ended in a slash and matches directories only
+	dirOnly bool
+	// owners - owners assigned by the rule; none leaves the matched files unowned
+	owners []string
+}
+
+// loadCodeOwners reads and parses the CODEOWNERS file at path.
+func loadCodeOwners(path string) (*codeOwners, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root := filepath.Dir(path)
+	switch filepath.Base(root) {
+	case ".github", ".gitlab", "docs":
+		root = filepath.Dir(root)
+	}
+
+	rules, err := parseCodeOwners(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &codeOwners{root: root, rules: rules}, nil
+}
+
+// parseCodeOwners parses CODEOWNERS lines of the form "pattern owner...". Comments, blank lines and
+// GitLab section headers are skipped.
+func parseCodeOwners(data []byte) ([]ownerRule, error) {
+	var rules []ownerRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, " #"); i >= 0 {
+			text = text[:i]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") ||
+			strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+			continue
+		}
+
+		rule, err := parseOwnerPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rule.owners = fields[1:]
+		rules = append(rules, rule)
+	}
+
+	return rules, scanner.Err()
+}
+
+// parseOwnerPattern compiles a CODEOWNERS path pattern. As in .gitignore, a pattern with a slash
+// before its last character is anchored at the root, and one without matches at any depth.
+func parseOwnerPattern(pattern string) (ownerRule, error) {
+	var rule ownerRule
+
+	trimmed := strings.TrimSuffix(pattern, "/")
+	rule.dirOnly = trimmed != pattern
+
+	if strings.Trim(trimmed, "/") == "" {
+		return rule, fmt.Errorf("empty pattern %q", pattern)
+	}
+
+	if !strings.Contains(trimmed, "/") {
+		trimmed = "**/" + trimmed
+	}
+
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	rule.segments = strings.Split(trimmed, "/")
+	for _, seg := range rule.segments {
+		if _, err := path.Match(seg, ""); err != nil {
+			return rule, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	return rule, nil
+}
+
+// matches reports whether the rule applies to the file at relPath, a slash-separated path relative to
+// the root. A pattern matching a directory applies to every file below it, except that a final "*"
+// only matches the files directly inside its directory.
+func (r ownerRule) matches(relPath string) bool {
+	elems := strings.Split(relPath, "/")
+
+	if !r.dirOnly && matchOwnerSegments(r.segments, elems) {
+		return true
+	}
+
+	if r.segments[len(r.segments)-1] == "*" {
+		return false
+	}
+
+	for i := len(elems) - 1; i > 0; i-- {
+		if matchOwnerSegments(r.segments, elems[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchOwnerSegments matches path elements against pattern segments, "**" standing for zero or more elements.
+func matchOwnerSegments(segments, elems []string) bool {
+	if len(segments) == 0 {
+		return len(elems) == 0
+	}
+
+	if segments[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchOwnerSegments(segments[1:], elems[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(elems) == 0 {
+		return false
+	}
+
+	ok, _ := path.Match(segments[0], elems[0])
+
+	return ok && matchOwnerSegments(segments[1:], elems[1:])
+}
+
+// owners returns the owners of the file at path, absolute or relative to the root. The last matching
+// rule wins, as GitHub and GitLab resolve CODEOWNERS, so more specific rules go further down the file.
+func (c *codeOwners) owners(file string) []string {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(c.root, file)
+	}
+
+	rel, err := filepath.Rel(c.root, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+
+	rel = filepath.ToSlash(rel)
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].matches(rel) {
+			return c.rules[i].owners
+		}
+	}
+
+	return nil
+}
+
+// ownership resolves the owners of the files in a tool result and counts findings per owner.
+// A nil ownership resolves nothing, so tools call it unconditionally.
+type ownership struct {
+	owners *codeOwners
+	dir    string
+	// files - resolved owner per relative path
+	files map[string]string
+	// byOwner - finding counts per owner (only with groupByOwner)
+	byOwner map[string]int
+}
+
+// newOwnership prepares the ownership enrichment of a tool call. file is the ownersFile input, relative
+// to dir unless absolute; when empty the file set by ConfigureOwnersFile is used. The result is nil when
+// neither names a file, which groupByOwner rejects.
+func newOwnership(dir, file string, groupByOwner bool) (*ownership, error) {
+	if file == "" {
+		ownersFile.RLock()
+		file = ownersFile.path
+		ownersFile.RUnlock()
+	} else if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+
+	if file == "" {
+		if groupByOwner {
+			return nil, invalidInput("groupByOwner needs an ownersFile (input or --owners-file)")
+		}
+
+		return nil, nil
+	}
+
+	owners, err := loadCodeOwners(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, notFound(nil, "ownersFile %q not found", file)
+		}
+
+		return nil, invalidInput("invalid ownersFile: %v", err)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &ownership{owners: owners, dir: absDir, files: make(map[string]string)}
+	if groupByOwner {
+		o.byOwner = make(map[string]int)
+	}
+
+	return o, nil
+}
+
+// owner returns the owners of the file at relPath, relative to the tool's dir, joined by spaces as
+// written in the CODEOWNERS file; empty for an unowned file.
+func (o *ownership) owner(relPath string) string {
+	if o == nil {
+		return ""
+	}
+
+	if owner, ok := o.files[relPath]; ok {
+		return owner
+	}
+
+	file := relPath
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(o.dir, filepath.FromSlash(file))
+	}
+
+	owner := strings.Join(o.owners.owners(file), " ")
+	o.files[relPath] = owner
+
+	return owner
+}
+
+// count adds n findings to owner, or to unownedKey when owner is empty.
+func (o *ownership) count(owner string, n int) {
+	if o == nil || o.byOwner == nil || n == 0 {
+		return
+	}
+
+	if owner == "" {
+		owner = unownedKey
+	}
+
+	o.byOwner[owner] += n
+}
+
+// counts returns the finding counts per owner, nil without groupByOwner.
+func (o *ownership) counts() map[string]int {
+	if o == nil {
+		return nil
+	}
+
+	return o.byOwner
+}
@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCodeOwners(t *testing.T) {
+	t.Parallel()
+
+	rules, err := parseCodeOwners([]byte(`# Default owners of everything.
+*                   @org/core
+
+*.md                @docs-team # inline comment
+/build/logs/        @ops
+apps/               @apps-team
+docs/*              @writers
+/cmd/**/main.go     @cli
+internal/legacy     @archivists
+
+[Section]
+/internal/legacy/keep.go
+`))
+	if err != nil {
+		t.Fatalf("parseCodeOwners: %v", err)
+	}
+
+	owners := &codeOwners{root: "/repo", rules: rules}
+
+	for _, tc := range []struct {
+		file string
+		want string
+	}{
+		// The * fallback owns every file no later rule matches.
+		{"main.go", "@org/core"},
+		{"pkg/deep/file.go", "@org/core"},
+		// Unanchored patterns match at any depth; later rules win over earlier ones.
+		{"README.md", "@docs-team"},
+		{"pkg/notes/CHANGES.md", "@docs-team"},
+		// A directory pattern owns everything below it, at the root when anchored.
+		{"build/logs/today/run.log", "@ops"},
+		{"src/build/logs/run.log", "@org/core"},
+		{"apps/web/app.go", "@apps-team"},
+		{"services/apps/api.go", "@apps-team"},
+		// A trailing slash matches directories only.
+		{"apps", "@org/core"},
+		// A final * matches the files of its directory but not those of subdirectories.
+		{"docs/intro.go", "@writers"},
+		{"docs/guide/intro.go", "@org/core"},
+		{"docs/intro.md", "@writers"},
+		// ** spans any number of directories, none included.
+		{"cmd/main.go", "@cli"},
+		{"cmd/tool/sub/main.go", "@cli"},
+		{"cmd/tool/helper.go", "@org/core"},
+		// A pattern with a middle slash is anchored and also matches a directory.
+		{"internal/legacy/old.go", "@archivists"},
+		{"x/internal/legacy/old.go", "@org/core"},
+		// A rule without owners unowns its files.
+		{"internal/legacy/keep.go", ""},
+		// Files outside the root have no owner.
+		{"../elsewhere/main.go", ""},
+	} {
+		if got := strings.Join(owners.owners(tc.file), " "); got != tc.want {
+			t.Errorf("owners(%q) = %q, want %q", tc.file, got, tc.want)
+		}
+	}
+
+	if _, err := parseCodeOwners([]byte("src/[a-  @team\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected an invalid pattern error on line 1, got %v", err)
+	}
+}
+
+func TestLoadCodeOwnersRoot(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".github", "CODEOWNERS"), []byte("/pkg/ @pkg-team\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := newOwnership(filepath.Join(dir, "pkg"), "../.github/CODEOWNERS", true)
+	if err != nil {
+		t.Fatalf("newOwnership: %v", err)
+	}
+
+	// Patterns are relative to the directory above .github, file paths to the tool's dir.
+	if got := o.owner("a.go"); got != "@pkg-team" {
+		t.Errorf("owner(a.go) = %q, want @pkg-team", got)
+	}
+
+	o.count(o.owner("a.go"), 2)
+	o.count("", 1)
+
+	if got := o.counts(); got["@pkg-team"] != 2 || got[unownedKey] != 1 {
+		t.Errorf("counts = %v, want 2 for @pkg-team and 1 unowned", got)
+	}
+
+	if _, err := newOwnership(dir, "", true); err == nil {
+		t.Error("expected groupByOwner without an ownersFile to fail")
+	}
+}
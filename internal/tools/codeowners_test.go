@@ -0,0 +1,82 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestDeadCodeOwners(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		".github/CODEOWNERS": `*                 @org/core
+/store/           @storage
+store/cache.go    @caching
+*_gen.go
+`,
+		"store/store.go":     "package store\n\nfunc unusedStore() {}\n",
+		"store/cache.go":     "package store\n\nfunc unusedCache() {}\n",
+		"store/model_gen.go": "package store\n\nfunc unusedGenerated() {}\n",
+		"api/api.go":         "package api\n\nfunc unusedAPI() {}\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.DeadCode(ctx, req, tools.DeadCodeInput{
+		Dir:          dir,
+		OwnersFile:   ".github/CODEOWNERS",
+		GroupByOwner: true,
+	})
+	if err != nil {
+		t.Fatalf("DeadCode: %v", err)
+	}
+
+	owners := make(map[string]string)
+	for _, sym := range out.Unused {
+		owners[sym.Name] = sym.Owner
+	}
+
+	want := map[string]string{
+		"unusedStore":     "@storage",
+		"unusedCache":     "@caching",
+		"unusedGenerated": "",
+		"unusedAPI":       "@org/core",
+	}
+	if fmt.Sprint(owners) != fmt.Sprint(want) {
+		t.Errorf("owners = %v, want %v", owners, want)
+	}
+
+	wantCounts := map[string]int{"@storage": 1, "@caching": 1, "@org/core": 1, "(unowned)": 1}
+	if fmt.Sprint(out.ByOwner) != fmt.Sprint(wantCounts) {
+		t.Errorf("byOwner = %v, want %v", out.ByOwner, wantCounts)
+	}
+
+	// Without groupByOwner the owners are still resolved but not counted.
+	_, out, err = tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, OwnersFile: ".github/CODEOWNERS"})
+	if err != nil {
+		t.Fatalf("DeadCode: %v", err)
+	}
+
+	for _, sym := range out.Unused {
+		if sym.Owner != want[sym.Name] {
+			t.Errorf("%s: owner %q, want %q", sym.Name, sym.Owner, want[sym.Name])
+		}
+	}
+
+	if out.ByOwner != nil {
+		t.Errorf("byOwner = %v, want none without groupByOwner", out.ByOwner)
+	}
+
+	_, _, err = tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, OwnersFile: "OWNERS"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound {
+		t.Errorf("expected NOT_FOUND for a missing ownersFile, got %v", err)
+	}
+
+	_, _, err = tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, GroupByOwner: true})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for groupByOwner without an ownersFile, got %v", err)
+	}
+}
@@ -0,0 +1,439 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Sources of configuration reads reported by analyzeConfigSurface.
+const (
+	configSourceEnv    = "env"
+	configSourceFlag   = "flag"
+	configSourceViper  = "viper"
+	configSourceCustom = "custom"
+)
+
+// configFunc is a pattern of functions reading configuration and the argument holding the key;
+// keyArg -1 takes the first string constant argument.
+type configFunc struct {
+	pattern string
+	source  string
+	keyArg  int
+}
+
+// knownConfigFuncs are the configuration reads recognized without extraFunctions. Patterns are matched
+// with path.Match against qualified names: pkg.Func for functions and pkg.Type.Method for methods.
+var knownConfigFuncs = []configFunc{
+	{pattern: "os.Getenv", source: configSourceEnv},
+	{pattern: "os.LookupEnv", source: configSourceEnv},
+	{pattern: "syscall.Getenv", source: configSourceEnv},
+	// Functions and FlagSet methods alike; XVar(&p, name, ...) and Var(value, name, usage) take the name second.
+	{pattern: "flag.*Var", source: configSourceFlag, keyArg: 1},
+	{pattern: "flag.*", source: configSourceFlag},
+	{pattern: "github.com/spf13/viper.Get*", source: configSourceViper},
+	{pattern: "github.com/spf13/viper.Viper.Get*", source: configSourceViper},
+}
+
+// flagDefiners are the flag package functions defining a flag; other flag functions (Parse, Args, ...)
+// read no key.
+var flagDefiners = map[string]struct{}{
+	"Bool": {}, "BoolVar": {}, "BoolFunc": {}, "Duration": {}, "DurationVar": {}, "Float64": {},
+	"Float64Var": {}, "Func": {}, "Int": {}, "IntVar": {}, "Int64": {}, "Int64Var": {}, "String": {},
+	"StringVar": {}, "TextVar": {}, "Uint": {}, "UintVar": {}, "Uint64": {}, "Uint64Var": {}, "Var": {},
+}
+
+// configParsers convert a raw string value; the type of their first result is the parsed type.
+var configParsers = map[string]struct{}{
+	"strconv.Atoi":       {},
+	"strconv.ParseBool":  {},
+	"strconv.ParseInt":   {},
+	"strconv.ParseUint":  {},
+	"strconv.ParseFloat": {},
+	"time.ParseDuration": {},
+}
+
+// AnalyzeConfigSurface inventories the configuration a module reads: environment variables, flags,
+// viper keys and calls matching extraFunctions, grouped by key with every read location and the Go type
+// the value ends up as.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter and extra read functions
+//
+// Returns:
+//   - MCP tool call result
+//   - reads grouped by key, plus the reads whose key is not a constant
+//   - error if an extra pattern is malformed or packages cannot be loaded
+func AnalyzeConfigSurface(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeConfigSurfaceInput) (
+	*mcp.CallToolResult,
+	AnalyzeConfigSurfaceOutput,
+	error,
+) {
+	start := logStart("AnalyzeConfigSurface", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("extraFunctions", strings.Join(input.ExtraFunctions, ",")),
+	))
+	out := AnalyzeConfigSurfaceOutput{}
+
+	defer func() { logEnd("AnalyzeConfigSurface", start, out.Total) }()
+
+	var funcs []configFunc
+
+	for _, pattern := range input.ExtraFunctions {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return fail(out, invalidInput("invalid extraFunctions pattern %q", pattern))
+		}
+
+		funcs = append(funcs, configFunc{pattern: pattern, source: configSourceCustom, keyArg: -1})
+	}
+
+	// Extra patterns take precedence over the built-in ones.
+	funcs = append(funcs, knownConfigFuncs...)
+
+	mode := loadModeSyntaxTypesNamed
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeConfigSurface")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	byKey := make(map[string]*ConfigKey)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			funcName := ""
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				funcName = qualifiedFuncName(fd)
+			}
+
+			for _, read := range collectConfigReads(pkg, decl, funcs) {
+				read.File = relPath
+				read.Line = pkg.Fset.Position(read.call.Pos()).Line
+				read.Function = funcName
+				out.Total++
+
+				if !read.constKey {
+					out.DynamicReads = append(out.DynamicReads, read.ConfigRead)
+
+					continue
+				}
+
+				entry, ok := byKey[read.key]
+				if !ok {
+					entry = &ConfigKey{Key: read.key}
+					byKey[read.key] = entry
+				}
+
+				entry.Reads = append(entry.Reads, read.ConfigRead)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, key := range sortedKeys(byKey) {
+		entry := byKey[key]
+		sortConfigReads(entry.Reads)
+
+		sources := make(map[string]struct{})
+		valueTypes := make(map[string]struct{})
+
+		for _, r := range entry.Reads {
+			sources[r.Source] = struct{}{}
+
+			if r.Type != "" {
+				valueTypes[r.Type] = struct{}{}
+			}
+		}
+
+		entry.Sources = sortedKeys(sources)
+		entry.Types = sortedKeys(valueTypes)
+		out.Keys = append(out.Keys, *entry)
+	}
+
+	sortConfigReads(out.DynamicReads)
+
+	return nil, out, nil
+}
+
+// configReadSite is a configuration read with its call and resolved key.
+type configReadSite struct {
+	ConfigRead
+
+	call     *ast.CallExpr
+	key      string
+	constKey bool
+}
+
+// collectConfigReads finds the calls of decl matching funcs, resolving their keys and value types.
+func collectConfigReads(pkg *packages.Package, decl ast.Decl, funcs []configFunc) []configReadSite {
+	info := pkg.TypesInfo
+	qualifier := types.RelativeTo(pkg.Types)
+
+	var (
+		result []configReadSite
+		stack  []ast.Node
+	)
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+
+			return true
+		}
+
+		stack = append(stack, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		fn := calledFunc(info, call)
+		if fn == nil || fn.Pkg() == nil {
+			return true
+		}
+
+		name := qualifiedObjectName(fn)
+
+		cf, ok := matchConfigFunc(funcs, fn, name)
+		if !ok {
+			return true
+		}
+
+		site := configReadSite{call: call}
+		site.Source = cf.source
+		site.Call = fn.Pkg().Name() + "." + strings.TrimPrefix(name, fn.Pkg().Path()+".")
+
+		keyExpr := configKeyArg(info, call, cf.keyArg)
+		if keyExpr != nil {
+			site.key, site.constKey = constantString(info, keyExpr)
+			if !site.constKey {
+				site.KeyExpr = types.ExprString(keyExpr)
+			}
+		}
+
+		site.Type = configValueType(info, fn, call, cf, stack, decl, qualifier)
+		result = append(result, site)
+
+		return true
+	})
+
+	return result
+}
+
+// qualifiedObjectName names a function pkg.Func and a method pkg.Type.Method.
+func qualifiedObjectName(fn *types.Func) string {
+	if recv := fn.Signature().Recv(); recv != nil {
+		t := recv.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+
+		if named, ok := types.Unalias(t).(*types.Named); ok {
+			return fn.Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+		}
+	}
+
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// matchConfigFunc returns the first of funcs matching a called function.
+func matchConfigFunc(funcs []configFunc, fn *types.Func, name string) (configFunc, bool) {
+	for _, cf := range funcs {
+		if ok, _ := path.Match(cf.pattern, name); !ok {
+			continue
+		}
+
+		if cf.source == configSourceFlag {
+			if _, ok := flagDefiners[fn.Name()]; !ok {
+				return configFunc{}, false
+			}
+		}
+
+		return cf, true
+	}
+
+	return configFunc{}, false
+}
+
+// configKeyArg returns the argument holding the key: argument keyArg, or the first string constant
+// argument when keyArg is -1 (the first argument if there is none, so its expression is reported).
+func configKeyArg(info *types.Info, call *ast.CallExpr, keyArg int) ast.Expr {
+	if keyArg >= 0 {
+		if keyArg < len(call.Args) {
+			return call.Args[keyArg]
+		}
+
+		return nil
+	}
+
+	for _, arg := range call.Args {
+		if _, ok := constantString(info, arg); ok {
+			return arg
+		}
+	}
+
+	if len(call.Args) > 0 {
+		return call.Args[0]
+	}
+
+	return nil
+}
+
+// constantString returns the value of a string constant expression, such as a literal or a named constant.
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+// configValueType returns the Go type a read value ends up as: the result of a parse call applied to it
+// directly or to the variable it is assigned to, otherwise the type the read function itself yields.
+func configValueType(info *types.Info, fn *types.Func, call *ast.CallExpr, cf configFunc, stack []ast.Node,
+	decl ast.Decl, qualifier types.Qualifier,
+) string {
+	if t := parsedType(info, call, stack, decl); t != nil {
+		return types.TypeString(t, qualifier)
+	}
+
+	sig := fn.Signature()
+
+	var t types.Type
+
+	switch {
+	case sig.Results().Len() > 0:
+		t = sig.Results().At(0).Type()
+	case cf.source == configSourceFlag && cf.keyArg == 1 && len(call.Args) > 0:
+		// XVar(&p, ...) stores into p.
+		t = info.TypeOf(call.Args[0])
+	}
+
+	if t == nil {
+		return ""
+	}
+
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	return types.TypeString(t, qualifier)
+}
+
+// parsedType returns the first result type of a parse call consuming the read: parse(read(...)), or
+// v := read(...) followed by parse(v) as the next use of v.
+func parsedType(info *types.Info, call *ast.CallExpr, stack []ast.Node, decl ast.Decl) types.Type {
+	parent := enclosingNonParen(stack)
+
+	if t := parseResult(info, parent, call); t != nil {
+		return t
+	}
+
+	var bound *ast.Ident
+
+	switch p := parent.(type) {
+	case *ast.AssignStmt:
+		if len(p.Rhs) == 1 && ast.Unparen(p.Rhs[0]) == call {
+			bound, _ = p.Lhs[0].(*ast.Ident)
+		}
+	case *ast.ValueSpec:
+		if len(p.Values) == 1 && ast.Unparen(p.Values[0]) == call && len(p.Names) > 0 {
+			bound = p.Names[0]
+		}
+	}
+
+	if bound == nil {
+		return nil
+	}
+
+	obj := info.ObjectOf(bound)
+	if obj == nil {
+		return nil
+	}
+
+	// The next use of the variable decides; anything but a parse call means the raw string is used.
+	var (
+		next       *ast.Ident
+		nextParent ast.Node
+		inner      []ast.Node
+	)
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if n == nil {
+			inner = inner[:len(inner)-1]
+
+			return true
+		}
+
+		inner = append(inner, n)
+
+		id, ok := n.(*ast.Ident)
+		if ok && id.Pos() > call.End() && info.Uses[id] == obj && (next == nil || id.Pos() < next.Pos()) {
+			next, nextParent = id, enclosingNonParen(inner)
+		}
+
+		return true
+	})
+
+	if next == nil {
+		return nil
+	}
+
+	return parseResult(info, nextParent, next)
+}
+
+// enclosingNonParen returns the closest node above the top of stack that is not a parenthesis.
+func enclosingNonParen(stack []ast.Node) ast.Node {
+	for i := len(stack) - 2; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.ParenExpr); !ok {
+			return stack[i]
+		}
+	}
+
+	return nil
+}
+
+// parseResult returns the first result type of node if it is a parse call taking arg first.
+func parseResult(info *types.Info, node ast.Node, arg ast.Expr) types.Type {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 || ast.Unparen(call.Args[0]) != arg {
+		return nil
+	}
+
+	fn := calledFunc(info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return nil
+	}
+
+	if _, ok := configParsers[fn.Pkg().Path()+"."+fn.Name()]; !ok || fn.Signature().Results().Len() == 0 {
+		return nil
+	}
+
+	return fn.Signature().Results().At(0).Type()
+}
+
+// sortConfigReads orders reads by file and line.
+func sortConfigReads(reads []ConfigRead) {
+	sort.SliceStable(reads, func(i, j int) bool {
+		if reads[i].File != reads[j].File {
+			return reads[i].File < reads[j].File
+		}
+
+		return reads[i].Line < reads[j].Line
+	})
+}
@@ -0,0 +1,117 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const configSource = `package lang
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"time"
+)
+
+const envTimeout = "APP_TIMEOUT"
+
+var verbose = flag.Bool("verbose", false, "log more")
+
+type settings struct {
+	port    int
+	timeout time.Duration
+	name    string
+}
+
+func load(fs *flag.FlagSet) settings {
+	var s settings
+
+	fs.StringVar(&s.name, "name", "app", "service name")
+
+	port, _ := strconv.Atoi(os.Getenv("APP_PORT"))
+	s.port = port
+
+	raw := os.Getenv(envTimeout)
+	s.timeout, _ = time.ParseDuration(raw)
+
+	if home, ok := os.LookupEnv("HOME"); ok {
+		s.name += home
+	}
+
+	s.name += lookup("APP_REGION", "eu")
+
+	return s
+}
+
+func lookup(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return fallback
+}
+`
+
+func TestAnalyzeConfigSurface(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"config.go": configSource})
+
+	_, out, err := tools.AnalyzeConfigSurface(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeConfigSurfaceInput{Dir: dir, ExtraFunctions: []string{"lang.lookup"}})
+	if err != nil {
+		t.Fatalf("AnalyzeConfigSurface error: %v", err)
+	}
+
+	keys := make(map[string]tools.ConfigKey)
+	names := make([]string, 0, len(out.Keys))
+
+	for _, k := range out.Keys {
+		keys[k.Key] = k
+		names = append(names, k.Key)
+	}
+
+	want := []string{"APP_PORT", "APP_REGION", "APP_TIMEOUT", "HOME", "name", "verbose"}
+	if !slices.Equal(names, want) || out.Total != 7 {
+		t.Fatalf("expected keys %v in 7 reads, got %v (%d reads)", want, names, out.Total)
+	}
+
+	checks := map[string]struct{ source, typ, function string }{
+		"APP_PORT":    {"env", "int", "load"},
+		"APP_TIMEOUT": {"env", "time.Duration", "load"},
+		"HOME":        {"env", "string", "load"},
+		"APP_REGION":  {"custom", "string", "load"},
+		"name":        {"flag", "string", "load"},
+		"verbose":     {"flag", "bool", ""},
+	}
+
+	for key, c := range checks {
+		r := keys[key].Reads[0]
+		if r.Source != c.source || r.Type != c.typ || r.Function != c.function || r.File != "config.go" {
+			t.Errorf("%s: expected %+v, got %+v", key, c, r)
+		}
+	}
+
+	if keys["name"].Reads[0].Call != "flag.FlagSet.StringVar" {
+		t.Errorf("unexpected call for name: %+v", keys["name"].Reads[0])
+	}
+
+	if len(out.DynamicReads) != 1 || out.DynamicReads[0].KeyExpr != "key" || out.DynamicReads[0].Function != "lookup" {
+		t.Fatalf("expected the os.Getenv(key) read as dynamic, got %+v", out.DynamicReads)
+	}
+}
+
+func TestAnalyzeConfigSurface_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.AnalyzeConfigSurface(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeConfigSurfaceInput{Dir: testDir(), ExtraFunctions: []string{"lang.[x"}})
+	if err == nil || tools.AsToolError(err).Code != tools.CodeInvalidInput {
+		t.Fatalf("expected INVALID_INPUT, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// hashContent returns the hex SHA-256 of a file's content, the contentHash reported by the read tools.
+func hashContent(src []byte) string {
+	sum := sha256.Sum256(src)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// fileContentHash returns the content hash of path through fileLinesCache, or "" if it cannot be read.
+func fileContentHash(path string) string {
+	item, ok := cachedFileContent(path)
+	if !ok {
+		return ""
+	}
+
+	return item.Hash
+}
+
+// checkExpectedHashes refuses a mutation when a file changed since the caller read it: every file of
+// expected, by path relative to dir, must still have the given content hash. A missing file is stale.
+// Mutating tools call it while holding the module's mutation lock, before they write anything.
+//
+// Returns:
+//   - nil if every hash matches or expected is empty
+//   - CONFLICT naming the stale files in its details otherwise
+func checkExpectedHashes(dir string, expected map[string]string) error {
+	var stale []string
+
+	for file, want := range expected {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, file)
+		}
+
+		if got := fileContentHash(path); !strings.EqualFold(got, want) || got == "" {
+			stale = append(stale, filepath.ToSlash(file))
+		}
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	sort.Strings(stale)
+
+	te := NewToolError(CodeConflict, fmt.Errorf(
+		"%s changed since it was read; read it again and retry with the new contentHash", strings.Join(stale, ", ")))
+	te.Details = &ToolErrorDetails{StaleFiles: stale}
+
+	return te
+}
+
+// expectedFileHash turns the expectedHash of a single-file tool into the map checkExpectedHashes takes.
+func expectedFileHash(file, hash string) map[string]string {
+	if hash == "" {
+		return nil
+	}
+
+	return map[string]string{file: hash}
+}
@@ -0,0 +1,95 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const contentHashSource = "package lang\n\nvar b = 2\n\nvar a = 1\n\nfunc Hello() string { return \"hello\" }\n"
+
+func TestContentHash_ReadToolsAgree(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": contentHashSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, fn, err := tools.ReadFunc(ctx, req, tools.ReadFuncInput{Dir: dir, Name: "Hello"})
+	if err != nil || fn.Function.ContentHash == "" {
+		t.Fatalf("expected a content hash from getFunctionSource, got %+v (err %v)", fn.Function, err)
+	}
+
+	_, file, err := tools.ReadGoFile(ctx, req, tools.ReadGoFileInput{Dir: dir, File: "lang.go"})
+	if err != nil || file.ContentHash != fn.Function.ContentHash {
+		t.Fatalf("expected getFileInfo to report hash %s, got %s (err %v)", fn.Function.ContentHash, file.ContentHash, err)
+	}
+
+	_, nav, err := tools.NavigateFile(ctx, req, tools.NavigateFileInput{Dir: dir, File: "lang.go", Direction: "first"})
+	if err != nil || nav.ContentHash != fn.Function.ContentHash {
+		t.Fatalf("expected navigateFile to report hash %s, got %s (err %v)", fn.Function.ContentHash, nav.ContentHash, err)
+	}
+}
+
+func TestContentHash_StaleFileRefusesMutation(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": contentHashSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+	path := filepath.Join(dir, "lang.go")
+
+	_, fn, err := tools.ReadFunc(ctx, req, tools.ReadFuncInput{Dir: dir, Name: "Hello"})
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	hash := fn.Function.ContentHash
+
+	// Another client edits the file between the read and the mutation.
+	edited := contentHashSource + "\n// edited concurrently\n"
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{
+		Dir: dir, OldName: "Hello", NewName: "Greet", Kind: "func",
+		ExpectedHashes: map[string]string{"lang.go": hash},
+	})
+	assertConflict(t, "renameSymbol", err, "lang.go")
+
+	_, _, err = tools.ReorderDeclarations(ctx, req, tools.ReorderDeclarationsInput{
+		Dir: dir, File: "lang.go", Policy: "std", ExpectedHash: hash,
+	})
+	assertConflict(t, "reorderDeclarations", err, "lang.go")
+
+	if got, _ := os.ReadFile(path); string(got) != edited {
+		t.Fatalf("expected the file untouched after the conflicts, got:\n%s", got)
+	}
+
+	// A fresh read gives the hash that lets the mutation through.
+	_, fn, err = tools.ReadFunc(ctx, req, tools.ReadFuncInput{Dir: dir, Name: "Hello"})
+	if err != nil || fn.Function.ContentHash == hash {
+		t.Fatalf("expected a new hash after the edit, got %s (err %v)", fn.Function.ContentHash, err)
+	}
+
+	_, out, err := tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{
+		Dir: dir, OldName: "Hello", NewName: "Greet", Kind: "func",
+		ExpectedHashes: map[string]string{"lang.go": fn.Function.ContentHash},
+	})
+	if err != nil || len(out.ChangedFiles) != 1 {
+		t.Fatalf("expected the rename to apply with the current hash, got %+v (err %v)", out, err)
+	}
+}
+
+func assertConflict(t *testing.T, tool string, err error, stale ...string) {
+	t.Helper()
+
+	te := tools.AsToolError(err)
+	if te == nil || te.Code != tools.CodeConflict || te.Details == nil || !slices.Equal(te.Details.StaleFiles, stale) {
+		t.Fatalf("%s: expected CONFLICT naming %v, got %+v", tool, stale, te)
+	}
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// defaultToolTimeout bounds a tool call whose request carries no deadline, unless ConfigureToolTimeout
+// changes it.
+const defaultToolTimeout = 90 * time.Second
+
+// cancelCheckInterval is the number of files walkPackageFiles visits between cancellation checks.
+const cancelCheckInterval = 16
+
+// toolTimeout holds the current default deadline of tool calls as a time.Duration; zero disables it.
+var toolTimeout atomic.Int64
+
+func init() {
+	toolTimeout.Store(int64(defaultToolTimeout))
+}
+
+// ConfigureToolTimeout sets the deadline applied to tool calls whose request has none. A call exceeding
+// it fails with CANCELLED and the progress made so far. Zero disables the default deadline.
+//
+// Parameters:
+//   - timeout: maximum duration of a tool call, 0 for none
+//
+// Returns:
+//   - error if timeout is negative
+func ConfigureToolTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("tool timeout must not be negative: %s", timeout)
+	}
+
+	toolTimeout.Store(int64(timeout))
+
+	return nil
+}
+
+// walkProgressKey is the context key of the counters installed by WithToolDeadline.
+type walkProgressKey struct{}
+
+// walkProgress counts the packages and files the walks of one tool call visited.
+type walkProgress struct {
+	packages atomic.Int64
+	files    atomic.Int64
+}
+
+// WithToolDeadline prepares the context of a tool call: it applies the configured default deadline when
+// ctx has none, and installs the walk counters CancellationError reports.
+//
+// Parameters:
+//   - ctx: context of the incoming request
+//
+// Returns:
+//   - the context to run the tool with
+//   - function releasing the deadline's resources
+func WithToolDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, walkProgressKey{}, &walkProgress{})
+
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	if timeout := time.Duration(toolTimeout.Load()); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// CancellationError adds the progress recorded in ctx to err when err is a CANCELLED tool error, so that
+// a client can tell a call that was stopped mid-analysis from one that never started. Other errors are
+// returned unchanged.
+//
+// Parameters:
+//   - ctx: context prepared by WithToolDeadline
+//   - err: error returned by the tool
+//
+// Returns:
+//   - the error to report
+func CancellationError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	te := AsToolError(err)
+	if te.Code != CodeCancelled {
+		return err
+	}
+
+	progress, ok := ctx.Value(walkProgressKey{}).(*walkProgress)
+	if !ok {
+		return te
+	}
+
+	if te.Details == nil {
+		te.Details = &ToolErrorDetails{}
+	}
+
+	te.Details.Progress = &ToolProgress{
+		PackagesWalked: int(progress.packages.Load()),
+		FilesWalked:    int(progress.files.Load()),
+	}
+
+	return te
+}
+
+// noteFileWalked records a visited file, and its package when it is the first file of one, in the
+// counters of ctx.
+func noteFileWalked(ctx context.Context, firstOfPackage bool) {
+	progress, ok := ctx.Value(walkProgressKey{}).(*walkProgress)
+	if !ok {
+		return
+	}
+
+	if firstOfPackage {
+		progress.packages.Add(1)
+	}
+
+	progress.files.Add(1)
+}
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// cancelAfterFiles is a context that reports cancellation once the walks of the call visited limit
+// files, which stops a walk at a deterministic point.
+type cancelAfterFiles struct {
+	context.Context
+
+	limit int64
+}
+
+func (c *cancelAfterFiles) Err() error {
+	if progress, ok := c.Value(walkProgressKey{}).(*walkProgress); ok && progress.files.Load() >= c.limit {
+		return context.Canceled
+	}
+
+	return c.Context.Err()
+}
+
+func TestWithToolDeadline(t *testing.T) {
+	ctx, cancel := WithToolDeadline(context.Background())
+	defer cancel()
+
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Duration(toolTimeout.Load()) {
+		t.Fatalf("expected the default tool deadline, got %v (set %v)", deadline, ok)
+	}
+
+	want := time.Now().Add(time.Hour)
+
+	parent, parentCancel := context.WithDeadline(context.Background(), want)
+	defer parentCancel()
+
+	ctx, cancel = WithToolDeadline(parent)
+	defer cancel()
+
+	if deadline, _ := ctx.Deadline(); !deadline.Equal(want) {
+		t.Fatalf("expected the request deadline %v to be kept, got %v", want, deadline)
+	}
+}
+
+func TestASTRewrite_CancelledMidWalk(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{"go.mod": "module lang\n\ngo 1.22\n"}
+
+	const total = 64
+	for i := range total {
+		files[fmt.Sprintf("f%02d.go", i)] = fmt.Sprintf("package lang\n\nvar v%d = 1 + 1\n", i)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	ctx, cancel := WithToolDeadline(context.Background())
+	defer cancel()
+
+	ctx = &cancelAfterFiles{Context: ctx, limit: 20}
+
+	start := time.Now()
+
+	_, _, err := ASTRewrite(ctx, &mcp.CallToolRequest{}, ASTRewriteInput{Dir: dir, Find: "1 + 1", Replace: "2"})
+
+	te := AsToolError(CancellationError(ctx, err))
+	if te == nil || te.Code != CodeCancelled {
+		t.Fatalf("expected CANCELLED, got %v", err)
+	}
+
+	if te.Details == nil || te.Details.Progress == nil {
+		t.Fatalf("expected progress details, got %+v", te)
+	}
+
+	// Cancellation is checked every cancelCheckInterval files, so the walk stops at the first check
+	// after the limit.
+	if got := te.Details.Progress; got.FilesWalked != 2*cancelCheckInterval || got.PackagesWalked != 1 {
+		t.Fatalf("expected the walk to stop after %d files of one package, got %+v", 2*cancelCheckInterval, got)
+	}
+
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Fatalf("cancelled call took %s", elapsed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "f00.go"))
+	if err != nil || string(data) != files["f00.go"] {
+		t.Fatalf("a cancelled rewrite must not write files, got %q (err %v)", data, err)
+	}
+}
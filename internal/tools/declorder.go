@@ -0,0 +1,589 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	declPolicyStd        = "std"
+	declPolicyVisibility = "visibility"
+	declPolicyCustom     = "custom"
+)
+
+// Top-level declaration categories used by ordering policies.
+const (
+	declCategoryConst = "const"
+	declCategoryVar   = "var"
+	declCategoryType  = "type"
+	declCategoryFunc  = "func"
+)
+
+var defaultDeclCategoryOrder = []string{declCategoryConst, declCategoryVar, declCategoryType, declCategoryFunc}
+
+// Positions of declarations inside a type group: type, constructors, exported methods, unexported methods.
+const (
+	declRoleType = iota
+	declRoleConstructor
+	declRoleExportedMethod
+	declRoleUnexportedMethod
+)
+
+// declBlock is a movable top-level declaration together with its doc comment and preceding floating comments.
+type declBlock struct {
+	decl     ast.Decl
+	name     string
+	category string
+	exported bool
+	line     int
+	index    int
+	text     []byte
+
+	// owner is the type block a method or constructor is grouped with.
+	owner *declBlock
+	role  int
+}
+
+// declLayout is a parsed file split into a fixed header, movable declaration blocks and a trailing tail.
+type declLayout struct {
+	path   string
+	src    []byte
+	header []byte
+	tail   []byte
+	blocks []*declBlock
+
+	// generated is set for files with a "Code generated ... DO NOT EDIT." header.
+	generated bool
+	generator string
+}
+
+// ReorderDeclarations moves top-level declarations of a file into the order required by a policy.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, file, ordering policy and dry-run flag
+//
+// Returns:
+//   - MCP tool call result
+//   - new declaration order and a unified diff
+//   - error if the file cannot be parsed or reordering would be unsafe
+func ReorderDeclarations(ctx context.Context, _ *mcp.CallToolRequest, input ReorderDeclarationsInput) (
+	*mcp.CallToolResult,
+	ReorderDeclarationsOutput,
+	error,
+) {
+	start := logStart("ReorderDeclarations", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("policy", input.Policy),
+		newLogField("dryRun", strconv.FormatBool(input.DryRun)),
+	))
+	out := ReorderDeclarationsOutput{File: input.File}
+
+	defer func() { logEnd("ReorderDeclarations", start, len(out.Order)) }()
+
+	if err := validateDiffMode(input.DiffMode); err != nil {
+		return fail(out, err)
+	}
+
+	defer lockModuleForMutation(input.Dir)()
+
+	if err := checkExpectedHashes(input.Dir, expectedFileHash(input.File, input.ExpectedHash)); err != nil {
+		return fail(out, err)
+	}
+
+	layout, err := parseDeclLayout(input.Dir, input.File)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	ordered, err := orderDeclBlocks(layout.blocks, input.Policy, input.CustomOrder)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	for _, b := range ordered {
+		out.Order = append(out.Order, b.name)
+	}
+
+	if !declOrderChanged(layout.blocks, ordered) {
+		return nil, out, nil
+	}
+
+	if err := checkVarInitOrder(layout.blocks, ordered); err != nil {
+		return fail(out, err)
+	}
+
+	newContent := layout.render(ordered)
+
+	if _, err := parser.ParseFile(token.NewFileSet(), layout.path, newContent, parser.ParseComments); err != nil {
+		return fail(out, fmt.Errorf("reordered file does not parse: %w", err))
+	}
+
+	out.Changed = true
+	out.Diff = diffFiles(layout.src, newContent, filepath.ToSlash(input.File), input.DiffMode)
+
+	if layout.generated && !input.AllowGenerated {
+		out.SkippedGenerated = []GeneratedFile{{File: filepath.ToSlash(input.File), Generator: layout.generator}}
+
+		return nil, out, nil
+	}
+
+	if input.DryRun {
+		return nil, out, nil
+	}
+
+	if err := safeWriteFile(layout.path, newContent, fileChange{tool: "reorderDeclarations", input: input}); err != nil {
+		logError("ReorderDeclarations", err, "failed to write file")
+
+		return fail(out, err)
+	}
+
+	out.Build = verifyAfterMutation(ctx, "ReorderDeclarations", input.Dir, input.VerifyBuild)
+
+	return nil, out, nil
+}
+
+// CheckDeclarationOrder reports top-level declarations that violate an ordering policy without changing the file.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, file and ordering policy
+//
+// Returns:
+//   - MCP tool call result
+//   - list of ordering violations
+//   - error if the file cannot be parsed
+func CheckDeclarationOrder(ctx context.Context, _ *mcp.CallToolRequest, input CheckDeclarationOrderInput) (
+	*mcp.CallToolResult,
+	CheckDeclarationOrderOutput,
+	error,
+) {
+	start := logStart("CheckDeclarationOrder", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("policy", input.Policy),
+	))
+	out := CheckDeclarationOrderOutput{File: input.File, Violations: []DeclOrderViolation{}}
+
+	defer func() { logEnd("CheckDeclarationOrder", start, len(out.Violations)) }()
+
+	layout, err := parseDeclLayout(input.Dir, input.File)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	ordered, err := orderDeclBlocks(layout.blocks, input.Policy, input.CustomOrder)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		prev, cur := ordered[i-1], ordered[i]
+		if cur.index < prev.index {
+			out.Violations = append(out.Violations, DeclOrderViolation{
+				Name:    cur.name,
+				Kind:    cur.category,
+				Line:    cur.line,
+				Message: fmt.Sprintf("%s should be declared after %s (line %d)", cur.name, prev.name, prev.line),
+			})
+		}
+	}
+
+	out.Ordered = len(out.Violations) == 0
+
+	return nil, out, nil
+}
+
+// parseDeclLayout reads a Go file and splits it into fixed and movable parts.
+func parseDeclLayout(dir, file string) (*declLayout, error) {
+	path := filepath.Join(dir, file)
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", file, err)
+	}
+
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Go file %q: %w", file, err)
+	}
+
+	layout := &declLayout{path: path, src: src}
+	layout.generator, layout.generated = generatedFileGenerator(f)
+
+	headerEnd := lineEndOffset(src, fset.Position(f.Name.End()).Offset)
+	firstMovable := 0
+
+	for i, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			break
+		}
+
+		headerEnd = lineEndOffset(src, fset.Position(gd.End()).Offset)
+		firstMovable = i + 1
+	}
+
+	for _, cg := range f.Comments {
+		if fset.Position(cg.Pos()).Offset < headerEnd {
+			continue
+		}
+
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, "//go:build") || strings.HasPrefix(c.Text, "// +build") {
+				return nil, fmt.Errorf("cannot reorder %s: build constraint at line %d splits the file",
+					file, fset.Position(c.Pos()).Line)
+			}
+		}
+	}
+
+	layout.header = src[:headerEnd]
+	prevEnd := headerEnd
+
+	for i, decl := range f.Decls[firstMovable:] {
+		end := lineEndOffset(src, fset.Position(decl.End()).Offset)
+		block := newDeclBlock(decl, i)
+		block.line = fset.Position(decl.Pos()).Line
+		block.text = bytes.TrimLeft(src[prevEnd:end], " \t\r\n")
+		layout.blocks = append(layout.blocks, block)
+		prevEnd = end
+	}
+
+	layout.tail = src[prevEnd:]
+
+	assignTypeGroups(layout.blocks)
+
+	return layout, nil
+}
+
+// lineEndOffset returns the offset just past the newline that terminates the line containing offset.
+func lineEndOffset(src []byte, offset int) int {
+	if offset >= len(src) {
+		return len(src)
+	}
+
+	if idx := bytes.IndexByte(src[offset:], '\n'); idx >= 0 {
+		return offset + idx + 1
+	}
+
+	return len(src)
+}
+
+func newDeclBlock(decl ast.Decl, index int) *declBlock {
+	block := &declBlock{decl: decl, index: index}
+
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		block.category = declCategoryFunc
+		block.name = qualifiedFuncName(d)
+		block.exported = d.Name.IsExported()
+	case *ast.GenDecl:
+		block.category = strings.ToLower(d.Tok.String())
+
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				block.name = s.Name.Name
+				block.exported = s.Name.IsExported()
+			case *ast.ValueSpec:
+				if len(s.Names) > 0 {
+					block.name = s.Names[0].Name
+					block.exported = s.Names[0].IsExported()
+				}
+			}
+
+			if block.name != "" {
+				break
+			}
+		}
+
+		block.name = block.category + " " + block.name
+	}
+
+	return block
+}
+
+// assignTypeGroups links methods and constructors to the type declared in the same file.
+func assignTypeGroups(blocks []*declBlock) {
+	typeOwner := make(map[string]*declBlock)
+
+	for _, b := range blocks {
+		gd, ok := b.decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				typeOwner[ts.Name.Name] = b
+			}
+		}
+	}
+
+	for _, b := range blocks {
+		fd, ok := b.decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if recv := receiverName(fd); recv != "" {
+			if owner, ok := typeOwner[recv]; ok {
+				b.owner = owner
+				b.role = declRoleUnexportedMethod
+
+				if fd.Name.IsExported() {
+					b.role = declRoleExportedMethod
+				}
+			}
+
+			continue
+		}
+
+		if owner := constructedType(fd, typeOwner); owner != nil {
+			b.owner = owner
+			b.role = declRoleConstructor
+		}
+	}
+}
+
+// constructedType returns the owning type block for constructor-like functions (NewT/newT or returning T/*T).
+func constructedType(fd *ast.FuncDecl, typeOwner map[string]*declBlock) *declBlock {
+	name := fd.Name.Name
+	for typeName, owner := range typeOwner {
+		if strings.EqualFold(name, "new"+typeName) {
+			return owner
+		}
+	}
+
+	if fd.Type.Results == nil || len(fd.Type.Results.List) == 0 {
+		return nil
+	}
+
+	result := fd.Type.Results.List[0].Type
+	if star, ok := result.(*ast.StarExpr); ok {
+		result = star.X
+	}
+
+	if ident, ok := result.(*ast.Ident); ok && strings.HasPrefix(strings.ToLower(name), "new") {
+		return typeOwner[ident.Name]
+	}
+
+	return nil
+}
+
+// orderDeclBlocks returns blocks sorted according to the requested policy.
+func orderDeclBlocks(blocks []*declBlock, policy string, customOrder []string) ([]*declBlock, error) {
+	if policy == "" {
+		policy = declPolicyStd
+	}
+
+	categoryOrder := defaultDeclCategoryOrder
+
+	switch policy {
+	case declPolicyStd, declPolicyVisibility:
+	case declPolicyCustom:
+		if len(customOrder) == 0 {
+			return nil, invalidInput("customOrder is required when policy is \"custom\"")
+		}
+
+		categoryOrder = make([]string, 0, len(defaultDeclCategoryOrder))
+
+		for _, c := range customOrder {
+			if !contains(defaultDeclCategoryOrder, c) {
+				return nil, invalidInput("unknown declaration category %q (expected const, var, type, func)", c)
+			}
+
+			if !contains(categoryOrder, c) {
+				categoryOrder = append(categoryOrder, c)
+			}
+		}
+
+		for _, c := range defaultDeclCategoryOrder {
+			if !contains(categoryOrder, c) {
+				categoryOrder = append(categoryOrder, c)
+			}
+		}
+	default:
+		return nil, invalidInput("unknown policy %q (expected std, visibility or custom)", policy)
+	}
+
+	categoryRank := make(map[string]int, len(categoryOrder))
+	for i, c := range categoryOrder {
+		categoryRank[c] = i
+	}
+
+	visibilityRank := func(b *declBlock) int {
+		if policy == declPolicyVisibility && !b.exported {
+			return 1
+		}
+
+		return 0
+	}
+
+	keys := make(map[*declBlock][]int, len(blocks))
+
+	for _, b := range blocks {
+		switch {
+		case b.owner != nil:
+			keys[b] = []int{categoryRank[declCategoryType], visibilityRank(b.owner), b.owner.index, b.role, b.index}
+		case b.category == declCategoryType:
+			keys[b] = []int{categoryRank[declCategoryType], visibilityRank(b), b.index, declRoleType, b.index}
+		case b.category == declCategoryFunc:
+			keys[b] = []int{categoryRank[declCategoryFunc], visibilityRank(b), b.index, 0, b.index}
+		default:
+			// const and var blocks keep their relative order to preserve initialization semantics.
+			keys[b] = []int{categoryRank[b.category], 0, b.index, 0, b.index}
+		}
+	}
+
+	ordered := append([]*declBlock(nil), blocks...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return compareIntSlices(keys[ordered[i]], keys[ordered[j]]) < 0
+	})
+
+	return ordered, nil
+}
+
+func compareIntSlices(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return len(a) - len(b)
+}
+
+func declOrderChanged(original, ordered []*declBlock) bool {
+	for i := range original {
+		if original[i] != ordered[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkVarInitOrder refuses to move package-level vars whose initializers reference other vars in the same file.
+func checkVarInitOrder(original, ordered []*declBlock) error {
+	varBlocks := make(map[string]*declBlock)
+
+	for _, b := range original {
+		gd, ok := b.decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok {
+				for _, name := range vs.Names {
+					varBlocks[name.Name] = b
+				}
+			}
+		}
+	}
+
+	newIndex := make(map[*declBlock]int, len(ordered))
+	for i, b := range ordered {
+		newIndex[b] = i
+	}
+
+	for _, b := range original {
+		gd, ok := b.decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			for _, value := range vs.Values {
+				for dep := range referencedIdents(value) {
+					depBlock, ok := varBlocks[dep]
+					if !ok || depBlock == b {
+						continue
+					}
+
+					if newIndex[b] != b.index || newIndex[depBlock] != depBlock.index {
+						return fmt.Errorf(
+							"cannot reorder: initializer of %s references package-level var %s declared in the same file; moving them could change initialization order",
+							b.name, dep,
+						)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// referencedIdents returns the unqualified identifiers used in an expression.
+func referencedIdents(expr ast.Expr) map[string]struct{} {
+	idents := make(map[string]struct{})
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			ast.Inspect(node.X, func(inner ast.Node) bool {
+				if id, ok := inner.(*ast.Ident); ok {
+					idents[id.Name] = struct{}{}
+				}
+
+				return true
+			})
+
+			return false
+		case *ast.Ident:
+			idents[node.Name] = struct{}{}
+		}
+
+		return true
+	})
+
+	return idents
+}
+
+// render assembles the file with blocks in the given order, separated by a single blank line.
+func (l *declLayout) render(ordered []*declBlock) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(bytes.TrimRight(l.header, " \t\r\n"))
+	buf.WriteByte('\n')
+
+	for _, b := range ordered {
+		buf.WriteByte('\n')
+		buf.Write(b.text)
+
+		if len(b.text) > 0 && b.text[len(b.text)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+
+	buf.Write(l.tail)
+
+	return buf.Bytes()
+}
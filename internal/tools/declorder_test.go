@@ -0,0 +1,131 @@
+package tools_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestCheckDeclarationOrder(t *testing.T) {
+	t.Parallel()
+
+	in := tools.CheckDeclarationOrderInput{Dir: testDir(), File: "widget.go"}
+
+	_, out, err := tools.CheckDeclarationOrder(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("CheckDeclarationOrder error: %v", err)
+	}
+
+	if out.Ordered || len(out.Violations) == 0 {
+		t.Fatalf("expected violations for widget.go, got %+v", out)
+	}
+}
+
+func TestReorderDeclarations(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	if err := copyDir(testDir(), tmpDir); err != nil {
+		t.Fatalf("copyDir error: %v", err)
+	}
+
+	in := tools.ReorderDeclarationsInput{Dir: tmpDir, File: "widget.go", Policy: "std"}
+
+	_, out, err := tools.ReorderDeclarations(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ReorderDeclarations error: %v", err)
+	}
+
+	expected := []string{"type Widget", "NewWidget", "Widget.Size", "Widget.reset"}
+	if strings.Join(out.Order, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected order %v, got %v", expected, out.Order)
+	}
+
+	if !out.Changed || out.Diff == "" {
+		t.Fatalf("expected a diff for reordered file")
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "widget.go"))
+	if err != nil {
+		t.Fatalf("failed to read reordered file: %v", err)
+	}
+
+	content := string(data)
+	if !strings.Contains(content, "// NewWidget returns a widget of the given size.\nfunc NewWidget") {
+		t.Errorf("expected doc comment to move together with NewWidget:\n%s", content)
+	}
+
+	if strings.Index(content, "type Widget") > strings.Index(content, "func (w *Widget) reset()") {
+		t.Errorf("expected type Widget before its methods:\n%s", content)
+	}
+
+	_, check, err := tools.CheckDeclarationOrder(context.Background(), &mcp.CallToolRequest{},
+		tools.CheckDeclarationOrderInput{Dir: tmpDir, File: "widget.go"})
+	if err != nil {
+		t.Fatalf("CheckDeclarationOrder error: %v", err)
+	}
+
+	if !check.Ordered {
+		t.Errorf("expected reordered file to satisfy policy, got %+v", check.Violations)
+	}
+}
+
+func TestReorderDeclarations_RefusesInitOrderDependencies(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	src := "package x\n\nfunc helper() int { return 1 }\n\nvar b = a + 1\n\nconst c = 1\n\nvar a = 2\n"
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "x.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	in := tools.ReorderDeclarationsInput{Dir: tmpDir, File: "x.go", DryRun: true}
+
+	_, _, err := tools.ReorderDeclarations(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil || !strings.Contains(err.Error(), "initializ") {
+		t.Fatalf("expected init-order refusal, got %v", err)
+	}
+}
+
+func TestReorderDeclarations_SkipsGeneratedFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	if err := copyDir(testDir(), tmpDir); err != nil {
+		t.Fatalf("copyDir error: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "greeting.pb.go")
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+
+	in := tools.ReorderDeclarationsInput{Dir: tmpDir, File: "greeting.pb.go"}
+
+	_, out, err := tools.ReorderDeclarations(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ReorderDeclarations error: %v", err)
+	}
+
+	if len(out.SkippedGenerated) != 1 || out.SkippedGenerated[0].Generator != "protoc-gen-go" {
+		t.Fatalf("expected the generated file to be skipped, got %+v", out)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+
+	if !bytes.Equal(before, after) {
+		t.Errorf("expected the generated file to stay untouched")
+	}
+}
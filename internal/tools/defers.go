@@ -0,0 +1,362 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Categories of AnalyzeDefers findings, in report order.
+const (
+	deferInLoop         = "defer-in-loop"
+	deferUncheckedClose = "unchecked-close"
+	deferLoopVarCapture = "loop-var-capture"
+	deferNilFunc        = "nil-func-value"
+)
+
+var deferCategories = []string{deferInLoop, deferUncheckedClose, deferLoopVarCapture, deferNilFunc}
+
+// AnalyzeDefers reports defer statements that are likely wrong: defers inside loops, which hold their
+// resources until the function returns; defer x.Close() discarding the error in a function that returns
+// one; deferred closures capturing loop variables in files before Go 1.22; and deferred function values
+// that may still be nil when the defer runs. Defers in function literals belong to the literal: loops
+// and results are those of the literal.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional package filter
+//
+// Returns:
+//   - MCP tool call result
+//   - findings grouped by category
+//   - error if an error occurred while loading packages
+func AnalyzeDefers(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeDefersInput) (
+	*mcp.CallToolResult,
+	AnalyzeDefersOutput,
+	error,
+) {
+	start := logStart("AnalyzeDefers", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := AnalyzeDefersOutput{Categories: []DeferCategory{}}
+
+	defer func() { logEnd("AnalyzeDefers", start, out.Total) }()
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "AnalyzeDefers")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	byCategory := make(map[string][]DeferFinding)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		s := &deferScanner{info: pkg.TypesInfo, oldLoopVars: beforeLoopVarSemantics(pkg, file)}
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			s.findings = s.findings[:0]
+			s.scan(fd.Body, pkg.TypesInfo.Defs[fd.Name].Type())
+
+			for _, f := range s.findings {
+				byCategory[f.category] = append(byCategory[f.category], DeferFinding{
+					Category:   f.category,
+					File:       relPath,
+					Line:       pkg.Fset.Position(f.pos).Line,
+					Function:   qualifiedFuncName(fd),
+					Message:    f.message,
+					Suggestion: f.suggestion,
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, category := range deferCategories {
+		findings := byCategory[category]
+		if len(findings) == 0 {
+			continue
+		}
+
+		sort.Slice(findings, func(i, j int) bool {
+			if findings[i].File != findings[j].File {
+				return findings[i].File < findings[j].File
+			}
+
+			return findings[i].Line < findings[j].Line
+		})
+
+		out.Categories = append(out.Categories, DeferCategory{Category: category, Count: len(findings), Findings: findings})
+		out.Total += len(findings)
+	}
+
+	return nil, out, nil
+}
+
+// beforeLoopVarSemantics reports whether file is compiled with the shared loop variables of Go before
+// 1.22. An unknown language version counts as current.
+func beforeLoopVarSemantics(pkg *packages.Package, file *ast.File) bool {
+	v := pkg.TypesInfo.FileVersions[file]
+	if v == "" && pkg.Module != nil && pkg.Module.GoVersion != "" {
+		v = "go" + pkg.Module.GoVersion
+	}
+
+	return version.IsValid(v) && version.Compare(v, "go1.22") < 0
+}
+
+// deferFinding is a suspicious defer statement.
+type deferFinding struct {
+	category   string
+	pos        token.Pos
+	message    string
+	suggestion string
+}
+
+// deferScanner checks the defer statements of one function body at a time.
+type deferScanner struct {
+	info        *types.Info
+	oldLoopVars bool
+	findings    []deferFinding
+}
+
+// scan checks the defers of body, a function of type sig, tracking the loops around every node the way
+// AnalyzeAllocations does. Function literals are scanned as functions of their own.
+func (s *deferScanner) scan(body *ast.BlockStmt, sig types.Type) {
+	var (
+		stack  []ast.Node
+		depths []int
+	)
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil {
+			stack, depths = stack[:len(stack)-1], depths[:len(depths)-1]
+
+			return true
+		}
+
+		depth := 0
+		if len(stack) > 0 {
+			depth = depths[len(depths)-1]
+			if iterates(stack[len(stack)-1], node) {
+				depth++
+			}
+		}
+
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			// Defers inside a literal run when the literal returns: its loops and results are its own.
+			s.scan(n.Body, s.info.TypeOf(n))
+
+			return false
+		case *ast.DeferStmt:
+			s.check(n, body, stack, depth, sig)
+		}
+
+		stack, depths = append(stack, node), append(depths, depth)
+
+		return true
+	})
+}
+
+// check matches one defer statement against the categories.
+func (s *deferScanner) check(d *ast.DeferStmt, body *ast.BlockStmt, stack []ast.Node, depth int, sig types.Type) {
+	if depth > 0 {
+		s.add(deferInLoop, d.Pos(),
+			fmt.Sprintf("defer inside %s: the deferred call runs when the function returns, not after each iteration", loopDepthPhrase(depth)),
+			"move the loop body into a function so the defer runs per iteration, or release the resource explicitly at the end of the iteration")
+	}
+
+	if sel, ok := ast.Unparen(d.Call.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Close" &&
+		lastResultIsError(s.info, d.Call) && signatureReturnsError(sig) {
+		x := types.ExprString(sel.X)
+		s.add(deferUncheckedClose, d.Pos(),
+			fmt.Sprintf("the error of deferred %s.Close() is discarded although the function returns an error", x),
+			fmt.Sprintf("name the error result and wrap the call: defer func() { if cerr := %s.Close(); cerr != nil && err == nil { err = cerr } }()", x))
+	}
+
+	if lit, ok := ast.Unparen(d.Call.Fun).(*ast.FuncLit); ok && s.oldLoopVars {
+		if name := s.capturedLoopVar(lit, stack); name != "" {
+			s.add(deferLoopVarCapture, d.Pos(),
+				fmt.Sprintf("deferred closure captures loop variable %s, which before Go 1.22 holds its last value when the defer runs", name),
+				fmt.Sprintf("pass %s as an argument of the deferred call, or copy it (%s := %s) inside the loop", name, name, name))
+		}
+	}
+
+	if id, ok := ast.Unparen(d.Call.Fun).(*ast.Ident); ok {
+		if nilAlways, ok := s.mayBeNilFunc(body, id, d); ok {
+			state := "may still be nil"
+			if nilAlways {
+				state = "is nil"
+			}
+
+			s.add(deferNilFunc, d.Pos(),
+				fmt.Sprintf("deferred function value %s %s at the defer, so the function panics when it returns", id.Name, state),
+				fmt.Sprintf("defer only after %s is set, or guard it: defer func() { if %s != nil { %s() } }()", id.Name, id.Name, id.Name))
+		}
+	}
+}
+
+func (s *deferScanner) add(category string, pos token.Pos, message, suggestion string) {
+	s.findings = append(s.findings, deferFinding{category: category, pos: pos, message: message, suggestion: suggestion})
+}
+
+// loopDepthPhrase describes a loop depth for messages.
+func loopDepthPhrase(depth int) string {
+	if depth == 1 {
+		return "a loop"
+	}
+
+	return fmt.Sprintf("%d nested loops", depth)
+}
+
+// capturedLoopVar returns the name of a variable declared by a loop around the defer (stack holds its
+// ancestors) that lit references, or "".
+func (s *deferScanner) capturedLoopVar(lit *ast.FuncLit, stack []ast.Node) string {
+	loopVars := make(map[types.Object]struct{})
+
+	for i, node := range stack {
+		var vars []ast.Expr
+
+		switch loop := node.(type) {
+		case *ast.RangeStmt:
+			if loop.Tok == token.DEFINE {
+				vars = []ast.Expr{loop.Key, loop.Value}
+			}
+		case *ast.ForStmt:
+			if assign, ok := loop.Init.(*ast.AssignStmt); ok && assign.Tok == token.DEFINE {
+				vars = assign.Lhs
+			}
+		}
+
+		if len(vars) == 0 || i+1 >= len(stack) || !iterates(node, stack[i+1]) {
+			continue
+		}
+
+		for _, v := range vars {
+			if id, ok := v.(*ast.Ident); ok && id.Name != "_" {
+				if obj := s.info.Defs[id]; obj != nil {
+					loopVars[obj] = struct{}{}
+				}
+			}
+		}
+	}
+
+	name := ""
+
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && name == "" {
+			if _, ok := loopVars[s.info.Uses[id]]; ok {
+				name = id.Name
+			}
+		}
+
+		return name == ""
+	})
+
+	return name
+}
+
+// mayBeNilFunc reports whether the deferred function value id is a variable of body that is nil at the
+// defer: declared without a value or with nil and, before the defer, assigned only inside if, switch or
+// select branches that do not contain the defer. nilAlways is set when it is not assigned at all.
+// Variables whose address is taken are left out.
+func (s *deferScanner) mayBeNilFunc(body *ast.BlockStmt, id *ast.Ident, d *ast.DeferStmt) (nilAlways, ok bool) {
+	v, isVar := s.info.Uses[id].(*types.Var)
+	if !isVar || v.Pos() < body.Pos() || v.Pos() >= body.End() {
+		return false, false
+	}
+
+	if _, isFunc := v.Type().Underlying().(*types.Signature); !isFunc {
+		return false, false
+	}
+
+	declaredNil, conditional, unconditional, addressed := false, 0, 0, false
+
+	var stack []ast.Node
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if s.info.Defs[name] == v {
+					declaredNil = len(n.Values) == 0 || (len(n.Values) == len(n.Names) && s.isNil(n.Values[i]))
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				lid, isIdent := ast.Unparen(lhs).(*ast.Ident)
+				if !isIdent {
+					continue
+				}
+
+				switch {
+				case n.Tok == token.DEFINE && s.info.Defs[lid] == v:
+					declaredNil = len(n.Rhs) == len(n.Lhs) && s.isNil(n.Rhs[i])
+				case s.info.Uses[lid] == v && n.Pos() < d.Pos():
+					if branchesAround(stack, d.Pos()) {
+						conditional++
+					} else {
+						unconditional++
+					}
+				}
+			}
+		case *ast.UnaryExpr:
+			if x, isIdent := ast.Unparen(n.X).(*ast.Ident); isIdent && n.Op == token.AND && s.info.Uses[x] == v {
+				addressed = true
+			}
+		}
+
+		stack = append(stack, n)
+
+		return true
+	})
+
+	if !declaredNil || unconditional > 0 || addressed {
+		return false, false
+	}
+
+	return conditional == 0, true
+}
+
+// branchesAround reports whether one of the nodes in stack is an if, switch or select statement that
+// does not contain pos, so the innermost node runs only on some paths before pos.
+func branchesAround(stack []ast.Node, pos token.Pos) bool {
+	for _, node := range stack {
+		switch node.(type) {
+		case *ast.IfStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			if pos < node.Pos() || pos >= node.End() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isNil reports whether e is the predeclared nil.
+func (s *deferScanner) isNil(e ast.Expr) bool {
+	tv, ok := s.info.Types[e]
+
+	return ok && tv.IsNil()
+}
@@ -0,0 +1,103 @@
+package tools_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const defersSource = `package lang
+
+import "os"
+
+func ReadAll(paths []string) error {
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+
+	return nil
+}
+
+func PerIteration(paths []string) {
+	for _, p := range paths {
+		func() {
+			f, _ := os.Open(p)
+			defer f.Close()
+		}()
+	}
+}
+
+func Log(paths []string) {
+	for i := 0; i < len(paths); i++ {
+		defer func() { println(paths[i]) }()
+		defer func(i int) { println(paths[i]) }(i)
+	}
+}
+
+func Cleanup(create bool) {
+	var cleanup func()
+	if create {
+		cleanup = func() {}
+	}
+	defer cleanup()
+
+	var always func()
+	defer always()
+
+	done := func() {}
+	defer done()
+}
+`
+
+func TestAnalyzeDefers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		goVersion string
+		want      map[string][]int
+	}{
+		{"1.21", map[string][]int{
+			"defer-in-loop":    {11, 28, 29},
+			"unchecked-close":  {11},
+			"loop-var-capture": {28},
+			"nil-func-value":   {38, 41},
+		}},
+		{"1.22", map[string][]int{
+			"defer-in-loop":   {11, 28, 29},
+			"unchecked-close": {11},
+			"nil-func-value":  {38, 41},
+		}},
+	}
+
+	for _, tc := range cases {
+		dir := writeLanguageModule(t, tc.goVersion, map[string]string{"lang.go": defersSource})
+
+		_, out, err := tools.AnalyzeDefers(context.Background(), &mcp.CallToolRequest{},
+			tools.AnalyzeDefersInput{Dir: dir, Package: "lang"})
+		if err != nil {
+			t.Fatalf("go %s: AnalyzeDefers error: %v", tc.goVersion, err)
+		}
+
+		got := make(map[string][]int)
+		for _, c := range out.Categories {
+			for _, f := range c.Findings {
+				got[c.Category] = append(got[c.Category], f.Line)
+			}
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("go %s: got %v, want %v", tc.goVersion, got, tc.want)
+		}
+
+		if c := out.Categories[0]; c.Category != "defer-in-loop" || c.Findings[0].Function != "ReadAll" || c.Findings[2].Function != "Log" {
+			t.Errorf("go %s: expected defer-in-loop first with enclosing functions, got %+v", tc.goVersion, c)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// PreviewDelete reports what deleting a symbol would break: every reference outside its declaration,
+// grouped by package and file, the types that would stop implementing a module interface when the symbol
+// is a method, and the test files using it, summed up in a verdict. Nothing is modified.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the symbol ('Name' or 'Type.Method') and an optional kind
+//
+// Returns:
+//   - MCP tool call result
+//   - verdict, whether the symbol is already dead, and the grouped references and broken implementations
+//   - error if the symbol is not found or packages cannot be loaded
+func PreviewDelete(ctx context.Context, _ *mcp.CallToolRequest, input PreviewDeleteInput) (
+	*mcp.CallToolResult,
+	PreviewDeleteOutput,
+	error,
+) {
+	start := logStart("PreviewDelete", logFields(
+		input.Dir,
+		newLogField("symbol", input.Symbol),
+		newLogField("kind", input.Kind),
+	))
+	out := PreviewDeleteOutput{}
+
+	defer func() { logEnd("PreviewDelete", start, out.References) }()
+
+	if input.Symbol == "" {
+		return fail(out, invalidInput("symbol must not be empty"))
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	target, err := findRenameTarget(ctx, pkgs, input.Dir, input.Symbol, input.Kind, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if target == nil {
+		return fail(out, missingSymbol(pkgs, input.Symbol, input.Kind, "symbol %q not found", input.Symbol))
+	}
+
+	out.Symbol = types.ObjectString(target, func(p *types.Package) string { return p.Name() })
+	out.Kind = objStringKind(target)
+
+	if posn := pkgs[0].Fset.Position(target.Pos()); posn.IsValid() {
+		out.File, out.Line = relativePath(input.Dir, posn.Filename), posn.Line
+	}
+
+	byPackage, testFiles := deleteReferences(ctx, pkgs, input.Dir, target)
+	if err := ctx.Err(); err != nil {
+		return fail(out, err)
+	}
+
+	for _, pkgPath := range sortedKeys(byPackage) {
+		records := byPackage[pkgPath]
+		sortLocationRecords(records)
+
+		out.Packages = append(out.Packages, DeleteImpactPackage{
+			Package:    pkgPath,
+			References: len(records),
+			Groups:     makeReferenceGroups(records),
+		})
+		out.References += len(records)
+	}
+
+	out.TestFiles = sortedKeys(testFiles)
+	out.BrokenImplementations = brokenImplementations(pkgs, input.Dir, target)
+	out.AlreadyDead = out.References == 0 && len(out.BrokenImplementations) == 0 && deadCandidate(target)
+	out.Safe = out.References == 0 && len(out.BrokenImplementations) == 0
+	out.Verdict = deleteVerdict(out)
+
+	return nil, out, nil
+}
+
+// deleteReferences collects the uses of target in pkgs by package path, and the test files among them.
+// Test variants repeat the files of their package, so a position is recorded once.
+func deleteReferences(ctx context.Context, pkgs []*packages.Package, dir string, target types.Object) (
+	map[string][]locationRecord,
+	map[string]struct{},
+) {
+	byPackage := make(map[string][]locationRecord)
+	testFiles := make(map[string]struct{})
+	seen := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		if shouldStop(ctx) || pkg.TypesInfo == nil {
+			continue
+		}
+
+		promoted := promotedMethodSelections(pkg.TypesInfo, target)
+
+		for _, file := range pkg.Syntax {
+			var lines []string
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if !ok || ident.Name != target.Name() {
+					return true
+				}
+
+				if _, ok := pkg.TypesInfo.Defs[ident]; ok {
+					return true // the declaration itself goes with the symbol
+				}
+
+				_, indirect := promoted[ident]
+				if !indirect && !sameObject(pkg.TypesInfo.Uses[ident], target) {
+					return true
+				}
+
+				pos := pkg.Fset.Position(ident.Pos())
+				rel := relativePath(dir, pos.Filename)
+
+				key := fmt.Sprintf("%s:%d:%d", rel, pos.Line, pos.Column)
+				if _, ok := seen[key]; ok {
+					return true
+				}
+
+				seen[key] = struct{}{}
+
+				if lines == nil {
+					lines = getFileLines(pkg.Fset, file)
+				}
+
+				pkgPath := normalizePackagePath(pkg)
+				byPackage[pkgPath] = append(byPackage[pkgPath], locationRecord{
+					File: rel, Line: pos.Line, Snippet: extractSnippet(lines, pos.Line), Indirect: indirect,
+				})
+
+				if strings.HasSuffix(rel, "_test.go") {
+					testFiles[rel] = struct{}{}
+				}
+
+				return true
+			})
+		}
+	}
+
+	return byPackage, testFiles
+}
+
+// brokenImplementations lists, for a concrete method, the module types that have the method (declared or
+// promoted from an embedded field) and implement a module interface requiring it, so that they would stop
+// implementing it once the method is gone. It returns nil for other symbols.
+func brokenImplementations(pkgs []*packages.Package, dir string, target types.Object) []BrokenImplementation {
+	fn, ok := target.(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil || types.IsInterface(sig.Recv().Type()) {
+		return nil
+	}
+
+	var (
+		holders    []*types.TypeName
+		interfaces []*types.TypeName
+	)
+
+	seen := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil || strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+
+		for _, obj := range pkg.TypesInfo.Defs {
+			tn, ok := obj.(*types.TypeName)
+			if !ok || tn.IsAlias() || tn.Parent() != tn.Pkg().Scope() {
+				continue
+			}
+
+			key := objectKey(tn)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			if iface, ok := tn.Type().Underlying().(*types.Interface); ok {
+				if hasMethodNamed(iface, fn.Name()) {
+					interfaces = append(interfaces, tn)
+				}
+
+				continue
+			}
+
+			if m, _, _ := types.LookupFieldOrMethod(types.NewPointer(tn.Type()), false, fn.Pkg(), fn.Name()); sameObject(m, fn) {
+				holders = append(holders, tn)
+			}
+		}
+	}
+
+	var broken []BrokenImplementation
+
+	for _, holder := range holders {
+		for _, iface := range interfaces {
+			target := iface.Type().Underlying().(*types.Interface)
+
+			typ := types.Type(holder.Type())
+			if !types.Implements(typ, target) {
+				typ = types.NewPointer(typ)
+				if !types.Implements(typ, target) {
+					continue
+				}
+			}
+
+			posn := pkgs[0].Fset.Position(holder.Pos())
+			broken = append(broken, BrokenImplementation{
+				Type:      types.TypeString(typ, (*types.Package).Name),
+				Interface: types.TypeString(iface.Type(), (*types.Package).Name),
+				File:      relativePath(dir, posn.Filename),
+				Line:      posn.Line,
+			})
+		}
+	}
+
+	sort.Slice(broken, func(i, j int) bool {
+		if broken[i].Type != broken[j].Type {
+			return broken[i].Type < broken[j].Type
+		}
+
+		return broken[i].Interface < broken[j].Interface
+	})
+
+	return broken
+}
+
+// hasMethodNamed reports whether iface requires a method called name.
+func hasMethodNamed(iface *types.Interface, name string) bool {
+	for i := range iface.NumMethods() {
+		if iface.Method(i).Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deadCandidate reports whether DeadCode would report target once unused: an unexported package-level
+// symbol or method, or an exported package-level symbol of an internal package.
+func deadCandidate(target types.Object) bool {
+	ident := ast.NewIdent(target.Name())
+
+	if isDeadCandidate(ident, target) {
+		return true
+	}
+
+	return target.Pkg() != nil && isInternalPackagePath(target.Pkg().Path()) && isInternalExportedCandidate(ident, target)
+}
+
+// deleteVerdict sums up a delete preview in one sentence.
+func deleteVerdict(out PreviewDeleteOutput) string {
+	if out.Safe {
+		switch {
+		case out.AlreadyDead:
+			return "safe: the symbol is already dead code"
+		case ast.IsExported(out.Symbol[strings.LastIndexAny(out.Symbol, ". ")+1:]):
+			return "safe within the module; exported, so importers outside it may still use it"
+		default:
+			return "safe"
+		}
+	}
+
+	var parts []string
+
+	if out.References > 0 {
+		parts = append(parts, fmt.Sprintf("breaks %d %s in %d %s",
+			out.References, plural(out.References, "reference"), len(out.Packages), plural(len(out.Packages), "package")))
+	}
+
+	if n := len(out.BrokenImplementations); n > 0 {
+		parts = append(parts, fmt.Sprintf("breaks %d interface %s", n, plural(n, "implementation")))
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// plural returns word with an s unless n is 1.
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+
+	return word + "s"
+}
@@ -0,0 +1,126 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func writeDeletePreviewModule(t *testing.T) string {
+	t.Helper()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"lang.go": `package lang
+
+type Closer interface{ Close() error }
+
+type Store struct{}
+
+func (s *Store) Close() error { return nil }
+
+type Cache struct{ *Store }
+
+func Parse(s string) string { return s }
+
+func helper() int { return 1 }
+
+func used() int { return 2 }
+
+var Value = used()
+`,
+		"lang_test.go": `package lang
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	if Parse("x") != "x" {
+		t.Fatal("Parse")
+	}
+}
+`,
+	})
+
+	if err := os.MkdirAll(filepath.Join(dir, "app"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	app := `package app
+
+import "lang"
+
+func Run() string { return lang.Parse("a") + lang.Parse("b") }
+`
+	if err := os.WriteFile(filepath.Join(dir, "app", "app.go"), []byte(app), 0o644); err != nil {
+		t.Fatalf("write app.go: %v", err)
+	}
+
+	return dir
+}
+
+func TestPreviewDelete(t *testing.T) {
+	t.Parallel()
+
+	dir := writeDeletePreviewModule(t)
+
+	_, out, err := tools.PreviewDelete(context.Background(), &mcp.CallToolRequest{}, tools.PreviewDeleteInput{
+		Dir: dir, Symbol: "Parse",
+	})
+	if err != nil {
+		t.Fatalf("PreviewDelete error: %v", err)
+	}
+
+	if out.Safe || out.References != 3 || len(out.Packages) != 2 || out.Verdict != "breaks 3 references in 2 packages" {
+		t.Fatalf("unexpected preview for Parse: %+v", out)
+	}
+
+	if out.Packages[0].Package != "lang" || out.Packages[1].Package != "lang/app" || out.Packages[1].References != 2 {
+		t.Fatalf("expected references grouped by package, got %+v", out.Packages)
+	}
+
+	if !slices.Equal(out.TestFiles, []string{"lang_test.go"}) {
+		t.Fatalf("expected lang_test.go as test file, got %v", out.TestFiles)
+	}
+
+	_, out, err = tools.PreviewDelete(context.Background(), &mcp.CallToolRequest{}, tools.PreviewDeleteInput{
+		Dir: dir, Symbol: "Store.Close", Kind: "func",
+	})
+	if err != nil {
+		t.Fatalf("PreviewDelete error: %v", err)
+	}
+
+	var broken []string
+	for _, b := range out.BrokenImplementations {
+		broken = append(broken, b.Type+" "+b.Interface)
+	}
+
+	want := []string{"*lang.Store lang.Closer", "lang.Cache lang.Closer"}
+	if out.Safe || out.References != 0 || !slices.Equal(broken, want) || out.Verdict != "breaks 2 interface implementations" {
+		t.Fatalf("expected Cache and Store to stop implementing Closer, got %+v", out)
+	}
+
+	_, out, err = tools.PreviewDelete(context.Background(), &mcp.CallToolRequest{}, tools.PreviewDeleteInput{
+		Dir: dir, Symbol: "helper",
+	})
+	if err != nil || !out.Safe || !out.AlreadyDead || out.File != "lang.go" {
+		t.Fatalf("expected helper to be safe and already dead, got %+v (err %v)", out, err)
+	}
+
+	_, out, err = tools.PreviewDelete(context.Background(), &mcp.CallToolRequest{}, tools.PreviewDeleteInput{
+		Dir: dir, Symbol: "used",
+	})
+	if err != nil || out.Safe || out.AlreadyDead || out.References != 1 {
+		t.Fatalf("expected used to break one reference, got %+v (err %v)", out, err)
+	}
+
+	_, _, err = tools.PreviewDelete(context.Background(), &mcp.CallToolRequest{}, tools.PreviewDeleteInput{
+		Dir: dir, Symbol: "Missing",
+	})
+	if tools.AsToolError(err).Code != tools.CodeNotFound {
+		t.Fatalf("expected NOT_FOUND for an unknown symbol, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadDependencyPackage loads a single dependency of the module in dir by import path, in the module's
+// context: the go command resolves it through the module's requirements, replace directives and vendor
+// directory, exactly as a build of the module would. Dependency loads bypass the package cache.
+//
+// Parameters:
+//   - ctx: execution context
+//   - dir: directory of the parent module
+//   - importPath: import path of the dependency package, e.g. "strings" or "github.com/org/lib/sub"
+//
+// Returns:
+//   - the loaded package, with syntax and type information
+//   - the dependency description to attach to the tool output; file paths are reported relative to its Dir
+//   - NOT_FOUND if the module cannot resolve the import path, INVALID_INPUT for patterns and for packages
+//     of the module itself
+func loadDependencyPackage(ctx context.Context, dir, importPath string) ([]*packages.Package, *DependencyInfo, error) {
+	if strings.Contains(importPath, "...") || strings.HasPrefix(importPath, ".") || filepath.IsAbs(importPath) {
+		return nil, nil, invalidInput("dependencyPackage must be a single import path, got %q", importPath)
+	}
+
+	pkgs, _, err := loadPackagesUncached(ctx, dir, loadModeDependency, false, nil, importPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(pkgs) != 1 || len(pkgs[0].GoFiles) == 0 {
+		var msgs []string
+
+		for _, pkg := range pkgs {
+			for _, e := range pkg.Errors {
+				msgs = append(msgs, e.Msg)
+			}
+		}
+
+		return nil, nil, notFound(nil, "dependency package %q cannot be loaded from %s: %s",
+			importPath, dir, strings.Join(msgs, "; "))
+	}
+
+	pkg := pkgs[0]
+
+	if pkg.Module != nil && pkg.Module.Main {
+		return nil, nil, invalidInput("%q belongs to the module itself; use package instead of dependencyPackage", importPath)
+	}
+
+	if err := checkLoadedTypes(pkgs, loadModeDependency); err != nil {
+		return nil, nil, err
+	}
+
+	info := &DependencyInfo{Package: pkg.PkgPath, Dir: filepath.Dir(pkg.GoFiles[0])}
+
+	if mod := pkg.Module; mod != nil {
+		info.Module, info.Version = mod.Path, mod.Version
+
+		if mod.Replace != nil {
+			info.Replace, info.Version = mod.Replace.Path, mod.Replace.Version
+		}
+	}
+
+	return pkgs, info, nil
+}
+
+// isDependencyFile reports whether path belongs to code the module does not own: the Go root, the
+// module cache or a vendor directory. Mutating tools refuse to write such files.
+func isDependencyFile(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	if root := findModuleRoot(filepath.Dir(abs)); root != "" {
+		if strings.HasPrefix(relativePath(root, abs), "vendor/") {
+			return true
+		}
+	}
+
+	for _, root := range dependencyRoots() {
+		if rel, err := filepath.Rel(root, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dependencyRoots returns the Go root and the module cache directory.
+func dependencyRoots() []string {
+	var roots []string
+
+	if build.Default.GOROOT != "" {
+		roots = append(roots, build.Default.GOROOT)
+	}
+
+	modCache := os.Getenv("GOMODCACHE")
+	if modCache == "" && build.Default.GOPATH != "" {
+		modCache = filepath.Join(filepath.SplitList(build.Default.GOPATH)[0], "pkg", "mod")
+	}
+
+	if modCache != "" {
+		roots = append(roots, modCache)
+	}
+
+	return roots
+}
+
+// dependencyFileError is returned by safeWriteFile for files isDependencyFile reports.
+func dependencyFileError(path string) error {
+	return NewToolError(CodePathDenied, fmt.Errorf("refusing to modify %s: it belongs to a dependency (Go root, module cache or vendor directory)", path))
+}
+
+// apply marks a response as external and attaches the dependency description; nil is a no-op.
+func (d *DependencyInfo) apply(external *bool, dependency **DependencyInfo) {
+	if d == nil {
+		return
+	}
+
+	*external, *dependency = true, d
+}
+
+// loadPackagesOrDependency loads the module in *dir with loadPackagesWithFallback, or only the dependency
+// package when importPath is set. For a dependency, *dir becomes the package directory, so that the tool
+// reports file paths relative to it.
+func loadPackagesOrDependency(ctx context.Context, dir *string, mode packages.LoadMode, importPath string) (
+	[]*packages.Package,
+	*loadDegradation,
+	*DependencyInfo,
+	error,
+) {
+	if importPath == "" {
+		pkgs, degraded, err := loadPackagesWithFallback(ctx, *dir, mode)
+
+		return pkgs, degraded, nil, err
+	}
+
+	pkgs, dependency, err := loadDependencyPackage(ctx, *dir, importPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	*dir = dependency.Dir
+
+	return pkgs, nil, dependency, nil
+}
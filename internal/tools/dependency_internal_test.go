@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeWriteFile_RefusesDependencies(t *testing.T) {
+	dir := t.TempDir()
+	vendored := filepath.Join(dir, "vendor", "example.com", "lib", "lib.go")
+
+	if err := os.MkdirAll(filepath.Dir(vendored), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	files := map[string]string{
+		filepath.Join(dir, "go.mod"):  "module lang\n\ngo 1.22\n",
+		filepath.Join(dir, "lang.go"): "package lang\n",
+		vendored:                      "package lib\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	err := safeWriteFile(vendored, []byte("package lib\n\nvar X = 1\n"), fileChange{tool: "test"})
+	if AsToolError(err).Code != CodePathDenied {
+		t.Fatalf("expected PATH_DENIED for a vendored file, got %v", err)
+	}
+
+	if data, _ := os.ReadFile(vendored); string(data) != files[vendored] {
+		t.Fatalf("vendored file was modified: %q", data)
+	}
+
+	if !isDependencyFile(filepath.Join(build.Default.GOROOT, "src", "strings", "strings.go")) {
+		t.Fatal("expected a GOROOT file to be a dependency file")
+	}
+
+	if isDependencyFile(filepath.Join(dir, "lang.go")) {
+		t.Fatal("a module file must not be a dependency file")
+	}
+}
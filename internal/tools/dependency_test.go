@@ -0,0 +1,93 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestDependencyPackage_StandardLibrary(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"lang.go": "package lang\n\nimport \"strings\"\n\nfunc Upper(s string) string { return strings.ToUpper(s) }\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, symbols, err := tools.ListSymbols(ctx, req, tools.ListSymbolsInput{Dir: dir, DependencyPackage: "strings"})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	if !symbols.External || symbols.Dependency == nil || symbols.Dependency.Package != "strings" || symbols.Dependency.Module != "" {
+		t.Fatalf("expected the result marked as the external strings package, got %+v", symbols.Dependency)
+	}
+
+	var names []string
+
+	for _, pkg := range symbols.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				names = append(names, file.File+":"+sym.Name)
+			}
+		}
+	}
+
+	if !slices.Contains(names, "strings.go:Cut") || !slices.Contains(names, "builder.go:Builder") || slices.Contains(names, "lang.go:Upper") {
+		t.Fatalf("expected the symbols of strings with paths relative to its directory, got %v", names)
+	}
+
+	_, fn, err := tools.ReadFunc(ctx, req, tools.ReadFuncInput{Dir: dir, DependencyPackage: "strings", Name: "Cut"})
+	if err != nil || !fn.External || fn.Function.File != "strings.go" {
+		t.Fatalf("expected strings.Cut from strings.go, got %+v (err %v)", fn.Function, err)
+	}
+
+	_, st, err := tools.ReadStruct(ctx, req, tools.ReadStructInput{Dir: dir, DependencyPackage: "strings", Name: "Builder"})
+	if err != nil || !st.External || st.Struct.File != "builder.go" {
+		t.Fatalf("expected strings.Builder from builder.go, got %+v (err %v)", st.Struct, err)
+	}
+
+	_, ifaces, err := tools.ListInterfaces(ctx, req, tools.ListInterfacesInput{Dir: dir, DependencyPackage: "io"})
+	if err != nil || !ifaces.External || len(ifaces.Interfaces) != 1 || ifaces.Interfaces[0].Package != "io" {
+		t.Fatalf("expected the interfaces of io, got %+v (err %v)", ifaces, err)
+	}
+
+	_, impls, err := tools.FindImplementations(ctx, req, tools.FindImplementationsInput{Dir: dir, DependencyPackage: "io", Name: "Reader"})
+	if err != nil || !impls.External {
+		t.Fatalf("FindImplementations error: %v (%+v)", err, impls)
+	}
+
+	var types []string
+	for _, impl := range impls.Implementations {
+		types = append(types, impl.Type)
+	}
+
+	if !slices.Contains(types, "io.ReadCloser") {
+		t.Fatalf("expected io.ReadCloser among the io.Reader implementations, got %v", types)
+	}
+}
+
+func TestDependencyPackage_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": "package lang\n\nfunc Hello() {}\n"})
+
+	cases := []struct {
+		pkg  string
+		code tools.ErrorCode
+	}{
+		{"lang", tools.CodeInvalidInput},
+		{"./...", tools.CodeInvalidInput},
+		{"example.com/does/not/exist", tools.CodeNotFound},
+	}
+
+	for _, tc := range cases {
+		_, _, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir, DependencyPackage: tc.pkg})
+		if got := tools.AsToolError(err); got == nil || got.Code != tc.code {
+			t.Errorf("dependencyPackage %q: expected %s, got %v", tc.pkg, tc.code, err)
+		}
+	}
+}
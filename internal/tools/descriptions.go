@@ -11,97 +11,187 @@ Example: listPackages { "dir": "." }
 
 // ListSymbolsDesc describes the listSymbols tool.
 const ListSymbolsDesc = `
-List functions, structs, interfaces, and methods in a package (go list path).
+List functions, structs, interfaces, and methods in a package (go list path); withFingerprints adds source fingerprints,
+withSignatures adds receiver, compact signature (types only) and generic flag. Symbols of constrained files
+carry buildConstraint. dependencyPackage lists a dependency's package instead (result marked external, with its module version).
+Every symbol carries symbolId, "pkgpath.[Owner.]Name#kind" (e.g. "example.com/app/store.Store.Save#func"), stable across edits
+that move lines; pass it as symbolId to getReferences, getSymbolContext or renameSymbol to select exactly that symbol.
+An unknown package fails with NOT_FOUND, listing the package paths nearest to it first in details.candidates.
 Example: listSymbols { "dir": ".", "package": "go-navigator/internal/tools" }
+Example: listSymbols { "dir": ".", "dependencyPackage": "github.com/rs/zerolog" }
 `
 
 // GetDefinitionsDesc describes the getDefinitions tool.
 const GetDefinitionsDesc = `
-Find definition sites for an identifier; grouped by file, supports limit/offset.
+Find definition sites for an identifier; grouped by file, supports limit/offset. Platform variants in files
+excluded on this host (stat_windows.go, //go:build) are included, each entry labeled with its buildConstraint.
+snippetMode "statement" widens each snippet to the enclosing statement (or signature), "declaration" to the enclosing declaration, capped by snippetMaxLines (default 20); snippetLines sets the lines around the hit in "line" mode.
+lspLocations adds lspLocation {uri, range} with zero-based UTF-16 positions of the identifier to every entry for LSP clients.
+package (an import path) keeps only the definitions in that package.
+An ident declared nowhere (or not in package) fails with NOT_FOUND, details.candidates holding the nearest declared names; a file that holds none of its definitions is total 0.
 Example: getDefinitions { "dir": ".", "ident": "TaskService" }
 `
 
 // GetReferencesDesc describes the getReferences tool.
 const GetReferencesDesc = `
-Find usages of an identifier; grouped by file, supports limit/offset.
+Find usages of an identifier; grouped by file, supports limit/offset. Calls of an interface method through an embedded field are marked indirect.
+snippetMode "statement" widens each snippet to the enclosing statement (or signature), "declaration" to the enclosing declaration, capped by snippetMaxLines (default 20); snippetLines sets the lines around the hit in "line" mode.
+lspLocations adds lspLocation {uri, range} with zero-based UTF-16 positions of the identifier to every entry for LSP clients.
+An unknown ident fails with NOT_FOUND and the nearest declared names as details.candidates; a symbol nothing references is total 0, not an error.
 Example: getReferences { "dir": ".", "ident": "TaskService" }
+symbolId (from listSymbols, getDefinitions and other results) selects exactly one symbol instead of ident/kind, same-named locals
+included ("pkg.Func.name#var@2" for the second one); the result echoes the symbolId of the target.
+When several packages declare ident the call fails with AMBIGUOUS listing them as "kind pkg.name (file:line)"; package
+(an import path) or kind picks one.
+compact: true returns files [{file, refs: [[line, snippet], ...]}] instead of groups (a third element true marks indirect), about half the size.
+References using a function or method as a value (passed, assigned, stored, returned, or run by go/defer) carry usage "funcValue"
+and name their destination ("argument 2 of http.Handle", "map value for key \"/a\"", "go statement"). onlyFuncValues keeps just those.
+Example: getReferences { "dir": ".", "ident": "TaskService", "snippetMode": "statement" }
+Example: getReferences { "dir": ".", "ident": "handleTasks", "onlyFuncValues": true }
+Example: getReferences { "dir": ".", "symbolId": "go-navigator/internal/tools.FindReferences#func" }
 `
 
 // GetSymbolContextDesc describes the getSymbolContext tool.
 const GetSymbolContextDesc = `
 Focused context bundle: definition, key usages, direct imports.
+snippetMode "statement" widens each snippet to the enclosing statement (or signature), "declaration" to the enclosing declaration, capped by snippetMaxLines (default 20); snippetLines sets the lines around the hit in "line" mode.
+lspLocations adds lspLocation {uri, range} with zero-based UTF-16 positions of the identifier to every entry for LSP clients.
+symbolId selects exactly one symbol instead of ident/kind; definitions carry their symbolId.
+package (an import path) restricts ident to one package; a name declared in several packages fails with AMBIGUOUS otherwise.
+An unknown ident fails with NOT_FOUND, suggesting the nearest declared names in details.candidates.
 Example: getSymbolContext { "dir": ".", "ident": "DoSomething", "kind": "func" }
 `
 
 // RenameSymbolDesc describes the renameSymbol tool.
 const RenameSymbolDesc = `
 Scope-aware rename with collision detection; use dryRun first.
+Pass renames [{oldName, newName, kind}] instead of oldName/newName to apply several renames atomically with one
+combined collision check and diff.
+Generated files are skipped (skippedGenerated) unless allowGenerated; symbols declared in them are refused.
+kind (func, var, const, type, package) picks among objects sharing the name; without it a name declared with several kinds
+fails with AMBIGUOUS listing every declaration as "kind pkg.name (file:line)", as does a name declared in several packages
+unless package (an import path) picks one. symbolId (from listSymbols and other results)
+replaces oldName and kind and renames exactly that symbol, one of several same-named locals included; also in renames entries.
+diffMode: "unified" (default), "minimal" (changed lines as file:line only) or "summary" (+/- counts and lines).
+previewOnly: true stops after resolving references and returns impact (affected files and packages, per-package counts,
+test/non-test/generated occurrences, blockedByGeneratedGuard) plus collisions and changedFiles, without diffs; use it before a large dryRun.
+verifyBuild: true reloads and type-checks the module after writing and returns the verifyBuild result in build.
+Example, Benchmark and Fuzz functions named after a renamed function, type or method (ExampleF, BenchmarkT_M, ExampleT_M_suffix)
+are renamed with it and listed in companions; with renameCompanions: false, an unexported new name or a name already taken they are
+listed in companionsNeedingRename instead, since their godoc linkage breaks.
+expectedHashes {file: contentHash} from prior reads refuses the call with CONFLICT (details.staleFiles) if any listed file changed since.
+packages reports every package as clean, modified (with files) or skipped: packages with load, parse or type errors are not
+analyzed and left untouched (error holds the first one). If a skipped package mentions the old name, the rename is partial: true
+and writing it fails with TYPE_ERRORS_PRESENT unless allowPartial; symbols declared in a skipped package cannot be renamed.
 Example: renameSymbol { "dir": ".", "oldName": "List", "newName": "ListTasks", "dryRun": true }
+Example: renameSymbol { "dir": ".", "oldName": "List", "newName": "ListTasks", "previewOnly": true }
+Example: renameSymbol { "dir": ".", "symbolId": "example.com/app.Run.count#var@2", "newName": "retries", "dryRun": true }
+Example: renameSymbol { "dir": ".", "renames": [{ "oldName": "Foo", "newName": "Bar" }, { "oldName": "NewFoo", "newName": "NewBar" }], "dryRun": true }
 `
 
 // ListImportsDesc describes the listImports tool.
 const ListImportsDesc = `
 List imports per file; optional package filter (go list path).
+aggregate=true returns one entry per external module instead: its version (or replacement), the import paths used with file and package counts, the number of importing files and packages, and the top importing packages; module-internal and standard library imports are only counted, import paths no module provides are listed as unresolved.
 Example: listImports { "dir": ".", "package": "go-navigator/internal/tools" }
+Example: listImports { "dir": ".", "aggregate": true }
 `
 
 // ListInterfacesDesc describes the listInterfaces tool.
 const ListInterfacesDesc = `
 List interfaces and methods; optional package filter (go list path).
+Scope with exportedOnly, minImplementations (implementing types and extending interfaces in the module) and usedAsParameter (appears as a parameter or field type); the last two need type information and add implementationCount and usageCount to every interface.
 Example: listInterfaces { "dir": ".", "package": "go-navigator/internal/tools" }
+Example: listInterfaces { "dir": ".", "exportedOnly": true, "minImplementations": 2 }
+Example: listInterfaces { "dir": ".", "dependencyPackage": "io" }
 `
 
 // GetComplexityReportDesc describes the getComplexityReport tool.
 const GetComplexityReportDesc = `
-Function metrics: LoC, nesting depth, cyclomatic complexity; optional package filter.
-Example: getComplexityReport { "dir": ".", "package": "go-navigator/internal/tools" }
+Function metrics: LoC, nesting depth, cyclomatic and cognitive complexity; optional package filter.
+Function literals are reported as separate entries (closure: true) named like the runtime does (Handler.func1, Handler.func1.1); their statements are not counted in the enclosing function.
+Set top (with sortBy: cyclomatic|cognitive|lines|nesting, order: desc|asc) for a ranked list of the worst functions plus module aggregates.
+With top, functions marked "//gonav:ignore getComplexityReport [reason]" (or "all") on or directly above them are left out of the ranking and listed in suppressed.
+detectRecursion marks functions that call themselves (recursive) or belong to a mutual recursion cycle in their package (recursionCycle), with per-package counts in the summary; calls are resolved with type information.
+With top and withHints, nextSteps holds a getFunctionSource call for the worst ranked function.
+ownersFile (or the server's --owners-file) names a CODEOWNERS file: every file group and ranked function gets owner, and groupByOwner adds byOwner counts of the analyzed functions.
+Example: getComplexityReport { "dir": ".", "sortBy": "cognitive", "top": 10 }
+Example: getComplexityReport { "dir": ".", "top": 20, "detectRecursion": true }
+Example: getComplexityReport { "dir": ".", "top": 20, "ownersFile": ".github/CODEOWNERS", "groupByOwner": true }
 `
 
 // GetDeadCodeReportDesc describes the getDeadCodeReport tool.
 const GetDeadCodeReportDesc = `
-Unused symbols report; optional package filter and limit.
+Unused symbols report; optional package filter and limit. Unused exported symbols of internal/ packages are flagged internalExported (disable with checkInternalExported=false).
+Declarations marked "//gonav:ignore getDeadCodeReport [reason]" (or "all") on or directly above them are listed in suppressed instead of unused; a directive above a grouped var/const/type block covers every spec.
+includeWriteOnly also reports variables that are assigned but never read as kind "write-only-var" (blank variables, named results and variables whose address is taken are excluded).
+withHints adds nextSteps: a ready previewDelete call for each of the first reported symbols.
+Packages in testdata directories (fixtures) are skipped and counted in skippedTestdataPackages; includeTestdata analyzes them too.
+ownersFile (or the server's --owners-file) names a CODEOWNERS file resolving the owner of every unused symbol's file (last matching rule wins); groupByOwner adds byOwner counts, "(unowned)" for files no rule matches.
 Example: getDeadCodeReport { "dir": ".", "package": "go-navigator/internal/tools", "limit": 10 }
+Example: getDeadCodeReport { "dir": ".", "includeWriteOnly": true }
+Example: getDeadCodeReport { "dir": ".", "ownersFile": ".github/CODEOWNERS", "groupByOwner": true }
 `
 
 // GetDependencyGraphDesc describes the getDependencyGraph tool.
 const GetDependencyGraphDesc = `
-Internal package dependency graph; optional package filter.
+Internal package dependency graph; optional package filter. Import cycles, which the go command rejects, are reported from its errors.
+withHints adds nextSteps: a listImports call for the package whose import closes each cycle.
+Over imports between internal packages, each package gets instability fanOut/(fanIn+fanOut) and layerDepth (longest import
+chain down to a leaf), and violations lists imports of a less stable package by a more stable one. sortBy orders packages by
+instability, fanIn or depth, highest first.
 Example: getDependencyGraph { "dir": ".", "package": "go-navigator/internal/tools" }
+Example: getDependencyGraph { "dir": ".", "sortBy": "instability" }
 `
 
 // GetImplementationsDesc describes the getImplementations tool.
 const GetImplementationsDesc = `
-Interface <-> concrete type implementations.
+Interface <-> concrete type implementations. With dependencyPackage, both are looked up in that dependency package.
+When nothing implements the interface, withHints adds nextSteps: an explainImplements call for the module type sharing most of its method names.
+lspLocations adds lspLocation {uri, range} with zero-based UTF-16 positions of the identifier to every entry for LSP clients.
+total counts the implementations: an interface nothing implements is total 0, an unknown name NOT_FOUND with the nearest type names as details.candidates.
 Example: getImplementations { "dir": ".", "name": "Repository" }
 `
 
 // GetMetricsSummaryDesc describes the getMetricsSummary tool.
 const GetMetricsSummaryDesc = `
 Aggregated metrics (counts, avg complexity, unused ratios); optional package filter.
+excludeHeaderComments leaves the leading comment block before the package clause (license headers) out of lineCount.
 Example: getMetricsSummary { "dir": ".", "package": "go-navigator/internal/tools" }
 `
 
 // RewriteAstDesc describes the rewriteAst tool.
 const RewriteAstDesc = `
 Semantic AST rewrite with pattern matching; supports dryRun.
+Generated files are skipped (skippedGenerated) unless allowGenerated.
+diffMode: "unified" (default), "minimal" (changed lines as file:line only) or "summary" (+/- counts and lines).
+verifyBuild: true reloads and type-checks the module after writing and returns the verifyBuild result in build.
+typeCheck (default true) type-checks the rewritten files in memory first: replacements introducing type errors are left out and listed in rejected {file, line, error} while the others apply; atomic: true applies nothing when any is rejected. Errors that cannot be tied to one replacement (e.g. an import left unused) reject the remaining replacements of their file.
+expectedHashes {file: contentHash} from prior reads refuses the call with CONFLICT (details.staleFiles) if any listed file changed since.
 Example: rewriteAst { "dir": ".", "find": "fmt.Println(x)", "replace": "log.Print(x)", "dryRun": true }
 `
 
 // GetFunctionSourceDesc describes the getFunctionSource tool.
 const GetFunctionSourceDesc = `
-Return function/method source + metadata by name.
+Return function/method source + metadata by name; contentHash of its file guards later edits (expectedHash).
+includeExamples adds, capped by maxExamples (default 5), the Example functions documenting it per go doc naming (ExampleF, ExampleT_M, optional _suffix) and the tests named after it, with verbatim source.
 Example: getFunctionSource { "dir": ".", "name": "TaskService.List" }
+Example: getFunctionSource { "dir": ".", "name": "TaskService.List", "includeExamples": true, "maxExamples": 3 }
+names [..] instead of name returns several in one call: functions in request order plus notFound; maxTotalLines caps source lines across them, cutting later bodies (truncated: true, signature kept).
+Example: getFunctionSource { "dir": ".", "dependencyPackage": "strings", "name": "Cut" }
+Example: getFunctionSource { "dir": ".", "names": ["TaskService.List", "TaskService.Get", "newStore"], "maxTotalLines": 200 }
 `
 
 // GetFileInfoDesc describes the getFileInfo tool.
 const GetFileInfoDesc = `
-Read file metadata; optional source/comments/bodies via options/filter.
+Read file metadata; optional source/comments/bodies via options/filter. contentHash guards later edits (expectedHash).
 Example: getFileInfo { "dir": ".", "file": "internal/tools/server.go", "options": { "withSource": true } }
 `
 
 // GetStructInfoDesc describes the getStructInfo tool.
 const GetStructInfoDesc = `
-Return a struct declaration; includeMethods lists associated methods.
+Return a struct declaration with the contentHash of its file; includeMethods lists associated methods; includeExamples adds its ExampleT[_suffix] functions and the tests named after it (maxExamples, default 5).
+dependencyPackage reads the struct from a dependency loaded in the module's context.
 Example: getStructInfo { "dir": ".", "name": "User", "includeMethods": true }
 `
 
@@ -111,6 +201,8 @@ Aggregates a full structural schema of a Go project including:
 - packages and their imports
 - structs, interfaces, and functions
 - external dependencies and inter-package dependency graph
+- packageDirs (import path -> directory relative to rootDir) and per-package fileCount/testFileCount, at every depth
+- entry points (standard and above): every main package with the functions main/init call, the flags it defines (flag/pflag with constant names) and the module packages it wires in, up to 'maxDepth' import levels (default 2)
 
 🪶 Use when:
 - You need a high-level overview of a Go module
@@ -122,4 +214,488 @@ Aggregates a full structural schema of a Go project including:
 
 💡 Example:
 getProjectSchema { "dir": ".", "depth": "standard" }
+getProjectSchema { "dir": ".", "maxDepth": 3 }
+`
+
+// AnalyzePurityDesc describes the analyzePurity tool.
+const AnalyzePurityDesc = `
+Classify functions as pure, reads-global, writes-global or performs-IO with the call chain behind each verdict.
+ownersFile (or the server's --owners-file) adds the CODEOWNERS owner of every file group; groupByOwner counts functions per owner.
+Example: analyzePurity { "dir": ".", "package": "go-navigator/internal/tools" }
+`
+
+// AnalyzeLoggingDesc describes the analyzeLogging tool.
+const AnalyzeLoggingDesc = `
+Inventory logging calls (logger package, level, enclosing function, error argument), flag packages mixing logger families and fmt.Print* leftovers.
+ownersFile (or the server's --owners-file) adds the CODEOWNERS owner of every file group; groupByOwner counts calls per owner.
+Example: analyzeLogging { "dir": ".", "loggers": ["log", "github.com/rs/zerolog"] }
+`
+
+// ReorderDeclarationsDesc describes the reorderDeclarations tool.
+const ReorderDeclarationsDesc = `
+Reorder top-level declarations (type, constructors, exported then unexported methods); use dryRun first.
+Generated files are skipped (skippedGenerated) unless allowGenerated.
+diffMode: "unified" (default), "minimal" (changed lines as file:line only) or "summary" (+/- counts and lines).
+verifyBuild: true reloads and type-checks the module after writing and returns the verifyBuild result in build.
+expectedHash (contentHash from a prior read) refuses the call with CONFLICT if the file changed since.
+Example: reorderDeclarations { "dir": ".", "file": "internal/tools/cache.go", "policy": "std", "dryRun": true }
+`
+
+// CheckDeclarationOrderDesc describes the checkDeclarationOrder tool.
+const CheckDeclarationOrderDesc = `
+Report top-level declarations that violate an ordering policy; read-only.
+Example: checkDeclarationOrder { "dir": ".", "file": "internal/tools/cache.go", "policy": "visibility" }
+`
+
+// WatchProjectDesc describes the watchProject tool.
+const WatchProjectDesc = `
+Subscribe to change notifications for a project: changed files and invalidated caches arrive as logging messages (at most one per second; set a log level first).
+Example: watchProject { "dir": "." }
+`
+
+// UnwatchProjectDesc describes the unwatchProject tool.
+const UnwatchProjectDesc = `
+Stop change notifications for a project previously passed to watchProject.
+Example: unwatchProject { "dir": "." }
+`
+
+// FindConstructionsDesc describes the findConstructions tool.
+const FindConstructionsDesc = `
+Find where a type is constructed: composite literals (positional ones flagged), optionally new(T) and functions returning T/*T with call counts.
+typeName may be qualified as pkg.Type or import/path.Type; a bare name declared in several packages fails with AMBIGUOUS listing them.
+A type never constructed is total 0; an unknown typeName fails with NOT_FOUND and suggests the nearest type names.
+Example: findConstructions { "dir": ".", "typeName": "PackageCacheItem", "includeNew": true, "includeConstructors": true }
+`
+
+// GetServerStatusDesc describes the getServerStatus tool.
+const GetServerStatusDesc = `
+Report go toolchain availability and cache statistics, including hydration of the persisted index (--cache-dir), the estimated
+memory of each in-memory load and in total against the --max-cache-mb budget, evictions, forced cleanups and the current heap,
+loads shared by concurrent identical requests and symbol lookups answered from remembered not-found results.
+Example: getServerStatus {}
+`
+
+// FindUntestedSymbolsDesc describes the findUntestedSymbols tool.
+const FindUntestedSymbolsDesc = `
+List exported functions and methods never referenced from any _test.go file, grouped by package with tested/total ratios (least tested first).
+Example: findUntestedSymbols { "dir": ".", "package": "go-navigator/internal/tools", "kinds": ["func"] }
+`
+
+// GetFingerprintsDesc describes the getFingerprints tool.
+const GetFingerprintsDesc = `
+Return per-file maps of declaration name → SHA-256 fingerprint of the normalized source (gofmt tokens, comments stripped) to detect stale cached artifacts.
+Example: getFingerprints { "dir": ".", "package": "go-navigator/internal/tools" }
+`
+
+// DescribeJSONShapeDesc describes the describeJSONShape tool.
+const DescribeJSONShapeDesc = `
+Report the effective serialized shape of a struct (JSON or YAML): keys with Go type and source path after embedding promotion, plus dropped, shadowed, ambiguous and unexported fields.
+Example: describeJSONShape { "dir": ".", "typeName": "tools.ListSymbolsOutput", "format": "json" }
+`
+
+// ExplainImplementsDesc describes the explainImplements tool.
+const ExplainImplementsDesc = `
+Explain whether a type implements an interface (value or pointer receiver); otherwise list missing methods with wanted signatures, wrong-signature methods side by side, and ready-to-paste stubs.
+Example: explainImplements { "dir": ".", "typeName": "MemStore", "interfaceName": "Storage" }
+`
+
+// WarmupDesc describes the warmup tool.
+const WarmupDesc = `
+Load and pin a module in the package cache so later calls skip packages.Load; returns timing and counts only. Fire it right after connecting.
+Example: warmup { "dir": ".", "packages": ["go-navigator/internal/tools"], "includeTests": true }
+`
+
+// AnalyzeAllocationsDesc describes the analyzeAllocations tool.
+const AnalyzeAllocationsDesc = `
+Rank syntactic allocation hotspots by loop depth: returned pointers to locals, appends without preallocation, string += in loops, fmt.Sprintf keys and []byte/string conversions in loops, each with a remediation hint.
+Example: analyzeAllocations { "dir": ".", "package": "go-navigator/internal/tools" }
+`
+
+// CheckArchitectureDesc describes the checkArchitecture tool.
+const CheckArchitectureDesc = `
+Check module-internal imports against directory rules {from, allow, deny} (globs on module-relative dirs or import paths, '**' spans segments, deny wins); reports pass/fail, each offending import with file:line, and counts per rule. Without rules, reads go-navigator.rules.json from the module root.
+Example: checkArchitecture { "dir": ".", "rules": [{ "from": "internal/handlers/**", "allow": ["internal/services/**"] }, { "from": "**", "deny": ["internal/handlers/**"] }] }
+`
+
+// GetTypeInfoDesc describes the getTypeInfo tool.
+const GetTypeInfoDesc = `
+Describe any named type (struct, map, slice, func, basic, …): underlying kind and type, value vs pointer receiver methods, struct fields, doc, location, and for types on a basic type the constants declared with it. getStructInfo is the struct-only view.
+An unknown name fails with NOT_FOUND, the nearest type names in details.candidates.
+Example: getTypeInfo { "dir": ".", "name": "Celsius" }
+`
+
+// FindSwallowedErrorsDesc describes the findSwallowedErrors tool.
+const FindSwallowedErrorsDesc = `
+Find calls whose error result is dropped (unchecked call, assigned to _, deferred or go call) inside functions that do not return an error themselves; each site names the callee and its context (defer, goroutine, test file), with counts per package. Skip known-safe callees with excludeCallees.
+Example: findSwallowedErrors { "dir": ".", "includeTests": true, "excludeCallees": ["fmt.Fprintf", "(*strings.Builder).WriteString"] }
+`
+
+// GetAuditLogDesc describes the getAuditLog tool.
+const GetAuditLogDesc = `
+Recent file mutations from the --audit-log JSONL (tool, input, file, before/after SHA-256, hunks); oldest first.
+Example: getAuditLog { "limit": 20, "sinceTimestamp": "2026-01-01T00:00:00Z" }
+`
+
+// AnalyzeExternalUsageDesc describes the analyzeExternalUsage tool.
+const AnalyzeExternalUsageDesc = `
+Exported symbols of a library module and how often consumer modules reference them; unreferenced symbols are
+removal candidates, methods reachable only through a consumer interface are reported as "interface".
+Example: analyzeExternalUsage { "libraryDir": "./lib", "consumerDirs": ["../app1", "../app2"] }
+`
+
+// CheckLanguageLevelDesc describes the checkLanguageLevel tool.
+const CheckLanguageLevelDesc = `
+Compare the go.mod go directive with detected constructs (generics, fuzzing, min/max/clear, loop variable
+capture, range over int/func, new std packages); reports uses needing a newer version and a directive far ahead.
+Example: checkLanguageLevel { "dir": "." }
+`
+
+// ExportIndexDesc describes the exportIndex tool.
+const ExportIndexDesc = `
+Write a versioned JSON (or .gz) index artifact of the module with per-file SHA-256 hashes and the selected
+sections: symbols, references, dependencies, interfaces, complexity (default all). Load it with importIndex.
+Example: exportIndex { "dir": ".", "outFile": ".go-navigator/index.json.gz", "include": ["symbols", "complexity"] }
+`
+
+// ImportIndexDesc describes the importIndex tool.
+const ImportIndexDesc = `
+Load an exportIndex artifact: files whose hash still matches answer listSymbols, listImports,
+getComplexityReport and summary getProjectSchema from it (others are analyzed live); getReferences and
+getImplementations use it while every file matches. Artifacts of another format version are rejected.
+Example: importIndex { "file": ".go-navigator/index.json.gz" }
+`
+
+// FindMagicValuesDesc describes the findMagicValues tool.
+const FindMagicValuesDesc = `
+String and number literals repeated within a package (minOccurrences, default 3; strings of minStringLength 4+),
+most frequent first, with file/line/function per use and an existing package constant of the same value to reuse.
+Struct tags, import paths and constant declarations are skipped; ignoreValues defaults to 0, 1, -1 and "".
+Example: findMagicValues { "dir": ".", "ignoreTests": true, "ignoreValues": ["0", "1", "\"\"", "100"] }
+`
+
+// ResolvePositionDesc describes the resolvePosition tool.
+const ResolvePositionDesc = `
+Map a batch of file:line positions (stack trace frames, coverage lines) to the enclosing function (name, receiver,
+start/end lines), the enclosing type declaration, the package, and whether the line is blank or inside a comment.
+Files match by absolute path, path relative to dir, or path suffix; unresolvable positions carry an error.
+Example: resolvePosition { "dir": ".", "positions": [{ "file": "/build/app/internal/tools/finders.go", "line": 120 }] }
+`
+
+// ListGeneratorsDesc describes the listGenerators tool.
+const ListGeneratorsDesc = `
+Inventory //go:generate directives per package: command and arguments split as go generate does, generator tool
+(including go run/go tool), -command aliases, output file (-o/-output/-destination, stringer default) fresh/stale/missing
+by mtime against the directive's file, and whether the binary is found on PATH/GOBIN/GOPATH/bin. Nothing is executed.
+Example: listGenerators { "dir": ".", "package": "go-navigator/internal/tools" }
+`
+
+// SuggestFileSplitDesc describes the suggestFileSplit tool.
+const SuggestFileSplitDesc = `
+Propose splitting an oversized file into files of at most targetMaxLines (default 800) without writing anything.
+Methods and constructors stay with their type; other declarations are grouped by references to each other's
+unexported names, shared unexported helpers and rarely used imports. Each part lists its declarations, the imports
+it needs and a name from its dominant type or function prefix; the largest part keeps the original file.
+Example: suggestFileSplit { "dir": ".", "file": "internal/tools/helpers.go", "targetMaxLines": 600 }
+`
+
+// ApplyFileSplitDesc describes the applyFileSplit tool.
+const ApplyFileSplitDesc = `
+Split a file as suggestFileSplit proposes, or by an explicit plan of {file, declarations} parts (unlisted
+declarations stay). New files keep the build constraints and package clause, declarations keep their doc comments,
+and every file imports only what it uses. The package is type-checked with the new contents before writing.
+Start with dryRun=true; diffMode selects unified, minimal or summary diffs. Test and generated files are refused.
+verifyBuild: true reloads and type-checks the module after writing and returns the verifyBuild result in build.
+expectedHash (contentHash from a prior read) refuses the call with CONFLICT if the file changed since.
+Example: applyFileSplit { "dir": ".", "file": "internal/tools/helpers.go", "parts": [{ "file": "diff.go", "declarations": ["diffFiles", "minimalDiff"] }], "dryRun": true }
+`
+
+// VerifyBuildDesc describes the verifyBuild tool.
+const VerifyBuildDesc = `
+Does the module still build? Reloads from disk, bypassing the package cache, and type-checks every package with its tests
+(or only the given packages). Returns ok, list/parse/type errors with file:line:column, and elapsedMs.
+Mutating tools run the same check after writing when called with verifyBuild: true.
+Example: verifyBuild { "dir": "." }
+Example: verifyBuild { "dir": ".", "packages": ["./internal/..."] }
+`
+
+// NavigateFileDesc describes the navigateFile tool.
+const NavigateFileDesc = `
+Step through one file's top-level declarations without reading source: direction "at" (default) returns the
+declaration containing line, "next"/"prev" the one after/before it, "first"/"last" the ends, each with previous/next
+neighbours and spans (docLine, startLine, endLine). Optional kind: func, method, type, const or var.
+Parses only that file; repeat calls are answered from a cached index. contentHash guards later edits (expectedHash).
+Example: navigateFile { "dir": ".", "file": "internal/tools/cache.go", "line": 120 }
+Example: navigateFile { "dir": ".", "file": "internal/tools/cache.go", "line": 120, "direction": "next", "kind": "func" }
+`
+
+// AnalyzeConfigSurfaceDesc describes the analyzeConfigSurface tool.
+const AnalyzeConfigSurfaceDesc = `
+Configuration surface: os.Getenv/LookupEnv, flag definitions (functions and FlagSet methods) and viper Get* reads,
+plus extraFunctions (path.Match patterns of pkg/path.Func or pkg/path.Type.Method; key = first string constant argument).
+Returns keys sorted by name with sources, Go types (following an immediate strconv.Parse*/Atoi or time.ParseDuration)
+and every read with its enclosing function; reads with a non-constant key are listed in dynamicReads with keyExpr.
+Example: analyzeConfigSurface { "dir": ".", "extraFunctions": ["example.com/app/config.Lookup*"] }
+`
+
+// ListRootsDesc describes the listRoots tool.
+const ListRootsDesc = `
+List the module directories registered at startup with --root name=path. Any tool taking dir accepts root: <name> instead; with a single registered root, dir and root may both be omitted.
+Example: listRoots {}
+`
+
+// PreviewDeleteDesc describes the previewDelete tool.
+const PreviewDeleteDesc = `
+Preview deleting a symbol without modifying anything: every reference that would break, grouped by package and file with snippets; for a method, the types that would stop implementing a module interface; the test files using it; and a verdict ("safe" or "breaks N references in M packages"). Says explicitly when the symbol is already dead code. Methods are written as Type.Method. A name declared in several packages fails with AMBIGUOUS unless package (an import path) picks one.
+Example: previewDelete { "dir": "/path/to/project", "symbol": "Store.Close", "kind": "func" }
+`
+
+// FindOverexportedSymbolsDesc describes the findOverexportedSymbols tool.
+const FindOverexportedSymbolsDesc = `
+Find exported funcs, types, vars and consts referenced only from their own package, which could be unexported to shrink the API surface. Reports reference counts grouped by package, a suggested unexported name (HTTPClient -> httpClient) checked against the package scope, imports and predeclared identifiers, and the exact renameSymbol input to apply it. Unreferenced symbols are left to getDeadCodeReport; symbols in generated files are skipped unless includeGenerated is set; ignoreTestReferences lets references from other packages' tests not count.
+Example: findOverexportedSymbols { "dir": "/path/to/project", "package": "example.com/app/internal/store", "excludeMain": true }
+`
+
+// AnalyzeFieldUsageDesc describes the analyzeFieldUsage tool.
+const AnalyzeFieldUsageDesc = `
+Per-field usage of a struct (typeName) or of every struct in package, across the module and its tests: reads, writes (assignment, ++/--, &field) and composite literal initializations, with up to maxSamples (default 3) locations each. Selections resolve through type information, so promoted fields count for the embedded struct declaring them and also as reads of the embedded field. Fields nobody reads are flagged unread (untouched when not even written); an unread field with a json name is flagged serialized, as encoding/json may be its only reader.
+Example: analyzeFieldUsage { "dir": ".", "typeName": "Config" }
+Example: analyzeFieldUsage { "dir": ".", "package": "example.com/app/internal/model" }
+`
+
+// SuggestParameterObjectsDesc describes the suggestParameterObjects tool.
+const SuggestParameterObjectsDesc = `
+Suggest parameter objects from signatures alone (go/types, no bodies): functions with more than maxParams (default 5) parameters are flagged exceedsMax, and per package every group of 3+ parameters with identical names and types shared by 3+ signatures is reported with its functions and a suggested struct declaration. context.Context, variadic, unnamed and blank parameters are never grouped. Groups are ordered by how many functions they would simplify.
+Example: suggestParameterObjects { "dir": ".", "package": "example.com/app/internal/client", "maxParams": 6 }
+`
+
+// AnalyzeDefersDesc describes the analyzeDefers tool.
+const AnalyzeDefersDesc = `
+Find suspicious defer statements, grouped by category: defer-in-loop (the call waits for the function to return, so resources pile up per iteration), unchecked-close (defer x.Close() drops its error in a function returning an error; the suggestion shows the named-result wrapper), loop-var-capture (a deferred closure uses a loop variable in a file before Go 1.22) and nil-func-value (a func variable declared nil and assigned only in if/switch branches before the defer). Defers inside function literals are judged against the literal's own loops and results. Each finding has file, line, enclosing function, message and suggestion.
+Example: analyzeDefers { "dir": ".", "package": "example.com/app/internal/store" }
+`
+
+// FindTypeAssertionsDesc describes the findTypeAssertions tool.
+const FindTypeAssertionsDesc = `
+List type assertions x.(T) and type switches whose operand is statically an interface (interfaceName, e.g. "Shape" or "lang.Shape", or every interface when omitted), grouped by interface then file. Each site has line, enclosing function, operand and the asserted type(s); single-value assertions that panic on a mismatch are marked panicking, type switches list every case type and set noDefault when there is no default case. Use before changing an interface to find the code that depends on its concrete types.
+An interfaceName that names no known type fails with NOT_FOUND (nearest type names in details.candidates) instead of matching nothing.
+Example: findTypeAssertions { "dir": ".", "interfaceName": "Shape" }
+Example: findTypeAssertions { "dir": ".", "package": "example.com/app/internal/store" }
+`
+
+// FindInterfaceConversionsDesc describes the findInterfaceConversions tool.
+const FindInterfaceConversionsDesc = `
+Find where values of a concrete type (typeName; T and *T, marked pointer) are implicitly converted to an interface: kind "arg" (interface parameter, variadic and append included), "assign" (assignment or typed var declaration), "return" (interface result) or "composite" (element, field value, map key or value of a literal). interfaceName ("Shape", "lang.Shape", "error", "any") keeps one target interface. Untyped nil, explicit conversions, values already of an interface type and generic type parameters are not reported. Complements getImplementations (what could implement) with where the type is actually used polymorphically; grouped by file with line, function and snippet.
+An unknown typeName or interfaceName fails with NOT_FOUND and nearest-name candidates; a type never converted is total 0.
+Example: findInterfaceConversions { "dir": ".", "typeName": "FileStore" }
+Example: findInterfaceConversions { "dir": ".", "typeName": "FileStore", "interfaceName": "Store" }
+`
+
+// FindCallPathDesc describes the findCallPath tool.
+const FindCallPathDesc = `
+Show how one function reaches another: up to maxPaths (default 3) shortest static call chains from "from" to "to" ("Func" or "Type.Method"), at most maxDepth calls (default 8). Each step has function, package, file and the line of its call into the next step (the last step has its declaration line); calls of interface methods continue into every in-module implementation and are marked dynamic. Calls inside function literals count for the enclosing function; function values are not followed. No chain is a result with empty paths, total 0 and a message, not an error; an unknown from or to fails with NOT_FOUND, suggesting the nearest function names. The call graph is cached per module until its packages reload.
+Example: findCallPath { "dir": ".", "from": "HandleRequest", "to": "SaveUser" }
+Example: findCallPath { "dir": ".", "from": "Server.Serve", "to": "Store.Save", "maxDepth": 5, "maxPaths": 1 }
+`
+
+// AnalyzeSQLDesc describes the analyzeSQL tool.
+const AnalyzeSQLDesc = `
+Inventory raw SQL passed to database calls, grouped by package with counts per statement type. callPatterns select the calls
+("(*pkg.Type).Method", "(*pkg.Type)" for every method, "pkg.Func"; default (*database/sql.DB).Query/Exec/QueryRow and every
+(*github.com/jmoiron/sqlx.DB) method); the first string argument is the SQL. Literals, named constants and locals assigned one
+constant are resolved to their text and classified by leading keyword (SELECT, INSERT, UPDATE, DELETE, DDL, OTHER, UNKNOWN).
+Queries concatenated or fmt.Sprintf-formatted from non-constant values, directly or through the local passed, are flagged
+injectionRisk. Each query has file, line, enclosing function and call.
+Example: analyzeSQL { "dir": ".", "package": "./internal/store/..." }
+Example: analyzeSQL { "dir": ".", "callPatterns": ["(*database/sql.Tx).*Context", "(*example.com/app/db.Conn)"] }
+`
+
+// CheckFileHeadersDesc describes the checkFileHeaders tool.
+const CheckFileHeadersDesc = `
+Check the header comment of every file: the first comment before the package clause other than build constraints and the
+"Package x" doc comment. The header text, comment markers stripped, must start with requiredPattern or match it as a regular
+expression. Issues are missing (no header), mismatch (with the header's first line) and unexpected: capture groups of the pattern,
+keyed by name or 1-based index, hold values other than the expected ones, given in expected or else the value most files have
+(e.g. a different year or company). Generated files and files matching exclude globs are skipped.
+Example: checkFileHeaders { "dir": ".", "requiredPattern": "Copyright" }
+Example: checkFileHeaders { "dir": ".", "requiredPattern": "Copyright (?P<year>\\d{4}) (?P<company>.+?)\\.", "exclude": ["third_party/**"], "expected": { "company": "Acme Inc" } }
+`
+
+// AnalyzeLifecyclesDesc describes the analyzeLifecycles tool.
+const AnalyzeLifecyclesDesc = `
+Goroutine and timer leak heuristics, grouped by category with file, line, enclosing function, leaked resource and fix:
+unstoppable-goroutine (a function starts a goroutine sending on a channel it returns, directly or as a field of a returned value,
+with no context or done channel parameter and no Close/Stop method on the receiver or result; "accept ctx and select"),
+time-tick (time.Tick, whose ticker is never stopped; "use NewTicker + Stop") and after-in-loop (time.After inside a loop, a timer
+per iteration; "use NewTimer + Stop"). extraLeakyFunctions adds wrappers of time.After (path.Match patterns of pkg/path.Func or
+pkg/path.Type.Method). Function literals have their own loops.
+Example: analyzeLifecycles { "dir": ".", "package": "./internal/..." }
+Example: analyzeLifecycles { "dir": ".", "extraLeakyFunctions": ["example.com/app/clock.After*"] }
+`
+
+// InspectNodeDesc describes the inspectNode tool.
+const InspectNodeDesc = `
+Syntax node chain at a position, from the file root to the innermost node, for planning rewrites rewriteAst cannot express.
+Each node has kind (go/ast type), label (Name=Load, Fun=os.Stat, Tok=:=, ...), start/end line and column and text (the source
+with white space collapsed, cut at 120 characters; never the whole file); path joins them, e.g.
+"File(Name=main) > FuncDecl(Name=Load) > BlockStmt > IfStmt > CallExpr(Fun=os.Stat)". column is a 1-based byte column, omitted
+for the first non-blank character of the line. depth keeps the innermost nodes only (truncated: true); parentOf: true returns
+only the smallest enclosing statement, spec or declaration. Files match by absolute, relative or suffix path.
+Example: inspectNode { "dir": ".", "file": "internal/config/load.go", "line": 42, "column": 9 }
+Example: inspectNode { "dir": ".", "file": "load.go", "line": 42, "parentOf": true }
+`
+
+// ReleaseReportDesc describes the releaseReport tool.
+const ReleaseReportDesc = `
+Pre-release checklist of the module containing dir, one section per check with status pass, warn or fail and its findings
+(file, line, symbol, kind, message) sorted by file and line; status is the worst section, ready to post on a PR.
+Sections, in order: api-diff (public symbols added (warn) and removed (fail) since baselineIndexFile, an exportIndex
+artifact with the symbols section from the previous release; skip without one), undocumented (public symbols without a
+doc comment), deprecated-usage (uses of symbols whose doc has a "Deprecated:" paragraph, outside deprecated code),
+todos (TODO and FIXME comments; warn on FIXME only), dead-internal-exports (unused exported symbols of internal packages)
+and dependency-cycles (fail). Public means exported from a non-internal, non-main package outside _test.go files.
+Example: releaseReport { "dir": ".", "baselineIndexFile": "release/v1.4.0-index.json.gz" }
+`
+
+// AnalyzeReceiverSemanticsDesc describes the analyzeReceiverSemantics tool.
+const AnalyzeReceiverSemanticsDesc = `
+Receiver and lock copying bugs, grouped by category with file, line, enclosing function, type and message:
+value-receiver-mutation (a value-receiver method assigns to or increments a field of its receiver, and the change is lost),
+mixed-receivers (a named type declares both pointer and value receiver methods) and lock-copy (as go vet copylocks: a receiver,
+parameter, assignment, var declaration or range variable copies a value whose type contains a lock such as sync.Mutex or
+sync.WaitGroup, directly, in an array or in a field; the message shows the path, e.g. "store.Cache contains sync.Mutex").
+Composite literals and call results are new values and never reported.
+Example: analyzeReceiverSemantics { "dir": ".", "package": "./internal/..." }
+`
+
+// AnalyzeImportWeightDesc describes the analyzeImportWeight tool.
+const AnalyzeImportWeightDesc = `
+Binary size hints from the import graph, no compilation: for every module package (or only mainPackage and the module
+packages it imports) the transitive external (other modules), stdlib and total packages and files it pulls in, and its top
+heaviest direct imports. An import edge weighs what only it reaches: the packages of its closure that no other direct import of
+the package reaches, so edges ranks the single imports whose replacement removes the most dependencies ("replace this yaml lib").
+Example: analyzeImportWeight { "dir": "." }
+Example: analyzeImportWeight { "dir": ".", "mainPackage": "example.com/app/cmd/server", "top": 5 }
+`
+
+// ResolvePackageDirDesc describes the resolvePackageDir tool.
+const ResolvePackageDirDesc = `
+Map import paths (as returned by most tools) to package directories relative to dir, for dir and file inputs, file reads
+and git commands; a lightweight alternative to getProjectSchema's packageDirs. Without packages every module package is mapped;
+requested paths that are not module packages are listed in unresolved.
+Example: resolvePackageDir { "dir": ".", "packages": ["example.com/app/internal/store"] }
+`
+
+// CheckTagConsistencyDesc describes the checkTagConsistency tool.
+const CheckTagConsistencyDesc = `
+Compare struct tags of several formats field by field (keys, default ["json", "yaml"]; e.g. add "mapstructure"), grouped by struct
+with file/line per field and a count per package: name-mismatch (json:"userID" yaml:"user_id", options like omitempty stripped;
+empty names keep the library default and are not compared), missing-key (a key other fields of the struct use, or with
+requireAll any key, is absent) and skip-conflict (json:"-" while yaml names the field). Syntax only.
+Example: checkTagConsistency { "dir": "." }
+Example: checkTagConsistency { "dir": ".", "package": "example.com/app/config", "keys": ["json", "yaml", "mapstructure"], "requireAll": true }
+`
+
+// SuggestIdentifierRenamesDesc describes the suggestIdentifierRenames tool.
+const SuggestIdentifierRenamesDesc = `
+Find declared identifiers breaking Go naming rules (rules, default all): underscore (user_name -> userName, MAX_SIZE -> MaxSize),
+initialisms (GetUserId -> GetUserID, parseUrls -> parseURLs), hungarian (strPrefix string -> prefix, bReady bool -> ready; only
+when the prefix matches the type) and stutter (package user: UserService -> Service). One suggestion per identifier fixes all
+selected rules, with the reason for each. The new name is checked like renameSymbol checks it (same scope, shadowed references,
+struct fields and methods, keywords, predeclared names); clean suggestions carry a ready renameSymbol input (rename, by symbolId),
+others a conflict. Struct fields whose json tag repeats the current name get a warning instead. Generated and test files are skipped.
+Example: suggestIdentifierRenames { "dir": "." }
+Example: suggestIdentifierRenames { "dir": ".", "package": "example.com/app/user", "rules": ["initialisms", "stutter"] }
+`
+
+// ValidateNewFileDesc describes the validateNewFile tool.
+const ValidateNewFileDesc = `
+Check a Go file before writing it (nothing is written): path (relative to dir) and the full source. Reports valid plus problems:
+packageMismatch against the package of the directory (expectedPackageName; test files may add _test, a new directory accepts any
+name and proposes its last element), syntaxErrors with line/column in the source, importCycles (an import of the module whose
+imports lead back to the file's package, with the cycle) and undefined identifiers not declared by the file, its imports, the other
+files of its package or the language (best-effort, syntax only; skipped with dot imports or syntax errors).
+Example: validateNewFile { "dir": ".", "path": "internal/store/cache.go", "source": "package store\n\nfunc Warm() {}\n" }
+`
+
+// AnalyzeInterfaceCohesionDesc describes the analyzeInterfaceCohesion tool.
+const AnalyzeInterfaceCohesionDesc = `
+Find interfaces that could be split by how their consumers use them: for every interface with at least 4 methods (or the named
+interfaceName), the functions calling its methods through values of the interface type (consumers), methods grouped into clusters
+no consumer crosses (two or more clusters suggest one interface per cluster), method subsets that several consumers use and
+nothing more ("3 of 5 consumers only use Load/Save"), and methods never called through the interface (unused).
+Example: analyzeInterfaceCohesion { "dir": "." }
+Example: analyzeInterfaceCohesion { "dir": ".", "interfaceName": "Store" }
+`
+
+// ApplyMigrationsDesc describes the applyMigrations tool.
+const ApplyMigrationsDesc = `
+Migrate uses of deprecated functions, methods and variables to their replacements from a spec of rules {findCall, replaceWith,
+argTransform}: findCall is io/ioutil.ReadFile or pkgpath.Type.Method, replaceWith os.ReadFile (a method name for methods).
+Uses are resolved through type information, so renamed imports count and same-named symbols do not. Each use gets the
+replacement selector and its arguments transformed ('same', 'dropFirst', or 'wrapContext' passing ctx or context.TODO()
+first); the new package is imported and the old import removed once unused. Uses it cannot rewrite safely (dot imports,
+shadowed package names, dropped arguments with calls, function values) are listed in manual with the reason, as are rules
+without replaceWith. preset 'ioutil' is a built-in spec for io/ioutil. dryRun returns diffs without writing.
+Example: applyMigrations { "dir": ".", "preset": "ioutil", "dryRun": true }
+Example: applyMigrations { "dir": ".", "spec": [{ "findCall": "golang.org/x/net/context.Background", "replaceWith": "context.Background" }] }
+`
+
+// UpdateFileDesc describes the updateFile tool.
+const UpdateFileDesc = `
+Bring the caches up to date after editing one file, without reloading the module: the file is parsed again, its package and
+the packages importing it directly are type-checked again against the cached packages, and every other package keeps its
+cached syntax and types, so the next queries reflect the edit at once. Call it after each edit, or pass newContent to write the
+file (atomically, audited, honoring expectedHashes and allowGenerated) and update in one step. Returns the patched loads, the
+packages rechecked with their parse and type errors, and the loads dropped for a full reload when the edit changed the
+package's imports or name, or other files changed too.
+Example: updateFile { "dir": ".", "file": "internal/tools/cache.go" }
+Example: updateFile { "dir": ".", "file": "pkg/a.go", "newContent": "package a\n\nfunc A() int { return 2 }\n" }
+`
+
+// AnalyzeHTTPSurfaceDesc describes the analyzeHTTPSurface tool.
+const AnalyzeHTTPSurfaceDesc = `
+Route table of a web service: every registration call of a router package (http.HandleFunc and ServeMux, chi Get/Post/Method/
+Route/Mount, gin GET/POST/Handle/Group, gorilla HandleFunc/Handle with Methods and PathPrefix().Subrouter()), resolved through
+type information so wrapper types embedding a router count too. Each route has method (ANY when unrestricted; Go 1.22 "GET /x"
+patterns are split), path with the prefixes of enclosing Route/Group/PathPrefix calls ({expr} and dynamic when not constant),
+handler (pkg.Func, pkg.Type.Method for method values, 'func literal', pkg.Func() for handler factories; conversions, local
+variables and wrapping calls are followed), middlewares (Use/With, leading gin handlers, wrapping calls) and file:line.
+Registrations whose handler cannot be resolved are listed in unresolved with the reason. frameworks replaces the default list
+(net/http, github.com/go-chi/chi, github.com/gin-gonic/gin, github.com/gorilla/mux); subpackages and major versions match.
+Example: analyzeHTTPSurface { "dir": "." }
+Example: analyzeHTTPSurface { "dir": ".", "frameworks": ["github.com/go-chi/chi", "example.com/internal/router"] }
+`
+
+// GetModuleInfoDesc describes the getModuleInfo tool.
+const GetModuleInfoDesc = `
+Parsed go.mod of the module containing dir (golang.org/x/mod/modfile): module path, go and toolchain directives, requires
+(path, version, indirect), replaces (old[@version] → new[@version], local set for directory replacements), excludes and retract
+ranges with their rationale. goSumEntries counts the go.sum hash lines; requires (after replacements, local ones skipped) with no
+go.sum entry are listed in missingSums and set goSumIncomplete — a set comparison, nothing is downloaded.
+Example: getModuleInfo { "dir": "." }
+`
+
+// ExplainTypeErrorDesc describes the explainTypeError tool.
+const ExplainTypeErrorDesc = `
+Everything needed to fix one type error reported by verifyBuild (or the compiler) in one call: give its file, line and column
+(column 0 with an optional message substring picks among several errors on the line). The package is type-checked again from the
+cached syntax; the result has the error, the enclosing statement's source, the expression the error points at with its actual
+and expected type and where the expectation comes from ("argument 1 of temp.Warm", "declaration of s", "result 1", "field X"),
+and definitions (signature, doc, file:line) of the named types involved and the called function. For assignability failures
+it adds the interface methods missing or with another signature, struct fields that differ, and hints (explicit conversion,
+&x or *x, passing a pointer). A line without a type error fails with NOT_FOUND listing the file's type errors as candidates.
+Example: explainTypeError { "dir": ".", "file": "internal/app/app.go", "line": 42, "column": 19 }
+`
+
+// AnalyzeInitDependenciesDesc describes the analyzeInitDependencies tool.
+const AnalyzeInitDependenciesDesc = `
+Package initialization order hazards. order lists the module packages in the order Go initializes them (imports first, then the
+lexically smallest import path among the ready packages) as a program importing all of them would. Package-level var initializers
+and init functions are scanned for reads and writes of other module packages' package-level variables; a write through a call of a
+function of the variable's package that assigns it (registry.Register) counts. A read from a package initialized before a package
+that writes the variable during its initialization is a read-before-write finding (the read misses the write: a registration map
+read before the plugin registers); every read of another package's variable in a var initializer is an initializer-read finding.
+Findings give the var (or init), the symbol read, both packages with their order positions and, for hazards, the writer's file:line.
+Function literals that are not called in place do not run during initialization and are skipped.
+Example: analyzeInitDependencies { "dir": "." }
 `
@@ -0,0 +1,563 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/tools/go/packages"
+)
+
+// diskCacheVersion is part of the on-disk layout; bump it whenever fileFacts or the code deriving them changes.
+const diskCacheVersion = "v5"
+
+// Hydration states of a (dir, mode) pair answered from the persisted index.
+const (
+	hydrationWarming = "warming"
+	hydrationWarm    = "warm"
+	hydrationFailed  = "failed"
+)
+
+// fileFacts are syntax-derived facts of a single Go file. They depend only on the file content,
+// so they are stored under the content hash and can never become stale.
+type fileFacts struct {
+	Package   string               `json:"package"`
+	Lines     int                  `json:"lines"`
+	Symbols   []Symbol             `json:"symbols,omitempty"`
+	Imports   []Import             `json:"imports,omitempty"`
+	Functions []FunctionComplexity `json:"functions,omitempty"`
+}
+
+// indexedFile is a source file of the persisted index together with its facts.
+type indexedFile struct {
+	relPath string
+	facts   *fileFacts
+}
+
+// indexedPackage is a package reconstructed from the persisted index without running the go command.
+type indexedPackage struct {
+	path  string
+	name  string
+	dir   string
+	files []indexedFile
+}
+
+// hydrationState tracks the background load that warms the in-memory cache for a (dir, mode) pair.
+type hydrationState struct {
+	dir   string
+	mode  packages.LoadMode
+	state string
+}
+
+var diskCache = struct {
+	sync.Mutex
+
+	dir          string
+	facts        map[string]*fileFacts
+	hits         int
+	misses       int
+	writes       int
+	indexAnswers int
+	hydration    map[string]*hydrationState
+}{
+	facts:     make(map[string]*fileFacts),
+	hydration: make(map[string]*hydrationState),
+}
+
+// ConfigureDiskCache enables the persistent on-disk cache of syntax-derived facts rooted at dir.
+// An empty dir disables it.
+//
+// Parameters:
+//   - dir: cache directory; created if it does not exist
+//
+// Returns:
+//   - error if the cache directory cannot be created
+func ConfigureDiskCache(dir string) error {
+	if dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Join(abs, diskCacheVersion), 0o755); err != nil {
+			return fmt.Errorf("failed to create cache dir: %w", err)
+		}
+
+		dir = abs
+	}
+
+	diskCache.Lock()
+	diskCache.dir = dir
+	diskCache.facts = make(map[string]*fileFacts)
+	diskCache.hits, diskCache.misses, diskCache.writes, diskCache.indexAnswers = 0, 0, 0, 0
+	diskCache.hydration = make(map[string]*hydrationState)
+	diskCache.Unlock()
+
+	return nil
+}
+
+// persistedIndexFor returns the packages under dir reconstructed from the persisted index when the
+// disk cache is enabled or an imported index artifact covers dir, and the in-memory cache for
+// (dir, mode) is still cold. In that case it also starts a background load that warms the in-memory
+// cache; once warm, it returns nil and tools use the regular load path.
+func persistedIndexFor(ctx context.Context, dir string, mode packages.LoadMode, tool string) []*indexedPackage {
+	diskCache.Lock()
+	enabled := diskCache.dir != ""
+	diskCache.Unlock()
+
+	if !enabled {
+		enabled = importedIndexFor(dir) != nil
+	}
+
+	// The index never holds testdata packages.
+	if !enabled || includesTestdata(ctx) || isPackageCacheWarm(dir, mode) {
+		return nil
+	}
+
+	index, err := buildPersistedIndex(ctx, dir)
+	if err != nil {
+		logError(tool, err, "persisted index unavailable, loading packages")
+
+		return nil
+	}
+
+	startHydration(dir, mode)
+
+	diskCache.Lock()
+	diskCache.indexAnswers++
+	diskCache.Unlock()
+
+	return index
+}
+
+// isPackageCacheWarm reports whether packages for (dir, mode) are already held in memory.
+func isPackageCacheWarm(dir string, mode packages.LoadMode) bool {
+	return len(cacheKeysFor(dir, mode, false, false)) > 0
+}
+
+// startHydration loads (dir, mode) in the background unless a load is already running or done.
+func startHydration(dir string, mode packages.LoadMode) {
+	key := makeCacheKey(dir, mode, false, false)
+
+	diskCache.Lock()
+	if state, ok := diskCache.hydration[key]; ok && state.state != hydrationFailed {
+		diskCache.Unlock()
+
+		return
+	}
+
+	state := &hydrationState{dir: dir, mode: mode, state: hydrationWarming}
+	diskCache.hydration[key] = state
+	diskCache.Unlock()
+
+	go func() {
+		_, err := loadPackagesWithCache(context.Background(), dir, mode)
+
+		diskCache.Lock()
+		defer diskCache.Unlock()
+
+		if err != nil {
+			log.Warn().Err(err).Str("dir", dir).Msg("background cache warm-up failed")
+
+			state.state = hydrationFailed
+
+			return
+		}
+
+		state.state = hydrationWarm
+	}()
+}
+
+// buildPersistedIndex walks the module packages below dir the way "go list ./..." selects them
+// and attaches the facts of every non-test Go file matching the current build context.
+func buildPersistedIndex(ctx context.Context, dir string) ([]*indexedPackage, error) {
+	modRoot := findModuleRoot(dir)
+	if modRoot == "" {
+		return nil, fmt.Errorf("go.mod not found for %s", dir)
+	}
+
+	modulePath, _ := readGoModInfo(modRoot)
+	if modulePath == "" {
+		return nil, fmt.Errorf("module path not found in %s", filepath.Join(modRoot, "go.mod"))
+	}
+
+	absDir := CanonicalDir(dir)
+	byDir := make(map[string]*indexedPackage)
+
+	err := walkModuleGoFiles(ctx, absDir, false, func(p string) error {
+		facts, err := factsForFile(p)
+		if err != nil {
+			return err
+		}
+
+		pkgDir := filepath.Dir(p)
+
+		pkg, ok := byDir[pkgDir]
+		if !ok {
+			rel, err := filepath.Rel(modRoot, pkgDir)
+			if err != nil {
+				return err
+			}
+
+			pkg = &indexedPackage{path: path.Join(modulePath, filepath.ToSlash(rel)), name: facts.Package, dir: pkgDir}
+			byDir[pkgDir] = pkg
+		}
+
+		pkg.files = append(pkg.files, indexedFile{relPath: relativePath(dir, p), facts: facts})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	index := make([]*indexedPackage, 0, len(byDir))
+	for _, pkg := range byDir {
+		index = append(index, pkg)
+	}
+
+	sort.Slice(index, func(i, j int) bool { return index[i].path < index[j].path })
+
+	return index, nil
+}
+
+// walkModuleGoFiles calls fn for every Go file of the module packages below absDir, selected the way
+// "go list ./..." selects them: testdata, vendor, hidden and nested modules are skipped, as are files
+// excluded by build constraints. Test files are included only when includeTests is set.
+func walkModuleGoFiles(ctx context.Context, absDir string, includeTests bool, fn func(path string) error) error {
+	return filepath.WalkDir(absDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if shouldStop(ctx) {
+			return context.Canceled
+		}
+
+		if d.IsDir() {
+			if p == absDir {
+				return nil
+			}
+
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir // nested module
+			}
+
+			return nil
+		}
+
+		name := d.Name()
+		if !strings.HasSuffix(name, ".go") || (!includeTests && strings.HasSuffix(name, "_test.go")) {
+			return nil
+		}
+
+		// Files excluded by build constraints (or with unreadable headers) are skipped like go list does.
+		if match, matchErr := build.Default.MatchFile(filepath.Dir(p), name); matchErr != nil || !match {
+			return nil
+		}
+
+		return fn(p)
+	})
+}
+
+// filterIndexedPackages applies the same package filter as filterPackagesByRequest to the persisted index.
+func filterIndexedPackages(index []*indexedPackage, requested string) ([]*indexedPackage, error) {
+	stubs := indexedPackageStubs(index)
+
+	filtered, err := filterPackagesByRequest(stubs, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	byStub := make(map[*packages.Package]*indexedPackage, len(stubs))
+	for i, stub := range stubs {
+		byStub[stub] = index[i]
+	}
+
+	result := make([]*indexedPackage, 0, len(filtered))
+	for _, stub := range filtered {
+		result = append(result, byStub[stub])
+	}
+
+	return result, nil
+}
+
+// indexedPackageStubs converts the persisted index to packages carrying only name, path, imports and GoFiles.
+func indexedPackageStubs(index []*indexedPackage) []*packages.Package {
+	stubs := make([]*packages.Package, 0, len(index))
+
+	for _, pkg := range index {
+		imports := make(map[string]*packages.Package)
+		goFiles := make([]string, 0, len(pkg.files))
+
+		for _, file := range pkg.files {
+			for _, imp := range file.facts.Imports {
+				imports[imp.Path] = &packages.Package{PkgPath: imp.Path}
+			}
+
+			if pkg.dir != "" {
+				goFiles = append(goFiles, filepath.Join(pkg.dir, path.Base(file.relPath)))
+			}
+		}
+
+		stubs = append(stubs, &packages.Package{PkgPath: pkg.path, Name: pkg.name, Imports: imports, GoFiles: goFiles})
+	}
+
+	return stubs
+}
+
+// factsForFile returns the facts of a file from memory, from disk or by parsing it, in that order.
+func factsForFile(filename string) (*fileFacts, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	diskCache.Lock()
+	cacheDir := diskCache.dir
+	facts, ok := diskCache.facts[hash]
+
+	if ok {
+		diskCache.hits++
+	}
+	diskCache.Unlock()
+
+	if ok {
+		return facts, nil
+	}
+
+	// Without a cache directory the index is answered from an imported artifact; files it does not
+	// cover are parsed but not persisted.
+	if cacheDir == "" {
+		facts, err = computeFileFacts(filename, content)
+		if err != nil {
+			return nil, err
+		}
+
+		rememberFacts(hash, facts, true)
+
+		return facts, nil
+	}
+
+	factsPath := filepath.Join(cacheDir, diskCacheVersion, hash[:2], hash+".json")
+
+	if data, err := os.ReadFile(factsPath); err == nil {
+		facts = &fileFacts{}
+		if err := json.Unmarshal(data, facts); err == nil {
+			rememberFacts(hash, facts, false)
+
+			return facts, nil
+		}
+	}
+
+	facts, err = computeFileFacts(filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeFacts(factsPath, facts); err != nil {
+		log.Warn().Err(err).Str("file", factsPath).Msg("failed to persist file facts")
+	}
+
+	rememberFacts(hash, facts, true)
+
+	return facts, nil
+}
+
+// rememberFacts stores facts in memory and updates the hit/miss counters.
+func rememberFacts(hash string, facts *fileFacts, computed bool) {
+	diskCache.Lock()
+	defer diskCache.Unlock()
+
+	diskCache.facts[hash] = facts
+
+	if computed {
+		diskCache.misses++
+	} else {
+		diskCache.hits++
+	}
+}
+
+// writeFacts atomically writes facts to path.
+func writeFacts(factsPath string, facts *fileFacts) error {
+	data, err := json.Marshal(facts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(factsPath), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(factsPath), "facts-*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), factsPath); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	diskCache.Lock()
+	diskCache.writes++
+	diskCache.Unlock()
+
+	return nil
+}
+
+// computeFileFacts parses a file and derives its facts. Package and File of symbols and
+// functions are left empty: they depend on where the file lives and are filled in by callers.
+func computeFileFacts(filename string, content []byte) (*fileFacts, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := &fileFacts{
+		Package: file.Name.Name,
+		Lines:   strings.Count(string(content), "\n"),
+	}
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		facts.Lines++
+	}
+
+	for _, sym := range collectSymbolsWithFingerprints(file, fset, "", "") {
+		sym.Package = ""
+		facts.Symbols = append(facts.Symbols, sym)
+	}
+
+	for _, imp := range file.Imports {
+		facts.Imports = append(facts.Imports, Import{
+			Path: strings.Trim(imp.Path.Value, `"`),
+			Line: fset.Position(imp.Pos()).Line,
+		})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		fd, ok := n.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			return true
+		}
+
+		facts.Functions = append(facts.Functions, declComplexity(context.Background(), fset, file.Name.Name, fd)...)
+
+		return false
+	})
+
+	return facts, nil
+}
+
+// diskCacheStats returns a snapshot of the persistent cache state for getServerStatus.
+func diskCacheStats() CacheStats {
+	packageCache.RLock()
+	loads := make([]CacheLoad, 0, len(packageCache.pkgs))
+
+	var estimated int64
+
+	for _, item := range packageCache.pkgs {
+		loads = append(loads, CacheLoad{Dir: item.Dir, Mode: item.describe(), Hits: item.Hits, Pinned: item.Pinned, EstimatedBytes: item.Size})
+		estimated += item.Size
+	}
+
+	hits, misses := packageCache.hits, packageCache.misses
+	evictions, forcedCleanups := packageCache.evictions, packageCache.forcedCleanups
+	packageCache.RUnlock()
+
+	loadFlights.Lock()
+	coalesced := loadFlights.coalesced
+	loadFlights.Unlock()
+
+	negativeLookups.Lock()
+	negativeHits, negativeEntries := negativeLookups.hits, len(negativeLookups.entries)
+	negativeLookups.Unlock()
+
+	sort.Slice(loads, func(i, j int) bool {
+		if loads[i].Dir != loads[j].Dir {
+			return loads[i].Dir < loads[j].Dir
+		}
+
+		return loads[i].Mode < loads[j].Mode
+	})
+
+	diskCache.Lock()
+	defer diskCache.Unlock()
+
+	stats := CacheStats{
+		InMemoryLoads:   len(loads),
+		MemoryHits:      hits,
+		MemoryMisses:    misses,
+		Loads:           loads,
+		DiskEnabled:     diskCache.dir != "",
+		DiskDir:         diskCache.dir,
+		FactsInMemory:   len(diskCache.facts),
+		FactsHits:       diskCache.hits,
+		FactsMisses:     diskCache.misses,
+		FactsWrites:     diskCache.writes,
+		IndexAnswers:    diskCache.indexAnswers,
+		BudgetBytes:     cacheBudget.Load(),
+		EstimatedBytes:  estimated,
+		Evictions:       evictions,
+		ForcedCleanups:  forcedCleanups,
+		HeapAllocBytes:  heapAlloc(),
+		CoalescedLoads:  coalesced,
+		NegativeHits:    negativeHits,
+		NegativeLookups: negativeEntries,
+	}
+
+	for _, state := range diskCache.hydration {
+		stats.Hydration = append(stats.Hydration, CacheHydration{
+			Dir:   state.dir,
+			Mode:  loadModeName(state.mode),
+			State: state.state,
+		})
+	}
+
+	sort.Slice(stats.Hydration, func(i, j int) bool {
+		if stats.Hydration[i].Dir != stats.Hydration[j].Dir {
+			return stats.Hydration[i].Dir < stats.Hydration[j].Dir
+		}
+
+		return stats.Hydration[i].Mode < stats.Hydration[j].Mode
+	})
+
+	return stats
+}
@@ -0,0 +1,176 @@
+package tools_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+// waitForHydration polls getServerStatus until the background warm-up of dir finishes.
+func waitForHydration(t *testing.T, dir string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Minute)
+
+	for time.Now().Before(deadline) {
+		_, status, err := tools.GetServerStatus(context.Background(), &mcp.CallToolRequest{}, tools.GetServerStatusInput{})
+		if err != nil {
+			t.Fatalf("GetServerStatus error: %v", err)
+		}
+
+		pending := false
+
+		for _, h := range status.CacheStats.Hydration {
+			if h.Dir != dir {
+				continue
+			}
+
+			switch h.State {
+			case "failed":
+				t.Fatalf("hydration of %s failed", dir)
+			case "warming":
+				pending = true
+			}
+		}
+
+		if !pending {
+			return
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("hydration of %s did not finish", dir)
+}
+
+func cacheStats(t *testing.T) tools.CacheStats {
+	t.Helper()
+
+	_, status, err := tools.GetServerStatus(context.Background(), &mcp.CallToolRequest{}, tools.GetServerStatusInput{})
+	if err != nil {
+		t.Fatalf("GetServerStatus error: %v", err)
+	}
+
+	return status.CacheStats
+}
+
+func TestDiskCache_AnswersColdCallsFromPersistedIndex(t *testing.T) {
+	cacheDir := t.TempDir()
+	if err := tools.ConfigureDiskCache(cacheDir); err != nil {
+		t.Fatalf("ConfigureDiskCache error: %v", err)
+	}
+
+	t.Cleanup(func() { _ = tools.ConfigureDiskCache("") })
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy sample: %v", err)
+	}
+
+	ctx := context.Background()
+
+	_, fromIndex, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	stats := cacheStats(t)
+	if !stats.DiskEnabled || stats.IndexAnswers != 1 {
+		t.Fatalf("expected the cold call to be answered from the index, got %+v", stats)
+	}
+
+	if stats.FactsMisses == 0 || stats.FactsWrites != stats.FactsMisses {
+		t.Errorf("expected computed facts to be persisted, got %+v", stats)
+	}
+
+	waitForHydration(t, dir)
+
+	_, fromLoad, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	if cacheStats(t).IndexAnswers != 1 {
+		t.Errorf("expected the warm call to use the in-memory cache")
+	}
+
+	if !reflect.DeepEqual(fromIndex, fromLoad) {
+		t.Errorf("persisted index and full load disagree:\nindex: %+v\nload:  %+v", fromIndex, fromLoad)
+	}
+
+	complexityIn := tools.AnalyzeComplexityInput{Dir: dir}
+
+	_, complexityFromIndex, err := tools.AnalyzeComplexity(ctx, &mcp.CallToolRequest{}, complexityIn)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	waitForHydration(t, dir)
+
+	_, complexityFromLoad, err := tools.AnalyzeComplexity(ctx, &mcp.CallToolRequest{}, complexityIn)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	if !reflect.DeepEqual(complexityFromIndex, complexityFromLoad) {
+		t.Errorf("persisted index and full load disagree on complexity")
+	}
+}
+
+func TestDiskCache_ReusesFactsAfterRestart(t *testing.T) {
+	cacheDir := t.TempDir()
+	if err := tools.ConfigureDiskCache(cacheDir); err != nil {
+		t.Fatalf("ConfigureDiskCache error: %v", err)
+	}
+
+	t.Cleanup(func() { _ = tools.ConfigureDiskCache("") })
+
+	ctx := context.Background()
+
+	first := t.TempDir()
+	if err := copyDir(testDir(), first); err != nil {
+		t.Fatalf("copy sample: %v", err)
+	}
+
+	if _, _, err := tools.ListImports(ctx, &mcp.CallToolRequest{}, tools.ListImportsInput{Dir: first}); err != nil {
+		t.Fatalf("ListImports error: %v", err)
+	}
+
+	waitForHydration(t, first)
+
+	// Reconfiguring drops everything held in memory, as a restart would.
+	if err := tools.ConfigureDiskCache(cacheDir); err != nil {
+		t.Fatalf("ConfigureDiskCache error: %v", err)
+	}
+
+	second := t.TempDir()
+	if err := copyDir(testDir(), second); err != nil {
+		t.Fatalf("copy sample: %v", err)
+	}
+
+	_, fromIndex, err := tools.ListImports(ctx, &mcp.CallToolRequest{}, tools.ListImportsInput{Dir: second})
+	if err != nil {
+		t.Fatalf("ListImports error: %v", err)
+	}
+
+	stats := cacheStats(t)
+	if stats.FactsMisses != 0 || stats.FactsHits == 0 {
+		t.Errorf("expected all facts to come from disk, got %+v", stats)
+	}
+
+	waitForHydration(t, second)
+
+	_, fromLoad, err := tools.ListImports(ctx, &mcp.CallToolRequest{}, tools.ListImportsInput{Dir: second})
+	if err != nil {
+		t.Fatalf("ListImports error: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromIndex, fromLoad) {
+		t.Errorf("persisted index and full load disagree:\nindex: %+v\nload:  %+v", fromIndex, fromLoad)
+	}
+}
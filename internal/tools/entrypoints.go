@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultEntryPointDepth is how many import levels of internal packages an entry point reports.
+const defaultEntryPointDepth = 2
+
+// pflagDefiners are the pflag functions defining a flag beyond those of the flag package; like them they
+// also come with a Var form and, in pflag, a P form taking a shorthand.
+var pflagDefiners = map[string]struct{}{
+	"BoolSlice": {}, "Count": {}, "DurationSlice": {}, "IntSlice": {}, "IP": {}, "IPNet": {}, "IPSlice": {},
+	"StringArray": {}, "StringSlice": {}, "StringToInt": {}, "StringToString": {}, "BytesHex": {},
+	"BytesBase64": {}, "Int32": {}, "Float32": {}, "Uint32": {},
+}
+
+// projectEntryPoints describes the main packages among pkgs: the functions called from main and init,
+// the flags they define and the packages of pkgs they import, directly or through up to maxDepth levels.
+// File and directory paths are relative to dir.
+func projectEntryPoints(dir string, pkgs []*packages.Package, maxDepth int) []ProjectEntryPoint {
+	byPath := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		byPath[pkg.PkgPath] = pkg
+	}
+
+	entryPoints := []ProjectEntryPoint{}
+
+	for _, pkg := range pkgs {
+		if pkg.Name != "main" || !hasTypes(pkg) || len(pkg.Syntax) == 0 {
+			continue
+		}
+
+		ep := ProjectEntryPoint{
+			Package: pkg.PkgPath,
+			Dir:     relativePath(dir, filepath.Dir(pkg.Fset.File(pkg.Syntax[0].Pos()).Name())),
+			Calls:   []string{},
+			Flags:   []ProjectFlag{},
+			Wiring:  wiredPackages(pkg, byPath, maxDepth),
+		}
+
+		seen := make(map[string]struct{})
+
+		for _, file := range pkg.Syntax {
+			relPath := relativePath(dir, pkg.Fset.File(file.Pos()).Name())
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncDecl:
+					if n.Recv == nil && n.Body != nil && (n.Name.Name == "main" || n.Name.Name == "init") {
+						for _, name := range entryCalls(pkg.TypesInfo, n.Body) {
+							if _, ok := seen[name]; !ok {
+								seen[name] = struct{}{}
+								ep.Calls = append(ep.Calls, name)
+							}
+						}
+					}
+				case *ast.CallExpr:
+					if flag, ok := flagDefinition(pkg.TypesInfo, n); ok {
+						flag.File, flag.Line = relPath, pkg.Fset.Position(n.Pos()).Line
+						ep.Flags = append(ep.Flags, flag)
+					}
+				}
+
+				return true
+			})
+		}
+
+		entryPoints = append(entryPoints, ep)
+	}
+
+	sort.Slice(entryPoints, func(i, j int) bool { return entryPoints[i].Package < entryPoints[j].Package })
+
+	return entryPoints
+}
+
+// entryCalls returns the functions and methods body calls statically, qualified pkg.Func or
+// pkg.Type.Method, in the order of their argument lists, so a.New().Run() lists New first; calls inside
+// function literals of body count as well.
+func entryCalls(info *types.Info, body *ast.BlockStmt) []string {
+	var calls []*ast.CallExpr
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if fn := calledFunc(info, call); fn != nil && fn.Pkg() != nil {
+				calls = append(calls, call)
+			}
+		}
+
+		return true
+	})
+
+	sort.SliceStable(calls, func(i, j int) bool { return calls[i].Lparen < calls[j].Lparen })
+
+	names := make([]string, 0, len(calls))
+	for _, call := range calls {
+		names = append(names, qualifiedObjectName(calledFunc(info, call)))
+	}
+
+	return names
+}
+
+// flagDefinition recognizes a call defining a flag with a constant name through the flag or pflag
+// package, or a FlagSet of either.
+func flagDefinition(info *types.Info, call *ast.CallExpr) (ProjectFlag, bool) {
+	fn := calledFunc(info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return ProjectFlag{}, false
+	}
+
+	name := fn.Name()
+
+	switch fn.Pkg().Path() {
+	case "flag":
+		if _, ok := flagDefiners[name]; !ok {
+			return ProjectFlag{}, false
+		}
+	case "github.com/spf13/pflag":
+		base := strings.TrimSuffix(strings.TrimSuffix(name, "P"), "Var")
+		_, std := flagDefiners[base]
+		_, extra := pflagDefiners[base]
+
+		if !std && !extra && base != "" {
+			return ProjectFlag{}, false
+		}
+	default:
+		return ProjectFlag{}, false
+	}
+
+	// XVar(&p, name, ...) and Var(value, name, usage) take the name second.
+	arg := 0
+	if strings.HasSuffix(strings.TrimSuffix(name, "P"), "Var") {
+		arg = 1
+	}
+
+	if len(call.Args) <= arg {
+		return ProjectFlag{}, false
+	}
+
+	tv, ok := info.Types[call.Args[arg]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return ProjectFlag{}, false
+	}
+
+	return ProjectFlag{Name: constant.StringVal(tv.Value), Func: qualifiedObjectName(fn)}, true
+}
+
+// wiredPackages returns the packages of byPath that pkg imports, directly at depth 1 or through other
+// packages of byPath up to maxDepth, ordered by depth and path.
+func wiredPackages(pkg *packages.Package, byPath map[string]*packages.Package, maxDepth int) []ProjectWiredPackage {
+	wired := []ProjectWiredPackage{}
+	seen := map[string]struct{}{pkg.PkgPath: {}}
+	level := []*packages.Package{pkg}
+
+	for depth := 1; depth <= maxDepth && len(level) > 0; depth++ {
+		var next []*packages.Package
+
+		for _, p := range level {
+			for _, path := range sortedKeys(p.Imports) {
+				imported, ok := byPath[path]
+				if _, done := seen[path]; !ok || done {
+					continue
+				}
+
+				seen[path] = struct{}{}
+				wired = append(wired, ProjectWiredPackage{Path: path, Depth: depth})
+				next = append(next, imported)
+			}
+		}
+
+		level = next
+	}
+
+	sort.SliceStable(wired, func(i, j int) bool {
+		if wired[i].Depth != wired[j].Depth {
+			return wired[i].Depth < wired[j].Depth
+		}
+
+		return wired[i].Path < wired[j].Path
+	})
+
+	return wired
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// ErrorCode is a machine-readable category of a tool failure, so that clients can decide whether to
+// retry, re-prompt or give up without matching error messages.
+type ErrorCode string
+
+// Error codes attached to tool errors.
+const (
+	// CodeNotFound - the requested symbol, type, package or file does not exist.
+	CodeNotFound ErrorCode = "NOT_FOUND"
+	// CodeAmbiguous - the name matches several candidates; details list them.
+	CodeAmbiguous ErrorCode = "AMBIGUOUS"
+	// CodeInvalidInput - an argument is missing, malformed or out of range.
+	CodeInvalidInput ErrorCode = "INVALID_INPUT"
+	// CodeLoadFailed - packages could not be loaded or lack the requested information.
+	CodeLoadFailed ErrorCode = "LOAD_FAILED"
+	// CodeTypeErrorsPresent - type checking failed, so type-dependent results are unavailable.
+	CodeTypeErrorsPresent ErrorCode = "TYPE_ERRORS_PRESENT"
+	// CodeCancelled - the request was cancelled or timed out.
+	CodeCancelled ErrorCode = "CANCELLED"
+	// CodePathDenied - a file or directory could not be accessed.
+	CodePathDenied ErrorCode = "PATH_DENIED"
+	// CodeGeneratedFile - the requested change belongs in the generator's source, not in generated code.
+	CodeGeneratedFile ErrorCode = "GENERATED_FILE"
+	// CodeToolDenied - the tool is disabled by the server's tool policy flags.
+	CodeToolDenied ErrorCode = "TOOL_DENIED"
+	// CodeConflict - a file changed on disk since the caller read it, so a mutation was refused.
+	CodeConflict ErrorCode = "CONFLICT"
+	// CodeInternal - any other failure.
+	CodeInternal ErrorCode = "INTERNAL"
+)
+
+// ToolError is a tool failure with a machine-readable code. It is also the JSON body of failed tool
+// calls. Error returns the message alone, so wrapping an error keeps its text.
+type ToolError struct {
+	// Code - machine-readable error category
+	Code ErrorCode `json:"code" jsonschema:"Machine-readable error category"`
+	// Message - human-readable error message
+	Message string `json:"message" jsonschema:"Human-readable error message"`
+	// Details - additional data, e.g. candidates of an ambiguous name
+	Details *ToolErrorDetails `json:"details,omitempty" jsonschema:"Additional data, e.g. candidates of an ambiguous name"`
+
+	err error
+}
+
+// ToolErrorDetails carries additional data of a tool error.
+type ToolErrorDetails struct {
+	// Candidates - names that matched an ambiguous request, or suggestions for a missing one
+	Candidates []string `json:"candidates,omitempty" jsonschema:"Names that matched an ambiguous request, or suggestions for a missing one"`
+	// Diagnostics - go command activity and package errors captured before a load failed
+	Diagnostics []string `json:"diagnostics,omitempty" jsonschema:"Go command activity and package errors captured before a load failed"`
+	// Progress - packages and files walked before a call was cancelled
+	Progress *ToolProgress `json:"progress,omitempty" jsonschema:"Packages and files walked before a call was cancelled"`
+	// StaleFiles - files whose content hash no longer matches the one the caller expected
+	StaleFiles []string `json:"staleFiles,omitempty" jsonschema:"Files whose content hash no longer matches the one the caller expected"`
+}
+
+// ToolProgress reports how far a cancelled tool call got.
+type ToolProgress struct {
+	// PackagesWalked - number of packages whose files were visited
+	PackagesWalked int `json:"packagesWalked" jsonschema:"Number of packages whose files were visited"`
+	// FilesWalked - number of files visited
+	FilesWalked int `json:"filesWalked" jsonschema:"Number of files visited"`
+}
+
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+func (e *ToolError) Unwrap() error {
+	return e.err
+}
+
+// NewToolError attaches a code and optional candidates to err.
+//
+// Parameters:
+//   - code: error category
+//   - err: underlying error, whose message is kept
+//   - candidates: optional candidate names reported in details
+//
+// Returns:
+//   - the tool error
+func NewToolError(code ErrorCode, err error, candidates ...string) *ToolError {
+	te := &ToolError{Code: code, Message: err.Error(), err: err}
+	if len(candidates) > 0 {
+		te.Details = &ToolErrorDetails{Candidates: candidates}
+	}
+
+	return te
+}
+
+// AsToolError returns err as a tool error. Errors that carry no code are classified by their cause:
+// cancellation, file permissions, missing files and missing type information; anything else is INTERNAL.
+// A tool error wrapped with more context keeps its code and details but takes the outer message.
+//
+// Parameters:
+//   - err: any error returned by a tool
+//
+// Returns:
+//   - the tool error, or nil if err is nil
+func AsToolError(err error) *ToolError {
+	if err == nil {
+		return nil
+	}
+
+	var te *ToolError
+	if errors.As(err, &te) {
+		if te == err {
+			return te
+		}
+
+		wrapped := *te
+		wrapped.Message = err.Error()
+		wrapped.err = err
+
+		return &wrapped
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return NewToolError(CodeCancelled, err)
+	case errors.Is(err, fs.ErrPermission):
+		return NewToolError(CodePathDenied, err)
+	case errors.Is(err, fs.ErrNotExist):
+		return NewToolError(CodeNotFound, err)
+	case errors.Is(err, errTypesNotLoaded):
+		return NewToolError(CodeLoadFailed, err)
+	}
+
+	return NewToolError(CodeInternal, err)
+}
+
+// notFound returns a NOT_FOUND error with a formatted message and optional suggestions.
+func notFound(candidates []string, format string, args ...any) error {
+	return NewToolError(CodeNotFound, fmt.Errorf(format, args...), candidates...)
+}
+
+// ambiguous returns an AMBIGUOUS error listing the matching candidates.
+func ambiguous(candidates []string, format string, args ...any) error {
+	return NewToolError(CodeAmbiguous, fmt.Errorf(format, args...), candidates...)
+}
+
+// invalidInput returns an INVALID_INPUT error with a formatted message.
+func invalidInput(format string, args ...any) error {
+	return NewToolError(CodeInvalidInput, fmt.Errorf(format, args...))
+}
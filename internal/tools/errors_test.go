@@ -0,0 +1,178 @@
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+// errorCode calls a tool and returns the code of its error, failing the test if it succeeded.
+func errorCode[In, Out any](t *testing.T, handler func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, Out, error), input In) *tools.ToolError {
+	t.Helper()
+
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	return tools.AsToolError(err)
+}
+
+func TestToolErrors_Codes(t *testing.T) {
+	t.Parallel()
+
+	dir := testDir()
+
+	cases := []struct {
+		name string
+		err  func(t *testing.T) *tools.ToolError
+		want tools.ErrorCode
+	}{
+		{"FindBestContext unknown symbol", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.FindBestContext, tools.FindBestContextInput{Dir: dir, Ident: "NoSuchSymbol"})
+		}, tools.CodeNotFound},
+		{"FindReferences unknown symbol", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.FindReferences, tools.FindReferencesInput{Dir: dir, Ident: "NoSuchSymbol"})
+		}, tools.CodeNotFound},
+		{"ReadFunc unknown function", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.ReadFunc, tools.ReadFuncInput{Dir: dir, Name: "NoSuchFunc"})
+		}, tools.CodeNotFound},
+		{"ReadStruct unknown struct", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.ReadStruct, tools.ReadStructInput{Dir: dir, Name: "NoSuchStruct"})
+		}, tools.CodeNotFound},
+		{"FindImplementations unknown interface", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.FindImplementations, tools.FindImplementationsInput{Dir: dir, Name: "NoSuchIface"})
+		}, tools.CodeNotFound},
+		{"RenameSymbol unknown symbol", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.RenameSymbol, tools.RenameSymbolInput{Dir: dir, OldName: "NoSuchSymbol", NewName: "X", DryRun: true})
+		}, tools.CodeNotFound},
+		{"AnalyzeComplexity invalid order", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.AnalyzeComplexity, tools.AnalyzeComplexityInput{Dir: dir, Order: "sideways"})
+		}, tools.CodeInvalidInput},
+		{"FindReferences negative limit", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.FindReferences, tools.FindReferencesInput{Dir: dir, Ident: "Foo", Limit: -1})
+		}, tools.CodeInvalidInput},
+		{"ASTRewrite invalid pattern", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.ASTRewrite, tools.ASTRewriteInput{Dir: dir, Find: "fmt.Println(", Replace: "x", DryRun: true})
+		}, tools.CodeInvalidInput},
+		{"FindConstructions without type", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.FindConstructions, tools.FindConstructionsInput{Dir: dir})
+		}, tools.CodeInvalidInput},
+		{"DescribeJSONShape non-struct", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.DescribeJSONShape, tools.DescribeJSONShapeInput{Dir: dir, TypeName: "Celsius"})
+		}, tools.CodeInvalidInput},
+		{"ListPackages missing dir", func(t *testing.T) *tools.ToolError {
+			return errorCode(t, tools.ListPackages, tools.ListPackagesInput{Dir: "/nonexistent/directory"})
+		}, tools.CodeLoadFailed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.err(t); got.Code != tc.want {
+				t.Errorf("expected %s, got %s (%s)", tc.want, got.Code, got.Message)
+			}
+		})
+	}
+}
+
+func TestToolErrors_CancelledLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy testdata: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+	if err == nil {
+		t.Fatal("expected an error for a cancelled context")
+	}
+
+	if te := tools.AsToolError(err); te.Code != tools.CodeCancelled {
+		t.Errorf("expected CANCELLED, got %s (%s)", te.Code, te.Message)
+	}
+}
+
+func TestToolErrors_PackageNotFoundSuggestsCandidates(t *testing.T) {
+	t.Parallel()
+
+	te := errorCode(t, tools.AnalyzeDependencies, tools.AnalyzeDependenciesInput{Dir: testDir(), Package: "nosuchpkg"})
+
+	if te.Code != tools.CodeNotFound || te.Details == nil || !slices.Contains(te.Details.Candidates, "sample") {
+		t.Errorf("expected NOT_FOUND with package candidates, got %+v", te)
+	}
+}
+
+func TestToolErrors_AmbiguousListsCandidates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":   "module amb\n\ngo 1.25\n",
+		"a/a.go":   "package a\n\ntype T struct{}\n",
+		"b/b.go":   "package b\n\ntype T struct{}\n",
+		"iface.go": "package amb\n\ntype Runner interface{ Run() }\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	te := errorCode(t, tools.ExplainImplements, tools.ExplainImplementsInput{Dir: dir, TypeName: "T", InterfaceName: "Runner"})
+
+	if te.Code != tools.CodeAmbiguous || te.Details == nil || !slices.Equal(te.Details.Candidates, []string{"amb/a.T", "amb/b.T"}) {
+		t.Errorf("expected AMBIGUOUS with both candidates, got %+v", te)
+	}
+}
+
+func TestAsToolError_ClassifiesPlainErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		err  error
+		want tools.ErrorCode
+	}{
+		{context.Canceled, tools.CodeCancelled},
+		{fmt.Errorf("walk: %w", context.DeadlineExceeded), tools.CodeCancelled},
+		{&fs.PathError{Op: "open", Path: "x.go", Err: fs.ErrPermission}, tools.CodePathDenied},
+		{&fs.PathError{Op: "open", Path: "x.go", Err: fs.ErrNotExist}, tools.CodeNotFound},
+		{errors.New("boom"), tools.CodeInternal},
+	}
+
+	for _, tc := range cases {
+		if got := tools.AsToolError(tc.err); got.Code != tc.want || got.Message != tc.err.Error() {
+			t.Errorf("AsToolError(%v) = %s %q, want %s", tc.err, got.Code, got.Message, tc.want)
+		}
+	}
+
+	wrapped := fmt.Errorf("context: %w", tools.NewToolError(tools.CodeAmbiguous, errors.New("two matches"), "a", "b"))
+	if got := tools.AsToolError(wrapped); got.Code != tools.CodeAmbiguous || got.Message != "context: two matches" || len(got.Details.Candidates) != 2 {
+		t.Errorf("expected a wrapped tool error to keep code and details, got %+v", got)
+	}
+
+	if tools.AsToolError(nil) != nil {
+		t.Error("expected nil for a nil error")
+	}
+}
@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultMaxExamples caps the examples and tests returned with a declaration unless maxExamples is set.
+const defaultMaxExamples = 5
+
+// exampleTarget is the declaration examples are collected for: a function (fn), a type (typ) or a
+// method (both).
+type exampleTarget struct {
+	typ string
+	fn  string
+}
+
+// id is the name go/doc associates examples with: "F", "T" or "T_M".
+func (t exampleTarget) id() string {
+	switch {
+	case t.typ == "":
+		return t.fn
+	case t.fn == "":
+		return t.typ
+	default:
+		return t.typ + "_" + t.fn
+	}
+}
+
+// namedBy reports whether a test name, without its "Test" prefix, mentions the target: a method is
+// mentioned by its receiver and method names together.
+func (t exampleTarget) namedBy(name string) bool {
+	return (t.typ == "" || mentions(name, t.typ)) && (t.fn == "" || mentions(name, t.fn))
+}
+
+// mentions reports whether name contains ident, capitalized as it would be after "Test" when unexported.
+func mentions(name, ident string) bool {
+	r, size := utf8.DecodeRuneInString(ident)
+
+	return strings.Contains(name, ident) || strings.Contains(name, string(unicode.ToUpper(r))+ident[size:])
+}
+
+// collectExamples returns the Example functions documenting target and the tests named after it, from the
+// _test.go files next to declFile that belong to pkg or its external test package. Examples come first;
+// the result holds at most limit functions and reports whether more were found.
+func collectExamples(dir string, pkg *packages.Package, declFile string, target exampleTarget, limit int) (
+	[]FunctionSource,
+	bool,
+) {
+	if limit <= 0 {
+		limit = defaultMaxExamples
+	}
+
+	testFiles, err := filepath.Glob(filepath.Join(filepath.Dir(declFile), "*_test.go"))
+	if err != nil || len(testFiles) == 0 {
+		return nil, false
+	}
+
+	ids := exampleIDs(pkg)
+	ids[target.id()] = struct{}{}
+
+	var examples, tests []FunctionSource
+
+	for _, path := range testFiles {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			logError("collectExamples", err, "failed to read "+path)
+
+			continue
+		}
+
+		fset := token.NewFileSet()
+
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+
+		pkgPath := pkg.PkgPath
+		switch file.Name.Name {
+		case pkg.Name:
+		case pkg.Name + "_test":
+			pkgPath += "_test"
+		default:
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Body == nil {
+				continue
+			}
+
+			if id, ok := exampleID(fd.Name.Name, ids); ok {
+				if id == target.id() {
+					examples = append(examples, exampleSource(dir, pkgPath, fset, fd, src))
+				}
+
+				continue
+			}
+
+			if rest, ok := testName(fd); ok && target.namedBy(rest) {
+				tests = append(tests, exampleSource(dir, pkgPath, fset, fd, src))
+			}
+		}
+	}
+
+	sortFunctionSources(examples)
+	sortFunctionSources(tests)
+
+	found := append(examples, tests...)
+	if len(found) > limit {
+		return found[:limit], true
+	}
+
+	return found, false
+}
+
+// exampleIDs returns the names examples of pkg can document: its exported functions and types, and the
+// exported methods of exported types as "T_M".
+func exampleIDs(pkg *packages.Package) map[string]struct{} {
+	ids := make(map[string]struct{})
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if !d.Name.IsExported() {
+					continue
+				}
+
+				if recv := receiverName(d); recv == "" {
+					ids[d.Name.Name] = struct{}{}
+				} else if ast.IsExported(recv) {
+					ids[recv+"_"+d.Name.Name] = struct{}{}
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.IsExported() {
+						ids[ts.Name.Name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return ids
+}
+
+// exampleID classifies an example function name the way go/doc does. Example and Example_suffix document
+// the package (id ""); ExampleF, ExampleT and ExampleT_M document the identifier, each optionally followed
+// by "_suffix" where suffix starts with a lower-case letter. The longest identifier in ids wins. ok is false
+// when name is not an example function or documents nothing in ids.
+func exampleID(name string, ids map[string]struct{}) (id string, ok bool) {
+	return namedTestID(name, "Example", ids)
+}
+
+// namedTestID classifies the name of a test function starting with prefix (Example, Benchmark, Fuzz)
+// by the identifier it is named after, following the example convention of exampleID.
+func namedTestID(name, prefix string, ids map[string]struct{}) (id string, ok bool) {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return "", false
+	}
+
+	if rest == "" {
+		return "", true
+	}
+
+	if suffix, ok := strings.CutPrefix(rest, "_"); ok {
+		return "", isExampleSuffix(suffix)
+	}
+
+	if r, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(r) {
+		return "", false // e.g. Examplesort is an ordinary function
+	}
+
+	for i := len(rest); i > 0; i = strings.LastIndexByte(rest[:i], '_') {
+		prefix := rest[:i]
+		if i < len(rest) && !isExampleSuffix(rest[i+1:]) {
+			continue
+		}
+
+		if _, ok := ids[prefix]; ok {
+			return prefix, true
+		}
+	}
+
+	return "", false
+}
+
+// isExampleSuffix reports whether s can follow an identifier in an example name.
+func isExampleSuffix(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+
+	return size > 0 && unicode.IsLower(r)
+}
+
+// testName returns the name of a test function without its "Test" prefix. Like go test, it requires a
+// single parameter and rejects names such as Testify, whose prefix is followed by a lower-case letter.
+func testName(fd *ast.FuncDecl) (string, bool) {
+	rest, ok := strings.CutPrefix(fd.Name.Name, "Test")
+	if !ok || fd.Type.Params == nil || fd.Type.Params.NumFields() != 1 {
+		return "", false
+	}
+
+	if r, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(r) {
+		return "", false
+	}
+
+	return rest, true
+}
+
+// exampleSource returns the verbatim source of a test function, so that // Output: comments survive.
+func exampleSource(dir, pkgPath string, fset *token.FileSet, fd *ast.FuncDecl, src []byte) FunctionSource {
+	start, end := fset.Position(fd.Pos()), fset.Position(fd.End())
+
+	return FunctionSource{
+		Name:       fd.Name.Name,
+		Package:    pkgPath,
+		File:       relativePath(dir, start.Filename),
+		StartLine:  start.Line,
+		EndLine:    end.Line,
+		SourceCode: string(src[start.Offset:end.Offset]),
+	}
+}
+
+// sortFunctionSources orders functions by file and line.
+func sortFunctionSources(fns []FunctionSource) {
+	sort.Slice(fns, func(i, j int) bool {
+		if fns[i].File != fns[j].File {
+			return fns[i].File < fns[j].File
+		}
+
+		return fns[i].StartLine < fns[j].StartLine
+	})
+}
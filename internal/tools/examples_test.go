@@ -0,0 +1,141 @@
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+var exampleModule = map[string]string{
+	"tasks.go": `package lang
+
+// TaskService manages tasks.
+type TaskService struct{ names []string }
+
+// List returns the task names.
+func (s *TaskService) List() []string { return s.names }
+
+// Get_All is an exported method whose name contains an underscore.
+func (s *TaskService) Get_All() []string { return s.names }
+
+// Parse reads a task name.
+func Parse(s string) string { return s }
+
+func normalize(s string) string { return s }
+`,
+	"example_test.go": `package lang_test
+
+import (
+	"fmt"
+
+	"lang"
+)
+
+func Example() {}
+
+func ExampleTaskService() {}
+
+func ExampleTaskService_List() {
+	fmt.Println(len(new(lang.TaskService).List()))
+	// Output: 0
+}
+
+func ExampleTaskService_List_empty() {}
+
+func ExampleTaskService_Get_All() {}
+
+func ExampleTaskService_Get_all() {}
+
+func ExampleParse_quoted() {}
+
+func ExampleTaskService_list() {}
+`,
+	"tasks_test.go": `package lang
+
+import "testing"
+
+func TestTaskService_List(t *testing.T) {}
+
+func TestList(t *testing.T) {}
+
+func TestNormalize(t *testing.T) {}
+
+func Testify(t *testing.T) {}
+`,
+}
+
+func TestReadFunc_IncludeExamples(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", exampleModule)
+
+	_, out, err := tools.ReadFunc(context.Background(), &mcp.CallToolRequest{},
+		tools.ReadFuncInput{Dir: dir, Name: "TaskService.List", IncludeExamples: true})
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if got := exampleNames(out.Examples); got != "ExampleTaskService_List ExampleTaskService_List_empty TestTaskService_List" {
+		t.Fatalf("unexpected examples for TaskService.List: %s", got)
+	}
+
+	first := out.Examples[0]
+	if first.File != "example_test.go" || first.Package != "lang_test" || !strings.Contains(first.SourceCode, "// Output: 0") {
+		t.Fatalf("expected the verbatim example with its output comment, got %+v", first)
+	}
+
+	// Get_All names the method itself; ExampleTaskService_Get_all documents nothing, as Get is no method.
+	_, out, err = tools.ReadFunc(context.Background(), &mcp.CallToolRequest{},
+		tools.ReadFuncInput{Dir: dir, Name: "TaskService.Get_All", IncludeExamples: true})
+	if err != nil || exampleNames(out.Examples) != "ExampleTaskService_Get_All" {
+		t.Fatalf("unexpected examples for TaskService.Get_All: %s (err %v)", exampleNames(out.Examples), err)
+	}
+
+	_, out, err = tools.ReadFunc(context.Background(), &mcp.CallToolRequest{},
+		tools.ReadFuncInput{Dir: dir, Name: "normalize", IncludeExamples: true})
+	if err != nil || exampleNames(out.Examples) != "TestNormalize" {
+		t.Fatalf("unexpected examples for normalize: %s (err %v)", exampleNames(out.Examples), err)
+	}
+
+	_, out, err = tools.ReadFunc(context.Background(), &mcp.CallToolRequest{},
+		tools.ReadFuncInput{Dir: dir, Name: "TaskService.List", IncludeExamples: true, MaxExamples: 1})
+	if err != nil || len(out.Examples) != 1 || !out.HasMoreExamples {
+		t.Fatalf("expected one example and hasMoreExamples, got %+v (err %v)", out, err)
+	}
+}
+
+func TestReadStruct_IncludeExamples(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", exampleModule)
+
+	_, out, err := tools.ReadStruct(context.Background(), &mcp.CallToolRequest{},
+		tools.ReadStructInput{Dir: dir, Name: "TaskService", IncludeExamples: true})
+	if err != nil {
+		t.Fatalf("ReadStruct error: %v", err)
+	}
+
+	// ExampleTaskService_list has a lower-case suffix and documents the type, not a method.
+	want := "ExampleTaskService ExampleTaskService_list TestTaskService_List"
+	if got := exampleNames(out.Examples); got != want {
+		t.Fatalf("unexpected examples for TaskService:\n got %s\nwant %s", got, want)
+	}
+
+	_, out, err = tools.ReadStruct(context.Background(), &mcp.CallToolRequest{},
+		tools.ReadStructInput{Dir: dir, Name: "TaskService"})
+	if err != nil || out.Examples != nil {
+		t.Fatalf("examples must be opt-in, got %+v (err %v)", out.Examples, err)
+	}
+}
+
+func exampleNames(fns []tools.FunctionSource) string {
+	names := make([]string, 0, len(fns))
+	for _, fn := range fns {
+		names = append(names, fn.Name)
+	}
+
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,374 @@
+package tools
+
+import (
+	"context"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Usage statuses of a library symbol across consumers.
+const (
+	externalUsageUsed      = "used"
+	externalUsageUnused    = "unused"
+	externalUsageInterface = "interface"
+)
+
+// externalSymbol is an exported library symbol together with its usage across consumers.
+type externalSymbol struct {
+	ExternalSymbolUsage
+
+	// typeName and method locate concrete methods for the interface check.
+	typeName string
+	method   string
+	// consumers holds the indexes of the consumers that reference the symbol.
+	consumers map[int]struct{}
+	// interfaces lists consumer interfaces the receiver satisfies that declare the method.
+	interfaces map[string]struct{}
+}
+
+// AnalyzeExternalUsage reports which exported symbols of a library module are referenced by a set of
+// consumer modules. Symbols without references are removal candidates; methods of library types that
+// satisfy an interface used by a consumer are reported separately, since they may be called dynamically.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the library directory and the consumer module directories
+//
+// Returns:
+//   - MCP tool call result
+//   - per-symbol reference and consumer counts with a usage status, and per-consumer totals
+//   - error if the library or a consumer cannot be loaded
+func AnalyzeExternalUsage(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeExternalUsageInput) (
+	*mcp.CallToolResult,
+	AnalyzeExternalUsageOutput,
+	error,
+) {
+	start := logStart("AnalyzeExternalUsage", logFields(
+		input.LibraryDir,
+		newLogField("consumers", strconv.Itoa(len(input.ConsumerDirs))),
+	))
+	out := AnalyzeExternalUsageOutput{}
+
+	defer func() { logEnd("AnalyzeExternalUsage", start, len(out.Symbols)) }()
+
+	if len(input.ConsumerDirs) == 0 {
+		return nil, out, invalidInput("consumerDirs must name at least one consumer module")
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	libPkgs, err := loadPackagesWithCache(ctx, input.LibraryDir, mode)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	symbols := libraryExportedSymbols(libPkgs, input.LibraryDir)
+
+	libPaths := make(map[string]struct{}, len(libPkgs))
+	for _, pkg := range libPkgs {
+		libPaths[pkg.PkgPath] = struct{}{}
+	}
+
+	// Every consumer is a separate root with its own cache entry, keyed by its directory.
+	for i, dir := range input.ConsumerDirs {
+		pkgs, err := loadPackagesWithCache(ctx, dir, mode)
+		if err != nil {
+			return fail(out, err)
+		}
+
+		consumer := ExternalConsumer{Dir: dir}
+
+		for _, pkg := range pkgs {
+			if shouldStop(ctx) {
+				return fail(out, context.Canceled)
+			}
+
+			if _, ok := libPaths[pkg.PkgPath]; ok {
+				continue
+			}
+
+			imported := false
+
+			for _, obj := range pkg.TypesInfo.Uses {
+				if obj.Pkg() == nil {
+					continue
+				}
+
+				if _, ok := libPaths[obj.Pkg().Path()]; !ok {
+					continue
+				}
+
+				key, ok := externalSymbolKey(obj)
+				if !ok {
+					continue
+				}
+
+				imported = true
+
+				if sym, ok := symbols[key]; ok {
+					sym.References++
+					sym.consumers[i] = struct{}{}
+					consumer.References++
+				}
+			}
+
+			if imported {
+				consumer.Packages++
+			}
+		}
+
+		markInterfaceSatisfiers(pkgs, libPaths, symbols)
+
+		out.Consumers = append(out.Consumers, consumer)
+	}
+
+	for _, sym := range symbols {
+		sym.Consumers = len(sym.consumers)
+
+		switch {
+		case sym.References > 0:
+			sym.Status = externalUsageUsed
+		case len(sym.interfaces) > 0:
+			sym.Status = externalUsageInterface
+			out.ViaInterface++
+
+			for name := range sym.interfaces {
+				sym.Interfaces = append(sym.Interfaces, name)
+			}
+
+			sort.Strings(sym.Interfaces)
+		default:
+			sym.Status = externalUsageUnused
+			out.Unused++
+		}
+
+		out.Symbols = append(out.Symbols, sym.ExternalSymbolUsage)
+	}
+
+	sort.Slice(out.Symbols, func(i, j int) bool {
+		if out.Symbols[i].Package != out.Symbols[j].Package {
+			return out.Symbols[i].Package < out.Symbols[j].Package
+		}
+
+		return out.Symbols[i].Name < out.Symbols[j].Name
+	})
+
+	out.Total = len(out.Symbols)
+
+	return nil, out, nil
+}
+
+// libraryExportedSymbols indexes the exported package-level objects and exported methods of named
+// types in importable library packages (internal and main packages are skipped) by externalSymbolKey.
+func libraryExportedSymbols(pkgs []*packages.Package, dir string) map[string]*externalSymbol {
+	symbols := make(map[string]*externalSymbol)
+
+	add := func(pkg *packages.Package, obj types.Object, name, kind, typeName, method string) {
+		key, ok := externalSymbolKey(obj)
+		if !ok {
+			return
+		}
+
+		pos := pkg.Fset.Position(obj.Pos())
+		symbols[key] = &externalSymbol{
+			ExternalSymbolUsage: ExternalSymbolUsage{
+				Package: pkg.PkgPath,
+				Name:    name,
+				Kind:    kind,
+				File:    relativePath(dir, pos.Filename),
+				Line:    pos.Line,
+			},
+			typeName:   typeName,
+			method:     method,
+			consumers:  make(map[int]struct{}),
+			interfaces: make(map[string]struct{}),
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Name == "main" || isInternalPackage(pkg.PkgPath) || !hasTypes(pkg) {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			if !obj.Exported() {
+				continue
+			}
+
+			add(pkg, obj, name, objStringKind(obj), "", "")
+
+			tn, ok := obj.(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+
+			named, ok := tn.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+
+			if iface, ok := named.Underlying().(*types.Interface); ok {
+				for m := range iface.ExplicitMethods() {
+					if m.Exported() {
+						add(pkg, m, name+"."+m.Name(), "method", "", "")
+					}
+				}
+
+				continue
+			}
+
+			for m := range named.Methods() {
+				if m.Exported() {
+					add(pkg, m, name+"."+m.Name(), "method", name, m.Name())
+				}
+			}
+		}
+	}
+
+	return symbols
+}
+
+// markInterfaceSatisfiers records, for library methods without references, the interfaces named in
+// consumer code that declare the method and are implemented by the method's receiver type.
+func markInterfaceSatisfiers(pkgs []*packages.Package, libPaths map[string]struct{}, symbols map[string]*externalSymbol) {
+	for _, pkg := range pkgs {
+		if _, ok := libPaths[pkg.PkgPath]; ok || !hasTypes(pkg) {
+			continue
+		}
+
+		var ifaces []*types.TypeName
+
+		seen := make(map[*types.TypeName]struct{})
+		collect := func(obj types.Object) {
+			tn, ok := obj.(*types.TypeName)
+			if !ok {
+				return
+			}
+
+			if _, ok := seen[tn]; ok {
+				return
+			}
+
+			seen[tn] = struct{}{}
+
+			if iface, ok := tn.Type().Underlying().(*types.Interface); ok && iface.NumMethods() > 0 {
+				ifaces = append(ifaces, tn)
+			}
+		}
+
+		for _, obj := range pkg.TypesInfo.Defs {
+			collect(obj)
+		}
+
+		for _, obj := range pkg.TypesInfo.Uses {
+			collect(obj)
+		}
+
+		if len(ifaces) == 0 {
+			continue
+		}
+
+		// The library as seen from this consumer's type universe.
+		imported := make(map[string]*types.Package)
+
+		for _, imp := range pkg.Types.Imports() {
+			if _, ok := libPaths[imp.Path()]; ok {
+				imported[imp.Path()] = imp
+			}
+		}
+
+		for _, sym := range symbols {
+			if sym.References > 0 || sym.method == "" {
+				continue
+			}
+
+			libPkg, ok := imported[sym.Package]
+			if !ok {
+				continue
+			}
+
+			tn, ok := libPkg.Scope().Lookup(sym.typeName).(*types.TypeName)
+			if !ok {
+				continue
+			}
+
+			for _, iface := range ifaces {
+				it := iface.Type().Underlying().(*types.Interface)
+
+				declares := false
+
+				for m := range it.Methods() {
+					if m.Name() == sym.method {
+						declares = true
+
+						break
+					}
+				}
+
+				if declares && (types.Implements(tn.Type(), it) || types.Implements(types.NewPointer(tn.Type()), it)) {
+					sym.interfaces[types.TypeString(iface.Type(), types.RelativeTo(pkg.Types))] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// externalSymbolKey identifies a package-level object or a method of a named type independently of the
+// load it comes from: 'pkgpath.Name' or 'pkgpath.Type.Method'. Fields and local objects have no key.
+func externalSymbolKey(obj types.Object) (string, bool) {
+	if obj == nil || obj.Pkg() == nil {
+		return "", false
+	}
+
+	path := obj.Pkg().Path()
+
+	if fn, ok := obj.(*types.Func); ok {
+		fn = fn.Origin()
+
+		recv := fn.Type().(*types.Signature).Recv()
+		if recv == nil {
+			return path + "." + fn.Name(), fn.Parent() == fn.Pkg().Scope()
+		}
+
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok {
+			return "", false
+		}
+
+		return path + "." + named.Origin().Obj().Name() + "." + fn.Name(), true
+	}
+
+	if v, ok := obj.(*types.Var); ok && v.IsField() {
+		return "", false
+	}
+
+	if obj.Parent() != obj.Pkg().Scope() {
+		return "", false
+	}
+
+	return path + "." + obj.Name(), true
+}
+
+// isInternalPackage reports whether an import path contains an internal element.
+func isInternalPackage(path string) bool {
+	for _, elem := range strings.Split(path, "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+
+	return false
+}
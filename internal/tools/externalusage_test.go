@@ -0,0 +1,95 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+// writeConsumerModule creates a module that imports the sample library through a replace directive.
+func writeConsumerModule(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module consumer\n\ngo 1.25\n\nrequire sample v0.0.0\n\nreplace sample => " + filepath.ToSlash(testDir()) + "\n",
+		"main.go": `package main
+
+import "sample"
+
+type sizer interface {
+	Size() int
+}
+
+func main() {
+	var s sizer = sample.NewWidget(3)
+
+	_ = s.Size()
+	_ = sample.FormatGreeting("consumer")
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestAnalyzeExternalUsage(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeExternalUsageInput{LibraryDir: testDir(), ConsumerDirs: []string{writeConsumerModule(t)}}
+
+	_, out, err := tools.AnalyzeExternalUsage(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeExternalUsage error: %v", err)
+	}
+
+	symbols := make(map[string]tools.ExternalSymbolUsage)
+	for _, sym := range out.Symbols {
+		symbols[sym.Package+"."+sym.Name] = sym
+	}
+
+	if sym := symbols["sample.NewWidget"]; sym.Status != "used" || sym.Consumers != 1 || sym.References != 1 {
+		t.Errorf("expected NewWidget used once by one consumer, got %+v", sym)
+	}
+
+	if sym := symbols["sample.Widget.Size"]; sym.Status != "interface" || len(sym.Interfaces) != 1 || sym.Interfaces[0] != "sizer" {
+		t.Errorf("expected Widget.Size reachable through the consumer's sizer interface, got %+v", sym)
+	}
+
+	if sym := symbols["sample.Logf"]; sym.Status != "unused" || sym.References != 0 {
+		t.Errorf("expected Logf to be unused, got %+v", sym)
+	}
+
+	if _, ok := symbols["sample/internal/textutil.Title"]; ok {
+		t.Errorf("expected internal packages to be skipped")
+	}
+
+	if out.Unused == 0 || out.ViaInterface != 1 || out.Total != len(out.Symbols) {
+		t.Errorf("unexpected totals: total %d, unused %d, via interface %d", out.Total, out.Unused, out.ViaInterface)
+	}
+
+	if len(out.Consumers) != 1 || out.Consumers[0].Packages != 1 || out.Consumers[0].References != 2 {
+		t.Errorf("unexpected consumer summary %+v", out.Consumers)
+	}
+}
+
+func TestAnalyzeExternalUsage_RequiresConsumers(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.AnalyzeExternalUsage(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeExternalUsageInput{LibraryDir: testDir()})
+	if err == nil {
+		t.Fatal("expected an error without consumer directories")
+	}
+}
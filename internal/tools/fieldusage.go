@@ -0,0 +1,416 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultFieldUsageSamples is the number of sample locations per access kind when maxSamples is not set.
+const defaultFieldUsageSamples = 3
+
+// AnalyzeFieldUsage counts, for every field of the requested structs, the selections reading it, the
+// selections writing it (assignments, increments and taking its address) and the composite literals
+// initializing it, across the module and its tests. Selections are resolved through TypesInfo.Selections,
+// so promoted fields count for the embedded struct declaring them and pointer receivers are followed.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, and a struct name or a package whose structs to analyze
+//
+// Returns:
+//   - MCP tool call result
+//   - per-struct field counts with sample locations; unread fields are flagged
+//   - error if packages cannot be loaded or the struct is not found
+func AnalyzeFieldUsage(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeFieldUsageInput) (
+	*mcp.CallToolResult,
+	AnalyzeFieldUsageOutput,
+	error,
+) {
+	start := logStart("AnalyzeFieldUsage", logFields(
+		input.Dir,
+		newLogField("typeName", input.TypeName),
+		newLogField("package", input.Package),
+	))
+	out := AnalyzeFieldUsageOutput{Structs: []StructFieldUsage{}}
+
+	defer func() { logEnd("AnalyzeFieldUsage", start, len(out.Structs)) }()
+
+	if input.TypeName == "" && input.Package == "" {
+		return fail(out, invalidInput("typeName or package is required"))
+	}
+
+	if input.MaxSamples < 0 {
+		return fail(out, invalidInput("maxSamples must not be negative, got %d", input.MaxSamples))
+	}
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, loadModeSyntaxTypesNamed)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	targets, err := fieldUsageTargets(pkgs, input)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	maxSamples := input.MaxSamples
+	if maxSamples == 0 {
+		maxSamples = defaultFieldUsageSamples
+	}
+
+	counter := &fieldCounter{dir: input.Dir, targets: targets, maxSamples: maxSamples, seen: make(map[fieldAccessKey]struct{})}
+
+	for _, pkg := range pkgs {
+		if shouldStop(ctx) {
+			return fail(out, context.Canceled)
+		}
+
+		counter.countPackage(pkg)
+	}
+
+	keys := make([]string, 0, len(targets))
+	for key := range targets {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		usage := targets[key].usage
+		for i := range usage.Fields {
+			f := &usage.Fields[i]
+			f.Unread = f.Reads == 0
+			f.Untouched = f.Unread && f.Writes == 0 && f.Literals == 0
+			// An unread field carrying a json name is likely filled or consumed by encoding/json only.
+			f.Serialized = f.Unread && f.JSONTag != "" && f.JSONTag != "-"
+
+			if f.Unread {
+				out.UnreadFields++
+			}
+		}
+
+		out.Structs = append(out.Structs, usage)
+	}
+
+	return nil, out, nil
+}
+
+// fieldTarget is an analyzed struct with its report entry, whose fields are indexed by name.
+type fieldTarget struct {
+	strct  *types.Struct
+	usage  StructFieldUsage
+	fields map[string]*FieldUsage
+}
+
+// fieldUsageTargets resolves the structs of input: the typeName, or every struct declared in the package.
+func fieldUsageTargets(pkgs []*packages.Package, input AnalyzeFieldUsageInput) (map[string]*fieldTarget, error) {
+	var names []*types.TypeName
+
+	if input.TypeName != "" {
+		scope := pkgs
+		if input.Package != "" {
+			filtered, err := filterPackagesByRequest(pkgs, input.Package)
+			if err != nil {
+				return nil, err
+			}
+
+			scope = filtered
+		}
+
+		tn, err := lookupTypeName(scope, input.TypeName)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := tn.Type().Underlying().(*types.Struct); !ok {
+			return nil, invalidInput("%s is not a struct type", input.TypeName)
+		}
+
+		names = append(names, tn)
+	} else {
+		filtered, err := filterPackagesByRequest(pkgs, input.Package)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range filtered {
+			// Test variants repeat the package's declarations.
+			if pkg.ID != pkg.PkgPath || pkg.Types == nil {
+				continue
+			}
+
+			scope := pkg.Types.Scope()
+			for _, name := range scope.Names() {
+				if tn, ok := scope.Lookup(name).(*types.TypeName); ok && !tn.IsAlias() {
+					if _, ok := tn.Type().Underlying().(*types.Struct); ok {
+						names = append(names, tn)
+					}
+				}
+			}
+		}
+	}
+
+	targets := make(map[string]*fieldTarget, len(names))
+
+	for _, tn := range names {
+		targets[qualifiedTypeName(tn)] = newFieldTarget(pkgs, input.Dir, tn)
+	}
+
+	return targets, nil
+}
+
+// newFieldTarget lists the fields of the struct tn in declaration order.
+func newFieldTarget(pkgs []*packages.Package, dir string, tn *types.TypeName) *fieldTarget {
+	strct := tn.Type().Underlying().(*types.Struct)
+	t := &fieldTarget{
+		strct:  strct,
+		usage:  StructFieldUsage{Struct: tn.Name(), Package: tn.Pkg().Path(), Fields: make([]FieldUsage, 0, strct.NumFields())},
+		fields: make(map[string]*FieldUsage, strct.NumFields()),
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Types == tn.Pkg() {
+			posn := pkg.Fset.Position(tn.Pos())
+			t.usage.File, t.usage.Line = relativePath(dir, posn.Filename), posn.Line
+
+			break
+		}
+	}
+
+	qualifier := types.RelativeTo(tn.Pkg())
+
+	for i := range strct.NumFields() {
+		field := strct.Field(i)
+		jsonName, _, _ := strings.Cut(reflect.StructTag(strct.Tag(i)).Get("json"), ",")
+
+		t.usage.Fields = append(t.usage.Fields, FieldUsage{
+			Name:     field.Name(),
+			Type:     types.TypeString(field.Type(), qualifier),
+			Embedded: field.Embedded(),
+			JSONTag:  jsonName,
+		})
+	}
+
+	for i := range t.usage.Fields {
+		t.fields[t.usage.Fields[i].Name] = &t.usage.Fields[i]
+	}
+
+	return t
+}
+
+// fieldAccessKey identifies one access to a field; test variants repeat the files of their package.
+type fieldAccessKey struct {
+	file   string
+	offset int
+	field  *FieldUsage
+}
+
+// fieldCounter accumulates the accesses to the fields of the targets.
+type fieldCounter struct {
+	dir        string
+	targets    map[string]*fieldTarget
+	maxSamples int
+	seen       map[fieldAccessKey]struct{}
+}
+
+// countPackage records the field accesses in the files of pkg.
+func (c *fieldCounter) countPackage(pkg *packages.Package) {
+	info := pkg.TypesInfo
+	if info == nil {
+		return
+	}
+
+	for _, file := range pkg.Syntax {
+		lines := getFileLines(pkg.Fset, file)
+		writes := fieldWriteTargets(file)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.SelectorExpr:
+				field, embedded := c.selectedField(info, node)
+
+				// A promoted selection reads the embedded fields it passes through.
+				for _, e := range embedded {
+					c.record(pkg, lines, node.Sel.Pos(), e, &e.Reads, &e.ReadSamples)
+				}
+
+				switch _, written := writes[node]; {
+				case field == nil:
+				case written:
+					c.record(pkg, lines, node.Sel.Pos(), field, &field.Writes, &field.WriteSamples)
+				default:
+					c.record(pkg, lines, node.Sel.Pos(), field, &field.Reads, &field.ReadSamples)
+				}
+			case *ast.CompositeLit:
+				c.countLiteral(pkg, lines, node)
+			}
+
+			return true
+		})
+	}
+}
+
+// selectedField returns the report entry of the target field sel selects, and those of the embedded fields
+// a promoted selection passes through. The selection's index path is followed from the receiver, so the
+// selected field is attributed to the struct declaring it.
+func (c *fieldCounter) selectedField(info *types.Info, sel *ast.SelectorExpr) (*FieldUsage, []*FieldUsage) {
+	selection := info.Selections[sel]
+	if selection == nil || selection.Kind() != types.FieldVal {
+		return nil, nil
+	}
+
+	var embedded []*FieldUsage
+
+	owner := selection.Recv()
+	index := selection.Index()
+
+	for _, i := range index[:len(index)-1] {
+		strct, ok := derefType(owner).Underlying().(*types.Struct)
+		if !ok {
+			return nil, nil
+		}
+
+		if f := c.fieldOf(derefType(owner), strct.Field(i).Name()); f != nil {
+			embedded = append(embedded, f)
+		}
+
+		owner = strct.Field(i).Type()
+	}
+
+	return c.fieldOf(derefType(owner), selection.Obj().Name()), embedded
+}
+
+// countLiteral records the fields a composite literal of a target struct initializes.
+func (c *fieldCounter) countLiteral(pkg *packages.Package, lines []string, lit *ast.CompositeLit) {
+	typ := pkg.TypesInfo.TypeOf(lit)
+	if typ == nil {
+		return
+	}
+
+	t := c.targetOf(derefType(typ))
+	if t == nil {
+		return
+	}
+
+	for i, elt := range lit.Elts {
+		name, pos := "", elt.Pos()
+
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if id, ok := kv.Key.(*ast.Ident); ok {
+				name, pos = id.Name, id.Pos()
+			}
+		} else if i < t.strct.NumFields() {
+			name = t.strct.Field(i).Name()
+		}
+
+		if f := t.fields[name]; f != nil {
+			c.record(pkg, lines, pos, f, &f.Literals, &f.LiteralSamples)
+		}
+	}
+}
+
+// fieldOf returns the report entry of the named field of typ, if typ is a target.
+func (c *fieldCounter) fieldOf(typ types.Type, name string) *FieldUsage {
+	if t := c.targetOf(typ); t != nil {
+		return t.fields[name]
+	}
+
+	return nil
+}
+
+// targetOf returns the target of a named struct type, instantiations included.
+func (c *fieldCounter) targetOf(typ types.Type) *fieldTarget {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	return c.targets[qualifiedTypeName(named.Origin().Obj())]
+}
+
+// record counts an access to field at pos once and keeps it as a sample while there is room.
+func (c *fieldCounter) record(
+	pkg *packages.Package,
+	lines []string,
+	pos token.Pos,
+	field *FieldUsage,
+	count *int,
+	samples *[]ContextLocation,
+) {
+	posn := pkg.Fset.Position(pos)
+
+	key := fieldAccessKey{posn.Filename, posn.Offset, field}
+	if _, ok := c.seen[key]; ok {
+		return
+	}
+
+	c.seen[key] = struct{}{}
+	*count++
+
+	if len(*samples) < c.maxSamples {
+		*samples = append(*samples, ContextLocation{
+			File:    relativePath(c.dir, posn.Filename),
+			Line:    posn.Line,
+			Snippet: extractSnippet(lines, posn.Line),
+		})
+	}
+}
+
+// fieldWriteTargets returns the selectors of file that are written: assigned, incremented, assigned by a
+// range clause, or whose address is taken.
+func fieldWriteTargets(file *ast.File) map[*ast.SelectorExpr]struct{} {
+	writes := make(map[*ast.SelectorExpr]struct{})
+
+	add := func(e ast.Expr) {
+		if sel, ok := ast.Unparen(e).(*ast.SelectorExpr); ok {
+			writes[sel] = struct{}{}
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				add(lhs)
+			}
+		case *ast.IncDecStmt:
+			add(node.X)
+		case *ast.RangeStmt:
+			if node.Tok == token.ASSIGN {
+				if node.Key != nil {
+					add(node.Key)
+				}
+
+				if node.Value != nil {
+					add(node.Value)
+				}
+			}
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				add(node.X)
+			}
+		}
+
+		return true
+	})
+
+	return writes
+}
+
+// derefType strips one pointer.
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,128 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const fieldUsageSource = `package lang
+
+type Base struct {
+	ID int
+}
+
+type Config struct {
+	Base
+	Name   string ` + "`json:\"name\"`" + `
+	Port   int
+	Debug  bool ` + "`json:\"debug,omitempty\"`" + `
+	Unused string
+}
+
+func NewConfig() *Config {
+	return &Config{Name: "app", Debug: true}
+}
+
+func (c *Config) Address() string {
+	return c.Name + ":" + string(rune(c.Port))
+}
+
+func Configure(c *Config) {
+	c.Port = 8080
+	c.Port++
+	c.ID = 1
+	enable(&c.Debug)
+}
+
+func Identify(c Config) int { return c.ID }
+
+func enable(b *bool) { *b = true }
+`
+
+func TestAnalyzeFieldUsage(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": fieldUsageSource})
+
+	_, out, err := tools.AnalyzeFieldUsage(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeFieldUsageInput{Dir: dir, Package: "lang"})
+	if err != nil {
+		t.Fatalf("AnalyzeFieldUsage error: %v", err)
+	}
+
+	if len(out.Structs) != 2 || out.Structs[0].Struct != "Base" || out.Structs[1].Struct != "Config" {
+		t.Fatalf("expected Base and Config in name order, got %+v", out.Structs)
+	}
+
+	type counts struct {
+		reads, writes, literals int
+		unread, untouched       bool
+		serialized              bool
+	}
+
+	want := map[string]counts{
+		"Base.ID":       {reads: 1, writes: 1},
+		"Config.Base":   {reads: 2},
+		"Config.Name":   {reads: 1, literals: 1},
+		"Config.Port":   {reads: 1, writes: 2},
+		"Config.Debug":  {writes: 1, literals: 1, unread: true, serialized: true},
+		"Config.Unused": {unread: true, untouched: true},
+	}
+
+	for _, s := range out.Structs {
+		for _, f := range s.Fields {
+			key := s.Struct + "." + f.Name
+
+			w, ok := want[key]
+			if !ok {
+				t.Errorf("unexpected field %s", key)
+
+				continue
+			}
+
+			got := counts{f.Reads, f.Writes, f.Literals, f.Unread, f.Untouched, f.Serialized}
+			if got != w {
+				t.Errorf("%s: got %+v, want %+v", key, got, w)
+			}
+		}
+	}
+
+	if out.UnreadFields != 2 {
+		t.Errorf("expected 2 unread fields, got %d", out.UnreadFields)
+	}
+
+	debug := out.Structs[1].Fields[3]
+	if debug.JSONTag != "debug" || len(debug.WriteSamples) != 1 || debug.WriteSamples[0].Line != 27 {
+		t.Errorf("expected the json name and the &c.Debug write sample on line 27, got %+v", debug)
+	}
+}
+
+func TestAnalyzeFieldUsage_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": fieldUsageSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	cases := []struct {
+		input tools.AnalyzeFieldUsageInput
+		code  tools.ErrorCode
+	}{
+		{tools.AnalyzeFieldUsageInput{Dir: dir}, tools.CodeInvalidInput},
+		{tools.AnalyzeFieldUsageInput{Dir: dir, TypeName: "Missing"}, tools.CodeNotFound},
+	}
+
+	for _, tc := range cases {
+		_, _, err := tools.AnalyzeFieldUsage(ctx, req, tc.input)
+		if te := tools.AsToolError(err); te == nil || te.Code != tc.code {
+			t.Errorf("%+v: expected %s, got %v", tc.input, tc.code, err)
+		}
+	}
+
+	_, out, err := tools.AnalyzeFieldUsage(ctx, req, tools.AnalyzeFieldUsageInput{Dir: dir, TypeName: "Config"})
+	if err != nil || len(out.Structs) != 1 || out.Structs[0].Struct != "Config" || out.Structs[0].Line != 7 {
+		t.Errorf("expected only Config declared on line 7, got %+v (err %v)", out.Structs, err)
+	}
+}
@@ -0,0 +1,1160 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultSplitTargetLines is the file size suggestFileSplit aims for when no target is given.
+const defaultSplitTargetLines = 800
+
+// Affinity between two top-level declarations, summed over everything they share.
+const (
+	// one declaration references an unexported name the other declares
+	splitWeightUnexportedRef = 3
+	// one declaration references an exported name the other declares
+	splitWeightExportedRef = 1
+	// both use the same rarely used import, or the same unexported name declared in another file
+	splitWeightShared = 1
+)
+
+// splitFile is a file analysed for splitting: its movable declarations and the imports they use.
+type splitFile struct {
+	pkg     *packages.Package
+	file    *ast.File
+	relPath string
+	absPath string
+	lines   int
+	// headerLines counts the lines before the first movable declaration.
+	headerLines int
+	imports     []splitImport
+	units       []*splitUnit
+}
+
+// splitImport is an import spec of a file being split.
+type splitImport struct {
+	text  string
+	blank bool
+	// group numbers the blank-line separated groups of the import block.
+	group int
+}
+
+// splitUnit is a movable top-level declaration of a file being split.
+type splitUnit struct {
+	block *declBlock
+	// name is unique within the file: repeated names (init, var _) get a "#N" suffix.
+	name  string
+	lines int
+	// imports holds indices into splitFile.imports.
+	imports map[int]struct{}
+	// refs sums the reference weights to other units of the file, keyed by unit index.
+	refs map[int]int
+	// shared holds the unexported package-level names from other files the declaration uses.
+	shared map[types.Object]struct{}
+}
+
+// splitPart is a group of units destined for one file.
+type splitPart struct {
+	file     string
+	units    []int
+	lines    int
+	reason   string
+	original bool
+}
+
+// SuggestFileSplit proposes how to split an oversized file into cohesive files of at most
+// targetMaxLines lines. Nothing is written.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, file and target size
+//
+// Returns:
+//   - MCP tool call result
+//   - the proposed files with their declarations and imports
+//   - error if the file cannot be found or its package cannot be loaded
+func SuggestFileSplit(ctx context.Context, _ *mcp.CallToolRequest, input SuggestFileSplitInput) (
+	*mcp.CallToolResult,
+	SuggestFileSplitOutput,
+	error,
+) {
+	start := logStart("SuggestFileSplit", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("targetMaxLines", strconv.Itoa(input.TargetMaxLines)),
+	))
+	out := SuggestFileSplitOutput{File: input.File}
+
+	defer func() { logEnd("SuggestFileSplit", start, len(out.Parts)) }()
+
+	target, err := splitTarget(input.TargetMaxLines)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	sf, err := analyzeSplitFile(ctx, input.Dir, input.File)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.File = sf.relPath
+	out.Lines = sf.lines
+	out.TargetMaxLines = target
+	out.SplitNeeded = sf.lines > target
+
+	parts := []splitPart{sf.originalPart(nil)}
+	if out.SplitNeeded {
+		parts = sf.suggest(target)
+	}
+
+	for _, p := range parts {
+		out.Parts = append(out.Parts, sf.describePart(p))
+	}
+
+	return nil, out, nil
+}
+
+// ApplyFileSplit moves declarations of a file into new files of the same package, either as planned
+// by the caller or as suggestFileSplit proposes. Every new file keeps the build constraints and package
+// clause of the original and imports only what its declarations use. The package is type-checked with
+// the new contents before anything is written.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, file, optional plan and dry-run flag
+//
+// Returns:
+//   - MCP tool call result
+//   - the resulting files and a diff
+//   - error if the plan is invalid or the split package would not type-check
+func ApplyFileSplit(ctx context.Context, _ *mcp.CallToolRequest, input ApplyFileSplitInput) (
+	*mcp.CallToolResult,
+	ApplyFileSplitOutput,
+	error,
+) {
+	start := logStart("ApplyFileSplit", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("parts", strconv.Itoa(len(input.Parts))),
+		newLogField("dryRun", strconv.FormatBool(input.DryRun)),
+	))
+	out := ApplyFileSplitOutput{File: input.File}
+
+	defer func() { logEnd("ApplyFileSplit", start, len(out.Files)) }()
+
+	if err := validateDiffMode(input.DiffMode); err != nil {
+		return fail(out, err)
+	}
+
+	target, err := splitTarget(input.TargetMaxLines)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	defer lockModuleForMutation(input.Dir)()
+
+	if err := checkExpectedHashes(input.Dir, expectedFileHash(input.File, input.ExpectedHash)); err != nil {
+		return fail(out, err)
+	}
+
+	sf, err := analyzeSplitFile(ctx, input.Dir, input.File)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.File = sf.relPath
+
+	layout, err := parseDeclLayout(input.Dir, input.File)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if layout.generated {
+		return fail(out, invalidInput("%s is generated by %q; change its generator instead", sf.relPath, layout.generator))
+	}
+
+	if err := sf.matchLayout(layout); err != nil {
+		return fail(out, err)
+	}
+
+	var parts []splitPart
+
+	switch {
+	case len(input.Parts) > 0:
+		parts, err = sf.planFromInput(input.Parts)
+		if err != nil {
+			return fail(out, err)
+		}
+	case sf.lines > target:
+		parts = sf.suggest(target)
+	default:
+		parts = []splitPart{sf.originalPart(nil)}
+	}
+
+	for _, p := range parts {
+		out.Files = append(out.Files, sf.describePart(p))
+	}
+
+	if len(parts) < 2 {
+		return nil, out, nil
+	}
+
+	overlay := make(map[string][]byte, len(parts))
+	dir := filepath.Dir(sf.absPath)
+	style := detectFileStyle(layout.src)
+
+	var pending []pendingWrite
+
+	for _, p := range parts {
+		if p.original {
+			continue
+		}
+
+		content, err := sf.renderPart(layout, p)
+		if err != nil {
+			return fail(out, err)
+		}
+
+		abs := filepath.Join(dir, p.file)
+		overlay[abs] = content
+		pending = append(pending, pendingWrite{
+			path:    abs,
+			relPath: relativePath(input.Dir, abs),
+			after:   style.apply(content),
+			created: true,
+		})
+	}
+
+	remaining, err := sf.renderPart(layout, parts[0])
+	if err != nil {
+		return fail(out, err)
+	}
+
+	overlay[sf.absPath] = remaining
+	// The original file is written last, so a failed write never leaves declarations defined twice.
+	pending = append(pending, pendingWrite{path: sf.absPath, relPath: sf.relPath, before: layout.src, after: remaining})
+
+	if err := checkSplitPackage(sf.pkg, overlay); err != nil {
+		return fail(out, err)
+	}
+
+	var diff strings.Builder
+	for _, w := range pending {
+		diff.WriteString(diffFiles(w.before, w.after, w.relPath, input.DiffMode))
+	}
+
+	out.Changed = true
+	out.Diff = diff.String()
+
+	if input.DryRun {
+		return nil, out, nil
+	}
+
+	if err := writeAllOrNothing(pending, fileChange{tool: "applyFileSplit", input: input}); err != nil {
+		logError("ApplyFileSplit", err, "failed to write files")
+
+		return fail(out, err)
+	}
+
+	out.Build = verifyAfterMutation(ctx, "ApplyFileSplit", input.Dir, input.VerifyBuild)
+
+	return nil, out, nil
+}
+
+// splitTarget validates targetMaxLines and applies its default.
+func splitTarget(target int) (int, error) {
+	switch {
+	case target < 0:
+		return 0, invalidInput("targetMaxLines must not be negative")
+	case target == 0:
+		return defaultSplitTargetLines, nil
+	}
+
+	return target, nil
+}
+
+// analyzeSplitFile loads the package of file and records, for every top-level declaration, its size,
+// the imports it uses and the declarations it references.
+func analyzeSplitFile(ctx context.Context, dir, file string) (*splitFile, error) {
+	want := path.Clean(filepath.ToSlash(file))
+	if strings.HasSuffix(want, "_test.go") {
+		return nil, invalidInput("cannot split test file %s", want)
+	}
+
+	pkgs, err := loadPackagesWithCache(ctx, dir, loadModeSyntaxTypesNamedFiles)
+	if err != nil {
+		logError("analyzeSplitFile", err, "failed to load packages")
+
+		return nil, err
+	}
+
+	var sf *splitFile
+
+	if err := walkPackageFiles(ctx, pkgs, dir, func(pkg *packages.Package, f *ast.File, relPath string, _ int) error {
+		if sf == nil && relPath == want {
+			sf = &splitFile{pkg: pkg, file: f, relPath: relPath, absPath: pkg.Fset.File(f.Pos()).Name()}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if sf == nil {
+		return nil, notFound(nil, "file %q not found in the loaded packages", want)
+	}
+
+	if len(sf.pkg.TypeErrors) > 0 {
+		return nil, NewToolError(CodeTypeErrorsPresent, fmt.Errorf("package %s has type errors: %v", sf.pkg.PkgPath, sf.pkg.TypeErrors[0]))
+	}
+
+	sf.index()
+
+	return sf, nil
+}
+
+// index fills in the imports and units of the file.
+func (sf *splitFile) index() {
+	fset, info := sf.pkg.Fset, sf.pkg.TypesInfo
+	sf.lines = fset.File(sf.file.Pos()).LineCount()
+	sf.headerLines = sf.lines
+
+	pkgNames := make(map[types.Object]int)
+	dotImports := make(map[string]int)
+	group, prevLine := 0, 0
+
+	var blocks []*declBlock
+
+	for _, decl := range sf.file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			blocks = append(blocks, newDeclBlock(decl, len(blocks)))
+
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			is := spec.(*ast.ImportSpec)
+			line := fset.Position(is.Pos()).Line
+
+			if prevLine != 0 && line > prevLine+1 {
+				group++
+			}
+
+			prevLine = fset.Position(is.End()).Line
+			imp := splitImport{text: is.Path.Value, group: group}
+
+			if is.Name != nil {
+				imp.text = is.Name.Name + " " + is.Path.Value
+				imp.blank = is.Name.Name == "_"
+			}
+
+			idx := len(sf.imports)
+			sf.imports = append(sf.imports, imp)
+
+			obj := info.Implicits[is]
+			if is.Name != nil {
+				obj = info.Defs[is.Name]
+			}
+
+			if pn, ok := obj.(*types.PkgName); ok {
+				pkgNames[pn] = idx
+				if pn.Name() == "." {
+					dotImports[pn.Imported().Path()] = idx
+				}
+			}
+		}
+
+		prevLine = fset.Position(gd.End()).Line
+	}
+
+	assignTypeGroups(blocks)
+
+	unitByObj := make(map[types.Object]int)
+	nameCount := make(map[string]int)
+
+	for i, b := range blocks {
+		start := b.decl.Pos()
+		if doc := declDoc(b.decl); doc != nil {
+			start = doc.Pos()
+		}
+
+		startLine := fset.Position(start).Line
+		if i == 0 {
+			sf.headerLines = startLine - 1
+		}
+
+		u := &splitUnit{
+			block:   b,
+			name:    b.name,
+			lines:   fset.Position(b.decl.End()).Line - startLine + 1,
+			imports: make(map[int]struct{}),
+			refs:    make(map[int]int),
+			shared:  make(map[types.Object]struct{}),
+		}
+
+		b.line = fset.Position(b.decl.Pos()).Line
+
+		if nameCount[b.name]++; nameCount[b.name] > 1 {
+			u.name = b.name + "#" + strconv.Itoa(nameCount[b.name])
+		}
+
+		for _, def := range topLevelNames(b.decl) {
+			if obj := info.Defs[def.name]; obj != nil {
+				unitByObj[obj] = i
+			}
+		}
+
+		sf.units = append(sf.units, u)
+	}
+
+	for i, u := range sf.units {
+		ast.Inspect(u.block.decl, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			obj := originObject(info.Uses[id])
+
+			switch {
+			case obj == nil:
+			case isPkgName(obj):
+				if idx, ok := pkgNames[obj]; ok {
+					u.imports[idx] = struct{}{}
+				}
+			default:
+				if j, ok := unitByObj[obj]; ok {
+					if j != i {
+						u.refs[j] += splitRefWeight(obj)
+					}
+
+					return true
+				}
+
+				if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+					return true
+				}
+
+				if obj.Pkg() == sf.pkg.Types {
+					if !obj.Exported() {
+						u.shared[obj] = struct{}{}
+					}
+				} else if idx, ok := dotImports[obj.Pkg().Path()]; ok {
+					u.imports[idx] = struct{}{}
+				}
+			}
+
+			return true
+		})
+	}
+}
+
+// isPkgName reports whether obj is an imported package name.
+func isPkgName(obj types.Object) bool {
+	_, ok := obj.(*types.PkgName)
+
+	return ok
+}
+
+// originObject maps the members of instantiated generic types and functions to their declarations.
+func originObject(obj types.Object) types.Object {
+	switch o := obj.(type) {
+	case *types.Func:
+		return o.Origin()
+	case *types.Var:
+		return o.Origin()
+	}
+
+	return obj
+}
+
+// splitRefWeight weighs a reference to a declaration of the same file.
+func splitRefWeight(obj types.Object) int {
+	if obj.Exported() {
+		return splitWeightExportedRef
+	}
+
+	return splitWeightUnexportedRef
+}
+
+// declDoc returns the doc comment of a top-level declaration.
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+
+	return nil
+}
+
+// matchLayout checks that the file on disk still holds the declarations of the loaded package.
+func (sf *splitFile) matchLayout(layout *declLayout) error {
+	if len(layout.blocks) == len(sf.units) {
+		i := 0
+		for ; i < len(sf.units); i++ {
+			if layout.blocks[i].name != sf.units[i].block.name || layout.blocks[i].line != sf.units[i].block.line {
+				break
+			}
+		}
+
+		if i == len(sf.units) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s changed since its package was loaded; retry the split", sf.relPath)
+}
+
+// suggest clusters the units into parts of at most target lines. Methods and constructors stay with
+// their type; the remaining declarations are merged along the strongest affinities first, and the
+// clusters left over are packed in file order.
+func (sf *splitFile) suggest(target int) []splitPart {
+	n := len(sf.units)
+	parent := make([]int, n)
+	size := make([]int, n)
+
+	for i, u := range sf.units {
+		parent[i], size[i] = i, u.lines
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		if ra == rb {
+			return
+		}
+
+		if rb < ra {
+			ra, rb = rb, ra
+		}
+
+		parent[rb] = ra
+		size[ra] += size[rb]
+	}
+
+	blockIndex := make(map[*declBlock]int, n)
+	for i, u := range sf.units {
+		blockIndex[u.block] = i
+	}
+
+	for i, u := range sf.units {
+		if u.block.owner != nil {
+			union(i, blockIndex[u.block.owner])
+		}
+	}
+
+	budget := max(target-sf.headerLines, 1)
+
+	for _, e := range sf.affinities() {
+		ra, rb := find(e.a), find(e.b)
+		if ra != rb && size[ra]+size[rb] <= budget {
+			union(ra, rb)
+		}
+	}
+
+	clusters := make(map[int][]int)
+	for i := range sf.units {
+		clusters[find(i)] = append(clusters[find(i)], i)
+	}
+
+	var bins []splitPart
+
+	// Roots are the first unit of their cluster, so clusters are packed in file order.
+	for root := range sf.units {
+		if find(root) != root {
+			continue
+		}
+
+		placed := false
+
+		for b := range bins {
+			if bins[b].lines+size[root] <= budget {
+				bins[b].units = append(bins[b].units, clusters[root]...)
+				bins[b].lines += size[root]
+				placed = true
+
+				break
+			}
+		}
+
+		if !placed {
+			bins = append(bins, splitPart{units: clusters[root], lines: size[root]})
+		}
+	}
+
+	// The largest part stays in the original file, which keeps the change smallest.
+	largest := 0
+	for b := range bins {
+		slices.Sort(bins[b].units)
+
+		if bins[b].lines > bins[largest].lines {
+			largest = b
+		}
+	}
+
+	parts := []splitPart{sf.originalPart(bins[largest].units)}
+	parts[0].reason = sf.partReason(parts[0].units)
+	taken := map[string]bool{filepath.Base(sf.absPath): true}
+
+	for b, bin := range bins {
+		if b == largest {
+			continue
+		}
+
+		bin.reason = sf.partReason(bin.units)
+		bin.file = sf.partFileName(bin.units, len(parts)+1, taken)
+		taken[bin.file] = true
+		parts = append(parts, bin)
+	}
+
+	return parts
+}
+
+// splitEdge is the affinity between two units.
+type splitEdge struct {
+	a, b   int
+	weight int
+}
+
+// affinities returns the affinity of every related pair of units, strongest first.
+func (sf *splitFile) affinities() []splitEdge {
+	weights := make(map[[2]int]int)
+	add := func(a, b, w int) {
+		if a > b {
+			a, b = b, a
+		}
+
+		weights[[2]int{a, b}] += w
+	}
+
+	importUsers := make(map[int][]int)
+	sharedUsers := make(map[types.Object][]int)
+
+	for i, u := range sf.units {
+		for j, w := range u.refs {
+			add(i, j, w)
+		}
+
+		for idx := range u.imports {
+			importUsers[idx] = append(importUsers[idx], i)
+		}
+
+		for obj := range u.shared {
+			sharedUsers[obj] = append(sharedUsers[obj], i)
+		}
+	}
+
+	// Imports and names used all over the file say nothing about which declarations belong together.
+	rare := max(2, len(sf.units)/4)
+	addShared := func(users []int) {
+		if len(users) > rare {
+			return
+		}
+
+		for x := range users {
+			for y := x + 1; y < len(users); y++ {
+				add(users[x], users[y], splitWeightShared)
+			}
+		}
+	}
+
+	for _, users := range importUsers {
+		addShared(users)
+	}
+
+	for _, users := range sharedUsers {
+		addShared(users)
+	}
+
+	edges := make([]splitEdge, 0, len(weights))
+	for k, w := range weights {
+		edges = append(edges, splitEdge{a: k[0], b: k[1], weight: w})
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].weight != edges[j].weight {
+			return edges[i].weight > edges[j].weight
+		}
+
+		if edges[i].a != edges[j].a {
+			return edges[i].a < edges[j].a
+		}
+
+		return edges[i].b < edges[j].b
+	})
+
+	return edges
+}
+
+// originalPart returns the part that stays in the original file: the given units, or all of them.
+func (sf *splitFile) originalPart(units []int) splitPart {
+	if units == nil {
+		for i := range sf.units {
+			units = append(units, i)
+		}
+	}
+
+	p := splitPart{file: filepath.Base(sf.absPath), units: units, original: true}
+	for _, i := range units {
+		p.lines += sf.units[i].lines
+	}
+
+	return p
+}
+
+// partReason explains what holds a suggested part together.
+func (sf *splitFile) partReason(units []int) string {
+	if typeName, ok := sf.dominantType(units); ok {
+		return "type " + typeName + " with its methods and constructors"
+	}
+
+	if prefix, ok := sf.dominantPrefix(units); ok {
+		return "functions prefixed " + prefix
+	}
+
+	return "declarations without a common type or prefix"
+}
+
+// dominantType returns the type whose declaration, methods and constructors make up at least a third
+// of the units' lines.
+func (sf *splitFile) dominantType(units []int) (string, bool) {
+	byType := make(map[string]int)
+	total := 0
+
+	for _, i := range units {
+		b := sf.units[i].block
+		total += sf.units[i].lines
+
+		owner := b.owner
+		if owner == nil && b.category == declCategoryType {
+			owner = b
+		}
+
+		if owner != nil {
+			byType[strings.TrimPrefix(owner.name, "type ")] += sf.units[i].lines
+		}
+	}
+
+	best := ""
+	for _, name := range sortedKeys(byType) {
+		if best == "" || byType[name] > byType[best] {
+			best = name
+		}
+	}
+
+	return best, best != "" && byType[best]*3 >= total
+}
+
+// dominantPrefix returns the first word shared by most of the units' functions, when at least two share it.
+func (sf *splitFile) dominantPrefix(units []int) (string, bool) {
+	counts := make(map[string]int)
+
+	for _, i := range units {
+		b := sf.units[i].block
+		if fd, ok := b.decl.(*ast.FuncDecl); ok && fd.Recv == nil && b.owner == nil {
+			if words := camelWords(fd.Name.Name); len(words) > 1 {
+				counts[strings.ToLower(words[0])]++
+			}
+		}
+	}
+
+	best := ""
+	for _, word := range sortedKeys(counts) {
+		if best == "" || counts[word] > counts[best] {
+			best = word
+		}
+	}
+
+	return best, counts[best] >= 2
+}
+
+// partFileName suggests a file name for a part: its dominant type in snake case, its functions' common
+// prefix, or the original name with a number. Names keep the GOOS/GOARCH suffix of the original file
+// and never collide with existing files.
+func (sf *splitFile) partFileName(units []int, n int, taken map[string]bool) string {
+	dir := filepath.Dir(sf.absPath)
+	stem := strings.TrimSuffix(filepath.Base(sf.absPath), ".go")
+	want := filenameConstraintString(filepath.Base(sf.absPath))
+
+	suffix := ""
+	if want != "" {
+		parts := strings.Split(stem, "_")
+		keep := 1
+
+		if len(parts) > 2 && knownOS[parts[len(parts)-2]] && knownArch[parts[len(parts)-1]] {
+			keep = 2
+		}
+
+		suffix = "_" + strings.Join(parts[len(parts)-keep:], "_")
+		stem = strings.Join(parts[:len(parts)-keep], "_")
+	}
+
+	var bases []string
+
+	if typeName, ok := sf.dominantType(units); ok {
+		bases = append(bases, snakeCase(typeName), stem+"_"+snakeCase(typeName))
+	} else if prefix, ok := sf.dominantPrefix(units); ok {
+		bases = append(bases, prefix, stem+"_"+prefix)
+	}
+
+	bases = append(bases, stem+"_"+strconv.Itoa(n))
+
+	usable := func(name string) bool {
+		if taken[name] || strings.HasSuffix(name, "_test.go") || filenameConstraintString(name) != want {
+			return false
+		}
+
+		_, err := os.Stat(filepath.Join(dir, name))
+
+		return os.IsNotExist(err)
+	}
+
+	for _, base := range bases {
+		if name := base + suffix + ".go"; usable(name) {
+			return name
+		}
+	}
+
+	for i := 2; ; i++ {
+		if name := stem + "_" + strconv.Itoa(n) + "_" + strconv.Itoa(i) + suffix + ".go"; usable(name) {
+			return name
+		}
+	}
+}
+
+// camelWords splits an identifier into its words: "parseHTTPRequest" becomes parse, HTTP, Request.
+func camelWords(name string) []string {
+	var words []string
+
+	runes := []rune(name)
+	start := 0
+
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_'
+		if !boundary && unicode.IsUpper(runes[i]) {
+			boundary = !unicode.IsUpper(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		}
+
+		if !boundary {
+			continue
+		}
+
+		if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+			words = append(words, word)
+		}
+
+		start = i
+	}
+
+	return words
+}
+
+// snakeCase converts an identifier to a lower-case file name stem: HTTPServer becomes http_server.
+func snakeCase(name string) string {
+	words := camelWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// describePart renders a part for the tool output.
+func (sf *splitFile) describePart(p splitPart) FileSplitPart {
+	out := FileSplitPart{
+		File:         p.file,
+		Original:     p.original,
+		Lines:        p.lines + len(p.units) + splitHeaderLines(len(sf.partImports(p))),
+		Declarations: []string{},
+		Reason:       p.reason,
+	}
+
+	if p.original {
+		out.Lines = sf.headerLines + p.lines + len(p.units)
+	}
+
+	for _, i := range p.units {
+		out.Declarations = append(out.Declarations, sf.units[i].name)
+	}
+
+	for _, imp := range sf.partImports(p) {
+		out.Imports = append(out.Imports, imp.text)
+	}
+
+	return out
+}
+
+// splitHeaderLines estimates the lines of a package clause and an import block of n imports.
+func splitHeaderLines(n int) int {
+	switch n {
+	case 0:
+		return 1
+	case 1:
+		return 3
+	}
+
+	return n + 4
+}
+
+// partImports returns the imports the units of a part use, in their original order. Blank imports are
+// kept by the original file only.
+func (sf *splitFile) partImports(p splitPart) []splitImport {
+	used := make(map[int]struct{})
+	for _, i := range p.units {
+		for idx := range sf.units[i].imports {
+			used[idx] = struct{}{}
+		}
+	}
+
+	var imports []splitImport
+
+	for idx, imp := range sf.imports {
+		if _, ok := used[idx]; ok || (imp.blank && p.original) {
+			imports = append(imports, imp)
+		}
+	}
+
+	return imports
+}
+
+// planFromInput turns a caller's plan into parts. Declarations the plan does not mention stay in the
+// original file, which always comes first.
+func (sf *splitFile) planFromInput(plans []FileSplitPlan) ([]splitPart, error) {
+	original := filepath.Base(sf.absPath)
+	want := filenameConstraintString(original)
+	dir := filepath.Dir(sf.absPath)
+
+	byName := make(map[string]int, len(sf.units))
+	names := make([]string, 0, len(sf.units))
+
+	for i, u := range sf.units {
+		byName[u.name] = i
+		names = append(names, u.name)
+	}
+
+	assigned := make(map[int]bool)
+	files := make(map[string]bool)
+	parts := []splitPart{{file: original, original: true}}
+
+	for _, plan := range plans {
+		name := plan.File
+
+		switch {
+		case name == "" || filepath.Base(name) != name || !strings.HasSuffix(name, ".go"):
+			return nil, invalidInput("part file %q must be a .go file name without a directory", name)
+		case strings.HasSuffix(name, "_test.go"):
+			return nil, invalidInput("part file %q must not be a test file", name)
+		case files[name]:
+			return nil, invalidInput("part file %q is listed twice", name)
+		case len(plan.Declarations) == 0:
+			return nil, invalidInput("part file %q lists no declarations", name)
+		}
+
+		files[name] = true
+
+		p := &parts[0]
+
+		if name != original {
+			if bc := filenameConstraintString(name); bc != want {
+				return nil, invalidInput("part file %q implies build constraint %q, but %s has %q", name, bc, original, want)
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+				return nil, invalidInput("part file %q already exists", name)
+			}
+
+			parts = append(parts, splitPart{file: name})
+			p = &parts[len(parts)-1]
+		}
+
+		for _, decl := range plan.Declarations {
+			i, ok := byName[decl]
+			if !ok {
+				return nil, notFound(names, "declaration %q not found in %s", decl, sf.relPath)
+			}
+
+			if assigned[i] {
+				return nil, invalidInput("declaration %q is assigned twice", decl)
+			}
+
+			assigned[i] = true
+			p.units = append(p.units, i)
+			p.lines += sf.units[i].lines
+		}
+	}
+
+	for i, u := range sf.units {
+		if !assigned[i] {
+			parts[0].units = append(parts[0].units, i)
+			parts[0].lines += u.lines
+		}
+	}
+
+	for i := range parts {
+		slices.Sort(parts[i].units)
+	}
+
+	return parts, nil
+}
+
+// renderPart builds the formatted content of a part's file. The original file keeps everything above
+// its imports (file comments, build constraints, package clause) and its trailing comments; new files
+// get the build constraints and the package clause.
+func (sf *splitFile) renderPart(layout *declLayout, p splitPart) ([]byte, error) {
+	var header strings.Builder
+
+	part := &declLayout{}
+
+	if p.original {
+		fset := token.NewFileSet()
+
+		f, err := parser.ParseFile(fset, layout.path, layout.src, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Go file %q: %w", sf.relPath, err)
+		}
+
+		header.Write(layout.src[:lineEndOffset(layout.src, fset.Position(f.Name.End()).Offset)])
+
+		part.tail = layout.tail
+	} else {
+		for _, group := range sf.file.Comments {
+			if group.Pos() >= sf.file.Package {
+				break
+			}
+
+			for _, c := range group.List {
+				if constraint.IsGoBuild(c.Text) || constraint.IsPlusBuild(c.Text) {
+					header.WriteString(c.Text + "\n")
+				}
+			}
+		}
+
+		if header.Len() > 0 {
+			header.WriteString("\n")
+		}
+
+		header.WriteString("package " + sf.file.Name.Name + "\n")
+	}
+
+	imports := sf.partImports(p)
+
+	switch len(imports) {
+	case 0:
+	case 1:
+		header.WriteString("\nimport " + imports[0].text + "\n")
+	default:
+		header.WriteString("\nimport (\n")
+
+		for i, imp := range imports {
+			if i > 0 && imp.group != imports[i-1].group {
+				header.WriteString("\n")
+			}
+
+			header.WriteString("\t" + imp.text + "\n")
+		}
+
+		header.WriteString(")\n")
+	}
+
+	part.header = []byte(header.String())
+
+	blocks := make([]*declBlock, 0, len(p.units))
+	for _, i := range p.units {
+		blocks = append(blocks, layout.blocks[i])
+	}
+
+	content, err := format.Source(part.render(blocks))
+	if err != nil {
+		return nil, fmt.Errorf("split file %s does not parse: %w", p.file, err)
+	}
+
+	return content, nil
+}
+
+// checkSplitPackage type-checks pkg with the given file contents replacing or adding to its files.
+// Imports resolve to the packages pkg was checked against, so no build tool runs.
+func checkSplitPackage(pkg *packages.Package, overlay map[string][]byte) error {
+	paths := slices.Clone(pkg.CompiledGoFiles)
+	for _, p := range sortedKeys(overlay) {
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(paths))
+
+	for _, p := range paths {
+		src, ok := overlay[p]
+		if !ok {
+			var err error
+
+			if src, err = os.ReadFile(p); err != nil {
+				return err
+			}
+		}
+
+		f, err := parser.ParseFile(fset, p, src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("split would not parse: %w", err)
+		}
+
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: packageImporter(pkg.Types.Imports())}
+	if _, err := conf.Check(pkg.PkgPath, fset, files, nil); err != nil {
+		return fmt.Errorf("split would not type-check: %w", err)
+	}
+
+	return nil
+}
+
+// packageImporter resolves imports to already type-checked packages.
+type packageImporter []*types.Package
+
+// Import implements types.Importer.
+func (imported packageImporter) Import(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+
+	for _, p := range imported {
+		if p.Path() == path || strings.HasSuffix(p.Path(), "/vendor/"+path) {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("package %q is not imported by the original package", path)
+}
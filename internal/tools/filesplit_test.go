@@ -0,0 +1,195 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const splitSource = `//go:build !js
+
+// Package lang caches and formats values.
+package lang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cache stores values by normalized key.
+type Cache struct {
+	items map[string]int
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{items: make(map[string]int)}
+}
+
+// Put stores v under k.
+func (c *Cache) Put(k string, v int) {
+	c.items[normalizeKey(k)] = v
+}
+
+// Get returns the value stored under k.
+func (c *Cache) Get(k string) int {
+	return c.items[normalizeKey(k)]
+}
+
+func normalizeKey(k string) string {
+	return strings.ToLower(strings.TrimSpace(k))
+}
+
+// formatInt renders a value.
+func formatInt(v int) string {
+	return strconv.Itoa(v)
+}
+
+// FormatPair renders a key and its value.
+func FormatPair(k string, v int) string {
+	return fmt.Sprintf("%s=%s", k, formatInt(v))
+}
+`
+
+func TestSuggestFileSplit_GroupsByCohesion(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"big.go": splitSource})
+
+	_, out, err := tools.SuggestFileSplit(context.Background(), &mcp.CallToolRequest{},
+		tools.SuggestFileSplitInput{Dir: dir, File: "big.go", TargetMaxLines: 35})
+	if err != nil {
+		t.Fatalf("SuggestFileSplit error: %v", err)
+	}
+
+	if !out.SplitNeeded || len(out.Parts) != 2 {
+		t.Fatalf("expected a split into 2 files, got %+v", out)
+	}
+
+	cache, format := out.Parts[0], out.Parts[1]
+	if !cache.Original || cache.File != "big.go" {
+		t.Fatalf("expected the larger part to keep big.go, got %+v", cache)
+	}
+
+	wantCache := []string{"type Cache", "NewCache", "Cache.Put", "Cache.Get", "normalizeKey"}
+	if !slices.Equal(cache.Declarations, wantCache) || !slices.Equal(cache.Imports, []string{`"strings"`}) {
+		t.Fatalf("unexpected original part: %+v", cache)
+	}
+
+	if format.File != "format.go" || format.Reason != "functions prefixed format" {
+		t.Fatalf("expected the format functions in format.go, got %+v", format)
+	}
+
+	if !slices.Equal(format.Declarations, []string{"formatInt", "FormatPair"}) ||
+		!slices.Equal(format.Imports, []string{`"fmt"`, `"strconv"`}) {
+		t.Fatalf("unexpected new part: %+v", format)
+	}
+}
+
+func TestSuggestFileSplit_SmallFile(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"big.go": splitSource})
+
+	_, out, err := tools.SuggestFileSplit(context.Background(), &mcp.CallToolRequest{},
+		tools.SuggestFileSplitInput{Dir: dir, File: "big.go"})
+	if err != nil {
+		t.Fatalf("SuggestFileSplit error: %v", err)
+	}
+
+	if out.SplitNeeded || out.TargetMaxLines != 800 || len(out.Parts) != 1 || len(out.Parts[0].Declarations) != 7 {
+		t.Fatalf("expected no split below the default target, got %+v", out)
+	}
+}
+
+func TestApplyFileSplit_WritesTypeCheckedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"big.go": splitSource})
+
+	in := tools.ApplyFileSplitInput{Dir: dir, File: "big.go", TargetMaxLines: 35, DryRun: true}
+
+	_, out, err := tools.ApplyFileSplit(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ApplyFileSplit dry run error: %v", err)
+	}
+
+	if !out.Changed || !strings.Contains(out.Diff, "+++ b/format.go") {
+		t.Fatalf("expected a diff creating format.go, got %+v", out)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "format.go")); !os.IsNotExist(err) {
+		t.Fatalf("dry run must not create files, stat error: %v", err)
+	}
+
+	in.DryRun = false
+
+	if _, _, err := tools.ApplyFileSplit(context.Background(), &mcp.CallToolRequest{}, in); err != nil {
+		t.Fatalf("ApplyFileSplit error: %v", err)
+	}
+
+	created, err := os.ReadFile(filepath.Join(dir, "format.go"))
+	if err != nil {
+		t.Fatalf("read format.go: %v", err)
+	}
+
+	want := "//go:build !js\n\npackage lang\n\nimport (\n\t\"fmt\"\n\t\"strconv\"\n)\n\n// formatInt renders a value.\n"
+	if !strings.HasPrefix(string(created), want) {
+		t.Fatalf("unexpected format.go:\n%s", created)
+	}
+
+	original, err := os.ReadFile(filepath.Join(dir, "big.go"))
+	if err != nil {
+		t.Fatalf("read big.go: %v", err)
+	}
+
+	if !strings.Contains(string(original), "// Package lang caches and formats values.\npackage lang\n\nimport \"strings\"\n") ||
+		strings.Contains(string(original), "FormatPair") {
+		t.Fatalf("unexpected big.go after split:\n%s", original)
+	}
+
+	// The split package must still answer type-dependent queries.
+	_, defs, err := tools.FindDefinitions(context.Background(), &mcp.CallToolRequest{},
+		tools.FindDefinitionsInput{Dir: dir, Ident: "FormatPair"})
+	if err != nil || defs.Total != 1 || defs.Groups[0].File != "format.go" {
+		t.Fatalf("expected FormatPair in format.go, got %+v (err %v)", defs, err)
+	}
+}
+
+func TestApplyFileSplit_ExplicitPlan(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"big.go": splitSource})
+
+	plan := []tools.FileSplitPlan{{File: "keys.go", Declarations: []string{"normalizeKey"}}}
+
+	_, out, err := tools.ApplyFileSplit(context.Background(), &mcp.CallToolRequest{},
+		tools.ApplyFileSplitInput{Dir: dir, File: "big.go", Parts: plan, DryRun: true, DiffMode: "summary"})
+	if err != nil {
+		t.Fatalf("ApplyFileSplit error: %v", err)
+	}
+
+	if len(out.Files) != 2 || out.Files[1].File != "keys.go" || !slices.Equal(out.Files[1].Imports, []string{`"strings"`}) {
+		t.Fatalf("unexpected files: %+v", out.Files)
+	}
+
+	if slices.Contains(out.Files[0].Declarations, "normalizeKey") || slices.Contains(out.Files[0].Imports, `"strings"`) {
+		t.Fatalf("normalizeKey and its import must leave big.go: %+v", out.Files[0])
+	}
+
+	plan[0].Declarations = []string{"missing"}
+
+	_, _, err = tools.ApplyFileSplit(context.Background(), &mcp.CallToolRequest{},
+		tools.ApplyFileSplitInput{Dir: dir, File: "big.go", Parts: plan, DryRun: true})
+
+	if err == nil || tools.AsToolError(err).Code != tools.CodeNotFound {
+		t.Fatalf("expected NOT_FOUND for an unknown declaration, got %v", err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -41,9 +43,21 @@ func FindReferences(ctx context.Context, _ *mcp.CallToolRequest, input FindRefer
 		return fail(out, err)
 	}
 
+	snippets, err := newSnippetSpec(input.SnippetLines, input.SnippetMode, input.SnippetMaxLines)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if input.Compact && input.LSPLocations {
+		return fail(out, invalidInput("compact cannot be combined with lspLocations"))
+	}
+
+	fileFilter := normalizeFileFilter(input.Dir, input.File)
+
 	start := logStart("FindReferences", logFields(
 		input.Dir,
 		newLogField("ident", input.Ident),
+		newLogField("symbolId", input.SymbolID),
 		newLogField("kind", input.Kind),
 	))
 
@@ -51,18 +65,42 @@ func FindReferences(ctx context.Context, _ *mcp.CallToolRequest, input FindRefer
 
 	defer func() { logEnd("FindReferences", start, resultCount) }()
 
-	mode := loadModeSyntaxTypes | packages.NeedFiles
+	// An imported index artifact stores answers without a kind or package filter or columns, with
+	// single-line snippets.
+	if input.Kind == "" && input.Package == "" && input.SymbolID == "" && snippets.isDefault() && !input.LSPLocations && !input.OnlyFuncValues {
+		if records, symbolID, ok := importedReferences(ctx, input.Dir, input.Ident); ok {
+			if fileFilter != "" {
+				records = slices.DeleteFunc(records, func(rec locationRecord) bool {
+					return !matchesFileFilter(rec.File, fileFilter)
+				})
+			}
+
+			out.SymbolID = symbolID
+			resultCount = pageReferences(&out, records, input.Offset, input.Limit, input.Compact)
+
+			return nil, out, nil
+		}
+	}
+
+	mode := loadModeSyntaxTypesFiles
 
 	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
 	if err != nil {
 		return fail(out, err)
 	}
 
-	target := findTargetObject(ctx, pkgs, input.Ident, input.Kind)
-	if target == nil {
-		return nil, out, fmt.Errorf("symbol %q not found", input.Ident)
+	target, err := findSymbolTarget(ctx, pkgs, input.Dir, input.SymbolID, input.Ident, input.Kind, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	// A symbol ID selects one declaration, even among same-named locals of the same type.
+	same := sameObject
+	if input.SymbolID != "" {
+		input.Ident, input.Kind, same = target.Name(), "", sameDeclaration
 	}
 
+	out.SymbolID = objectSymbolID(pkgs, target)
 	records := make([]locationRecord, 0)
 
 	for _, pkg := range pkgs {
@@ -70,11 +108,24 @@ func FindReferences(ctx context.Context, _ *mcp.CallToolRequest, input FindRefer
 			return fail(out, context.Canceled)
 		}
 
+		promoted := promotedMethodSelections(pkg.TypesInfo, target)
+
 		for i, file := range pkg.Syntax {
 			relPath := resolveFilePath(pkg, input.Dir, i, file)
 			lines := getFileLines(pkg.Fset, file)
 
+			// stack holds the nodes enclosing n, n included, for funcReferenceUsage.
+			var stack []ast.Node
+
 			ast.Inspect(file, func(n ast.Node) bool {
+				if n == nil {
+					stack = stack[:len(stack)-1]
+
+					return true
+				}
+
+				stack = append(stack, n)
+
 				ident, ok := n.(*ast.Ident)
 				if !ok || ident.Name != input.Ident {
 					return true
@@ -85,7 +136,8 @@ func FindReferences(ctx context.Context, _ *mcp.CallToolRequest, input FindRefer
 					return true
 				}
 
-				if !sameObject(obj, target) {
+				_, indirect := promoted[ident]
+				if !indirect && !same(obj, target) {
 					return true
 				}
 
@@ -94,30 +146,59 @@ func FindReferences(ctx context.Context, _ *mcp.CallToolRequest, input FindRefer
 					return true
 				}
 
-				if input.File != "" && !strings.HasSuffix(pos.Filename, input.File) {
+				if !matchesFileFilter(relPath, fileFilter) {
+					return true
+				}
+
+				var usage, destination string
+				if _, ok := target.(*types.Func); ok {
+					usage, destination = funcReferenceUsage(pkg.TypesInfo, stack)
+				}
+
+				if input.OnlyFuncValues && usage != usageFuncValue {
 					return true
 				}
 
-				snip := extractSnippet(lines, pos.Line)
-				appendReference(&records, input.Dir, relPath, pos.Line, snip)
+				var loc *LSPLocation
+				if input.LSPLocations {
+					loc = lspLocationAt(pos, ident.Name)
+				}
+
+				snip := snippets.extract(lines, pkg.Fset, file, ident.Pos())
+				appendReference(&records, input.Dir, pos.Filename, pos.Line, snip, indirect, loc)
+				// Direct calls stay untagged so the default payload keeps its shape.
+				if usage == usageFuncValue {
+					records[len(records)-1].Usage, records[len(records)-1].Destination = usage, destination
+				}
 
 				return true
 			})
 		}
 	}
 
+	resultCount = pageReferences(&out, records, input.Offset, input.Limit, input.Compact)
+
+	return nil, out, nil
+}
+
+// pageReferences sorts records, fills out with the requested page of them grouped by file, as tuples
+// when compact, and returns the page size.
+func pageReferences(out *FindReferencesOutput, records []locationRecord, offset, limit int, compact bool) int {
 	sortLocationRecords(records)
 
 	out.Total = len(records)
 
-	offset, paged := applyPagination(records, input.Offset, input.Limit)
+	offset, paged := applyPagination(records, offset, limit)
 	out.Offset = offset
-	out.Limit = input.Limit
+	out.Limit = limit
 
-	resultCount = len(paged)
-	out.Groups = makeReferenceGroups(paged)
+	if compact {
+		out.Files = makeCompactReferenceGroups(paged)
+	} else {
+		out.Groups = makeReferenceGroups(paged)
+	}
 
-	return nil, out, nil
+	return len(paged)
 }
 
 // FindBestContext returns a curated, minimal context bundle for a symbol.
@@ -137,6 +218,7 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 	start := logStart("FindBestContext", logFields(
 		input.Dir,
 		newLogField("ident", input.Ident),
+		newLogField("symbolId", input.SymbolID),
 		newLogField("kind", input.Kind),
 	))
 
@@ -144,16 +226,27 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 
 	defer func() { logEnd("FindBestContext", start, resultCount) }()
 
-	mode := loadModeSyntaxTypes | packages.NeedFiles
+	snippets, err := newSnippetSpec(input.SnippetLines, input.SnippetMode, input.SnippetMaxLines)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	mode := loadModeSyntaxTypesFiles
 
 	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
 	if err != nil {
 		return fail(out, err)
 	}
 
-	target := findTargetObject(ctx, pkgs, input.Ident, input.Kind)
-	if target == nil {
-		return nil, out, fmt.Errorf("symbol %q not found", input.Ident)
+	target, err := findSymbolTarget(ctx, pkgs, input.Dir, input.SymbolID, input.Ident, input.Kind, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	matches := matchesTargetObject
+	if input.SymbolID != "" {
+		input.Ident, matches = target.Name(), sameDeclaration
+		out.Symbol = input.Ident
 	}
 
 	out.Kind = objStringKind(target)
@@ -201,7 +294,7 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 				}
 
 				obj := selectorObject(pkg.TypesInfo, node)
-				if !matchesTargetObject(obj, target) {
+				if !matches(obj, target) {
 					return true
 				}
 
@@ -213,7 +306,10 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 				rec := locationRecord{
 					File:    relPath,
 					Line:    pos.Line,
-					Snippet: extractSnippet(lines, pos.Line),
+					Snippet: snippets.extract(lines, pkg.Fset, file, selIdent.Pos()),
+				}
+				if input.LSPLocations {
+					rec.LSPLocation = lspLocationAt(pos, selIdent.Name)
 				}
 				key := fmt.Sprintf("%s:%d", relPath, pos.Line)
 
@@ -237,7 +333,7 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 				}
 
 				obj := objectForIdent(pkg.TypesInfo, ident)
-				if !matchesTargetObject(obj, target) {
+				if !matches(obj, target) {
 					return true
 				}
 
@@ -249,12 +345,16 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 				rec := locationRecord{
 					File:    relPath,
 					Line:    pos.Line,
-					Snippet: extractSnippet(lines, pos.Line),
+					Snippet: snippets.extract(lines, pkg.Fset, file, ident.Pos()),
+				}
+				if input.LSPLocations {
+					rec.LSPLocation = lspLocationAt(pos, ident.Name)
 				}
 				key := fmt.Sprintf("%s:%d", relPath, pos.Line)
 
-				if defObj := pkg.TypesInfo.Defs[ident]; defObj != nil && matchesTargetObject(defObj, target) {
+				if defObj := pkg.TypesInfo.Defs[ident]; defObj != nil && matches(defObj, target) {
 					if _, ok := seenDefinitions[key]; !ok {
+						rec.SymbolID = symbolID(pkg.TypesInfo, defObj)
 						definitionRecords = append(definitionRecords, rec)
 						seenDefinitions[key] = struct{}{}
 						definitionFiles[relPath] = struct{}{}
@@ -312,7 +412,7 @@ func FindBestContext(ctx context.Context, _ *mcp.CallToolRequest, input FindBest
 
 	defLocations := toContextLocations(definitionRecords, 0)
 	if len(defLocations) == 0 {
-		return nil, out, fmt.Errorf("definition for %q not found", input.Ident)
+		return nil, out, notFound(nil, "definition for %q not found", input.Ident)
 	}
 
 	out.Definition = &defLocations[0]
@@ -342,7 +442,13 @@ func toContextLocations(records []locationRecord, limit int) []ContextLocation {
 	result := make([]ContextLocation, 0, len(slice))
 
 	for _, rec := range slice {
-		result = append(result, ContextLocation(rec))
+		result = append(result, ContextLocation{
+			File:        rec.File,
+			Line:        rec.Line,
+			SymbolID:    rec.SymbolID,
+			Snippet:     rec.Snippet,
+			LSPLocation: rec.LSPLocation,
+		})
 	}
 
 	return result
@@ -467,6 +573,13 @@ func FindDefinitions(ctx context.Context, _ *mcp.CallToolRequest, input FindDefi
 		return fail(out, err)
 	}
 
+	snippets, err := newSnippetSpec(input.SnippetLines, input.SnippetMode, input.SnippetMaxLines)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	fileFilter := normalizeFileFilter(input.Dir, input.File)
+
 	start := logStart("FindDefinitions", logFields(
 		input.Dir,
 		newLogField("ident", input.Ident),
@@ -477,7 +590,8 @@ func FindDefinitions(ctx context.Context, _ *mcp.CallToolRequest, input FindDefi
 
 	defer func() { logEnd("FindDefinitions", start, resultCount) }()
 
-	mode := loadModeSyntaxTypes
+	// IgnoredFiles (NeedFiles) lists the platform variants excluded on this host.
+	mode := loadModeSyntaxTypesFiles
 
 	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
 	if err != nil {
@@ -485,14 +599,43 @@ func FindDefinitions(ctx context.Context, _ *mcp.CallToolRequest, input FindDefi
 	}
 
 	records := make([]locationRecord, 0)
+	searched := make([]*packages.Package, 0, len(pkgs))
+	declared := 0
 
 	for _, pkg := range pkgs {
 		if shouldStop(ctx) {
 			return fail(out, context.Canceled)
 		}
 
-		if obj := findTargetObject(ctx, []*packages.Package{pkg}, input.Ident, input.Kind); obj != nil {
-			appendDefinition(&records, input.Dir, pkg.Fset, obj.Pos(), input.File)
+		if input.Package != "" && (!hasTypes(pkg) || pkg.Types.Path() != input.Package) {
+			continue
+		}
+
+		searched = append(searched, pkg)
+
+		// Every package lists its own declarations: a definitions lookup is never ambiguous.
+		candidates, err := findTargetCandidates(ctx, []*packages.Package{pkg}, input.Ident, input.Kind, "")
+		if err != nil {
+			return fail(out, err)
+		}
+
+		declared += len(candidates)
+
+		for _, c := range candidates {
+			appendDefinition(&records, input.Dir, pkg, c.obj, fileFilter, snippets, input.LSPLocations)
+		}
+
+		appendConstrainedVariants(&records, input.Dir, pkg, input.Ident, input.Kind, fileFilter, snippets, input.LSPLocations)
+	}
+
+	// A file filter may leave a declared symbol without definitions; a symbol declared nowhere is missing.
+	if len(records) == 0 && declared == 0 {
+		if input.Package != "" && len(searched) == 0 {
+			return fail(out, missingPackage(pkgs, input.Package))
+		}
+
+		if !declaredInIgnoredFiles(input.Dir, searched, input.Ident, input.Kind) {
+			return fail(out, missingSymbol(pkgs, input.Ident, input.Kind, "symbol %q not found", input.Ident))
 		}
 	}
 
@@ -534,15 +677,37 @@ func FindImplementations(ctx context.Context, _ *mcp.CallToolRequest, input Find
 
 	defer func() { logEnd("FindImplementations", start, len(out.Implementations)) }()
 
-	mode := loadModeSyntaxTypes
+	var (
+		pkgs       []*packages.Package
+		dependency *DependencyInfo
+		err        error
+	)
+
+	if input.DependencyPackage != "" {
+		// Both the interface and its implementations are looked up in the dependency package.
+		pkgs, dependency, err = loadDependencyPackage(ctx, input.Dir, input.DependencyPackage)
+	} else {
+		// The imported index keeps no method sets, which the hints need, and no columns.
+		if impls, ok := importedImplementations(ctx, input.Dir, input.Name); ok && !input.WithHints && !input.LSPLocations {
+			out.Implementations, out.Total = impls, len(impls)
+
+			return nil, out, nil
+		}
+
+		pkgs, err = loadPackagesWithCache(ctx, input.Dir, loadModeSyntaxTypes)
+	}
 
-	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
 	if err != nil {
 		logError("FindImplementations", err, "failed to load packages")
 
 		return fail(out, err)
 	}
 
+	if dependency != nil {
+		input.Dir = dependency.Dir // file paths are reported relative to the dependency
+		dependency.apply(&out.External, &out.Dependency)
+	}
+
 	// Find the target interface/type in the type information
 	var (
 		targetObj      types.Object
@@ -581,13 +746,13 @@ func FindImplementations(ctx context.Context, _ *mcp.CallToolRequest, input Find
 	}
 
 	if targetObj == nil {
-		return nil, out, fmt.Errorf("interface or type %q not found", input.Name)
+		return nil, out, missingSymbol(pkgs, input.Name, "type", "interface or type %q not found", input.Name)
 	}
 
 	// Verify that the target is an interface
 	targetType, ok := targetObj.Type().Underlying().(*types.Interface)
 	if !ok {
-		return nil, out, fmt.Errorf("%q is not an interface", input.Name)
+		return nil, out, invalidInput("%q is not an interface", input.Name)
 	}
 
 	// Look for types that implement this interface
@@ -607,28 +772,22 @@ func FindImplementations(ctx context.Context, _ *mcp.CallToolRequest, input Find
 					// Get the type from types info
 					if obj := pkg.TypesInfo.Defs[decl.Name]; obj != nil {
 						typ := obj.Type()
-						if typ != nil && types.Implements(typ, targetType) {
-							// Type implements the interface
+						if matched, isType := implementsInterface(typ, targetType); matched {
 							pos := pkg.Fset.Position(decl.Pos())
-							out.Implementations = append(out.Implementations, Implementation{
+
+							impl := Implementation{
 								Type:      typ.String(),
+								SymbolID:  symbolID(pkg.TypesInfo, obj),
 								Interface: targetTypeName,
 								File:      relPath,
 								Line:      pos.Line,
-								IsType:    true,
-							})
-						} else if iface, ok := typ.Underlying().(*types.Interface); ok && iface != targetType {
-							// Check if it's another interface that extends the target one
-							if sameInterface(iface, targetType) || interfaceExtends(iface, targetType) {
-								pos := pkg.Fset.Position(decl.Pos())
-								out.Implementations = append(out.Implementations, Implementation{
-									Type:      typ.String(),
-									Interface: targetTypeName,
-									File:      relPath,
-									Line:      pos.Line,
-									IsType:    false,
-								})
+								IsType:    isType,
 							}
+							if input.LSPLocations {
+								impl.LSPLocation = lspLocationAt(pos, decl.Name.Name)
+							}
+
+							out.Implementations = append(out.Implementations, impl)
 						}
 					}
 				}
@@ -638,9 +797,114 @@ func FindImplementations(ctx context.Context, _ *mcp.CallToolRequest, input Find
 		}
 	}
 
+	out.Total = len(out.Implementations)
+
+	if input.WithHints && dependency == nil && len(out.Implementations) == 0 {
+		out.NextSteps = implementationHints(input.Dir, pkgs, targetObj, targetType)
+	}
+
 	return nil, out, nil
 }
 
+// implementsInterface reports whether typ implements target the way FindImplementations counts it: a type
+// whose method set satisfies target (isType), or another interface declaring at least target's methods.
+func implementsInterface(typ types.Type, target *types.Interface) (matched, isType bool) {
+	if typ == nil {
+		return false, false
+	}
+
+	if types.Implements(typ, target) {
+		return true, true
+	}
+
+	if iface, ok := typ.Underlying().(*types.Interface); ok && iface != target {
+		return sameInterface(iface, target) || interfaceExtends(iface, target), false
+	}
+
+	return false, false
+}
+
+// implementationIndex counts implementations of many interfaces in one pass: every declared type is
+// indexed once by the names in its method set, and an interface is only checked against the types
+// declaring its rarest method instead of against every type of the module.
+type implementationIndex struct {
+	all      []*types.TypeName
+	byMethod map[string][]*types.TypeName
+}
+
+// newImplementationIndex indexes the types declared by the type specs of pkgs. It fails only when ctx is
+// cancelled.
+func newImplementationIndex(ctx context.Context, pkgs []*packages.Package) (*implementationIndex, error) {
+	idx := &implementationIndex{byMethod: make(map[string][]*types.TypeName)}
+
+	err := walkPackageFiles(ctx, pkgs, "", func(pkg *packages.Package, file *ast.File, _ string, _ int) error {
+		if pkg.TypesInfo == nil {
+			return nil
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+
+			tn, ok := pkg.TypesInfo.Defs[ts.Name].(*types.TypeName)
+			if !ok {
+				return true
+			}
+
+			idx.all = append(idx.all, tn)
+
+			mset := types.NewMethodSet(tn.Type())
+			for i := range mset.Len() {
+				name := mset.At(i).Obj().Name()
+				idx.byMethod[name] = append(idx.byMethod[name], tn)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// count returns the number of indexed types, other than iface itself, implementing or extending iface.
+func (idx *implementationIndex) count(iface *types.TypeName) int {
+	target, ok := iface.Type().Underlying().(*types.Interface)
+	if !ok {
+		return 0
+	}
+
+	candidates := idx.all
+
+	for i := range target.NumMethods() {
+		if byName := idx.byMethod[target.Method(i).Name()]; i == 0 || len(byName) < len(candidates) {
+			candidates = byName
+		}
+	}
+
+	n := 0
+
+	for _, tn := range candidates {
+		if tn == iface {
+			continue
+		}
+
+		if matched, _ := implementsInterface(tn.Type(), target); matched {
+			n++
+		}
+	}
+
+	return n
+}
+
+// objectForIdent resolves an identifier through Defs, Uses and Selections; it returns nil when info is nil
+// because the package was loaded without type information.
 func objectForIdent(info *types.Info, ident *ast.Ident) types.Object {
 	if info == nil || ident == nil {
 		return nil
@@ -712,3 +976,280 @@ func matchesTargetObject(obj types.Object, target types.Object) bool {
 
 	return false
 }
+
+// FindConstructions finds all places where a named type is constructed: composite literals
+// (keyed and positional, including &T{...} and elided elements of []T / map[K]T), optionally new(T)
+// and functions returning T or *T.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, type name and optional construction kinds
+//
+// Returns:
+//   - MCP tool call result
+//   - construction sites grouped by file and optional constructor functions
+//   - error if the type is not found or another error occurred
+func FindConstructions(ctx context.Context, _ *mcp.CallToolRequest, input FindConstructionsInput) (
+	*mcp.CallToolResult,
+	FindConstructionsOutput,
+	error,
+) {
+	start := logStart("FindConstructions", logFields(
+		input.Dir,
+		newLogField("typeName", input.TypeName),
+	))
+	out := FindConstructionsOutput{}
+
+	defer func() { logEnd("FindConstructions", start, out.Total) }()
+
+	if input.TypeName == "" {
+		return fail(out, invalidInput("typeName is required"))
+	}
+
+	mode := loadModeSyntaxTypesFiles
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	tn, err := lookupTypeName(pkgs, input.TypeName)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	targets := map[string]struct{}{objectKey(tn): {}}
+
+	sites := make(map[string][]ConstructionSite)
+	constructors := make(map[string]*ConstructorFunc)
+	calls := make(map[string]int)
+	seen := make(map[token.Position]struct{})
+
+	// Test variants repeat the package's files, so each position is reported once.
+	firstVisit := func(pos token.Position) bool {
+		if _, ok := seen[pos]; ok {
+			return false
+		}
+
+		seen[pos] = struct{}{}
+
+		return true
+	}
+
+	for _, pkg := range pkgs {
+		if shouldStop(ctx) {
+			return fail(out, context.Canceled)
+		}
+
+		for i, file := range pkg.Syntax {
+			relPath := resolveFilePath(pkg, input.Dir, i, file)
+			lines := getFileLines(pkg.Fset, file)
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.CompositeLit:
+					named := namedTypeOf(pkg.TypesInfo.TypeOf(node))
+					if !isTargetNamed(named, targets) {
+						return true
+					}
+
+					pos := pkg.Fset.Position(node.Pos())
+					if !firstVisit(pos) {
+						return true
+					}
+
+					_, isStruct := named.Underlying().(*types.Struct)
+					sites[relPath] = append(sites[relPath], ConstructionSite{
+						Line:       pos.Line,
+						Kind:       "literal",
+						Positional: isStruct && isPositionalLiteral(node),
+						Snippet:    extractSnippet(lines, pos.Line),
+					})
+				case *ast.CallExpr:
+					if input.IncludeNew && isBuiltinNew(pkg.TypesInfo, node) && len(node.Args) == 1 {
+						if isTargetNamed(namedTypeOf(pkg.TypesInfo.TypeOf(node.Args[0])), targets) {
+							if pos := pkg.Fset.Position(node.Pos()); firstVisit(pos) {
+								sites[relPath] = append(sites[relPath], ConstructionSite{
+									Line:    pos.Line,
+									Kind:    "new",
+									Snippet: extractSnippet(lines, pos.Line),
+								})
+							}
+						}
+					}
+
+					if input.IncludeConstructors {
+						if fn := calledFunc(pkg.TypesInfo, node); fn != nil {
+							if pos := pkg.Fset.Position(node.Lparen); firstVisit(pos) {
+								calls[funcKey(fn)]++
+							}
+						}
+					}
+				case *ast.FuncDecl:
+					if !input.IncludeConstructors || node.Recv != nil {
+						return true
+					}
+
+					fn, ok := pkg.TypesInfo.Defs[node.Name].(*types.Func)
+					if !ok {
+						return true
+					}
+
+					if pointer, ok := returnsTarget(fn, targets); ok {
+						key := funcKey(fn)
+						if _, exists := constructors[key]; !exists {
+							constructors[key] = &ConstructorFunc{
+								Name:    node.Name.Name,
+								File:    relPath,
+								Line:    pkg.Fset.Position(node.Pos()).Line,
+								Pointer: pointer,
+							}
+						}
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	out.Groups = makeConstructionGroups(sites)
+	for _, group := range out.Groups {
+		for _, site := range group.Sites {
+			out.Total++
+
+			if site.Positional {
+				out.PositionalCount++
+			}
+		}
+	}
+
+	for key, ctor := range constructors {
+		ctor.CallSites = calls[key]
+		out.Constructors = append(out.Constructors, *ctor)
+	}
+
+	sort.Slice(out.Constructors, func(i, j int) bool {
+		if out.Constructors[i].File != out.Constructors[j].File {
+			return out.Constructors[i].File < out.Constructors[j].File
+		}
+
+		return out.Constructors[i].Line < out.Constructors[j].Line
+	})
+
+	return nil, out, nil
+}
+
+// namedTypeOf returns the named type behind t, or nil.
+func namedTypeOf(t types.Type) *types.Named {
+	if t == nil {
+		return nil
+	}
+
+	named, _ := types.Unalias(t).(*types.Named)
+
+	return named
+}
+
+// isTargetNamed reports whether a named type (or its generic origin) is one of the target type names.
+func isTargetNamed(named *types.Named, targets map[string]struct{}) bool {
+	if named == nil || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	_, ok := targets[objectKey(named.Origin().Obj())]
+
+	return ok
+}
+
+// isPositionalLiteral reports whether a composite literal lists its elements without keys.
+func isPositionalLiteral(lit *ast.CompositeLit) bool {
+	if len(lit.Elts) == 0 {
+		return false
+	}
+
+	_, keyed := lit.Elts[0].(*ast.KeyValueExpr)
+
+	return !keyed
+}
+
+// isBuiltinNew reports whether call invokes the builtin new.
+func isBuiltinNew(info *types.Info, call *ast.CallExpr) bool {
+	return isBuiltinCall(info, call, "new")
+}
+
+// isBuiltinCall reports whether call invokes the named builtin function (new, make, append, ...).
+func isBuiltinCall(info *types.Info, call *ast.CallExpr, name string) bool {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	builtin, ok := info.Uses[ident].(*types.Builtin)
+
+	return ok && builtin.Name() == name
+}
+
+// calledFunc returns the statically called function of a call expression, or nil.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var obj types.Object
+
+	switch fun := calleeExpr(ast.Unparen(call.Fun)).(type) {
+	case *ast.Ident:
+		obj = info.Uses[fun]
+	case *ast.SelectorExpr:
+		obj = selectorObject(info, fun)
+	}
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	return fn.Origin()
+}
+
+// returnsTarget reports whether fn has a result of type T or *T (ok) and whether that result is a pointer.
+func returnsTarget(fn *types.Func, targets map[string]struct{}) (pointer, ok bool) {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return false, false
+	}
+
+	for i := range sig.Results().Len() {
+		t := sig.Results().At(i).Type()
+		if isTargetNamed(namedTypeOf(t), targets) {
+			return false, true
+		}
+
+		if ptr, ok := t.(*types.Pointer); ok && isTargetNamed(namedTypeOf(ptr.Elem()), targets) {
+			return true, true
+		}
+	}
+
+	return false, false
+}
+
+// makeConstructionGroups sorts construction sites by file and line.
+func makeConstructionGroups(sites map[string][]ConstructionSite) []ConstructionGroup {
+	if len(sites) == 0 {
+		return nil
+	}
+
+	groups := make([]ConstructionGroup, 0, len(sites))
+
+	for file, list := range sites {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Line < list[j].Line
+		})
+
+		groups = append(groups, ConstructionGroup{File: file, Sites: list})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].File < groups[j].File
+	})
+
+	return groups
+}
@@ -2,6 +2,9 @@ package tools_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -299,6 +302,42 @@ func TestFindReferences_WithFileFilter(t *testing.T) {
 	}
 }
 
+func TestFindReferences_PromotedInterfaceMethod(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindReferencesInput{Dir: testDir(), Ident: "Fetch", File: "gateway.go"}
+
+	_, out, err := tools.FindReferences(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("FindReferences error: %v", err)
+	}
+
+	indirect := make(map[string]bool)
+	for _, ref := range flattenReferences(out.Groups) {
+		indirect[ref.entry.Snippet] = ref.entry.Indirect
+	}
+
+	want := map[string]bool{
+		"Fetch(key string) (string, error)": false,
+		"return g.Fetch(key)":               true,
+		"return p.Fetch(key)":               true,
+		"return f.Fetch(key)":               false,
+	}
+
+	for snippet, wantIndirect := range want {
+		got, ok := indirect[snippet]
+		if !ok {
+			t.Errorf("expected a reference at %q, got %+v", snippet, out.Groups)
+
+			continue
+		}
+
+		if got != wantIndirect {
+			t.Errorf("expected indirect=%v at %q, got %v", wantIndirect, snippet, got)
+		}
+	}
+}
+
 func TestFindReferences_Pagination(t *testing.T) {
 	t.Parallel()
 
@@ -424,14 +463,9 @@ func TestFindDefinitions_WithNonexistentIdent(t *testing.T) {
 		Ident: "NonexistentSymbol",
 	}
 
-	_, out, err := tools.FindDefinitions(context.Background(), &mcp.CallToolRequest{}, in)
-	if err != nil {
-		t.Fatalf("FindDefinitions error: %v", err)
-	}
-
-	// Should return empty result for non-existent identifier
-	if out.Total != 0 {
-		t.Errorf("expected 0 definitions for non-existent symbol, got total %v", out.Total)
+	_, _, err := tools.FindDefinitions(context.Background(), &mcp.CallToolRequest{}, in)
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound {
+		t.Fatalf("expected NOT_FOUND for non-existent symbol, got %v", err)
 	}
 }
 
@@ -518,6 +552,42 @@ func TestFindDefinitions_Pagination(t *testing.T) {
 	}
 }
 
+func TestFindDefinitions_PlatformVariants(t *testing.T) {
+	t.Parallel()
+
+	_, out, err := tools.FindDefinitions(context.Background(), &mcp.CallToolRequest{}, tools.FindDefinitionsInput{
+		Dir:   testDir(),
+		Ident: "PlatformName",
+	})
+	if err != nil {
+		t.Fatalf("FindDefinitions error: %v", err)
+	}
+
+	constraints := make(map[string]string)
+
+	for _, group := range out.Groups {
+		for _, def := range group.Definitions {
+			constraints[group.File] = def.BuildConstraint
+		}
+	}
+
+	want := map[string]string{
+		"platform_linux.go":   "linux",
+		"platform_other.go":   "!linux && !windows",
+		"platform_windows.go": "windows",
+	}
+
+	if out.Total != len(want) || len(constraints) != len(want) {
+		t.Fatalf("expected one definition per platform variant, got %+v", out.Groups)
+	}
+
+	for file, constraint := range want {
+		if constraints[file] != constraint {
+			t.Errorf("%s: expected build constraint %q, got %q", file, constraint, constraints[file])
+		}
+	}
+}
+
 func TestFindImplementations(t *testing.T) {
 	t.Parallel()
 
@@ -619,3 +689,220 @@ func BenchmarkFindDefinitions(b *testing.B) {
 		}
 	}
 }
+
+func TestFindConstructions(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindConstructionsInput{
+		Dir:                 testDir(),
+		TypeName:            "Point",
+		IncludeNew:          true,
+		IncludeConstructors: true,
+	}
+
+	_, out, err := tools.FindConstructions(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("FindConstructions error: %v", err)
+	}
+
+	if len(out.Groups) != 1 || out.Groups[0].File != "point.go" {
+		t.Fatalf("expected construction sites only in point.go, got %+v", out.Groups)
+	}
+
+	literals, news := 0, 0
+	positionalLines := []int{}
+
+	for _, site := range out.Groups[0].Sites {
+		switch site.Kind {
+		case "literal":
+			literals++
+		case "new":
+			news++
+		}
+
+		if site.Positional {
+			positionalLines = append(positionalLines, site.Line)
+		}
+	}
+
+	// &Point{X, Y}, Point{0, 0}, and the two elided elements of []Point.
+	if literals != 4 {
+		t.Errorf("expected 4 literals, got %d: %+v", literals, out.Groups[0].Sites)
+	}
+
+	if news != 1 {
+		t.Errorf("expected 1 new(Point) call, got %d", news)
+	}
+
+	if out.PositionalCount != 2 || len(positionalLines) != 2 {
+		t.Errorf("expected 2 positional literals, got %d (lines %v)", out.PositionalCount, positionalLines)
+	}
+
+	if out.Total != 5 {
+		t.Errorf("expected total 5, got %d", out.Total)
+	}
+
+	ctors := map[string]tools.ConstructorFunc{}
+	for _, c := range out.Constructors {
+		ctors[c.Name] = c
+	}
+
+	if c, ok := ctors["NewPoint"]; !ok || !c.Pointer || c.CallSites != 1 {
+		t.Errorf("expected NewPoint pointer constructor with 1 call site, got %+v", c)
+	}
+
+	if c, ok := ctors["origin"]; !ok || c.Pointer {
+		t.Errorf("expected origin value constructor, got %+v", c)
+	}
+
+	if _, ok := ctors["corners"]; ok {
+		t.Errorf("did not expect corners ([]Point) to be a constructor")
+	}
+}
+
+func TestFindConstructions_TypeNotFound(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindConstructionsInput{Dir: testDir(), TypeName: "NoSuchType"}
+
+	_, _, err := tools.FindConstructions(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatal("expected error for unknown type")
+	}
+}
+
+func TestFindConstructions_SameNameInTwoPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"db/db.go":   "package db\n\ntype Config struct{ DSN string }\n\nvar Default = Config{DSN: \"local\"}\n",
+		"web/web.go": "package web\n\ntype Config struct{ Addr string }\n\nvar Default = Config{Addr: \":80\"}\n\nvar TLS = Config{\":443\"}\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, _, err := tools.FindConstructions(ctx, req, tools.FindConstructionsInput{Dir: dir, TypeName: "Config"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeAmbiguous {
+		t.Fatalf("expected AMBIGUOUS for Config declared in two packages, got %v", err)
+	}
+
+	// A package qualifier reports the sites of that type only.
+	_, out, err := tools.FindConstructions(ctx, req, tools.FindConstructionsInput{Dir: dir, TypeName: "web.Config"})
+	if err != nil {
+		t.Fatalf("FindConstructions error: %v", err)
+	}
+
+	if out.Total != 2 || out.PositionalCount != 1 || len(out.Groups) != 1 || out.Groups[0].File != "web/web.go" {
+		t.Errorf("expected the two sites of web.Config, got %+v", out)
+	}
+}
+
+func TestFindConstructions_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindConstructionsInput{Dir: "/nonexistent/directory", TypeName: "Point"}
+
+	_, _, err := tools.FindConstructions(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatal("expected error for non-existent directory")
+	}
+}
+
+func TestFindReferences_DirSpellings(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"foo.go":     "package lang\n\nfunc Foo() int { return 1 }\n",
+		"myfoo.go":   "package lang\n\nvar my = Foo()\n",
+		"sub/sub.go": "package sub\n\nimport \"lang\"\n\nvar v = lang.Foo()\n",
+	})
+
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, want, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Foo"})
+	if err != nil {
+		t.Fatalf("FindReferences error: %v", err)
+	}
+
+	var files []string
+	for _, ref := range flattenReferences(want.Groups) {
+		files = append(files, ref.file)
+	}
+
+	if strings.Join(files, ",") != "foo.go,myfoo.go,sub/sub.go" {
+		t.Fatalf("expected module-relative files, got %v", files)
+	}
+
+	for _, variant := range []string{dir + "/", link, link + "/"} {
+		_, got, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: variant, Ident: "Foo"})
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("dir %q: got %+v (err %v), want %+v", variant, got.Groups, err, want.Groups)
+		}
+
+		_, defs, err := tools.FindDefinitions(ctx, req, tools.FindDefinitionsInput{Dir: variant, Ident: "Foo", File: "./foo.go"})
+		if err != nil || defs.Total != 1 || defs.Groups[0].File != "foo.go" {
+			t.Errorf("dir %q: expected the definition in foo.go, got %+v (err %v)", variant, defs.Groups, err)
+		}
+	}
+
+	for filter, total := range map[string]int{"foo.go": 1, "myfoo.go": 1, "sub.go": 0, "sub/sub.go": 1, filepath.Join(link, "sub", "sub.go"): 1} {
+		_, got, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: link, Ident: "Foo", File: filter})
+		if err != nil || got.Total != total {
+			t.Errorf("file %q: expected %d references, got %d (err %v)", filter, total, got.Total, err)
+		}
+	}
+}
+
+func TestFindTargetObject_SameNameInTwoPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"server/config.go": "package server\n\ntype Config struct{ Port int }\n\nfunc Load() Config { return Config{} }\n",
+		"client/config.go": "package client\n\ntype Config struct{ URL string }\n\nvar Default = Config{}\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	wantCandidates := []string{
+		"type lang/client.Config (client/config.go:3)",
+		"type lang/server.Config (server/config.go:3)",
+	}
+
+	_, _, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Config"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeAmbiguous || te.Details == nil || !reflect.DeepEqual(te.Details.Candidates, wantCandidates) {
+		t.Fatalf("expected AMBIGUOUS with both declarations, got %v", err)
+	}
+
+	_, _, err = tools.FindBestContext(ctx, req, tools.FindBestContextInput{Dir: dir, Ident: "Config"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeAmbiguous {
+		t.Errorf("getSymbolContext: expected AMBIGUOUS, got %v", err)
+	}
+
+	_, _, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, OldName: "Config", NewName: "Settings", DryRun: true})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeAmbiguous {
+		t.Errorf("renameSymbol: expected AMBIGUOUS, got %v", err)
+	}
+
+	_, refs, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Config", Package: "lang/server"})
+	if err != nil {
+		t.Fatalf("FindReferences with package: %v", err)
+	}
+
+	if refs.SymbolID != "lang/server.Config#type" || refs.Total != 3 {
+		t.Errorf("references = %s, %d; want the 3 mentions of server.Config", refs.SymbolID, refs.Total)
+	}
+
+	for _, group := range refs.Groups {
+		if group.File != "server/config.go" {
+			t.Errorf("reference in %s, want server/config.go only", group.File)
+		}
+	}
+
+	_, defs, err := tools.FindDefinitions(ctx, req, tools.FindDefinitionsInput{Dir: dir, Ident: "Config"})
+	if err != nil || defs.Total != 2 {
+		t.Errorf("getDefinitions: %+v, %v; want both declarations", defs, err)
+	}
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"go/ast"
+	"go/format"
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strconv"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// GetFingerprints returns fingerprints of files and their top-level declarations so clients can
+// detect which of their cached per-symbol artifacts are stale without diffing source.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional package filter
+//
+// Returns:
+//   - MCP tool call result
+//   - name → fingerprint maps per file
+//   - error if an error occurred while loading packages
+func GetFingerprints(ctx context.Context, _ *mcp.CallToolRequest, input GetFingerprintsInput) (
+	*mcp.CallToolResult,
+	GetFingerprintsOutput,
+	error,
+) {
+	start := logStart("GetFingerprints", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := GetFingerprintsOutput{}
+
+	defer func() { logEnd("GetFingerprints", start, len(out.Files)) }()
+
+	mode := loadModeBasicSyntax
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "GetFingerprints")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		out.Files = append(out.Files, FileFingerprints{
+			File:        relPath,
+			Package:     normalizePackagePath(pkg),
+			Fingerprint: fingerprintNode(pkg.Fset, file),
+			Symbols:     declarationFingerprints(pkg.Fset, file),
+		})
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.Slice(out.Files, func(i, j int) bool { return out.Files[i].File < out.Files[j].File })
+
+	return nil, out, nil
+}
+
+// declarationFingerprints maps top-level declaration names to their fingerprints.
+// Repeated names (several init functions) get a "#n" suffix in source order.
+func declarationFingerprints(fset *token.FileSet, file *ast.File) map[string]string {
+	result := make(map[string]string)
+
+	add := func(name string, node ast.Node) {
+		if name == "_" {
+			return
+		}
+
+		key := name
+		for n := 2; ; n++ {
+			if _, exists := result[key]; !exists {
+				break
+			}
+
+			key = name + "#" + strconv.Itoa(n)
+		}
+
+		result[key] = fingerprintNode(fset, node)
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			add(qualifiedFuncName(d), d)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name.Name, s)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						add(name.Name, s)
+					}
+				}
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// fingerprintNode returns the hex SHA-256 of a node normalized to its gofmt token stream
+// without comments, or "" if the node cannot be formatted.
+func fingerprintNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return fingerprintSource(buf.Bytes())
+}
+
+// fingerprintInterfaceMethod fingerprints an interface method as its name followed by its signature.
+func fingerprintInterfaceMethod(fset *token.FileSet, name string, field *ast.Field, enabled bool) string {
+	if !enabled {
+		return ""
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(name + " ")
+
+	if err := format.Node(&buf, fset, field.Type); err != nil {
+		return ""
+	}
+
+	return fingerprintSource(buf.Bytes())
+}
+
+// fingerprintSource hashes the token stream of src. Comments and whitespace (including line
+// endings) are not part of the stream, so the result does not depend on formatting or platform.
+func fingerprintSource(src []byte) string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+
+	s.Init(file, src, nil, 0)
+
+	h := sha256.New()
+
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		switch {
+		case tok == token.SEMICOLON:
+			lit = ";" // automatically inserted semicolons carry "\n" as literal
+		case lit == "":
+			lit = tok.String()
+		}
+
+		h.Write([]byte(lit))
+		h.Write([]byte{' '})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,165 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func fileFingerprints(t *testing.T, dir, file string) tools.FileFingerprints {
+	t.Helper()
+
+	in := tools.GetFingerprintsInput{Dir: dir, Package: "sample"}
+
+	_, out, err := tools.GetFingerprints(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("GetFingerprints error: %v", err)
+	}
+
+	for _, f := range out.Files {
+		if f.File == file {
+			return f
+		}
+	}
+
+	t.Fatalf("file %s not found in %+v", file, out.Files)
+
+	return tools.FileFingerprints{}
+}
+
+func TestGetFingerprints_MatchReadersAndListSymbols(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	point := fileFingerprints(t, testDir(), "point.go")
+
+	if point.Fingerprint == "" || len(point.Symbols["NewPoint"]) != 64 {
+		t.Fatalf("expected hex SHA-256 fingerprints, got %+v", point)
+	}
+
+	_, fn, err := tools.ReadFunc(ctx, &mcp.CallToolRequest{}, tools.ReadFuncInput{Dir: testDir(), Name: "NewPoint", WithFingerprints: true})
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if fn.Function.Fingerprint != point.Symbols["NewPoint"] {
+		t.Errorf("readFunc fingerprint %q differs from getFingerprints %q", fn.Function.Fingerprint, point.Symbols["NewPoint"])
+	}
+
+	_, st, err := tools.ReadStruct(ctx, &mcp.CallToolRequest{}, tools.ReadStructInput{Dir: testDir(), Name: "Point", WithFingerprints: true})
+	if err != nil {
+		t.Fatalf("ReadStruct error: %v", err)
+	}
+
+	if st.Struct.Fingerprint != point.Symbols["Point"] {
+		t.Errorf("readStruct fingerprint %q differs from getFingerprints %q", st.Struct.Fingerprint, point.Symbols["Point"])
+	}
+
+	_, syms, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: testDir(), Package: "sample", WithFingerprints: true})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	found := false
+
+	for _, pkg := range syms.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				if file.File == "point.go" && sym.Name == "NewPoint" {
+					found = sym.Fingerprint == point.Symbols["NewPoint"]
+				}
+			}
+		}
+	}
+
+	if !found {
+		t.Errorf("expected listSymbols fingerprint of NewPoint to match getFingerprints")
+	}
+
+	_, fn, err = tools.ReadFunc(ctx, &mcp.CallToolRequest{}, tools.ReadFuncInput{Dir: testDir(), Name: "NewPoint"})
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if fn.Function.Fingerprint != "" {
+		t.Errorf("expected no fingerprint unless requested")
+	}
+}
+
+// samplePointVariant copies the sample module to a fresh directory with point.go rewritten by edit.
+// Each variant gets its own directory so cached package loads never mask the edit.
+func samplePointVariant(t *testing.T, edit func(string) string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy sample: %v", err)
+	}
+
+	path := filepath.Join(dir, "point.go")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(edit(string(content))), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestGetFingerprints_IgnoreCommentsAndLineEndings(t *testing.T) {
+	t.Parallel()
+
+	before := fileFingerprints(t, testDir(), "point.go")
+
+	// Comments, blank lines and CRLF line endings must not change any fingerprint.
+	reformatted := samplePointVariant(t, func(src string) string {
+		src = strings.Replace(src, "return &Point{X: x, Y: y}", "// build it\n\n\treturn &Point{X: x, Y: y} // done", 1)
+
+		return strings.ReplaceAll(src, "\n", "\r\n")
+	})
+
+	after := fileFingerprints(t, reformatted, "point.go")
+	if after.Fingerprint != before.Fingerprint {
+		t.Errorf("file fingerprint changed after reformatting")
+	}
+
+	for name, fp := range before.Symbols {
+		if after.Symbols[name] != fp {
+			t.Errorf("fingerprint of %s changed after reformatting", name)
+		}
+	}
+
+	changed := samplePointVariant(t, func(src string) string {
+		return strings.Replace(src, "return &Point{X: x, Y: y}", "return &Point{X: y, Y: x}", 1)
+	})
+
+	edited := fileFingerprints(t, changed, "point.go")
+	if edited.Symbols["NewPoint"] == before.Symbols["NewPoint"] {
+		t.Errorf("expected NewPoint fingerprint to change after editing its body")
+	}
+
+	if edited.Symbols["origin"] != before.Symbols["origin"] {
+		t.Errorf("expected unrelated declarations to keep their fingerprints")
+	}
+}
+
+func TestGetFingerprints_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.GetFingerprintsInput{Dir: "/nonexistent/directory"}
+
+	_, _, err := tools.GetFingerprints(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Error("expected error for invalid directory")
+	}
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// Usages of a function or method reference in getReferences.
+const (
+	usageCall      = "call"
+	usageFuncValue = "funcValue"
+)
+
+// funcReferenceUsage classifies a reference to a function or method, whose enclosing nodes are stack with
+// the identifier last: usageCall for a call, usageFuncValue with the destination of the value otherwise.
+// Calls made by go and defer statements count as values, as the function runs apart from its call site.
+// Declarations get no usage.
+func funcReferenceUsage(info *types.Info, stack []ast.Node) (string, string) {
+	i := len(stack) - 1
+
+	ident, ok := stack[i].(*ast.Ident)
+	if !ok || info.Defs[ident] != nil {
+		return "", ""
+	}
+
+	var expr ast.Expr = ident
+
+	if sel, ok := stack[i-1].(*ast.SelectorExpr); ok && sel.Sel == ident {
+		expr, i = sel, i-1
+	}
+
+	// Parentheses and instantiations of generic functions wrap the reference.
+	for ; i > 0; i-- {
+		switch p := stack[i-1].(type) {
+		case *ast.ParenExpr:
+			expr = p
+
+			continue
+		case *ast.IndexExpr:
+			if p.X == expr {
+				expr = p
+
+				continue
+			}
+		case *ast.IndexListExpr:
+			if p.X == expr {
+				expr = p
+
+				continue
+			}
+		}
+
+		break
+	}
+
+	if i == 0 {
+		return "", ""
+	}
+
+	switch p := stack[i-1].(type) {
+	case *ast.CallExpr:
+		if p.Fun == expr {
+			if i > 1 {
+				switch stack[i-2].(type) {
+				case *ast.GoStmt:
+					return usageFuncValue, "go statement"
+				case *ast.DeferStmt:
+					return usageFuncValue, "defer statement"
+				}
+			}
+
+			return usageCall, ""
+		}
+
+		if tv, ok := info.Types[p.Fun]; ok && tv.IsType() {
+			return usageFuncValue, "converted to " + types.ExprString(p.Fun)
+		}
+
+		return usageFuncValue, fmt.Sprintf("argument %d of %s", slices.Index(p.Args, expr)+1, types.ExprString(p.Fun))
+	case *ast.AssignStmt:
+		if idx := slices.Index(p.Rhs, expr); idx >= 0 && len(p.Lhs) == len(p.Rhs) {
+			return usageFuncValue, "assigned to " + types.ExprString(p.Lhs[idx])
+		}
+	case *ast.ValueSpec:
+		if idx := slices.Index(p.Values, expr); idx >= 0 && len(p.Names) == len(p.Values) {
+			return usageFuncValue, "assigned to " + p.Names[idx].Name
+		}
+	case *ast.KeyValueExpr:
+		lit, ok := stack[i-2].(*ast.CompositeLit)
+		if !ok || p.Value != expr {
+			break
+		}
+
+		switch info.TypeOf(lit).Underlying().(type) {
+		case *types.Map:
+			return usageFuncValue, "map value for key " + types.ExprString(p.Key)
+		case *types.Struct:
+			return usageFuncValue, "field " + types.ExprString(p.Key)
+		}
+
+		return usageFuncValue, "element " + types.ExprString(p.Key)
+	case *ast.CompositeLit:
+		return usageFuncValue, "element of " + info.TypeOf(p).String()
+	case *ast.ReturnStmt:
+		return usageFuncValue, "returned"
+	}
+
+	return usageFuncValue, ""
+}
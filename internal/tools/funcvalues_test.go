@@ -0,0 +1,84 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestFindReferences_FuncValues(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"app/app.go": `package app
+
+type Handler func()
+
+type Server struct{ OnClose func() }
+
+func worker() {}
+
+func run(name string, f func()) { f() }
+
+func Start() {
+	worker()
+	run("w", worker)
+	go worker()
+	defer worker()
+	routes := map[string]func(){"/a": worker}
+	h := Handler(worker)
+	s := Server{OnClose: worker}
+	_, _, _ = routes, h, s
+}
+
+func Pick() func() { return (worker) }
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	usages := func(out tools.FindReferencesOutput) string {
+		var lines []string
+		for _, g := range out.Groups {
+			for _, r := range g.References {
+				lines = append(lines, fmt.Sprintf("%d %s %s", r.Line, r.Usage, r.Destination))
+			}
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
+	_, out, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "worker"})
+	if err != nil {
+		t.Fatalf("FindReferences: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"7  ",
+		"12  ",
+		"13 funcValue argument 2 of run",
+		"14 funcValue go statement",
+		"15 funcValue defer statement",
+		`16 funcValue map value for key "/a"`,
+		"17 funcValue converted to Handler",
+		"18 funcValue field OnClose",
+		"22 funcValue returned",
+	}, "\n")
+	if got := usages(out); got != want {
+		t.Errorf("references:\n%s\nwant:\n%s", got, want)
+	}
+
+	_, out, err = tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "worker", OnlyFuncValues: true})
+	if err != nil || out.Total != 7 || strings.Contains(usages(out), "12 ") {
+		t.Errorf("onlyFuncValues: %d references, %v:\n%s\nwant the 7 funcValue ones", out.Total, err, usages(out))
+	}
+
+	// Variables are not functions: no usage.
+	_, out, err = tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "routes"})
+	if err != nil || strings.Contains(usages(out), "funcValue") {
+		t.Errorf("routes: %v:\n%s\nwant no usage", err, usages(out))
+	}
+}
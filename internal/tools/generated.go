@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// generatedFileGenerator returns the generator named in the standard "Code generated ... DO NOT EDIT."
+// header of a generated file, e.g. "MockGen" for "// Code generated by MockGen. DO NOT EDIT.", and
+// whether the file is generated at all. The generator is empty when the header does not name one.
+func generatedFileGenerator(file *ast.File) (string, bool) {
+	if file == nil || !ast.IsGenerated(file) {
+		return "", false
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			text, ok := strings.CutPrefix(comment.Text, "// Code generated ")
+			if !ok {
+				continue
+			}
+
+			text, ok = strings.CutSuffix(text, "DO NOT EDIT.")
+			if !ok {
+				continue
+			}
+
+			text = strings.TrimPrefix(strings.TrimSpace(text), "by ")
+			text = strings.TrimRight(text, ".;, ")
+
+			return strings.Trim(text, `"`), true
+		}
+	}
+
+	return "", true
+}
+
+// declaringFile returns the parsed file of pkgs that contains pos, or nil.
+func declaringFile(pkgs []*packages.Package, pos token.Pos) *ast.File {
+	if !pos.IsValid() {
+		return nil
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			if file.FileStart <= pos && pos <= file.FileEnd {
+				return file
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// goGeneratePrefix starts a go:generate directive; go generate only honors it at the start of a line.
+const goGeneratePrefix = "//go:generate"
+
+// States of a generator's output file compared with the file holding the directive.
+const (
+	generatorOutputFresh   = "fresh"
+	generatorOutputStale   = "stale"
+	generatorOutputMissing = "missing"
+)
+
+// generatorOutputFlags are flags through which common generators name their output file.
+var generatorOutputFlags = map[string]struct{}{
+	"o":           {},
+	"out":         {},
+	"output":      {},
+	"destination": {},
+}
+
+// ListGenerators inventories the go:generate directives of a module: parsed command, generator tool,
+// output file freshness and whether the tool binary looks resolvable. Nothing is executed.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and an optional package filter
+//
+// Returns:
+//   - MCP tool call result
+//   - directives grouped by package, with per-tool counts for the module
+//   - error if packages cannot be loaded
+func ListGenerators(ctx context.Context, _ *mcp.CallToolRequest, input ListGeneratorsInput) (
+	*mcp.CallToolResult,
+	ListGeneratorsOutput,
+	error,
+) {
+	start := logStart("ListGenerators", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := ListGeneratorsOutput{}
+
+	defer func() { logEnd("ListGenerators", start, out.Total) }()
+
+	mode := loadModeBasicSyntax
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		logError("ListGenerators", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	filtered, err := filterPackagesByRequest(pkgs, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	byPackage := make(map[string][]GenerateDirective)
+	// Test variants contain the package's own files again; every file is scanned once.
+	seen := make(map[string]struct{})
+	resolved := make(map[string]generatorBinary)
+
+	if err := walkPackageFiles(ctx, filtered, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if _, ok := seen[relPath]; ok || strings.HasSuffix(pkg.PkgPath, ".test") {
+			return nil
+		}
+
+		seen[relPath] = struct{}{}
+
+		filename := pkg.Fset.File(file.Pos()).Name()
+		// Aliases defined with -command apply to the rest of their file.
+		aliases := make(map[string][]string)
+
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				posn := pkg.Fset.Position(c.Pos())
+				if posn.Column != 1 || !isGoGenerateDirective(c.Text) {
+					continue
+				}
+
+				d := GenerateDirective{File: relPath, Line: posn.Line}
+
+				words, err := splitGenerateDirective(c.Text)
+				if err != nil {
+					d.Error = err.Error()
+					byPackage[pkg.PkgPath] = append(byPackage[pkg.PkgPath], d)
+
+					continue
+				}
+
+				if words[0] == "-command" {
+					if len(words) < 3 {
+						d.Error = "-command requires a name and a command"
+						words = nil
+					} else {
+						aliases[words[1]] = words[2:]
+						d.Alias, words = words[1], words[2:]
+					}
+				} else if alias, ok := aliases[words[0]]; ok {
+					words = append(slices.Clone(alias), words[1:]...)
+				}
+
+				if len(words) > 0 {
+					d.Command, d.Args = words[0], words[1:]
+					d.Tool = generatorTool(words)
+
+					bin, ok := resolved[d.Command]
+					if !ok {
+						bin = resolveGeneratorBinary(d.Command, filepath.Dir(filename))
+						resolved[d.Command] = bin
+					}
+
+					d.Resolvable, d.BinaryPath = bin.found, bin.path
+				}
+
+				if d.Alias == "" && len(words) > 0 {
+					expanded := expandGenerateArgs(words, filepath.Base(filename), file.Name.Name, posn.Line)
+					if output := generatorOutput(d.Tool, expanded[1:]); output != "" {
+						outPath := output
+						if !filepath.IsAbs(outPath) {
+							outPath = filepath.Join(filepath.Dir(filename), output)
+						}
+
+						d.Output = relativePath(input.Dir, outPath)
+						d.OutputStatus = generatorOutputState(outPath, filename)
+					}
+				}
+
+				byPackage[pkg.PkgPath] = append(byPackage[pkg.PkgPath], d)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	toolCounts := make(map[string]*GeneratorToolCount)
+	toolPackages := make(map[string]map[string]struct{})
+
+	for _, pkgPath := range sortedKeys(byPackage) {
+		directives := byPackage[pkgPath]
+
+		sort.SliceStable(directives, func(i, j int) bool {
+			if directives[i].File != directives[j].File {
+				return directives[i].File < directives[j].File
+			}
+
+			return directives[i].Line < directives[j].Line
+		})
+
+		for _, d := range directives {
+			out.Total++
+
+			// An alias definition runs nothing; its uses are counted instead.
+			if d.Tool == "" || d.Alias != "" {
+				continue
+			}
+
+			if !d.Resolvable {
+				out.Unresolvable++
+			}
+
+			count, ok := toolCounts[d.Tool]
+			if !ok {
+				count = &GeneratorToolCount{Tool: d.Tool}
+				toolCounts[d.Tool] = count
+				toolPackages[d.Tool] = make(map[string]struct{})
+			}
+
+			count.Directives++
+			toolPackages[d.Tool][pkgPath] = struct{}{}
+		}
+
+		out.Packages = append(out.Packages, GeneratorPackage{Package: pkgPath, Directives: directives})
+	}
+
+	for tool, count := range toolCounts {
+		count.Packages = len(toolPackages[tool])
+		out.Tools = append(out.Tools, *count)
+	}
+
+	sort.Slice(out.Tools, func(i, j int) bool {
+		if out.Tools[i].Directives != out.Tools[j].Directives {
+			return out.Tools[i].Directives > out.Tools[j].Directives
+		}
+
+		return out.Tools[i].Tool < out.Tools[j].Tool
+	})
+
+	return nil, out, nil
+}
+
+// isGoGenerateDirective reports whether a comment is a go:generate directive.
+func isGoGenerateDirective(text string) bool {
+	rest, ok := strings.CutPrefix(text, goGeneratePrefix)
+
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// splitGenerateDirective splits a directive into words the way go generate does: fields are separated
+// by spaces and tabs, and double-quoted fields are Go string literals.
+func splitGenerateDirective(text string) ([]string, error) {
+	line := strings.TrimSpace(strings.TrimPrefix(text, goGeneratePrefix))
+
+	var words []string
+
+Words:
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			break
+		}
+
+		if line[0] == '"' {
+			for i := 1; i < len(line); i++ {
+				switch line[i] {
+				case '\\':
+					i++
+				case '"':
+					word, err := strconv.Unquote(line[:i+1])
+					if err != nil {
+						return nil, errors.New("bad quoted string")
+					}
+
+					words = append(words, word)
+					line = line[i+1:]
+
+					if line != "" && line[0] != ' ' && line[0] != '\t' {
+						return nil, errors.New("expect space after quoted argument")
+					}
+
+					continue Words
+				}
+			}
+
+			return nil, errors.New("mismatched quoted string")
+		}
+
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			i = len(line)
+		}
+
+		words = append(words, line[:i])
+		line = line[i:]
+	}
+
+	if len(words) == 0 {
+		return nil, errors.New("no arguments to directive")
+	}
+
+	return words, nil
+}
+
+// expandGenerateArgs substitutes the variables go generate defines for every directive; other
+// variables are left as written.
+func expandGenerateArgs(words []string, goFile, goPackage string, line int) []string {
+	vars := map[string]string{
+		"GOFILE":    goFile,
+		"GOPACKAGE": goPackage,
+		"GOLINE":    strconv.Itoa(line),
+		"DOLLAR":    "$",
+	}
+
+	expanded := make([]string, len(words))
+	for i, w := range words {
+		expanded[i] = os.Expand(w, func(name string) string {
+			if v, ok := vars[name]; ok {
+				return v
+			}
+
+			return "$" + name
+		})
+	}
+
+	return expanded
+}
+
+// generatorTool names the generator a directive runs: the program name, the package of
+// `go run pkg@version`, or the tool of `go tool name`.
+func generatorTool(words []string) string {
+	if filepath.Base(words[0]) == "go" && len(words) > 1 {
+		switch words[1] {
+		case "run":
+			for _, arg := range words[2:] {
+				if strings.HasPrefix(arg, "-") {
+					continue
+				}
+
+				pkg, _, _ := strings.Cut(arg, "@")
+
+				return path.Base(strings.TrimSuffix(pkg, ".go"))
+			}
+		case "tool":
+			if len(words) > 2 {
+				return path.Base(words[2])
+			}
+		}
+	}
+
+	return filepath.Base(words[0])
+}
+
+// generatorOutput returns the output file named by a directive's arguments: an -o/-output/-out/
+// -destination flag, or stringer's default <type>_string.go. It returns "" when it cannot tell.
+func generatorOutput(tool string, args []string) string {
+	typeName := ""
+
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		if _, ok := generatorOutputFlags[name]; ok && value != "" {
+			return value
+		}
+
+		if name == "type" {
+			typeName, _, _ = strings.Cut(value, ",")
+		}
+	}
+
+	if tool == "stringer" && typeName != "" {
+		return strings.ToLower(typeName) + "_string.go"
+	}
+
+	return ""
+}
+
+// generatorOutputState compares the modification time of a generator's output with that of the file
+// holding the directive, the only input known without running the generator.
+func generatorOutputState(output, input string) string {
+	outInfo, err := os.Stat(output)
+	if err != nil {
+		return generatorOutputMissing
+	}
+
+	inInfo, err := os.Stat(input)
+	if err == nil && outInfo.ModTime().Before(inInfo.ModTime()) {
+		return generatorOutputStale
+	}
+
+	return generatorOutputFresh
+}
+
+// generatorBinary is where a directive's program was found, if anywhere.
+type generatorBinary struct {
+	path  string
+	found bool
+}
+
+// resolveGeneratorBinary looks a program up without running it: paths relative to the package
+// directory, then PATH, GOBIN and GOPATH/bin.
+func resolveGeneratorBinary(name, pkgDir string) generatorBinary {
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		p := name
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(pkgDir, p)
+		}
+
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return generatorBinary{path: p, found: true}
+		}
+
+		return generatorBinary{}
+	}
+
+	if p, err := exec.LookPath(name); err == nil {
+		return generatorBinary{path: p, found: true}
+	}
+
+	var dirs []string
+
+	if gobin := os.Getenv("GOBIN"); gobin != "" {
+		dirs = append(dirs, gobin)
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			gopath = filepath.Join(home, "go")
+		}
+	}
+
+	for _, p := range filepath.SplitList(gopath) {
+		dirs = append(dirs, filepath.Join(p, "bin"))
+	}
+
+	for _, dir := range dirs {
+		p := filepath.Join(dir, name)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+			return generatorBinary{path: p, found: true}
+		}
+	}
+
+	return generatorBinary{}
+}
@@ -0,0 +1,91 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestListGenerators_Directives(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"gen.go": `package lang
+
+//go:generate stringer -type=Color
+//go:generate go run golang.org/x/tools/cmd/stringer@v0.20.0 -type=Shade -output shade_string.go
+//go:generate -command mock nonexistent-mockgen-tool
+//go:generate mock -destination mocks.go -source $GOFILE
+//go:generate sh -c "echo \"two words\" > /dev/null"
+//go:generate broken "unterminated
+
+// Color is a generated enum.
+type Color int
+`,
+		"color_string.go": "package lang\n",
+		"shade_string.go": "package lang\n",
+	})
+
+	now := time.Now()
+	touch := func(name string, at time.Time) {
+		if err := os.Chtimes(filepath.Join(dir, name), at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	touch("gen.go", now.Add(-time.Hour))
+	touch("color_string.go", now)
+	touch("shade_string.go", now.Add(-2*time.Hour))
+
+	_, out, err := tools.ListGenerators(context.Background(), &mcp.CallToolRequest{}, tools.ListGeneratorsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("ListGenerators error: %v", err)
+	}
+
+	if out.Total != 6 || len(out.Packages) != 1 || len(out.Packages[0].Directives) != 6 {
+		t.Fatalf("expected 6 directives in one package, got %+v", out)
+	}
+
+	d := out.Packages[0].Directives
+
+	if d[0].Tool != "stringer" || d[0].Output != "color_string.go" || d[0].OutputStatus != "fresh" || d[0].Line != 3 {
+		t.Errorf("unexpected stringer directive: %+v", d[0])
+	}
+
+	if d[1].Command != "go" || d[1].Tool != "stringer" || d[1].Output != "shade_string.go" || d[1].OutputStatus != "stale" {
+		t.Errorf("unexpected go run directive: %+v", d[1])
+	}
+
+	if d[2].Alias != "mock" || d[2].Command != "nonexistent-mockgen-tool" {
+		t.Errorf("unexpected -command directive: %+v", d[2])
+	}
+
+	wantArgs := []string{"-destination", "mocks.go", "-source", "$GOFILE"}
+	if d[3].Command != "nonexistent-mockgen-tool" || !reflect.DeepEqual(d[3].Args, wantArgs) || d[3].Resolvable ||
+		d[3].Output != "mocks.go" || d[3].OutputStatus != "missing" {
+		t.Errorf("unexpected aliased directive: %+v", d[3])
+	}
+
+	if !reflect.DeepEqual(d[4].Args, []string{"-c", `echo "two words" > /dev/null`}) || !d[4].Resolvable || d[4].BinaryPath == "" {
+		t.Errorf("expected quoted arguments and a resolvable sh: %+v", d[4])
+	}
+
+	if d[5].Error != "mismatched quoted string" || d[5].Command != "" {
+		t.Errorf("expected a parse error, got %+v", d[5])
+	}
+
+	if len(out.Tools) == 0 || out.Tools[0] != (tools.GeneratorToolCount{Tool: "stringer", Directives: 2, Packages: 1}) {
+		t.Errorf("expected stringer to lead the tool counts, got %+v", out.Tools)
+	}
+
+	if out.Unresolvable == 0 {
+		t.Error("expected the missing mock generator to be counted as unresolvable")
+	}
+}
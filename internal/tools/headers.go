@@ -0,0 +1,275 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Problems reported by checkFileHeaders.
+const (
+	headerMissing    = "missing"
+	headerMismatch   = "mismatch"
+	headerUnexpected = "unexpected"
+)
+
+// CheckFileHeaders reports files whose header comment is missing, does not match a required pattern or
+// names a different year or company than the other files.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the required pattern and the files to skip
+//
+// Returns:
+//   - MCP tool call result
+//   - non-compliant files with their header and captured values
+//   - error if the pattern is empty, a glob or expected group is invalid or loading packages failed
+func CheckFileHeaders(ctx context.Context, _ *mcp.CallToolRequest, input CheckFileHeadersInput) (
+	*mcp.CallToolResult,
+	CheckFileHeadersOutput,
+	error,
+) {
+	start := logStart("CheckFileHeaders", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("requiredPattern", input.RequiredPattern),
+	))
+	out := CheckFileHeadersOutput{Issues: []HeaderIssue{}}
+
+	defer func() { logEnd("CheckFileHeaders", start, len(out.Issues)) }()
+
+	if strings.TrimSpace(input.RequiredPattern) == "" {
+		return fail(out, invalidInput("requiredPattern is required"))
+	}
+
+	// A pattern that is not a valid regular expression is still a valid literal prefix.
+	re, _ := regexp.Compile(input.RequiredPattern)
+
+	for _, glob := range input.Exclude {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fail(out, invalidInput("invalid exclude pattern %q: %w", glob, err))
+		}
+	}
+
+	for group := range input.Expected {
+		if re == nil || headerGroupIndex(re, group) < 0 {
+			return fail(out, invalidInput("expected group %q is not a capture group of requiredPattern", group))
+		}
+	}
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeBasicSyntax, input.Package, "CheckFileHeaders")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	var matched []HeaderIssue
+
+	seen := make(map[string]bool)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(_ *packages.Package, file *ast.File, relPath string, _ int) error {
+		relPath = filepath.ToSlash(relPath)
+		if seen[relPath] || ast.IsGenerated(file) || excludedFile(input.Exclude, relPath) {
+			return nil
+		}
+
+		seen[relPath] = true
+		out.Checked++
+
+		header := fileHeader(file)
+		if header == nil {
+			out.Issues = append(out.Issues, HeaderIssue{File: relPath, Problem: headerMissing})
+
+			return nil
+		}
+
+		text := header.Text()
+		firstLine, _, _ := strings.Cut(text, "\n")
+		captures, ok := matchHeader(re, input.RequiredPattern, text)
+
+		if !ok {
+			out.Issues = append(out.Issues, HeaderIssue{File: relPath, Problem: headerMismatch, Header: firstLine})
+
+			return nil
+		}
+
+		matched = append(matched, HeaderIssue{File: relPath, Header: firstLine, Captures: captures})
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	expected := expectedCaptures(matched, input.Expected)
+
+	for _, file := range matched {
+		differing := make(map[string]string)
+
+		for group, value := range expected {
+			if got, ok := file.Captures[group]; ok && got != value {
+				differing[group] = value
+			}
+		}
+
+		if len(differing) == 0 {
+			out.Compliant++
+
+			continue
+		}
+
+		file.Problem, file.Expected = headerUnexpected, differing
+		out.Issues = append(out.Issues, file)
+	}
+
+	sort.Slice(out.Issues, func(i, j int) bool { return out.Issues[i].File < out.Issues[j].File })
+
+	return nil, out, nil
+}
+
+// fileHeader returns the header comment of file: the first comment group before the package clause that
+// is neither build constraints and directives only nor the package doc comment, nil when there is none.
+func fileHeader(file *ast.File) *ast.CommentGroup {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		text := group.Text() // drops //go: directives
+		if !hasCommentText(text) {
+			continue
+		}
+
+		if group == file.Doc && strings.HasPrefix(text, "Package ") {
+			return nil
+		}
+
+		return group
+	}
+
+	return nil
+}
+
+// hasCommentText reports whether the text of a comment group has a line other than a +build constraint.
+func hasCommentText(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "+build") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// headerLines returns the number of lines the header comment of file spans, 0 when it has none.
+func headerLines(fset *token.FileSet, file *ast.File) int {
+	header := fileHeader(file)
+	if header == nil {
+		return 0
+	}
+
+	return fset.Position(header.End()).Line - fset.Position(header.Pos()).Line + 1
+}
+
+// matchHeader reports whether text starts with the literal prefix pattern or matches re, and returns the
+// values of the capture groups of re that took part in the match.
+func matchHeader(re *regexp.Regexp, pattern, text string) (map[string]string, bool) {
+	var loc []int
+	if re != nil {
+		loc = re.FindStringSubmatchIndex(text)
+	}
+
+	if loc == nil {
+		return nil, strings.HasPrefix(text, pattern)
+	}
+
+	captures := make(map[string]string)
+
+	for i, name := range re.SubexpNames() {
+		if i == 0 || loc[2*i] < 0 {
+			continue
+		}
+
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+
+		captures[name] = text[loc[2*i]:loc[2*i+1]]
+	}
+
+	return captures, true
+}
+
+// headerGroupIndex returns the index of the capture group of re named by group, its name or 1-based index,
+// or -1.
+func headerGroupIndex(re *regexp.Regexp, group string) int {
+	if i := re.SubexpIndex(group); i > 0 {
+		return i
+	}
+
+	if i, err := strconv.Atoi(group); err == nil && i > 0 && i <= re.NumSubexp() && re.SubexpNames()[i] == "" {
+		return i
+	}
+
+	return -1
+}
+
+// expectedCaptures returns the expected value of every captured group: the given one, or else the value
+// most matched files have, the smallest on ties.
+func expectedCaptures(matched []HeaderIssue, given map[string]string) map[string]string {
+	counts := make(map[string]map[string]int)
+
+	for _, file := range matched {
+		for group, value := range file.Captures {
+			if counts[group] == nil {
+				counts[group] = make(map[string]int)
+			}
+
+			counts[group][value]++
+		}
+	}
+
+	expected := make(map[string]string, len(counts))
+
+	for group, values := range counts {
+		keys := sortedKeys(values)
+		best := keys[0]
+
+		for _, value := range keys[1:] {
+			if values[value] > values[best] {
+				best = value
+			}
+		}
+
+		expected[group] = best
+	}
+
+	for group, value := range given {
+		expected[group] = value
+	}
+
+	return expected
+}
+
+// excludedFile reports whether relPath or its base name matches one of the exclude globs.
+func excludedFile(globs []string, relPath string) bool {
+	for _, glob := range globs {
+		if matchGlobSegments(strings.Split(glob, "/"), strings.Split(relPath, "/")) {
+			return true
+		}
+
+		if ok, _ := path.Match(glob, path.Base(relPath)); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const headerPattern = `Copyright (?P<year>\d{4}) (?P<company>[A-Z]\w+)`
+
+var headerFiles = map[string]string{
+	"a.go":          "// Copyright 2024 Acme. All rights reserved.\n// Use of this source code is governed by a BSD license.\n\npackage lang\n\nfunc A() {}\n",
+	"b.go":          "//go:build !windows\n\n// Copyright 2024 Acme. All rights reserved.\n\n// Package lang is a fixture.\npackage lang\n\nfunc B() {}\n",
+	"c.go":          "// Copyright 2021 Globex. All rights reserved.\n\npackage lang\n\nfunc C() {}\n",
+	"d.go":          "// Package lang is a fixture.\npackage lang\n\nfunc D() {}\n",
+	"e.go":          "// Licensed under the MIT license.\n\npackage lang\n\nfunc E() {}\n",
+	"vendored/v.go": "package vendored\n",
+}
+
+func TestCheckFileHeaders(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", headerFiles)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.CheckFileHeaders(ctx, req, tools.CheckFileHeadersInput{
+		Dir: dir, RequiredPattern: headerPattern, Exclude: []string{"vendored/**"},
+	})
+	if err != nil {
+		t.Fatalf("CheckFileHeaders: %v", err)
+	}
+
+	if out.Checked != 5 || out.Compliant != 2 || len(out.Issues) != 3 {
+		t.Fatalf("out = %+v, want 2 of 5 files compliant", out)
+	}
+
+	c, d, e := out.Issues[0], out.Issues[1], out.Issues[2]
+	if c.File != "c.go" || c.Problem != "unexpected" || c.Captures["year"] != "2021" ||
+		c.Expected["year"] != "2024" || c.Expected["company"] != "Acme" {
+		t.Errorf("c.go = %+v, want the year and company of the other files expected", c)
+	}
+
+	if d.File != "d.go" || d.Problem != "missing" {
+		t.Errorf("d.go = %+v, want a missing header: a package doc comment is not one", d)
+	}
+
+	if e.File != "e.go" || e.Problem != "mismatch" || e.Header != "Licensed under the MIT license." {
+		t.Errorf("e.go = %+v, want a mismatching header", e)
+	}
+
+	_, out, err = tools.CheckFileHeaders(ctx, req, tools.CheckFileHeadersInput{
+		Dir: dir, RequiredPattern: "Copyright 20", Exclude: []string{"[de].go", "vendored/**"},
+	})
+	if err != nil || out.Checked != 3 || out.Compliant != 3 {
+		t.Errorf("literal prefix: %+v, %v", out, err)
+	}
+
+	_, _, err = tools.CheckFileHeaders(ctx, req, tools.CheckFileHeadersInput{
+		Dir: dir, RequiredPattern: headerPattern, Expected: map[string]string{"owner": "Acme"},
+	})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for an unknown group, got %v", err)
+	}
+}
+
+func TestMetricsSummary_ExcludeHeaderComments(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", headerFiles)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, all, err := tools.MetricsSummary(ctx, req, tools.MetricsSummaryInput{Dir: dir, Package: "lang"})
+	if err != nil {
+		t.Fatalf("MetricsSummary: %v", err)
+	}
+
+	_, code, err := tools.MetricsSummary(ctx, req, tools.MetricsSummaryInput{Dir: dir, Package: "lang", ExcludeHeaderComments: true})
+	if err != nil {
+		t.Fatalf("MetricsSummary: %v", err)
+	}
+
+	// a.go has a two-line header, b.go, c.go and e.go one each; d.go only a package doc comment.
+	if all.LineCount-code.LineCount != 5 {
+		t.Errorf("lineCount = %d with headers, %d without, want 5 header lines excluded", all.LineCount, code.LineCount)
+	}
+}
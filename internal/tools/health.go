@@ -1,8 +1,11 @@
 package tools
 
 import (
+	"context"
 	"errors"
 	"os/exec"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func HealthCheck() error {
@@ -13,3 +16,30 @@ func HealthCheck() error {
 
 	return nil
 }
+
+// GetServerStatus reports the health of the server and the state of its package caches.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: empty input
+//
+// Returns:
+//   - MCP tool call result
+//   - go toolchain availability, cache statistics including persisted index hydration, and preload status
+//   - error (always nil)
+func GetServerStatus(ctx context.Context, _ *mcp.CallToolRequest, _ GetServerStatusInput) (
+	*mcp.CallToolResult,
+	GetServerStatusOutput,
+	error,
+) {
+	start := logStart("GetServerStatus", nil)
+	out := GetServerStatusOutput{GoAvailable: HealthCheck() == nil}
+
+	defer func() { logEnd("GetServerStatus", start, 1) }()
+
+	out.CacheStats = diskCacheStats()
+	out.Preload = currentPreloadStatus()
+
+	return nil, out, nil
+}
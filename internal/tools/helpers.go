@@ -3,18 +3,22 @@ package tools
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"go/ast"
 	"go/format"
+	"go/parser"
 	"go/token"
 	"go/types"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -23,54 +27,59 @@ import (
 )
 
 func getFileLines(fset *token.FileSet, file *ast.File) []string {
-	filename := fset.File(file.Pos()).Name()
+	item, ok := cachedFileContent(fset.File(file.Pos()).Name())
+	if !ok {
+		return []string{}
+	}
+
+	return item.Lines
+}
+
+// cachedFileContent returns the lines and content hash of filename from fileLinesCache, reading the file
+// again when its modification time or size changed since it was cached.
+func cachedFileContent(filename string) (FileLinesCacheItem, bool) {
+	st, err := os.Stat(filename)
+	if err != nil {
+		return FileLinesCacheItem{}, false
+	}
 
-	// Check cache
 	fileLinesCache.RLock()
 	item, ok := fileLinesCache.data[filename]
 	fileLinesCache.RUnlock()
 
-	if ok {
-		// Check if the file has changed on disk
-		if st, err := os.Stat(filename); err == nil {
-			if st.ModTime().Equal(item.ModTime) {
-				// File hasn't changed - return cache
-				fileLinesCache.Lock()
+	if ok && st.ModTime().Equal(item.ModTime) && st.Size() == item.Size {
+		// File hasn't changed - return cache
+		fileLinesCache.Lock()
 
-				item.LastAccess = time.Now()
-				fileLinesCache.data[filename] = item
-				fileLinesCache.Unlock()
+		item.LastAccess = time.Now()
+		fileLinesCache.data[filename] = item
+		fileLinesCache.Unlock()
 
-				return item.Lines
-			}
-		}
+		return item, true
 	}
 
 	// File changed or not cached - read again
 	src, err := os.ReadFile(filename)
 	if err != nil {
-		return []string{}
+		return FileLinesCacheItem{}, false
 	}
 
-	lines := strings.Split(string(src), "\n")
-
-	var modTime time.Time
-	if st, err := os.Stat(filename); err == nil {
-		modTime = st.ModTime()
+	item = FileLinesCacheItem{
+		Lines:      strings.Split(string(src), "\n"),
+		LastAccess: time.Now(),
+		ModTime:    st.ModTime(),
+		Size:       int64(len(src)),
+		Hash:       hashContent(src),
 	}
 
 	fileLinesCache.Lock()
-	fileLinesCache.data[filename] = FileLinesCacheItem{
-		Lines:      lines,
-		LastAccess: time.Now(),
-		ModTime:    modTime,
-	}
+	fileLinesCache.data[filename] = item
 	fileLinesCache.Unlock()
 
-	// Add file to watcher to track changes
+	// Add file to watch to track changes
 	_ = addFileToWatch(filename, filename) // Using filename as a simple cache key for file lines cache
 
-	return lines
+	return item, true
 }
 
 func getFileLinesFromPath(path string) []string {
@@ -82,8 +91,6 @@ func getFileLinesFromPath(path string) []string {
 	return strings.Split(string(data), "\n")
 }
 
-const packageSuggestionLimit = 5
-
 func normalizePackagePath(pkg *packages.Package) string {
 	if pkg == nil {
 		return ""
@@ -96,31 +103,27 @@ func normalizePackagePath(pkg *packages.Package) string {
 	return pkg.Name
 }
 
+// relativePath returns filename relative to baseDir with forward slashes. When the plain relation
+// leaves baseDir, both sides are compared again canonicalized, so a dir spelled through a symlink
+// (/var vs /private/var on macOS), with a trailing slash or, on case-insensitive file systems, in a
+// different case still yields module-relative paths. Files truly outside baseDir keep their "../" form.
 func relativePath(baseDir, filename string) string {
 	if filename == "" {
 		return ""
 	}
 
-	absFile := filename
-	if !filepath.IsAbs(absFile) {
-		if abs, err := filepath.Abs(absFile); err == nil {
-			absFile = abs
-		} else {
-			return filepath.ToSlash(filename)
-		}
+	if baseDir == "" {
+		baseDir = "."
 	}
 
-	absBase := baseDir
-	if absBase == "" {
-		absBase = "."
+	absFile, err := filepath.Abs(filename)
+	if err != nil {
+		return filepath.ToSlash(filename)
 	}
 
-	if !filepath.IsAbs(absBase) {
-		if abs, err := filepath.Abs(absBase); err == nil {
-			absBase = abs
-		} else {
-			return filepath.ToSlash(filename)
-		}
+	absBase, err := filepath.Abs(baseDir)
+	if err != nil {
+		return filepath.ToSlash(filename)
 	}
 
 	rel, err := filepath.Rel(absBase, absFile)
@@ -128,9 +131,69 @@ func relativePath(baseDir, filename string) string {
 		return filepath.ToSlash(filename)
 	}
 
+	if !escapesDir(rel) {
+		return filepath.ToSlash(rel)
+	}
+
+	canonBase := cachedCanonicalDir(absBase)
+	canonFile := filepath.Join(cachedCanonicalDir(filepath.Dir(absFile)), filepath.Base(absFile))
+
+	if canonRel, err := filepath.Rel(canonBase, canonFile); err == nil && !escapesDir(canonRel) {
+		return filepath.ToSlash(canonRel)
+	}
+
+	if caseInsensitiveFS && len(canonFile) > len(canonBase) && canonFile[len(canonBase)] == filepath.Separator &&
+		strings.EqualFold(canonFile[:len(canonBase)], canonBase) {
+		return filepath.ToSlash(canonFile[len(canonBase)+1:])
+	}
+
 	return filepath.ToSlash(rel)
 }
 
+// caseInsensitiveFS reports whether the default file systems of the platform ignore case.
+const caseInsensitiveFS = runtime.GOOS == "darwin" || runtime.GOOS == "windows"
+
+// escapesDir reports whether the relative path rel leaves its base directory.
+func escapesDir(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// canonicalDirs memoizes CanonicalDir for relativePath, which runs once per reported location.
+var canonicalDirs sync.Map
+
+// cachedCanonicalDir returns CanonicalDir(dir), resolving each directory once.
+func cachedCanonicalDir(dir string) string {
+	if v, ok := canonicalDirs.Load(dir); ok {
+		return v.(string)
+	}
+
+	canon := CanonicalDir(dir)
+	canonicalDirs.Store(dir, canon)
+
+	return canon
+}
+
+// normalizeFileFilter turns the file input of a search into the module-relative, slash-separated path
+// results are reported with, so it can be compared with matchesFileFilter. Absolute paths are made
+// relative to dir; an empty filter stays empty.
+func normalizeFileFilter(dir, filter string) string {
+	if filter == "" {
+		return ""
+	}
+
+	if filepath.IsAbs(filter) {
+		return relativePath(dir, filter)
+	}
+
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(filter)), "./")
+}
+
+// matchesFileFilter reports whether the module-relative path rel passes a filter normalized with
+// normalizeFileFilter. Paths are compared whole: "foo.go" matches neither "myfoo.go" nor "pkg/foo.go".
+func matchesFileFilter(rel, filter string) bool {
+	return filter == "" || rel == filter
+}
+
 func resolveFilePath(pkg *packages.Package, inputDir string, fileIndex int, file *ast.File) string {
 	var absPath string
 
@@ -158,13 +221,21 @@ func resolveFilePath(pkg *packages.Package, inputDir string, fileIndex int, file
 	return relPath
 }
 
+// walkPackageFiles calls fn for every file of pkgs, stopping at the first error. Cancellation of ctx is
+// checked every cancelCheckInterval files, and the files visited are counted for CancellationError.
 func walkPackageFiles(ctx context.Context, pkgs []*packages.Package, dir string, fn func(pkg *packages.Package, file *ast.File, relPath string, fileIndex int) error) error {
+	visited := 0
+
 	for _, pkg := range pkgs {
 		for i, file := range pkg.Syntax {
-			if shouldStop(ctx) {
-				return context.Canceled
+			if visited%cancelCheckInterval == 0 && shouldStop(ctx) {
+				return ctx.Err()
 			}
 
+			visited++
+
+			noteFileWalked(ctx, i == 0)
+
 			relPath := resolveFilePath(pkg, dir, i, file)
 
 			err := fn(pkg, file, relPath, i)
@@ -179,11 +250,11 @@ func walkPackageFiles(ctx context.Context, pkgs []*packages.Package, dir string,
 
 func validatePagination(limit, offset int) error {
 	if limit < 0 {
-		return errors.New("limit must be >= 0")
+		return invalidInput("limit must be >= 0")
 	}
 
 	if offset < 0 {
-		return errors.New("offset must be >= 0")
+		return invalidInput("offset must be >= 0")
 	}
 
 	return nil
@@ -219,6 +290,33 @@ func loadFilteredPackages(ctx context.Context, dir string, mode packages.LoadMod
 	return pkgs, filtered, nil
 }
 
+// loadFilteredPackagesWithFallback is loadFilteredPackages for tools that can work from syntax alone:
+// when module resolution fails, it falls back to an offline syntax-only load and reports the degradation.
+func loadFilteredPackagesWithFallback(ctx context.Context, dir string, mode packages.LoadMode, requested, tool string) (
+	[]*packages.Package,
+	[]*packages.Package,
+	*loadDegradation,
+	error,
+) {
+	pkgs, degraded, err := loadPackagesWithFallback(ctx, dir, mode)
+	if err != nil {
+		logError(tool, err, "failed to load packages")
+
+		return nil, nil, nil, err
+	}
+
+	if degraded != nil {
+		logError(tool, degraded.err, "module resolution failed, using offline syntax-only load")
+	}
+
+	filtered, err := filterPackagesByRequest(pkgs, requested)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return pkgs, filtered, degraded, nil
+}
+
 func filterPackagesByRequest(pkgs []*packages.Package, requested string) ([]*packages.Package, error) {
 	if requested == "" {
 		return pkgs, nil
@@ -226,19 +324,8 @@ func filterPackagesByRequest(pkgs []*packages.Package, requested string) ([]*pac
 
 	var filtered []*packages.Package
 
-	availableSet := make(map[string]struct{})
-	available := make([]string, 0, len(pkgs))
-
 	for _, pkg := range pkgs {
-		key := normalizePackagePath(pkg)
-		if key != "" {
-			if _, seen := availableSet[key]; !seen {
-				available = append(available, key)
-				availableSet[key] = struct{}{}
-			}
-		}
-
-		if key == requested || pkg.Name == requested {
+		if normalizePackagePath(pkg) == requested || pkg.Name == requested {
 			filtered = append(filtered, pkg)
 		}
 	}
@@ -247,64 +334,167 @@ func filterPackagesByRequest(pkgs []*packages.Package, requested string) ([]*pac
 		return filtered, nil
 	}
 
-	sort.Strings(available)
+	return nil, missingPackage(pkgs, requested)
+}
+
+// missingPackage returns the NOT_FOUND error of a package filter that matches none of pkgs, listing the
+// packages nearest to requested by edit distance first.
+func missingPackage(pkgs []*packages.Package, requested string) error {
+	paths := make(map[string]struct{}, len(pkgs))
 
-	if len(available) > packageSuggestionLimit {
-		available = available[:packageSuggestionLimit]
+	for _, pkg := range pkgs {
+		if key := normalizePackagePath(pkg); key != "" {
+			paths[key] = struct{}{}
+		}
 	}
 
+	available := rankNames(requested, sortedKeys(paths), -1)
+
 	suggestion := ""
 	if len(available) > 0 {
 		suggestion = "; available packages include: " + strings.Join(available, ", ")
 	}
 
-	return nil, fmt.Errorf("package %q not found%s", requested, suggestion)
+	return notFound(available, "package %q not found%s", requested, suggestion)
 }
 
 func collectSymbols(file *ast.File, fset *token.FileSet, pkgPath, relPath string) []Symbol {
+	return collectSymbolsInternal(file, fset, pkgPath, relPath, symbolOptions{})
+}
+
+// collectSymbolsWithFingerprints is collectSymbols with the Fingerprint of every symbol filled in.
+func collectSymbolsWithFingerprints(file *ast.File, fset *token.FileSet, pkgPath, relPath string) []Symbol {
+	return collectSymbolsInternal(file, fset, pkgPath, relPath, symbolOptions{fingerprints: true})
+}
+
+// symbolOptions selects the optional Symbol fields filled in by collectSymbolsInternal.
+type symbolOptions struct {
+	fingerprints bool
+	signatures   bool
+	// info resolves signatures; without it they are rendered from the syntax.
+	info *types.Info
+}
+
+// topLevelDeclIdents returns the names declared at package level in file, interface methods included.
+func topLevelDeclIdents(file *ast.File) map[*ast.Ident]struct{} {
+	idents := make(map[*ast.Ident]struct{})
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			idents[d.Name] = struct{}{}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					idents[sp.Name] = struct{}{}
+
+					if it, ok := sp.Type.(*ast.InterfaceType); ok && it.Methods != nil {
+						for _, m := range it.Methods.List {
+							for _, name := range m.Names {
+								idents[name] = struct{}{}
+							}
+						}
+					}
+				case *ast.ValueSpec:
+					for _, name := range sp.Names {
+						idents[name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return idents
+}
+
+func collectSymbolsInternal(file *ast.File, fset *token.FileSet, pkgPath, relPath string, opts symbolOptions) []Symbol {
 	if file == nil || fset == nil {
 		return nil
 	}
 
+	withFingerprints := opts.fingerprints
+
+	fingerprint := func(node ast.Node) string {
+		if !withFingerprints {
+			return ""
+		}
+
+		return fingerprintNode(fset, node)
+	}
+
 	if pkgPath == "" && file.Name != nil {
 		pkgPath = file.Name.Name
 	}
 
+	topLevel := topLevelDeclIdents(file)
+
+	// id is the symbol ID of the declaration of ident, from the type information when there is some and
+	// from the syntax for package-level declarations otherwise.
+	id := func(ident *ast.Ident, owner, kind string) string {
+		if opts.info != nil {
+			if obj := opts.info.Defs[ident]; obj != nil {
+				return symbolID(opts.info, obj)
+			}
+		}
+
+		if _, ok := topLevel[ident]; !ok {
+			return ""
+		}
+
+		return syntaxSymbolID(pkgPath, owner, ident.Name, kind)
+	}
+
 	symbols := make([]Symbol, 0)
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch decl := n.(type) {
 		case *ast.FuncDecl:
-			symbols = append(symbols, Symbol{
-				Kind:     "func",
-				Name:     decl.Name.Name,
-				Package:  pkgPath,
-				File:     relPath,
-				Line:     fset.Position(decl.Pos()).Line,
-				Exported: decl.Name.IsExported(),
-			})
+			sym := Symbol{
+				Kind:        "func",
+				Name:        decl.Name.Name,
+				SymbolID:    id(decl.Name, receiverName(decl), "func"),
+				Package:     pkgPath,
+				File:        relPath,
+				Line:        fset.Position(decl.Pos()).Line,
+				Exported:    decl.Name.IsExported(),
+				Fingerprint: fingerprint(decl),
+			}
+
+			if opts.signatures {
+				sym.Receiver, sym.Signature, sym.Generic = funcDeclSignature(decl, opts.info)
+			}
+
+			symbols = append(symbols, sym)
 		case *ast.TypeSpec:
 			line := fset.Position(decl.Pos()).Line
 			exported := decl.Name.IsExported()
+			generic := opts.signatures && decl.TypeParams != nil && len(decl.TypeParams.List) > 0
 
 			switch t := decl.Type.(type) {
 			case *ast.StructType:
 				symbols = append(symbols, Symbol{
-					Kind:     "struct",
-					Name:     decl.Name.Name,
-					Package:  pkgPath,
-					File:     relPath,
-					Line:     line,
-					Exported: exported,
+					Kind:        "struct",
+					Name:        decl.Name.Name,
+					SymbolID:    id(decl.Name, "", "type"),
+					Package:     pkgPath,
+					File:        relPath,
+					Line:        line,
+					Exported:    exported,
+					Fingerprint: fingerprint(decl),
+					Generic:     generic,
 				})
 			case *ast.InterfaceType:
 				symbols = append(symbols, Symbol{
-					Kind:     "interface",
-					Name:     decl.Name.Name,
-					Package:  pkgPath,
-					File:     relPath,
-					Line:     line,
-					Exported: exported,
+					Kind:        "interface",
+					Name:        decl.Name.Name,
+					SymbolID:    id(decl.Name, "", "type"),
+					Package:     pkgPath,
+					File:        relPath,
+					Line:        line,
+					Exported:    exported,
+					Fingerprint: fingerprint(decl),
+					Generic:     generic,
 				})
 
 				if t.Methods != nil {
@@ -314,24 +504,36 @@ func collectSymbols(file *ast.File, fset *token.FileSet, pkgPath, relPath string
 						}
 
 						name := m.Names[0]
-						symbols = append(symbols, Symbol{
-							Kind:     "method",
-							Name:     decl.Name.Name + "." + name.Name,
-							Package:  pkgPath,
-							File:     relPath,
-							Line:     fset.Position(m.Pos()).Line,
-							Exported: name.IsExported(),
-						})
+						sym := Symbol{
+							Kind:        "method",
+							Name:        decl.Name.Name + "." + name.Name,
+							SymbolID:    id(name, decl.Name.Name, "func"),
+							Package:     pkgPath,
+							File:        relPath,
+							Line:        fset.Position(m.Pos()).Line,
+							Exported:    name.IsExported(),
+							Fingerprint: fingerprintInterfaceMethod(fset, name.Name, m, withFingerprints),
+						}
+
+						if opts.signatures {
+							sym.Receiver, sym.Generic = decl.Name.Name, generic
+							sym.Signature = interfaceMethodSignature(name, m, opts.info)
+						}
+
+						symbols = append(symbols, sym)
 					}
 				}
 			default:
 				symbols = append(symbols, Symbol{
-					Kind:     "type",
-					Name:     decl.Name.Name,
-					Package:  pkgPath,
-					File:     relPath,
-					Line:     line,
-					Exported: exported,
+					Kind:        "type",
+					Name:        decl.Name.Name,
+					SymbolID:    id(decl.Name, "", "type"),
+					Package:     pkgPath,
+					File:        relPath,
+					Line:        line,
+					Exported:    exported,
+					Fingerprint: fingerprint(decl),
+					Generic:     generic,
 				})
 			}
 		case *ast.GenDecl:
@@ -345,12 +547,14 @@ func collectSymbols(file *ast.File, fset *token.FileSet, pkgPath, relPath string
 
 					for _, name := range valueSpec.Names {
 						symbols = append(symbols, Symbol{
-							Kind:     strings.ToLower(decl.Tok.String()),
-							Name:     name.Name,
-							Package:  pkgPath,
-							File:     relPath,
-							Line:     fset.Position(name.Pos()).Line,
-							Exported: name.IsExported(),
+							Kind:        strings.ToLower(decl.Tok.String()),
+							Name:        name.Name,
+							SymbolID:    id(name, "", strings.ToLower(decl.Tok.String())),
+							Package:     pkgPath,
+							File:        relPath,
+							Line:        fset.Position(name.Pos()).Line,
+							Exported:    name.IsExported(),
+							Fingerprint: fingerprint(valueSpec),
 						})
 					}
 				}
@@ -360,6 +564,14 @@ func collectSymbols(file *ast.File, fset *token.FileSet, pkgPath, relPath string
 		return true
 	})
 
+	if tf := fset.File(file.Pos()); tf != nil {
+		if bc := fileBuildConstraint(tf.Name(), file); bc != "" {
+			for i := range symbols {
+				symbols[i].BuildConstraint = bc
+			}
+		}
+	}
+
 	return symbols
 }
 
@@ -392,19 +604,32 @@ func shouldStop(ctx context.Context) bool {
 	return ctx.Err() != nil
 }
 
+// fail logs err and returns it as a tool error carrying a machine-readable code (see AsToolError).
 func fail[T any](out T, err error) (*mcp.CallToolResult, T, error) {
-	if err != nil {
-		log.Printf("[go-navigator] error: %v", err)
+	if err == nil {
+		return nil, out, nil
 	}
 
-	return nil, out, err
+	log.Printf("[go-navigator] error: %v", err)
+
+	return nil, out, AsToolError(err)
 }
 
 func symbolPos(pkg *packages.Package, n ast.Node) token.Position {
 	return pkg.Fset.Position(n.Pos())
 }
 
-func safeWriteFile(path string, data []byte) error {
+// safeWriteFile atomically replaces path with data, keeping the line endings and byte order mark
+// of the existing file. Every mutating tool writes through it, so every change reaches the audit log
+// and the caches holding the old content are dropped before the call returns. Files of dependencies
+// (see isDependencyFile) are refused.
+func safeWriteFile(path string, data []byte, change fileChange) error {
+	if isDependencyFile(path) {
+		return dependencyFileError(path)
+	}
+
+	before, _ := os.ReadFile(path)
+	data = preserveFileStyle(path, data)
 	tmp := path + ".tmp"
 
 	err := os.WriteFile(tmp, data, 0o644)
@@ -412,7 +637,71 @@ func safeWriteFile(path string, data []byte) error {
 		return err
 	}
 
-	return os.Rename(tmp, path)
+	// The audit entry is written before the rename, so a crash cannot apply a change without its record.
+	if err := appendAuditEntry(change, path, before, data); err != nil {
+		_ = os.Remove(tmp)
+
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	// The file watcher invalidates asynchronously; a read issued right after the write must not see the
+	// old trees.
+	invalidateCachesForFile(path)
+
+	return nil
+}
+
+// mutationLocks holds one mutex per module root. Mutating tools hold it for the whole call, so at most
+// one mutation per module runs at a time while read-only tools keep running concurrently.
+var mutationLocks = struct {
+	sync.Mutex
+
+	byRoot map[string]*sync.Mutex
+}{byRoot: make(map[string]*sync.Mutex)}
+
+// lockModuleForMutation blocks until no other mutating tool works on the module containing dir and
+// returns the function that releases the lock.
+func lockModuleForMutation(dir string) func() {
+	root := findModuleRoot(dir)
+	if root == "" {
+		root, _ = filepath.Abs(dir)
+	}
+
+	mutationLocks.Lock()
+
+	mu, ok := mutationLocks.byRoot[root]
+	if !ok {
+		mu = &sync.Mutex{}
+		mutationLocks.byRoot[root] = mu
+	}
+
+	mutationLocks.Unlock()
+
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+// parseFileForMutation reads and parses path into a private file set. Mutating tools edit this copy:
+// the cached syntax trees are shared with concurrent read-only calls and must never be modified.
+func parseFileForMutation(path string) (*token.FileSet, *ast.File, []byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return fset, file, src, nil
 }
 
 // isDeadCandidate determines whether to consider an object as a "dead" candidate at all.
@@ -455,6 +744,56 @@ func isDeadCandidate(ident *ast.Ident, obj types.Object) bool {
 	return true
 }
 
+// isInternalExportedCandidate reports whether an exported package-level object can be dead code.
+// Used for packages under internal/, where exported symbols are not visible outside the module subtree.
+// Methods are skipped because they may satisfy interfaces.
+func isInternalExportedCandidate(ident *ast.Ident, obj types.Object) bool {
+	if !ast.IsExported(ident.Name) || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return false
+	}
+
+	switch obj.(type) {
+	case *types.Var, *types.Const, *types.TypeName, *types.Func:
+		return true
+	default:
+		return false
+	}
+}
+
+// isInternalPackagePath reports whether an import path refers to an internal package.
+func isInternalPackagePath(path string) bool {
+	return path == "internal" ||
+		strings.HasPrefix(path, "internal/") ||
+		strings.HasSuffix(path, "/internal") ||
+		strings.Contains(path, "/internal/")
+}
+
+// usedObjectKeys collects "pkgpath.Name" keys of the package-level objects referenced across the loaded
+// packages. Keys are used instead of object identity because imported packages may be type-checked
+// separately. Fields and methods are left out: their key would hide a package-level object of that name.
+func usedObjectKeys(pkgs []*packages.Package) map[string]struct{} {
+	keys := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+
+		for _, obj := range pkg.TypesInfo.Uses {
+			if obj != nil && obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+				keys[objectKey(obj)] = struct{}{}
+			}
+		}
+	}
+
+	return keys
+}
+
+// objectKey returns the "pkgpath.Name" key of an object.
+func objectKey(obj types.Object) string {
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
 func sameObject(a, b types.Object) bool {
 	if a == nil || b == nil {
 		return false
@@ -552,61 +891,311 @@ func interfaceExtends(impl, target *types.Interface) bool {
 	return true
 }
 
-func findTargetObject(ctx context.Context, pkgs []*packages.Package, ident, kind string) types.Object {
+// lookupTypeName resolves "Type", "pkg.Type" or "import/path.Type" to a single package-level type.
+// Module packages are searched first; packages they import (e.g. "io.Writer") are the fallback.
+//
+// Parameters:
+//   - pkgs: loaded packages with type information
+//   - name: type name, optionally qualified by package name or import path
+//
+// Returns:
+//   - the type name object
+//   - error if the type is not found or matches several packages
+func lookupTypeName(pkgs []*packages.Package, name string) (*types.TypeName, error) {
+	pkgName, typeName := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		pkgName, typeName = name[:i], name[i+1:]
+	}
+
+	lookup := func(candidates []*types.Package) []*types.TypeName {
+		var matches []*types.TypeName
+
+		seen := make(map[string]bool)
+
+		for _, pkg := range candidates {
+			if pkg == nil || seen[pkg.Path()] || (pkgName != "" && pkg.Name() != pkgName && pkg.Path() != pkgName) {
+				continue
+			}
+
+			seen[pkg.Path()] = true
+
+			if tn, ok := pkg.Scope().Lookup(typeName).(*types.TypeName); ok {
+				matches = append(matches, tn)
+			}
+		}
+
+		return matches
+	}
+
+	var module, imported []*types.Package
+
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+
+		module = append(module, pkg.Types)
+		imported = append(imported, pkg.Types.Imports()...)
+	}
+
+	matches := lookup(module)
+	if len(matches) == 0 && pkgName != "" {
+		matches = lookup(imported)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, missingSymbol(pkgs, name, "type", "type %q not found", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	paths := make([]string, 0, len(matches))
+	for _, tn := range matches {
+		paths = append(paths, tn.Pkg().Path()+"."+tn.Name())
+	}
+
+	sort.Strings(paths)
+
+	return nil, ambiguous(paths, "type %q is ambiguous: %s", name, strings.Join(paths, ", "))
+}
+
+// targetCandidate is a declaration matching a looked-up name, with the package declaring it.
+type targetCandidate struct {
+	obj types.Object
+	pkg *packages.Package
+}
+
+// findTargetCandidates returns the distinct declarations named ident in pkgs, optionally of the given kind
+// and declared in the package with import path pkgPath: the package-level ones when there are any, every
+// declaration (methods, fields and locals included) otherwise. A declaration repeated by the test variant
+// of its package is returned once, with the package without tests. Candidates are sorted by package path
+// and position, whatever the order of pkgs. It returns an error wrapping errTypesNotLoaded if a package
+// lacks type information.
+func findTargetCandidates(ctx context.Context, pkgs []*packages.Package, ident, kind, pkgPath string) ([]targetCandidate, error) {
+	byPos := make(map[token.Pos]targetCandidate)
+
 	for _, pkg := range pkgs {
 		if shouldStop(ctx) {
-			return nil
+			return nil, ctx.Err()
 		}
 
-		if scope := pkg.Types.Scope(); scope != nil {
-			if obj := scope.Lookup(ident); obj != nil {
-				if kind == "" || objStringKind(obj) == kind {
-					return obj
-				}
+		if !hasTypes(pkg) {
+			return nil, fmt.Errorf("%w for package %s", errTypesNotLoaded, pkg.ID)
+		}
+
+		if pkgPath != "" && pkg.Types.Path() != pkgPath {
+			continue
+		}
+
+		for id, def := range pkg.TypesInfo.Defs {
+			if def == nil || id.Name != ident || def.Pkg() == nil || (kind != "" && objStringKind(def) != kind) {
+				continue
+			}
+
+			// Embedded fields share the name of their type and are not declarations of their own.
+			if v, ok := def.(*types.Var); ok && v.Embedded() {
+				continue
 			}
+
+			if prev, ok := byPos[def.Pos()]; ok && !isTestVariant(prev.pkg) {
+				continue
+			}
+
+			byPos[def.Pos()] = targetCandidate{obj: def, pkg: pkg}
+		}
+	}
+
+	candidates := make([]targetCandidate, 0, len(byPos))
+	packageLevel := make([]targetCandidate, 0, len(byPos))
+
+	for _, c := range byPos {
+		candidates = append(candidates, c)
+
+		if c.obj.Parent() == c.obj.Pkg().Scope() {
+			packageLevel = append(packageLevel, c)
+		}
+	}
+
+	if len(packageLevel) > 0 {
+		candidates = packageLevel
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.obj.Pkg().Path() != b.obj.Pkg().Path() {
+			return a.obj.Pkg().Path() < b.obj.Pkg().Path()
+		}
+
+		return a.obj.Pos() < b.obj.Pos()
+	})
+
+	return candidates, nil
+}
+
+// isTestVariant reports whether pkg is the variant of a package compiled with its tests.
+func isTestVariant(pkg *packages.Package) bool {
+	return strings.Contains(pkg.ID, " [")
+}
+
+// findTargetObject returns the only declaration named ident in pkgs, optionally of the given kind and
+// declared in the package with import path pkgPath, as findTargetCandidates selects them; nil when none
+// matches. Several matching declarations are never resolved silently: they fail with an AMBIGUOUS error
+// listing them as "kind pkg.ident (file:line)".
+func findTargetObject(ctx context.Context, pkgs []*packages.Package, dir, ident, kind, pkgPath string) (types.Object, error) {
+	candidates, err := findTargetCandidates(ctx, pkgs, ident, kind, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return candidates[0].obj, nil
+	}
+
+	return nil, ambiguousTarget(dir, ident, candidates)
+}
+
+// ambiguousTarget returns the AMBIGUOUS error of a name matching several declarations.
+func ambiguousTarget(dir, ident string, candidates []targetCandidate) error {
+	listed := make([]string, 0, len(candidates))
+
+	for _, c := range candidates {
+		posn := c.pkg.Fset.Position(c.obj.Pos())
+		listed = append(listed, fmt.Sprintf("%s %s.%s (%s:%d)",
+			objStringKind(c.obj), c.obj.Pkg().Path(), ident, relativePath(dir, posn.Filename), posn.Line))
+	}
+
+	return ambiguous(listed, "%q matches %d declarations; pass package, kind or symbolId to choose one: %s",
+		ident, len(candidates), strings.Join(listed, ", "))
+}
+
+// declaredKinds returns the sorted kinds ident is declared with at package scope in pkgs and, as
+// candidates, every such declaration once as "kind pkg.ident (file:line)", sorted. Fields, methods,
+// parameters and locals are left out: without a kind, findTargetCandidates picks a package-level
+// declaration over them, so they never make a name ambiguous.
+func declaredKinds(pkgs []*packages.Package, dir, ident string) ([]string, []string) {
+	kinds := make(map[string]struct{})
+	seen := make(map[string]struct{})
+
+	var candidates []string
+
+	for _, pkg := range pkgs {
+		if !hasTypes(pkg) {
+			continue
 		}
 
 		for id, def := range pkg.TypesInfo.Defs {
-			if shouldStop(ctx) {
-				return nil
+			if def == nil || id.Name != ident || def.Pkg() == nil || def.Parent() != def.Pkg().Scope() {
+				continue
 			}
 
-			if def != nil && id.Name == ident {
-				if kind == "" || objStringKind(def) == kind {
-					return def
-				}
+			posn := pkg.Fset.Position(def.Pos())
+			kind := objStringKind(def)
+			candidate := fmt.Sprintf("%s %s.%s (%s:%d)", kind, def.Pkg().Path(), ident, relativePath(dir, posn.Filename), posn.Line)
+
+			// Test variants of a package declare the same objects again.
+			if _, ok := seen[candidate]; ok {
+				continue
 			}
+
+			seen[candidate] = struct{}{}
+			kinds[kind] = struct{}{}
+			candidates = append(candidates, candidate)
 		}
 	}
 
-	return nil
+	sort.Strings(candidates)
+
+	return slices.Sorted(maps.Keys(kinds)), candidates
+}
+
+// promotedMethodSelections returns the selector identifiers that reach target, an interface method,
+// through one or more embedded fields (e.g. s.Get where s embeds the interface). It returns nil when
+// target is not an interface method.
+func promotedMethodSelections(info *types.Info, target types.Object) map[*ast.Ident]struct{} {
+	fn, ok := target.(*types.Func)
+	if !ok || info == nil {
+		return nil
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil || !types.IsInterface(sig.Recv().Type()) {
+		return nil
+	}
+
+	var idents map[*ast.Ident]struct{}
+
+	for expr, sel := range info.Selections {
+		if sel.Kind() != types.MethodVal || len(sel.Index()) < 2 {
+			continue
+		}
+
+		method, ok := sel.Obj().(*types.Func)
+		if !ok || !sameObject(method.Origin(), fn.Origin()) {
+			continue
+		}
+
+		if idents == nil {
+			idents = make(map[*ast.Ident]struct{})
+		}
+
+		idents[expr.Sel] = struct{}{}
+	}
+
+	return idents
 }
 
 type locationRecord struct {
-	File    string
-	Line    int
-	Snippet string
+	File            string
+	Line            int
+	SymbolID        string
+	Snippet         string
+	Indirect        bool
+	BuildConstraint string
+	LSPLocation     *LSPLocation
+	// Usage and Destination classify references to functions (see funcReferenceUsage).
+	Usage       string
+	Destination string
 }
 
-func appendDefinition(out *[]locationRecord, dir string, fset *token.FileSet, pos token.Pos, fileFilter string) {
-	posn := fset.Position(pos)
+// appendDefinition appends the declaration of obj; withLSP adds the LSP location of its name.
+func appendDefinition(out *[]locationRecord, dir string, pkg *packages.Package, obj types.Object, fileFilter string, snippets snippetSpec, withLSP bool) {
+	pos := obj.Pos()
+
+	posn := pkg.Fset.Position(pos)
 	if posn.Filename == "" {
 		return
 	}
 
-	if fileFilter != "" && !strings.HasSuffix(posn.Filename, fileFilter) {
+	rel := relativePath(dir, posn.Filename)
+	if !matchesFileFilter(rel, fileFilter) {
 		return
 	}
 
-	rel := relativePath(dir, posn.Filename)
 	lines := getFileLinesFromPath(posn.Filename)
-	snippet := extractSnippet(lines, posn.Line)
-	*out = append(*out, locationRecord{File: rel, Line: posn.Line, Snippet: snippet})
+	snippet := snippets.extract(lines, pkg.Fset, syntaxFileAt(pkg, pos), pos)
+
+	var loc *LSPLocation
+	if withLSP {
+		loc = lspLocationAt(posn, obj.Name())
+	}
+
+	*out = append(*out, locationRecord{
+		File:            rel,
+		Line:            posn.Line,
+		SymbolID:        symbolID(pkg.TypesInfo, obj),
+		Snippet:         snippet,
+		BuildConstraint: fileBuildConstraintAt(posn.Filename),
+		LSPLocation:     loc,
+	})
 }
 
-func appendReference(out *[]locationRecord, dir string, absPath string, line int, snippet string) {
+func appendReference(out *[]locationRecord, dir string, absPath string, line int, snippet string, indirect bool, loc *LSPLocation) {
 	rel := relativePath(dir, absPath)
-	*out = append(*out, locationRecord{File: rel, Line: line, Snippet: snippet})
+	*out = append(*out, locationRecord{File: rel, Line: line, Snippet: snippet, Indirect: indirect, LSPLocation: loc})
 }
 
 func sortLocationRecords(records []locationRecord) {
@@ -649,11 +1238,17 @@ func makeReferenceGroups(records []locationRecord) []ReferenceGroup {
 	index := make(map[string]int, len(records))
 
 	for _, rec := range records {
+		entry := ReferenceEntry{
+			Line:        rec.Line,
+			Snippet:     rec.Snippet,
+			Indirect:    rec.Indirect,
+			LSPLocation: rec.LSPLocation,
+			Usage:       rec.Usage,
+			Destination: rec.Destination,
+		}
+
 		if idx, ok := index[rec.File]; ok {
-			groups[idx].References = append(groups[idx].References, ReferenceEntry{
-				Line:    rec.Line,
-				Snippet: rec.Snippet,
-			})
+			groups[idx].References = append(groups[idx].References, entry)
 
 			continue
 		}
@@ -661,17 +1256,43 @@ func makeReferenceGroups(records []locationRecord) []ReferenceGroup {
 		index[rec.File] = len(groups)
 
 		groups = append(groups, ReferenceGroup{
-			File: rec.File,
-			References: []ReferenceEntry{{
-				Line:    rec.Line,
-				Snippet: rec.Snippet,
-			}},
+			File:       rec.File,
+			References: []ReferenceEntry{entry},
 		})
 	}
 
 	return groups
 }
 
+// makeCompactReferenceGroups groups records by file like makeReferenceGroups, each reference as a
+// [line, snippet] tuple with a trailing true when it is indirect.
+func makeCompactReferenceGroups(records []locationRecord) []CompactReferenceGroup {
+	if len(records) == 0 {
+		return nil
+	}
+
+	groups := make([]CompactReferenceGroup, 0)
+	index := make(map[string]int, len(records))
+
+	for _, rec := range records {
+		ref := CompactReference{rec.Line, rec.Snippet}
+		if rec.Indirect {
+			ref = append(ref, true)
+		}
+
+		idx, ok := index[rec.File]
+		if !ok {
+			idx = len(groups)
+			index[rec.File] = idx
+			groups = append(groups, CompactReferenceGroup{File: rec.File})
+		}
+
+		groups[idx].Refs = append(groups[idx].Refs, ref)
+	}
+
+	return groups
+}
+
 func makeDefinitionGroups(records []locationRecord) []DefinitionGroup {
 	if len(records) == 0 {
 		return nil
@@ -683,8 +1304,11 @@ func makeDefinitionGroups(records []locationRecord) []DefinitionGroup {
 	for _, rec := range records {
 		if idx, ok := index[rec.File]; ok {
 			groups[idx].Definitions = append(groups[idx].Definitions, DefinitionEntry{
-				Line:    rec.Line,
-				Snippet: rec.Snippet,
+				Line:            rec.Line,
+				SymbolID:        rec.SymbolID,
+				Snippet:         rec.Snippet,
+				BuildConstraint: rec.BuildConstraint,
+				LSPLocation:     rec.LSPLocation,
 			})
 
 			continue
@@ -695,8 +1319,11 @@ func makeDefinitionGroups(records []locationRecord) []DefinitionGroup {
 		groups = append(groups, DefinitionGroup{
 			File: rec.File,
 			Definitions: []DefinitionEntry{{
-				Line:    rec.Line,
-				Snippet: rec.Snippet,
+				Line:            rec.Line,
+				SymbolID:        rec.SymbolID,
+				Snippet:         rec.Snippet,
+				BuildConstraint: rec.BuildConstraint,
+				LSPLocation:     rec.LSPLocation,
 			}},
 		})
 	}
@@ -836,28 +1463,71 @@ func compareExprSlices(a, b []ast.Expr) bool {
 
 // receiverName returns the receiver type name for a method if present.
 // For example, for `func (s *TaskService) List()` returns "TaskService".
+// Generic receivers such as `func (s *Store[T]) Get()` resolve to the base type name "Store".
 // If the function is not a method, returns an empty string.
 func receiverName(fd *ast.FuncDecl) string {
 	if fd.Recv == nil || len(fd.Recv.List) == 0 {
 		return ""
 	}
 
-	recvType := fd.Recv.List[0].Type
-
-	switch expr := recvType.(type) {
-	case *ast.StarExpr:
-		// Pointer to type, e.g. (*TaskService)
-		if ident, ok := expr.X.(*ast.Ident); ok {
-			return ident.Name
-		}
-	case *ast.Ident:
-		// Direct type without pointer, e.g. TaskService
-		return expr.Name
+	if ident, _ := receiverBase(fd.Recv.List[0].Type); ident != nil {
+		return ident.Name
 	}
 
 	return ""
 }
 
+// receiverTypeParams returns the type parameter names used by a generic method receiver,
+// e.g. ["K", "V"] for `func (m *Map[K, V]) Get()`.
+func receiverTypeParams(fd *ast.FuncDecl) []string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return nil
+	}
+
+	_, params := receiverBase(fd.Recv.List[0].Type)
+
+	names := make([]string, 0, len(params))
+
+	for _, p := range params {
+		names = append(names, exprString(p))
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	return names
+}
+
+// receiverBase unwraps pointers, parentheses and type parameter lists from a receiver type expression
+// and returns the base type identifier together with the type parameter expressions.
+func receiverBase(expr ast.Expr) (*ast.Ident, []ast.Expr) {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			// Pointer to type, e.g. (*TaskService)
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			// Generic type with a single type parameter, e.g. Store[T]
+			ident, _ := receiverBase(e.X)
+
+			return ident, []ast.Expr{e.Index}
+		case *ast.IndexListExpr:
+			// Generic type with several type parameters, e.g. Map[K, V]
+			ident, _ := receiverBase(e.X)
+
+			return ident, e.Indices
+		case *ast.Ident:
+			// Direct type without pointer, e.g. TaskService
+			return e, nil
+		default:
+			return nil, nil
+		}
+	}
+}
+
 // exprString returns the string representation of an AST expression type (for struct fields).
 func exprString(e ast.Expr) string {
 	var buf bytes.Buffer
@@ -870,10 +1540,42 @@ func exprString(e ast.Expr) string {
 	return buf.String()
 }
 
-func diffFiles(oldData, newData []byte, rel string) string {
+// Diff renderings selected by the diffMode input of mutating tools.
+const (
+	diffModeUnified = "unified"
+	diffModeMinimal = "minimal"
+	diffModeSummary = "summary"
+)
+
+// validateDiffMode rejects unknown diffMode values; empty selects the unified diff.
+func validateDiffMode(mode string) error {
+	switch mode {
+	case "", diffModeUnified, diffModeMinimal, diffModeSummary:
+		return nil
+	}
+
+	return NewToolError(CodeInvalidInput, fmt.Errorf("unknown diffMode %q", mode),
+		diffModeUnified, diffModeMinimal, diffModeSummary)
+}
+
+// diffFiles renders the change between two versions of a file in the given diff mode: a unified diff
+// with 3 context lines (default), changed lines only ("minimal") or counts and line numbers ("summary").
+// Both sides are compared with LF line endings and without a BOM, matching what safeWriteFile preserves,
+// so no spurious whole-file diffs appear.
+func diffFiles(oldData, newData []byte, rel, mode string) string {
+	a := difflib.SplitLines(string(normalizeLineEndings(oldData)))
+	b := difflib.SplitLines(string(normalizeLineEndings(newData)))
+
+	switch mode {
+	case diffModeMinimal:
+		return minimalDiff(a, b, rel)
+	case diffModeSummary:
+		return summaryDiff(a, b, rel)
+	}
+
 	diff := difflib.UnifiedDiff{
-		A:        difflib.SplitLines(string(oldData)),
-		B:        difflib.SplitLines(string(newData)),
+		A:        a,
+		B:        b,
 		FromFile: "a/" + rel,
 		ToFile:   "b/" + rel,
 		Context:  3,
@@ -883,6 +1585,83 @@ func diffFiles(oldData, newData []byte, rel string) string {
 	return text
 }
 
+// minimalDiff lists removed lines with their old line number and added lines with their new line
+// number, e.g. "main.go:4: -func Old() {", without context.
+func minimalDiff(a, b []string, rel string) string {
+	var buf strings.Builder
+
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+
+		for i := op.I1; i < op.I2; i++ {
+			fmt.Fprintf(&buf, "%s:%d: -%s\n", rel, i+1, strings.TrimSuffix(a[i], "\n"))
+		}
+
+		for j := op.J1; j < op.J2; j++ {
+			fmt.Fprintf(&buf, "%s:%d: +%s\n", rel, j+1, strings.TrimSuffix(b[j], "\n"))
+		}
+	}
+
+	return buf.String()
+}
+
+// summaryDiff renders "main.go: +2 -1 lines 4,9-10": inserted and deleted line counts and the changed
+// lines of the new version (a deletion is reported at the line that follows it).
+func summaryDiff(a, b []string, rel string) string {
+	insertions, deletions := 0, 0
+
+	var lines []int
+
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+
+		deletions += op.I2 - op.I1
+		insertions += op.J2 - op.J1
+
+		if op.J2 == op.J1 {
+			lines = append(lines, max(min(op.J1+1, len(b)), 1))
+
+			continue
+		}
+
+		for j := op.J1; j < op.J2; j++ {
+			lines = append(lines, j+1)
+		}
+	}
+
+	if insertions == 0 && deletions == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s: +%d -%d lines %s\n", rel, insertions, deletions, lineRanges(lines))
+}
+
+// lineRanges compacts ascending line numbers into "4,9-11".
+func lineRanges(lines []int) string {
+	var parts []string
+
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] <= lines[j]+1 {
+			j++
+		}
+
+		if lines[j] == lines[i] {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+
+		i = j + 1
+	}
+
+	return strings.Join(parts, ",")
+}
+
 func computeFunctionMetrics(ctx context.Context, fset *token.FileSet, fn *ast.FuncDecl) (lines int, maxNesting int, cyclomatic int) {
 	if fn == nil || fn.Body == nil {
 		return 0, 0, 0
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// maxNextSteps caps the follow-up calls suggested by one response.
+const maxNextSteps = 10
+
+// newNextStep encodes input, the input struct of tool, into a suggested call.
+func newNextStep(tool string, input any, reason string) NextStep {
+	// The inputs are plain structs of strings, numbers and booleans, which always encode.
+	data, _ := json.Marshal(input)
+
+	return NextStep{Tool: tool, InputJSON: string(data), Reason: reason}
+}
+
+// deadCodeHints suggests previewDelete for the first unused symbols in file order, to confirm nothing
+// outside the analyzed scope breaks before deleting them. targets holds the previewDelete symbol of each
+// finding; findings without one are skipped.
+func deadCodeHints(dir string, unused []DeadSymbol, targets map[DeadSymbol]string) []NextStep {
+	sorted := append([]DeadSymbol(nil), unused...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].File != sorted[j].File {
+			return sorted[i].File < sorted[j].File
+		}
+
+		return sorted[i].Line < sorted[j].Line
+	})
+
+	var steps []NextStep
+
+	for _, sym := range sorted {
+		symbol := targets[sym]
+		if symbol == "" {
+			continue
+		}
+
+		if len(steps) == maxNextSteps {
+			break
+		}
+
+		steps = append(steps, newNextStep("previewDelete",
+			PreviewDeleteInput{Dir: dir, Symbol: symbol, Kind: sym.Kind},
+			fmt.Sprintf("%s %s (%s:%d) has no uses in %s; previewDelete confirms the deletion breaks nothing",
+				sym.Kind, symbol, sym.File, sym.Line, sym.Package)))
+	}
+
+	return steps
+}
+
+// deadSymbolTarget returns the name previewDelete resolves obj by: Type.method for methods, or "" for
+// local declarations such as unused parameters, which it cannot address.
+func deadSymbolTarget(obj types.Object) string {
+	if fn, ok := obj.(*types.Func); ok {
+		if named := receiverNamed(fn); named != nil {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+	}
+
+	if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return ""
+	}
+
+	return obj.Name()
+}
+
+// complexityHints suggests getFunctionSource for the worst function of a descending ranking. A closure is
+// read through the declaration enclosing it.
+func complexityHints(dir string, ranked []FunctionComplexity, input AnalyzeComplexityInput) []NextStep {
+	if len(ranked) == 0 || input.Order == "asc" {
+		return nil
+	}
+
+	worst := ranked[0]
+
+	name, _, _ := strings.Cut(worst.Name, ".func")
+	if worst.Receiver != "" {
+		name = worst.Receiver + "." + name
+	}
+
+	metric := input.SortBy
+	if metric == "" {
+		metric = "cyclomatic"
+	}
+
+	return []NextStep{newNextStep("getFunctionSource",
+		ReadFuncInput{Dir: dir, Name: name},
+		fmt.Sprintf("%s (%s:%d) ranks worst by %s (cyclomatic %d, cognitive %d, nesting %d, %d lines); read it to plan a split",
+			worst.Name, worst.File, worst.Line, metric, worst.Cyclomatic, worst.Cognitive, worst.Nesting, worst.Lines))}
+}
+
+// cycleHints suggests listImports for the package whose import closes each cycle: the last package of
+// the cycle imports the first.
+func cycleHints(dir string, cycles [][]string) []NextStep {
+	var steps []NextStep
+
+	for _, cycle := range cycles[:min(len(cycles), maxNextSteps)] {
+		if len(cycle) == 0 {
+			continue
+		}
+
+		from, to := cycle[len(cycle)-1], cycle[0]
+
+		steps = append(steps, newNextStep("listImports",
+			ListImportsInput{Dir: dir, Package: from},
+			fmt.Sprintf("%s imports %s, closing the cycle %s -> %s; listImports shows the files declaring the import",
+				from, to, strings.Join(cycle, " -> "), to)))
+	}
+
+	return steps
+}
+
+// implementationHints suggests explainImplements for the module type sharing most method names with
+// iface, when nothing implements it. Ties go to the first type by qualified name; no type sharing a
+// method gives no hint.
+func implementationHints(dir string, pkgs []*packages.Package, target types.Object, iface *types.Interface) []NextStep {
+	wanted := make(map[string]struct{}, iface.NumMethods())
+	for i := range iface.NumMethods() {
+		wanted[iface.Method(i).Name()] = struct{}{}
+	}
+
+	var (
+		best      *types.TypeName
+		bestCount int
+	)
+
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || tn.IsAlias() || types.IsInterface(tn.Type()) {
+				continue
+			}
+
+			count := 0
+
+			mset := types.NewMethodSet(types.NewPointer(tn.Type()))
+			for i := range mset.Len() {
+				if _, ok := wanted[mset.At(i).Obj().Name()]; ok {
+					count++
+				}
+			}
+
+			if count > bestCount || (count == bestCount && best != nil && qualifiedTypeName(tn) < qualifiedTypeName(best)) {
+				best, bestCount = tn, count
+			}
+		}
+	}
+
+	if best == nil || bestCount == 0 {
+		return nil
+	}
+
+	return []NextStep{newNextStep("explainImplements",
+		ExplainImplementsInput{Dir: dir, TypeName: qualifiedTypeName(best), InterfaceName: qualifiedTypeName(target)},
+		fmt.Sprintf("nothing implements %s; %s comes closest with %d of its %d methods, explainImplements lists the missing and mismatched ones",
+			target.Name(), best.Name(), bestCount, len(wanted)))}
+}
+
+// qualifiedTypeName returns "pkgpath.Name", the form lookupTypeName resolves without ambiguity.
+func qualifiedTypeName(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+
+	return obj.Pkg().Path() + "." + obj.Name()
+}
@@ -0,0 +1,148 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const hintsSource = `package lang
+
+type Closer interface {
+	Close() error
+	Flush() error
+}
+
+type Store struct{ n int }
+
+func (s *Store) Close() error { return nil }
+
+func (s *Store) Process(x int) int {
+	if x > 0 {
+		if x > 10 {
+			return s.n
+		}
+	}
+
+	return 0
+}
+
+func (s *Store) reset() {}
+
+func helper() {}
+
+type Other struct{}
+
+func (Other) String() string { return "" }
+`
+
+// assertNextStep checks that step calls tool with an input decoding to want.
+func assertNextStep[T any](t *testing.T, step tools.NextStep, tool string, want T) {
+	t.Helper()
+
+	var got T
+	if err := json.Unmarshal([]byte(step.InputJSON), &got); err != nil {
+		t.Fatalf("decode %s input %q: %v", tool, step.InputJSON, err)
+	}
+
+	if step.Tool != tool || !reflect.DeepEqual(got, want) || step.Reason == "" {
+		t.Fatalf("expected %s with %+v, got %+v", tool, want, step)
+	}
+}
+
+func TestNextSteps_DeadCodeAndComplexity(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": hintsSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, dead, err := tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, WithHints: true})
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	if len(dead.NextSteps) != 2 {
+		t.Fatalf("expected a previewDelete hint per unused symbol, got %+v", dead.NextSteps)
+	}
+
+	assertNextStep(t, dead.NextSteps[0], "previewDelete", tools.PreviewDeleteInput{Dir: dir, Symbol: "Store.reset", Kind: "func"})
+	assertNextStep(t, dead.NextSteps[1], "previewDelete", tools.PreviewDeleteInput{Dir: dir, Symbol: "helper", Kind: "func"})
+
+	_, complexity, err := tools.AnalyzeComplexity(ctx, req, tools.AnalyzeComplexityInput{Dir: dir, Top: 3, WithHints: true})
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	if len(complexity.NextSteps) != 1 {
+		t.Fatalf("expected one hint for the worst function, got %+v", complexity.NextSteps)
+	}
+
+	assertNextStep(t, complexity.NextSteps[0], "getFunctionSource", tools.ReadFuncInput{Dir: dir, Name: "Store.Process"})
+
+	_, complexity, err = tools.AnalyzeComplexity(ctx, req, tools.AnalyzeComplexityInput{Dir: dir, Top: 3})
+	if err != nil || complexity.NextSteps != nil {
+		t.Fatalf("expected no hints without withHints, got %+v (err %v)", complexity.NextSteps, err)
+	}
+}
+
+func TestNextSteps_Implementations(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": hintsSource})
+
+	_, out, err := tools.FindImplementations(context.Background(), &mcp.CallToolRequest{}, tools.FindImplementationsInput{
+		Dir:       dir,
+		Name:      "Closer",
+		WithHints: true,
+	})
+	if err != nil {
+		t.Fatalf("FindImplementations error: %v", err)
+	}
+
+	if len(out.Implementations) != 0 || len(out.NextSteps) != 1 {
+		t.Fatalf("expected no implementations and one hint, got %+v", out)
+	}
+
+	assertNextStep(t, out.NextSteps[0], "explainImplements",
+		tools.ExplainImplementsInput{Dir: dir, TypeName: "lang.Store", InterfaceName: "lang.Closer"})
+}
+
+func TestNextSteps_DependencyCycle(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{})
+
+	for name, content := range map[string]string{
+		"a/a.go": "package a\n\nimport \"lang/b\"\n\nfunc A() { b.B() }\n",
+		"b/b.go": "package b\n\nimport \"lang/a\"\n\nfunc B() { a.A() }\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	_, out, err := tools.AnalyzeDependencies(context.Background(), &mcp.CallToolRequest{}, tools.AnalyzeDependenciesInput{
+		Dir:       dir,
+		WithHints: true,
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeDependencies error: %v", err)
+	}
+
+	if !reflect.DeepEqual(out.Cycles, [][]string{{"lang/a", "lang/b"}}) || len(out.NextSteps) != 1 {
+		t.Fatalf("expected the cycle lang/a -> lang/b and one hint, got %+v", out)
+	}
+
+	assertNextStep(t, out.NextSteps[0], "listImports", tools.ListImportsInput{Dir: dir, Package: "lang/b"})
+}
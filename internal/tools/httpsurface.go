@@ -0,0 +1,533 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultHTTPFrameworks are the router packages analyzeHTTPSurface matches without frameworks.
+var defaultHTTPFrameworks = []string{
+	"net/http",
+	"github.com/go-chi/chi",
+	"github.com/gin-gonic/gin",
+	"github.com/gorilla/mux",
+}
+
+// httpRouteAny is the method of a route registered for every HTTP method.
+const httpRouteAny = "ANY"
+
+// httpVerbs maps the names of per-method registration methods (chi Get, gin GET) to their HTTP method.
+var httpVerbs = map[string]string{
+	"Get": "GET", "Post": "POST", "Put": "PUT", "Patch": "PATCH", "Delete": "DELETE", "Head": "HEAD",
+	"Options": "OPTIONS", "Connect": "CONNECT", "Trace": "TRACE",
+	"GET": "GET", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH", "DELETE": "DELETE", "HEAD": "HEAD",
+	"OPTIONS": "OPTIONS", "Any": httpRouteAny,
+}
+
+// maxHandlerIndirections bounds how many variables and wrapper calls resolveHandler follows.
+const maxHandlerIndirections = 4
+
+// AnalyzeHTTPSurface lists the HTTP routes a module registers: every call of a registration method of a
+// router package (ServeMux.HandleFunc, chi Get/Route/Mount, gin GET/Group, gorilla HandleFunc().Methods),
+// matched through the type information so routers embedded in wrapper types count too. Each route gets
+// its method, its path with the prefixes of enclosing Route/Group/PathPrefix calls (marked dynamic when not
+// a constant), the handler function behind method values, conversions, variables and wrapping calls, and
+// the middlewares applied by Use, With, gin handler chains and wrapping calls. Registrations whose handler
+// cannot be resolved are listed in unresolved.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and the router packages to match
+//
+// Returns:
+//   - MCP tool call result
+//   - the route table and the unresolved registrations
+//   - error if packages cannot be loaded
+func AnalyzeHTTPSurface(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeHTTPSurfaceInput) (
+	*mcp.CallToolResult,
+	AnalyzeHTTPSurfaceOutput,
+	error,
+) {
+	start := logStart("AnalyzeHTTPSurface", logFields(
+		input.Dir,
+		newLogField("frameworks", strings.Join(input.Frameworks, ",")),
+	))
+	out := AnalyzeHTTPSurfaceOutput{Routes: []HTTPRoute{}, Unresolved: []UnresolvedRoute{}}
+
+	defer func() { logEnd("AnalyzeHTTPSurface", start, out.Total) }()
+
+	out.Frameworks = input.Frameworks
+	if len(out.Frameworks) == 0 {
+		out.Frameworks = defaultHTTPFrameworks
+	}
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeSyntaxTypesNamed)
+	if err != nil {
+		logError("AnalyzeHTTPSurface", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if !hasTypes(pkg) {
+			return nil
+		}
+
+		for _, decl := range file.Decls {
+			w := &httpRouteWalker{
+				pkg:        pkg,
+				relPath:    relPath,
+				frameworks: out.Frameworks,
+				scopes:     make(map[types.Object]*routeScope),
+				values:     make(map[types.Object]ast.Expr),
+				methods:    make(map[*ast.CallExpr][]string),
+			}
+
+			ast.Inspect(decl, w.visit)
+
+			out.Routes = append(out.Routes, w.routes...)
+			out.Unresolved = append(out.Unresolved, w.unresolved...)
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.SliceStable(out.Routes, func(i, j int) bool {
+		a, b := out.Routes[i], out.Routes[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	sort.SliceStable(out.Unresolved, func(i, j int) bool {
+		a, b := out.Unresolved[i], out.Unresolved[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	out.Total = len(out.Routes)
+
+	return nil, out, nil
+}
+
+// routeScope is what a router value adds to the routes registered on it: the path prefix and the
+// middlewares of the Route, Group, PathPrefix and With calls it came from, and those added by Use.
+type routeScope struct {
+	prefix      string
+	dynamic     bool
+	middlewares []string
+}
+
+// child returns a scope below s with the path p, its prefix when dynamic, and further middlewares.
+func (s *routeScope) child(p string, dynamic bool, middlewares ...string) *routeScope {
+	return &routeScope{
+		prefix:      joinRoutePath(s.prefix, p),
+		dynamic:     s.dynamic || dynamic,
+		middlewares: append(slices.Clone(s.middlewares), middlewares...),
+	}
+}
+
+// joinRoutePath appends p to prefix without doubling the slash between them.
+func joinRoutePath(prefix, p string) string {
+	if strings.HasSuffix(prefix, "/") && strings.HasPrefix(p, "/") {
+		return prefix + p[1:]
+	}
+
+	return prefix + p
+}
+
+// httpRouteWalker collects the routes registered in one declaration. Router variables and variables
+// holding handlers are followed within the declaration only: a router passed in has no known prefix.
+type httpRouteWalker struct {
+	pkg        *packages.Package
+	relPath    string
+	frameworks []string
+	scopes     map[types.Object]*routeScope
+	values     map[types.Object]ast.Expr
+	// methods holds the methods of gorilla Methods calls by the registration call they apply to.
+	methods    map[*ast.CallExpr][]string
+	routes     []HTTPRoute
+	unresolved []UnresolvedRoute
+}
+
+// framework returns the entry of frameworks declaring fn, empty if none does.
+func (w *httpRouteWalker) framework(fn *types.Func) string {
+	if fn == nil || fn.Pkg() == nil {
+		return ""
+	}
+
+	path := fn.Pkg().Path()
+	for _, fw := range w.frameworks {
+		if path == fw || strings.HasPrefix(path, fw+"/") {
+			return fw
+		}
+	}
+
+	return ""
+}
+
+// objectOf returns the object an identifier or selector denotes.
+func (w *httpRouteWalker) objectOf(expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		if obj := w.pkg.TypesInfo.Uses[e]; obj != nil {
+			return obj
+		}
+
+		return w.pkg.TypesInfo.Defs[e]
+	case *ast.SelectorExpr:
+		return selectorObject(w.pkg.TypesInfo, e)
+	}
+
+	return nil
+}
+
+// receiver returns the receiver expression of a method call, nil for a function call.
+func (w *httpRouteWalker) receiver(call *ast.CallExpr) ast.Expr {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || w.pkg.TypesInfo.Selections[sel] == nil {
+		return nil
+	}
+
+	return sel.X
+}
+
+// routePath returns the constant string expr holds, or its source in braces and false.
+func (w *httpRouteWalker) routePath(expr ast.Expr) (string, bool) {
+	if s, ok := constantString(w.pkg.TypesInfo, expr); ok {
+		return s, true
+	}
+
+	return "{" + types.ExprString(expr) + "}", false
+}
+
+// scopeOf returns the scope of a router expression: the one recorded for a variable (a new empty one on
+// first use), or the scope a Group, With or PathPrefix call derives from its receiver.
+func (w *httpRouteWalker) scopeOf(expr ast.Expr) *routeScope {
+	if expr == nil {
+		return &routeScope{}
+	}
+
+	expr = ast.Unparen(expr)
+
+	if obj := w.objectOf(expr); obj != nil {
+		if _, ok := obj.(*types.Var); ok {
+			if w.scopes[obj] == nil {
+				w.scopes[obj] = &routeScope{}
+			}
+
+			return w.scopes[obj]
+		}
+	}
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return &routeScope{}
+	}
+
+	fn := calledFunc(w.pkg.TypesInfo, call)
+	if w.framework(fn) == "" || w.receiver(call) == nil {
+		return &routeScope{}
+	}
+
+	parent := w.scopeOf(w.receiver(call))
+
+	switch fn.Name() {
+	case "Group":
+		// gin Group(prefix, middlewares...); chi Group(fn) registers in place and returns the router.
+		if len(call.Args) > 0 && !isFuncLit(call.Args[len(call.Args)-1]) {
+			p, ok := w.routePath(call.Args[0])
+
+			return parent.child(p, !ok, exprStrings(call.Args[1:])...)
+		}
+	case "With":
+		return parent.child("", false, exprStrings(call.Args)...)
+	case "PathPrefix", "Path":
+		if len(call.Args) == 1 {
+			p, ok := w.routePath(call.Args[0])
+
+			return parent.child(p, !ok)
+		}
+	}
+
+	// Subrouter, Methods, Name and other chained calls keep the scope of their receiver.
+	return parent
+}
+
+// isFuncLit reports whether expr is a function literal.
+func isFuncLit(expr ast.Expr) bool {
+	_, ok := ast.Unparen(expr).(*ast.FuncLit)
+
+	return ok
+}
+
+// exprStrings returns the source of each expression.
+func exprStrings(exprs []ast.Expr) []string {
+	var out []string
+	for _, e := range exprs {
+		out = append(out, types.ExprString(e))
+	}
+
+	return out
+}
+
+// bindClosure gives the router parameter of a chi Route or Group closure the scope s.
+func (w *httpRouteWalker) bindClosure(expr ast.Expr, s *routeScope) {
+	lit, ok := ast.Unparen(expr).(*ast.FuncLit)
+	if !ok || len(lit.Type.Params.List) != 1 || len(lit.Type.Params.List[0].Names) != 1 {
+		return
+	}
+
+	if obj := w.pkg.TypesInfo.Defs[lit.Type.Params.List[0].Names[0]]; obj != nil {
+		w.scopes[obj] = s
+	}
+}
+
+// visit records variable values and router scopes, and the routes of registration calls.
+func (w *httpRouteWalker) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				w.assign(lhs, n.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		if len(n.Names) == len(n.Values) {
+			for i, name := range n.Names {
+				w.assign(name, n.Values[i])
+			}
+		}
+	case *ast.CallExpr:
+		w.call(n)
+	}
+
+	return true
+}
+
+// assign records the value of a variable and, for a router built by a framework call, its scope.
+func (w *httpRouteWalker) assign(lhs, rhs ast.Expr) {
+	obj, ok := w.objectOf(lhs).(*types.Var)
+	if !ok {
+		return
+	}
+
+	w.values[obj] = rhs
+
+	if call, ok := ast.Unparen(rhs).(*ast.CallExpr); ok && w.framework(calledFunc(w.pkg.TypesInfo, call)) != "" {
+		w.scopes[obj] = w.scopeOf(call)
+	}
+}
+
+// call handles a call of a framework function: registrations become routes, Use and closures of Route
+// and Group update scopes, and gorilla Methods calls annotate the registration they follow.
+func (w *httpRouteWalker) call(call *ast.CallExpr) {
+	fn := calledFunc(w.pkg.TypesInfo, call)
+
+	fw := w.framework(fn)
+	if fw == "" {
+		return
+	}
+
+	recv := w.receiver(call)
+	name := fn.Name()
+	args := call.Args
+
+	switch {
+	case name == "Methods" && recv != nil:
+		if inner, ok := ast.Unparen(recv).(*ast.CallExpr); ok {
+			for _, arg := range args {
+				if m, ok := constantString(w.pkg.TypesInfo, arg); ok {
+					w.methods[inner] = append(w.methods[inner], strings.ToUpper(m))
+				}
+			}
+		}
+	case name == "Use" && recv != nil:
+		s := w.scopeOf(recv)
+		s.middlewares = append(s.middlewares, exprStrings(args)...)
+	case name == "Route" && recv != nil && len(args) == 2:
+		p, ok := w.routePath(args[0])
+		w.bindClosure(args[1], w.scopeOf(recv).child(p, !ok))
+	case name == "Group" && recv != nil && len(args) == 1 && isFuncLit(args[0]):
+		w.bindClosure(args[0], w.scopeOf(recv).child("", false))
+	case name == "Mount" && recv != nil && len(args) == 2:
+		w.register(call, fw, recv, []string{httpRouteAny}, args[0], "/*", args[1:])
+	case httpVerbs[name] != "" && recv != nil && fw != "net/http" && len(args) >= 2:
+		w.register(call, fw, recv, []string{httpVerbs[name]}, args[0], "", args[1:])
+	case name == "Handle" || name == "HandleFunc" || name == "Method" || name == "MethodFunc":
+		params := fn.Signature().Params()
+		if len(args) >= 3 && params.Len() >= 3 && isStringType(params.At(0).Type()) && isStringType(params.At(1).Type()) {
+			// gin Handle and chi Method take the HTTP method first.
+			method, ok := constantString(w.pkg.TypesInfo, args[0])
+			if !ok {
+				method = "{" + types.ExprString(args[0]) + "}"
+			}
+
+			w.register(call, fw, recv, []string{strings.ToUpper(method)}, args[1], "", args[2:])
+		} else if len(args) >= 2 {
+			w.register(call, fw, recv, w.methods[call], args[0], "", args[1:])
+		}
+	case (name == "Handler" || name == "HandlerFunc") && recv != nil && len(args) == 1:
+		// gorilla r.PathPrefix(p).Handler(h): the path is the scope of the receiver.
+		w.register(call, fw, recv, w.methods[call], nil, "", args)
+	}
+}
+
+// register records the routes of one registration call: one per method (ANY when none is known), with the
+// path of pathExpr and suffix below the scope of recv, served by the last of handlers after the others.
+func (w *httpRouteWalker) register(
+	call *ast.CallExpr,
+	fw string,
+	recv ast.Expr,
+	methods []string,
+	pathExpr ast.Expr,
+	suffix string,
+	handlers []ast.Expr,
+) {
+	scope := w.scopeOf(recv)
+	path, dynamic := scope.prefix, scope.dynamic
+
+	if pathExpr != nil {
+		p, ok := w.routePath(pathExpr)
+		path, dynamic = joinRoutePath(path, p), dynamic || !ok
+	}
+
+	path = joinRoutePath(path, suffix)
+
+	// Go 1.22 ServeMux patterns carry the method: "GET /users/{id}".
+	if fw == "net/http" && len(methods) == 0 {
+		if method, rest, ok := strings.Cut(path, " "); ok && method != "" && strings.ToUpper(method) == method {
+			methods, path = []string{method}, strings.TrimSpace(rest)
+		}
+	}
+
+	if len(methods) == 0 {
+		methods = []string{httpRouteAny}
+	}
+
+	line := w.pkg.Fset.Position(call.Pos()).Line
+	registration := types.ExprString(call.Fun)
+
+	last := handlers[len(handlers)-1]
+
+	handler, wrappers, reason := w.resolveHandler(last, 0)
+	if call.Ellipsis.IsValid() {
+		reason = "handlers are passed as a spread slice"
+	}
+
+	if reason != "" {
+		for _, method := range methods {
+			w.unresolved = append(w.unresolved, UnresolvedRoute{
+				Method:       method,
+				Path:         path,
+				Registration: registration,
+				Reason:       reason,
+				File:         w.relPath,
+				Line:         line,
+			})
+		}
+
+		return
+	}
+
+	middlewares := slices.Clone(scope.middlewares)
+	middlewares = append(middlewares, exprStrings(handlers[:len(handlers)-1])...)
+	middlewares = append(middlewares, wrappers...)
+
+	for _, method := range methods {
+		w.routes = append(w.routes, HTTPRoute{
+			Method:      method,
+			Path:        path,
+			Dynamic:     dynamic,
+			Handler:     handler,
+			Middlewares: middlewares,
+			Framework:   fw,
+			File:        w.relPath,
+			Line:        line,
+		})
+	}
+}
+
+// resolveHandler returns the function serving a handler expression: a function or method value by its
+// qualified name, a function literal, or a handler factory called as name(). Conversions such as
+// http.HandlerFunc(f) and local variables are followed, and a call wrapping a handler argument is a
+// middleware returned in wrappers, outermost first. reason explains a handler that cannot be resolved.
+func (w *httpRouteWalker) resolveHandler(expr ast.Expr, depth int) (string, []string, string) {
+	expr = ast.Unparen(expr)
+	info := w.pkg.TypesInfo
+
+	if depth > maxHandlerIndirections {
+		return "", nil, "too many indirections to the handler " + types.ExprString(expr)
+	}
+
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return "func literal", nil, ""
+	case *ast.Ident, *ast.SelectorExpr:
+		switch obj := w.objectOf(e).(type) {
+		case *types.Func:
+			return qualifiedObjectName(obj), nil, ""
+		case *types.Var:
+			if value := w.values[obj]; value != nil {
+				return w.resolveHandler(value, depth+1)
+			}
+
+			return "", nil, fmt.Sprintf("handler %s is a parameter, field or variable not assigned in this function",
+				types.ExprString(e))
+		}
+	case *ast.CallExpr:
+		if tv, ok := info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return w.resolveHandler(e.Args[0], depth+1)
+		}
+
+		// A call taking a function or a value of its own result type wraps that handler.
+		for i := len(e.Args) - 1; i >= 0; i-- {
+			arg := e.Args[i]
+
+			argType := info.TypeOf(arg)
+			if argType == nil {
+				continue
+			}
+
+			if _, isFunc := argType.Underlying().(*types.Signature); !isFunc && !types.Identical(argType, info.TypeOf(e)) {
+				continue
+			}
+
+			if handler, wrappers, reason := w.resolveHandler(arg, depth+1); reason == "" {
+				return handler, append([]string{types.ExprString(e.Fun)}, wrappers...), ""
+			}
+		}
+
+		if fn := calledFunc(info, e); fn != nil && fn.Pkg() != nil {
+			return qualifiedObjectName(fn) + "()", nil, ""
+		}
+
+		return "", nil, "handler is returned by " + types.ExprString(e.Fun) + ", which cannot be resolved"
+	}
+
+	return "", nil, "handler " + types.ExprString(expr) + " cannot be resolved"
+}
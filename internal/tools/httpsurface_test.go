@@ -0,0 +1,136 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// chiStub is a chi-style router without the standard library, so that the fixture type-checks offline.
+const chiStub = `package chi
+
+type Handler interface{ Serve() }
+
+type HandlerFunc func()
+
+func (f HandlerFunc) Serve() { f() }
+
+type Middleware func(Handler) Handler
+
+type Router interface {
+	Use(middlewares ...Middleware)
+	With(middlewares ...Middleware) Router
+	Get(pattern string, h HandlerFunc)
+	Post(pattern string, h HandlerFunc)
+	Method(method, pattern string, h HandlerFunc)
+	Route(pattern string, fn func(r Router)) Router
+	Group(fn func(r Router)) Router
+	Mount(pattern string, h Handler)
+}
+
+type Mux struct{}
+
+func NewRouter() *Mux { return &Mux{} }
+
+func (m *Mux) Use(middlewares ...Middleware)                  {}
+func (m *Mux) With(middlewares ...Middleware) Router          { return m }
+func (m *Mux) Get(pattern string, h HandlerFunc)              {}
+func (m *Mux) Post(pattern string, h HandlerFunc)             {}
+func (m *Mux) Method(method, pattern string, h HandlerFunc)   {}
+func (m *Mux) Route(pattern string, fn func(r Router)) Router { return m }
+func (m *Mux) Group(fn func(r Router)) Router                 { return m }
+func (m *Mux) Mount(pattern string, h Handler)                {}
+`
+
+func TestAnalyzeHTTPSurface(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"chi/chi.go": chiStub,
+		"app/app.go": `package app
+
+import "lang/chi"
+
+type Server struct{ router *chi.Mux }
+
+func (s *Server) listUsers()  {}
+func (s *Server) createUser() {}
+
+func health() {}
+
+func logger(h chi.Handler) chi.Handler { return h }
+func auth(h chi.Handler) chi.Handler   { return h }
+
+func timed(f chi.HandlerFunc) chi.HandlerFunc { return f }
+
+func admin() chi.Handler { return nil }
+
+func Routes(s *Server, prefix string, dyn chi.HandlerFunc) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(logger)
+	r.Get("/health", health)
+	r.Route("/api", func(r chi.Router) {
+		r.Use(auth)
+		r.Get("/users", s.listUsers)
+		r.With(logger).Post("/users", timed(s.createUser))
+	})
+	r.Mount("/admin", admin())
+	h := chi.HandlerFunc(health)
+	r.Method("delete", prefix+"/cache", h)
+	r.Get("/dyn", dyn)
+	return r
+}
+
+// App embeds the router: its methods are matched by their declaring type.
+type App struct{ *chi.Mux }
+
+func (a App) Register() {
+	a.Group(func(r chi.Router) {
+		r.Get("/inline", func() {})
+	})
+}
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeHTTPSurface(ctx, req, tools.AnalyzeHTTPSurfaceInput{Dir: dir, Frameworks: []string{"lang/chi"}})
+	if err != nil {
+		t.Fatalf("AnalyzeHTTPSurface: %v", err)
+	}
+
+	var routes []string
+	for _, r := range out.Routes {
+		routes = append(routes, fmt.Sprintf("%s %s %s %v %s:%d", r.Method, r.Path, r.Handler, r.Middlewares, r.File, r.Line))
+	}
+
+	want := []string{
+		"ANY /admin/* lang/app.admin() [logger] app/app.go:28",
+		"GET /api/users lang/app.Server.listUsers [logger auth] app/app.go:25",
+		"POST /api/users lang/app.Server.createUser [logger auth logger timed] app/app.go:26",
+		"GET /health lang/app.health [logger] app/app.go:22",
+		"GET /inline func literal [] app/app.go:40",
+		"DELETE {prefix + \"/cache\"} lang/app.health [logger] app/app.go:30",
+	}
+	if strings.Join(routes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("routes:\n%s\nwant:\n%s", strings.Join(routes, "\n"), strings.Join(want, "\n"))
+	}
+
+	if out.Total != 6 || !out.Routes[5].Dynamic || out.Routes[0].Dynamic || out.Routes[0].Framework != "lang/chi" {
+		t.Errorf("total = %d, routes = %+v; want 6 with only the cache route dynamic", out.Total, out.Routes)
+	}
+
+	if len(out.Unresolved) != 1 || out.Unresolved[0].Path != "/dyn" || out.Unresolved[0].Registration != "r.Get" ||
+		!strings.Contains(out.Unresolved[0].Reason, "dyn") {
+		t.Errorf("unresolved = %+v, want the /dyn registration with a parameter handler", out.Unresolved)
+	}
+
+	// Without the stub among the frameworks nothing is a registration.
+	_, out, err = tools.AnalyzeHTTPSurface(ctx, req, tools.AnalyzeHTTPSurfaceInput{Dir: dir})
+	if err != nil || out.Total != 0 || len(out.Unresolved) != 0 || len(out.Frameworks) != 4 {
+		t.Errorf("default frameworks: %+v, %v; want no routes", out, err)
+	}
+}
@@ -0,0 +1,490 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Naming rules checked by SuggestIdentifierRenames, applied in this order.
+const (
+	ruleUnderscore  = "underscore"
+	ruleInitialisms = "initialisms"
+	ruleHungarian   = "hungarian"
+	ruleStutter     = "stutter"
+)
+
+var identifierRules = []string{ruleUnderscore, ruleInitialisms, ruleHungarian, ruleStutter}
+
+// commonInitialisms are the initialisms Go names spell in a single case, as listed by golint.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true,
+	"GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true,
+	"LHS": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true,
+	"SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// hungarianPrefixes are the type prefixes of Hungarian notation, longest first, with the types they
+// announce.
+var hungarianPrefixes = []struct {
+	prefix string
+	match  func(types.Type) bool
+}{
+	{"str", isStringType}, {"arr", isSequenceType}, {"lst", isSequenceType}, {"int", isIntegerType},
+	{"ptr", isPointerType}, {"map", isMapType}, {"flt", isFloatType}, {"sz", isStringType},
+	{"s", isStringType}, {"i", isIntegerType}, {"b", isBoolType}, {"f", isFloatType},
+	{"p", isPointerType}, {"m", isMapType},
+}
+
+// SuggestIdentifierRenames finds declared identifiers breaking Go naming conventions (underscores,
+// mixed-case initialisms, Hungarian type prefixes, names repeating their package name) and proposes a
+// name fixing all selected rules at once. Every suggestion is checked for collisions the way renameSymbol
+// checks them and, when clean, carries the renameSymbol input applying it. Struct fields whose json tag
+// repeats their current name get a warning instead: the name likely mirrors an external contract.
+// Generated files and test files are skipped.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, an optional package and the rules to check
+//
+// Returns:
+//   - MCP tool call result
+//   - suggestions in package, file and position order, with reasons, conflicts or warnings
+//   - error if a rule is unknown or packages cannot be loaded
+func SuggestIdentifierRenames(ctx context.Context, _ *mcp.CallToolRequest, input SuggestIdentifierRenamesInput) (
+	*mcp.CallToolResult,
+	SuggestIdentifierRenamesOutput,
+	error,
+) {
+	rules := input.Rules
+	if len(rules) == 0 {
+		rules = identifierRules
+	}
+
+	start := logStart("SuggestIdentifierRenames", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("rules", strings.Join(rules, ",")),
+	))
+	out := SuggestIdentifierRenamesOutput{Suggestions: []IdentifierRenameSuggestion{}}
+
+	defer func() { logEnd("SuggestIdentifierRenames", start, out.Total) }()
+
+	selected := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if !slices.Contains(identifierRules, rule) {
+			return fail(out, invalidInput("unknown rule %q: use initialisms, underscore, hungarian or stutter", rule))
+		}
+
+		selected[rule] = true
+	}
+
+	for _, rule := range identifierRules {
+		if selected[rule] {
+			out.Rules = append(out.Rules, rule)
+		}
+	}
+
+	_, filtered, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "SuggestIdentifierRenames")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	taken := make(map[string]string) // scope key -> old name of the suggestion taking it
+
+	if err := walkPackageFiles(ctx, filtered, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if !hasTypes(pkg) {
+			return nil
+		}
+
+		if _, generated := generatedFileGenerator(file); generated {
+			return nil
+		}
+
+		owners := make(map[*types.Var]*structOwner)
+		named := make(map[*types.Struct]*types.Named)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if tn, ok := pkg.TypesInfo.Defs[n.Name].(*types.TypeName); ok {
+					if nt, ok := tn.Type().(*types.Named); ok {
+						if st, ok := nt.Underlying().(*types.Struct); ok {
+							named[st] = nt
+						}
+					}
+				}
+			case *ast.StructType:
+				if st, ok := pkg.TypesInfo.TypeOf(n).(*types.Struct); ok {
+					for i := range st.NumFields() {
+						owners[st.Field(i)] = &structOwner{st: st, named: named[st], tag: st.Tag(i)}
+					}
+				}
+			case *ast.Ident:
+				obj := pkg.TypesInfo.Defs[n]
+				if obj == nil || !isRenameCandidate(obj) {
+					return true
+				}
+
+				newName, reasons, applied := applyNamingRules(pkg, obj, selected)
+				if len(applied) == 0 {
+					return true
+				}
+
+				posn := pkg.Fset.Position(n.Pos())
+				s := IdentifierRenameSuggestion{
+					Package: normalizePackagePath(pkg),
+					File:    relPath,
+					Line:    posn.Line,
+					Name:    obj.Name(),
+					NewName: newName,
+					Kind:    symbolIDKind(obj),
+					Rules:   applied,
+					Reason:  strings.Join(reasons, "; "),
+				}
+
+				owner := owners[asField(obj)]
+
+				if owner != nil && tagName(reflect.StructTag(owner.tag).Get("json")) == obj.Name() {
+					s.Warning = fmt.Sprintf("json tag %q repeats the field name, which may mirror an external contract; rename by hand if intended",
+						obj.Name())
+				} else {
+					s.Conflict = identifierRenameConflict(pkg, obj, owner, newName, input.Dir, taken)
+				}
+
+				if s.Warning == "" && s.Conflict == "" {
+					s.Rename = &RenameSymbolInput{Dir: input.Dir, SymbolID: symbolID(pkg.TypesInfo, obj), NewName: newName}
+				}
+
+				out.Suggestions = append(out.Suggestions, s)
+			}
+
+			return true
+		})
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.SliceStable(out.Suggestions, func(i, j int) bool {
+		a, b := out.Suggestions[i], out.Suggestions[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	for _, s := range out.Suggestions {
+		if s.Rename != nil {
+			out.Total++
+		}
+	}
+
+	return nil, out, nil
+}
+
+// structOwner is the struct declaring a field, with its named type if any.
+type structOwner struct {
+	st    *types.Struct
+	named *types.Named
+	tag   string
+}
+
+// asField returns obj as a struct field, or nil.
+func asField(obj types.Object) *types.Var {
+	if v, ok := obj.(*types.Var); ok && v.IsField() {
+		return v
+	}
+
+	return nil
+}
+
+// isRenameCandidate reports whether obj is a declaration whose name is chosen by its author: not blank,
+// not an embedded field named after its type, not a package name or label, and not init or main.
+func isRenameCandidate(obj types.Object) bool {
+	name := obj.Name()
+	if name == "_" || strings.HasPrefix(name, "_") || obj.Pkg() == nil {
+		return false
+	}
+
+	switch o := obj.(type) {
+	case *types.Var:
+		return !o.Embedded()
+	case *types.Func:
+		return o.Parent() != o.Pkg().Scope() || (name != "init" && name != "main")
+	case *types.Const, *types.TypeName:
+		return true
+	}
+
+	return false
+}
+
+// applyNamingRules applies the selected rules to the name of obj in order, returning the fixed name, the
+// reason of each rule that changed it and those rules.
+func applyNamingRules(pkg *packages.Package, obj types.Object, selected map[string]bool) (string, []string, []string) {
+	name := obj.Name()
+
+	var reasons, applied []string
+
+	for _, rule := range identifierRules {
+		if !selected[rule] {
+			continue
+		}
+
+		var (
+			fixed  string
+			reason string
+		)
+
+		switch rule {
+		case ruleUnderscore:
+			fixed, reason = fixUnderscores(name)
+		case ruleInitialisms:
+			fixed, reason = fixInitialisms(name)
+		case ruleHungarian:
+			fixed, reason = fixHungarian(name, obj)
+		case ruleStutter:
+			fixed, reason = fixStutter(name, obj, pkg)
+		}
+
+		if fixed != "" && fixed != name {
+			name = fixed
+			reasons = append(reasons, reason)
+			applied = append(applied, rule)
+		}
+	}
+
+	return name, reasons, applied
+}
+
+// fixUnderscores joins the underscore-separated words of name in MixedCaps, keeping its exportedness:
+// user_name becomes userName, MAX_SIZE becomes MaxSize. All-caps words that are not initialisms are
+// title-cased.
+func fixUnderscores(name string) (string, string) {
+	if !strings.Contains(name, "_") {
+		return "", ""
+	}
+
+	var b strings.Builder
+
+	for i, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' }) {
+		if word == strings.ToUpper(word) && len(word) > 1 && !commonInitialisms[word] {
+			word = word[:1] + strings.ToLower(word[1:])
+		}
+
+		if i > 0 {
+			word = upperFirst(word)
+		}
+
+		b.WriteString(word)
+	}
+
+	return b.String(), "Go names use MixedCaps, not underscores"
+}
+
+// fixInitialisms spells the mixed-case initialisms of name in a single case: GetUserId becomes GetUserID,
+// parseUrls becomes parseURLs. A leading initialism of an unexported name stays lowercase.
+func fixInitialisms(name string) (string, string) {
+	words := splitNameWords(name)
+
+	var fixed []string
+
+	for i, word := range words {
+		stem, plural := word, ""
+		if len(word) > 2 && strings.HasSuffix(word, "s") && !commonInitialisms[strings.ToUpper(word)] {
+			stem, plural = word[:len(word)-1], "s"
+		}
+
+		upper := strings.ToUpper(stem)
+		if !commonInitialisms[upper] || stem == upper || (i == 0 && stem == strings.ToLower(stem)) {
+			continue
+		}
+
+		if i == 0 && !token.IsExported(name) {
+			upper = strings.ToLower(upper)
+		}
+
+		words[i] = upper + plural
+		fixed = append(fixed, fmt.Sprintf("%s should be %s", word, words[i]))
+	}
+
+	if len(fixed) == 0 {
+		return "", ""
+	}
+
+	return strings.Join(words, ""), "initialisms keep a single case: " + strings.Join(fixed, ", ")
+}
+
+// splitNameWords splits a MixedCaps name into words: HTTPServerId gives HTTP, Server, Id.
+func splitNameWords(name string) []string {
+	runes := []rune(name)
+
+	var (
+		words []string
+		begin int
+	)
+
+	for i := 1; i < len(runes); i++ {
+		prev, r := runes[i-1], runes[i]
+
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			words = append(words, string(runes[begin:i]))
+			begin = i
+		}
+	}
+
+	return append(words, string(runes[begin:]))
+}
+
+// fixHungarian drops a type prefix from an unexported variable or constant whose type it announces:
+// strPrefix string becomes prefix, bReady bool becomes ready.
+func fixHungarian(name string, obj types.Object) (string, string) {
+	switch obj.(type) {
+	case *types.Var, *types.Const:
+	default:
+		return "", ""
+	}
+
+	if token.IsExported(name) {
+		return "", ""
+	}
+
+	for _, h := range hungarianPrefixes {
+		rest, ok := strings.CutPrefix(name, h.prefix)
+		if !ok || rest == "" || !unicode.IsUpper([]rune(rest)[0]) || !h.match(obj.Type()) {
+			continue
+		}
+
+		return unexportedName(rest), fmt.Sprintf("prefix %q repeats the type %s", h.prefix, shortTypeString(obj.Type()))
+	}
+
+	return "", ""
+}
+
+// fixStutter drops the package name from the front of an exported package-level name: in package user,
+// UserService becomes Service, as callers already write user.Service.
+func fixStutter(name string, obj types.Object, pkg *packages.Package) (string, string) {
+	pkgName := pkg.Types.Name()
+	if pkgName == "main" || obj.Parent() != pkg.Types.Scope() || !token.IsExported(name) || len(name) <= len(pkgName) {
+		return "", ""
+	}
+
+	if !strings.EqualFold(name[:len(pkgName)], pkgName) || !unicode.IsUpper(rune(name[len(pkgName)])) {
+		return "", ""
+	}
+
+	rest := name[len(pkgName):]
+
+	return rest, fmt.Sprintf("%s.%s stutters; callers would write %s.%s", pkgName, name, pkgName, rest)
+}
+
+// identifierRenameConflict explains why obj cannot be renamed to newName, or returns "". The scope of
+// each clean suggestion is recorded in taken, so two suggestions never claim one name.
+func identifierRenameConflict(pkg *packages.Package, obj types.Object, owner *structOwner, newName, dir string, taken map[string]string) string {
+	var scopeKey string
+
+	switch {
+	case token.IsKeyword(newName):
+		return fmt.Sprintf("%s is a Go keyword", newName)
+	case owner != nil:
+		if owner.named != nil {
+			if existing, _, _ := types.LookupFieldOrMethod(owner.named, true, pkg.Types, newName); existing != nil {
+				return fmt.Sprintf("%s already has a field or method %s", owner.named.Obj().Name(), newName)
+			}
+		} else {
+			for i := range owner.st.NumFields() {
+				if owner.st.Field(i).Name() == newName {
+					return fmt.Sprintf("the struct already has a field %s", newName)
+				}
+			}
+		}
+
+		scopeKey = fmt.Sprintf("struct %p.%s", owner.st, newName)
+	case obj.Parent() == pkg.Types.Scope():
+		if conflict := unexportConflict(pkg, newName, nil); conflict != "" {
+			return conflict
+		}
+	case obj.Parent() != nil && types.Universe.Lookup(newName) != nil:
+		return fmt.Sprintf("%s would shadow the predeclared identifier", newName)
+	}
+
+	if scopeKey == "" {
+		scopeKey, _ = renameScope(obj, newName)
+	}
+
+	if other, ok := taken[scopeKey]; ok && scopeKey != "" {
+		return fmt.Sprintf("%s is already suggested for %s in the same scope", newName, other)
+	}
+
+	r := &renameRequest{RenamePair: RenamePair{OldName: obj.Name(), NewName: newName}, target: obj, match: obj.Name(), exact: true}
+	if collisions := renameCollisions([]*packages.Package{pkg}, []*renameRequest{r}, dir); len(collisions) > 0 {
+		return collisions[0]
+	}
+
+	taken[scopeKey] = obj.Name()
+
+	return ""
+}
+
+// upperFirst capitalizes the first letter of s.
+func upperFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+
+	return string(runes)
+}
+
+func isFloatType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsFloat != 0
+}
+
+func isBoolType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsBoolean != 0
+}
+
+func isPointerType(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Pointer)
+
+	return ok
+}
+
+func isMapType(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Map)
+
+	return ok
+}
+
+func isSequenceType(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Array:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func writeIdentifierRenamesModule(t *testing.T) string {
+	t.Helper()
+
+	return writeLanguageModule(t, "1.22", map[string]string{
+		"names.go": `package lang
+
+type Record struct {
+	Id      int    ` + "`json:\"Id\"`" + `
+	Url     string ` + "`json:\"url\"`" + `
+	user_id int
+	userId  int
+}
+
+const MAX_SIZE = 10
+
+func parseUrls(strPrefix string, prefix string, bReady bool, sCount int) string {
+	var strLen string
+	_, _ = strLen, sCount
+	if bReady {
+		return prefix
+	}
+	return strPrefix
+}
+`,
+		"user/user.go": `package user
+
+type UserService struct{}
+
+type UserStore struct{}
+
+type Store struct{}
+
+func (s *UserService) GetUserId() int { return 0 }
+`,
+	})
+}
+
+// formatRenameSuggestions renders suggestions as "file:line old->new [rules] outcome".
+func formatRenameSuggestions(suggestions []tools.IdentifierRenameSuggestion) []string {
+	var lines []string
+
+	for _, s := range suggestions {
+		outcome := "rename"
+
+		switch {
+		case s.Warning != "":
+			outcome = "warning"
+		case s.Conflict != "":
+			outcome = "conflict: " + s.Conflict
+		case s.Rename == nil:
+			outcome = "missing rename"
+		}
+
+		lines = append(lines, fmt.Sprintf("%s:%d %s->%s %v %s", s.File, s.Line, s.Name, s.NewName, s.Rules, outcome))
+	}
+
+	return lines
+}
+
+func TestSuggestIdentifierRenames(t *testing.T) {
+	t.Parallel()
+
+	dir := writeIdentifierRenamesModule(t)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.SuggestIdentifierRenames(ctx, req, tools.SuggestIdentifierRenamesInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("SuggestIdentifierRenames: %v", err)
+	}
+
+	want := []string{
+		"names.go:4 Id->ID [initialisms] warning",
+		"names.go:5 Url->URL [initialisms] rename",
+		"names.go:6 user_id->userID [underscore initialisms] rename",
+		"names.go:7 userId->userID [initialisms] conflict: userID is already suggested for user_id in the same scope",
+		"names.go:10 MAX_SIZE->MaxSize [underscore] rename",
+		"names.go:12 parseUrls->parseURLs [initialisms] rename",
+		`names.go:12 strPrefix->prefix [hungarian] conflict: cannot rename "strPrefix" to "prefix": "prefix" is already declared in the same scope`,
+		"names.go:12 bReady->ready [hungarian] rename",
+		"names.go:13 strLen->len [hungarian] conflict: len would shadow the predeclared identifier",
+		"user/user.go:3 UserService->Service [stutter] rename",
+		"user/user.go:5 UserStore->Store [stutter] conflict: Store is already declared in package user (type)",
+		"user/user.go:9 GetUserId->GetUserID [initialisms] rename",
+	}
+
+	if got := formatRenameSuggestions(out.Suggestions); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("suggestions:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if out.Total != 7 || fmt.Sprint(out.Rules) != "[underscore initialisms hungarian stutter]" {
+		t.Errorf("total = %d, rules = %v, want 7 and all rules", out.Total, out.Rules)
+	}
+
+	// The suggested input applies as is.
+	rename := *out.Suggestions[len(out.Suggestions)-1].Rename
+	rename.DryRun = true
+
+	_, renamed, err := tools.RenameSymbol(ctx, req, rename)
+	if err != nil || len(renamed.Collisions) > 0 || len(renamed.Diffs) != 1 {
+		t.Errorf("RenameSymbol(%+v) = %+v, %v; want one diff", rename, renamed, err)
+	}
+}
+
+func TestSuggestIdentifierRenames_Rules(t *testing.T) {
+	t.Parallel()
+
+	dir := writeIdentifierRenamesModule(t)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.SuggestIdentifierRenames(ctx, req, tools.SuggestIdentifierRenamesInput{
+		Dir:     dir,
+		Package: "lang/user",
+		Rules:   []string{"stutter"},
+	})
+	if err != nil {
+		t.Fatalf("SuggestIdentifierRenames: %v", err)
+	}
+
+	want := []string{
+		"user/user.go:3 UserService->Service [stutter] rename",
+		"user/user.go:5 UserStore->Store [stutter] conflict: Store is already declared in package user (type)",
+	}
+
+	if got := formatRenameSuggestions(out.Suggestions); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("stutter only:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	_, out, err = tools.SuggestIdentifierRenames(ctx, req, tools.SuggestIdentifierRenamesInput{Dir: dir, Rules: []string{"underscore"}})
+	if err != nil || len(out.Suggestions) != 2 || out.Suggestions[0].NewName != "userId" || out.Suggestions[1].NewName != "MaxSize" {
+		t.Errorf("underscore only: %+v, %v; want user_id->userId and MAX_SIZE->MaxSize", out.Suggestions, err)
+	}
+
+	_, _, err = tools.SuggestIdentifierRenames(ctx, req, tools.SuggestIdentifierRenamesInput{Dir: dir, Rules: []string{"camel"}})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for an unknown rule, got %v", err)
+	}
+}
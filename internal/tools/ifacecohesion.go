@@ -0,0 +1,375 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// minCohesionMethods is the method count from which interfaces are analyzed without being named.
+const minCohesionMethods = 4
+
+// maxCohesionSubsets caps the method subsets reported per interface.
+const maxCohesionSubsets = 3
+
+// AnalyzeInterfaceCohesion groups the methods of large interfaces by how their consumers call them. A
+// consumer is a function or method calling, or taking a method value of, at least one method through a
+// value of the interface type, resolved through types.Info.Selections. Methods never called together by
+// any consumer form separate clusters, each a candidate for its own interface; method sets shared by
+// several consumers are reported as subsets ("5 of 7 consumers only use Load, Save"). Calls through other
+// interfaces embedding or embedded in it are not attributed to it.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and an optional interface name
+//
+// Returns:
+//   - MCP tool call result
+//   - per-interface clusters, shared subsets, unused methods and a suggestion
+//   - error if the named interface is not found or packages cannot be loaded
+func AnalyzeInterfaceCohesion(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeInterfaceCohesionInput) (
+	*mcp.CallToolResult,
+	AnalyzeInterfaceCohesionOutput,
+	error,
+) {
+	start := logStart("AnalyzeInterfaceCohesion", logFields(
+		input.Dir,
+		newLogField("interfaceName", input.InterfaceName),
+	))
+	out := AnalyzeInterfaceCohesionOutput{Interfaces: []InterfaceCohesion{}}
+
+	defer func() { logEnd("AnalyzeInterfaceCohesion", start, out.Total) }()
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeSyntaxTypesNamed)
+	if err != nil {
+		logError("AnalyzeInterfaceCohesion", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	ifaces := make(map[*types.TypeName]*cohesionInterface)
+
+	var large []string
+
+	for _, pkg := range pkgs {
+		if !hasTypes(pkg) {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+
+			iface, ok := tn.Type().Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 {
+				continue
+			}
+
+			if iface.NumMethods() >= minCohesionMethods {
+				large = append(large, name)
+			}
+
+			if input.InterfaceName != "" && name != input.InterfaceName ||
+				input.InterfaceName == "" && iface.NumMethods() < minCohesionMethods {
+				continue
+			}
+
+			posn := pkg.Fset.Position(tn.Pos())
+			ci := &cohesionInterface{
+				report: InterfaceCohesion{
+					Interface: name,
+					Package:   normalizePackagePath(pkg),
+					File:      relativePath(input.Dir, posn.Filename),
+					Line:      posn.Line,
+				},
+				consumers: make(map[string]map[string]bool),
+			}
+
+			for i := range iface.NumMethods() {
+				ci.report.Methods = append(ci.report.Methods, iface.Method(i).Name())
+			}
+
+			sort.Strings(ci.report.Methods)
+			ifaces[tn] = ci
+		}
+	}
+
+	if input.InterfaceName != "" && len(ifaces) == 0 {
+		sort.Strings(large)
+
+		return fail(out, notFound(large, "interface %q not found in the module", input.InterfaceName))
+	}
+
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, _ string, _ int) error {
+		if !hasTypes(pkg) {
+			return nil
+		}
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			consumer := normalizePackagePath(pkg) + "." + qualifiedFuncName(fd)
+
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+
+				selection := pkg.TypesInfo.Selections[sel]
+				if selection == nil || selection.Kind() == types.FieldVal {
+					return true
+				}
+
+				named, ok := types.Unalias(selection.Recv()).(*types.Named)
+				if !ok {
+					return true
+				}
+
+				if ci := ifaces[named.Origin().Obj()]; ci != nil {
+					ci.use(consumer, selection.Obj().Name())
+				}
+
+				return true
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, ci := range ifaces {
+		if len(ci.consumers) == 0 && input.InterfaceName == "" {
+			continue
+		}
+
+		report := ci.analyze()
+		if report.Suggestion != "" {
+			out.Total++
+		}
+
+		out.Interfaces = append(out.Interfaces, report)
+	}
+
+	sort.Slice(out.Interfaces, func(i, j int) bool {
+		a, b := out.Interfaces[i], out.Interfaces[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		return a.Interface < b.Interface
+	})
+
+	return nil, out, nil
+}
+
+// cohesionInterface collects the methods each consumer uses through one interface.
+type cohesionInterface struct {
+	report    InterfaceCohesion
+	consumers map[string]map[string]bool // consumer -> methods used
+}
+
+func (ci *cohesionInterface) use(consumer, method string) {
+	if ci.consumers[consumer] == nil {
+		ci.consumers[consumer] = make(map[string]bool)
+	}
+
+	ci.consumers[consumer][method] = true
+}
+
+// analyze fills the clusters, subsets, unused methods and suggestion of the report.
+func (ci *cohesionInterface) analyze() InterfaceCohesion {
+	r := ci.report
+	r.Consumers = len(ci.consumers)
+	r.Clusters = []MethodCluster{}
+
+	consumers := sortedKeys(ci.consumers)
+
+	// Union-find over the methods: a consumer using two methods joins their clusters.
+	parent := make(map[string]string)
+
+	var find func(string) string
+
+	find = func(m string) string {
+		if parent[m] == m {
+			return m
+		}
+
+		parent[m] = find(parent[m])
+
+		return parent[m]
+	}
+
+	for _, c := range consumers {
+		methods := sortedKeys(ci.consumers[c])
+		for _, m := range methods {
+			if _, ok := parent[m]; !ok {
+				parent[m] = m
+			}
+		}
+
+		for _, m := range methods[1:] {
+			if a, b := find(methods[0]), find(m); a != b {
+				parent[max(a, b)] = min(a, b)
+			}
+		}
+	}
+
+	clusters := make(map[string]*MethodCluster)
+
+	for _, m := range r.Methods {
+		if _, used := parent[m]; !used {
+			r.Unused = append(r.Unused, m)
+
+			continue
+		}
+
+		root := find(m)
+		if clusters[root] == nil {
+			clusters[root] = &MethodCluster{}
+		}
+
+		clusters[root].Methods = append(clusters[root].Methods, m)
+	}
+
+	for _, c := range consumers {
+		for m := range ci.consumers[c] {
+			cl := clusters[find(m)]
+			cl.Consumers = append(cl.Consumers, c)
+
+			break
+		}
+	}
+
+	for _, root := range sortedKeys(clusters) {
+		r.Clusters = append(r.Clusters, *clusters[root])
+	}
+
+	r.Subsets = ci.subsets(consumers, len(r.Methods))
+
+	switch {
+	case len(r.Clusters) > 1:
+		var groups []string
+		for _, cl := range r.Clusters {
+			groups = append(groups, fmt.Sprintf("{%s} (%d consumers)", strings.Join(cl.Methods, ", "), len(cl.Consumers)))
+		}
+
+		r.Suggestion = fmt.Sprintf("methods fall into %d groups never called together: %s; consider splitting %s into one interface per group",
+			len(r.Clusters), strings.Join(groups, ", "), r.Interface)
+	case len(r.Subsets) > 0:
+		r.Suggestion = r.Subsets[0].Message
+	}
+
+	return r
+}
+
+// subsets returns the method sets of consumers that cover at least two consumers without being the whole
+// interface, most consumers first, each with the consumers using nothing else. A set covering no more
+// consumers than a smaller set it contains is left out.
+func (ci *cohesionInterface) subsets(consumers []string, methodCount int) []MethodSubset {
+	candidates := make(map[string][]string) // joined method set -> methods
+
+	for _, c := range consumers {
+		methods := sortedKeys(ci.consumers[c])
+		if len(methods) < methodCount {
+			candidates[strings.Join(methods, ",")] = methods
+		}
+	}
+
+	covered := func(methods []string) []string {
+		var users []string
+
+		for _, c := range consumers {
+			inside := true
+
+			for m := range ci.consumers[c] {
+				if !containsMethod(methods, m) {
+					inside = false
+
+					break
+				}
+			}
+
+			if inside {
+				users = append(users, c)
+			}
+		}
+
+		return users
+	}
+
+	var subsets []MethodSubset
+
+	for _, key := range sortedKeys(candidates) {
+		methods := candidates[key]
+		if users := covered(methods); len(users) >= 2 {
+			subsets = append(subsets, MethodSubset{
+				Methods:   methods,
+				Consumers: users,
+				Message: fmt.Sprintf("%d of %d consumers only use %s; consider a smaller interface with just these methods",
+					len(users), len(consumers), strings.Join(methods, "/")),
+			})
+		}
+	}
+
+	sort.SliceStable(subsets, func(i, j int) bool {
+		if len(subsets[i].Consumers) != len(subsets[j].Consumers) {
+			return len(subsets[i].Consumers) > len(subsets[j].Consumers)
+		}
+
+		return len(subsets[i].Methods) < len(subsets[j].Methods)
+	})
+
+	kept := []MethodSubset{}
+
+	for _, s := range subsets {
+		redundant := false
+
+		for _, k := range kept {
+			if len(k.Consumers) == len(s.Consumers) && isMethodSubset(k.Methods, s.Methods) {
+				redundant = true
+
+				break
+			}
+		}
+
+		if !redundant {
+			kept = append(kept, s)
+		}
+	}
+
+	return kept[:min(len(kept), maxCohesionSubsets)]
+}
+
+// containsMethod reports whether the sorted methods contain m.
+func containsMethod(methods []string, m string) bool {
+	i := sort.SearchStrings(methods, m)
+
+	return i < len(methods) && methods[i] == m
+}
+
+// isMethodSubset reports whether every method of sub is in the sorted methods.
+func isMethodSubset(sub, methods []string) bool {
+	for _, m := range sub {
+		if !containsMethod(methods, m) {
+			return false
+		}
+	}
+
+	return true
+}
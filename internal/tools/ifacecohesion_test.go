@@ -0,0 +1,102 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestAnalyzeInterfaceCohesion(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"store/store.go": `package store
+
+type Store interface {
+	Load(key string) string
+	Save(key, value string)
+	Delete(key string)
+	List() []string
+	Close() error
+}
+
+type Small interface {
+	Get() string
+	Put(string)
+}
+`,
+		"app/app.go": `package app
+
+import "lang/store"
+
+func Read(s store.Store) string { return s.Load("a") }
+
+func Write(s store.Store) { s.Save("a", s.Load("b")) }
+
+func Copy(s store.Store) { s.Save("b", s.Load("a")) }
+
+func Cleanup(s store.Store) {
+	for _, k := range s.List() {
+		s.Delete(k)
+	}
+}
+
+func Purge(s store.Store) { s.Delete("x") }
+
+func Fetch(s store.Small) string { return s.Get() }
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeInterfaceCohesion(ctx, req, tools.AnalyzeInterfaceCohesionInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("AnalyzeInterfaceCohesion: %v", err)
+	}
+
+	if out.Total != 1 || len(out.Interfaces) != 1 {
+		t.Fatalf("got %+v, want only Store reported", out)
+	}
+
+	store := out.Interfaces[0]
+	if store.Interface != "Store" || store.Package != "lang/store" || store.File != "store/store.go" || store.Line != 3 ||
+		store.Consumers != 5 || fmt.Sprint(store.Unused) != "[Close]" {
+		t.Errorf("Store = %+v, want 5 consumers and Close unused", store)
+	}
+
+	var clusters []string
+	for _, cl := range store.Clusters {
+		clusters = append(clusters, fmt.Sprint(cl.Methods, cl.Consumers))
+	}
+
+	wantClusters := []string{
+		"[Delete List] [lang/app.Cleanup lang/app.Purge]",
+		"[Load Save] [lang/app.Copy lang/app.Read lang/app.Write]",
+	}
+	if strings.Join(clusters, "\n") != strings.Join(wantClusters, "\n") {
+		t.Errorf("clusters:\n%s\nwant:\n%s", strings.Join(clusters, "\n"), strings.Join(wantClusters, "\n"))
+	}
+
+	if len(store.Subsets) != 2 || !strings.HasPrefix(store.Subsets[0].Message, "3 of 5 consumers only use Load/Save") ||
+		fmt.Sprint(store.Subsets[1].Methods) != "[Delete List]" {
+		t.Errorf("subsets = %+v, want Load/Save (3) then Delete/List (2)", store.Subsets)
+	}
+
+	if !strings.Contains(store.Suggestion, "2 groups") {
+		t.Errorf("suggestion = %q, want a split into 2 groups", store.Suggestion)
+	}
+
+	_, out, err = tools.AnalyzeInterfaceCohesion(ctx, req, tools.AnalyzeInterfaceCohesionInput{Dir: dir, InterfaceName: "Small"})
+	if err != nil || len(out.Interfaces) != 1 || out.Total != 0 || fmt.Sprint(out.Interfaces[0].Unused) != "[Put]" ||
+		len(out.Interfaces[0].Clusters) != 1 {
+		t.Errorf("Small: %+v, %v; want one cluster, Put unused and no suggestion", out, err)
+	}
+
+	_, _, err = tools.AnalyzeInterfaceCohesion(ctx, req, tools.AnalyzeInterfaceCohesionInput{Dir: dir, InterfaceName: "Missing"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound {
+		t.Errorf("expected NOT_FOUND for an unknown interface, got %v", err)
+	}
+}
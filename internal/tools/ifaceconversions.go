@@ -0,0 +1,344 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of FindInterfaceConversions sites.
+const (
+	conversionKindArg       = "arg"
+	conversionKindAssign    = "assign"
+	conversionKindReturn    = "return"
+	conversionKindComposite = "composite"
+)
+
+// FindInterfaceConversions finds the places where a value of a named type (T or *T) is implicitly
+// converted to an interface: passed as an interface parameter (append included), assigned to an
+// interface variable or field, returned as an interface result, or stored as an element, field value
+// or map key/value of a composite literal with an interface type. Untyped nil and values that already
+// have an interface type are not conversions; type parameters are not interfaces.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, type name and optional interface and package filters
+//
+// Returns:
+//   - MCP tool call result
+//   - conversion sites grouped by file
+//   - error if the type is not found or another error occurred
+func FindInterfaceConversions(ctx context.Context, _ *mcp.CallToolRequest, input FindInterfaceConversionsInput) (
+	*mcp.CallToolResult,
+	FindInterfaceConversionsOutput,
+	error,
+) {
+	start := logStart("FindInterfaceConversions", logFields(
+		input.Dir,
+		newLogField("typeName", input.TypeName),
+		newLogField("interfaceName", input.InterfaceName),
+	))
+	out := FindInterfaceConversionsOutput{Groups: []InterfaceConversionGroup{}}
+
+	defer func() { logEnd("FindInterfaceConversions", start, out.Total) }()
+
+	if input.TypeName == "" {
+		return fail(out, invalidInput("typeName is required"))
+	}
+
+	pkgs, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "FindInterfaceConversions")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	targets := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		if tn, ok := pkg.Types.Scope().Lookup(input.TypeName).(*types.TypeName); ok {
+			if _, isIface := tn.Type().Underlying().(*types.Interface); !isIface {
+				targets[objectKey(tn)] = struct{}{}
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return fail(out, missingSymbol(pkgs, input.TypeName, "type", "concrete type %q not found", input.TypeName))
+	}
+
+	if err := checkInterfaceFilter(pkgs, input.InterfaceName); err != nil {
+		return fail(out, err)
+	}
+
+	sites := make(map[string][]InterfaceConversion)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		lines := getFileLines(pkg.Fset, file)
+		qualifier := (*types.Package).Name
+
+		for _, decl := range file.Decls {
+			function := ""
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				function = qualifiedFuncName(fd)
+			}
+
+			for _, c := range collectInterfaceConversions(pkg.TypesInfo, decl, targets) {
+				iface := types.TypeString(c.iface, qualifier)
+				if input.InterfaceName != "" && !matchesInterfaceName(iface, input.InterfaceName) {
+					continue
+				}
+
+				line := pkg.Fset.Position(c.expr.Pos()).Line
+				sites[relPath] = append(sites[relPath], InterfaceConversion{
+					Line:      line,
+					Kind:      c.kind,
+					Interface: iface,
+					Pointer:   c.pointer,
+					Function:  function,
+					Snippet:   extractSnippet(lines, line),
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for file, list := range sites {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Line < list[j].Line })
+
+		out.Groups = append(out.Groups, InterfaceConversionGroup{File: file, Conversions: list})
+		out.Total += len(list)
+	}
+
+	sort.Slice(out.Groups, func(i, j int) bool { return out.Groups[i].File < out.Groups[j].File })
+
+	return nil, out, nil
+}
+
+// interfaceConversion is an expression of a target type used where an interface is expected.
+type interfaceConversion struct {
+	expr    ast.Expr
+	iface   types.Type
+	kind    string
+	pointer bool
+}
+
+// collectInterfaceConversions returns the implicit conversions of target-typed values to interfaces in
+// node, in source order.
+func collectInterfaceConversions(info *types.Info, node ast.Node, targets map[string]struct{}) []interfaceConversion {
+	var (
+		found []interfaceConversion
+		// funcs is the stack of enclosing function signatures, for return statements.
+		funcs []*types.Signature
+		stack []ast.Node
+	)
+
+	check := func(expr ast.Expr, expected types.Type, kind string) {
+		if expr == nil || expected == nil || !isInterfaceType(expected) {
+			return
+		}
+
+		tv, ok := info.Types[expr]
+		if !ok || tv.Type == nil || tv.IsNil() || isInterfaceType(tv.Type) {
+			return
+		}
+
+		t, pointer := tv.Type, false
+		if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+			t, pointer = ptr.Elem(), true
+		}
+
+		if isTargetNamed(namedTypeOf(t), targets) {
+			found = append(found, interfaceConversion{expr: expr, iface: expected, kind: kind, pointer: pointer})
+		}
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == nil {
+			if top := stack[len(stack)-1]; isFuncNode(top) {
+				funcs = funcs[:len(funcs)-1]
+			}
+
+			stack = stack[:len(stack)-1]
+
+			return true
+		}
+
+		stack = append(stack, n)
+
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			var sig *types.Signature
+			if obj := info.Defs[n.Name]; obj != nil {
+				sig, _ = obj.Type().(*types.Signature)
+			}
+
+			funcs = append(funcs, sig)
+		case *ast.FuncLit:
+			sig, _ := info.TypeOf(n).(*types.Signature)
+			funcs = append(funcs, sig)
+		case *ast.CallExpr:
+			for i, arg := range n.Args {
+				check(arg, expectedArgType(info, n, i), conversionKindArg)
+			}
+		case *ast.AssignStmt:
+			if n.Tok == token.ASSIGN && len(n.Lhs) == len(n.Rhs) {
+				for i, rhs := range n.Rhs {
+					check(rhs, info.TypeOf(n.Lhs[i]), conversionKindAssign)
+				}
+			}
+		case *ast.ValueSpec:
+			if n.Type != nil && len(n.Names) == len(n.Values) {
+				for _, value := range n.Values {
+					check(value, info.TypeOf(n.Type), conversionKindAssign)
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(funcs) == 0 || funcs[len(funcs)-1] == nil {
+				return true
+			}
+
+			results := funcs[len(funcs)-1].Results()
+			if results.Len() == len(n.Results) {
+				for i, res := range n.Results {
+					check(res, results.At(i).Type(), conversionKindReturn)
+				}
+			}
+		case *ast.CompositeLit:
+			checkCompositeElements(info, n, func(expr ast.Expr, expected types.Type) {
+				check(expr, expected, conversionKindComposite)
+			})
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// isFuncNode reports whether n pushes a signature on the stack of collectInterfaceConversions.
+func isFuncNode(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.FuncDecl, *ast.FuncLit:
+		return true
+	}
+
+	return false
+}
+
+// isInterfaceType reports whether t is an interface type; type parameters, whose underlying type is
+// their constraint, are not.
+func isInterfaceType(t types.Type) bool {
+	if _, ok := types.Unalias(t).(*types.TypeParam); ok {
+		return false
+	}
+
+	return types.IsInterface(t)
+}
+
+// expectedArgType returns the parameter type the i-th argument of call is assigned to, or nil for
+// conversions, spread variadic arguments and builtins other than append.
+func expectedArgType(info *types.Info, call *ast.CallExpr, i int) types.Type {
+	if tv, ok := info.Types[call.Fun]; ok && tv.IsType() {
+		return nil // T(x) is an explicit conversion
+	}
+
+	if isBuiltinCall(info, call, "append") {
+		if i == 0 || call.Ellipsis.IsValid() {
+			return nil
+		}
+
+		if slice, ok := info.TypeOf(call.Args[0]).Underlying().(*types.Slice); ok {
+			return slice.Elem()
+		}
+
+		return nil
+	}
+
+	sig, ok := info.TypeOf(call.Fun).(*types.Signature)
+	if !ok {
+		return nil
+	}
+
+	params := sig.Params()
+
+	switch {
+	case sig.Variadic() && i >= params.Len()-1:
+		if call.Ellipsis.IsValid() {
+			return nil
+		}
+
+		if slice, ok := params.At(params.Len() - 1).Type().(*types.Slice); ok {
+			return slice.Elem()
+		}
+
+		return nil
+	case i < params.Len():
+		return params.At(i).Type()
+	}
+
+	return nil
+}
+
+// checkCompositeElements calls fn with every element, field value, map key and map value of lit and
+// the type it is stored as.
+func checkCompositeElements(info *types.Info, lit *ast.CompositeLit, fn func(ast.Expr, types.Type)) {
+	t := info.TypeOf(lit)
+	if t == nil {
+		return
+	}
+
+	if ptr, ok := t.Underlying().(*types.Pointer); ok { // elided &T{...} elements
+		t = ptr.Elem()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for i, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					if field, ok := info.Uses[key].(*types.Var); ok {
+						fn(kv.Value, field.Type())
+					}
+				}
+
+				continue
+			}
+
+			if i < u.NumFields() {
+				fn(elt, u.Field(i).Type())
+			}
+		}
+	case *types.Slice:
+		compositeValues(lit, u.Elem(), nil, fn)
+	case *types.Array:
+		compositeValues(lit, u.Elem(), nil, fn)
+	case *types.Map:
+		compositeValues(lit, u.Elem(), u.Key(), fn)
+	}
+}
+
+// compositeValues calls fn with the values (and, for maps, the keys) of a slice, array or map literal.
+func compositeValues(lit *ast.CompositeLit, elem, key types.Type, fn func(ast.Expr, types.Type)) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			fn(elt, elem)
+
+			continue
+		}
+
+		if key != nil {
+			fn(kv.Key, key)
+		}
+
+		fn(kv.Value, elem)
+	}
+}
@@ -0,0 +1,119 @@
+package tools_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const interfaceConversionsSource = `package lang
+
+type Shape interface{ Area() float64 }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Holder struct {
+	Shape Shape
+	Size  float64
+}
+
+func Total(shapes ...Shape) float64 { return 0 }
+
+func Print(v any) {}
+
+var Default Shape = Square{Side: 1}
+
+func New(side float64) Shape {
+	return &Square{Side: side}
+}
+
+func Use(sq Square) {
+	var s Shape
+	s = sq
+	s = nil
+	Print(&sq)
+	Total(sq, s)
+	shapes := []Shape{sq}
+	shapes = append(shapes, sq)
+	_ = Holder{Shape: sq, Size: sq.Side}
+	_ = map[any]Shape{sq: nil}
+	concrete := sq
+	_, _ = concrete, s
+}
+
+func Pass[T any](v T) {}
+
+func Generic(sq Square) { Pass(sq) }
+`
+
+func TestFindInterfaceConversions(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": interfaceConversionsSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.FindInterfaceConversions(ctx, req, tools.FindInterfaceConversionsInput{Dir: dir, TypeName: "Square"})
+	if err != nil {
+		t.Fatalf("FindInterfaceConversions error: %v", err)
+	}
+
+	if len(out.Groups) != 1 || out.Groups[0].File != "lang.go" {
+		t.Fatalf("expected one group for lang.go, got %+v", out.Groups)
+	}
+
+	type site struct {
+		Line      int
+		Kind      string
+		Interface string
+		Pointer   bool
+	}
+
+	var got []site
+	for _, c := range out.Groups[0].Conversions {
+		got = append(got, site{c.Line, c.Kind, c.Interface, c.Pointer})
+	}
+
+	// Untyped nil, values already typed as Shape, := and type parameters are not conversions.
+	want := []site{
+		{18, "assign", "lang.Shape", false},
+		{21, "return", "lang.Shape", true},
+		{26, "assign", "lang.Shape", false},
+		{28, "arg", "any", true},
+		{29, "arg", "lang.Shape", false},
+		{30, "composite", "lang.Shape", false},
+		{31, "arg", "lang.Shape", false},
+		{32, "composite", "lang.Shape", false},
+		{33, "composite", "any", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected conversions:\n got %+v\nwant %+v", got, want)
+	}
+
+	if out.Total != len(want) {
+		t.Errorf("total = %d, want %d", out.Total, len(want))
+	}
+
+	if c := out.Groups[0].Conversions[1]; c.Function != "New" || c.Snippet != "return &Square{Side: side}" {
+		t.Errorf("unexpected function or snippet: %+v", c)
+	}
+
+	_, filtered, err := tools.FindInterfaceConversions(ctx, req,
+		tools.FindInterfaceConversionsInput{Dir: dir, TypeName: "Square", InterfaceName: "any"})
+	if err != nil {
+		t.Fatalf("FindInterfaceConversions (any) error: %v", err)
+	}
+
+	if filtered.Total != 2 {
+		t.Errorf("expected the two conversions to any, got %+v", filtered.Groups)
+	}
+
+	_, _, err = tools.FindInterfaceConversions(ctx, req, tools.FindInterfaceConversionsInput{Dir: dir, TypeName: "Shape"})
+	if err == nil {
+		t.Error("expected an error for an interface typeName")
+	}
+}
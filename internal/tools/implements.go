@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ExplainImplements reports whether a type implements an interface and, if not, which methods are
+// missing or have the wrong signature, together with stub skeletons for the missing ones.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, type name and interface name
+//
+// Returns:
+//   - MCP tool call result
+//   - the receiver kind that satisfies the interface, or missing and mismatched methods
+//   - error if either type is not found or the interface is not a method set
+func ExplainImplements(ctx context.Context, _ *mcp.CallToolRequest, input ExplainImplementsInput) (
+	*mcp.CallToolResult,
+	ExplainImplementsOutput,
+	error,
+) {
+	start := logStart("ExplainImplements", logFields(
+		input.Dir,
+		newLogField("typeName", input.TypeName),
+		newLogField("interfaceName", input.InterfaceName),
+	))
+	out := ExplainImplementsOutput{}
+
+	defer func() { logEnd("ExplainImplements", start, len(out.Missing)+len(out.Mismatched)) }()
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
+	if err != nil {
+		logError("ExplainImplements", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	tn, err := lookupTypeName(pkgs, input.TypeName)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	in, err := lookupTypeName(pkgs, input.InterfaceName)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	iface, ok := in.Type().Underlying().(*types.Interface)
+	if !ok {
+		return fail(out, invalidInput("type %q is not an interface", input.InterfaceName))
+	}
+
+	if !iface.IsMethodSet() {
+		return fail(out, invalidInput("interface %q is a type constraint, not a method set", input.InterfaceName))
+	}
+
+	out.Type = tn.Pkg().Path() + "." + tn.Name()
+	out.Interface = in.Pkg().Path() + "." + in.Name()
+	// Other packages are written by name so that signatures and stubs paste into the type's package.
+	qualifier := func(p *types.Package) string {
+		if p == tn.Pkg() {
+			return ""
+		}
+
+		return p.Name()
+	}
+
+	t := tn.Type()
+	ptr := types.NewPointer(t)
+	_, isIface := t.Underlying().(*types.Interface)
+
+	switch {
+	case types.Implements(t, iface):
+		out.Implements = true
+		out.Receiver = "value"
+	case !isIface && types.Implements(ptr, iface):
+		out.Implements = true
+		out.Receiver = "pointer"
+	}
+
+	if out.Implements {
+		return nil, out, nil
+	}
+
+	// The pointer method set contains every method of T, so it is the one to compare against.
+	target := types.Type(ptr)
+	if isIface {
+		target = t
+	}
+
+	out.Missing, out.Mismatched = compareMethodSet(target, iface, qualifier)
+
+	if named := namedTypeOf(t); named != nil && !isIface && len(out.Missing) > 0 {
+		out.Stubs = methodStubs(named, out.Missing)
+	}
+
+	return nil, out, nil
+}
+
+// compareMethodSet returns the methods of iface that target lacks and those it has with another
+// signature (or as a field), with signatures written using qualifier.
+func compareMethodSet(target types.Type, iface *types.Interface, qualifier types.Qualifier) ([]MethodSignature, []MethodMismatch) {
+	var (
+		missing    []MethodSignature
+		mismatched []MethodMismatch
+	)
+
+	for i := range iface.NumMethods() {
+		want := iface.Method(i)
+		wantSig := methodSignature(want.Name(), want.Signature(), qualifier)
+
+		obj, _, _ := types.LookupFieldOrMethod(target, false, want.Pkg(), want.Name())
+
+		switch have := obj.(type) {
+		case nil:
+			missing = append(missing, MethodSignature{Name: want.Name(), Signature: wantSig})
+		case *types.Func:
+			if !types.Identical(have.Signature(), want.Signature()) {
+				mismatched = append(mismatched, MethodMismatch{
+					Name: want.Name(),
+					Want: wantSig,
+					Have: methodSignature(have.Name(), have.Signature(), qualifier),
+				})
+			}
+		default:
+			mismatched = append(mismatched, MethodMismatch{
+				Name: want.Name(),
+				Want: wantSig,
+				Have: "field " + have.Name() + " " + types.TypeString(have.Type(), qualifier),
+			})
+		}
+	}
+
+	return missing, mismatched
+}
+
+// methodSignature formats a method as "Name(params) results".
+func methodSignature(name string, sig *types.Signature, qualifier types.Qualifier) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(name)
+	types.WriteSignature(&buf, sig, qualifier)
+
+	return buf.String()
+}
+
+// methodStubs renders panic("not implemented") skeletons for the missing methods. The receiver
+// reuses the name and pointer-ness of the type's existing methods, defaulting to a pointer receiver.
+func methodStubs(named *types.Named, missing []MethodSignature) string {
+	recvName, pointer := "", true
+
+	if named.NumMethods() > 0 {
+		recv := named.Method(0).Signature().Recv()
+		_, pointer = types.Unalias(recv.Type()).(*types.Pointer)
+		recvName = recv.Name()
+	}
+
+	if recvName == "" || recvName == "_" {
+		recvName = string(unicode.ToLower([]rune(named.Obj().Name())[0]))
+	}
+
+	recvType := named.Obj().Name()
+	if tparams := named.TypeParams(); tparams.Len() > 0 {
+		names := make([]string, 0, tparams.Len())
+		for i := range tparams.Len() {
+			names = append(names, tparams.At(i).Obj().Name())
+		}
+
+		recvType += "[" + strings.Join(names, ", ") + "]"
+	}
+
+	if pointer {
+		recvType = "*" + recvType
+	}
+
+	var b strings.Builder
+
+	for i, m := range missing {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "func (%s %s) %s {\n\tpanic(\"not implemented\")\n}\n", recvName, recvType, m.Signature)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func explainImplements(t *testing.T, typeName, interfaceName string) tools.ExplainImplementsOutput {
+	t.Helper()
+
+	in := tools.ExplainImplementsInput{Dir: testDir(), TypeName: typeName, InterfaceName: interfaceName}
+
+	_, out, err := tools.ExplainImplements(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ExplainImplements(%s, %s) error: %v", typeName, interfaceName, err)
+	}
+
+	return out
+}
+
+func TestExplainImplements_Receivers(t *testing.T) {
+	t.Parallel()
+
+	if out := explainImplements(t, "MemStore", "Storage"); !out.Implements || out.Receiver != "pointer" {
+		t.Errorf("expected MemStore to implement Storage via pointer receiver, got %+v", out)
+	}
+
+	if out := explainImplements(t, "sample.Job", "Bar"); !out.Implements || out.Receiver != "value" {
+		t.Errorf("expected Job to implement Bar via value receiver, got %+v", out)
+	}
+
+	if out := explainImplements(t, "nopReader", "io.Reader"); !out.Implements || out.Receiver != "pointer" {
+		t.Errorf("expected nopReader to implement io.Reader via pointer receiver, got %+v", out)
+	}
+}
+
+func TestExplainImplements_MissingAndMismatched(t *testing.T) {
+	t.Parallel()
+
+	out := explainImplements(t, "DraftStore", "Storage")
+	if out.Implements {
+		t.Fatalf("expected DraftStore not to implement Storage")
+	}
+
+	if len(out.Missing) != 1 || out.Missing[0].Signature != "Load(key string) (string, error)" {
+		t.Errorf("expected missing Load with full signature, got %+v", out.Missing)
+	}
+
+	if len(out.Mismatched) != 1 {
+		t.Fatalf("expected one mismatched method, got %+v", out.Mismatched)
+	}
+
+	if m := out.Mismatched[0]; m.Want != "Save(key string, value string) error" || m.Have != "Save(key string) error" {
+		t.Errorf("unexpected mismatch: %+v", m)
+	}
+
+	want := "func (d DraftStore) Load(key string) (string, error) {\n\tpanic(\"not implemented\")\n}\n"
+	if out.Stubs != want {
+		t.Errorf("expected stubs %q, got %q", want, out.Stubs)
+	}
+
+	out = explainImplements(t, "Job", "io.Writer")
+	if len(out.Missing) != 1 || !strings.HasPrefix(out.Stubs, "func (j Job) Write(p []byte) (n int, err error)") {
+		t.Errorf("expected a value-receiver Write stub matching Job.Run, got %+v", out)
+	}
+}
+
+func TestExplainImplements_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []tools.ExplainImplementsInput{
+		{Dir: testDir(), TypeName: "NoSuchType", InterfaceName: "Storage"},
+		{Dir: testDir(), TypeName: "MemStore", InterfaceName: "Point"},
+		{Dir: "/nonexistent/directory", TypeName: "MemStore", InterfaceName: "Storage"},
+	} {
+		if _, _, err := tools.ExplainImplements(context.Background(), &mcp.CallToolRequest{}, in); err == nil {
+			t.Errorf("expected error for %+v", in)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	// maxTopImportPaths caps ImportAggregate.TopPaths.
+	maxTopImportPaths = 10
+	// maxTopImporters caps ImportedModule.TopImporters.
+	maxTopImporters = 5
+)
+
+// fileImports holds the distinct import paths of one file.
+type fileImports struct {
+	pkg   string
+	file  string
+	paths []string
+}
+
+// moduleImports accumulates the importers of one external module.
+type moduleImports struct {
+	module *packages.Module
+	paths  map[string]*importers
+	all    importers
+}
+
+// importers collects the files and packages importing a path or module.
+type importers struct {
+	files    map[string]struct{}
+	pkgFiles map[string]int
+}
+
+// add records f as an importer; a file is counted once.
+func (im *importers) add(f fileImports) {
+	if im.files == nil {
+		im.files, im.pkgFiles = make(map[string]struct{}), make(map[string]int)
+	}
+
+	if _, ok := im.files[f.file]; ok {
+		return
+	}
+
+	im.files[f.file] = struct{}{}
+	im.pkgFiles[f.pkg]++
+}
+
+// aggregateImports summarizes the imports of the packages selected by input per external module. Import
+// paths are resolved to modules by a second load of just those paths, in the module's context, so
+// replace directives and vendoring apply; the standard library is what resolves to no module.
+func aggregateImports(ctx context.Context, input ListImportsInput) (*ImportAggregate, error) {
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeBasicSyntaxModule, input.Package, "ListImports")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		files   []fileImports
+		paths   = make(map[string]struct{})
+		modules = make(map[string]struct{})
+	)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if pkg.Module != nil && pkg.Module.Main {
+			modules[pkg.Module.Path] = struct{}{}
+		}
+
+		f := fileImports{pkg: normalizePackagePath(pkg), file: relPath}
+
+		for _, imp := range file.Imports {
+			path := strings.Trim(imp.Path.Value, `"`)
+			if !slices.Contains(f.paths, path) {
+				f.paths = append(f.paths, path)
+				paths[path] = struct{}{}
+			}
+		}
+
+		files = append(files, f)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var external []string
+
+	internal := make(map[string]struct{})
+
+	for _, path := range sortedKeys(paths) {
+		if inModules(path, modules) {
+			internal[path] = struct{}{}
+		} else if path != "C" {
+			external = append(external, path)
+		}
+	}
+
+	resolved, err := resolveImportModules(ctx, input.Dir, external)
+	if err != nil {
+		return nil, err
+	}
+
+	agg := &ImportAggregate{Modules: []ImportedModule{}}
+
+	var internalFiles, stdlibFiles importers
+
+	byModule := make(map[string]*moduleImports)
+	byPath := make(map[string]*importers)
+	stdlib := make(map[string]struct{})
+	unresolved := make(map[string]struct{})
+
+	for _, f := range files {
+		for _, path := range f.paths {
+			if _, ok := internal[path]; ok {
+				internalFiles.add(f)
+
+				continue
+			}
+
+			mod, ok := resolved[path]
+
+			switch {
+			case path == "C" || (ok && mod == nil):
+				stdlib[path] = struct{}{}
+				stdlibFiles.add(f)
+
+				continue
+			case !ok:
+				unresolved[path] = struct{}{}
+
+				continue
+			}
+
+			mi := byModule[mod.Path]
+			if mi == nil {
+				mi = &moduleImports{module: mod, paths: make(map[string]*importers)}
+				byModule[mod.Path] = mi
+			}
+
+			if mi.paths[path] == nil {
+				mi.paths[path] = &importers{}
+				byPath[path] = mi.paths[path]
+			}
+
+			mi.paths[path].add(f)
+			mi.all.add(f)
+		}
+	}
+
+	agg.Internal = ImportCount{Paths: len(internal), Files: len(internalFiles.files)}
+	agg.Stdlib = ImportCount{Paths: len(stdlib), Files: len(stdlibFiles.files)}
+	agg.Unresolved = sortedKeys(unresolved)
+
+	for _, mi := range byModule {
+		agg.Modules = append(agg.Modules, mi.summary())
+	}
+
+	sort.Slice(agg.Modules, func(i, j int) bool {
+		a, b := agg.Modules[i], agg.Modules[j]
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+
+		return a.Module < b.Module
+	})
+
+	agg.TopPaths = rankImportedPaths(byPath)
+	if len(agg.TopPaths) > maxTopImportPaths {
+		agg.TopPaths = agg.TopPaths[:maxTopImportPaths]
+	}
+
+	return agg, nil
+}
+
+// resolveImportModules maps import paths to the module providing them, nil for the standard library.
+// Paths the go command cannot resolve are left out.
+func resolveImportModules(ctx context.Context, dir string, paths []string) (map[string]*packages.Module, error) {
+	resolved := make(map[string]*packages.Module, len(paths))
+	if len(paths) == 0 {
+		return resolved, nil
+	}
+
+	pkgs, _, err := loadPackagesUncached(ctx, dir, loadModeModule, false, nil, paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range pkgs {
+		switch {
+		case pkg.Module != nil:
+			resolved[pkg.PkgPath] = pkg.Module
+		case len(pkg.Errors) == 0:
+			resolved[pkg.PkgPath] = nil
+		}
+	}
+
+	return resolved, nil
+}
+
+// summary converts the accumulated importers of a module into its report entry.
+func (mi *moduleImports) summary() ImportedModule {
+	mod := ImportedModule{
+		Module:   mi.module.Path,
+		Version:  mi.module.Version,
+		Paths:    rankImportedPaths(mi.paths),
+		Files:    len(mi.all.files),
+		Packages: len(mi.all.pkgFiles),
+	}
+
+	if r := mi.module.Replace; r != nil {
+		mod.Replace, mod.Version = r.Path, r.Version
+	}
+
+	for pkg, n := range mi.all.pkgFiles {
+		mod.TopImporters = append(mod.TopImporters, ImporterCount{Package: pkg, Files: n})
+	}
+
+	sort.Slice(mod.TopImporters, func(i, j int) bool {
+		a, b := mod.TopImporters[i], mod.TopImporters[j]
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+
+		return a.Package < b.Package
+	})
+
+	if len(mod.TopImporters) > maxTopImporters {
+		mod.TopImporters = mod.TopImporters[:maxTopImporters]
+	}
+
+	return mod
+}
+
+// rankImportedPaths returns the paths ordered by importing files, then by path.
+func rankImportedPaths(byPath map[string]*importers) []ImportedPath {
+	ranked := make([]ImportedPath, 0, len(byPath))
+
+	for path, im := range byPath {
+		ranked = append(ranked, ImportedPath{Path: path, Files: len(im.files), Packages: len(im.pkgFiles)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Files != ranked[j].Files {
+			return ranked[i].Files > ranked[j].Files
+		}
+
+		return ranked[i].Path < ranked[j].Path
+	})
+
+	return ranked
+}
+
+// inModules reports whether path is a package of one of the modules.
+func inModules(path string, modules map[string]struct{}) bool {
+	for mod := range modules {
+		if path == mod || strings.HasPrefix(path, mod+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,248 @@
+package tools
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultImportWeightTop is the number of heaviest direct imports reported per package.
+const defaultImportWeightTop = 3
+
+// AnalyzeImportWeight reports how much of the dependency tree each module package pulls in, from the
+// loaded import graph without compiling anything: the transitive external (other modules), standard
+// library and total packages and files of its import closure, and its heaviest direct imports. The weight
+// of an import edge is what only that import reaches: the packages of its closure (itself included) that
+// none of the package's other direct imports reach, so replacing or dropping it removes exactly them.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, an optional main package and the edges per package
+//
+// Returns:
+//   - MCP tool call result
+//   - per-package weights and the heaviest import edges ranked across packages
+//   - error if the main package is not found or an error occurred while loading packages
+func AnalyzeImportWeight(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeImportWeightInput) (
+	*mcp.CallToolResult,
+	AnalyzeImportWeightOutput,
+	error,
+) {
+	start := logStart("AnalyzeImportWeight", logFields(
+		input.Dir,
+		newLogField("mainPackage", input.MainPackage),
+		newLogField("top", strconv.Itoa(input.Top)),
+	))
+	out := AnalyzeImportWeightOutput{Packages: []PackageImportWeight{}, Edges: []ImportEdgeWeight{}}
+
+	defer func() { logEnd("AnalyzeImportWeight", start, len(out.Edges)) }()
+
+	if input.Top < 0 {
+		return fail(out, invalidInput("top must be >= 0"))
+	}
+
+	top := input.Top
+	if top == 0 {
+		top = defaultImportWeightTop
+	}
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeImportGraph)
+	if err != nil {
+		logError("AnalyzeImportWeight", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	g := newImportGraph()
+	scope := pkgs
+
+	if input.MainPackage != "" {
+		mainPkg, err := findMainPackage(pkgs, input.MainPackage)
+		if err != nil {
+			return fail(out, err)
+		}
+
+		out.MainPackage = mainPkg.PkgPath
+		scope = []*packages.Package{mainPkg}
+
+		for _, dep := range g.closure(mainPkg) {
+			if isModulePackage(dep) {
+				scope = append(scope, dep)
+			}
+		}
+	}
+
+	for _, pkg := range scope {
+		weight := PackageImportWeight{Package: normalizePackagePath(pkg), DirectImports: len(pkg.Imports)}
+
+		for _, dep := range g.closure(pkg) {
+			weight.TransitivePackages++
+			weight.TransitiveFiles += len(dep.GoFiles)
+
+			switch {
+			case isModulePackage(dep):
+			case dep.Module == nil:
+				weight.StdlibPackages++
+			default:
+				weight.ExternalPackages++
+			}
+		}
+
+		out.Packages = append(out.Packages, weight)
+		out.Edges = append(out.Edges, heaviestImports(g, pkg, top)...)
+	}
+
+	sort.Slice(out.Packages, func(i, j int) bool {
+		a, b := out.Packages[i], out.Packages[j]
+		if a.ExternalPackages != b.ExternalPackages {
+			return a.ExternalPackages > b.ExternalPackages
+		}
+
+		if a.TransitivePackages != b.TransitivePackages {
+			return a.TransitivePackages > b.TransitivePackages
+		}
+
+		return a.Package < b.Package
+	})
+
+	sortImportEdges(out.Edges)
+
+	return nil, out, nil
+}
+
+// findMainPackage returns the package main of pkgs with import path path.
+func findMainPackage(pkgs []*packages.Package, path string) (*packages.Package, error) {
+	var mains []string
+
+	for _, pkg := range pkgs {
+		if pkg.Name != "main" {
+			continue
+		}
+
+		if pkg.PkgPath == path {
+			return pkg, nil
+		}
+
+		mains = append(mains, pkg.PkgPath)
+	}
+
+	sort.Strings(mains)
+
+	return nil, notFound(mains, "main package %q not found in the module", path)
+}
+
+// heaviestImports returns up to top direct imports of pkg by the packages only they reach.
+func heaviestImports(g *importGraph, pkg *packages.Package, top int) []ImportEdgeWeight {
+	reached := make(map[string]int) // package path -> number of direct imports reaching it
+
+	for _, imp := range pkg.Imports {
+		for path := range g.reach(imp) {
+			reached[path]++
+		}
+	}
+
+	var edges []ImportEdgeWeight
+
+	for path, imp := range pkg.Imports {
+		edge := ImportEdgeWeight{From: normalizePackagePath(pkg), Import: path}
+
+		for depPath, dep := range g.reach(imp) {
+			if reached[depPath] != 1 {
+				continue
+			}
+
+			edge.UniquePackages++
+			edge.UniqueFiles += len(dep.GoFiles)
+
+			if !isModulePackage(dep) && dep.Module != nil {
+				edge.UniqueExternalPackages++
+			}
+		}
+
+		if edge.UniquePackages > 0 {
+			edges = append(edges, edge)
+		}
+	}
+
+	sortImportEdges(edges)
+
+	return edges[:min(top, len(edges))]
+}
+
+// sortImportEdges orders edges heaviest first: by unique external packages, unique files, unique
+// packages, then by path.
+func sortImportEdges(edges []ImportEdgeWeight) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.UniqueExternalPackages != b.UniqueExternalPackages {
+			return a.UniqueExternalPackages > b.UniqueExternalPackages
+		}
+
+		if a.UniqueFiles != b.UniqueFiles {
+			return a.UniqueFiles > b.UniqueFiles
+		}
+
+		if a.UniquePackages != b.UniquePackages {
+			return a.UniquePackages > b.UniquePackages
+		}
+
+		if a.From != b.From {
+			return a.From < b.From
+		}
+
+		return a.Import < b.Import
+	})
+}
+
+// isModulePackage reports whether pkg belongs to the main module.
+func isModulePackage(pkg *packages.Package) bool {
+	return pkg.Module != nil && pkg.Module.Main
+}
+
+// importGraph memoizes the transitive import closures of a loaded import graph. go/packages drops the
+// import closing a cycle, so the graph is acyclic.
+type importGraph struct {
+	closures map[*packages.Package]map[string]*packages.Package
+}
+
+func newImportGraph() *importGraph {
+	return &importGraph{closures: make(map[*packages.Package]map[string]*packages.Package)}
+}
+
+// closure returns the packages pkg imports directly or indirectly, keyed by import path.
+func (g *importGraph) closure(pkg *packages.Package) map[string]*packages.Package {
+	if c, ok := g.closures[pkg]; ok {
+		return c
+	}
+
+	c := make(map[string]*packages.Package)
+	g.closures[pkg] = c // an import cycle left in the graph ends here instead of recursing forever
+
+	for path, imp := range pkg.Imports {
+		c[path] = imp
+
+		for depPath, dep := range g.closure(imp) {
+			c[depPath] = dep
+		}
+	}
+
+	return c
+}
+
+// reach returns pkg and its closure, keyed by import path.
+func (g *importGraph) reach(pkg *packages.Package) map[string]*packages.Package {
+	c := g.closure(pkg)
+	r := make(map[string]*packages.Package, len(c)+1)
+
+	for path, dep := range c {
+		r[path] = dep
+	}
+
+	r[normalizePackagePath(pkg)] = pkg
+
+	return r
+}
@@ -0,0 +1,112 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// writeHeavyModule writes example.com/heavy, whose yaml package pulls in a parser, lexer and token chain
+// and whose small package imports nothing, and returns its directory.
+func writeHeavyModule(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":             "module example.com/heavy\n\ngo 1.22\n",
+		"yaml/yaml.go":       "package yaml\n\nimport (\n\t_ \"example.com/heavy/parser\"\n\t_ \"example.com/heavy/token\"\n)\n",
+		"parser/parser.go":   "package parser\n\nimport _ \"example.com/heavy/lexer\"\n",
+		"lexer/lexer.go":     "package lexer\n\nimport _ \"example.com/heavy/token\"\n",
+		"lexer/lexer_gen.go": "package lexer\n",
+		"token/token.go":     "package token\n",
+		"small/small.go":     "package small\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestAnalyzeImportWeight(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"cmd/app/main.go":  "package main\n\nimport (\n\t_ \"lang/heavyuser\"\n\t_ \"lang/light\"\n)\n\nfunc main() {}\n",
+		"light/light.go":   "package light\n\nimport _ \"example.com/heavy/small\"\n",
+		"heavyuser/h.go":   "package heavyuser\n\nimport (\n\t_ \"example.com/heavy/yaml\"\n\t_ \"lang/light\"\n)\n",
+		"unused/unused.go": "package unused\n",
+	})
+
+	goMod := "module lang\n\ngo 1.22\n\nrequire example.com/heavy v0.0.0\n\nreplace example.com/heavy => " + filepath.ToSlash(writeHeavyModule(t)) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeImportWeight(ctx, req, tools.AnalyzeImportWeightInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("AnalyzeImportWeight: %v", err)
+	}
+
+	var packages []string
+	for _, p := range out.Packages {
+		packages = append(packages, fmt.Sprintf("%s ext=%d all=%d files=%d", p.Package, p.ExternalPackages, p.TransitivePackages, p.TransitiveFiles))
+	}
+
+	wantPackages := []string{
+		"lang/cmd/app ext=5 all=7 files=8",
+		"lang/heavyuser ext=5 all=6 files=7",
+		"lang/light ext=1 all=1 files=1",
+		"lang/unused ext=0 all=0 files=0",
+	}
+
+	if fmt.Sprint(packages) != fmt.Sprint(wantPackages) {
+		t.Errorf("packages:\n%s\nwant:\n%s", packages, wantPackages)
+	}
+
+	var edges []string
+	for _, e := range out.Edges {
+		edges = append(edges, fmt.Sprintf("%s -> %s ext=%d all=%d files=%d", e.From, e.Import, e.UniqueExternalPackages, e.UniquePackages, e.UniqueFiles))
+	}
+
+	// lang/light is reached by both imports of cmd/app, so that edge removes nothing.
+	wantEdges := []string{
+		"lang/cmd/app -> lang/heavyuser ext=4 all=5 files=6",
+		"lang/heavyuser -> example.com/heavy/yaml ext=4 all=4 files=5",
+		"lang/heavyuser -> lang/light ext=1 all=2 files=2",
+		"lang/light -> example.com/heavy/small ext=1 all=1 files=1",
+	}
+
+	if fmt.Sprint(edges) != fmt.Sprint(wantEdges) {
+		t.Errorf("edges:\n%s\nwant:\n%s", edges, wantEdges)
+	}
+
+	_, out, err = tools.AnalyzeImportWeight(ctx, req, tools.AnalyzeImportWeightInput{Dir: dir, MainPackage: "lang/cmd/app", Top: 1})
+	if err != nil {
+		t.Fatalf("AnalyzeImportWeight mainPackage: %v", err)
+	}
+
+	if out.MainPackage != "lang/cmd/app" || len(out.Packages) != 3 || len(out.Edges) != 3 || out.Edges[1].Import != "example.com/heavy/yaml" {
+		t.Errorf("mainPackage: %+v, want cmd/app, heavyuser and light with one edge each", out)
+	}
+
+	_, _, err = tools.AnalyzeImportWeight(ctx, req, tools.AnalyzeImportWeightInput{Dir: dir, MainPackage: "lang/light"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound || fmt.Sprint(te.Details.Candidates) != "[lang/cmd/app]" {
+		t.Errorf("expected NOT_FOUND listing lang/cmd/app for a non-main package, got %v", err)
+	}
+}
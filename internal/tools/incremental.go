@@ -0,0 +1,650 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// retiredEntryTTL is how long a package cache entry dropped by a file change can still be patched by
+// updateFile instead of being reloaded.
+const retiredEntryTTL = 2 * time.Minute
+
+// retiredEntry is a package cache entry dropped by file changes, with the files whose events dropped it
+// or arrived since and the generation it was retired at. updateFile revives it only when the file it
+// patches is the only change.
+type retiredEntry struct {
+	item       PackageCacheItem
+	changed    map[string]bool
+	retiredAt  time.Time
+	generation int
+}
+
+// retireEntry moves the entry under key from the package cache to the retired entries. The caller holds
+// the packageCache lock.
+func retireEntry(key string, item PackageCacheItem) {
+	delete(packageCache.pkgs, key)
+	packageCache.retired[key] = retiredEntry{
+		item:       item,
+		changed:    make(map[string]bool),
+		retiredAt:  time.Now(),
+		generation: packageCache.generation,
+	}
+}
+
+// noteRetiredChange records a change of file in every retired entry holding a file of its directory.
+func noteRetiredChange(file string) {
+	dir := filepath.Dir(file)
+
+	packageCache.Lock()
+	defer packageCache.Unlock()
+
+	for _, entry := range packageCache.retired {
+		for f := range entry.item.FileModTime {
+			if filepath.Dir(f) == dir {
+				entry.changed[file] = true
+
+				break
+			}
+		}
+	}
+}
+
+// patchedByUpdate reports whether a watcher event for file, or for the temporary file safeWriteFile
+// renames onto it, belongs to a change updateFile already patched the cache for: the file still has the
+// modification time it had then.
+func patchedByUpdate(file string) bool {
+	file = strings.TrimSuffix(file, ".tmp")
+
+	packageCache.RLock()
+	modTime, ok := packageCache.patched[file]
+	packageCache.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	if st, err := os.Stat(file); err == nil && st.ModTime().Equal(modTime) {
+		return true
+	}
+
+	packageCache.Lock()
+	delete(packageCache.patched, file)
+	packageCache.Unlock()
+
+	return false
+}
+
+// UpdateFile brings the package cache up to date with one changed file without reloading the module.
+// With newContent the file is written first through the audited atomic path. Every cached load holding
+// the file, including one its change just invalidated, is patched: the file is parsed again into the
+// load's file set, its package is type-checked again against the cached packages it imports, and so are
+// the packages of the load importing it directly. Other packages keep their cached syntax and types, and
+// further importers keep referring to the previous types of the changed package. A load whose other files
+// changed too, whose changed package now has different imports or another name, or that holds the file
+// only through cgo is dropped instead and reloaded by the next query.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the file and its optional new content
+//
+// Returns:
+//   - MCP tool call result
+//   - the patched and dropped loads, the packages type-checked again and their errors
+//   - error if the file does not exist or cannot be written
+func UpdateFile(_ context.Context, _ *mcp.CallToolRequest, input UpdateFileInput) (
+	*mcp.CallToolResult,
+	UpdateFileOutput,
+	error,
+) {
+	start := logStart("UpdateFile", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("write", strconv.FormatBool(input.NewContent != "")),
+	))
+	out := UpdateFileOutput{Patched: []string{}, Rechecked: []string{}, Dropped: []string{}, Errors: []BuildError{}}
+
+	defer func() { logEnd("UpdateFile", start, len(out.Rechecked)) }()
+
+	if input.File == "" {
+		return fail(out, invalidInput("file is required"))
+	}
+
+	dir := CanonicalDir(input.Dir)
+
+	path := input.File
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	path = filepath.Join(CanonicalDir(filepath.Dir(path)), filepath.Base(path))
+	out.File = relativePath(dir, path)
+
+	if input.NewContent != "" {
+		unlock := lockModuleForMutation(dir)
+		defer unlock()
+
+		if err := checkExpectedHashes(dir, input.ExpectedHashes); err != nil {
+			return fail(out, err)
+		}
+
+		// Editing a generated file is undone by the next generator run.
+		if existing, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments|parser.PackageClauseOnly); err == nil {
+			if generator, ok := generatedFileGenerator(existing); ok && !input.AllowGenerated {
+				if generator == "" {
+					generator = "its generator"
+				}
+
+				return fail(out, NewToolError(CodeGeneratedFile, fmt.Errorf(
+					"%s is a generated file; change the source of %s and regenerate", out.File, generator)))
+			}
+		}
+
+		if err := safeWriteFile(path, []byte(input.NewContent), fileChange{tool: "updateFile", input: input}); err != nil {
+			logError("UpdateFile", err, "failed to write file")
+
+			return fail(out, err)
+		}
+
+		out.Written = true
+	}
+
+	st, err := os.Stat(path)
+	if err != nil {
+		return fail(out, notFound(nil, "file %q not found: %v", input.File, err))
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	update := updateCachedFile(path, st.ModTime(), src)
+
+	out.Patched = update.patched
+	out.Dropped = update.dropped
+	out.Rechecked = sortedKeys(update.rechecked)
+
+	seen := make(map[string]bool)
+
+	for _, id := range out.Rechecked {
+		pkg := update.rechecked[id]
+		for _, e := range pkg.Errors {
+			if e.Kind != packages.ParseError && e.Kind != packages.TypeError {
+				continue
+			}
+
+			be := newBuildError(dir, pkg, e)
+			if key := fmt.Sprint(be); !seen[key] {
+				seen[key] = true
+
+				out.Errors = append(out.Errors, be)
+			}
+		}
+	}
+
+	if !out.Written {
+		// The watcher events of the change are ignored once the cache is patched.
+		notifyProjectWatchers(path, nil)
+	}
+
+	return nil, out, nil
+}
+
+// cacheUpdate is the outcome of updateCachedFile: labels of the patched loads, labels of the dropped
+// loads with the reason, and the packages type-checked again by ID.
+type cacheUpdate struct {
+	patched   []string
+	dropped   []string
+	rechecked map[string]*packages.Package
+}
+
+// updateCachedFile patches every live or retired cache entry holding path for its new content src with
+// modification time modTime, dropping the entries it cannot patch. A file no entry holds invalidates the
+// caches of its directory as a watcher event would.
+func updateCachedFile(path string, modTime time.Time, src []byte) cacheUpdate {
+	update := cacheUpdate{patched: []string{}, dropped: []string{}, rechecked: make(map[string]*packages.Package)}
+	candidates := make(map[string]PackageCacheItem)
+
+	packageCache.Lock()
+
+	generation := packageCache.generation
+	packageCache.inFlight[generation]++
+
+	defer releaseGeneration(generation)
+
+	for key, item := range packageCache.pkgs {
+		if _, ok := item.FileModTime[path]; ok {
+			candidates[key] = item
+		}
+	}
+
+	for key, entry := range packageCache.retired {
+		if _, ok := entry.item.FileModTime[path]; !ok {
+			continue
+		}
+
+		if _, live := packageCache.pkgs[key]; live {
+			continue
+		}
+
+		delete(entry.changed, path)
+		delete(entry.changed, path+".tmp")
+
+		if len(entry.changed) > 0 {
+			update.dropped = append(update.dropped, entry.item.describe()+": other files changed: "+
+				strings.Join(sortedKeys(entry.changed), ", "))
+			delete(packageCache.retired, key)
+
+			continue
+		}
+
+		candidates[key] = entry.item
+	}
+
+	if len(candidates) > 0 {
+		packageCache.patched[path] = modTime
+	}
+
+	packageCache.Unlock()
+
+	if len(candidates) == 0 {
+		invalidateCachesForFile(path)
+
+		return update
+	}
+
+	patched := make(map[string]PackageCacheItem)
+
+	for _, key := range sortedKeys(candidates) {
+		item := candidates[key]
+
+		others := maps.Clone(item.FileModTime)
+		delete(others, path)
+
+		if isPackageModified(others) {
+			update.dropped = append(update.dropped, item.describe()+": other files changed")
+
+			continue
+		}
+
+		pkgs, rechecked, err := patchPackages(item, path, src)
+		if err != nil {
+			update.dropped = append(update.dropped, item.describe()+": "+err.Error())
+
+			continue
+		}
+
+		for _, pkg := range rechecked {
+			update.rechecked[pkg.ID] = pkg
+		}
+
+		next := item
+		next.Packages = pkgs
+		next.FileModTime = maps.Clone(item.FileModTime)
+		next.FileModTime[path] = modTime
+		next.LastFileCheck = time.Now()
+		next.LastAccess = time.Now()
+		patched[key] = next
+	}
+
+	packageCache.Lock()
+
+	for _, key := range sortedKeys(candidates) {
+		item := candidates[key]
+		live, isLive := packageCache.pkgs[key]
+
+		// A fresh load stored meanwhile already reads the new content.
+		if isLive && !slices.Equal(live.Packages, item.Packages) {
+			delete(packageCache.retired, key)
+
+			continue
+		}
+
+		next, ok := patched[key]
+		if ok && changedSince(item, generation) {
+			update.dropped = append(update.dropped, item.describe()+": files changed during the update")
+			ok = false
+		}
+
+		delete(packageCache.retired, key)
+
+		if !ok {
+			delete(packageCache.pkgs, key)
+
+			continue
+		}
+
+		packageCache.pkgs[key] = next
+		update.patched = append(update.patched, next.describe())
+	}
+
+	packageCache.Unlock()
+
+	dropFileCaches(path)
+
+	for _, item := range candidates {
+		forgetNegativeLookups(item.Dir)
+	}
+
+	return update
+}
+
+// changedSince reports whether a directory of item was invalidated after generation. The watcher events
+// of the patched file itself are ignored by then. The caller holds the packageCache lock.
+func changedSince(item PackageCacheItem, generation int) bool {
+	for file := range item.FileModTime {
+		if packageCache.invalidatedDirs[filepath.Dir(file)] > generation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// patchPackages returns a copy of the packages of item with path parsed again from src and, for typed
+// loads, its packages and their direct importers in item type-checked again, together with the packages
+// checked. Typed loads need not hold Imports: the import graph is read from the cached types. The cached
+// packages are not modified: concurrent readers keep the previous ones.
+func patchPackages(item PackageCacheItem, path string, src []byte) ([]*packages.Package, []*packages.Package, error) {
+	pkgs := slices.Clone(item.Packages)
+
+	var targets []int
+
+	for i, pkg := range pkgs {
+		if slices.Contains(pkg.CompiledGoFiles, path) {
+			targets = append(targets, i)
+		} else if slices.Contains(pkg.GoFiles, path) {
+			return nil, nil, errors.New("the file uses cgo")
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, nil, errors.New("the file is not part of a loaded package")
+	}
+
+	fset := pkgs[targets[0]].Fset
+	if item.Mode&packages.NeedSyntax == 0 || fset == nil {
+		fset = token.NewFileSet()
+	}
+
+	file, err := parser.ParseFile(fset, path, src, parser.AllErrors|parser.ParseComments|parser.SkipObjectResolution)
+	if file == nil {
+		return nil, nil, fmt.Errorf("cannot parse the file: %w", err)
+	}
+
+	var parseErrors []packages.Error
+
+	var list scanner.ErrorList
+	if errors.As(err, &list) {
+		for _, e := range list {
+			parseErrors = append(parseErrors, packages.Error{Pos: e.Pos.String(), Msg: e.Msg, Kind: packages.ParseError})
+		}
+	}
+
+	typed := item.Mode&packages.NeedTypes != 0 && item.Mode&packages.NeedSyntax != 0
+	replaced := make(map[*types.Package]*packages.Package) // previous types -> package checked again
+
+	var rechecked []*packages.Package
+
+	for _, i := range targets {
+		old := pkgs[i]
+
+		name := old.Name
+		if old.Types != nil {
+			name = old.Types.Name()
+		}
+
+		if typed && old.Types == nil {
+			return nil, nil, fmt.Errorf("%s has no cached types", old.ID)
+		}
+
+		if name != "" && file.Name.Name != name {
+			return nil, nil, fmt.Errorf("the package clause changed from %s to %s", name, file.Name.Name)
+		}
+
+		pkg := *old
+		pkg.Errors = keepErrors(old.Errors, path, typed)
+		pkg.Errors = append(pkg.Errors, parseErrors...)
+
+		if item.Mode&packages.NeedSyntax != 0 {
+			pkg.Syntax = slices.Clone(old.Syntax)
+
+			at := slices.IndexFunc(pkg.Syntax, func(f *ast.File) bool { return fset.File(f.Pos()).Name() == path })
+			if at < 0 {
+				return nil, nil, errors.New("the file has no cached syntax")
+			}
+
+			pkg.Syntax[at] = file
+		}
+
+		if added, removed := importChanges(&pkg, file); len(added)+len(removed) > 0 {
+			return nil, nil, fmt.Errorf("the imports of %s changed (added %v, removed %v)", old.ID, added, removed)
+		}
+
+		if typed {
+			pkg.Errors = append(pkg.Errors, recheckPackage(&pkg, replaced)...)
+			rechecked = append(rechecked, &pkg)
+			replaced[old.Types] = &pkg
+		}
+
+		pkg.IllTyped = len(pkg.Errors) > 0
+		pkgs[i] = &pkg
+	}
+
+	if !typed {
+		return pkgs, rechecked, nil
+	}
+
+	// Direct importers are checked dependencies first, so that one importing another sees its new types.
+	pending := make(map[*types.Package]int)
+
+	for i, pkg := range pkgs {
+		if pkg.Types == nil || replaced[pkg.Types] != nil || len(pkg.Syntax) == 0 {
+			continue
+		}
+
+		if slices.ContainsFunc(pkg.Types.Imports(), func(imp *types.Package) bool { return replaced[imp] != nil }) {
+			pending[pkg.Types] = i
+		}
+	}
+
+	for len(pending) > 0 {
+		var ready []int
+
+		for _, i := range pending {
+			waiting := slices.ContainsFunc(pkgs[i].Types.Imports(), func(imp *types.Package) bool {
+				_, ok := pending[imp]
+
+				return ok
+			})
+			if !waiting {
+				ready = append(ready, i)
+			}
+		}
+
+		if len(ready) == 0 {
+			return nil, nil, errors.New("the importers of the file's package form an import cycle")
+		}
+
+		slices.Sort(ready)
+
+		for _, i := range ready {
+			old := pkgs[i]
+
+			pkg := *old
+			pkg.Errors = keepErrors(old.Errors, "", true)
+			pkg.Errors = append(pkg.Errors, recheckPackage(&pkg, replaced)...)
+			pkg.IllTyped = len(pkg.Errors) > 0
+			pkgs[i] = &pkg
+			replaced[old.Types] = &pkg
+			rechecked = append(rechecked, &pkg)
+
+			delete(pending, old.Types)
+		}
+	}
+
+	return pkgs, rechecked, nil
+}
+
+// keepErrors returns the errors of a package that a new check does not report again: all but the type
+// errors when typed, and all but the parse errors of path.
+func keepErrors(errs []packages.Error, path string, typed bool) []packages.Error {
+	var kept []packages.Error
+
+	for _, e := range errs {
+		if typed && e.Kind == packages.TypeError ||
+			path != "" && e.Kind == packages.ParseError && strings.HasPrefix(e.Pos, path+":") {
+			continue
+		}
+
+		kept = append(kept, e)
+	}
+
+	return kept
+}
+
+// importChanges compares the import paths of pkg, whose syntax holds file, with those it was loaded with:
+// its Imports when loaded, otherwise the packages its cached types import. It returns the paths added
+// and, with Imports, the paths removed; a typed package dropping an import is checked again as is.
+func importChanges(pkg *packages.Package, file *ast.File) ([]string, []string) {
+	before := make(map[string]bool)
+
+	switch {
+	case pkg.Imports != nil:
+		for p := range pkg.Imports {
+			before[p] = true
+		}
+	case pkg.Types != nil:
+		for _, imp := range pkg.Types.Imports() {
+			before[imp.Path()] = true
+		}
+	default:
+		return nil, nil
+	}
+
+	files := pkg.Syntax
+	if len(files) == 0 {
+		files = []*ast.File{file}
+	}
+
+	now := make(map[string]bool)
+
+	for _, f := range files {
+		for _, spec := range f.Imports {
+			if p, err := strconv.Unquote(spec.Path.Value); err == nil && p != "C" {
+				now[p] = true
+			}
+		}
+	}
+
+	var added, removed []string
+
+	for _, p := range sortedKeys(now) {
+		if !before[p] {
+			added = append(added, p)
+		}
+	}
+
+	if pkg.Imports != nil && len(pkg.Syntax) > 0 {
+		for _, p := range sortedKeys(before) {
+			if !now[p] {
+				removed = append(removed, p)
+			}
+		}
+	}
+
+	return added, removed
+}
+
+// importerFunc adapts a function to types.Importer.
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// recheckPackage type-checks the syntax of pkg again into new Types and TypesInfo. Imports resolve to the
+// packages its previous types imported, or to the ones checked again in replaced, and pkg.Imports is
+// pointed at the latter. It returns the type errors.
+func recheckPackage(pkg *packages.Package, replaced map[*types.Package]*packages.Package) []packages.Error {
+	imports := make(map[string]*types.Package)
+
+	for _, imp := range pkg.Types.Imports() {
+		imports[imp.Path()] = imp
+		if r := replaced[imp]; r != nil {
+			imports[imp.Path()] = r.Types
+		}
+	}
+
+	if pkg.Imports != nil {
+		pkg.Imports = maps.Clone(pkg.Imports)
+
+		for path, imp := range pkg.Imports {
+			if r := replaced[imp.Types]; r != nil {
+				pkg.Imports[path] = r
+			}
+		}
+	}
+
+	var errs []packages.Error
+
+	cfg := &types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "unsafe" {
+				return types.Unsafe, nil
+			}
+
+			if imp := imports[path]; imp != nil {
+				return imp, nil
+			}
+
+			return nil, fmt.Errorf("no cached types for %q", path)
+		}),
+		Sizes: pkg.TypesSizes,
+		Error: func(err error) {
+			var te types.Error
+			if errors.As(err, &te) {
+				errs = append(errs, packages.Error{Pos: te.Fset.Position(te.Pos).String(), Msg: te.Msg, Kind: packages.TypeError})
+
+				return
+			}
+
+			errs = append(errs, packages.Error{Pos: "-", Msg: err.Error(), Kind: packages.TypeError})
+		},
+	}
+
+	if pkg.Module != nil && pkg.Module.GoVersion != "" {
+		cfg.GoVersion = "go" + pkg.Module.GoVersion
+	}
+
+	pkg.Types = types.NewPackage(pkg.Types.Path(), pkg.Types.Name())
+	pkg.TypesInfo = &types.Info{
+		Types:        make(map[ast.Expr]types.TypeAndValue),
+		Defs:         make(map[*ast.Ident]types.Object),
+		Uses:         make(map[*ast.Ident]types.Object),
+		Implicits:    make(map[ast.Node]types.Object),
+		Instances:    make(map[*ast.Ident]types.Instance),
+		Scopes:       make(map[ast.Node]*types.Scope),
+		Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+		FileVersions: make(map[*ast.File]string),
+	}
+
+	_ = types.NewChecker(cfg, pkg.Fset, pkg.Types, pkg.TypesInfo).Files(pkg.Syntax)
+
+	return errs
+}
@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// cachedPackage returns the package with pkgPath of the cached loads of dir holding file.
+func cachedPackage(t *testing.T, dir, file, pkgPath string) *packages.Package {
+	t.Helper()
+
+	packageCache.RLock()
+	defer packageCache.RUnlock()
+
+	for _, item := range packageCache.pkgs {
+		if _, ok := item.FileModTime[file]; !ok || item.Dir != dir {
+			continue
+		}
+
+		for _, pkg := range item.Packages {
+			if pkg.ID == pkgPath {
+				return pkg
+			}
+		}
+	}
+
+	t.Fatalf("no cached load of %s holds %s", pkgPath, file)
+
+	return nil
+}
+
+// Not parallel: the test counts the loads of the shared package cache.
+func TestUpdateFile_PatchesCachedPackages(t *testing.T) {
+	dir := CanonicalDir(t.TempDir())
+
+	for name, content := range map[string]string{
+		"go.mod":   "module incr\n\ngo 1.22\n",
+		"a/a.go":   "package a\n\nfunc Old() int { return 1 }\n",
+		"b/b.go":   "package b\n\nimport \"incr/a\"\n\nfunc Use() int { return a.Old() }\n",
+		"c/c.go":   "package c\n\nfunc C() {}\n",
+		"c/dep.go": "package c\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+	aFile := filepath.Join(dir, "a", "a.go")
+
+	references := func(ident string) int {
+		t.Helper()
+
+		_, out, err := FindReferences(ctx, req, FindReferencesInput{Dir: dir, Ident: ident})
+		if err != nil {
+			t.Fatalf("FindReferences %s: %v", ident, err)
+		}
+
+		return out.Total
+	}
+
+	if n := references("Old"); n != 2 {
+		t.Fatalf("Old: %d references, want 2", n)
+	}
+
+	c := cachedPackage(t, dir, aFile, "incr/c")
+
+	packageCache.RLock()
+	misses := packageCache.misses
+	packageCache.RUnlock()
+
+	_, out, err := UpdateFile(ctx, req, UpdateFileInput{
+		Dir:        dir,
+		File:       "a/a.go",
+		NewContent: "package a\n\nfunc Old() int { return 1 }\n\nfunc New() int { return Old() }\n",
+	})
+	if err != nil {
+		t.Fatalf("UpdateFile: %v", err)
+	}
+
+	if !out.Written || len(out.Patched) == 0 || len(out.Dropped) != 0 || fmt.Sprint(out.Rechecked) != "[incr/a incr/b]" ||
+		len(out.Errors) != 0 {
+		t.Fatalf("got %+v, want a and b rechecked without errors", out)
+	}
+
+	// The watcher events of the write arrive asynchronously and must leave the patched loads alone.
+	time.Sleep(200 * time.Millisecond)
+
+	if n := references("Old"); n != 3 {
+		t.Errorf("Old after the edit: %d references, want 3", n)
+	}
+
+	if n := references("New"); n != 1 {
+		t.Errorf("New: %d references, want 1", n)
+	}
+
+	packageCache.RLock()
+	reloads := packageCache.misses - misses
+	packageCache.RUnlock()
+
+	if reloads != 0 {
+		t.Errorf("%d reloads after updateFile, want 0", reloads)
+	}
+
+	if cachedPackage(t, dir, aFile, "incr/c") != c {
+		t.Error("the unrelated package c was replaced")
+	}
+
+	// An edit made on disk is picked up without newContent, and its type errors are reported.
+	bFile := filepath.Join(dir, "b", "b.go")
+	if err := os.WriteFile(bFile, []byte("package b\n\nimport \"incr/a\"\n\nfunc Use() string { return a.Old() }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, out, err = UpdateFile(ctx, req, UpdateFileInput{Dir: dir, File: bFile})
+	if err != nil {
+		t.Fatalf("UpdateFile: %v", err)
+	}
+
+	if out.Written || fmt.Sprint(out.Rechecked) != "[incr/b]" || len(out.Errors) != 1 ||
+		out.Errors[0].File != "b/b.go" || out.Errors[0].Line != 5 || out.Errors[0].Kind != buildErrorType {
+		t.Errorf("got %+v, want one type error on b/b.go:5", out)
+	}
+
+	// A new import cannot be patched: the load is dropped and the next query reloads it.
+	_, out, err = UpdateFile(ctx, req, UpdateFileInput{
+		Dir:        dir,
+		File:       "a/a.go",
+		NewContent: "package a\n\nimport \"incr/c\"\n\nfunc Old() int { c.C(); return 1 }\n",
+	})
+	if err != nil {
+		t.Fatalf("UpdateFile: %v", err)
+	}
+
+	if len(out.Patched) != 0 || len(out.Dropped) == 0 {
+		t.Errorf("got %+v, want the load dropped for the new import", out)
+	}
+
+	if n := references("C"); n != 2 {
+		t.Errorf("C after the reload: %d references, want 2", n)
+	}
+
+	_, _, err = UpdateFile(ctx, req, UpdateFileInput{Dir: dir, File: "a/missing.go"})
+	if te := AsToolError(err); te == nil || te.Code != CodeNotFound {
+		t.Errorf("expected NOT_FOUND for a missing file, got %v", err)
+	}
+}
+
+func TestPruneInvalidatedDirs(t *testing.T) {
+	t.Parallel()
+
+	dirs := map[string]int{"a": 3, "b": 5, "c": 7}
+	before := maps.Clone(dirs)
+
+	pruneInvalidatedDirs(dirs, 5)
+
+	if fmt.Sprint(dirs) != "map[c:7]" {
+		t.Errorf("dirs = %v, want only c left", dirs)
+	}
+
+	// A load or update of generation 5 or later sees the same invalidations.
+	for _, generation := range []int{5, 6, 7} {
+		for dir, at := range before {
+			if (at > generation) != (dirs[dir] > generation) {
+				t.Errorf("generation %d: %s invalidated at %d reads %d after pruning", generation, dir, at, dirs[dir])
+			}
+		}
+	}
+}
@@ -0,0 +1,578 @@
+package tools
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// indexArtifactFormat identifies files written by exportIndex.
+const indexArtifactFormat = "go-navigator-index"
+
+// indexArtifactVersion is the layout version of index artifacts; bump it whenever indexArtifact, fileFacts
+// or the analyses stored in it change. Artifacts of other versions are rejected on import.
+const indexArtifactVersion = 3
+
+// Sections of an index artifact.
+const (
+	indexSectionSymbols      = "symbols"
+	indexSectionReferences   = "references"
+	indexSectionDependencies = "dependencies"
+	indexSectionInterfaces   = "interfaces"
+	indexSectionComplexity   = "complexity"
+)
+
+var indexSections = []string{
+	indexSectionSymbols,
+	indexSectionReferences,
+	indexSectionDependencies,
+	indexSectionInterfaces,
+	indexSectionComplexity,
+}
+
+// indexArtifact is the on-disk layout of an exported project index. Paths are relative to Root.
+type indexArtifact struct {
+	Format    string              `json:"format"`
+	Version   int                 `json:"version"`
+	Module    string              `json:"module"`
+	GoVersion string              `json:"goVersion,omitempty"`
+	Root      string              `json:"root"`
+	Created   string              `json:"created"`
+	Sections  []string            `json:"sections"`
+	Files     []indexArtifactFile `json:"files"`
+	// References holds the getReferences answer per identifier, for identifiers resolving to a single symbol.
+	References map[string]indexedReferences `json:"references,omitempty"`
+	// Interfaces holds the getImplementations answer per interface name.
+	Interfaces map[string][]Implementation `json:"interfaces,omitempty"`
+}
+
+// indexedReferences is a stored getReferences answer with the ID of the symbol it resolved to.
+type indexedReferences struct {
+	SymbolID string           `json:"symbolId,omitempty"`
+	Groups   []ReferenceGroup `json:"groups"`
+}
+
+// indexArtifactFile is a source file of the artifact with its content hash and syntax-derived facts.
+type indexArtifactFile struct {
+	Path  string     `json:"path"`
+	Hash  string     `json:"sha256"`
+	Facts *fileFacts `json:"facts,omitempty"`
+}
+
+// importedIndex is an artifact loaded by importIndex, registered under its module root.
+type importedIndex struct {
+	root     string
+	artifact *indexArtifact
+	hashes   map[string]string
+}
+
+var indexImports = struct {
+	sync.Mutex
+
+	byRoot map[string]*importedIndex
+}{
+	byRoot: make(map[string]*importedIndex),
+}
+
+// ExportIndex runs the selected analyses over the module containing dir and writes them, together with
+// a content hash of every source file, to a single versioned JSON artifact (gzip-compressed when outFile
+// ends in ".gz").
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the module directory, the output file and the sections to include
+//
+// Returns:
+//   - MCP tool call result
+//   - the written file with its size and per-section counts
+//   - error if a section is unknown, the module cannot be analyzed or the file cannot be written
+func ExportIndex(ctx context.Context, _ *mcp.CallToolRequest, input ExportIndexInput) (
+	*mcp.CallToolResult,
+	ExportIndexOutput,
+	error,
+) {
+	start := logStart("ExportIndex", logFields(
+		input.Dir,
+		newLogField("outFile", input.OutFile),
+		newLogField("include", strings.Join(input.Include, ",")),
+	))
+	out := ExportIndexOutput{}
+
+	defer func() { logEnd("ExportIndex", start, out.Files) }()
+
+	if input.OutFile == "" {
+		return nil, out, invalidInput("outFile is required")
+	}
+
+	sections := input.Include
+	if len(sections) == 0 {
+		sections = indexSections
+	}
+
+	include := make(map[string]bool, len(sections))
+
+	for _, section := range sections {
+		if !slices.Contains(indexSections, section) {
+			return nil, out, NewToolError(CodeInvalidInput, fmt.Errorf("unknown index section %q", section), indexSections...)
+		}
+
+		include[section] = true
+	}
+
+	root := findModuleRoot(input.Dir)
+	if root == "" {
+		return nil, out, notFound(nil, "no go.mod found for %q", input.Dir)
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	module, goVersion := readGoModInfo(root)
+
+	artifact := &indexArtifact{
+		Format:    indexArtifactFormat,
+		Version:   indexArtifactVersion,
+		Module:    module,
+		GoVersion: goVersion,
+		Root:      filepath.ToSlash(root),
+		Created:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	for _, section := range indexSections {
+		if include[section] {
+			artifact.Sections = append(artifact.Sections, section)
+		}
+	}
+
+	symbolNames := make(map[string]struct{})
+	interfaceNames := make(map[string]struct{})
+
+	if err := walkModuleGoFiles(ctx, root, true, func(p string) error {
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		facts, err := computeFileFacts(p, content)
+		if err != nil {
+			return err
+		}
+
+		for _, sym := range facts.Symbols {
+			switch sym.Kind {
+			case "func", "method", "struct", "interface", "type":
+				symbolNames[sym.Name] = struct{}{}
+			}
+
+			if sym.Kind == "interface" {
+				interfaceNames[sym.Name] = struct{}{}
+			}
+		}
+
+		file := indexArtifactFile{Path: relativePath(root, p), Hash: sha256Hex(content)}
+
+		if include[indexSectionSymbols] || include[indexSectionDependencies] || include[indexSectionComplexity] {
+			if !include[indexSectionSymbols] {
+				facts.Symbols = nil
+			}
+
+			if !include[indexSectionDependencies] {
+				facts.Imports = nil
+			}
+
+			if !include[indexSectionComplexity] {
+				facts.Functions = nil
+			}
+
+			file.Facts = facts
+		}
+
+		artifact.Files = append(artifact.Files, file)
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	if include[indexSectionReferences] {
+		artifact.References = make(map[string]indexedReferences)
+
+		for _, name := range sortedKeys(symbolNames) {
+			_, refs, err := FindReferences(ctx, nil, FindReferencesInput{Dir: root, Ident: name})
+			if err != nil {
+				if shouldStop(ctx) {
+					return fail(out, context.Canceled)
+				}
+
+				// Ambiguous identifiers have no single answer and are left to live analysis.
+				continue
+			}
+
+			artifact.References[name] = indexedReferences{SymbolID: refs.SymbolID, Groups: refs.Groups}
+		}
+	}
+
+	if include[indexSectionInterfaces] {
+		artifact.Interfaces = make(map[string][]Implementation)
+
+		for _, name := range sortedKeys(interfaceNames) {
+			_, impls, err := FindImplementations(ctx, nil, FindImplementationsInput{Dir: root, Name: name})
+			if err != nil {
+				if shouldStop(ctx) {
+					return fail(out, context.Canceled)
+				}
+
+				continue
+			}
+
+			artifact.Interfaces[name] = impls.Implementations
+		}
+	}
+
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	outFile := input.OutFile
+	if !filepath.IsAbs(outFile) {
+		outFile = filepath.Join(input.Dir, outFile)
+	}
+
+	if strings.HasSuffix(outFile, ".gz") {
+		var buf bytes.Buffer
+
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return fail(out, err)
+		}
+
+		if err := zw.Close(); err != nil {
+			return fail(out, err)
+		}
+
+		data = buf.Bytes()
+	}
+
+	if err := writeIndexArtifact(outFile, data); err != nil {
+		return fail(out, err)
+	}
+
+	out.File, _ = filepath.Abs(outFile)
+	out.Version = indexArtifactVersion
+	out.Module = module
+	out.Sections = artifact.Sections
+	out.Files = len(artifact.Files)
+	out.References = len(artifact.References)
+	out.Interfaces = len(artifact.Interfaces)
+	out.Bytes = len(data)
+
+	return nil, out, nil
+}
+
+// writeIndexArtifact atomically writes an artifact to path, creating its directory.
+func writeIndexArtifact(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".index-*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return nil
+}
+
+// ImportIndex loads an artifact written by exportIndex. Facts of files whose content hash still
+// matches answer listSymbols, listImports, getComplexityReport and the summary getProjectSchema while
+// the in-memory cache is cold; other files are analyzed live. Stored references and implementations
+// answer getReferences and getImplementations only while every file of the module still matches.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the artifact and optionally the module directory it applies to
+//
+// Returns:
+//   - MCP tool call result
+//   - the artifact metadata with the number of matching, stale and untracked files
+//   - error if the artifact cannot be read, is malformed or has an unsupported version
+func ImportIndex(ctx context.Context, _ *mcp.CallToolRequest, input ImportIndexInput) (
+	*mcp.CallToolResult,
+	ImportIndexOutput,
+	error,
+) {
+	start := logStart("ImportIndex", logFields(input.Dir, newLogField("file", input.File)))
+	out := ImportIndexOutput{}
+
+	defer func() { logEnd("ImportIndex", start, out.Matching) }()
+
+	if input.File == "" {
+		return nil, out, invalidInput("file is required")
+	}
+
+	artifact, err := readIndexArtifact(input.File)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	root := filepath.FromSlash(artifact.Root)
+
+	if input.Dir != "" {
+		root = findModuleRoot(input.Dir)
+		if root == "" {
+			return nil, out, notFound(nil, "no go.mod found for %q", input.Dir)
+		}
+
+		if root, err = filepath.Abs(root); err != nil {
+			return fail(out, err)
+		}
+	}
+
+	if module, _ := readGoModInfo(root); module != artifact.Module {
+		return nil, out, invalidInput("index artifact is for module %q, but %q contains module %q", artifact.Module, root, module)
+	}
+
+	idx := &importedIndex{root: root, artifact: artifact, hashes: make(map[string]string, len(artifact.Files))}
+
+	// Facts missing a syntax section would answer it with nothing, so partial facts are not used.
+	completeFacts := slices.Contains(artifact.Sections, indexSectionSymbols) &&
+		slices.Contains(artifact.Sections, indexSectionDependencies) &&
+		slices.Contains(artifact.Sections, indexSectionComplexity)
+
+	for _, file := range artifact.Files {
+		if shouldStop(ctx) {
+			return fail(out, context.Canceled)
+		}
+
+		idx.hashes[file.Path] = file.Hash
+
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(file.Path)))
+		if err != nil || sha256Hex(content) != file.Hash {
+			out.Stale = append(out.Stale, file.Path)
+
+			continue
+		}
+
+		out.Matching++
+
+		if completeFacts && file.Facts != nil {
+			diskCache.Lock()
+			diskCache.facts[file.Hash] = file.Facts
+			diskCache.Unlock()
+		}
+	}
+
+	if err := walkModuleGoFiles(ctx, root, true, func(p string) error {
+		if _, ok := idx.hashes[relativePath(root, p)]; !ok {
+			out.Untracked++
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	indexImports.Lock()
+	indexImports.byRoot[root] = idx
+	indexImports.Unlock()
+
+	out.Root = filepath.ToSlash(root)
+	out.Module = artifact.Module
+	out.Version = artifact.Version
+	out.Created = artifact.Created
+	out.Sections = artifact.Sections
+	out.Files = len(artifact.Files)
+	out.Current = len(out.Stale) == 0 && out.Untracked == 0
+
+	return nil, out, nil
+}
+
+// readIndexArtifact reads a plain or gzip-compressed artifact and checks its format and version.
+func readIndexArtifact(path string) (*indexArtifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, invalidInput("index artifact %q is not valid gzip: %v", path, err)
+		}
+
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, invalidInput("index artifact %q is not valid gzip: %v", path, err)
+		}
+	}
+
+	var header struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil || header.Format != indexArtifactFormat {
+		return nil, invalidInput("%q is not a go-navigator index artifact", path)
+	}
+
+	if header.Version != indexArtifactVersion {
+		return nil, invalidInput(
+			"index artifact %q has format version %d, this server reads version %d; re-export it with exportIndex",
+			path, header.Version, indexArtifactVersion,
+		)
+	}
+
+	artifact := &indexArtifact{}
+	if err := json.Unmarshal(data, artifact); err != nil {
+		return nil, invalidInput("index artifact %q is malformed: %v", path, err)
+	}
+
+	return artifact, nil
+}
+
+// importedIndexFor returns the imported artifact of the module containing dir, or nil.
+func importedIndexFor(dir string) *importedIndex {
+	indexImports.Lock()
+	empty := len(indexImports.byRoot) == 0
+	indexImports.Unlock()
+
+	if empty {
+		return nil
+	}
+
+	root := findModuleRoot(dir)
+	if root == "" {
+		return nil
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil
+	}
+
+	indexImports.Lock()
+	defer indexImports.Unlock()
+
+	return indexImports.byRoot[root]
+}
+
+// current reports whether the module's source files are exactly those of the artifact, unchanged.
+// Type-derived sections depend on every file, so they are only used when this holds.
+func (idx *importedIndex) current(ctx context.Context) bool {
+	seen := 0
+
+	err := walkModuleGoFiles(ctx, idx.root, true, func(p string) error {
+		hash, ok := idx.hashes[relativePath(idx.root, p)]
+		if !ok {
+			return errIndexStale
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil || sha256Hex(content) != hash {
+			return errIndexStale
+		}
+
+		seen++
+
+		return nil
+	})
+
+	return err == nil && seen == len(idx.hashes)
+}
+
+var errIndexStale = errors.New("index artifact is stale")
+
+// importedReferences returns the references of ident stored in an imported artifact covering dir, with
+// paths relative to dir, and the ID of the symbol they belong to. It reports false when no current
+// artifact has an answer.
+func importedReferences(ctx context.Context, dir, ident string) ([]locationRecord, string, bool) {
+	idx := importedIndexFor(dir)
+	if idx == nil {
+		return nil, "", false
+	}
+
+	stored, ok := idx.artifact.References[ident]
+	if !ok || !idx.current(ctx) {
+		return nil, "", false
+	}
+
+	records := make([]locationRecord, 0)
+
+	for _, group := range stored.Groups {
+		abs := filepath.Join(idx.root, filepath.FromSlash(group.File))
+
+		for _, ref := range group.References {
+			appendReference(&records, dir, abs, ref.Line, ref.Snippet, ref.Indirect, nil)
+		}
+	}
+
+	countIndexAnswer()
+
+	return records, stored.SymbolID, true
+}
+
+// importedImplementations returns the implementations of name stored in an imported artifact covering
+// dir, with paths relative to dir. It reports false when no current artifact has an answer.
+func importedImplementations(ctx context.Context, dir, name string) ([]Implementation, bool) {
+	idx := importedIndexFor(dir)
+	if idx == nil {
+		return nil, false
+	}
+
+	stored, ok := idx.artifact.Interfaces[name]
+	if !ok || !idx.current(ctx) {
+		return nil, false
+	}
+
+	impls := make([]Implementation, 0, len(stored))
+
+	for _, impl := range stored {
+		impl.File = relativePath(dir, filepath.Join(idx.root, filepath.FromSlash(impl.File)))
+		impls = append(impls, impl)
+	}
+
+	countIndexAnswer()
+
+	return impls, true
+}
+
+func countIndexAnswer() {
+	diskCache.Lock()
+	diskCache.indexAnswers++
+	diskCache.Unlock()
+}
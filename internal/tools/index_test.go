@@ -0,0 +1,212 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func copySample(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy sample: %v", err)
+	}
+
+	return dir
+}
+
+func TestExportImportIndex_AnswersFromArtifact(t *testing.T) {
+	ctx := context.Background()
+	src := copySample(t)
+	artifact := filepath.Join(t.TempDir(), "index.json.gz")
+
+	_, exported, err := tools.ExportIndex(ctx, &mcp.CallToolRequest{}, tools.ExportIndexInput{Dir: src, OutFile: artifact})
+	if err != nil {
+		t.Fatalf("ExportIndex error: %v", err)
+	}
+
+	if exported.Files == 0 || exported.References == 0 || exported.Interfaces == 0 || len(exported.Sections) != 5 {
+		t.Fatalf("unexpected export summary: %+v", exported)
+	}
+
+	data, err := os.ReadFile(artifact)
+	if err != nil || len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatalf("expected a gzip artifact, read err %v", err)
+	}
+
+	_, liveSymbols, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: src})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	_, liveImpls, err := tools.FindImplementations(ctx, &mcp.CallToolRequest{}, tools.FindImplementationsInput{Dir: src, Name: "Fetcher"})
+	if err != nil {
+		t.Fatalf("FindImplementations error: %v", err)
+	}
+
+	_, liveRefs, err := tools.FindReferences(ctx, &mcp.CallToolRequest{}, tools.FindReferencesInput{Dir: src, Ident: "FormatGreeting"})
+	if err != nil {
+		t.Fatalf("FindReferences error: %v", err)
+	}
+
+	dst := copySample(t)
+
+	_, imported, err := tools.ImportIndex(ctx, &mcp.CallToolRequest{}, tools.ImportIndexInput{File: artifact, Dir: dst})
+	if err != nil {
+		t.Fatalf("ImportIndex error: %v", err)
+	}
+
+	if !imported.Current || imported.Matching != exported.Files || imported.Version != exported.Version {
+		t.Fatalf("unexpected import summary: %+v", imported)
+	}
+
+	before := cacheStats(t).IndexAnswers
+
+	_, impls, err := tools.FindImplementations(ctx, &mcp.CallToolRequest{}, tools.FindImplementationsInput{Dir: dst, Name: "Fetcher"})
+	if err != nil {
+		t.Fatalf("FindImplementations from artifact error: %v", err)
+	}
+
+	_, refs, err := tools.FindReferences(ctx, &mcp.CallToolRequest{}, tools.FindReferencesInput{Dir: dst, Ident: "FormatGreeting"})
+	if err != nil {
+		t.Fatalf("FindReferences from artifact error: %v", err)
+	}
+
+	_, symbols, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dst})
+	if err != nil {
+		t.Fatalf("ListSymbols from artifact error: %v", err)
+	}
+
+	if got := cacheStats(t).IndexAnswers - before; got != 3 {
+		t.Fatalf("expected 3 answers from the artifact, got %d", got)
+	}
+
+	if !reflect.DeepEqual(impls, liveImpls) {
+		t.Errorf("implementations differ:\nartifact: %+v\nlive:     %+v", impls, liveImpls)
+	}
+
+	if !reflect.DeepEqual(refs, liveRefs) {
+		t.Errorf("references differ:\nartifact: %+v\nlive:     %+v", refs, liveRefs)
+	}
+
+	if !reflect.DeepEqual(symbols, liveSymbols) {
+		t.Errorf("symbols differ between artifact and live analysis")
+	}
+
+	// A package filter is not stored, so it is answered live.
+	before = cacheStats(t).IndexAnswers
+
+	_, filtered, err := tools.FindReferences(ctx, &mcp.CallToolRequest{}, tools.FindReferencesInput{Dir: dst, Ident: "FormatGreeting", Package: "sample"})
+	if err != nil {
+		t.Fatalf("FindReferences with package error: %v", err)
+	}
+
+	if got := cacheStats(t).IndexAnswers - before; got != 0 || filtered.SymbolID != liveRefs.SymbolID {
+		t.Errorf("package-filtered references: %d artifact answers, symbol %q; want a live answer for %q", got, filtered.SymbolID, liveRefs.SymbolID)
+	}
+
+	waitForHydration(t, dst)
+}
+
+func TestImportIndex_StaleFilesFallBackToLiveAnalysis(t *testing.T) {
+	ctx := context.Background()
+	src := copySample(t)
+	artifact := filepath.Join(t.TempDir(), "index.json")
+
+	if _, _, err := tools.ExportIndex(ctx, &mcp.CallToolRequest{}, tools.ExportIndexInput{Dir: src, OutFile: artifact}); err != nil {
+		t.Fatalf("ExportIndex error: %v", err)
+	}
+
+	dst := copySample(t)
+
+	f, err := os.OpenFile(filepath.Join(dst, "greeting.go"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.WriteString("\nfunc FormatFarewell(name string) string { return \"bye \" + name }\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, imported, err := tools.ImportIndex(ctx, &mcp.CallToolRequest{}, tools.ImportIndexInput{File: artifact, Dir: dst})
+	if err != nil {
+		t.Fatalf("ImportIndex error: %v", err)
+	}
+
+	if imported.Current || !slices.Equal(imported.Stale, []string{"greeting.go"}) {
+		t.Fatalf("expected greeting.go to be stale: %+v", imported)
+	}
+
+	// The cold call is answered per file: matching files from the artifact, greeting.go live.
+	_, symbols, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dst})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	found := false
+
+	for _, group := range symbols.GroupedSymbols {
+		for _, file := range group.Files {
+			for _, sym := range file.Symbols {
+				found = found || sym.Name == "FormatFarewell"
+			}
+		}
+	}
+
+	if !found {
+		t.Fatal("expected the symbol added to a stale file to be listed")
+	}
+
+	before := cacheStats(t).IndexAnswers
+
+	if _, _, err := tools.FindImplementations(ctx, &mcp.CallToolRequest{}, tools.FindImplementationsInput{Dir: dst, Name: "Fetcher"}); err != nil {
+		t.Fatalf("FindImplementations error: %v", err)
+	}
+
+	if got := cacheStats(t).IndexAnswers - before; got != 0 {
+		t.Fatalf("implementations must be analyzed live while a file is stale, got %d artifact answers", got)
+	}
+
+	waitForHydration(t, dst)
+}
+
+func TestImportIndex_RejectsOtherVersions(t *testing.T) {
+	t.Parallel()
+
+	artifact := filepath.Join(t.TempDir(), "index.json")
+	if err := os.WriteFile(artifact, []byte(`{"format":"go-navigator-index","version":999}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := tools.ImportIndex(context.Background(), &mcp.CallToolRequest{}, tools.ImportIndexInput{File: artifact})
+	if te := tools.AsToolError(err); te.Code != tools.CodeInvalidInput || !strings.Contains(te.Message, "version 999") {
+		t.Fatalf("expected INVALID_INPUT naming version 999, got %v", err)
+	}
+}
+
+func TestExportIndex_RejectsUnknownSection(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.ExportIndex(context.Background(), &mcp.CallToolRequest{}, tools.ExportIndexInput{
+		Dir:     testDir(),
+		OutFile: filepath.Join(t.TempDir(), "index.json"),
+		Include: []string{"symbols", "callers"},
+	})
+	if te := tools.AsToolError(err); te.Code != tools.CodeInvalidInput || !slices.Contains(te.Details.Candidates, "references") {
+		t.Fatalf("expected INVALID_INPUT listing the sections, got %v", err)
+	}
+}
@@ -0,0 +1,424 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of analyzeInitDependencies findings.
+const (
+	// initReadBeforeWrite is a read of package state that a package initialized later writes during its
+	// own initialization, so the read does not see the write.
+	initReadBeforeWrite = "read-before-write"
+	// initInitializerRead is a read of another package's variable in a package-level var initializer.
+	initInitializerRead = "initializer-read"
+)
+
+// AnalyzeInitDependencies checks package initialization for order hazards. It computes the order the
+// module's packages are initialized in (imports first, then the lexically smallest import path among the
+// ready packages, as Go 1.21 specifies), collects the writes package-level var initializers and init
+// functions make to other packages' variables, directly or through a function of the variable's package
+// (registration), and reports the reads of such variables from initialization code of a package
+// initialized before the writer. Every read of another module package's variable in a package-level var
+// initializer is reported as well.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory
+//
+// Returns:
+//   - MCP tool call result
+//   - the initialization order and the findings
+//   - error if packages cannot be loaded
+func AnalyzeInitDependencies(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeInitDependenciesInput) (
+	*mcp.CallToolResult,
+	AnalyzeInitDependenciesOutput,
+	error,
+) {
+	start := logStart("AnalyzeInitDependencies", logFields(input.Dir))
+	out := AnalyzeInitDependenciesOutput{Order: []string{}, Findings: []InitDependencyFinding{}}
+
+	defer func() { logEnd("AnalyzeInitDependencies", start, out.Total) }()
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeSyntaxTypesNamed)
+	if err != nil {
+		logError("AnalyzeInitDependencies", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	module := make(map[*types.Package]*packages.Package)
+
+	for _, pkg := range pkgs {
+		if hasTypes(pkg) && !isTestVariant(pkg) {
+			module[pkg.Types] = pkg
+		}
+	}
+
+	out.Order = initOrder(module)
+
+	position := make(map[string]int, len(out.Order))
+	for i, path := range out.Order {
+		position[path] = i + 1
+	}
+
+	writers := packageVarWriters(module)
+	units := initUnits(ctx, input.Dir, module, writers)
+
+	if err := ctx.Err(); err != nil {
+		return fail(out, err)
+	}
+
+	// writes holds the first initialization-time write of each variable by each foreign package.
+	writes := make(map[*types.Var]map[string]initAccess)
+
+	for _, u := range units {
+		for _, w := range u.writes {
+			if writes[w.v] == nil {
+				writes[w.v] = make(map[string]initAccess)
+			}
+
+			if _, ok := writes[w.v][u.pkg.PkgPath]; !ok {
+				writes[w.v][u.pkg.PkgPath] = w
+			}
+		}
+	}
+
+	for _, u := range units {
+		pos := position[u.pkg.PkgPath]
+
+		for _, r := range u.reads {
+			finding := InitDependencyFinding{
+				Var:            u.name,
+				Package:        u.pkg.PkgPath,
+				Position:       pos,
+				File:           r.file,
+				Line:           r.line,
+				Symbol:         r.v.Pkg().Name() + "." + r.v.Name(),
+				SymbolPackage:  r.v.Pkg().Path(),
+				SymbolPosition: position[r.v.Pkg().Path()],
+			}
+
+			for _, writer := range sortedKeys(writes[r.v]) {
+				if writer == u.pkg.PkgPath || position[writer] <= pos {
+					continue
+				}
+
+				w := writes[r.v][writer]
+				hazard := finding
+				hazard.Kind = initReadBeforeWrite
+				hazard.Writer, hazard.WriterPosition = writer, position[writer]
+				hazard.WriterFile, hazard.WriterLine, hazard.WriterVia = w.file, w.line, w.via
+				hazard.Message = fmt.Sprintf("%s reads %s during initialization (position %d), before %s writes it (position %d)",
+					u.name, finding.Symbol, pos, writer, position[writer])
+				out.Findings = append(out.Findings, hazard)
+			}
+
+			if u.initializer {
+				finding.Kind = initInitializerRead
+				finding.Message = fmt.Sprintf("initializer of %s reads %s of package %s", u.name, finding.Symbol, finding.SymbolPackage)
+				out.Findings = append(out.Findings, finding)
+			}
+		}
+	}
+
+	sort.SliceStable(out.Findings, func(i, j int) bool {
+		a, b := out.Findings[i], out.Findings[j]
+		if a.Kind != b.Kind {
+			return a.Kind == initReadBeforeWrite
+		}
+
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	out.Total = len(out.Findings)
+
+	return nil, out, nil
+}
+
+// initOrder returns the import paths of the module packages in initialization order: a package follows
+// every module package it imports, and among the packages ready next the lexically smallest path goes
+// first. Packages of an import cycle, which the go command rejects, are appended by path.
+func initOrder(module map[*types.Package]*packages.Package) []string {
+	pending := make(map[string][]string, len(module))
+	for _, pkg := range module {
+		pending[pkg.PkgPath] = nil
+	}
+
+	for _, pkg := range module {
+		for _, imp := range pkg.Types.Imports() {
+			if _, ok := pending[imp.Path()]; ok {
+				pending[pkg.PkgPath] = append(pending[pkg.PkgPath], imp.Path())
+			}
+		}
+	}
+
+	order := make([]string, 0, len(pending))
+	done := make(map[string]bool, len(pending))
+
+	for len(pending) > 0 {
+		next := ""
+
+		for _, path := range sortedKeys(pending) {
+			ready := true
+
+			for _, imp := range pending[path] {
+				if !done[imp] {
+					ready = false
+
+					break
+				}
+			}
+
+			if ready {
+				next = path
+
+				break
+			}
+		}
+
+		if next == "" {
+			next = sortedKeys(pending)[0]
+		}
+
+		order = append(order, next)
+		done[next] = true
+		delete(pending, next)
+	}
+
+	return order
+}
+
+// packageVarWriters maps the functions of the module, by funcKey, to the package-level variables of
+// their own package their bodies assign, so a call during initialization counts as a write of them.
+func packageVarWriters(module map[*types.Package]*packages.Package) map[string][]*types.Var {
+	writers := make(map[string][]*types.Var)
+
+	for _, pkg := range module {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok || fd.Body == nil {
+					continue
+				}
+
+				fn, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+				if !ok {
+					continue
+				}
+
+				seen := make(map[*types.Var]bool)
+
+				ast.Inspect(fd.Body, func(n ast.Node) bool {
+					for _, lhs := range assignedExprs(n) {
+						if v, _ := packageVarRoot(pkg.TypesInfo, lhs); v != nil && v.Pkg() == pkg.Types && !seen[v] {
+							seen[v] = true
+							writers[funcKey(fn)] = append(writers[funcKey(fn)], v)
+						}
+					}
+
+					return true
+				})
+			}
+		}
+	}
+
+	return writers
+}
+
+// initAccess is a read or write of a package-level variable during initialization.
+type initAccess struct {
+	v    *types.Var
+	file string
+	line int
+	// via - function of the variable's package whose call writes it, empty for a direct write
+	via string
+}
+
+// initUnit is code run during package initialization: one init function or the initializer of one
+// package-level var, with the accesses it makes to other module packages' variables.
+type initUnit struct {
+	pkg *packages.Package
+	// name - the initialized variables, or "init"
+	name        string
+	initializer bool
+	reads       []initAccess
+	writes      []initAccess
+}
+
+// initUnits collects the init functions and package-level var initializers of the module packages with
+// their foreign variable accesses. Function literals run only when called in place, so others are skipped.
+func initUnits(ctx context.Context, dir string, module map[*types.Package]*packages.Package, writers map[string][]*types.Var) []*initUnit {
+	var units []*initUnit
+
+	for _, pkg := range module {
+		for _, file := range pkg.Syntax {
+			if shouldStop(ctx) {
+				return units
+			}
+
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Recv == nil && d.Name.Name == "init" && d.Body != nil {
+						u := &initUnit{pkg: pkg, name: "init"}
+						u.collect(dir, module, writers, d.Body)
+						units = append(units, u)
+					}
+				case *ast.GenDecl:
+					if d.Tok != token.VAR {
+						continue
+					}
+
+					for _, spec := range d.Specs {
+						vs := spec.(*ast.ValueSpec)
+
+						for i, value := range vs.Values {
+							names := vs.Names
+							if len(vs.Values) == len(vs.Names) {
+								names = vs.Names[i : i+1]
+							}
+
+							u := &initUnit{pkg: pkg, name: identNames(names), initializer: true}
+							u.collect(dir, module, writers, value)
+							units = append(units, u)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return units
+}
+
+// collect records the accesses of node to package-level variables of other module packages.
+func (u *initUnit) collect(dir string, module map[*types.Package]*packages.Package, writers map[string][]*types.Var, node ast.Node) {
+	info := u.pkg.TypesInfo
+	written := make(map[*ast.Ident]bool)
+	called := make(map[*ast.FuncLit]bool)
+
+	foreign := func(v *types.Var) bool {
+		if v == nil || v.Pkg() == u.pkg.Types {
+			return false
+		}
+
+		_, ok := module[v.Pkg()]
+
+		return ok
+	}
+
+	access := func(v *types.Var, pos token.Pos, via string) initAccess {
+		p := u.pkg.Fset.Position(pos)
+
+		return initAccess{v: v, file: relativePath(dir, p.Filename), line: p.Line, via: via}
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return called[n]
+		case *ast.CallExpr:
+			if lit, ok := ast.Unparen(n.Fun).(*ast.FuncLit); ok {
+				called[lit] = true
+			}
+
+			if fn := calledFunc(info, n); fn != nil && fn.Pkg() != u.pkg.Types {
+				for _, v := range writers[funcKey(fn)] {
+					if foreign(v) {
+						u.writes = append(u.writes, access(v, n.Pos(), fn.Pkg().Name()+"."+fn.Name()))
+					}
+				}
+			}
+		case *ast.Ident:
+			if v, ok := info.Uses[n].(*types.Var); ok && !written[n] && v.Parent() == v.Pkg().Scope() && foreign(v) {
+				u.reads = append(u.reads, access(v, n.Pos(), ""))
+			}
+		}
+
+		for _, lhs := range assignedExprs(n) {
+			if v, ident := packageVarRoot(info, lhs); v != nil {
+				written[ident] = true
+
+				if foreign(v) {
+					u.writes = append(u.writes, access(v, lhs.Pos(), ""))
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// assignedExprs returns the expressions an assignment or increment statement writes to.
+func assignedExprs(n ast.Node) []ast.Expr {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok != token.DEFINE {
+			return n.Lhs
+		}
+	case *ast.IncDecStmt:
+		return []ast.Expr{n.X}
+	}
+
+	return nil
+}
+
+// packageVarRoot returns the package-level variable an assigned expression writes into (v, v.f, v[k],
+// *v, pkg.V[k], ...) with the identifier naming it; nil when the root is not a package-level variable.
+func packageVarRoot(info *types.Info, expr ast.Expr) (*types.Var, *ast.Ident) {
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			v, ok := info.Uses[e].(*types.Var)
+			if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+				return nil, nil
+			}
+
+			return v, e
+		case *ast.SelectorExpr:
+			if x, ok := e.X.(*ast.Ident); ok {
+				if _, ok := info.Uses[x].(*types.PkgName); ok {
+					expr = e.Sel
+
+					continue
+				}
+			}
+
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// identNames joins the names of identifiers with commas.
+func identNames(idents []*ast.Ident) string {
+	names := make([]string, len(idents))
+	for i, id := range idents {
+		names[i] = id.Name
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,92 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestAnalyzeInitDependencies(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"registry/registry.go": `package registry
+
+var Handlers = map[string]func() string{}
+
+var Names []string
+
+func Register(name string, h func() string) {
+	Handlers[name] = h
+	Names = append(Names, name)
+}
+`,
+		"plugin/plugin.go": `package plugin
+
+import "lang/registry"
+
+func init() {
+	registry.Handlers["direct"] = hello
+}
+
+func init() {
+	registry.Register("hello", hello)
+}
+
+func hello() string { return "hello" }
+`,
+		"app/app.go": `package app
+
+import "lang/registry"
+
+var Count = len(registry.Handlers)
+
+var lookup = func() int { return len(registry.Names) }
+
+func init() {
+	_ = registry.Names
+}
+`,
+		"zeta/zeta.go": `package zeta
+
+import "lang/registry"
+
+var Total = len(registry.Handlers)
+`,
+	})
+
+	_, out, err := tools.AnalyzeInitDependencies(context.Background(), &mcp.CallToolRequest{}, tools.AnalyzeInitDependenciesInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("AnalyzeInitDependencies: %v", err)
+	}
+
+	if got := strings.Join(out.Order, " "); got != "lang/registry lang/app lang/plugin lang/zeta" {
+		t.Errorf("order = %s", got)
+	}
+
+	var got []string
+	for _, f := range out.Findings {
+		got = append(got, fmt.Sprintf("%s %s@%d %s:%d %s@%d %s@%d %s:%d %s",
+			f.Kind, f.Var, f.Position, f.File, f.Line, f.Symbol, f.SymbolPosition, f.Writer, f.WriterPosition, f.WriterFile, f.WriterLine, f.WriterVia))
+	}
+
+	// The uncalled function literal of lookup does not run during initialization; zeta is initialized
+	// after plugin, so it only reads in an initializer.
+	want := []string{
+		"read-before-write Count@2 app/app.go:5 registry.Handlers@1 lang/plugin@3 plugin/plugin.go:6 ",
+		"read-before-write init@2 app/app.go:10 registry.Names@1 lang/plugin@3 plugin/plugin.go:10 registry.Register",
+		"initializer-read Count@2 app/app.go:5 registry.Handlers@1 @0 :0 ",
+		"initializer-read Total@4 zeta/zeta.go:5 registry.Handlers@1 @0 :0 ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if out.Total != 4 {
+		t.Errorf("total = %d, want 4", out.Total)
+	}
+}
@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// maxNodeTextLen caps the compact rendering of a node returned by inspectNode.
+const maxNodeTextLen = 120
+
+// InspectNode returns the chain of syntax nodes from the file root to the innermost node at a position,
+// each with its kind, a distinguishing label, its span and a compact rendering. It works on the cached
+// parse and never renders more than maxNodeTextLen characters of a node.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the file, line and column, and the depth limit
+//
+// Returns:
+//   - MCP tool call result
+//   - the node chain, outermost first
+//   - error if the position is invalid, the file is not in the module or packages cannot be loaded
+func InspectNode(ctx context.Context, _ *mcp.CallToolRequest, input InspectNodeInput) (
+	*mcp.CallToolResult,
+	InspectNodeOutput,
+	error,
+) {
+	start := logStart("InspectNode", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("line", strconv.Itoa(input.Line)),
+		newLogField("column", strconv.Itoa(input.Column)),
+	))
+	out := InspectNodeOutput{}
+
+	defer func() { logEnd("InspectNode", start, len(out.Nodes)) }()
+
+	if input.File == "" {
+		return fail(out, invalidInput("file is required"))
+	}
+
+	if input.Line < 1 || input.Column < 0 || input.Depth < 0 {
+		return fail(out, invalidInput("line must be positive, column and depth must not be negative"))
+	}
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, loadModeBasicSyntax)
+	if err != nil {
+		logError("InspectNode", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	files, err := positionFiles(ctx, pkgs, input.Dir)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	pf, err := matchPositionFile(files, input.File)
+	if err != nil {
+		return fail(out, notFound(nil, "%v", err))
+	}
+
+	lines := getFileLines(pf.pkg.Fset, pf.file)
+
+	pos, err := pf.pos(lines, input.Line, input.Column)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	path, _ := astutil.PathEnclosingInterval(pf.file, pos, pos)
+
+	if input.ParentOf {
+		path = enclosingStatementPath(path)
+	}
+
+	if input.Depth > 0 && len(path) > input.Depth {
+		path, out.Truncated = path[:input.Depth], true
+	}
+
+	out.File = pf.relPath
+
+	kinds := make([]string, 0, len(path))
+
+	for i := len(path) - 1; i >= 0; i-- {
+		node := describeNode(pf.pkg.Fset, lines, path[i])
+		out.Nodes = append(out.Nodes, node)
+
+		kind := node.Kind
+		if node.Label != "" {
+			kind += "(" + node.Label + ")"
+		}
+
+		kinds = append(kinds, kind)
+	}
+
+	out.Path = strings.Join(kinds, " > ")
+
+	return nil, out, nil
+}
+
+// pos converts a 1-based line and byte column of the file to a position; column 0 stands for the first
+// non-blank character of the line.
+func (pf *positionFile) pos(lines []string, line, column int) (token.Pos, error) {
+	tf := pf.pkg.Fset.File(pf.file.Pos())
+	if tf == nil {
+		return token.NoPos, fmt.Errorf("no position information for %s", pf.relPath)
+	}
+
+	if line > tf.LineCount() || line > len(lines) {
+		return token.NoPos, invalidInput("line %d is out of range: %s has %d lines", line, pf.relPath, tf.LineCount())
+	}
+
+	text := strings.TrimRight(lines[line-1], "\r")
+	if column == 0 {
+		column = len(text) - len(strings.TrimLeft(text, " \t")) + 1
+	}
+
+	if column > len(text)+1 {
+		return token.NoPos, invalidInput("column %d is out of range: line %d of %s has %d bytes", column, line, pf.relPath, len(text))
+	}
+
+	return tf.LineStart(line) + token.Pos(column-1), nil
+}
+
+// enclosingStatementPath returns the part of path, innermost first, that starts at the smallest statement,
+// spec or declaration other than a block.
+func enclosingStatementPath(path []ast.Node) []ast.Node {
+	for i, node := range path {
+		switch node.(type) {
+		case *ast.BlockStmt:
+		case ast.Stmt, ast.Spec, ast.Decl:
+			return path[i : i+1]
+		}
+	}
+
+	return path[len(path)-1:]
+}
+
+// describeNode returns the kind, label, span and compact rendering of node.
+func describeNode(fset *token.FileSet, lines []string, node ast.Node) ASTNodeInfo {
+	start, end := fset.Position(node.Pos()), fset.Position(node.End())
+	info := ASTNodeInfo{
+		Kind:        strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast."),
+		Label:       nodeLabel(node),
+		StartLine:   start.Line,
+		StartColumn: start.Column,
+		EndLine:     end.Line,
+		EndColumn:   end.Column,
+	}
+
+	if file, ok := node.(*ast.File); ok {
+		// The root is never rendered: its text would be the whole file.
+		info.Text = "package " + file.Name.Name
+
+		return info
+	}
+
+	info.Text = compactSource(lines, start, end)
+
+	return info
+}
+
+// nodeLabel returns what tells node apart from its siblings of the same kind, such as the name of a
+// declaration or the function of a call, or "".
+func nodeLabel(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.File:
+		return "Name=" + n.Name.Name
+	case *ast.FuncDecl:
+		return "Name=" + qualifiedFuncName(n)
+	case *ast.GenDecl:
+		return "Tok=" + n.Tok.String()
+	case *ast.TypeSpec:
+		return "Name=" + n.Name.Name
+	case *ast.ValueSpec:
+		return "Names=" + joinIdentNames(n.Names)
+	case *ast.ImportSpec:
+		return "Path=" + n.Path.Value
+	case *ast.Field:
+		if len(n.Names) > 0 {
+			return "Names=" + joinIdentNames(n.Names)
+		}
+	case *ast.Ident:
+		return "Name=" + n.Name
+	case *ast.BasicLit:
+		return "Value=" + truncateNodeText(n.Value)
+	case *ast.CallExpr:
+		return "Fun=" + truncateNodeText(types.ExprString(n.Fun))
+	case *ast.SelectorExpr:
+		return "Sel=" + n.Sel.Name
+	case *ast.BinaryExpr:
+		return "Op=" + n.Op.String()
+	case *ast.UnaryExpr:
+		return "Op=" + n.Op.String()
+	case *ast.AssignStmt:
+		return "Tok=" + n.Tok.String()
+	case *ast.IncDecStmt:
+		return "Tok=" + n.Tok.String()
+	case *ast.BranchStmt:
+		return "Tok=" + n.Tok.String()
+	case *ast.LabeledStmt:
+		return "Label=" + n.Label.Name
+	}
+
+	return ""
+}
+
+// joinIdentNames joins the names of idents with commas.
+func joinIdentNames(idents []*ast.Ident) string {
+	names := make([]string, 0, len(idents))
+	for _, id := range idents {
+		names = append(names, id.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// compactSource returns the source between start and end with every run of white space collapsed to one
+// space, reading only as many lines as the capped rendering needs.
+func compactSource(lines []string, start, end token.Position) string {
+	var b strings.Builder
+
+	for line := start.Line; line <= end.Line && line <= len(lines) && b.Len() <= maxNodeTextLen; line++ {
+		text := strings.TrimRight(lines[line-1], "\r")
+		if line == end.Line {
+			text = text[:min(max(end.Column-1, 0), len(text))]
+		}
+
+		if line == start.Line {
+			text = text[min(max(start.Column-1, 0), len(text)):]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(strings.Join(fields, " "))
+	}
+
+	return truncateNodeText(b.String())
+}
+
+// truncateNodeText cuts text to maxNodeTextLen bytes, marking the cut with "...".
+func truncateNodeText(text string) string {
+	if len(text) <= maxNodeTextLen {
+		return text
+	}
+
+	cut := maxNodeTextLen - len("...")
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+
+	return text[:cut] + "..."
+}
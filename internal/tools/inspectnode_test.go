@@ -0,0 +1,77 @@
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const inspectNodeSource = `package lang
+
+func stat(name string) (int, error) { return len(name), nil }
+
+func Load(name string) int {
+	if n, err := stat(name); err == nil {
+		return n
+	}
+
+	return strings0(name + "` + "a very long suffix that does not fit into the compact rendering of a node at all, not even close to it" + `")
+}
+
+func strings0(s string) int { return len(s) }
+`
+
+func TestInspectNode(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": inspectNodeSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	// Line 6 column 15 is the "stat" of the call in the if statement.
+	_, out, err := tools.InspectNode(ctx, req, tools.InspectNodeInput{Dir: dir, File: "lang.go", Line: 6, Column: 15})
+	if err != nil {
+		t.Fatalf("InspectNode: %v", err)
+	}
+
+	want := "File(Name=lang) > FuncDecl(Name=Load) > BlockStmt > IfStmt > AssignStmt(Tok=:=) > CallExpr(Fun=stat) > Ident(Name=stat)"
+	if out.File != "lang.go" || out.Path != want {
+		t.Fatalf("path = %s, want %s", out.Path, want)
+	}
+
+	call := out.Nodes[5]
+	if call.Text != "stat(name)" || call.StartLine != 6 || call.StartColumn != 15 || call.EndColumn != 25 {
+		t.Errorf("call = %+v", call)
+	}
+
+	if root := out.Nodes[0]; root.Text != "package lang" || root.EndLine < 13 {
+		t.Errorf("root = %+v, want its span without its text", root)
+	}
+
+	if fn := out.Nodes[1]; !strings.HasPrefix(fn.Text, "func Load(name string) int { if n, err := stat(name); err == nil { return n } return") ||
+		len(fn.Text) != 120 || !strings.HasSuffix(fn.Text, "...") {
+		t.Errorf("function text = %q, want a compact rendering cut at 120 characters", fn.Text)
+	}
+
+	_, out, err = tools.InspectNode(ctx, req, tools.InspectNodeInput{Dir: dir, File: "lang.go", Line: 7, Depth: 2})
+	if err != nil || !out.Truncated || out.Path != "BlockStmt > ReturnStmt" {
+		t.Errorf("depth 2 at the first non-blank column: %+v, %v", out, err)
+	}
+
+	_, out, err = tools.InspectNode(ctx, req, tools.InspectNodeInput{Dir: dir, File: "lang.go", Line: 6, Column: 15, ParentOf: true})
+	if err != nil || out.Path != "AssignStmt(Tok=:=)" {
+		t.Errorf("parentOf: %+v, %v", out, err)
+	}
+
+	_, _, err = tools.InspectNode(ctx, req, tools.InspectNodeInput{Dir: dir, File: "lang.go", Line: 99})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a line out of range, got %v", err)
+	}
+
+	_, _, err = tools.InspectNode(ctx, req, tools.InspectNodeInput{Dir: dir, File: "missing.go", Line: 1})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound {
+		t.Errorf("expected NOT_FOUND for an unknown file, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"sort"
+)
+
+// Orders of getDependencyGraph's sortBy.
+const (
+	dependencySortInstability = "instability"
+	dependencySortFanIn       = "fanIn"
+	dependencySortDepth       = "depth"
+)
+
+// dependencyMetrics holds the package metrics derived from the internal dependency graph.
+type dependencyMetrics struct {
+	instability map[string]float64
+	depth       map[string]int
+}
+
+// computeDependencyMetrics derives the instability and layer depth of every internal package from graph,
+// the imports by package, counting only edges between internal packages. Instability is
+// fanOut/(fanIn+fanOut), 0 for an isolated package; the layer depth is the length of the longest import
+// chain down to a package importing no internal package. Edges closing a cycle do not lengthen chains.
+func computeDependencyMetrics(graph map[string][]string, internal map[string]bool) dependencyMetrics {
+	fanIn := make(map[string]int)
+	fanOut := make(map[string]int)
+
+	for from, imports := range graph {
+		if !internal[from] {
+			continue
+		}
+
+		for _, to := range imports {
+			if internal[to] && to != from {
+				fanOut[from]++
+				fanIn[to]++
+			}
+		}
+	}
+
+	m := dependencyMetrics{instability: make(map[string]float64), depth: make(map[string]int)}
+
+	for pkg := range internal {
+		if total := fanIn[pkg] + fanOut[pkg]; total > 0 {
+			m.instability[pkg] = float64(fanOut[pkg]) / float64(total)
+		}
+	}
+
+	onPath := make(map[string]bool)
+	done := make(map[string]bool)
+
+	var visit func(string) int
+
+	visit = func(pkg string) int {
+		if done[pkg] || onPath[pkg] {
+			return m.depth[pkg]
+		}
+
+		onPath[pkg] = true
+
+		depth := 0
+
+		for _, dep := range graph[pkg] {
+			if internal[dep] && dep != pkg && !onPath[dep] {
+				depth = max(depth, visit(dep)+1)
+			}
+		}
+
+		onPath[pkg] = false
+		done[pkg] = true
+		m.depth[pkg] = depth
+
+		return depth
+	}
+
+	for _, pkg := range sortedKeys(internal) {
+		visit(pkg)
+	}
+
+	return m
+}
+
+// stabilityViolations returns the internal edges from a package to a less stable one, which break the
+// stable-dependencies principle, for the packages in from (all when empty), largest instability gap
+// first.
+func stabilityViolations(graph map[string][]string, internal map[string]bool, from map[string]struct{}, m dependencyMetrics) []DependencyViolation {
+	violations := []DependencyViolation{}
+
+	for _, pkg := range sortedKeys(graph) {
+		if _, ok := from[pkg]; !internal[pkg] || len(from) > 0 && !ok {
+			continue
+		}
+
+		for _, dep := range graph[pkg] {
+			if internal[dep] && dep != pkg && m.instability[pkg] < m.instability[dep] {
+				violations = append(violations, DependencyViolation{
+					From:            pkg,
+					To:              dep,
+					FromInstability: m.instability[pkg],
+					ToInstability:   m.instability[dep],
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+
+		return a.ToInstability-a.FromInstability > b.ToInstability-b.FromInstability
+	})
+
+	return violations
+}
+
+// validateDependencySort checks the sortBy input of getDependencyGraph.
+func validateDependencySort(sortBy string) error {
+	switch sortBy {
+	case "", dependencySortInstability, dependencySortFanIn, dependencySortDepth:
+		return nil
+	}
+
+	return invalidInput("sortBy must be %s, %s or %s, got %q",
+		dependencySortInstability, dependencySortFanIn, dependencySortDepth, sortBy)
+}
+
+// sortDependencies orders deps by sortBy, highest first, then by package path; "" sorts by package path.
+func sortDependencies(deps []PackageDependency, sortBy string) {
+	key := func(d PackageDependency) float64 {
+		switch sortBy {
+		case dependencySortInstability:
+			return d.Instability
+		case dependencySortFanIn:
+			return float64(d.FanIn)
+		case dependencySortDepth:
+			return float64(d.LayerDepth)
+		}
+
+		return 0
+	}
+
+	sort.SliceStable(deps, func(i, j int) bool {
+		if ki, kj := key(deps[i]), key(deps[j]); ki != kj {
+			return ki > kj
+		}
+
+		return deps[i].Package < deps[j].Package
+	})
+}
@@ -0,0 +1,74 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func writeLayeredModule(t *testing.T) string {
+	t.Helper()
+
+	return writeLanguageModule(t, "1.22", map[string]string{
+		"api/api.go":         "package api\n\nimport _ \"lang/service\"\n",
+		"service/service.go": "package service\n\nimport (\n\t_ \"lang/model\"\n\t_ \"lang/store\"\n\t_ \"lang/util\"\n)\n",
+		"util/util.go":       "package util\n\nimport _ \"lang/cli\"\n",
+		"cli/cli.go":         "package cli\n\nimport (\n\t_ \"lang/model\"\n\t_ \"lang/store\"\n)\n",
+		"store/store.go":     "package store\n\nimport _ \"lang/model\"\n",
+		"model/model.go":     "package model\n",
+	})
+}
+
+func TestAnalyzeDependencies_Instability(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLayeredModule(t)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeDependencies(ctx, req, tools.AnalyzeDependenciesInput{Dir: dir, SortBy: "instability"})
+	if err != nil {
+		t.Fatalf("AnalyzeDependencies: %v", err)
+	}
+
+	var got []string
+	for _, d := range out.Dependencies {
+		got = append(got, fmt.Sprintf("%s I=%.2f depth=%d", d.Package, d.Instability, d.LayerDepth))
+	}
+
+	want := []string{
+		"lang/api I=1.00 depth=5",
+		"lang/service I=0.75 depth=4",
+		"lang/cli I=0.67 depth=2",
+		"lang/util I=0.50 depth=3",
+		"lang/store I=0.33 depth=1",
+		"lang/model I=0.00 depth=0",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("by instability:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// util, imported by service only, depends on the less stable cli.
+	if len(out.Violations) != 1 || fmt.Sprintf("%s->%s %.2f<%.2f", out.Violations[0].From, out.Violations[0].To,
+		out.Violations[0].FromInstability, out.Violations[0].ToInstability) != "lang/util->lang/cli 0.50<0.67" {
+		t.Errorf("violations = %+v, want only lang/util -> lang/cli", out.Violations)
+	}
+
+	_, out, err = tools.AnalyzeDependencies(ctx, req, tools.AnalyzeDependenciesInput{Dir: dir, SortBy: "fanIn"})
+	if err != nil || out.Dependencies[0].Package != "lang/model" || out.Dependencies[0].FanIn != 3 {
+		t.Errorf("by fanIn: %+v, %v; want lang/model with 3 first", out.Dependencies, err)
+	}
+
+	_, out, err = tools.AnalyzeDependencies(ctx, req, tools.AnalyzeDependenciesInput{Dir: dir, SortBy: "depth"})
+	if err != nil || out.Dependencies[2].Package != "lang/util" || out.Dependencies[3].Package != "lang/cli" {
+		t.Errorf("by depth: %+v, %v; want util before cli", out.Dependencies, err)
+	}
+
+	_, _, err = tools.AnalyzeDependencies(ctx, req, tools.AnalyzeDependenciesInput{Dir: dir, SortBy: "size"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for an unknown sortBy, got %v", err)
+	}
+}
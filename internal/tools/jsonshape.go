@@ -0,0 +1,432 @@
+package tools
+
+import (
+	"context"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Reasons reported for struct fields that do not appear in the serialized shape.
+const (
+	shapeDropUnexported = "unexported"
+	shapeDropIgnored    = "ignored"
+	shapeDropAmbiguous  = "ambiguous"
+	shapeDropShadowed   = "shadowed"
+	shapeDropDuplicate  = "duplicate"
+)
+
+// shapeField is a candidate serialized field collected while walking a struct and its embeddings.
+type shapeField struct {
+	name      string
+	tagged    bool
+	depth     int
+	index     []int
+	path      string
+	typ       types.Type
+	omitEmpty bool
+	asString  bool
+}
+
+// DescribeJSONShape computes the effective serialized field set of a struct, applying the
+// promotion and tag rules of encoding/json (or gopkg.in/yaml.v3 for format "yaml").
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, type name and format
+//
+// Returns:
+//   - MCP tool call result
+//   - serialized fields with their Go types and source paths, plus dropped fields with reasons
+//   - error if the type is not found, is not a struct, or the format is unsupported
+func DescribeJSONShape(ctx context.Context, _ *mcp.CallToolRequest, input DescribeJSONShapeInput) (
+	*mcp.CallToolResult,
+	DescribeJSONShapeOutput,
+	error,
+) {
+	start := logStart("DescribeJSONShape", logFields(
+		input.Dir,
+		newLogField("typeName", input.TypeName),
+		newLogField("format", input.Format),
+	))
+	out := DescribeJSONShapeOutput{Format: input.Format}
+
+	defer func() { logEnd("DescribeJSONShape", start, len(out.Fields)) }()
+
+	if out.Format == "" {
+		out.Format = "json"
+	}
+
+	if out.Format != "json" && out.Format != "yaml" {
+		return fail(out, invalidInput("invalid format %q: expected json or yaml", input.Format))
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
+	if err != nil {
+		logError("DescribeJSONShape", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	tn, err := lookupTypeName(pkgs, input.TypeName)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	st, ok := tn.Type().Underlying().(*types.Struct)
+	if !ok {
+		return fail(out, invalidInput("type %q is not a struct", input.TypeName))
+	}
+
+	out.Type = tn.Pkg().Path() + "." + tn.Name()
+	qualifier := types.RelativeTo(tn.Pkg())
+
+	var fields []shapeField
+
+	if out.Format == "yaml" {
+		fields, out.Dropped = yamlShapeFields(st, nil, "", 0)
+	} else {
+		fields, out.Dropped = jsonShapeFields(st)
+	}
+
+	for _, f := range fields {
+		out.Fields = append(out.Fields, ShapeField{
+			Name:      f.name,
+			Type:      types.TypeString(f.typ, qualifier),
+			Path:      f.path,
+			OmitEmpty: f.omitEmpty,
+			String:    f.asString,
+		})
+	}
+
+	sort.SliceStable(out.Dropped, func(i, j int) bool { return out.Dropped[i].Path < out.Dropped[j].Path })
+
+	return nil, out, nil
+}
+
+// jsonShapeFields mirrors typeFields of encoding/json: a breadth-first walk over embedded structs
+// where, for each name, the shallowest field wins, a tagged field beats untagged ones at the same
+// depth, and remaining ties at the same depth drop the name altogether.
+func jsonShapeFields(root *types.Struct) ([]shapeField, []ShapeDroppedField) {
+	type level struct {
+		st    *types.Struct
+		key   string
+		index []int
+		path  string
+	}
+
+	var (
+		candidates []shapeField
+		dropped    []ShapeDroppedField
+	)
+
+	current := []level{}
+	next := []level{{st: root}}
+	count := map[string]int{}
+	nextCount := map[string]int{}
+	visited := map[string]bool{}
+
+	for depth := 0; len(next) > 0; depth++ {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[string]int{}
+
+		for _, lvl := range current {
+			if lvl.key != "" {
+				if visited[lvl.key] {
+					continue
+				}
+
+				visited[lvl.key] = true
+			}
+
+			for i := range lvl.st.NumFields() {
+				field := lvl.st.Field(i)
+				path := joinFieldPath(lvl.path, field.Name())
+				index := append(append([]int{}, lvl.index...), i)
+				tag := reflect.StructTag(lvl.st.Tag(i))
+
+				ft := field.Type()
+				if ptr, ok := ft.(*types.Pointer); ok && !isNamedType(ft) {
+					ft = ptr.Elem()
+				}
+
+				_, isStruct := ft.Underlying().(*types.Struct)
+
+				if field.Embedded() {
+					if !field.Exported() && !isStruct {
+						dropped = append(dropped, ShapeDroppedField{Path: path, Reason: shapeDropUnexported})
+
+						continue
+					}
+				} else if !field.Exported() {
+					dropped = append(dropped, ShapeDroppedField{Path: path, Reason: shapeDropUnexported})
+
+					continue
+				}
+
+				jsonTag := tag.Get("json")
+				if jsonTag == "-" {
+					dropped = append(dropped, ShapeDroppedField{Path: path, Reason: shapeDropIgnored})
+
+					continue
+				}
+
+				name, opts, _ := strings.Cut(jsonTag, ",")
+				if !isValidJSONTagName(name) {
+					name = ""
+				}
+
+				if name != "" || !field.Embedded() || !isStruct {
+					f := shapeField{
+						name:      name,
+						tagged:    name != "",
+						depth:     depth,
+						index:     index,
+						path:      path,
+						typ:       field.Type(),
+						omitEmpty: hasTagOption(opts, "omitempty"),
+						asString:  hasTagOption(opts, "string") && isStringableKind(ft),
+					}
+					if f.name == "" {
+						f.name = field.Name()
+					}
+
+					candidates = append(candidates, f)
+
+					// The same struct embedded twice at this depth annihilates its fields.
+					if count[lvl.key] > 1 {
+						candidates = append(candidates, f)
+					}
+
+					continue
+				}
+
+				key := types.TypeString(ft, nil)
+
+				nextCount[key]++
+				if nextCount[key] == 1 {
+					next = append(next, level{st: ft.Underlying().(*types.Struct), key: key, index: index, path: path})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+
+		if a.depth != b.depth {
+			return a.depth < b.depth
+		}
+
+		if a.tagged != b.tagged {
+			return a.tagged
+		}
+
+		return lessIndex(a.index, b.index)
+	})
+
+	var fields []shapeField
+
+	for i := 0; i < len(candidates); {
+		j := i + 1
+		for j < len(candidates) && candidates[j].name == candidates[i].name {
+			j++
+		}
+
+		group := candidates[i:j]
+		i = j
+
+		winner := 0
+		if len(group) > 1 && group[0].depth == group[1].depth && group[0].tagged == group[1].tagged {
+			winner = -1
+		}
+
+		for k, f := range group {
+			switch {
+			case k == winner:
+				fields = append(fields, f)
+			case winner < 0 && f.depth == group[0].depth:
+				dropped = appendDropped(dropped, ShapeDroppedField{Name: f.name, Path: f.path, Reason: shapeDropAmbiguous})
+			default:
+				dropped = appendDropped(dropped, ShapeDroppedField{Name: f.name, Path: f.path, Reason: shapeDropShadowed})
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return lessIndex(fields[i].index, fields[j].index) })
+
+	return fields, dropped
+}
+
+// yamlShapeFields mirrors getStructInfo of gopkg.in/yaml.v3: only ",inline" fields are flattened,
+// untagged keys are the lowercased field name, and a repeated key makes marshaling fail.
+func yamlShapeFields(st *types.Struct, index []int, prefix string, depth int) ([]shapeField, []ShapeDroppedField) {
+	var (
+		fields  []shapeField
+		dropped []ShapeDroppedField
+	)
+
+	seen := map[string]bool{}
+
+	add := func(f shapeField) {
+		if seen[f.name] {
+			dropped = append(dropped, ShapeDroppedField{Name: f.name, Path: f.path, Reason: shapeDropDuplicate})
+
+			return
+		}
+
+		seen[f.name] = true
+		fields = append(fields, f)
+	}
+
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		path := joinFieldPath(prefix, field.Name())
+		fieldIndex := append(append([]int{}, index...), i)
+
+		if !field.Exported() && !field.Embedded() {
+			dropped = append(dropped, ShapeDroppedField{Path: path, Reason: shapeDropUnexported})
+
+			continue
+		}
+
+		tag := reflect.StructTag(st.Tag(i))
+
+		yamlTag, ok := tag.Lookup("yaml")
+		if !ok && !strings.Contains(string(tag), ":") {
+			yamlTag = string(tag)
+		}
+
+		if yamlTag == "-" {
+			dropped = append(dropped, ShapeDroppedField{Path: path, Reason: shapeDropIgnored})
+
+			continue
+		}
+
+		name, opts, _ := strings.Cut(yamlTag, ",")
+
+		if hasTagOption(opts, "inline") {
+			ft := field.Type()
+			if ptr, ok := ft.Underlying().(*types.Pointer); ok {
+				ft = ptr.Elem()
+			}
+
+			if inner, ok := ft.Underlying().(*types.Struct); ok {
+				innerFields, innerDropped := yamlShapeFields(inner, fieldIndex, path, depth+1)
+				for _, f := range innerFields {
+					add(f)
+				}
+
+				dropped = append(dropped, innerDropped...)
+
+				continue
+			}
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name())
+		}
+
+		add(shapeField{
+			name:      name,
+			tagged:    yamlTag != "",
+			depth:     depth,
+			index:     fieldIndex,
+			path:      path,
+			typ:       field.Type(),
+			omitEmpty: hasTagOption(opts, "omitempty"),
+		})
+	}
+
+	return fields, dropped
+}
+
+// appendDropped appends a dropped field unless the same path is already reported.
+func appendDropped(dropped []ShapeDroppedField, d ShapeDroppedField) []ShapeDroppedField {
+	for _, existing := range dropped {
+		if existing.Path == d.Path && existing.Reason == d.Reason {
+			return dropped
+		}
+	}
+
+	return append(dropped, d)
+}
+
+// joinFieldPath returns the dotted Go field path of a (possibly promoted) field.
+func joinFieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// hasTagOption reports whether a comma-separated tag option list contains opt.
+func hasTagOption(opts, opt string) bool {
+	for o := range strings.SplitSeq(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNamedType reports whether t is a named (or alias) type rather than a type literal.
+func isNamedType(t types.Type) bool {
+	switch t.(type) {
+	case *types.Named, *types.Alias:
+		return true
+	}
+
+	return false
+}
+
+// isStringableKind reports whether the ",string" option applies to values of t, as in encoding/json.
+func isStringableKind(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0 && basic.Info()&types.IsComplex == 0
+}
+
+// isValidJSONTagName mirrors isValidTag of encoding/json.
+func isValidJSONTagName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+
+	return true
+}
+
+// lessIndex orders field index sequences the way encoding/json orders the final field list.
+func lessIndex(a, b []int) bool {
+	for i := range a {
+		if i >= len(b) {
+			return false
+		}
+
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
@@ -0,0 +1,125 @@
+package tools_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func describeShape(t *testing.T, typeName, format string) tools.DescribeJSONShapeOutput {
+	t.Helper()
+
+	in := tools.DescribeJSONShapeInput{Dir: testDir(), TypeName: typeName, Format: format}
+
+	_, out, err := tools.DescribeJSONShape(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("DescribeJSONShape error: %v", err)
+	}
+
+	return out
+}
+
+func shapeNames(fields []tools.ShapeField) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+
+	return names
+}
+
+func droppedReasons(dropped []tools.ShapeDroppedField) map[string]string {
+	reasons := make(map[string]string, len(dropped))
+	for _, d := range dropped {
+		reasons[d.Path] = d.Reason
+	}
+
+	return reasons
+}
+
+func TestDescribeJSONShape_JSON(t *testing.T) {
+	t.Parallel()
+
+	out := describeShape(t, "Payload", "")
+
+	if out.Type != "sample.Payload" || out.Format != "json" {
+		t.Errorf("unexpected type/format: %q %q", out.Type, out.Format)
+	}
+
+	want := []string{"created_by", "tags", "trace", "name", "labels", "-", "Count"}
+	if got := shapeNames(out.Fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected fields %v, got %v", want, got)
+	}
+
+	for _, f := range out.Fields {
+		switch f.Name {
+		case "created_by":
+			if f.Path != "Audit.CreatedBy" || !f.OmitEmpty {
+				t.Errorf("unexpected promoted field: %+v", f)
+			}
+		case "tags":
+			if f.Type != "[]string" || f.Path != "Meta.Tags" {
+				t.Errorf("unexpected field promoted through pointer: %+v", f)
+			}
+		case "Count":
+			if !f.String {
+				t.Errorf("expected ,string option on Count: %+v", f)
+			}
+		}
+	}
+
+	reasons := droppedReasons(out.Dropped)
+	for path, reason := range map[string]string{
+		"Audit.ID":   "ambiguous",
+		"Meta.ID":    "ambiguous",
+		"Audit.Note": "ambiguous",
+		"Meta.Note":  "ambiguous",
+		"Secret":     "ignored",
+		"password":   "unexported",
+	} {
+		if reasons[path] != reason {
+			t.Errorf("expected %s to be dropped as %s, got %q", path, reason, reasons[path])
+		}
+	}
+}
+
+func TestDescribeJSONShape_YAML(t *testing.T) {
+	t.Parallel()
+
+	out := describeShape(t, "Payload", "yaml")
+
+	want := []string{"audit", "meta", "traceinfo", "name", "labels", "dash", "count"}
+	if got := shapeNames(out.Fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected fields %v, got %v", want, got)
+	}
+
+	inline := describeShape(t, "sample.InlinePayload", "yaml")
+
+	want = []string{"id", "createdby", "note"}
+	if got := shapeNames(inline.Fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected inlined fields %v, got %v", want, got)
+	}
+
+	if reasons := droppedReasons(inline.Dropped); reasons["Note"] != "duplicate" {
+		t.Errorf("expected Note to collide with the inlined Audit.Note, got %+v", inline.Dropped)
+	}
+}
+
+func TestDescribeJSONShape_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []tools.DescribeJSONShapeInput{
+		{Dir: testDir(), TypeName: "NoSuchType"},
+		{Dir: testDir(), TypeName: "Payload", Format: "xml"},
+		{Dir: testDir(), TypeName: "Point", Format: "toml"},
+		{Dir: "/nonexistent/directory", TypeName: "Payload"},
+	} {
+		if _, _, err := tools.DescribeJSONShape(context.Background(), &mcp.CallToolRequest{}, in); err == nil {
+			t.Errorf("expected error for %+v", in)
+		}
+	}
+}
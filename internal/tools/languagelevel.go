@@ -0,0 +1,393 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// languageLevelSlack is how many minor releases the go directive may be ahead of the newest detected
+// construct before it is reported as far newer than needed.
+const languageLevelSlack = 4
+
+// languageLevelBaseline is the version assumed when no tracked construct is used; older features are
+// not detected.
+const languageLevelBaseline = "1.17"
+
+// Language constructs and the Go version that introduced them.
+const (
+	featureGenerics      = "generics"
+	featureFuzzing       = "fuzzing"
+	featureLoopVar       = "per-iteration loop variables"
+	featureRangeOverInt  = "range over int"
+	featureRangeOverFunc = "range over func"
+)
+
+var featureVersions = map[string]string{
+	featureGenerics:      "1.18",
+	featureFuzzing:       "1.18",
+	featureLoopVar:       "1.22",
+	featureRangeOverInt:  "1.22",
+	featureRangeOverFunc: "1.23",
+}
+
+// builtinVersions lists builtins added after generics.
+var builtinVersions = map[string]string{
+	"min":   "1.21",
+	"max":   "1.21",
+	"clear": "1.21",
+}
+
+// stdPackageVersions lists standard library packages added after generics.
+var stdPackageVersions = map[string]string{
+	"slices":           "1.21",
+	"maps":             "1.21",
+	"cmp":              "1.21",
+	"log/slog":         "1.21",
+	"math/rand/v2":     "1.22",
+	"iter":             "1.23",
+	"unique":           "1.23",
+	"structs":          "1.23",
+	"weak":             "1.24",
+	"crypto/hkdf":      "1.24",
+	"crypto/mlkem":     "1.24",
+	"crypto/pbkdf2":    "1.24",
+	"crypto/sha3":      "1.24",
+	"testing/synctest": "1.25",
+}
+
+// languageFeatureSite is a use of a versioned construct found in a file.
+type languageFeatureSite struct {
+	construct string
+	version   string
+	pos       token.Pos
+}
+
+// CheckLanguageLevel compares the go directive of go.mod with the language constructs, builtins and
+// standard library packages the module uses, reporting uses that need a newer version and a directive
+// that is far newer than anything detected requires.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the module directory
+//
+// Returns:
+//   - MCP tool call result
+//   - declared and required versions, uses above the declared version and counts per construct
+//   - error if go.mod has no go directive or packages cannot be loaded
+func CheckLanguageLevel(ctx context.Context, _ *mcp.CallToolRequest, input CheckLanguageLevelInput) (
+	*mcp.CallToolResult,
+	CheckLanguageLevelOutput,
+	error,
+) {
+	start := logStart("CheckLanguageLevel", logFields(input.Dir))
+	out := CheckLanguageLevelOutput{}
+
+	defer func() { logEnd("CheckLanguageLevel", start, len(out.Violations)) }()
+
+	root := findModuleRoot(input.Dir)
+	if root == "" {
+		return nil, out, notFound(nil, "no go.mod found for %q", input.Dir)
+	}
+
+	_, declared := readGoModInfo(root)
+	if declared == "" || !version.IsValid("go"+declared) {
+		return nil, out, invalidInput("go.mod in %q has no valid go directive", root)
+	}
+
+	out.Declared = declared
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	counts := make(map[string]*LanguageFeatureCount)
+	required := languageLevelBaseline
+	// Test variants contain the package's own files again; every file is scanned once.
+	seen := make(map[string]struct{})
+
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if _, ok := seen[relPath]; ok || strings.HasSuffix(pkg.PkgPath, ".test") {
+			return nil
+		}
+
+		seen[relPath] = struct{}{}
+
+		// A //go:build goX.Y constraint raises the language version of its file.
+		effective := "go" + declared
+		if file.GoVersion != "" && version.Compare(file.GoVersion, effective) > 0 {
+			effective = file.GoVersion
+		}
+
+		for _, site := range languageFeatureSites(pkg.TypesInfo, file, strings.HasSuffix(relPath, "_test.go")) {
+			count, ok := counts[site.construct]
+			if !ok {
+				count = &LanguageFeatureCount{Construct: site.construct, Version: site.version}
+				counts[site.construct] = count
+			}
+
+			count.Count++
+
+			if version.Compare("go"+site.version, "go"+required) > 0 {
+				required = site.version
+			}
+
+			if version.Compare("go"+site.version, effective) > 0 {
+				out.Violations = append(out.Violations, LanguageFeatureUse{
+					Construct: site.construct,
+					Version:   site.version,
+					File:      relPath,
+					Line:      pkg.Fset.Position(site.pos).Line,
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.Slice(out.Violations, func(i, j int) bool {
+		if out.Violations[i].File != out.Violations[j].File {
+			return out.Violations[i].File < out.Violations[j].File
+		}
+
+		return out.Violations[i].Line < out.Violations[j].Line
+	})
+
+	for _, count := range counts {
+		out.Features = append(out.Features, *count)
+	}
+
+	sort.Slice(out.Features, func(i, j int) bool {
+		if out.Features[i].Version != out.Features[j].Version {
+			return version.Compare("go"+out.Features[i].Version, "go"+out.Features[j].Version) < 0
+		}
+
+		return out.Features[i].Construct < out.Features[j].Construct
+	})
+
+	out.Required = required
+	out.Passed = len(out.Violations) == 0
+	out.DirectiveAhead = goMinorVersion(declared)-goMinorVersion(required) > languageLevelSlack
+
+	return nil, out, nil
+}
+
+// languageFeatureSites finds uses of versioned constructs in a file: type parameters, fuzz targets,
+// new builtins, range over int or func, closures and addresses of loop variables that rely on
+// per-iteration semantics, and imports of new standard library packages.
+func languageFeatureSites(info *types.Info, file *ast.File, inTest bool) []languageFeatureSite {
+	var sites []languageFeatureSite
+
+	add := func(construct, v string, pos token.Pos) {
+		sites = append(sites, languageFeatureSite{construct: construct, version: v, pos: pos})
+	}
+
+	feature := func(construct string, pos token.Pos) {
+		add(construct, featureVersions[construct], pos)
+	}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		if v, ok := stdPackageVersions[path]; ok {
+			add("package "+path, v, spec.Pos())
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if node.Type.TypeParams != nil && len(node.Type.TypeParams.List) > 0 {
+				feature(featureGenerics, node.Name.Pos())
+			}
+
+			if inTest && node.Recv == nil && strings.HasPrefix(node.Name.Name, "Fuzz") && isFuzzTarget(info, node) {
+				feature(featureFuzzing, node.Name.Pos())
+			}
+		case *ast.TypeSpec:
+			if node.TypeParams != nil && len(node.TypeParams.List) > 0 {
+				feature(featureGenerics, node.Name.Pos())
+			}
+		case *ast.CallExpr:
+			if ident, ok := ast.Unparen(node.Fun).(*ast.Ident); ok {
+				if builtin, ok := info.Uses[ident].(*types.Builtin); ok {
+					if v, ok := builtinVersions[builtin.Name()]; ok {
+						add("builtin "+builtin.Name(), v, ident.Pos())
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if node.X != nil {
+				switch t := info.TypeOf(node.X); {
+				case t == nil:
+				case isIntegerType(t):
+					feature(featureRangeOverInt, node.For)
+				default:
+					if _, ok := t.Underlying().(*types.Signature); ok {
+						feature(featureRangeOverFunc, node.For)
+					}
+				}
+			}
+
+			if node.Tok == token.DEFINE {
+				for _, pos := range loopVarCaptures(info, node.Body, node.Key, node.Value) {
+					feature(featureLoopVar, pos)
+				}
+			}
+		case *ast.ForStmt:
+			if assign, ok := node.Init.(*ast.AssignStmt); ok && assign.Tok == token.DEFINE {
+				for _, pos := range loopVarCaptures(info, node.Body, assign.Lhs...) {
+					feature(featureLoopVar, pos)
+				}
+			}
+		}
+
+		return true
+	})
+
+	return sites
+}
+
+// loopVarCaptures returns the positions in body where a loop variable declared by vars escapes its
+// iteration: a closure referencing it, or its address, that is run by a go or defer statement, stored,
+// appended, sent or returned. Such code is only correct with the per-iteration loop variables of Go 1.22.
+func loopVarCaptures(info *types.Info, body *ast.BlockStmt, vars ...ast.Expr) []token.Pos {
+	loopVars := make(map[types.Object]struct{})
+
+	for _, v := range vars {
+		if ident, ok := v.(*ast.Ident); ok && ident.Name != "_" {
+			if obj := info.Defs[ident]; obj != nil {
+				loopVars[obj] = struct{}{}
+			}
+		}
+	}
+
+	if len(loopVars) == 0 || body == nil {
+		return nil
+	}
+
+	uses := func(node ast.Node) bool {
+		found := false
+
+		ast.Inspect(node, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				if _, ok := loopVars[info.Uses[ident]]; ok {
+					found = true
+				}
+			}
+
+			return !found
+		})
+
+		return found
+	}
+
+	var (
+		positions []token.Pos
+		stack     []ast.Node
+	)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+
+			return true
+		}
+
+		captured := false
+
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			captured = uses(node.Body)
+		case *ast.UnaryExpr:
+			_, isIdent := ast.Unparen(node.X).(*ast.Ident)
+			captured = node.Op == token.AND && isIdent && uses(node.X)
+		}
+
+		if captured && escapesIteration(info, stack) {
+			positions = append(positions, n.Pos())
+
+			return false
+		}
+
+		stack = append(stack, n)
+
+		return true
+	})
+
+	return positions
+}
+
+// escapesIteration reports whether the value of the node on top of stack outlives the statement it
+// appears in: it is run by a go or defer statement, assigned, appended, sent, returned or stored in a
+// composite literal.
+func escapesIteration(info *types.Info, stack []ast.Node) bool {
+	if len(stack) == 0 {
+		return false
+	}
+
+	switch parent := stack[len(stack)-1].(type) {
+	case *ast.AssignStmt, *ast.ValueSpec, *ast.SendStmt, *ast.ReturnStmt, *ast.CompositeLit, *ast.KeyValueExpr:
+		return true
+	case *ast.CallExpr:
+		if ident, ok := ast.Unparen(parent.Fun).(*ast.Ident); ok {
+			if builtin, ok := info.Uses[ident].(*types.Builtin); ok && builtin.Name() == "append" {
+				return true
+			}
+		}
+
+		if len(stack) > 1 {
+			switch stack[len(stack)-2].(type) {
+			case *ast.GoStmt, *ast.DeferStmt:
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isFuzzTarget reports whether a function takes a single *testing.F.
+func isFuzzTarget(info *types.Info, fn *ast.FuncDecl) bool {
+	params := fn.Type.Params
+	if params == nil || len(params.List) != 1 || len(params.List[0].Names) > 1 {
+		return false
+	}
+
+	t := info.TypeOf(params.List[0].Type)
+
+	return t != nil && t.String() == "*testing.F"
+}
+
+func isIntegerType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsInteger != 0
+}
+
+// goMinorVersion returns the minor version of a go directive such as "1.22" or "1.22.3".
+func goMinorVersion(v string) int {
+	minor, err := strconv.Atoi(strings.TrimPrefix(version.Lang("go"+v), "go1."))
+	if err != nil {
+		return 0
+	}
+
+	return minor
+}
@@ -0,0 +1,201 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+// writeLanguageModule creates a module named lang with the given go directive and files.
+func writeLanguageModule(t testing.TB, goVersion string, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files["go.mod"] = "module lang\n\ngo " + goVersion + "\n"
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", name, err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestCheckLanguageLevel_Constructs(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		goVersion string
+		files     map[string]string
+		construct string // expected violation, empty if none
+		version   string
+	}{
+		{
+			name:      "generics",
+			goVersion: "1.17",
+			files:     map[string]string{"lang.go": "package lang\n\nfunc Same[T any](x T) T { return x }\n"},
+			construct: "generics",
+			version:   "1.18",
+		},
+		{
+			name:      "fuzzing",
+			goVersion: "1.17",
+			files: map[string]string{
+				"lang.go":      "package lang\n",
+				"lang_test.go": "package lang\n\nimport \"testing\"\n\nfunc FuzzParse(f *testing.F) {}\n",
+			},
+			construct: "fuzzing",
+			version:   "1.18",
+		},
+		{
+			name:      "builtin min",
+			goVersion: "1.20",
+			files:     map[string]string{"lang.go": "package lang\n\nfunc Low(a, b int) int { return min(a, b) }\n"},
+			construct: "builtin min",
+			version:   "1.21",
+		},
+		{
+			name:      "builtin clear",
+			goVersion: "1.20",
+			files:     map[string]string{"lang.go": "package lang\n\nfunc Reset(m map[string]int) { clear(m) }\n"},
+			construct: "builtin clear",
+			version:   "1.21",
+		},
+		{
+			name:      "user-defined min",
+			goVersion: "1.20",
+			files: map[string]string{"lang.go": "package lang\n\nfunc min(a, b int) int {\n\tif a < b {\n\t\treturn a\n\t}\n\n\treturn b\n}\n\n" +
+				"func Low(a, b int) int { return min(a, b) }\n"},
+		},
+		{
+			name:      "new std package",
+			goVersion: "1.20",
+			files:     map[string]string{"lang.go": "package lang\n\nimport \"slices\"\n\nfunc Has(s []int, v int) bool { return slices.Contains(s, v) }\n"},
+			construct: "package slices",
+			version:   "1.21",
+		},
+		{
+			name:      "range over int",
+			goVersion: "1.21",
+			files:     map[string]string{"lang.go": "package lang\n\nfunc Count() (n int) {\n\tfor range 10 {\n\t\tn++\n\t}\n\n\treturn n\n}\n"},
+			construct: "range over int",
+			version:   "1.22",
+		},
+		{
+			name:      "range over func",
+			goVersion: "1.22",
+			files: map[string]string{"lang.go": "package lang\n\nfunc Each(yield func(int) bool) {}\n\n" +
+				"func Sum() (s int) {\n\tfor v := range Each {\n\t\ts += v\n\t}\n\n\treturn s\n}\n"},
+			construct: "range over func",
+			version:   "1.23",
+		},
+		{
+			name:      "loop variable captured by goroutine",
+			goVersion: "1.21",
+			files: map[string]string{"lang.go": "package lang\n\nfunc Start(items []int, out chan<- int) {\n" +
+				"\tfor _, it := range items {\n\t\tgo func() { out <- it }()\n\t}\n}\n"},
+			construct: "per-iteration loop variables",
+			version:   "1.22",
+		},
+		{
+			name:      "loop variable address appended",
+			goVersion: "1.21",
+			files: map[string]string{"lang.go": "package lang\n\nfunc Ptrs(items []int) (ptrs []*int) {\n" +
+				"\tfor i := 0; i < len(items); i++ {\n\t\tptrs = append(ptrs, &i)\n\t}\n\n\treturn ptrs\n}\n"},
+			construct: "per-iteration loop variables",
+			version:   "1.22",
+		},
+		{
+			name:      "loop variable used synchronously",
+			goVersion: "1.21",
+			files: map[string]string{"lang.go": "package lang\n\nfunc Sum(items []int) (s int) {\n" +
+				"\tfor _, it := range items {\n\t\tfunc() { s += it }()\n\t}\n\n\treturn s\n}\n"},
+		},
+		{
+			name:      "build constraint raises file version",
+			goVersion: "1.21",
+			files:     map[string]string{"lang.go": "//go:build go1.22\n\npackage lang\n\nfunc Count() (n int) {\n\tfor range 10 {\n\t\tn++\n\t}\n\n\treturn n\n}\n"},
+		},
+		{
+			name:      "declared version suffices",
+			goVersion: "1.22",
+			files:     map[string]string{"lang.go": "package lang\n\nfunc Count() (n int) {\n\tfor range 10 {\n\t\tn++\n\t}\n\n\treturn n\n}\n"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := writeLanguageModule(t, tc.goVersion, tc.files)
+
+			_, out, err := tools.CheckLanguageLevel(context.Background(), &mcp.CallToolRequest{}, tools.CheckLanguageLevelInput{Dir: dir})
+			if err != nil {
+				t.Fatalf("CheckLanguageLevel error: %v", err)
+			}
+
+			if out.Declared != tc.goVersion {
+				t.Errorf("expected declared version %s, got %s", tc.goVersion, out.Declared)
+			}
+
+			if tc.construct == "" {
+				if !out.Passed || len(out.Violations) != 0 {
+					t.Errorf("expected no violations, got %+v", out.Violations)
+				}
+
+				return
+			}
+
+			if out.Passed || len(out.Violations) != 1 {
+				t.Fatalf("expected one violation, got %+v", out.Violations)
+			}
+
+			v := out.Violations[0]
+			if v.Construct != tc.construct || v.Version != tc.version || v.Line == 0 {
+				t.Errorf("expected %s (%s), got %+v", tc.construct, tc.version, v)
+			}
+
+			if out.Required != tc.version {
+				t.Errorf("expected required version %s, got %s", tc.version, out.Required)
+			}
+		})
+	}
+}
+
+func TestCheckLanguageLevel_DirectiveAhead(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.25", map[string]string{"lang.go": "package lang\n\nfunc Add(a, b int) int { return a + b }\n"})
+
+	_, out, err := tools.CheckLanguageLevel(context.Background(), &mcp.CallToolRequest{}, tools.CheckLanguageLevelInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("CheckLanguageLevel error: %v", err)
+	}
+
+	if !out.Passed || !out.DirectiveAhead || out.Required != "1.17" {
+		t.Errorf("expected a passing check with the directive far ahead of 1.17, got %+v", out)
+	}
+
+	dir = writeLanguageModule(t, "1.22", map[string]string{"lang.go": "package lang\n\nfunc Same[T any](x T) T { return x }\n"})
+
+	_, out, err = tools.CheckLanguageLevel(context.Background(), &mcp.CallToolRequest{}, tools.CheckLanguageLevelInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("CheckLanguageLevel error: %v", err)
+	}
+
+	if out.DirectiveAhead {
+		t.Errorf("expected go 1.22 to be within range of generics (1.18), got %+v", out)
+	}
+}
@@ -0,0 +1,338 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Categories of AnalyzeLifecycles findings, in report order.
+const (
+	lifecycleUnstoppableGoroutine = "unstoppable-goroutine"
+	lifecycleTimeTick             = "time-tick"
+	lifecycleAfterInLoop          = "after-in-loop"
+)
+
+var lifecycleCategories = []string{lifecycleUnstoppableGoroutine, lifecycleTimeTick, lifecycleAfterInLoop}
+
+// Resources leaked by AnalyzeLifecycles findings.
+const (
+	leakedGoroutine = "goroutine"
+	leakedTicker    = "ticker"
+	leakedTimer     = "timer"
+)
+
+// leakyTimerFuncs create a timer per call that lives until it fires; extraLeakyFunctions add wrappers.
+var leakyTimerFuncs = []string{"time.After"}
+
+// AnalyzeLifecycles reports goroutines and timers that outlive their use: functions starting a goroutine
+// that sends on a channel they return without a way to stop it (no context or done channel parameter, no
+// Close or Stop method on the receiver or a result), time.Tick calls, whose ticker is never stopped, and
+// time.After calls (or configured wrappers) inside loops, which create a timer per iteration. Function
+// literals are scanned as functions of their own: loops are those of the literal.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter and extra leaky functions
+//
+// Returns:
+//   - MCP tool call result
+//   - findings grouped by category
+//   - error if a pattern is invalid or an error occurred while loading packages
+func AnalyzeLifecycles(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeLifecyclesInput) (
+	*mcp.CallToolResult,
+	AnalyzeLifecyclesOutput,
+	error,
+) {
+	start := logStart("AnalyzeLifecycles", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("extraLeakyFunctions", strings.Join(input.ExtraLeakyFunctions, ",")),
+	))
+	out := AnalyzeLifecyclesOutput{Categories: []LifecycleCategory{}}
+
+	defer func() { logEnd("AnalyzeLifecycles", start, out.Total) }()
+
+	for _, pattern := range input.ExtraLeakyFunctions {
+		if _, err := path.Match(pattern, ""); err != nil || !strings.Contains(pattern, ".") {
+			return fail(out, invalidInput("invalid extraLeakyFunctions pattern %q", pattern))
+		}
+	}
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "AnalyzeLifecycles")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	timers := append(append([]string{}, leakyTimerFuncs...), input.ExtraLeakyFunctions...)
+	byCategory := make(map[string][]LifecycleFinding)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			s := &lifecycleScanner{info: pkg.TypesInfo, timers: timers}
+			s.scanTimers(fd.Body)
+			s.scanGoroutines(fd)
+
+			for _, f := range s.findings {
+				f.File, f.Line, f.Function = relPath, pkg.Fset.Position(f.pos).Line, qualifiedFuncName(fd)
+				byCategory[f.Category] = append(byCategory[f.Category], f.LifecycleFinding)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, category := range lifecycleCategories {
+		findings := byCategory[category]
+		if len(findings) == 0 {
+			continue
+		}
+
+		sort.Slice(findings, func(i, j int) bool {
+			if findings[i].File != findings[j].File {
+				return findings[i].File < findings[j].File
+			}
+
+			return findings[i].Line < findings[j].Line
+		})
+
+		out.Categories = append(out.Categories, LifecycleCategory{Category: category, Count: len(findings), Findings: findings})
+		out.Total += len(findings)
+	}
+
+	return nil, out, nil
+}
+
+// lifecycleFinding is a finding with the position its line is taken from.
+type lifecycleFinding struct {
+	LifecycleFinding
+
+	pos token.Pos
+}
+
+// lifecycleScanner checks the goroutines and timers of one function declaration.
+type lifecycleScanner struct {
+	info     *types.Info
+	timers   []string
+	findings []lifecycleFinding
+}
+
+func (s *lifecycleScanner) add(pos token.Pos, category, resource, call, message, suggestion string) {
+	s.findings = append(s.findings, lifecycleFinding{pos: pos, LifecycleFinding: LifecycleFinding{
+		Category: category, Resource: resource, Call: call, Message: message, Suggestion: suggestion,
+	}})
+}
+
+// scanTimers reports time.Tick calls anywhere in body and leaky timer calls inside its loops, tracking the
+// loops around every node the way AnalyzeDefers does. Function literals are scanned on their own.
+func (s *lifecycleScanner) scanTimers(body *ast.BlockStmt) {
+	var (
+		stack  []ast.Node
+		depths []int
+	)
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil {
+			stack, depths = stack[:len(stack)-1], depths[:len(depths)-1]
+
+			return true
+		}
+
+		depth := 0
+		if len(stack) > 0 {
+			depth = depths[len(depths)-1]
+			if iterates(stack[len(stack)-1], node) {
+				depth++
+			}
+		}
+
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			s.scanTimers(n.Body)
+
+			return false
+		case *ast.CallExpr:
+			s.checkTimerCall(n, depth)
+		}
+
+		stack, depths = append(stack, node), append(depths, depth)
+
+		return true
+	})
+}
+
+// checkTimerCall matches a call at the given loop depth against time.Tick and the leaky timer functions.
+func (s *lifecycleScanner) checkTimerCall(call *ast.CallExpr, depth int) {
+	fn := calledFunc(s.info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return
+	}
+
+	name := qualifiedObjectName(fn)
+
+	switch {
+	case name == "time.Tick":
+		s.add(call.Pos(), lifecycleTimeTick, leakedTicker, name,
+			"time.Tick creates a ticker that can never be stopped, so it keeps firing after its receiver is gone",
+			"use NewTicker + Stop")
+	case depth > 0 && matchesAnyPattern(s.timers, name):
+		s.add(call.Pos(), lifecycleAfterInLoop, leakedTimer, name,
+			fmt.Sprintf("%s inside %s creates a new timer per iteration that lives until it fires", name, loopDepthPhrase(depth)),
+			"use NewTimer + Stop")
+	}
+}
+
+// scanGoroutines reports the go statements of fd that send on a channel fd returns, directly or as a
+// field of a returned value, when fd gives its caller no way to stop them.
+func (s *lifecycleScanner) scanGoroutines(fd *ast.FuncDecl) {
+	fn, ok := s.info.Defs[fd.Name].(*types.Func)
+	if !ok {
+		return
+	}
+
+	returned := s.returnedLocals(fd.Body)
+	if len(returned) == 0 || s.stoppable(fn.Signature(), returned) {
+		return
+	}
+
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		g, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+
+		if ch := s.returnedChannel(g.Call, returned); ch != "" {
+			s.add(g.Pos(), lifecycleUnstoppableGoroutine, leakedGoroutine, ch,
+				fmt.Sprintf("goroutine sends on %s, which %s returns, and nothing can stop it: it blocks forever once the caller stops receiving",
+					ch, fd.Name.Name),
+				"accept ctx and select")
+		}
+
+		return true
+	})
+}
+
+// returnedLocals returns the local variables body returns, leaving out returns of function literals.
+func (s *lifecycleScanner) returnedLocals(body *ast.BlockStmt) map[types.Object]struct{} {
+	returned := make(map[types.Object]struct{})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if id, ok := ast.Unparen(result).(*ast.Ident); ok {
+					if v, ok := s.info.Uses[id].(*types.Var); ok && v.Pos() >= body.Pos() && v.Pos() < body.End() {
+						returned[v] = struct{}{}
+					}
+				}
+			}
+		}
+
+		return true
+	})
+
+	return returned
+}
+
+// returnedChannel returns the channel expression a goroutine started with call sends on, or passes as an
+// argument, when it is one of the returned locals or a field of one; "" otherwise.
+func (s *lifecycleScanner) returnedChannel(call *ast.CallExpr, returned map[types.Object]struct{}) string {
+	isReturned := func(expr ast.Expr) bool {
+		t := s.info.TypeOf(expr)
+		if t == nil {
+			return false
+		}
+
+		if _, ok := t.Underlying().(*types.Chan); !ok {
+			return false
+		}
+
+		_, ok := returned[s.info.Uses[rootIdent(expr)]]
+
+		return ok
+	}
+
+	if lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit); ok {
+		ch := ""
+
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			if send, ok := n.(*ast.SendStmt); ok && ch == "" && isReturned(send.Chan) {
+				ch = types.ExprString(send.Chan)
+			}
+
+			return ch == ""
+		})
+
+		return ch
+	}
+
+	for _, arg := range call.Args {
+		if isReturned(arg) {
+			return types.ExprString(arg)
+		}
+	}
+
+	return ""
+}
+
+// stoppable reports whether a function of signature sig returning the locals returned lets its caller stop
+// the goroutines it starts: a context or done channel parameter, or a Close or Stop method on the receiver,
+// a result or a returned value.
+func (s *lifecycleScanner) stoppable(sig *types.Signature, returned map[types.Object]struct{}) bool {
+	for i := range sig.Params().Len() {
+		t := sig.Params().At(i).Type()
+		if ch, ok := t.Underlying().(*types.Chan); ok && ch.Dir() != types.SendOnly {
+			return true
+		}
+
+		if hasMethod(t, "Done") {
+			return true // context.Context or a similar cancellation signal
+		}
+	}
+
+	closable := func(t types.Type) bool { return hasMethod(t, "Close") || hasMethod(t, "Stop") }
+
+	if recv := sig.Recv(); recv != nil && closable(recv.Type()) {
+		return true
+	}
+
+	for i := range sig.Results().Len() {
+		if closable(sig.Results().At(i).Type()) {
+			return true
+		}
+	}
+
+	for obj := range returned {
+		if closable(obj.Type()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasMethod reports whether values of type t, or pointers to them, have a method called name.
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	_, ok := obj.(*types.Func)
+
+	return ok
+}
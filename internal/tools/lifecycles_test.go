@@ -0,0 +1,130 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const lifecyclesSource = `package lang
+
+import "lang/clock"
+
+type Ctx interface{ Done() <-chan struct{} }
+
+type Feed struct{ C chan int }
+
+type Stream struct{ C chan int }
+
+func (s *Stream) Close() {}
+
+func Generate() <-chan int {
+	ch := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+func produce(ch chan int) { ch <- 1 }
+
+func Spawn() chan int {
+	ch := make(chan int)
+	go produce(ch)
+	return ch
+}
+
+func NewFeed() *Feed {
+	f := &Feed{C: make(chan int)}
+	go func() { f.C <- 1 }()
+	return f
+}
+
+func GenerateCtx(ctx Ctx) <-chan int {
+	ch := make(chan int)
+	go func() { ch <- 1 }()
+	return ch
+}
+
+func GenerateDone(done <-chan struct{}) <-chan int {
+	ch := make(chan int)
+	go func() { ch <- 1 }()
+	return ch
+}
+
+func NewStream() *Stream {
+	s := &Stream{C: make(chan int)}
+	go func() { s.C <- 1 }()
+	return s
+}
+
+func Poll(events <-chan int) {
+	for {
+		select {
+		case <-events:
+		case <-clock.After(1):
+			return
+		}
+	}
+}
+
+func Once() { <-clock.After(1) }
+
+func Watch(events <-chan int) {
+	go func() {
+		for range events {
+			<-clock.After(2)
+		}
+	}()
+}
+`
+
+func TestAnalyzeLifecycles(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"lang.go":        lifecyclesSource,
+		"clock/clock.go": "package clock\n\nfunc After(d int) <-chan int { return make(chan int) }\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeLifecycles(ctx, req, tools.AnalyzeLifecyclesInput{Dir: dir, ExtraLeakyFunctions: []string{"lang/clock.After"}})
+	if err != nil {
+		t.Fatalf("AnalyzeLifecycles: %v", err)
+	}
+
+	var got []string
+
+	for _, c := range out.Categories {
+		for _, f := range c.Findings {
+			got = append(got, fmt.Sprintf("%s %s:%d %s %s (%s)", f.Category, f.Function, f.Line, f.Resource, f.Call, f.Suggestion))
+		}
+	}
+
+	want := []string{
+		"unstoppable-goroutine Generate:15 goroutine ch (accept ctx and select)",
+		"unstoppable-goroutine Spawn:27 goroutine ch (accept ctx and select)",
+		"unstoppable-goroutine NewFeed:33 goroutine f.C (accept ctx and select)",
+		"after-in-loop Poll:59 timer lang/clock.After (use NewTimer + Stop)",
+		"after-in-loop Watch:70 timer lang/clock.After (use NewTimer + Stop)",
+	}
+
+	if out.Total != len(want) || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("findings:\n%s\nwant:\n%s", got, want)
+	}
+
+	_, out, err = tools.AnalyzeLifecycles(ctx, req, tools.AnalyzeLifecyclesInput{Dir: dir})
+	if err != nil || out.Total != 3 || len(out.Categories) != 1 {
+		t.Errorf("without extraLeakyFunctions: %+v, %v; want the goroutines only", out, err)
+	}
+
+	_, _, err = tools.AnalyzeLifecycles(ctx, req, tools.AnalyzeLifecyclesInput{Dir: dir, ExtraLeakyFunctions: []string{"After"}})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a pattern without package, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"bytes"
+	"os"
+)
+
+// utf8BOM is the UTF-8 byte order mark some editors put at the start of files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// fileStyle records the line endings and byte order mark of a source file, so that content produced
+// by go/format (always LF, never a BOM) can be written back in the file's original style.
+type fileStyle struct {
+	crlf bool
+	bom  bool
+}
+
+// detectFileStyle returns the style of data: CRLF if most of its line breaks are CRLF, and whether it
+// starts with a UTF-8 BOM.
+func detectFileStyle(data []byte) fileStyle {
+	crlf := bytes.Count(data, []byte("\r\n"))
+	lf := bytes.Count(data, []byte("\n")) - crlf
+
+	return fileStyle{crlf: crlf > lf, bom: bytes.HasPrefix(data, utf8BOM)}
+}
+
+// apply converts data to the style; data may use any mix of line endings.
+func (s fileStyle) apply(data []byte) []byte {
+	data = normalizeLineEndings(data)
+
+	if s.crlf {
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
+
+	if s.bom {
+		data = append(append([]byte{}, utf8BOM...), data...)
+	}
+
+	return data
+}
+
+// normalizeLineEndings strips a UTF-8 BOM and converts CRLF line endings to LF.
+func normalizeLineEndings(data []byte) []byte {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	return bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+}
+
+// preserveFileStyle converts data to the style of the file currently at path; new files keep data as is.
+func preserveFileStyle(path string, data []byte) []byte {
+	orig, err := os.ReadFile(path)
+	if err != nil {
+		return data
+	}
+
+	return detectFileStyle(orig).apply(data)
+}
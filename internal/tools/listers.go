@@ -3,8 +3,7 @@ package tools
 import (
 	"context"
 	"go/ast"
-	"os"
-	"path/filepath"
+	"go/types"
 	"sort"
 	"strings"
 
@@ -34,15 +33,20 @@ func ListPackages(ctx context.Context, _ *mcp.CallToolRequest, input ListPackage
 
 	defer func() { logEnd("ListPackages", start, len(out.Packages)) }()
 
+	ctx = withTestdata(ctx, input.IncludeTestdata)
+	out.SkippedTestdataPackages = skippedTestdataPackages(ctx, input.Dir)
+
 	mode := loadModeBasic
 
-	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
+	pkgs, degraded, err := loadPackagesWithFallback(ctx, input.Dir, mode)
 	if err != nil {
 		logError("ListPackages", err, "failed to load packages")
 
 		return fail(out, err)
 	}
 
+	degraded.apply(&out.Degraded, &out.LoadError)
+
 	for _, pkg := range pkgs {
 		path := normalizePackagePath(pkg)
 		if path == "" && pkg.Name != "" {
@@ -81,11 +85,59 @@ func ListSymbols(ctx context.Context, _ *mcp.CallToolRequest, input ListSymbolsI
 
 	mode := loadModeSyntaxTypesNamedFiles
 
-	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "ListSymbols")
+	// Persisted facts carry no signatures, so signature requests always take the regular load path.
+	if index := persistedIndexFor(ctx, input.Dir, mode, "ListSymbols"); index != nil && !input.WithSignatures && input.DependencyPackage == "" {
+		indexed, err := filterIndexedPackages(index, input.Package)
+		if err != nil {
+			return fail(ListSymbolsOutput{}, err)
+		}
+
+		for _, pkg := range indexed {
+			for _, file := range pkg.files {
+				for _, sym := range file.facts.Symbols {
+					switch sym.Kind {
+					case "func", "struct", "interface", "method":
+						sym.Package, sym.File = pkg.path, file.relPath
+						if sym.SymbolID != "" {
+							// Facts are computed per file and know the package by its name only.
+							sym.SymbolID = pkg.path + strings.TrimPrefix(sym.SymbolID, file.facts.Package)
+						}
+						if !input.WithFingerprints {
+							sym.Fingerprint = ""
+						}
+
+						symbols = append(symbols, sym)
+					}
+				}
+			}
+		}
+
+		sortSymbolsByPackage(symbols)
+
+		return nil, ListSymbolsOutput{GroupedSymbols: groupSymbolsByPackageAndFile(symbols)}, nil
+	}
+
+	var (
+		filteredPkgs []*packages.Package
+		degraded     *loadDegradation
+		dependency   *DependencyInfo
+		err          error
+	)
+
+	if input.DependencyPackage != "" {
+		filteredPkgs, dependency, err = loadDependencyPackage(ctx, input.Dir, input.DependencyPackage)
+	} else {
+		_, filteredPkgs, degraded, err = loadFilteredPackagesWithFallback(ctx, input.Dir, mode, input.Package, "ListSymbols")
+	}
+
 	if err != nil {
 		return fail(ListSymbolsOutput{}, err)
 	}
 
+	if dependency != nil {
+		input.Dir = dependency.Dir // file paths are reported relative to the dependency
+	}
+
 	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
 		pkgPath := normalizePackagePath(pkg)
 		if pkgPath == "" && file.Name != nil {
@@ -96,7 +148,12 @@ func ListSymbols(ctx context.Context, _ *mcp.CallToolRequest, input ListSymbolsI
 			pkgPath = "(unknown)"
 		}
 
-		for _, sym := range collectSymbols(file, pkg.Fset, pkgPath, relPath) {
+		opts := symbolOptions{fingerprints: input.WithFingerprints, signatures: input.WithSignatures}
+		if hasTypes(pkg) {
+			opts.info = pkg.TypesInfo
+		}
+
+		for _, sym := range collectSymbolsInternal(file, pkg.Fset, pkgPath, relPath, opts) {
 			switch sym.Kind {
 			case "func", "struct", "interface", "method":
 				symbols = append(symbols, sym)
@@ -108,13 +165,7 @@ func ListSymbols(ctx context.Context, _ *mcp.CallToolRequest, input ListSymbolsI
 		return fail(ListSymbolsOutput{}, err)
 	}
 
-	sort.Slice(symbols, func(i, j int) bool {
-		if symbols[i].Package == symbols[j].Package {
-			return symbols[i].Name < symbols[j].Name
-		}
-
-		return symbols[i].Package < symbols[j].Package
-	})
+	sortSymbolsByPackage(symbols)
 
 	// Group symbols by package and file for token efficiency
 	groupedSymbols := groupSymbolsByPackageAndFile(symbols)
@@ -122,10 +173,32 @@ func ListSymbols(ctx context.Context, _ *mcp.CallToolRequest, input ListSymbolsI
 	out := ListSymbolsOutput{
 		GroupedSymbols: groupedSymbols,
 	}
+	degraded.apply(&out.Degraded, &out.LoadError)
+	dependency.apply(&out.External, &out.Dependency)
 
 	return nil, out, nil
 }
 
+// sortSymbolsByPackage orders symbols by package, then by name; methods sharing a name are ordered by position.
+func sortSymbolsByPackage(symbols []Symbol) {
+	sort.Slice(symbols, func(i, j int) bool {
+		a, b := symbols[i], symbols[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+}
+
 // groupSymbolsByPackageAndFile groups symbols by package and file for token efficiency.
 func groupSymbolsByPackageAndFile(symbols []Symbol) []SymbolGroupByPackage {
 	packageMap := make(map[string]map[string][]SymbolInfo)
@@ -137,10 +210,16 @@ func groupSymbolsByPackageAndFile(symbols []Symbol) []SymbolGroupByPackage {
 		}
 
 		symbolInfo := SymbolInfo{
-			Kind:     sym.Kind,
-			Name:     sym.Name,
-			Line:     sym.Line,
-			Exported: sym.Exported,
+			Kind:            sym.Kind,
+			Name:            sym.Name,
+			SymbolID:        sym.SymbolID,
+			Line:            sym.Line,
+			Exported:        sym.Exported,
+			Fingerprint:     sym.Fingerprint,
+			Receiver:        sym.Receiver,
+			Signature:       sym.Signature,
+			Generic:         sym.Generic,
+			BuildConstraint: sym.BuildConstraint,
 		}
 
 		packageMap[sym.Package][sym.File] = append(packageMap[sym.Package][sym.File], symbolInfo)
@@ -189,10 +268,16 @@ func groupFunctionComplexityByFile(functions []FunctionComplexity) []FunctionCom
 
 		functionInfo := FunctionComplexityInfo{
 			Name:       fn.Name,
+			SymbolID:   fn.SymbolID,
 			Line:       fn.Line,
 			Lines:      fn.Lines,
 			Nesting:    fn.Nesting,
 			Cyclomatic: fn.Cyclomatic,
+			Cognitive:  fn.Cognitive,
+			Closure:    fn.Closure,
+
+			Recursive:      fn.Recursive,
+			RecursionCycle: fn.RecursionCycle,
 		}
 
 		fileMap[fn.File] = append(fileMap[fn.File], functionInfo)
@@ -284,16 +369,17 @@ func groupInterfacesByPackage(data map[string][]InterfaceInfo) []InterfaceGroupB
 	return result
 }
 
-// ListImports returns a list of all imported packages in Go files in the specified directory.
+// ListImports returns a list of all imported packages in Go files in the specified directory, or with
+// input.Aggregate a summary of the imports per external module.
 //
 // Parameters:
 //   - ctx: execution context
 //   - req: MCP tool request
-//   - input: input data specifying the directory to scan
+//   - input: input data specifying the directory to scan and whether to aggregate by module
 //
 // Returns:
 //   - MCP tool call result
-//   - list of found imports
+//   - list of found imports grouped by file, or the per-module aggregate
 //   - error if an error occurred while loading packages
 func ListImports(ctx context.Context, _ *mcp.CallToolRequest, input ListImportsInput) (
 	*mcp.CallToolResult,
@@ -308,15 +394,49 @@ func ListImports(ctx context.Context, _ *mcp.CallToolRequest, input ListImportsI
 
 	defer func() { logEnd("ListImports", start, len(out.Imports)) }()
 
+	// Modules are not kept in the persisted index, and a syntax-only fallback cannot resolve them.
+	if input.Aggregate {
+		aggregate, err := aggregateImports(ctx, input)
+		if err != nil {
+			return fail(out, err)
+		}
+
+		out.Aggregate = aggregate
+
+		return nil, out, nil
+	}
+
 	mode := loadModeBasicSyntax
 
 	flatImports := make([]Import, 0)
 
-	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "ListImports")
+	if index := persistedIndexFor(ctx, input.Dir, mode, "ListImports"); index != nil {
+		indexed, err := filterIndexedPackages(index, input.Package)
+		if err != nil {
+			return fail(out, err)
+		}
+
+		for _, pkg := range indexed {
+			for _, file := range pkg.files {
+				for _, imp := range file.facts.Imports {
+					imp.File = file.relPath
+					flatImports = append(flatImports, imp)
+				}
+			}
+		}
+
+		out.Imports = groupImportsByFile(flatImports)
+
+		return nil, out, nil
+	}
+
+	_, filteredPkgs, degraded, err := loadFilteredPackagesWithFallback(ctx, input.Dir, mode, input.Package, "ListImports")
 	if err != nil {
 		return fail(out, err)
 	}
 
+	degraded.apply(&out.Degraded, &out.LoadError)
+
 	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
 		for _, imp := range file.Imports {
 			path := strings.Trim(imp.Path.Value, `"`)
@@ -358,16 +478,66 @@ func ListInterfaces(ctx context.Context, _ *mcp.CallToolRequest, input ListInter
 
 	defer func() { logEnd("ListInterfaces", start, len(out.Interfaces)) }()
 
-	mode := loadModeBasicSyntax
+	if input.MinImplementations < 0 {
+		return fail(out, invalidInput("minImplementations must not be negative"))
+	}
+
+	// Implementation and usage counts need type information, which a syntax-only fallback cannot provide.
+	needCounts := input.MinImplementations > 0 || input.UsedAsParameter
+	mode := loadModeFor(loadModeBasicSyntax, needCounts)
 
 	interfacesByPackage := make(map[string][]InterfaceInfo)
 
-	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "ListInterfaces")
+	var (
+		pkgs, filteredPkgs []*packages.Package
+		dependency         *DependencyInfo
+		err                error
+	)
+
+	switch {
+	case input.DependencyPackage != "":
+		// Counts then cover the dependency package alone.
+		pkgs, dependency, err = loadDependencyPackage(ctx, input.Dir, input.DependencyPackage)
+		filteredPkgs = pkgs
+	case needCounts:
+		pkgs, filteredPkgs, err = loadFilteredPackages(ctx, input.Dir, mode, input.Package, "ListInterfaces")
+	default:
+		var degraded *loadDegradation
+
+		pkgs, filteredPkgs, degraded, err = loadFilteredPackagesWithFallback(ctx, input.Dir, mode, input.Package, "ListInterfaces")
+		degraded.apply(&out.Degraded, &out.LoadError)
+	}
+
 	if err != nil {
 		return fail(out, err)
 	}
 
+	if dependency != nil {
+		input.Dir = dependency.Dir // file paths are reported relative to the dependency
+		dependency.apply(&out.External, &out.Dependency)
+	}
+
+	var (
+		impls  *implementationIndex
+		usages map[*types.TypeName]int
+	)
+
+	if needCounts {
+		// Counts cover the whole module even when the listing is restricted to one package.
+		if impls, err = newImplementationIndex(ctx, pkgs); err != nil {
+			return fail(out, err)
+		}
+
+		if usages, err = interfaceUsageCounts(ctx, pkgs); err != nil {
+			return fail(out, err)
+		}
+	}
+
 	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if needCounts && pkg.TypesInfo == nil {
+			return errTypesNotLoaded
+		}
+
 		pkgKey := normalizePackagePath(pkg)
 		if pkgKey == "" && file.Name != nil {
 			pkgKey = file.Name.Name
@@ -384,11 +554,30 @@ func ListInterfaces(ctx context.Context, _ *mcp.CallToolRequest, input ListInter
 			}
 
 			if iface, ok := ts.Type.(*ast.InterfaceType); ok {
+				if input.ExportedOnly && !ts.Name.IsExported() {
+					return true
+				}
+
 				pos := symbolPos(pkg, ts)
 
 				ifInfo := InterfaceInfo{
 					Name: ts.Name.Name, File: relPath, Line: pos.Line, Methods: []InterfaceMethod{},
 				}
+
+				if needCounts {
+					tn, ok := pkg.TypesInfo.Defs[ts.Name].(*types.TypeName)
+					if !ok {
+						return true
+					}
+
+					ifInfo.ImplementationCount = impls.count(tn)
+					ifInfo.UsageCount = usages[tn]
+
+					if ifInfo.ImplementationCount < input.MinImplementations || (input.UsedAsParameter && ifInfo.UsageCount == 0) {
+						return true
+					}
+				}
+
 				if iface.Methods != nil {
 					for _, m := range iface.Methods.List {
 						if len(m.Names) > 0 {
@@ -415,6 +604,83 @@ func ListInterfaces(ctx context.Context, _ *mcp.CallToolRequest, input ListInter
 	return nil, out, nil
 }
 
+// interfaceUsageCounts counts, per named interface, the parameters and struct fields of pkgs whose type
+// is the interface, directly or as the element of a pointer, slice, array, map or channel. It fails only
+// when ctx is cancelled.
+func interfaceUsageCounts(ctx context.Context, pkgs []*packages.Package) (map[*types.TypeName]int, error) {
+	counts := make(map[*types.TypeName]int)
+
+	err := walkPackageFiles(ctx, pkgs, "", func(pkg *packages.Package, file *ast.File, _ string, _ int) error {
+		if pkg.TypesInfo == nil {
+			return nil
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			var fields *ast.FieldList
+
+			switch x := n.(type) {
+			case *ast.FuncType:
+				fields = x.Params
+			case *ast.StructType:
+				fields = x.Fields
+			}
+
+			if fields == nil {
+				return true
+			}
+
+			for _, field := range fields.List {
+				expr := field.Type
+				if ell, ok := expr.(*ast.Ellipsis); ok {
+					expr = ell.Elt
+				}
+
+				if tn := usedInterface(pkg.TypesInfo.TypeOf(expr)); tn != nil {
+					counts[tn] += max(1, len(field.Names))
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// usedInterface returns the named interface a parameter or field type refers to, looking through
+// pointers, slices, arrays, maps and channels; nil for any other type.
+func usedInterface(t types.Type) *types.TypeName {
+	for t != nil {
+		switch u := types.Unalias(t).(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Map:
+			t = u.Elem()
+		case *types.Chan:
+			t = u.Elem()
+		case *types.Named:
+			if _, ok := u.Underlying().(*types.Interface); ok {
+				return u.Origin().Obj()
+			}
+
+			return nil
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
 // ProjectSchema aggregates full structural metadata of a Go module,
 // including packages, symbols, interfaces, imports, and dependency graph.
 //
@@ -446,19 +712,36 @@ func ProjectSchema(ctx context.Context, _ *mcp.CallToolRequest, input ProjectSch
 		depth = "standard" // default level
 	}
 
-	// Adjust analysis mode based on depth
-	mode := loadModeBasic
-	if depth == "standard" || depth == "deep" {
-		mode |= packages.NeedImports | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
-	} else {
-		mode |= packages.NeedImports // minimal for summary
+	if input.MaxDepth < 0 {
+		return fail(out, invalidInput("maxDepth must not be negative, got %d", input.MaxDepth))
 	}
 
-	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
-	if err != nil {
-		logError("ProjectSchema", err, "failed to load packages")
+	maxDepth := input.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultEntryPointDepth
+	}
 
-		return fail(out, err)
+	// Only the standard and deep levels inspect declarations; summary needs names and imports.
+	mode := loadModeFor(loadModeImports, depth == "standard" || depth == "deep")
+
+	var pkgs []*packages.Package
+
+	// The summary depth only needs package names and imports, which the persisted index provides.
+	if depth != "standard" && depth != "deep" {
+		if index := persistedIndexFor(ctx, input.Dir, mode, "ProjectSchema"); index != nil {
+			pkgs = indexedPackageStubs(index)
+		}
+	}
+
+	if pkgs == nil {
+		var err error
+
+		pkgs, err = loadPackagesWithCache(ctx, input.Dir, mode)
+		if err != nil {
+			logError("ProjectSchema", err, "failed to load packages")
+
+			return fail(out, err)
+		}
 	}
 
 	// Read go.mod metadata
@@ -500,9 +783,7 @@ func ProjectSchema(ctx context.Context, _ *mcp.CallToolRequest, input ProjectSch
 			pkgInterfaceMethods := make(map[string][]string)
 			interfaceListed := make(map[string]struct{})
 
-			for i, file := range pkg.Syntax {
-				relPath := resolveFilePath(pkg, input.Dir, i, file)
-
+			err := walkPackageFiles(ctx, []*packages.Package{pkg}, input.Dir, func(_ *packages.Package, file *ast.File, relPath string, _ int) error {
 				for _, sym := range collectSymbols(file, pkg.Fset, pkgPath, relPath) {
 					switch sym.Kind {
 					case "struct":
@@ -526,6 +807,11 @@ func ProjectSchema(ctx context.Context, _ *mcp.CallToolRequest, input ProjectSch
 						}
 					}
 				}
+
+				return nil
+			})
+			if err != nil {
+				return fail(out, err)
 			}
 
 			for _, ifaceName := range symbols.Interfaces {
@@ -536,11 +822,15 @@ func ProjectSchema(ctx context.Context, _ *mcp.CallToolRequest, input ProjectSch
 			}
 		}
 
+		fileCount, testFileCount := countPackageFiles(packageSourceDir(pkg))
+
 		pkgMap[pkgPath] = ProjectPackage{
-			Path:    pkgPath,
-			Name:    pkg.Name,
-			Imports: imports,
-			Symbols: symbols,
+			Path:          pkgPath,
+			Name:          pkg.Name,
+			FileCount:     fileCount,
+			TestFileCount: testFileCount,
+			Imports:       imports,
+			Symbols:       symbols,
 		}
 	}
 
@@ -558,10 +848,12 @@ func ProjectSchema(ctx context.Context, _ *mcp.CallToolRequest, input ProjectSch
 	sort.Strings(out.ExternalDeps)
 
 	out.DependencyGraph = depGraph
+	out.PackageDirs = packageDirs(input.Dir, pkgs)
 
-	// Only include interfaces if we did detailed analysis
+	// Only include interfaces and entry points if we did detailed analysis
 	if depth == "standard" || depth == "deep" {
 		out.Interfaces = allInterfaces
+		out.EntryPoints = projectEntryPoints(input.Dir, pkgs, maxDepth)
 	}
 
 	out.Summary = ProjectSummary{
@@ -574,33 +866,26 @@ func ProjectSchema(ctx context.Context, _ *mcp.CallToolRequest, input ProjectSch
 	return nil, out, nil
 }
 
-// readGoModInfo reads the module name and Go version from go.mod located in the given directory.
+// readGoModInfo reads the module name and Go version from go.mod located in the given directory,
+// parsed like getModuleInfo parses it.
 //
 // Returns:
 //   - moduleName: value after "module" directive
 //   - goVersion: value after "go" directive
 func readGoModInfo(dir string) (moduleName, goVersion string) {
-	modFile := filepath.Join(dir, "go.mod")
-
-	data, err := os.ReadFile(modFile)
+	mf, err := parseGoMod(dir)
 	if err != nil {
-		log.Debug().Err(err).Str("file", modFile).Msg("go.mod not found or unreadable")
+		log.Debug().Err(err).Str("dir", dir).Msg("go.mod not found or unreadable")
 
 		return "", ""
 	}
 
-	lines := strings.SplitSeq(string(data), "\n")
-	for line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "module ") {
-			moduleName = strings.TrimSpace(strings.TrimPrefix(line, "module"))
-
-			continue
-		}
+	if mf.Module != nil {
+		moduleName = mf.Module.Mod.Path
+	}
 
-		if strings.HasPrefix(line, "go ") {
-			goVersion = strings.TrimSpace(strings.TrimPrefix(line, "go"))
-		}
+	if mf.Go != nil {
+		goVersion = mf.Go.Version
 	}
 
 	if moduleName == "" {
@@ -2,6 +2,9 @@ package tools_test
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -96,6 +99,105 @@ func TestListSymbols_WithInvalidPackage(t *testing.T) {
 	}
 }
 
+func TestListSymbols_WithSignatures(t *testing.T) {
+	t.Parallel()
+
+	in := tools.ListSymbolsInput{Dir: testDir(), Package: "sample", WithSignatures: true}
+
+	_, out, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	symbols := make(map[string]tools.SymbolInfo)
+
+	for _, pkg := range out.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				symbols[file.File+":"+sym.Name] = sym
+			}
+		}
+	}
+
+	cases := []struct {
+		key       string
+		receiver  string
+		signature string
+		generic   bool
+	}{
+		{"widget.go:Size", "*Widget", "() int", false},
+		{"print.go:Logf", "", "(string, ...any) (int, error)", false},
+		{"generic_store.go:Get", "*Store[T]", "(string) (T, bool)", true},
+		{"store.go:Storage.Save", "Storage", "(string, string) error", false},
+	}
+
+	for _, tc := range cases {
+		sym, ok := symbols[tc.key]
+		if !ok {
+			t.Errorf("expected symbol %s, got %v", tc.key, out.GroupedSymbols)
+
+			continue
+		}
+
+		if sym.Receiver != tc.receiver || sym.Signature != tc.signature || sym.Generic != tc.generic {
+			t.Errorf("%s: expected receiver %q, signature %q, generic %v, got %+v",
+				tc.key, tc.receiver, tc.signature, tc.generic, sym)
+		}
+	}
+
+	if sym := symbols["generic_store.go:Store"]; !sym.Generic {
+		t.Errorf("expected the generic Store type to be marked generic, got %+v", sym)
+	}
+
+	_, plain, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: testDir(), Package: "sample"})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	for _, pkg := range plain.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				if sym.Signature != "" || sym.Receiver != "" {
+					t.Fatalf("expected no signatures without withSignatures, got %+v", sym)
+				}
+			}
+		}
+	}
+}
+
+func TestListSymbols_BuildConstraint(t *testing.T) {
+	t.Parallel()
+
+	_, out, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: testDir()})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	want := map[string]string{
+		"platform_linux.go":   "linux",
+		"platform_other.go":   "!linux && !windows",
+		"platform_windows.go": "windows",
+	}
+
+	found := false
+
+	for _, group := range out.GroupedSymbols {
+		for _, file := range group.Files {
+			for _, sym := range file.Symbols {
+				if sym.BuildConstraint != want[file.File] {
+					t.Errorf("%s %s: expected build constraint %q, got %q", file.File, sym.Name, want[file.File], sym.BuildConstraint)
+				}
+
+				found = found || sym.Name == "PlatformName"
+			}
+		}
+	}
+
+	if !found {
+		t.Fatal("expected the host platform's PlatformName to be listed")
+	}
+}
+
 func TestListSymbols_WithPackageFilter(t *testing.T) {
 	t.Parallel()
 
@@ -180,6 +282,73 @@ func TestListImports_WithInvalidPackage(t *testing.T) {
 	}
 }
 
+func TestListImports_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	// The repository's own module and its direct dependencies.
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("abs: %v", err)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		t.Fatalf("read go.mod: %v", err)
+	}
+
+	_, out, err := tools.ListImports(context.Background(), &mcp.CallToolRequest{}, tools.ListImportsInput{Dir: root, Aggregate: true})
+	if err != nil {
+		t.Fatalf("ListImports error: %v", err)
+	}
+
+	agg := out.Aggregate
+	if agg == nil || len(out.Imports) != 0 {
+		t.Fatalf("expected only the aggregate, got %+v", out)
+	}
+
+	modules := make(map[string]tools.ImportedModule)
+	for _, mod := range agg.Modules {
+		modules[mod.Module] = mod
+
+		if !strings.Contains(string(goMod), mod.Module+" "+mod.Version) {
+			t.Errorf("expected %s at the version required by go.mod, got %q", mod.Module, mod.Version)
+		}
+	}
+
+	cases := []struct {
+		module, path string
+		files        int
+	}{
+		{"github.com/fsnotify/fsnotify", "github.com/fsnotify/fsnotify", 1},
+		{"github.com/pmezard/go-difflib", "github.com/pmezard/go-difflib/difflib", 2},
+	}
+
+	for _, tc := range cases {
+		mod, ok := modules[tc.module]
+		if !ok {
+			t.Fatalf("expected module %s, got %+v", tc.module, agg.Modules)
+		}
+
+		if len(mod.Paths) != 1 || mod.Paths[0].Path != tc.path || mod.Files != tc.files || mod.Packages != 1 ||
+			len(mod.TopImporters) != 1 || mod.TopImporters[0] != (tools.ImporterCount{Package: "go-navigator/internal/tools", Files: tc.files}) {
+			t.Errorf("unexpected aggregate for %s: %+v", tc.module, mod)
+		}
+	}
+
+	zerolog, ok := modules["github.com/rs/zerolog"]
+	if !ok || zerolog.Packages != 2 || zerolog.Files < 2 {
+		t.Fatalf("expected zerolog imported by the command and the tools package, got %+v", zerolog)
+	}
+
+	if agg.Stdlib.Paths == 0 || agg.Internal.Paths == 0 || len(agg.Unresolved) != 0 {
+		t.Fatalf("expected stdlib and internal counts without unresolved paths, got %+v", agg)
+	}
+
+	if _, ok := modules["go-navigator"]; ok {
+		t.Fatalf("the module's own packages must not be listed as a dependency")
+	}
+}
+
 func TestListImports_WithPackageFilter(t *testing.T) {
 	t.Parallel()
 
@@ -283,6 +452,86 @@ func TestListInterfaces_HandlesEmptyInterface(t *testing.T) {
 	t.Fatalf("expected to find Empty interface in testdata, but it was missing")
 }
 
+const scopedInterfacesSource = `package lang
+
+type Shape interface{ Area() float64 }
+
+type Solid interface {
+	Shape
+	Volume() float64
+}
+
+type namer interface{ name() string }
+
+type Square struct{ side float64 }
+
+func (s Square) Area() float64 { return s.side * s.side }
+
+type Cube struct{ Square }
+
+func (c Cube) Volume() float64 { return c.side * c.Area() }
+
+type Canvas struct {
+	shapes []Shape
+}
+
+func Total(shapes ...Shape) float64 { return 0 }
+
+func label(n namer) string { return n.name() }
+`
+
+func TestListInterfaces_ScopedByImplementationsAndUsage(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"shapes.go": scopedInterfacesSource})
+
+	list := func(in tools.ListInterfacesInput) map[string]tools.InterfaceInfo {
+		t.Helper()
+
+		in.Dir = dir
+
+		_, out, err := tools.ListInterfaces(context.Background(), &mcp.CallToolRequest{}, in)
+		if err != nil {
+			t.Fatalf("ListInterfaces error: %v", err)
+		}
+
+		found := make(map[string]tools.InterfaceInfo)
+
+		for _, group := range out.Interfaces {
+			for _, iface := range group.Interfaces {
+				found[iface.Name] = iface
+			}
+		}
+
+		return found
+	}
+
+	// Shape: Square, Cube and the extending Solid; Solid: Cube.
+	found := list(tools.ListInterfacesInput{MinImplementations: 1})
+	if len(found) != 2 || found["Shape"].ImplementationCount != 3 || found["Solid"].ImplementationCount != 1 {
+		t.Fatalf("expected Shape (3) and Solid (1), got %+v", found)
+	}
+
+	if found["Shape"].UsageCount != 2 || found["Solid"].UsageCount != 0 {
+		t.Fatalf("expected Shape used by a field and a variadic parameter, got %+v", found)
+	}
+
+	found = list(tools.ListInterfacesInput{UsedAsParameter: true})
+	if len(found) != 2 || found["namer"].UsageCount != 1 || found["namer"].ImplementationCount != 0 {
+		t.Fatalf("expected Shape and namer, got %+v", found)
+	}
+
+	found = list(tools.ListInterfacesInput{UsedAsParameter: true, ExportedOnly: true})
+	if _, ok := found["Shape"]; !ok || len(found) != 1 {
+		t.Fatalf("expected only Shape, got %+v", found)
+	}
+
+	found = list(tools.ListInterfacesInput{ExportedOnly: true})
+	if len(found) != 2 || found["Shape"].ImplementationCount != 0 {
+		t.Fatalf("expected Shape and Solid without counts, got %+v", found)
+	}
+}
+
 func TestListInterfaces_WithInvalidDir(t *testing.T) {
 	t.Parallel()
 
@@ -413,6 +662,98 @@ func TestProjectSchema_WithSummaryDepth(t *testing.T) {
 	}
 }
 
+func TestProjectSchema_EntryPoints(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"cmd/server/main.go": `package main
+
+import (
+	"flag"
+	"time"
+
+	"lang/internal/api"
+	"lang/internal/store"
+)
+
+var addr = flag.String("addr", ":8080", "listen address")
+
+func main() {
+	var timeout time.Duration
+	flag.DurationVar(&timeout, "timeout", time.Second, "request timeout")
+	flag.Parse()
+
+	s := store.Open()
+	api.NewServer(s).Run(*addr)
+}
+`,
+		"internal/api/api.go": `package api
+
+import "lang/internal/store"
+
+type Server struct{ s *store.Store }
+
+func NewServer(s *store.Store) *Server { return &Server{s: s} }
+
+func (srv *Server) Run(addr string) {}
+`,
+		"internal/store/store.go": `package store
+
+import "lang/internal/store/sqlite"
+
+type Store struct{}
+
+func Open() *Store { sqlite.Connect(); return &Store{} }
+`,
+		"internal/store/sqlite/sqlite.go": "package sqlite\n\nfunc Connect() {}\n",
+	})
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.ProjectSchema(ctx, req, tools.ProjectSchemaInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("ProjectSchema error: %v", err)
+	}
+
+	if len(out.EntryPoints) != 1 {
+		t.Fatalf("expected one entry point, got %+v", out.EntryPoints)
+	}
+
+	ep := out.EntryPoints[0]
+	if ep.Package != "lang/cmd/server" || ep.Dir != "cmd/server" {
+		t.Errorf("expected lang/cmd/server in cmd/server, got %s in %s", ep.Package, ep.Dir)
+	}
+
+	calls := strings.Join(ep.Calls, ",")
+	if calls != "flag.DurationVar,flag.Parse,lang/internal/store.Open,lang/internal/api.NewServer,lang/internal/api.Server.Run" {
+		t.Errorf("unexpected calls %v", ep.Calls)
+	}
+
+	if len(ep.Flags) != 2 || ep.Flags[0].Name != "addr" || ep.Flags[1].Name != "timeout" ||
+		ep.Flags[1].Func != "flag.DurationVar" || ep.Flags[1].Line != 15 {
+		t.Errorf("expected the addr and timeout flags, got %+v", ep.Flags)
+	}
+
+	var wiring []string
+	for _, w := range ep.Wiring {
+		wiring = append(wiring, fmt.Sprintf("%s@%d", w.Path, w.Depth))
+	}
+
+	if strings.Join(wiring, ",") != "lang/internal/api@1,lang/internal/store@1,lang/internal/store/sqlite@2" {
+		t.Errorf("unexpected wiring %v", wiring)
+	}
+
+	_, out, err = tools.ProjectSchema(ctx, req, tools.ProjectSchemaInput{Dir: dir, MaxDepth: 1})
+	if err != nil || len(out.EntryPoints) != 1 || len(out.EntryPoints[0].Wiring) != 2 {
+		t.Errorf("expected only direct imports with maxDepth 1, got %+v (err %v)", out.EntryPoints, err)
+	}
+
+	_, out, err = tools.ProjectSchema(ctx, req, tools.ProjectSchemaInput{Dir: dir, Depth: "summary"})
+	if err != nil || out.EntryPoints != nil {
+		t.Errorf("expected no entry points at summary depth, got %+v (err %v)", out.EntryPoints, err)
+	}
+}
+
 func TestProjectSchema_WithInvalidDir(t *testing.T) {
 	t.Parallel()
 
@@ -437,3 +778,114 @@ func BenchmarkListSymbols(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkListInterfaces_MinImplementations(b *testing.B) {
+	var src strings.Builder
+
+	src.WriteString("package lang\n")
+
+	// 300 interfaces of one or two methods and 600 types implementing one or two of them each.
+	for i := range 300 {
+		fmt.Fprintf(&src, "\ntype I%d interface{ M%d() }\n", i, i)
+		fmt.Fprintf(&src, "\ntype J%d interface{ M%d(); M%d() }\n", i, i, (i+1)%300)
+	}
+
+	for i := range 600 {
+		fmt.Fprintf(&src, "\ntype T%d struct{}\n\nfunc (T%d) M%d() {}\n", i, i, i%300)
+
+		if i%2 == 0 {
+			fmt.Fprintf(&src, "\nfunc (T%d) M%d() {}\n", i, (i%300+1)%300)
+		}
+	}
+
+	dir := writeLanguageModule(b, "1.22", map[string]string{"ifaces.go": src.String()})
+	in := tools.ListInterfacesInput{Dir: dir, MinImplementations: 2}
+
+	for b.Loop() {
+		_, _, err := tools.ListInterfaces(context.Background(), &mcp.CallToolRequest{}, in)
+		if err != nil {
+			b.Fatalf("ListInterfaces error: %v", err)
+		}
+	}
+}
+
+// writeUnresolvableModule creates a module whose go.mod cannot be resolved offline:
+// it requires a toolchain that is not installed and toolchain downloads are disabled.
+func writeUnresolvableModule(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module offline\n\ngo 1.999\n",
+		"greet.go": `package offline
+
+// Greeter says hello.
+type Greeter struct {
+	Name string
+}
+
+// Greet returns a greeting.
+func Greet(g Greeter) string {
+	return "hello " + g.Name
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	t.Setenv("GOTOOLCHAIN", "local")
+
+	return dir
+}
+
+func TestListSymbols_OfflineSyntaxFallback(t *testing.T) {
+	dir := writeUnresolvableModule(t)
+
+	_, out, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("expected syntax-only fallback, got error: %v", err)
+	}
+
+	if out.Degraded != "syntax-only" {
+		t.Errorf("expected degraded=syntax-only, got %q", out.Degraded)
+	}
+
+	if out.LoadError == "" {
+		t.Errorf("expected original load error to be reported")
+	}
+
+	found := false
+
+	for _, pkg := range out.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				if sym.Name == "Greet" {
+					found = true
+				}
+			}
+		}
+	}
+
+	if !found {
+		t.Errorf("expected Greet in syntax-only symbols, got %+v", out.GroupedSymbols)
+	}
+
+	_, signed, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir, WithSignatures: true})
+	if err != nil {
+		t.Fatalf("expected syntax-only fallback with signatures, got error: %v", err)
+	}
+
+	for _, pkg := range signed.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				if sym.Name == "Greet" && sym.Signature != "(Greeter) string" {
+					t.Errorf("expected the syntax-rendered signature of Greet, got %+v", sym)
+				}
+			}
+		}
+	}
+}
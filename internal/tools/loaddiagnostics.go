@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultLoadTimeout bounds a single packages.Load unless ConfigureLoadTimeout changes it.
+const defaultLoadTimeout = 2 * time.Minute
+
+// slowLoadThreshold is the load duration above which the go command invocations and their timings
+// are reported as diagnostics.
+const slowLoadThreshold = 10 * time.Second
+
+// maxLoadDiagnostics caps the diagnostics kept per load; the rest is summarized in one line.
+const maxLoadDiagnostics = 20
+
+// loadTimeout holds the current bound of packages.Load as a time.Duration; zero disables it.
+var loadTimeout atomic.Int64
+
+func init() {
+	loadTimeout.Store(int64(defaultLoadTimeout))
+}
+
+// ConfigureLoadTimeout bounds every package load. A load exceeding it is cancelled and fails with
+// LOAD_FAILED and the diagnostics captured so far. Zero disables the bound.
+//
+// Parameters:
+//   - timeout: maximum duration of a load, 0 for none
+//
+// Returns:
+//   - error if timeout is negative
+func ConfigureLoadTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("load timeout must not be negative: %s", timeout)
+	}
+
+	loadTimeout.Store(int64(timeout))
+
+	return nil
+}
+
+// driverLog records what go/packages logs through Config.Logf: the go command invocations and their
+// durations. Every line is also logged at debug level.
+type driverLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (d *driverLog) logf(format string, args ...any) {
+	msg := strings.TrimSpace(fmt.Sprintf(format, args...))
+	log.Debug().Str("driver", "go/packages").Msg(msg)
+
+	d.mu.Lock()
+	d.lines = append(d.lines, msg)
+	d.mu.Unlock()
+}
+
+func (d *driverLog) snapshot() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return slices.Clone(d.lines)
+}
+
+// packageLoadDiagnostics collects the package errors of a load, a note when the pattern matched no
+// packages, and the driver log when the load was slow.
+func packageLoadDiagnostics(dir string, patterns []string, pkgs []*packages.Package, driver *driverLog, elapsed time.Duration) []string {
+	var diags []string
+
+	if len(pkgs) == 0 {
+		diags = append(diags, fmt.Sprintf("pattern %s matched no packages in %s", strings.Join(patterns, " "), dir))
+	}
+
+	// Errors of dependencies, e.g. an import no module provides, explain failures of the packages using them.
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, e := range pkg.Errors {
+			diags = append(diags, pkg.ID+": "+e.Error())
+		}
+	})
+
+	if elapsed > slowLoadThreshold {
+		diags = append(diags, fmt.Sprintf("loading packages took %s", elapsed.Round(time.Millisecond)))
+		diags = append(diags, driver.snapshot()...)
+	}
+
+	return capLoadDiagnostics(diags)
+}
+
+// capLoadDiagnostics removes duplicates and keeps at most maxLoadDiagnostics lines.
+func capLoadDiagnostics(diags []string) []string {
+	seen := make(map[string]struct{}, len(diags))
+	unique := diags[:0]
+
+	for _, d := range diags {
+		if _, ok := seen[d]; ok {
+			continue
+		}
+
+		seen[d] = struct{}{}
+		unique = append(unique, d)
+	}
+
+	if len(unique) > maxLoadDiagnostics {
+		more := len(unique) - maxLoadDiagnostics
+		unique = append(unique[:maxLoadDiagnostics], fmt.Sprintf("... and %d more", more))
+	}
+
+	if len(unique) == 0 {
+		return nil
+	}
+
+	return unique
+}
+
+// loadFailure turns a failed packages.Load into a tool error carrying the driver log. A load cancelled
+// by the load timeout, rather than by the caller, fails with LOAD_FAILED.
+func loadFailure(ctx, loadCtx context.Context, err error, driver *driverLog) error {
+	if ctx.Err() != nil {
+		return NewToolError(CodeCancelled, err)
+	}
+
+	diags := capLoadDiagnostics(driver.snapshot())
+
+	if errors.Is(loadCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("loading packages timed out after %s: %w", time.Duration(loadTimeout.Load()), err)
+		if len(diags) > 0 {
+			err = fmt.Errorf("%w (last driver activity: %s)", err, diags[len(diags)-1])
+		}
+	}
+
+	te := NewToolError(CodeLoadFailed, err)
+	if len(diags) > 0 {
+		te.Details = &ToolErrorDetails{Diagnostics: diags}
+	}
+
+	return te
+}
+
+// loadDiagnosticsKey is the context key of the collector installed by WithLoadDiagnostics.
+type loadDiagnosticsKey struct{}
+
+// loadDiagnosticsCollector gathers the diagnostics of every load a tool call performs.
+type loadDiagnosticsCollector struct {
+	mu    sync.Mutex
+	diags []string
+}
+
+// WithLoadDiagnostics returns a context in which package loads, including ones answered from the cache,
+// record their diagnostics, and a function returning what was recorded.
+//
+// Parameters:
+//   - ctx: parent context, typically that of a tool call
+//
+// Returns:
+//   - the context to pass to the tool
+//   - function returning the distinct diagnostics recorded so far, or nil
+func WithLoadDiagnostics(ctx context.Context) (context.Context, func() []string) {
+	c := &loadDiagnosticsCollector{}
+
+	return context.WithValue(ctx, loadDiagnosticsKey{}, c), func() []string {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		return capLoadDiagnostics(slices.Clone(c.diags))
+	}
+}
+
+// reportLoadDiagnostics adds diagnostics to the collector of ctx, if any.
+func reportLoadDiagnostics(ctx context.Context, diags []string) {
+	c, ok := ctx.Value(loadDiagnosticsKey{}).(*loadDiagnosticsCollector)
+	if !ok || len(diags) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.diags = append(c.diags, diags...)
+	c.mu.Unlock()
+}
@@ -0,0 +1,69 @@
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestLoadDiagnostics_PackageErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"lang.go": "package lang\n\nimport _ \"example.com/missing\"\n",
+	})
+
+	for _, call := range []string{"load", "cache hit"} {
+		ctx, diagnostics := tools.WithLoadDiagnostics(context.Background())
+
+		if _, _, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir}); err != nil {
+			t.Fatalf("%s: ListSymbols error: %v", call, err)
+		}
+
+		diags := diagnostics()
+		if !slices.ContainsFunc(diags, func(d string) bool { return strings.Contains(d, "example.com/missing") }) {
+			t.Fatalf("%s: expected a diagnostic for the missing import, got %q", call, diags)
+		}
+	}
+}
+
+func TestLoadDiagnostics_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"README": "no Go files\n"})
+	ctx, diagnostics := tools.WithLoadDiagnostics(context.Background())
+
+	_, _, _ = tools.ListPackages(ctx, &mcp.CallToolRequest{}, tools.ListPackagesInput{Dir: dir})
+
+	if diags := diagnostics(); len(diags) == 0 || !strings.Contains(diags[0], "matched no packages") {
+		t.Fatalf("expected an unmatched pattern diagnostic, got %q", diags)
+	}
+}
+
+// Not parallel: the load timeout is global, and parallel tests only start once this one has returned.
+func TestLoadTimeout_FailsWithLoadFailed(t *testing.T) {
+	if err := tools.ConfigureLoadTimeout(-time.Second); err == nil {
+		t.Fatalf("expected a negative timeout to be rejected")
+	}
+
+	if err := tools.ConfigureLoadTimeout(time.Nanosecond); err != nil {
+		t.Fatalf("ConfigureLoadTimeout error: %v", err)
+	}
+
+	t.Cleanup(func() { _ = tools.ConfigureLoadTimeout(2 * time.Minute) })
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": "package lang\n"})
+
+	_, _, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+
+	var te *tools.ToolError
+	if !errors.As(err, &te) || te.Code != tools.CodeLoadFailed || !strings.Contains(te.Message, "timed out after 1ns") {
+		t.Fatalf("expected LOAD_FAILED for a timed out load, got %v", err)
+	}
+}
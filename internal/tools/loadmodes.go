@@ -1,11 +1,133 @@
 package tools
 
-import "golang.org/x/tools/go/packages"
+import (
+	"errors"
+	"fmt"
+	"strconv"
 
+	"golang.org/x/tools/go/packages"
+)
+
+// Load modes used by the tools. Every tool picks one of these constants instead of combining
+// packages.Need* bits inline, so the guarantees below hold for whatever the tool receives:
+// modes containing NeedTypesInfo always yield packages with non-nil Types and TypesInfo
+// (enforced by checkLoadedTypes), all other modes must be treated as syntax-only.
 const (
-	loadModeBasic                 packages.LoadMode = packages.NeedName | packages.NeedCompiledGoFiles
-	loadModeSyntaxTypes                             = packages.NeedSyntax | packages.NeedTypes | packages.NeedCompiledGoFiles | packages.NeedTypesInfo
-	loadModeSyntaxTypesNamed                        = loadModeSyntaxTypes | packages.NeedName
-	loadModeBasicSyntax                             = loadModeBasic | packages.NeedSyntax
-	loadModeSyntaxTypesNamedFiles                   = loadModeSyntaxTypesNamed | packages.NeedFiles
+	// loadModeBasic guarantees Name, PkgPath and CompiledGoFiles; no syntax or types.
+	loadModeBasic packages.LoadMode = packages.NeedName | packages.NeedCompiledGoFiles
+	// loadModeImports extends loadModeBasic with the Imports map; no syntax or types.
+	loadModeImports = loadModeBasic | packages.NeedImports
+	// loadModeBasicSyntax extends loadModeBasic with parsed Syntax; no types.
+	loadModeBasicSyntax = loadModeBasic | packages.NeedSyntax
+	// loadModeOfflineSyntax is loadModeBasicSyntax plus GoFiles, used by the offline fallback; no types.
+	loadModeOfflineSyntax = loadModeBasicSyntax | packages.NeedFiles
+	// loadModeSyntaxTypes guarantees Syntax, Types and TypesInfo; Name and PkgPath are not requested.
+	loadModeSyntaxTypes = packages.NeedSyntax | packages.NeedTypes | packages.NeedCompiledGoFiles | packages.NeedTypesInfo
+	// loadModeSyntaxTypesFiles extends loadModeSyntaxTypes with GoFiles.
+	loadModeSyntaxTypesFiles = loadModeSyntaxTypes | packages.NeedFiles
+	// loadModeSyntaxTypesNamed extends loadModeSyntaxTypes with Name and PkgPath.
+	loadModeSyntaxTypesNamed = loadModeSyntaxTypes | packages.NeedName
+	// loadModeSyntaxTypesNamedFiles extends loadModeSyntaxTypesNamed with GoFiles.
+	loadModeSyntaxTypesNamedFiles = loadModeSyntaxTypesNamed | packages.NeedFiles
+	// loadModeAll is the union of the modes above; a cached load with it answers every tool.
+	loadModeAll = loadModeSyntaxTypesNamedFiles | packages.NeedImports
+	// loadModeDependency extends loadModeSyntaxTypesNamedFiles with Module, for dependencyPackage loads.
+	loadModeDependency = loadModeSyntaxTypesNamedFiles | packages.NeedModule
+	// loadModeBasicSyntaxModule extends loadModeBasicSyntax with Module; no types.
+	loadModeBasicSyntaxModule = loadModeBasicSyntax | packages.NeedModule
+	// loadModeModule guarantees Name, PkgPath and Module only, to resolve import paths to modules.
+	loadModeModule = packages.NeedName | packages.NeedModule
+	// loadModeImportGraph extends loadModeImports with GoFiles, Module and the whole transitive import
+	// graph (NeedDeps: Imports hold loaded dependencies, not stubs); no syntax or types.
+	loadModeImportGraph = loadModeImports | packages.NeedFiles | packages.NeedModule | packages.NeedDeps
 )
+
+// errTypesNotLoaded is returned instead of dereferencing missing type information,
+// e.g. when packages were loaded with a syntax-only mode.
+var errTypesNotLoaded = errors.New("type information not loaded")
+
+// loadModeFor extends a base mode with syntax and type information when the caller needs types.
+//
+// Parameters:
+//   - base: mode without type information (e.g. loadModeImports)
+//   - needTypes: whether the caller will read Syntax, Types or TypesInfo
+//
+// Returns:
+//   - base, or base with NeedSyntax, NeedTypes and NeedTypesInfo added
+func loadModeFor(base packages.LoadMode, needTypes bool) packages.LoadMode {
+	if needTypes {
+		return base | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+	}
+
+	return base
+}
+
+// modeNeedsTypes reports whether a load mode promises type information.
+func modeNeedsTypes(mode packages.LoadMode) bool {
+	return mode&packages.NeedTypesInfo != 0
+}
+
+// hasTypes reports whether a package carries both Types and TypesInfo.
+func hasTypes(pkg *packages.Package) bool {
+	return pkg != nil && pkg.Types != nil && pkg.TypesInfo != nil
+}
+
+// checkLoadedTypes verifies that packages loaded with a typed mode actually carry type information.
+//
+// Parameters:
+//   - pkgs: loaded packages
+//   - mode: mode the packages were requested with
+//
+// Returns:
+//   - an error wrapping errTypesNotLoaded naming the first package without types (a TYPE_ERRORS_PRESENT
+//     tool error if type checking reported errors for it), or nil
+func checkLoadedTypes(pkgs []*packages.Package, mode packages.LoadMode) error {
+	if !modeNeedsTypes(mode) {
+		return nil
+	}
+
+	for _, pkg := range pkgs {
+		if !hasTypes(pkg) {
+			err := fmt.Errorf("%w for package %s (load mode %s)", errTypesNotLoaded, pkg.ID, loadModeName(mode))
+			if len(pkg.TypeErrors) > 0 {
+				return NewToolError(CodeTypeErrorsPresent, fmt.Errorf("%w: %v", err, pkg.TypeErrors[0]))
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadModeName returns the name of a known load mode for diagnostics and falls back to its numeric value.
+func loadModeName(mode packages.LoadMode) string {
+	switch mode {
+	case loadModeBasic:
+		return "basic"
+	case loadModeImports:
+		return "imports"
+	case loadModeSyntaxTypes:
+		return "syntaxTypes"
+	case loadModeSyntaxTypesFiles:
+		return "syntaxTypesFiles"
+	case loadModeSyntaxTypesNamed:
+		return "syntaxTypesNamed"
+	case loadModeBasicSyntax:
+		return "basicSyntax"
+	case loadModeSyntaxTypesNamedFiles:
+		return "syntaxTypesNamedFiles"
+	case loadModeOfflineSyntax:
+		return "offlineSyntax"
+	case loadModeAll:
+		return "all"
+	case loadModeDependency:
+		return "dependency"
+	case loadModeBasicSyntaxModule:
+		return "basicSyntaxModule"
+	case loadModeModule:
+		return "module"
+	default:
+		return "mode" + strconv.Itoa(int(mode))
+	}
+}
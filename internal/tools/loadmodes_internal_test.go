@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// seedWeakPackages loads a copy of the sample module with the weakest load mode and serves those
+// packages for every mode the tools request, simulating a load that lost its type information.
+func seedWeakPackages(t *testing.T) string {
+	t.Helper()
+
+	_, filename, _, _ := runtime.Caller(0)
+
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join(filepath.Dir(filename), "testdata", "sample"))); err != nil {
+		t.Fatalf("copy sample: %v", err)
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: loadModeBasic, Dir: dir}, "./...")
+	if err != nil {
+		t.Fatalf("load weak packages: %v", err)
+	}
+
+	modes := []packages.LoadMode{
+		loadModeBasic,
+		loadModeImports,
+		loadModeBasicSyntax,
+		loadModeSyntaxTypes,
+		loadModeSyntaxTypesFiles,
+		loadModeSyntaxTypesNamed,
+		loadModeSyntaxTypesNamedFiles,
+		loadModeFor(loadModeImports, true),
+		loadModeAll,
+		loadModeImportGraph,
+	}
+
+	packageCache.Lock()
+	defer packageCache.Unlock()
+
+	for _, mode := range modes {
+		for _, includeTests := range []bool{false, true} {
+			packageCache.pkgs[makeCacheKey(dir, mode, includeTests, false)] = PackageCacheItem{
+				Packages:      pkgs,
+				LastAccess:    time.Now(),
+				LastFileCheck: time.Now(),
+				CheckValidFor: time.Hour,
+				Mode:          mode,
+				IncludeTests:  includeTests,
+			}
+		}
+	}
+
+	return dir
+}
+
+// callTool adapts a tool handler to a uniform call that drops its output.
+func callTool[In, Out any](handler func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, Out, error), input In) func() error {
+	return func() error {
+		_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+		return err
+	}
+}
+
+func TestTools_WeakestLoadModeDoesNotPanic(t *testing.T) {
+	t.Parallel()
+
+	dir := seedWeakPackages(t)
+	file := filepath.Join(dir, "sample.go")
+
+	cases := []struct {
+		name  string
+		call  func() error
+		typed bool
+	}{
+		{"ListPackages", callTool(ListPackages, ListPackagesInput{Dir: dir}), false},
+		{"ListSymbols", callTool(ListSymbols, ListSymbolsInput{Dir: dir}), true},
+		{"ListImports", callTool(ListImports, ListImportsInput{Dir: dir}), false},
+		{"ListInterfaces", callTool(ListInterfaces, ListInterfacesInput{Dir: dir}), false},
+		{"ProjectSchema", callTool(ProjectSchema, ProjectSchemaInput{Dir: dir, Depth: "deep"}), true},
+		{"FindReferences", callTool(FindReferences, FindReferencesInput{Dir: dir, Ident: "Foo"}), true},
+		{"FindBestContext", callTool(FindBestContext, FindBestContextInput{Dir: dir, Ident: "Foo"}), true},
+		{"FindDefinitions", callTool(FindDefinitions, FindDefinitionsInput{Dir: dir, Ident: "Foo"}), true},
+		{"FindImplementations", callTool(FindImplementations, FindImplementationsInput{Dir: dir, Name: "Shape"}), true},
+		{"FindConstructions", callTool(FindConstructions, FindConstructionsInput{Dir: dir, TypeName: "Point"}), true},
+		{"AnalyzeComplexity", callTool(AnalyzeComplexity, AnalyzeComplexityInput{Dir: dir}), true},
+		{"DeadCode", callTool(DeadCode, DeadCodeInput{Dir: dir}), true},
+		{"AnalyzeDependencies", callTool(AnalyzeDependencies, AnalyzeDependenciesInput{Dir: dir}), false},
+		{"MetricsSummary", callTool(MetricsSummary, MetricsSummaryInput{Dir: dir}), true},
+		{"AnalyzePurity", callTool(AnalyzePurity, AnalyzePurityInput{Dir: dir}), true},
+		{"AnalyzeLogging", callTool(AnalyzeLogging, AnalyzeLoggingInput{Dir: dir}), true},
+		{"ReadFunc", callTool(ReadFunc, ReadFuncInput{Dir: dir, Name: "Foo"}), true},
+		{"ReadGoFile", callTool(ReadGoFile, ReadGoFileInput{Dir: dir, File: "sample.go"}), false},
+		{"ReadStruct", callTool(ReadStruct, ReadStructInput{Dir: dir, Name: "Point"}), true},
+		{"RenameSymbol", callTool(RenameSymbol, RenameSymbolInput{Dir: dir, OldName: "Foo", NewName: "Bar", DryRun: true}), true},
+		{"ASTRewrite", callTool(ASTRewrite, ASTRewriteInput{Dir: dir, Find: "fmt.Println(x)", Replace: "fmt.Print(x)", DryRun: true}), true},
+		{"ReorderDeclarations", callTool(ReorderDeclarations, ReorderDeclarationsInput{Dir: dir, File: file, DryRun: true}), false},
+		{"CheckDeclarationOrder", callTool(CheckDeclarationOrder, CheckDeclarationOrderInput{Dir: dir, File: file}), false},
+		{"FindUntestedSymbols", callTool(FindUntestedSymbols, FindUntestedSymbolsInput{Dir: dir}), true},
+		{"GetFingerprints", callTool(GetFingerprints, GetFingerprintsInput{Dir: dir}), false},
+		{"DescribeJSONShape", callTool(DescribeJSONShape, DescribeJSONShapeInput{Dir: dir, TypeName: "Payload"}), true},
+		{"ExplainImplements", callTool(ExplainImplements, ExplainImplementsInput{Dir: dir, TypeName: "MemStore", InterfaceName: "Storage"}), true},
+		{"Warmup", callTool(Warmup, WarmupInput{Dir: dir}), true},
+		{"AnalyzeAllocations", callTool(AnalyzeAllocations, AnalyzeAllocationsInput{Dir: dir}), true},
+		{"CheckArchitecture", callTool(CheckArchitecture, CheckArchitectureInput{Dir: dir, Rules: []ArchitectureRule{{From: "**", Deny: []string{"internal/**"}}}}), false},
+		{"GetTypeInfo", callTool(GetTypeInfo, GetTypeInfoInput{Dir: dir, Name: "Point"}), true},
+		{"FindSwallowedErrors", callTool(FindSwallowedErrors, FindSwallowedErrorsInput{Dir: dir}), true},
+		{"GetAuditLog", callTool(GetAuditLog, GetAuditLogInput{}), false},
+		{"AnalyzeExternalUsage", callTool(AnalyzeExternalUsage, AnalyzeExternalUsageInput{LibraryDir: dir, ConsumerDirs: []string{dir}}), true},
+		{"CheckLanguageLevel", callTool(CheckLanguageLevel, CheckLanguageLevelInput{Dir: dir}), true},
+		{"ExportIndex", callTool(ExportIndex, ExportIndexInput{Dir: dir, OutFile: filepath.Join(t.TempDir(), "index.json"), Include: []string{"symbols"}}), false},
+		{"FindMagicValues", callTool(FindMagicValues, FindMagicValuesInput{Dir: dir}), true},
+		{"ResolvePosition", callTool(ResolvePosition, ResolvePositionInput{Dir: dir, Positions: []SourcePosition{{File: "foo.go", Line: 1}}}), false},
+		{"ListGenerators", callTool(ListGenerators, ListGeneratorsInput{Dir: dir}), false},
+		{"SuggestFileSplit", callTool(SuggestFileSplit, SuggestFileSplitInput{Dir: dir, File: "sample.go"}), true},
+		{"ApplyFileSplit", callTool(ApplyFileSplit, ApplyFileSplitInput{Dir: dir, File: "sample.go", DryRun: true}), true},
+		{"VerifyBuild", callTool(VerifyBuild, VerifyBuildInput{Dir: dir}), false},
+		{"NavigateFile", callTool(NavigateFile, NavigateFileInput{Dir: dir, File: "foo.go", Line: 1}), false},
+		{"AnalyzeConfigSurface", callTool(AnalyzeConfigSurface, AnalyzeConfigSurfaceInput{Dir: dir}), true},
+		{"PreviewDelete", callTool(PreviewDelete, PreviewDeleteInput{Dir: dir, Symbol: "Foo"}), true},
+		{"FindOverexportedSymbols", callTool(FindOverexportedSymbols, FindOverexportedSymbolsInput{Dir: dir}), true},
+		{"AnalyzeFieldUsage", callTool(AnalyzeFieldUsage, AnalyzeFieldUsageInput{Dir: dir, Package: "./..."}), true},
+		{"SuggestParameterObjects", callTool(SuggestParameterObjects, SuggestParameterObjectsInput{Dir: dir, Package: "./..."}), true},
+		{"AnalyzeDefers", callTool(AnalyzeDefers, AnalyzeDefersInput{Dir: dir, Package: "./..."}), true},
+		{"FindTypeAssertions", callTool(FindTypeAssertions, FindTypeAssertionsInput{Dir: dir, Package: "./..."}), true},
+		{"FindInterfaceConversions", callTool(FindInterfaceConversions, FindInterfaceConversionsInput{Dir: dir, TypeName: "Foo"}), true},
+		{"FindCallPath", callTool(FindCallPath, FindCallPathInput{Dir: dir, From: "Foo", To: "Bar"}), true},
+		{"AnalyzeSQL", callTool(AnalyzeSQL, AnalyzeSQLInput{Dir: dir, Package: "./..."}), true},
+		{"CheckFileHeaders", callTool(CheckFileHeaders, CheckFileHeadersInput{Dir: dir, RequiredPattern: "Copyright"}), false},
+		{"AnalyzeLifecycles", callTool(AnalyzeLifecycles, AnalyzeLifecyclesInput{Dir: dir}), true},
+		{"InspectNode", callTool(InspectNode, InspectNodeInput{Dir: dir, File: "a.go", Line: 1}), false},
+		{"ReleaseReport", callTool(ReleaseReport, ReleaseReportInput{Dir: dir}), true},
+		{"AnalyzeReceiverSemantics", callTool(AnalyzeReceiverSemantics, AnalyzeReceiverSemanticsInput{Dir: dir}), true},
+		{"AnalyzeImportWeight", callTool(AnalyzeImportWeight, AnalyzeImportWeightInput{Dir: dir}), false},
+		{"ResolvePackageDir", callTool(ResolvePackageDir, ResolvePackageDirInput{Dir: dir}), false},
+		{"CheckTagConsistency", callTool(CheckTagConsistency, CheckTagConsistencyInput{Dir: dir}), false},
+		{"SuggestIdentifierRenames", callTool(SuggestIdentifierRenames, SuggestIdentifierRenamesInput{Dir: dir}), true},
+		{"AnalyzeInterfaceCohesion", callTool(AnalyzeInterfaceCohesion, AnalyzeInterfaceCohesionInput{Dir: dir}), true},
+		{"ValidateNewFile", callTool(ValidateNewFile, ValidateNewFileInput{Dir: dir, Path: "new.go", Source: "package main\n"}), false},
+		{"ApplyMigrations", callTool(ApplyMigrations, ApplyMigrationsInput{Dir: dir, Preset: "ioutil", DryRun: true}), true},
+		{"UpdateFile", callTool(UpdateFile, UpdateFileInput{Dir: dir, File: file}), false},
+		{"AnalyzeHTTPSurface", callTool(AnalyzeHTTPSurface, AnalyzeHTTPSurfaceInput{Dir: dir}), true},
+		{"GetModuleInfo", callTool(GetModuleInfo, GetModuleInfoInput{Dir: dir}), false},
+		{"ExplainTypeError", callTool(ExplainTypeError, ExplainTypeErrorInput{Dir: dir, File: "sample.go", Line: 1}), true},
+		{"AnalyzeInitDependencies", callTool(AnalyzeInitDependencies, AnalyzeInitDependenciesInput{Dir: dir}), true},
+	}
+
+	for _, tc := range cases {
+		var panicked any
+
+		err := func() error {
+			defer func() { panicked = recover() }()
+
+			return tc.call()
+		}()
+
+		if panicked != nil {
+			t.Errorf("%s panicked with weak packages: %v", tc.name, panicked)
+
+			continue
+		}
+
+		if tc.typed && !errors.Is(err, errTypesNotLoaded) {
+			t.Errorf("%s: expected errTypesNotLoaded, got %v", tc.name, err)
+		}
+	}
+}
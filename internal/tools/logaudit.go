@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultLoggerPrefixes lists logger families recognized when the input does not specify any.
+var defaultLoggerPrefixes = []string{"log", "github.com/rs/zerolog", "go.uber.org/zap", "fmt.Print"}
+
+// fmtPrintFamily is the logger family whose calls are reported as findings outside main packages.
+// Test files are not loaded, so they never produce findings.
+const fmtPrintFamily = "fmt.Print"
+
+// AnalyzeLogging inventories logging calls and reports packages that mix logger families.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter and logger prefixes
+//
+// Returns:
+//   - MCP tool call result
+//   - logging calls grouped by file, per-package logger summary and fmt.Print* findings
+//   - error if an error occurred while loading packages
+func AnalyzeLogging(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeLoggingInput) (
+	*mcp.CallToolResult,
+	AnalyzeLoggingOutput,
+	error,
+) {
+	start := logStart("AnalyzeLogging", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := AnalyzeLoggingOutput{}
+
+	defer func() { logEnd("AnalyzeLogging", start, out.Total) }()
+
+	prefixes := input.Loggers
+	if len(prefixes) == 0 {
+		prefixes = defaultLoggerPrefixes
+	}
+
+	owners, err := newOwnership(input.Dir, input.OwnersFile, input.GroupByOwner)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeLogging")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	calls := make(map[string][]LogCall)
+	families := make(map[string]map[string]int)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			funcName := ""
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				funcName = qualifiedFuncName(fd)
+			}
+
+			for _, call := range collectLogCalls(pkg.TypesInfo, decl, prefixes) {
+				call.Line = pkg.Fset.Position(call.pos).Line
+				call.Function = funcName
+				calls[relPath] = append(calls[relPath], call.LogCall)
+
+				if families[pkg.PkgPath] == nil {
+					families[pkg.PkgPath] = make(map[string]int)
+				}
+
+				families[pkg.PkgPath][call.Family]++
+				out.Total++
+
+				if call.Family == fmtPrintFamily && pkg.Name != "main" {
+					out.FmtPrintFindings = append(out.FmtPrintFindings, LogFinding{
+						File:     relPath,
+						Line:     call.Line,
+						Function: funcName,
+						Call:     call.Logger + "." + call.Level,
+					})
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	out.Calls = groupLogCallsByFile(calls)
+
+	for i := range out.Calls {
+		out.Calls[i].Owner = owners.owner(out.Calls[i].File)
+		owners.count(out.Calls[i].Owner, len(out.Calls[i].Calls))
+	}
+
+	out.ByOwner = owners.counts()
+	out.Packages = summarizeLoggingPackages(families)
+
+	sort.Slice(out.FmtPrintFindings, func(i, j int) bool {
+		if out.FmtPrintFindings[i].File != out.FmtPrintFindings[j].File {
+			return out.FmtPrintFindings[i].File < out.FmtPrintFindings[j].File
+		}
+
+		return out.FmtPrintFindings[i].Line < out.FmtPrintFindings[j].Line
+	})
+
+	for _, summary := range out.Packages {
+		if summary.Mixed {
+			out.MixedPackages = append(out.MixedPackages, summary.Package)
+		}
+	}
+
+	return nil, out, nil
+}
+
+// logCallSite is a logging call together with its position in the file set.
+type logCallSite struct {
+	LogCall
+
+	pos token.Pos
+}
+
+// collectLogCalls finds logging calls in a declaration.
+// A method chain such as log.Error().Err(err).Msg("...") is reported once, at its outermost call.
+func collectLogCalls(info *types.Info, decl ast.Decl, prefixes []string) []logCallSite {
+	var result []logCallSite
+
+	consumed := make(map[*ast.CallExpr]struct{})
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		if _, ok := consumed[call]; ok {
+			return true
+		}
+
+		fn := calledFunc(info, call)
+		if fn == nil || fn.Pkg() == nil {
+			return true
+		}
+
+		family := loggerFamily(fn, prefixes)
+		if family == "" {
+			return true
+		}
+
+		site := logCallSite{pos: call.Pos()}
+		site.Family = family
+		site.Logger = fn.Pkg().Path()
+		site.Level = fn.Name()
+		site.HasError = hasErrorArg(info, call)
+
+		// Descend the chain so inner calls are not reported again; the innermost logging call names the level.
+		for inner := chainedCall(call); inner != nil; inner = chainedCall(inner) {
+			innerFn := calledFunc(info, inner)
+			if innerFn == nil || innerFn.Pkg() == nil || loggerFamily(innerFn, prefixes) == "" {
+				break
+			}
+
+			consumed[inner] = struct{}{}
+			site.Logger = innerFn.Pkg().Path()
+			site.Level = innerFn.Name()
+			site.HasError = site.HasError || hasErrorArg(info, inner)
+		}
+
+		result = append(result, site)
+
+		return true
+	})
+
+	return result
+}
+
+// loggerFamily returns the longest prefix matching fn, or "" when fn is not a logging call.
+// A prefix matches a package path (and its subpackages) or the start of a qualified function name.
+func loggerFamily(fn *types.Func, prefixes []string) string {
+	pkgPath := fn.Pkg().Path()
+	qualified := pkgPath + "." + fn.Name()
+	best := ""
+
+	for _, prefix := range prefixes {
+		matched := pkgPath == prefix ||
+			strings.HasPrefix(pkgPath, prefix+"/") ||
+			(len(prefix) > len(pkgPath) && strings.HasPrefix(qualified, prefix))
+		if matched && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+
+	return best
+}
+
+// chainedCall returns the call a method is invoked on, e.g. log.Error() in log.Error().Msg("x").
+func chainedCall(call *ast.CallExpr) *ast.CallExpr {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	inner, _ := ast.Unparen(sel.X).(*ast.CallExpr)
+
+	return inner
+}
+
+// hasErrorArg reports whether any argument of the call is a non-nil value implementing error.
+func hasErrorArg(info *types.Info, call *ast.CallExpr) bool {
+	errType := types.Universe.Lookup("error").Type()
+
+	for _, arg := range call.Args {
+		tv, ok := info.Types[arg]
+		if !ok || tv.Type == nil || tv.IsNil() {
+			continue
+		}
+
+		if types.Implements(tv.Type, errType.Underlying().(*types.Interface)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// summarizeLoggingPackages builds sorted per-package logger family summaries.
+func summarizeLoggingPackages(families map[string]map[string]int) []LoggingPackageSummary {
+	if len(families) == 0 {
+		return nil
+	}
+
+	result := make([]LoggingPackageSummary, 0, len(families))
+
+	for pkgPath, counts := range families {
+		summary := LoggingPackageSummary{
+			Package:  pkgPath,
+			Families: sortedKeys(counts),
+		}
+		for _, n := range counts {
+			summary.Calls += n
+		}
+
+		summary.Mixed = len(summary.Families) > 1
+		result = append(result, summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Package < result[j].Package })
+
+	return result
+}
+
+// groupLogCallsByFile sorts files and calls for stable output.
+func groupLogCallsByFile(data map[string][]LogCall) []LogCallGroupByFile {
+	if len(data) == 0 {
+		return nil
+	}
+
+	result := make([]LogCallGroupByFile, 0, len(data))
+
+	for file, calls := range data {
+		sort.Slice(calls, func(i, j int) bool { return calls[i].Line < calls[j].Line })
+		result = append(result, LogCallGroupByFile{File: filepath.ToSlash(file), Calls: calls})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].File < result[j].File })
+
+	return result
+}
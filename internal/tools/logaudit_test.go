@@ -0,0 +1,92 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestAnalyzeLogging(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeLoggingInput{Dir: testDir()}
+
+	_, out, err := tools.AnalyzeLogging(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeLogging error: %v", err)
+	}
+
+	var calls []tools.LogCall
+
+	for _, group := range out.Calls {
+		if group.File == "audit/audit.go" {
+			calls = group.Calls
+		}
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 logging calls in audit/audit.go, got %+v", calls)
+	}
+
+	// stdlog alias with an error argument
+	if calls[0].Logger != "log" || calls[0].Level != "Printf" || !calls[0].HasError || calls[0].Function != "Record" {
+		t.Errorf("unexpected aliased log call: %+v", calls[0])
+	}
+
+	// dot-imported log.Println
+	if calls[1].Logger != "log" || calls[1].Level != "Println" || calls[1].HasError {
+		t.Errorf("unexpected dot-imported log call: %+v", calls[1])
+	}
+
+	if calls[2].Family != "fmt.Print" || calls[2].Level != "Println" {
+		t.Errorf("unexpected fmt call: %+v", calls[2])
+	}
+
+	if !containsAll(out.MixedPackages, "sample/audit") {
+		t.Errorf("expected sample/audit to be flagged as mixed, got %v", out.MixedPackages)
+	}
+
+	findings := map[string]bool{}
+	for _, f := range out.FmtPrintFindings {
+		findings[f.File+":"+f.Function] = true
+	}
+
+	for _, want := range []string{"audit/audit.go:Record", "print.go:show"} {
+		if !findings[want] {
+			t.Errorf("expected fmt.Print finding %s, got %+v", want, out.FmtPrintFindings)
+		}
+	}
+}
+
+func TestAnalyzeLogging_CustomLoggers(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeLoggingInput{Dir: testDir(), Package: "sample/audit", Loggers: []string{"log"}}
+
+	_, out, err := tools.AnalyzeLogging(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzeLogging error: %v", err)
+	}
+
+	if out.Total != 2 {
+		t.Errorf("expected 2 log calls, got %d", out.Total)
+	}
+
+	if len(out.MixedPackages) != 0 || len(out.FmtPrintFindings) != 0 {
+		t.Errorf("expected no mixing or fmt findings with only the log family, got %+v", out)
+	}
+}
+
+func TestAnalyzeLogging_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzeLoggingInput{Dir: "/nonexistent/directory"}
+
+	_, _, err := tools.AnalyzeLogging(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Error("expected error for invalid directory")
+	}
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"go/token"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+// lspLocationAt returns the LSP location of the identifier name starting at posn: the file URI and a
+// zero-based range whose characters count UTF-16 code units, as LSP positions do by default. The line
+// text comes from the file on disk; nil is returned when posn has no file or the file cannot be read.
+func lspLocationAt(posn token.Position, name string) *LSPLocation {
+	if posn.Filename == "" || posn.Line < 1 {
+		return nil
+	}
+
+	item, ok := cachedFileContent(posn.Filename)
+	if !ok || posn.Line > len(item.Lines) {
+		return nil
+	}
+
+	line := item.Lines[posn.Line-1]
+
+	col := min(max(posn.Column-1, 0), len(line))
+	start := utf16Len(line[:col])
+
+	return &LSPLocation{
+		URI: fileURI(posn.Filename),
+		Range: LSPRange{
+			Start: LSPPosition{Line: posn.Line - 1, Character: start},
+			End:   LSPPosition{Line: posn.Line - 1, Character: start + utf16Len(name)},
+		},
+	}
+}
+
+// utf16Len returns the number of UTF-16 code units encoding s.
+func utf16Len(s string) int {
+	n := 0
+
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
+
+// fileURI returns the file:// URI of path, made absolute.
+func fileURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // C:/src/x.go -> /C:/src/x.go
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
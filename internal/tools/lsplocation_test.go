@@ -0,0 +1,146 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// lspSource places identifiers at a line start (line 5), after a tab (line 12) and after multi-byte
+// characters, including a comment with a character outside the BMP (line 8) and a non-ASCII identifier.
+const lspSource = `package lang
+
+const Alpha = 1
+
+var total = 1 +
+Alpha
+
+func Größe() int {
+	return /* 𝔊 */ Alpha + total
+}
+
+func use() int {
+	x := Größe() + Größe()
+	return x
+}
+
+type Sizer interface{ Size() int }
+
+type box struct{}
+
+func (box) Size() int { return Alpha }
+`
+
+// lspRange renders the zero-based range of loc as "line:start-end".
+func lspRange(t *testing.T, loc *tools.LSPLocation) string {
+	t.Helper()
+
+	if loc == nil {
+		t.Fatal("lspLocation missing")
+	}
+
+	if !strings.HasPrefix(loc.URI, "file:///") || !strings.HasSuffix(loc.URI, "/lang.go") {
+		t.Errorf("uri = %q, want an absolute file URI of lang.go", loc.URI)
+	}
+
+	if loc.Range.Start.Line != loc.Range.End.Line {
+		t.Errorf("range spans lines %d-%d", loc.Range.Start.Line, loc.Range.End.Line)
+	}
+
+	return fmt.Sprintf("%d:%d-%d", loc.Range.Start.Line, loc.Range.Start.Character, loc.Range.End.Character)
+}
+
+func TestFindReferences_LSPLocations(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": lspSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	cases := []struct {
+		ident string
+		want  []string
+	}{
+		// Line start, after a tab and a surrogate pair (byte column 20, UTF-16 character 17), after a tab.
+		{"Alpha", []string{"2:6-11", "5:0-5", "8:17-22", "20:31-36"}},
+		// The second call follows "Größe() + ": 18 bytes but 16 UTF-16 code units.
+		{"Größe", []string{"7:5-10", "12:6-11", "12:16-21"}},
+	}
+
+	for _, tc := range cases {
+		_, out, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: tc.ident, LSPLocations: true})
+		if err != nil {
+			t.Fatalf("FindReferences(%s): %v", tc.ident, err)
+		}
+
+		var got []string
+		for _, ref := range flattenReferences(out.Groups) {
+			got = append(got, lspRange(t, ref.entry.LSPLocation))
+		}
+
+		if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+			t.Errorf("%s ranges = %v, want %v", tc.ident, got, tc.want)
+		}
+	}
+
+	_, out, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Alpha"})
+	if err != nil {
+		t.Fatalf("FindReferences: %v", err)
+	}
+
+	for _, ref := range flattenReferences(out.Groups) {
+		if ref.entry.LSPLocation != nil {
+			t.Errorf("line %d: lspLocation set without lspLocations", ref.entry.Line)
+		}
+	}
+}
+
+func TestFindDefinitionsAndImplementations_LSPLocations(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": lspSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, defs, err := tools.FindDefinitions(ctx, req, tools.FindDefinitionsInput{Dir: dir, Ident: "Größe", LSPLocations: true})
+	if err != nil {
+		t.Fatalf("FindDefinitions: %v", err)
+	}
+
+	flat := flattenDefinitions(defs.Groups)
+	if len(flat) != 1 {
+		t.Fatalf("definitions = %+v, want one", flat)
+	}
+
+	if got := lspRange(t, flat[0].entry.LSPLocation); got != "7:5-10" {
+		t.Errorf("definition range = %s, want 7:5-10", got)
+	}
+
+	_, impls, err := tools.FindImplementations(ctx, req, tools.FindImplementationsInput{Dir: dir, Name: "Sizer", LSPLocations: true})
+	if err != nil {
+		t.Fatalf("FindImplementations: %v", err)
+	}
+
+	if len(impls.Implementations) != 1 {
+		t.Fatalf("implementations = %+v, want box", impls.Implementations)
+	}
+
+	if got := lspRange(t, impls.Implementations[0].LSPLocation); got != "18:5-8" {
+		t.Errorf("implementation range = %s, want 18:5-8", got)
+	}
+
+	_, bundle, err := tools.FindBestContext(ctx, req, tools.FindBestContextInput{Dir: dir, Ident: "Größe", LSPLocations: true})
+	if err != nil {
+		t.Fatalf("FindBestContext: %v", err)
+	}
+
+	if bundle.Definition == nil || lspRange(t, bundle.Definition.LSPLocation) != "7:5-10" {
+		t.Errorf("context definition = %+v, want range 7:5-10", bundle.Definition)
+	}
+
+	if len(bundle.KeyUsages) != 1 || lspRange(t, bundle.KeyUsages[0].LSPLocation) != "12:6-11" {
+		t.Errorf("context usages = %+v, want the first call on line 12", bundle.KeyUsages)
+	}
+}
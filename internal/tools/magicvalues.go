@@ -0,0 +1,354 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Defaults of findMagicValues.
+const (
+	defaultMagicMinOccurrences  = 3
+	defaultMagicMinStringLength = 4
+)
+
+// Kinds of repeated literal values.
+const (
+	magicValueKindString = "string"
+	magicValueKindNumber = "number"
+)
+
+// defaultMagicIgnoreValues are values too common to be worth a constant.
+var defaultMagicIgnoreValues = []string{"0", "1", "-1", `""`}
+
+// magicLiteral is a string or number literal found outside constant declarations.
+type magicLiteral struct {
+	key      string
+	kind     string
+	pos      token.Pos
+	function string
+}
+
+// FindMagicValues groups identical string and number literals of a package that repeat often enough to
+// be worth a named constant, and names an existing package constant with the same value when there is one.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter, thresholds and ignored values
+//
+// Returns:
+//   - MCP tool call result
+//   - repeated values with their occurrences, most frequent first
+//   - error if a threshold is negative or packages cannot be loaded
+func FindMagicValues(ctx context.Context, _ *mcp.CallToolRequest, input FindMagicValuesInput) (
+	*mcp.CallToolResult,
+	FindMagicValuesOutput,
+	error,
+) {
+	start := logStart("FindMagicValues", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("minOccurrences", strconv.Itoa(input.MinOccurrences)),
+		newLogField("ignoreTests", strconv.FormatBool(input.IgnoreTests)),
+	))
+	out := FindMagicValuesOutput{}
+
+	defer func() { logEnd("FindMagicValues", start, out.Total) }()
+
+	if input.MinOccurrences < 0 || input.MinStringLength < 0 {
+		return nil, out, invalidInput("minOccurrences and minStringLength must be non-negative")
+	}
+
+	minOccurrences := input.MinOccurrences
+	if minOccurrences == 0 {
+		minOccurrences = defaultMagicMinOccurrences
+	}
+
+	minStringLength := input.MinStringLength
+	if minStringLength == 0 {
+		minStringLength = defaultMagicMinStringLength
+	}
+
+	ignoreValues := input.IgnoreValues
+	if ignoreValues == nil {
+		ignoreValues = defaultMagicIgnoreValues
+	}
+
+	ignored := make(map[string]struct{}, len(ignoreValues))
+	for _, v := range ignoreValues {
+		ignored[magicIgnoreKey(v)] = struct{}{}
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCacheInternal(ctx, input.Dir, mode, !input.IgnoreTests)
+	if err != nil {
+		logError("FindMagicValues", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	filtered, err := filterPackagesByRequest(pkgs, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	groups := make(map[string]*MagicValue)
+	constants := make(map[string]map[string]string) // package -> value key -> constant name
+	// Test variants contain the package's own files again; every file is scanned once.
+	seen := make(map[string]struct{})
+
+	if err := walkPackageFiles(ctx, filtered, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if _, ok := seen[relPath]; ok || strings.HasSuffix(pkg.PkgPath, ".test") {
+			return nil
+		}
+
+		seen[relPath] = struct{}{}
+
+		if input.IgnoreTests && strings.HasSuffix(relPath, "_test.go") {
+			return nil
+		}
+
+		if _, ok := constants[pkg.PkgPath]; !ok {
+			constants[pkg.PkgPath] = packageConstantValues(pkg.Types)
+		}
+
+		for _, lit := range magicLiterals(file) {
+			if _, skip := ignored[lit.key]; skip {
+				continue
+			}
+
+			if lit.kind == magicValueKindString {
+				if s, err := strconv.Unquote(lit.key); err == nil && utf8.RuneCountInString(s) < minStringLength {
+					continue
+				}
+			}
+
+			groupKey := pkg.PkgPath + "\x00" + lit.key
+
+			group, ok := groups[groupKey]
+			if !ok {
+				group = &MagicValue{Package: pkg.PkgPath, Value: lit.key, Kind: lit.kind}
+				groups[groupKey] = group
+			}
+
+			group.Occurrences = append(group.Occurrences, MagicValueOccurrence{
+				File:     relPath,
+				Line:     pkg.Fset.Position(lit.pos).Line,
+				Function: lit.function,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, group := range groups {
+		if len(group.Occurrences) < minOccurrences {
+			continue
+		}
+
+		group.Count = len(group.Occurrences)
+		group.Suggestion = "extract a constant"
+
+		if name, ok := constants[group.Package][group.Value]; ok {
+			group.ExistingConstant = name
+			group.Suggestion = "reuse constant " + name
+		}
+
+		sort.Slice(group.Occurrences, func(i, j int) bool {
+			if group.Occurrences[i].File != group.Occurrences[j].File {
+				return group.Occurrences[i].File < group.Occurrences[j].File
+			}
+
+			return group.Occurrences[i].Line < group.Occurrences[j].Line
+		})
+
+		out.Values = append(out.Values, *group)
+	}
+
+	sort.Slice(out.Values, func(i, j int) bool {
+		a, b := out.Values[i], out.Values[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		return a.Value < b.Value
+	})
+
+	out.Total = len(out.Values)
+
+	return nil, out, nil
+}
+
+// magicLiterals returns the string and number literals of a file with their enclosing function. Import
+// paths, struct tags and constant declarations are skipped; a negated number is one literal.
+func magicLiterals(file *ast.File) []magicLiteral {
+	tags := make(map[*ast.BasicLit]struct{})
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if field, ok := n.(*ast.Field); ok && field.Tag != nil {
+			tags[field.Tag] = struct{}{}
+		}
+
+		return true
+	})
+
+	var literals []magicLiteral
+
+	for _, decl := range file.Decls {
+		function := ""
+
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT || d.Tok == token.CONST {
+				continue
+			}
+		case *ast.FuncDecl:
+			function = qualifiedFuncName(d)
+		}
+
+		add := func(lit *ast.BasicLit, pos token.Pos, negate bool) {
+			if key, kind, ok := magicLiteralKey(lit, negate); ok {
+				literals = append(literals, magicLiteral{key: key, kind: kind, pos: pos, function: function})
+			}
+		}
+
+		ast.Inspect(decl, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.GenDecl:
+				return node.Tok != token.CONST
+			case *ast.UnaryExpr:
+				if lit, ok := node.X.(*ast.BasicLit); ok && node.Op == token.SUB {
+					add(lit, node.Pos(), true)
+
+					return false
+				}
+			case *ast.BasicLit:
+				if _, ok := tags[node]; !ok {
+					add(node, node.Pos(), false)
+				}
+			}
+
+			return true
+		})
+	}
+
+	return literals
+}
+
+// magicLiteralKey returns the canonical form of a string or number literal: a Go-quoted string, or the
+// exact value of a number (so 0x10 and 16 are one value). Characters and imaginary numbers have none.
+func magicLiteralKey(lit *ast.BasicLit, negate bool) (string, string, bool) {
+	switch lit.Kind {
+	case token.STRING:
+		if negate {
+			return "", "", false
+		}
+
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", "", false
+		}
+
+		return strconv.Quote(s), magicValueKindString, true
+	case token.INT, token.FLOAT:
+		val := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+		if negate {
+			val = constant.UnaryOp(token.SUB, val, 0)
+		}
+
+		key, ok := constantValueKey(val)
+
+		return key, magicValueKindNumber, ok
+	}
+
+	return "", "", false
+}
+
+// constantValueKey returns the canonical form of a string or numeric constant value.
+func constantValueKey(val constant.Value) (string, bool) {
+	switch val.Kind() {
+	case constant.String:
+		return strconv.Quote(constant.StringVal(val)), true
+	case constant.Int:
+		return val.ExactString(), true
+	case constant.Float:
+		if i := constant.ToInt(val); i.Kind() == constant.Int {
+			return i.ExactString(), true
+		}
+
+		f, _ := constant.Float64Val(val)
+
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	}
+
+	return "", false
+}
+
+// magicIgnoreKey canonicalizes an ignoreValues entry: Go literals (`"GET"`, `0x10`, `-1`) as literals,
+// anything else as a string.
+func magicIgnoreKey(v string) string {
+	if expr, err := parser.ParseExpr(v); err == nil {
+		negate := false
+
+		if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+			expr, negate = unary.X, true
+		}
+
+		if lit, ok := expr.(*ast.BasicLit); ok {
+			if key, _, ok := magicLiteralKey(lit, negate); ok {
+				return key
+			}
+		}
+	}
+
+	return strconv.Quote(v)
+}
+
+// packageConstantValues maps the canonical values of the package-level constants of pkg to a constant
+// name, preferring exported names and then the alphabetically first.
+func packageConstantValues(pkg *types.Package) map[string]string {
+	values := make(map[string]string)
+	if pkg == nil {
+		return values
+	}
+
+	scope := pkg.Scope()
+
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok {
+			continue
+		}
+
+		key, ok := constantValueKey(c.Val())
+		if !ok {
+			continue
+		}
+
+		if existing, ok := values[key]; ok && (token.IsExported(existing) || !c.Exported()) {
+			continue
+		}
+
+		values[key] = name
+	}
+
+	return values
+}
@@ -0,0 +1,135 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+const magicValuesSource = `package lang
+
+import "fmt"
+
+const StatusActive = "active"
+
+type Account struct {
+	Status string ` + "`json:\"status\"`" + `
+	Limit  int    ` + "`json:\"limit\"`" + `
+}
+
+func NewAccount() Account { return Account{Status: "active", Limit: 0x1F4} }
+
+func (a Account) Active() bool { return a.Status == "active" }
+
+func Describe(a Account) string {
+	if a.Status == "active" && a.Limit > 500 {
+		return fmt.Sprint("pending", -1, 1)
+	}
+
+	return fmt.Sprint("pending", a.Limit*500, "ok")
+}
+
+func Retry() string { return "pending" }
+`
+
+const magicValuesTestSource = `package lang
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	if Describe(Account{Status: "pending"}) == "" {
+		t.Fatal("pending")
+	}
+}
+`
+
+func findMagicValue(out tools.FindMagicValuesOutput, value string) (tools.MagicValue, bool) {
+	for _, v := range out.Values {
+		if v.Value == value {
+			return v, true
+		}
+	}
+
+	return tools.MagicValue{}, false
+}
+
+func TestFindMagicValues(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.25", map[string]string{
+		"account.go":      magicValuesSource,
+		"account_test.go": magicValuesTestSource,
+	})
+
+	_, out, err := tools.FindMagicValues(context.Background(), &mcp.CallToolRequest{}, tools.FindMagicValuesInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("FindMagicValues error: %v", err)
+	}
+
+	if len(out.Values) != 3 || out.Values[0].Value != `"pending"` || out.Values[0].Count != 5 {
+		t.Fatalf(`expected "pending" (5 uses incl. tests) first of three values, got %+v`, out.Values)
+	}
+
+	active, ok := findMagicValue(out, `"active"`)
+	if !ok || active.Count != 3 || active.ExistingConstant != "StatusActive" || active.Suggestion != "reuse constant StatusActive" {
+		t.Fatalf(`expected "active" to reuse StatusActive, got %+v`, active)
+	}
+
+	if occ := active.Occurrences[1]; occ.File != "account.go" || occ.Function != "Account.Active" {
+		t.Errorf("unexpected occurrence %+v", occ)
+	}
+
+	// 0x1F4 and 500 are one value; tags, import paths and ignored values are not reported.
+	if n, ok := findMagicValue(out, "500"); !ok || n.Kind != "number" || n.Count != 3 || n.ExistingConstant != "" {
+		t.Errorf("expected 500 three times, got %+v", n)
+	}
+
+	for _, skipped := range []string{`"json:\"status\""`, `"fmt"`, "-1", "1", `"ok"`} {
+		if _, ok := findMagicValue(out, skipped); ok {
+			t.Errorf("did not expect %s to be reported", skipped)
+		}
+	}
+}
+
+func TestFindMagicValues_Options(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.25", map[string]string{
+		"account.go":      magicValuesSource,
+		"account_test.go": magicValuesTestSource,
+	})
+
+	_, out, err := tools.FindMagicValues(context.Background(), &mcp.CallToolRequest{}, tools.FindMagicValuesInput{
+		Dir:             dir,
+		IgnoreTests:     true,
+		MinOccurrences:  2,
+		MinStringLength: 2,
+		IgnoreValues:    []string{"500", `"active"`},
+	})
+	if err != nil {
+		t.Fatalf("FindMagicValues error: %v", err)
+	}
+
+	pending, ok := findMagicValue(out, `"pending"`)
+	if !ok || pending.Count != 3 {
+		t.Fatalf(`expected "pending" three times without tests, got %+v`, out.Values)
+	}
+
+	if _, ok := findMagicValue(out, "-1"); ok {
+		t.Error("an explicit ignoreValues list should not report -1 (used once)")
+	}
+
+	for _, ignored := range []string{"500", `"active"`} {
+		if _, ok := findMagicValue(out, ignored); ok {
+			t.Errorf("expected %s to be ignored", ignored)
+		}
+	}
+
+	_, _, err = tools.FindMagicValues(context.Background(), &mcp.CallToolRequest{}, tools.FindMagicValuesInput{Dir: dir, MinOccurrences: -1})
+	if te := tools.AsToolError(err); te.Code != tools.CodeInvalidInput {
+		t.Fatalf("expected INVALID_INPUT for a negative threshold, got %v", err)
+	}
+}
@@ -0,0 +1,679 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// Argument transforms of a migration rule.
+const (
+	argTransformSame        = "same"
+	argTransformDropFirst   = "dropFirst"
+	argTransformWrapContext = "wrapContext"
+)
+
+// builtinMigrations holds the migration specs selectable by preset name. Rules without replaceWith
+// have no drop-in replacement and only report their call sites.
+var builtinMigrations = map[string][]MigrationRule{
+	"ioutil": {
+		{FindCall: "io/ioutil.Discard", ReplaceWith: "io.Discard"},
+		{FindCall: "io/ioutil.NopCloser", ReplaceWith: "io.NopCloser"},
+		{FindCall: "io/ioutil.ReadAll", ReplaceWith: "io.ReadAll"},
+		// os.ReadDir returns []os.DirEntry, not []fs.FileInfo.
+		{FindCall: "io/ioutil.ReadDir"},
+		{FindCall: "io/ioutil.ReadFile", ReplaceWith: "os.ReadFile"},
+		{FindCall: "io/ioutil.TempDir", ReplaceWith: "os.MkdirTemp"},
+		{FindCall: "io/ioutil.TempFile", ReplaceWith: "os.CreateTemp"},
+		{FindCall: "io/ioutil.WriteFile", ReplaceWith: "os.WriteFile"},
+	},
+}
+
+// migrationRule is a validated MigrationRule: the qualified name of the deprecated object, and the
+// import path and name of its replacement, a method name for method rules.
+type migrationRule struct {
+	MigrationRule
+
+	method      bool
+	replacePath string
+	replaceName string
+}
+
+// migrationEdit replaces the bytes [start, end) of a file with text.
+type migrationEdit struct {
+	start, end int
+	text       string
+}
+
+// overlaps reports whether e and o change bytes of each other, or e inserts inside the range of o.
+func (e migrationEdit) overlaps(o migrationEdit) bool {
+	return e.start < o.end && o.start < e.end
+}
+
+// migrationSite is a matched use of a deprecated object with the edits migrating it, the imports they
+// need and the import they may leave unused.
+type migrationSite struct {
+	report  MigrationSite
+	edits   []migrationEdit
+	imports []string
+	oldPath string
+}
+
+// ApplyMigrations rewrites uses of deprecated functions, methods and variables to their replacements, as
+// listed by a migration spec: the rules passed in spec, the built-in preset, or both. Uses are matched by
+// the object they resolve to through types.Info, never by text, so renamed imports and shadowing names are
+// handled. Each use gets its selector replaced and its arguments transformed; the replacement's import is
+// added and the deprecated package's import removed once no use of it remains. Uses that cannot be
+// rewritten safely are reported as manual with the reason.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the rules or preset and the dry-run flag
+//
+// Returns:
+//   - MCP tool call result
+//   - migrated and manual sites, changed files and diffs in dry-run mode
+//   - error if the spec is invalid, packages cannot be loaded or files cannot be written
+func ApplyMigrations(ctx context.Context, _ *mcp.CallToolRequest, input ApplyMigrationsInput) (
+	*mcp.CallToolResult,
+	ApplyMigrationsOutput,
+	error,
+) {
+	start := logStart("ApplyMigrations", logFields(
+		input.Dir,
+		newLogField("preset", input.Preset),
+		newLogField("dryRun", strconv.FormatBool(input.DryRun)),
+	))
+	out := ApplyMigrationsOutput{
+		ChangedFiles: []string{},
+		Migrated:     []MigrationSite{},
+		Manual:       []MigrationSite{},
+	}
+
+	defer func() { logEnd("ApplyMigrations", start, out.TotalChanges) }()
+
+	if err := validateDiffMode(input.DiffMode); err != nil {
+		return fail(out, err)
+	}
+
+	rules, err := migrationRules(input.Preset, input.Spec)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	for _, rule := range rules {
+		out.Rules = append(out.Rules, rule.MigrationRule)
+	}
+
+	defer lockModuleForMutation(input.Dir)()
+
+	if err := checkExpectedHashes(input.Dir, input.ExpectedHashes); err != nil {
+		return fail(out, err)
+	}
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, loadModeSyntaxTypes)
+	if err != nil {
+		logError("ApplyMigrations", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	byTarget := make(map[string]migrationRule, len(rules))
+	for _, rule := range rules {
+		byTarget[rule.FindCall] = rule
+	}
+
+	type migratedFile struct {
+		filename, rel string
+		content       []byte
+	}
+
+	var files []migratedFile
+
+	seen := make(map[string]bool)
+
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, rel string, i int) error {
+		filename := pkg.CompiledGoFiles[i]
+		if seen[filename] || !hasTypes(pkg) {
+			return nil
+		}
+
+		// Test variants of a package carry its files again.
+		seen[filename] = true
+
+		sites := findMigrationSites(pkg, file, rel, byTarget)
+		if len(sites) == 0 {
+			return nil
+		}
+
+		if generator, generated := generatedFileGenerator(file); generated && !input.AllowGenerated {
+			out.SkippedGenerated = append(out.SkippedGenerated, GeneratedFile{File: rel, Generator: generator})
+
+			return nil
+		}
+
+		size := pkg.Fset.File(file.Pos()).Size()
+
+		src, content, migrated, err := migrateFile(filename, size, sites, &out.Manual)
+		if err != nil {
+			return err
+		}
+
+		out.Migrated = append(out.Migrated, migrated...)
+
+		if len(migrated) == 0 {
+			return nil
+		}
+
+		out.ChangedFiles = append(out.ChangedFiles, rel)
+		out.TotalChanges += len(migrated)
+
+		if input.DryRun {
+			out.Diffs = append(out.Diffs, FileDiff{Path: rel, Diff: diffFiles(src, content, rel, input.DiffMode)})
+		} else {
+			files = append(files, migratedFile{filename: filename, rel: rel, content: content})
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	// Every file is migrated in memory before the first write, so a failure changes nothing.
+	for _, f := range files {
+		if err := safeWriteFile(f.filename, f.content, fileChange{tool: "applyMigrations", input: input}); err != nil {
+			logError("ApplyMigrations", err, "failed to write file")
+
+			return fail(out, err)
+		}
+	}
+
+	if !input.DryRun && out.TotalChanges > 0 {
+		out.Build = verifyAfterMutation(ctx, "ApplyMigrations", input.Dir, input.VerifyBuild)
+	}
+
+	return nil, out, nil
+}
+
+// migrationRules returns the rules of preset followed by spec, validated, with spec rules overriding
+// preset rules for the same findCall.
+func migrationRules(preset string, spec []MigrationRule) ([]migrationRule, error) {
+	var all []MigrationRule
+
+	if preset != "" {
+		builtin, ok := builtinMigrations[preset]
+		if !ok {
+			return nil, invalidInput("unknown preset %q (available: %s)", preset,
+				strings.Join(sortedKeys(builtinMigrations), ", "))
+		}
+
+		all = append(all, builtin...)
+	}
+
+	all = append(all, spec...)
+	if len(all) == 0 {
+		return nil, invalidInput("spec or preset is required")
+	}
+
+	index := make(map[string]int)
+
+	var rules []migrationRule
+
+	for _, r := range all {
+		rule, err := parseMigrationRule(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[rule.FindCall]; ok {
+			rules[i] = rule
+
+			continue
+		}
+
+		index[rule.FindCall] = len(rules)
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseMigrationRule validates r. findCall is "path.Name" or "path.Type.Method"; replaceWith is
+// "path.Name" for functions and variables, a method name (optionally "Type.Method") for methods.
+func parseMigrationRule(r MigrationRule) (migrationRule, error) {
+	if r.ArgTransform == "" {
+		r.ArgTransform = argTransformSame
+	}
+
+	switch r.ArgTransform {
+	case argTransformSame, argTransformDropFirst, argTransformWrapContext:
+	default:
+		return migrationRule{}, invalidInput("argTransform %q of %s must be %s, %s or %s",
+			r.ArgTransform, r.FindCall, argTransformSame, argTransformDropFirst, argTransformWrapContext)
+	}
+
+	pkgPath, name, ok := splitQualifiedName(r.FindCall)
+	if !ok {
+		return migrationRule{}, invalidInput("findCall %q is not of the form <import path>.<Name> or <import path>.<Type>.<Method>", r.FindCall)
+	}
+
+	rule := migrationRule{MigrationRule: r, method: strings.Contains(name, ".")}
+
+	switch {
+	case r.ReplaceWith == "":
+	case rule.method:
+		rule.replaceName = r.ReplaceWith[strings.LastIndexByte(r.ReplaceWith, '.')+1:]
+	default:
+		replacePath, replaceName, ok := splitQualifiedName(r.ReplaceWith)
+		if !ok || strings.Contains(replaceName, ".") {
+			return migrationRule{}, invalidInput("replaceWith %q of %s is not of the form <import path>.<Name>", r.ReplaceWith, r.FindCall)
+		}
+
+		rule.replacePath, rule.replaceName = replacePath, replaceName
+	}
+
+	if rule.replacePath == pkgPath && rule.replaceName == name {
+		return migrationRule{}, invalidInput("replaceWith of %s names the same object", r.FindCall)
+	}
+
+	return rule, nil
+}
+
+// splitQualifiedName splits "path.Name" or "path.Type.Method" at the first dot after the last slash.
+func splitQualifiedName(s string) (string, string, bool) {
+	slash := strings.LastIndexByte(s, '/')
+
+	dot := strings.IndexByte(s[slash+1:], '.')
+	if dot <= 0 {
+		return "", "", false
+	}
+
+	dot += slash + 1
+
+	return s[:dot], s[dot+1:], dot+1 < len(s)
+}
+
+// migrationTarget returns the qualified name of obj as migration rules spell it: "path.Name" for
+// package-level objects, "path.Type.Method" for methods, "" for anything else.
+func migrationTarget(obj types.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+
+	if fn, ok := obj.(*types.Func); ok {
+		if recv := fn.Origin().Signature().Recv(); recv != nil {
+			if tn := namedTypeName(recv.Type()); tn != nil {
+				return fn.Pkg().Path() + "." + tn.Name() + "." + fn.Name()
+			}
+
+			return ""
+		}
+	}
+
+	if obj.Parent() != obj.Pkg().Scope() {
+		return ""
+	}
+
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// findMigrationSites returns the uses of the rules' objects in file, in source order. A use that cannot be
+// migrated gets no edits and the reason in report.Reason.
+func findMigrationSites(pkg *packages.Package, file *ast.File, rel string, rules map[string]migrationRule) []migrationSite {
+	var sites []migrationSite
+
+	selected := make(map[*ast.Ident]bool)
+	calls := make(map[ast.Expr]*ast.CallExpr)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			calls[ast.Unparen(n.Fun)] = n
+		case *ast.SelectorExpr:
+			selected[n.Sel] = true
+
+			rule, ok := rules[migrationTarget(pkg.TypesInfo.Uses[n.Sel])]
+			if !ok {
+				return true
+			}
+
+			sites = append(sites, newMigrationSite(pkg, file, rel, rule, n, calls[n]))
+		case *ast.Ident:
+			// Uses through a dot import have no selector to rewrite.
+			if rule, ok := rules[migrationTarget(pkg.TypesInfo.Uses[n])]; ok && !selected[n] {
+				site := migrationSite{report: migrationReport(pkg, rel, rule, n)}
+				site.report.Reason = "dot-imported use; qualify it first"
+				sites = append(sites, site)
+			}
+		}
+
+		return true
+	})
+
+	return sites
+}
+
+// migrationReport returns the report of a use of rule at node.
+func migrationReport(pkg *packages.Package, rel string, rule migrationRule, node ast.Node) MigrationSite {
+	posn := pkg.Fset.Position(node.Pos())
+
+	return MigrationSite{
+		File:        rel,
+		Line:        posn.Line,
+		Column:      posn.Column,
+		FindCall:    rule.FindCall,
+		ReplaceWith: rule.ReplaceWith,
+	}
+}
+
+// newMigrationSite computes the edits migrating sel, a use of rule's object called by call, if any.
+func newMigrationSite(pkg *packages.Package, file *ast.File, rel string, rule migrationRule, sel *ast.SelectorExpr, call *ast.CallExpr) migrationSite {
+	site := migrationSite{report: migrationReport(pkg, rel, rule, sel)}
+	offset := func(pos token.Pos) int { return pkg.Fset.Position(pos).Offset }
+
+	manual := func(format string, args ...any) migrationSite {
+		site.report.Reason = fmt.Sprintf(format, args...)
+		site.edits, site.imports = nil, nil
+
+		return site
+	}
+
+	if rule.ReplaceWith == "" {
+		return manual("no drop-in replacement; migrate by hand")
+	}
+
+	scope := pkg.Types.Scope().Innermost(sel.Pos())
+
+	if rule.method {
+		site.edits = append(site.edits, migrationEdit{start: offset(sel.Sel.Pos()), end: offset(sel.Sel.End()), text: rule.replaceName})
+	} else {
+		pkgName, ok := pkg.TypesInfo.Uses[identOf(sel.X)].(*types.PkgName)
+		if !ok {
+			return manual("not a qualified identifier")
+		}
+
+		site.oldPath = pkgName.Imported().Path()
+
+		name, err := importNameAt(file, scope, sel.Pos(), rule.replacePath)
+		if err != nil {
+			return manual("%v", err)
+		}
+
+		site.imports = append(site.imports, rule.replacePath)
+		site.edits = append(site.edits, migrationEdit{start: offset(sel.Pos()), end: offset(sel.End()), text: name + "." + rule.replaceName})
+	}
+
+	if rule.ArgTransform == argTransformSame {
+		return site
+	}
+
+	if call == nil {
+		return manual("used as a value; %s needs a call", rule.ArgTransform)
+	}
+
+	if call.Ellipsis.IsValid() {
+		return manual("variadic call; %s cannot move the arguments", rule.ArgTransform)
+	}
+
+	switch rule.ArgTransform {
+	case argTransformDropFirst:
+		if len(call.Args) == 0 {
+			return manual("no argument to drop")
+		}
+
+		if hasCall(call.Args[0]) {
+			return manual("the first argument contains a call, which dropping it would skip")
+		}
+
+		end := offset(call.Rparen)
+		if len(call.Args) > 1 {
+			end = offset(call.Args[1].Pos())
+		}
+
+		site.edits = append(site.edits, migrationEdit{start: offset(call.Args[0].Pos()), end: end})
+	case argTransformWrapContext:
+		ctxExpr, ok := contextAt(scope, call.Pos())
+		if !ok {
+			name, err := importNameAt(file, scope, call.Pos(), "context")
+			if err != nil {
+				return manual("%v", err)
+			}
+
+			ctxExpr = name + ".TODO()"
+			site.imports = append(site.imports, "context")
+		}
+
+		at, text := offset(call.Rparen), ctxExpr
+		if len(call.Args) > 0 {
+			at, text = offset(call.Args[0].Pos()), ctxExpr+", "
+		}
+
+		site.edits = append(site.edits, migrationEdit{start: at, end: at, text: text})
+	}
+
+	return site
+}
+
+// identOf returns expr as an identifier, nil if it is none.
+func identOf(expr ast.Expr) *ast.Ident {
+	id, _ := ast.Unparen(expr).(*ast.Ident)
+
+	return id
+}
+
+// hasCall reports whether expr contains a call or a conversion.
+func hasCall(expr ast.Expr) bool {
+	found := false
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if _, ok := n.(*ast.CallExpr); ok {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// importNameAt returns the name the package importPath is referred to by at pos of file: the name of its
+// import when file has one, its default name otherwise. It fails when that name means something else at
+// pos.
+func importNameAt(file *ast.File, scope *types.Scope, pos token.Pos, importPath string) (string, error) {
+	name := defaultImportName(importPath)
+
+	for _, spec := range file.Imports {
+		if strings.Trim(spec.Path.Value, `"`) != importPath {
+			continue
+		}
+
+		if spec.Name != nil {
+			if spec.Name.Name == "." || spec.Name.Name == "_" {
+				return "", fmt.Errorf("%s is imported as %s", importPath, spec.Name.Name)
+			}
+
+			name = spec.Name.Name
+		}
+
+		break
+	}
+
+	if scope == nil {
+		return name, nil
+	}
+
+	if _, obj := scope.LookupParent(name, pos); obj != nil {
+		if pn, ok := obj.(*types.PkgName); !ok || pn.Imported().Path() != importPath {
+			return "", fmt.Errorf("%s would refer to the %s declared at this point", name, objStringKind(obj))
+		}
+	}
+
+	return name, nil
+}
+
+// defaultImportName returns the name a package is imported by without an explicit name: the last
+// element of its path, skipping a major version suffix.
+func defaultImportName(importPath string) string {
+	base := path.Base(importPath)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" && path.Dir(importPath) != "." {
+		base = path.Base(path.Dir(importPath))
+	}
+
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		base = base[:i]
+	}
+
+	return strings.ReplaceAll(base, "-", "_")
+}
+
+// contextAt returns the name of a context.Context variable in scope at pos.
+func contextAt(scope *types.Scope, pos token.Pos) (string, bool) {
+	if scope == nil {
+		return "", false
+	}
+
+	_, obj := scope.LookupParent("ctx", pos)
+
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return "", false
+	}
+
+	if named, ok := types.Unalias(v.Type()).(*types.Named); ok &&
+		named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context" {
+		return "ctx", true
+	}
+
+	return "", false
+}
+
+// migrateFile applies the edits of sites to filename, whose loaded syntax covers size bytes, and fixes
+// its imports. Sites without edits, or whose edits overlap those of an earlier site, are appended to
+// manual. It returns the original and migrated source and the reports of the sites applied.
+func migrateFile(filename string, size int, sites []migrationSite, manual *[]MigrationSite) ([]byte, []byte, []MigrationSite, error) {
+	_, _, src, err := parseFileForMutation(filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The edits hold offsets into the loaded syntax.
+	if len(src) != size {
+		return nil, nil, nil, NewToolError(CodeConflict, fmt.Errorf("%s changed since it was loaded; retry", filename))
+	}
+
+	var (
+		edits    []migrationEdit
+		migrated []MigrationSite
+		imports  []string
+		oldPaths []string
+	)
+
+	for _, site := range sites {
+		if len(site.edits) == 0 {
+			*manual = append(*manual, site.report)
+
+			continue
+		}
+
+		if slices.ContainsFunc(site.edits, func(e migrationEdit) bool {
+			return slices.ContainsFunc(edits, e.overlaps)
+		}) {
+			site.report.Reason = "overlaps the migration of an enclosing call"
+			*manual = append(*manual, site.report)
+
+			continue
+		}
+
+		edits = append(edits, site.edits...)
+		migrated = append(migrated, site.report)
+		imports = append(imports, site.imports...)
+
+		if site.oldPath != "" {
+			oldPaths = append(oldPaths, site.oldPath)
+		}
+	}
+
+	if len(edits) == 0 {
+		return src, src, nil, nil
+	}
+
+	// An insertion goes before a replacement starting at the same offset.
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start < edits[j].start
+		}
+
+		return edits[i].end < edits[j].end
+	})
+
+	var buf bytes.Buffer
+
+	pos := 0
+
+	for _, e := range edits {
+		buf.Write(src[pos:e.start])
+		buf.WriteString(e.text)
+		pos = e.end
+	}
+
+	buf.Write(src[pos:])
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("migrated %s does not parse: %w", filename, err)
+	}
+
+	slices.Sort(oldPaths)
+
+	for _, oldPath := range slices.Compact(oldPaths) {
+		if astutil.UsesImport(file, oldPath) {
+			continue
+		}
+
+		for _, spec := range file.Imports {
+			if strings.Trim(spec.Path.Value, `"`) == oldPath {
+				name := ""
+				if spec.Name != nil {
+					name = spec.Name.Name
+				}
+
+				astutil.DeleteNamedImport(fset, file, name, oldPath)
+
+				break
+			}
+		}
+	}
+
+	// Adding after deleting gives a file left without imports a plain import declaration, not a
+	// parenthesized one.
+	slices.Sort(imports)
+
+	for _, importPath := range slices.Compact(imports) {
+		astutil.AddImport(fset, file, importPath)
+	}
+
+	var out bytes.Buffer
+
+	if err := format.Node(&out, fset, file); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return src, out.Bytes(), migrated, nil
+}
@@ -0,0 +1,172 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestApplyMigrations(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"oldio/oldio.go": `package oldio
+
+func ReadFile(name string) string { return name }
+
+var Discard = 0
+
+func Send(channel, msg string) {}
+`,
+		"newio/newio.go": `package newio
+
+func ReadFile(name string) string { return name }
+
+var Discard = 0
+
+func Send(msg string) {}
+`,
+		"app/app.go": `package app
+
+import "lang/oldio"
+
+func Load() string {
+	return oldio.ReadFile("a")
+}
+
+func Sink() int { return oldio.Discard }
+
+func Send() { oldio.Send("ch", "hello") }
+
+func SendNow() { oldio.Send(channel(), "now") }
+
+func channel() string { return "" }
+
+func Shadowed() string {
+	newio := "b"
+	return oldio.ReadFile(newio)
+}
+`,
+		"app/only.go": `package app
+
+import old "lang/oldio"
+
+func Only() string { return old.ReadFile("c") }
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+	spec := []tools.MigrationRule{
+		{FindCall: "lang/oldio.ReadFile", ReplaceWith: "lang/newio.ReadFile"},
+		{FindCall: "lang/oldio.Discard", ReplaceWith: "lang/newio.Discard"},
+		{FindCall: "lang/oldio.Send", ReplaceWith: "lang/newio.Send", ArgTransform: "dropFirst"},
+	}
+
+	_, out, err := tools.ApplyMigrations(ctx, req, tools.ApplyMigrationsInput{Dir: dir, Spec: spec, DryRun: true})
+	if err != nil {
+		t.Fatalf("ApplyMigrations: %v", err)
+	}
+
+	format := func(sites []tools.MigrationSite) string {
+		var lines []string
+		for _, s := range sites {
+			lines = append(lines, fmt.Sprintf("%s:%d %s %s", s.File, s.Line, s.FindCall, s.Reason))
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
+	wantMigrated := strings.Join([]string{
+		"app/app.go:6 lang/oldio.ReadFile ",
+		"app/app.go:9 lang/oldio.Discard ",
+		"app/app.go:11 lang/oldio.Send ",
+		"app/only.go:5 lang/oldio.ReadFile ",
+	}, "\n")
+	if got := format(out.Migrated); got != wantMigrated {
+		t.Errorf("migrated:\n%s\nwant:\n%s", got, wantMigrated)
+	}
+
+	wantManual := strings.Join([]string{
+		"app/app.go:13 lang/oldio.Send the first argument contains a call, which dropping it would skip",
+		"app/app.go:19 lang/oldio.ReadFile newio would refer to the var declared at this point",
+	}, "\n")
+	if got := format(out.Manual); got != wantManual {
+		t.Errorf("manual:\n%s\nwant:\n%s", got, wantManual)
+	}
+
+	if out.TotalChanges != 4 || fmt.Sprint(out.ChangedFiles) != "[app/app.go app/only.go]" || len(out.Diffs) != 2 {
+		t.Errorf("total = %d, changed = %v, %d diffs; want 4 in both files", out.TotalChanges, out.ChangedFiles, len(out.Diffs))
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "app", "only.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "app", "only.go")); strings.Contains(string(data), "newio") {
+		t.Errorf("dry run wrote only.go:\n%s", data)
+	}
+
+	if _, _, err := tools.ApplyMigrations(ctx, req, tools.ApplyMigrationsInput{Dir: dir, Spec: spec}); err != nil {
+		t.Fatalf("ApplyMigrations: %v", err)
+	}
+
+	app, _ := os.ReadFile(filepath.Join(dir, "app", "app.go"))
+	for _, want := range []string{"return newio.ReadFile(\"a\")", "return newio.Discard", "newio.Send(\"hello\")", "oldio.Send(channel(), \"now\")", "\"lang/oldio\""} {
+		if !strings.Contains(string(app), want) {
+			t.Errorf("app.go lacks %q:\n%s", want, app)
+		}
+	}
+
+	// The only use of the renamed import is gone, and the import with it.
+	only, _ := os.ReadFile(filepath.Join(dir, "app", "only.go"))
+	if want := "package app\n\nimport \"lang/newio\"\n\nfunc Only() string { return newio.ReadFile(\"c\") }\n"; string(only) != want {
+		t.Errorf("only.go:\n%s\nwant:\n%s", only, want)
+	}
+}
+
+func TestApplyMigrations_IoutilPreset(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"files.go": `package lang
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+func Read() ([]byte, error) { return ioutil.ReadFile("a.txt") }
+
+func Drain(r io.Reader) error {
+	_, err := io.Copy(ioutil.Discard, r)
+	return err
+}
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.ApplyMigrations(ctx, req, tools.ApplyMigrationsInput{Dir: dir, Preset: "ioutil", DryRun: true})
+	if err != nil {
+		t.Fatalf("ApplyMigrations: %v", err)
+	}
+
+	if out.TotalChanges != 2 || len(out.Manual) != 0 || len(out.Diffs) != 1 {
+		t.Fatalf("got %+v, want ReadFile and Discard migrated", out)
+	}
+
+	for _, want := range []string{"+\t\"os\"", "-\t\"io/ioutil\"", "+func Read() ([]byte, error) { return os.ReadFile(\"a.txt\") }", "+\t_, err := io.Copy(io.Discard, r)"} {
+		if !strings.Contains(out.Diffs[0].Diff, want) {
+			t.Errorf("diff lacks %q:\n%s", want, out.Diffs[0].Diff)
+		}
+	}
+
+	_, _, err = tools.ApplyMigrations(ctx, req, tools.ApplyMigrationsInput{Dir: dir, Preset: "errors"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for an unknown preset, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/mod/modfile"
+)
+
+// GetModuleInfo summarizes the go.mod of the module containing dir: module path, go and toolchain
+// directives, requires, replaces, excludes and retractions, and checks go.sum against the requires.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory
+//
+// Returns:
+//   - MCP tool call result
+//   - the parsed go.mod with the go.sum entry count and the requires it lacks
+//   - error if there is no go.mod or it cannot be parsed
+func GetModuleInfo(_ context.Context, _ *mcp.CallToolRequest, input GetModuleInfoInput) (
+	*mcp.CallToolResult,
+	GetModuleInfoOutput,
+	error,
+) {
+	start := logStart("GetModuleInfo", logFields(input.Dir))
+	out := GetModuleInfoOutput{
+		Requires: []ModuleRequire{},
+		Replaces: []ModuleReplace{},
+	}
+
+	defer func() { logEnd("GetModuleInfo", start, len(out.Requires)) }()
+
+	root := findModuleRoot(input.Dir)
+	if root == "" {
+		return fail(out, notFound(nil, "no go.mod found for %q", input.Dir))
+	}
+
+	mf, err := parseGoMod(root)
+	if err != nil {
+		return fail(out, NewToolError(CodeLoadFailed, err))
+	}
+
+	out.GoMod = relativePath(input.Dir, filepath.Join(root, "go.mod"))
+
+	if mf.Module != nil {
+		out.Module = mf.Module.Mod.Path
+	}
+
+	if mf.Go != nil {
+		out.GoVersion = mf.Go.Version
+	}
+
+	if mf.Toolchain != nil {
+		out.Toolchain = mf.Toolchain.Name
+	}
+
+	for _, r := range mf.Require {
+		out.Requires = append(out.Requires, ModuleRequire{Path: r.Mod.Path, Version: r.Mod.Version, Indirect: r.Indirect})
+	}
+
+	for _, r := range mf.Replace {
+		out.Replaces = append(out.Replaces, ModuleReplace{
+			Old:        r.Old.Path,
+			OldVersion: r.Old.Version,
+			New:        r.New.Path,
+			NewVersion: r.New.Version,
+			Local:      modfile.IsDirectoryPath(r.New.Path),
+		})
+	}
+
+	for _, e := range mf.Exclude {
+		out.Excludes = append(out.Excludes, ModuleExclude{Path: e.Mod.Path, Version: e.Mod.Version})
+	}
+
+	for _, r := range mf.Retract {
+		out.Retracts = append(out.Retracts, ModuleRetract{Low: r.Low, High: r.High, Rationale: r.Rationale})
+	}
+
+	sums, err := readGoSum(root)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.GoSumEntries = sums.entries
+	out.MissingSums = missingGoSums(mf, sums.modules)
+	out.GoSumIncomplete = len(out.MissingSums) > 0
+
+	return nil, out, nil
+}
+
+// parseGoMod parses the go.mod in dir.
+func parseGoMod(dir string) (*modfile.File, error) {
+	path := filepath.Join(dir, "go.mod")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return modfile.Parse(path, data, nil)
+}
+
+// goSum holds the entries of a go.sum file.
+type goSum struct {
+	// entries - number of hash lines
+	entries int
+	// modules - path@version of every module with a hash of its zip or its go.mod
+	modules map[string]struct{}
+}
+
+// readGoSum reads the go.sum in dir; a missing go.sum has no entries.
+func readGoSum(dir string) (goSum, error) {
+	sums := goSum{modules: make(map[string]struct{})}
+
+	data, err := os.ReadFile(filepath.Join(dir, "go.sum"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return sums, nil
+	}
+
+	if err != nil {
+		return sums, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		sums.entries++
+		sums.modules[fields[0]+"@"+strings.TrimSuffix(fields[1], "/go.mod")] = struct{}{}
+	}
+
+	return sums, scanner.Err()
+}
+
+// missingGoSums returns path@version of the requires of mf without a go.sum entry. A require is checked
+// under its replacement module; requires replaced by a local directory need no checksum.
+func missingGoSums(mf *modfile.File, sums map[string]struct{}) []string {
+	var missing []string
+
+	for _, r := range mf.Require {
+		path, version := r.Mod.Path, r.Mod.Version
+
+		// A replacement of this version wins over one of every version.
+		var replacement *modfile.Replace
+
+		for _, rep := range mf.Replace {
+			switch {
+			case rep.Old.Path != path:
+			case rep.Old.Version == version:
+				replacement = rep
+			case rep.Old.Version == "" && replacement == nil:
+				replacement = rep
+			}
+		}
+
+		if replacement != nil {
+			path, version = replacement.New.Path, replacement.New.Version
+		}
+
+		if modfile.IsDirectoryPath(path) {
+			continue
+		}
+
+		if _, ok := sums[path+"@"+version]; !ok {
+			missing = append(missing, path+"@"+version)
+		}
+	}
+
+	return missing
+}
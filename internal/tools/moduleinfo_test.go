@@ -0,0 +1,91 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestGetModuleInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"app/app.go": "package app\n",
+	})
+
+	goMod := `module example.com/app
+
+go 1.22.1
+
+toolchain go1.22.3
+
+require (
+	example.com/lib v1.2.0
+	example.com/util v0.3.0 // indirect
+	example.com/forked v1.0.0
+)
+
+replace example.com/lib => ../lib
+
+replace example.com/forked v1.0.0 => example.com/fork v1.0.1
+
+exclude example.com/util v0.2.0
+
+retract [v1.0.0, v1.0.5] // published with a broken API
+`
+	goSum := `example.com/fork v1.0.1 h1:abc=
+example.com/fork v1.0.1/go.mod h1:def=
+example.com/forked v1.0.0/go.mod h1:ghi=
+`
+
+	for name, content := range map[string]string{"go.mod": goMod, "go.sum": goSum} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.GetModuleInfo(ctx, req, tools.GetModuleInfoInput{Dir: filepath.Join(dir, "app")})
+	if err != nil {
+		t.Fatalf("GetModuleInfo: %v", err)
+	}
+
+	if out.GoMod != "../go.mod" || out.Module != "example.com/app" || out.GoVersion != "1.22.1" || out.Toolchain != "go1.22.3" {
+		t.Errorf("header = %q %q %q %q, want the directives of go.mod", out.GoMod, out.Module, out.GoVersion, out.Toolchain)
+	}
+
+	if got := fmt.Sprint(out.Requires); got != "[{example.com/lib v1.2.0 false} {example.com/util v0.3.0 true} {example.com/forked v1.0.0 false}]" {
+		t.Errorf("requires = %s", got)
+	}
+
+	if len(out.Replaces) != 2 || !out.Replaces[0].Local || out.Replaces[0].New != "../lib" ||
+		out.Replaces[1].Local || out.Replaces[1].OldVersion != "v1.0.0" || out.Replaces[1].New != "example.com/fork" {
+		t.Errorf("replaces = %+v, want ../lib local and example.com/fork versioned", out.Replaces)
+	}
+
+	if fmt.Sprint(out.Excludes) != "[{example.com/util v0.2.0}]" ||
+		fmt.Sprint(out.Retracts) != "[{v1.0.0 v1.0.5 published with a broken API}]" {
+		t.Errorf("excludes = %v, retracts = %v", out.Excludes, out.Retracts)
+	}
+
+	// The local replacement needs no sum and the fork is checked under its replacement.
+	if out.GoSumEntries != 3 || !out.GoSumIncomplete || fmt.Sprint(out.MissingSums) != "[example.com/util@v0.3.0]" {
+		t.Errorf("go.sum: %d entries, incomplete %v, missing %v; want 3 and util missing",
+			out.GoSumEntries, out.GoSumIncomplete, out.MissingSums)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\nrequire\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = tools.GetModuleInfo(ctx, req, tools.GetModuleInfoInput{Dir: dir})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeLoadFailed {
+		t.Errorf("expected LOAD_FAILED for a malformed go.mod, got %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Directions accepted by navigateFile.
+const (
+	navigateAt    = "at"
+	navigateNext  = "next"
+	navigatePrev  = "prev"
+	navigateFirst = "first"
+	navigateLast  = "last"
+)
+
+// navigateKinds are the declaration kinds navigateFile reports and filters by.
+var navigateKinds = map[string]struct{}{
+	"func":   {},
+	"method": {},
+	"type":   {},
+	"const":  {},
+	"var":    {},
+}
+
+// fileNavIndex is the declaration index of one file, sorted by line. It is valid while the file keeps
+// its size and modification time.
+type fileNavIndex struct {
+	modTime    time.Time
+	size       int64
+	hash       string
+	lines      int
+	decls      []FileDeclaration
+	parseError string
+	lastAccess time.Time
+}
+
+// fileNavCache holds one parse per file for navigateFile, keyed by absolute path.
+var fileNavCache = struct {
+	sync.RWMutex
+
+	data map[string]*fileNavIndex
+}{
+	data: make(map[string]*fileNavIndex),
+}
+
+// NavigateFile steps through the top-level declarations of one file: the declaration containing a line,
+// the next or previous one, or the first or last, each with its neighbours and line spans. It parses
+// only that file and answers repeat calls from a cached index.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the file, the line, the direction and an optional kind filter
+//
+// Returns:
+//   - MCP tool call result
+//   - the selected declaration, nil when there is none in that direction, and its neighbours
+//   - error if the input is invalid or the file cannot be read
+func NavigateFile(_ context.Context, _ *mcp.CallToolRequest, input NavigateFileInput) (
+	*mcp.CallToolResult,
+	NavigateFileOutput,
+	error,
+) {
+	start := logStart("NavigateFile", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("line", strconv.Itoa(input.Line)),
+		newLogField("direction", input.Direction),
+	))
+	out := NavigateFileOutput{File: filepath.ToSlash(input.File)}
+
+	defer func() { logEnd("NavigateFile", start, out.Total) }()
+
+	direction := input.Direction
+	if direction == "" {
+		direction = navigateAt
+	}
+
+	switch direction {
+	case navigateAt, navigateNext, navigatePrev, navigateFirst, navigateLast:
+	default:
+		return fail(out, invalidInput("unknown direction %q: use at, next, prev, first or last", input.Direction))
+	}
+
+	if _, ok := navigateKinds[input.Kind]; input.Kind != "" && !ok {
+		return fail(out, invalidInput("unknown kind %q: use func, method, type, const or var", input.Kind))
+	}
+
+	if input.File == "" {
+		return fail(out, invalidInput("file must not be empty"))
+	}
+
+	path := input.File
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(input.Dir, path)
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	idx, err := fileNavIndexFor(path)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.Lines = idx.lines
+	out.ContentHash = idx.hash
+	out.ParseError = idx.parseError
+
+	needsLine := direction == navigateAt || direction == navigateNext || direction == navigatePrev
+	if needsLine && (input.Line < 1 || input.Line > idx.lines) {
+		return fail(out, invalidInput("line %d is out of range: %s has %d lines", input.Line, out.File, idx.lines))
+	}
+
+	decls := idx.decls
+	if input.Kind != "" {
+		decls = nil
+
+		for _, d := range idx.decls {
+			if d.Kind == input.Kind {
+				decls = append(decls, d)
+			}
+		}
+	}
+
+	out.Total = len(decls)
+
+	// after is the first declaration starting after the line; every one before it starts at or above it.
+	after := sort.Search(len(decls), func(i int) bool { return decls[i].spanStart() > input.Line })
+
+	selected := -1
+
+	switch direction {
+	case navigateAt:
+		if after > 0 && decls[after-1].EndLine >= input.Line {
+			selected = after - 1
+		}
+
+		out.Previous, out.Next = navNeighbour(decls, after-1, input.Line), navNeighbour(decls, after, 0)
+		if selected >= 0 {
+			out.Previous = navNeighbour(decls, selected-1, 0)
+		}
+	case navigateNext:
+		selected = after
+	case navigatePrev:
+		selected = after - 1
+		if selected >= 0 && decls[selected].EndLine >= input.Line {
+			selected--
+		}
+	case navigateFirst:
+		selected = 0
+	case navigateLast:
+		selected = len(decls) - 1
+	}
+
+	if selected < 0 || selected >= len(decls) {
+		return nil, out, nil
+	}
+
+	d := decls[selected]
+	out.Declaration = &d
+
+	if direction != navigateAt {
+		out.Previous, out.Next = navNeighbour(decls, selected-1, 0), navNeighbour(decls, selected+1, 0)
+	}
+
+	return nil, out, nil
+}
+
+// navNeighbour returns a copy of decls[i], or nil if i is out of range or the declaration does not end
+// before line (a line of 0 accepts any declaration).
+func navNeighbour(decls []FileDeclaration, i, line int) *FileDeclaration {
+	if i < 0 || i >= len(decls) || (line > 0 && decls[i].EndLine >= line) {
+		return nil
+	}
+
+	d := decls[i]
+
+	return &d
+}
+
+// spanStart is the first line of a declaration including its doc comment.
+func (d FileDeclaration) spanStart() int {
+	if d.DocLine > 0 {
+		return d.DocLine
+	}
+
+	return d.StartLine
+}
+
+// fileNavIndexFor returns the cached index of the file at path, parsing it again if it changed.
+func fileNavIndexFor(path string) (*fileNavIndex, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, notFound(nil, "file %q not found", path)
+		}
+
+		return nil, err
+	}
+
+	fileNavCache.RLock()
+	idx, ok := fileNavCache.data[path]
+	fileNavCache.RUnlock()
+
+	if ok && idx.modTime.Equal(st.ModTime()) && idx.size == st.Size() {
+		fileNavCache.Lock()
+		idx.lastAccess = time.Now()
+		fileNavCache.Unlock()
+
+		return idx, nil
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx = buildFileNavIndex(path, src)
+	idx.modTime, idx.size, idx.hash = st.ModTime(), st.Size(), hashContent(src)
+
+	fileNavCache.Lock()
+	fileNavCache.data[path] = idx
+	fileNavCache.Unlock()
+
+	return idx, nil
+}
+
+// buildFileNavIndex parses src and indexes its top-level declarations other than imports. A file that
+// does not parse is indexed from the partial syntax tree, as it typically is in the middle of an edit.
+func buildFileNavIndex(path string, src []byte) *fileNavIndex {
+	fset := token.NewFileSet()
+	idx := &fileNavIndex{lastAccess: time.Now()}
+
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		idx.parseError = err.Error()
+	}
+
+	idx.lines = strings.Count(string(src), "\n")
+	if len(src) > 0 && src[len(src)-1] != '\n' {
+		idx.lines++
+	}
+
+	if file == nil {
+		return idx
+	}
+
+	for _, decl := range file.Decls {
+		d := FileDeclaration{
+			StartLine: fset.Position(decl.Pos()).Line,
+			EndLine:   fset.Position(decl.End()).Line,
+		}
+
+		var doc *ast.CommentGroup
+
+		switch x := decl.(type) {
+		case *ast.FuncDecl:
+			d.Kind, d.Name, doc = "func", qualifiedFuncName(x), x.Doc
+			if x.Recv != nil {
+				d.Kind = "method"
+			}
+		case *ast.GenDecl:
+			if x.Tok == token.IMPORT {
+				continue
+			}
+
+			d.Kind, doc = strings.ToLower(x.Tok.String()), x.Doc
+
+			for _, spec := range x.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					d.Names = append(d.Names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						d.Names = append(d.Names, name.Name)
+					}
+				}
+			}
+
+			if len(d.Names) > 0 {
+				d.Name = d.Names[0]
+			}
+
+			if len(d.Names) < 2 {
+				d.Names = nil
+			}
+		default:
+			continue
+		}
+
+		if doc != nil {
+			d.DocLine = fset.Position(doc.Pos()).Line
+		}
+
+		idx.decls = append(idx.decls, d)
+	}
+
+	return idx
+}
+
+// cleanupFileNavCache removes navigation indexes not used within maxAge.
+func cleanupFileNavCache(maxAge time.Duration) {
+	fileNavCache.Lock()
+	defer fileNavCache.Unlock()
+
+	now := time.Now()
+	for key, idx := range fileNavCache.data {
+		if now.Sub(idx.lastAccess) > maxAge {
+			delete(fileNavCache.data, key)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const navigateSource = `package lang
+
+import "strings"
+
+// Limit bounds a value.
+const Limit = 10
+
+const (
+	Low  = 1
+	High = 2
+)
+
+// Box holds a value.
+type Box struct {
+	v int
+}
+
+// Get returns the value.
+func (b Box) Get() int {
+	return b.v
+}
+
+func upper(s string) string {
+	return strings.ToUpper(s)
+}
+`
+
+func navigate(t *testing.T, in tools.NavigateFileInput) tools.NavigateFileOutput {
+	t.Helper()
+
+	_, out, err := tools.NavigateFile(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("NavigateFile error: %v", err)
+	}
+
+	return out
+}
+
+func TestNavigateFile_Directions(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"nav.go": navigateSource})
+
+	// Line 18 is the doc comment of Box.Get.
+	out := navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Line: 18})
+	if out.Total != 5 || out.Lines != 25 || out.Declaration == nil {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+
+	want := tools.FileDeclaration{Kind: "method", Name: "Box.Get", DocLine: 18, StartLine: 19, EndLine: 21}
+	if got := *out.Declaration; got.Kind != want.Kind || got.Name != want.Name || got.DocLine != want.DocLine ||
+		got.StartLine != want.StartLine || got.EndLine != want.EndLine {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if out.Previous == nil || out.Previous.Name != "Box" || out.Next == nil || out.Next.Name != "upper" {
+		t.Fatalf("unexpected neighbours: %+v, %+v", out.Previous, out.Next)
+	}
+
+	// Between declarations nothing is selected, but both neighbours are reported.
+	out = navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Line: 12})
+	if out.Declaration != nil || out.Previous == nil || out.Previous.Name != "Low" || out.Next == nil || out.Next.Name != "Box" {
+		t.Fatalf("unexpected result between declarations: %+v", out)
+	}
+
+	if len(out.Previous.Names) != 2 {
+		t.Fatalf("expected both names of the const group, got %+v", out.Previous)
+	}
+
+	out = navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Line: 16, Direction: "next"})
+	if out.Declaration == nil || out.Declaration.Name != "Box.Get" {
+		t.Fatalf("expected Box.Get after Box, got %+v", out.Declaration)
+	}
+
+	out = navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Line: 16, Direction: "prev"})
+	if out.Declaration == nil || out.Declaration.Name != "Low" {
+		t.Fatalf("expected the const group before Box, got %+v", out.Declaration)
+	}
+
+	out = navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Direction: "first", Kind: "const"})
+	if out.Total != 2 || out.Declaration == nil || out.Declaration.Name != "Limit" || out.Previous != nil {
+		t.Fatalf("expected Limit first among consts, got %+v", out)
+	}
+
+	out = navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Line: 24, Direction: "next"})
+	if out.Declaration != nil {
+		t.Fatalf("expected nothing after the last declaration, got %+v", out.Declaration)
+	}
+}
+
+func TestNavigateFile_RefreshesAfterEdit(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"nav.go": navigateSource})
+
+	out := navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Direction: "last"})
+	if out.Declaration == nil || out.Declaration.Name != "upper" {
+		t.Fatalf("expected upper last, got %+v", out.Declaration)
+	}
+
+	edited := navigateSource + "\nfunc lower(s string) string {\n\treturn strings.ToLower(s\n"
+	if err := os.WriteFile(filepath.Join(dir, "nav.go"), []byte(edited), 0o644); err != nil {
+		t.Fatalf("write nav.go: %v", err)
+	}
+
+	out = navigate(t, tools.NavigateFileInput{Dir: dir, File: "nav.go", Direction: "last"})
+	if out.Declaration == nil || out.Declaration.Name != "lower" || out.ParseError == "" {
+		t.Fatalf("expected the partially parsed lower last with a parse error, got %+v", out)
+	}
+}
+
+func TestNavigateFile_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"nav.go": navigateSource})
+
+	cases := []tools.NavigateFileInput{
+		{Dir: dir, File: "nav.go", Line: 1, Direction: "up"},
+		{Dir: dir, File: "nav.go", Line: 1, Kind: "import"},
+		{Dir: dir, File: "nav.go", Line: 99},
+		{Dir: dir, File: "nav.go"},
+	}
+
+	for _, in := range cases {
+		_, _, err := tools.NavigateFile(context.Background(), &mcp.CallToolRequest{}, in)
+		if err == nil || tools.AsToolError(err).Code != tools.CodeInvalidInput {
+			t.Errorf("%+v: expected INVALID_INPUT, got %v", in, err)
+		}
+	}
+
+	_, _, err := tools.NavigateFile(context.Background(), &mcp.CallToolRequest{},
+		tools.NavigateFileInput{Dir: dir, File: "missing.go", Line: 1})
+	if err == nil || tools.AsToolError(err).Code != tools.CodeNotFound {
+		t.Fatalf("expected NOT_FOUND for a missing file, got %v", err)
+	}
+}
+
+func BenchmarkNavigateFile(b *testing.B) {
+	in := tools.NavigateFileInput{Dir: benchDir(), File: "complex.go", Line: 20, Direction: "next"}
+
+	for b.Loop() {
+		_, _, err := tools.NavigateFile(context.Background(), &mcp.CallToolRequest{}, in)
+		if err != nil {
+			b.Fatalf("NavigateFile error: %v", err)
+		}
+	}
+}
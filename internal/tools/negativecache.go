@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// negativeLookupTTL is how long a failed symbol lookup is remembered.
+const negativeLookupTTL = 30 * time.Second
+
+// negativeLookups remembers symbol lookups that found nothing, so that repeated probes for a missing
+// symbol answer without walking the packages again. hits counts the lookups answered from it.
+var negativeLookups = struct {
+	sync.Mutex
+
+	entries map[string]negativeLookup
+	hits    int
+}{entries: make(map[string]negativeLookup)}
+
+// negativeLookup is a remembered NOT_FOUND error. It holds only for the load it was computed on,
+// identified by its first package: a reload after a file change returns new packages.
+type negativeLookup struct {
+	err     error
+	load    *packages.Package
+	expires time.Time
+}
+
+// negativeLookupKey returns the key of a lookup of ident of kind, or of symbolID, in pkgPath under dir.
+func negativeLookupKey(dir, symbolID, ident, kind, pkgPath string) string {
+	return strings.Join([]string{CanonicalDir(dir), symbolID, ident, kind, pkgPath}, "\x00")
+}
+
+// cachedNegativeLookup returns the remembered NOT_FOUND error of key if it was computed on pkgs and has
+// not expired.
+func cachedNegativeLookup(key string, pkgs []*packages.Package) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+
+	negativeLookups.Lock()
+	defer negativeLookups.Unlock()
+
+	entry, ok := negativeLookups.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if entry.load != pkgs[0] || time.Now().After(entry.expires) {
+		delete(negativeLookups.entries, key)
+
+		return nil
+	}
+
+	negativeLookups.hits++
+
+	return entry.err
+}
+
+// rememberNegativeLookup stores err under key for pkgs when it is a NOT_FOUND error.
+func rememberNegativeLookup(key string, pkgs []*packages.Package, err error) {
+	if te := AsToolError(err); te == nil || te.Code != CodeNotFound || len(pkgs) == 0 {
+		return
+	}
+
+	negativeLookups.Lock()
+	negativeLookups.entries[key] = negativeLookup{err: err, load: pkgs[0], expires: time.Now().Add(negativeLookupTTL)}
+	negativeLookups.Unlock()
+}
+
+// forgetNegativeLookups drops the remembered lookups under dir, whose packages updateFile patched in
+// place: the symbols they missed may exist now.
+func forgetNegativeLookups(dir string) {
+	prefix := CanonicalDir(dir) + "\x00"
+
+	negativeLookups.Lock()
+	defer negativeLookups.Unlock()
+
+	for key := range negativeLookups.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(negativeLookups.entries, key)
+		}
+	}
+}
+
+// cleanupNegativeLookups drops the expired remembered lookups.
+func cleanupNegativeLookups() {
+	negativeLookups.Lock()
+	defer negativeLookups.Unlock()
+
+	now := time.Now()
+	for key, entry := range negativeLookups.entries {
+		if now.After(entry.expires) {
+			delete(negativeLookups.entries, key)
+		}
+	}
+}
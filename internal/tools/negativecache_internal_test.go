@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Not parallel: the test counts the hits of the shared negative lookup cache.
+func TestNegativeLookups_RememberedUntilReload(t *testing.T) {
+	dir := writeCoalesceModule(t)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	negativeLookups.Lock()
+	hits := negativeLookups.hits
+	negativeLookups.Unlock()
+
+	for range 2 {
+		_, _, err := FindReferences(ctx, req, FindReferencesInput{Dir: dir, Ident: "Missing"})
+		if te := AsToolError(err); te == nil || te.Code != CodeNotFound {
+			t.Fatalf("FindReferences(Missing) = %v, want NOT_FOUND", err)
+		}
+	}
+
+	negativeLookups.Lock()
+	hits = negativeLookups.hits - hits
+	negativeLookups.Unlock()
+
+	if hits != 1 {
+		t.Errorf("two probes for Missing hit the negative cache %d times, want 1", hits)
+	}
+
+	path := filepath.Join(dir, "a.go")
+	if err := os.WriteFile(path, []byte("package coalesce\n\nfunc A() {}\n\nfunc Missing() {}\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	invalidateCachesForFile(path)
+
+	if _, _, err := FindReferences(ctx, req, FindReferencesInput{Dir: dir, Ident: "Missing"}); err != nil {
+		t.Errorf("after the change FindReferences(Missing) = %v, want it found", err)
+	}
+
+	// The lookup remembered for the load before the change is gone.
+	negativeLookups.Lock()
+	_, remembered := negativeLookups.entries[negativeLookupKey(dir, "", "Missing", "", "")]
+	negativeLookups.Unlock()
+
+	if remembered {
+		t.Errorf("lookup of Missing still remembered after the reload")
+	}
+}
@@ -0,0 +1,452 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// ValidateNewFile checks a file before it is written: whether its package clause matches the package of
+// the target directory, whether it parses, whether its imports close an import cycle with the module's
+// packages, and which identifiers neither the file, its imports nor the other files of its package
+// declare. The undefined check is syntax-only and best-effort: it assumes the conventional name of
+// imports outside the module and is skipped for files with dot imports or syntax errors. Nothing is
+// written.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, the intended path and the source
+//
+// Returns:
+//   - MCP tool call result
+//   - verdict with the expected package name, syntax errors, import cycles and undefined identifiers
+//   - error if the path is invalid or packages cannot be loaded
+func ValidateNewFile(ctx context.Context, _ *mcp.CallToolRequest, input ValidateNewFileInput) (
+	*mcp.CallToolResult,
+	ValidateNewFileOutput,
+	error,
+) {
+	start := logStart("ValidateNewFile", logFields(
+		input.Dir,
+		newLogField("path", input.Path),
+		newLogField("bytes", strconv.Itoa(len(input.Source))),
+	))
+	out := ValidateNewFileOutput{
+		SyntaxErrors: []BuildError{},
+		ImportCycles: []NewFileImportCycle{},
+		Undefined:    []UndefinedIdentifier{},
+	}
+
+	defer func() { logEnd("ValidateNewFile", start, len(out.Problems)) }()
+
+	abs, rel, err := newFilePath(input.Dir, input.Path)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.Path = rel
+
+	if _, err := os.Stat(abs); err == nil {
+		out.Exists = true
+	}
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeImports)
+	if err != nil {
+		logError("ValidateNewFile", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	fset := token.NewFileSet()
+
+	file, parseErr := parser.ParseFile(fset, rel, input.Source, parser.AllErrors|parser.ParseComments)
+	if file != nil && file.Name != nil {
+		out.PackageName = file.Name.Name
+	}
+
+	var list scanner.ErrorList
+	if errors.As(parseErr, &list) {
+		for _, e := range list {
+			out.SyntaxErrors = append(out.SyntaxErrors, BuildError{
+				Kind:    "parse",
+				File:    rel,
+				Line:    e.Pos.Line,
+				Column:  e.Pos.Column,
+				Message: e.Msg,
+			})
+		}
+	} else if parseErr != nil {
+		out.SyntaxErrors = append(out.SyntaxErrors, BuildError{Kind: "parse", File: rel, Message: parseErr.Error()})
+	}
+
+	fileDir := filepath.Dir(abs)
+	isTest := strings.HasSuffix(abs, "_test.go")
+	siblings := parseSiblingFiles(fileDir, abs)
+
+	out.Package = newFileImportPath(input.Dir, fileDir, pkgs)
+	out.ExpectedPackageName, out.NewPackage = expectedPackageName(fileDir, siblings)
+
+	if out.PackageName != "" && !packageClauseMatches(out.PackageName, out.ExpectedPackageName, out.NewPackage, isTest) {
+		out.PackageMismatch = true
+	}
+
+	for _, b := range out.SyntaxErrors {
+		out.Problems = append(out.Problems, fmt.Sprintf("syntax error at %d:%d: %s", b.Line, b.Column, b.Message))
+	}
+
+	if out.PackageMismatch {
+		out.Problems = append(out.Problems, fmt.Sprintf("package clause %q does not match package %q of %s",
+			out.PackageName, out.ExpectedPackageName, path.Dir(rel)))
+	}
+
+	if file == nil {
+		return nil, out, nil
+	}
+
+	// An external test package is not imported by anything, so it cannot close a cycle.
+	if !isTest || !strings.HasSuffix(out.PackageName, "_test") {
+		out.ImportCycles = newFileImportCycles(file, out.Package, pkgs)
+	}
+
+	for _, c := range out.ImportCycles {
+		out.Problems = append(out.Problems, fmt.Sprintf("import %q creates the cycle %s", c.Import, strings.Join(c.Cycle, " -> ")))
+	}
+
+	if len(out.SyntaxErrors) == 0 {
+		out.Undefined, out.UndefinedSkipped = undefinedIdentifiers(fset, file, siblings, pkgs)
+
+		for _, u := range out.Undefined {
+			out.Problems = append(out.Problems, fmt.Sprintf("undefined: %s at %d:%d", u.Name, u.Line, u.Column))
+		}
+	}
+
+	out.Valid = len(out.Problems) == 0
+
+	return nil, out, nil
+}
+
+// newFilePath resolves the intended path of a new Go file against dir, returning it absolute and
+// relative to dir; it must stay inside dir.
+func newFilePath(dir, p string) (string, string, error) {
+	if p == "" {
+		return "", "", invalidInput("path is required")
+	}
+
+	if !strings.HasSuffix(p, ".go") {
+		return "", "", invalidInput("path %q is not a .go file", p)
+	}
+
+	abs := p
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(CanonicalDir(dir), filepath.FromSlash(p))
+	}
+
+	rel := relativePath(dir, abs)
+	if escapesDir(filepath.FromSlash(rel)) || filepath.IsAbs(rel) {
+		return "", "", invalidInput("path %q is outside %s", p, dir)
+	}
+
+	return abs, rel, nil
+}
+
+// siblingFile is a Go file of the target directory matching the build context.
+type siblingFile struct {
+	name string
+	file *ast.File
+}
+
+// parseSiblingFiles parses the Go files of dir matching the build context, other than exclude, keeping
+// their declarations.
+func parseSiblingFiles(dir, exclude string) []siblingFile {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var files []siblingFile
+
+	fset := token.NewFileSet()
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || filepath.Join(dir, name) == exclude {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil || f.Name == nil {
+			continue
+		}
+
+		files = append(files, siblingFile{name: name, file: f})
+	}
+
+	return files
+}
+
+// expectedPackageName returns the package name of the non-test files of dir, else the name its test
+// files belong to, and whether dir holds no package yet; for a new package it returns the conventional
+// name, the last element of dir.
+func expectedPackageName(dir string, siblings []siblingFile) (string, bool) {
+	var testName string
+
+	for _, s := range siblings {
+		if !strings.HasSuffix(s.name, "_test.go") {
+			return s.file.Name.Name, false
+		}
+
+		if testName == "" {
+			testName = strings.TrimSuffix(s.file.Name.Name, "_test")
+		}
+	}
+
+	if testName != "" {
+		return testName, false
+	}
+
+	return assumedPackageName(filepath.ToSlash(dir)), true
+}
+
+// packageClauseMatches reports whether a file declaring name fits the directory's package expected;
+// test files may declare the external test package expected_test. Any name starts a new package.
+func packageClauseMatches(name, expected string, newPackage, isTest bool) bool {
+	if newPackage || name == expected {
+		return true
+	}
+
+	return isTest && name == expected+"_test"
+}
+
+// newFileImportPath returns the import path of the package in fileDir: that of the loaded package with
+// files there, else the module path joined with the directory.
+func newFileImportPath(dir, fileDir string, pkgs []*packages.Package) string {
+	for _, pkg := range pkgs {
+		if d := packageSourceDir(pkg); d != "" && CanonicalDir(d) == CanonicalDir(fileDir) {
+			return pkg.PkgPath
+		}
+	}
+
+	root := findModuleRoot(dir)
+	modulePath, _ := readGoModInfo(root)
+
+	rel := relativePath(root, fileDir)
+	if rel == "" || rel == "." {
+		return modulePath
+	}
+
+	return path.Join(modulePath, rel)
+}
+
+// newFileImportCycles returns, for every import of file that reaches target through the module's
+// import graph, the cycle it would close, starting and ending at target.
+func newFileImportCycles(file *ast.File, target string, pkgs []*packages.Package) []NewFileImportCycle {
+	graph := make(map[string][]string, len(pkgs))
+
+	for _, pkg := range pkgs {
+		graph[pkg.PkgPath] = sortedKeys(pkg.Imports)
+	}
+
+	var cycles []NewFileImportCycle
+
+	for _, spec := range file.Imports {
+		imp, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		if imp == target {
+			cycles = append(cycles, NewFileImportCycle{Import: imp, Cycle: []string{target, target}})
+
+			continue
+		}
+
+		if _, ok := graph[imp]; !ok {
+			continue
+		}
+
+		if chain := importChain(graph, imp, target); chain != nil {
+			cycles = append(cycles, NewFileImportCycle{Import: imp, Cycle: append([]string{target}, chain...)})
+		}
+	}
+
+	return cycles
+}
+
+// importChain returns the shortest chain of imports from one package to another, both included, or nil.
+func importChain(graph map[string][]string, from, to string) []string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if cur == to {
+			var chain []string
+
+			for p := cur; p != ""; p = prev[p] {
+				chain = append([]string{p}, chain...)
+			}
+
+			return chain
+		}
+
+		for _, next := range graph[cur] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil
+}
+
+// undefinedIdentifiers returns the identifiers file leaves unresolved that are neither predeclared,
+// import names, nor declared at package level by the siblings in the same package, first use only. It
+// reports true instead when a dot import makes the check meaningless.
+func undefinedIdentifiers(fset *token.FileSet, file *ast.File, siblings []siblingFile, pkgs []*packages.Package) ([]UndefinedIdentifier, bool) {
+	names := make(map[string]string, len(pkgs))
+	for _, pkg := range pkgs {
+		names[pkg.PkgPath] = pkg.Name
+	}
+
+	known := make(map[string]bool)
+
+	for _, spec := range file.Imports {
+		imp, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case spec.Name != nil && spec.Name.Name == ".":
+			return []UndefinedIdentifier{}, true
+		case spec.Name != nil:
+			known[spec.Name.Name] = true
+		case names[imp] != "":
+			known[names[imp]] = true
+		default:
+			known[assumedPackageName(imp)] = true
+		}
+	}
+
+	for _, s := range siblings {
+		if s.file.Name.Name != file.Name.Name {
+			continue
+		}
+
+		for _, decl := range s.file.Decls {
+			for _, name := range declNames(decl) {
+				known[name] = true
+			}
+		}
+	}
+
+	undefined := []UndefinedIdentifier{}
+	seen := make(map[string]bool)
+
+	for _, ident := range file.Unresolved {
+		name := ident.Name
+		if known[name] || seen[name] || types.Universe.Lookup(name) != nil {
+			continue
+		}
+
+		seen[name] = true
+		posn := fset.Position(ident.Pos())
+		undefined = append(undefined, UndefinedIdentifier{Name: name, Line: posn.Line, Column: posn.Column})
+	}
+
+	sort.SliceStable(undefined, func(i, j int) bool {
+		if undefined[i].Line != undefined[j].Line {
+			return undefined[i].Line < undefined[j].Line
+		}
+
+		return undefined[i].Column < undefined[j].Column
+	})
+
+	return undefined, false
+}
+
+// declNames returns the package-level names declared by decl; methods declare none.
+func declNames(decl ast.Decl) []string {
+	var names []string
+
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil {
+			names = append(names, d.Name.Name)
+		}
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					names = append(names, n.Name)
+				}
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// assumedPackageName returns the name a package with import path p conventionally has: its last element
+// without a major version (example.com/yaml/v3, gopkg.in/yaml.v3), a "go-" prefix and anything from the
+// first character not valid in an identifier on.
+func assumedPackageName(p string) string {
+	elems := strings.Split(p, "/")
+	name := elems[len(elems)-1]
+
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+
+	if i := strings.LastIndex(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+
+	name = strings.TrimPrefix(name, "go-")
+
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return name[:i]
+		}
+	}
+
+	return name
+}
+
+// isMajorVersion reports whether s is a major version suffix such as v2.
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+
+	_, err := strconv.Atoi(s[1:])
+
+	return err == nil
+}
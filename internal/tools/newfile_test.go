@@ -0,0 +1,71 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestValidateNewFile(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"store/store.go": "package store\n\nimport \"lang/util\"\n\nfunc Get() string { return util.Name }\n",
+		"util/util.go":   "package util\n\nconst Name = \"x\"\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	validate := func(path, source string) tools.ValidateNewFileOutput {
+		t.Helper()
+
+		_, out, err := tools.ValidateNewFile(ctx, req, tools.ValidateNewFileInput{Dir: dir, Path: path, Source: source})
+		if err != nil {
+			t.Fatalf("ValidateNewFile %s: %v", path, err)
+		}
+
+		return out
+	}
+
+	out := validate("store/cache.go", "package cache\n\nfunc Warm() { _ = Get() }\n")
+	if out.Valid || !out.PackageMismatch || out.ExpectedPackageName != "store" || out.PackageName != "cache" || out.Package != "lang/store" {
+		t.Errorf("wrong package clause: %+v, want a mismatch against store", out)
+	}
+
+	out = validate("util/extra.go", "package util\n\nimport \"lang/store\"\n\nvar _ = store.Get\n")
+	if out.Valid || len(out.ImportCycles) != 1 || fmt.Sprint(out.ImportCycles[0].Cycle) != "[lang/util lang/store lang/util]" {
+		t.Errorf("cycle: %+v, want lang/util -> lang/store -> lang/util", out)
+	}
+
+	out = validate("store/extra.go", "package store\n\nimport \"strings\"\n\nfunc Loud() string {\n\treturn strings.ToUpper(Get() + missing)\n}\n")
+	if out.Valid || fmt.Sprintf("%+v", out.Undefined) != "[{Name:missing Line:6 Column:33}]" || len(out.ImportCycles) != 0 {
+		t.Errorf("undefined: %+v, want only missing at 6:33", out)
+	}
+
+	out = validate("store/broken.go", "package store\n\nfunc {\n")
+	if out.Valid || len(out.SyntaxErrors) == 0 || out.SyntaxErrors[0].Line != 3 || len(out.Undefined) != 0 {
+		t.Errorf("syntax: %+v, want an error on line 3 and no undefined check", out)
+	}
+
+	out = validate("store/store_test.go", "package store_test\n\nimport \"lang/store\"\n\nvar _ = store.Get\n")
+	if !out.Valid {
+		t.Errorf("external test package: %+v, want valid", out)
+	}
+
+	out = validate("store/store.go", "package store\n\nfunc Get() string { return \"\" }\n")
+	if !out.Valid || !out.Exists {
+		t.Errorf("replacement: %+v, want valid and exists", out)
+	}
+
+	out = validate("feature/flags.go", "package featureflags\n")
+	if !out.Valid || !out.NewPackage || out.ExpectedPackageName != "feature" || out.Package != "lang/feature" {
+		t.Errorf("new package: %+v, want valid with proposed name feature", out)
+	}
+
+	_, _, err := tools.ValidateNewFile(ctx, req, tools.ValidateNewFileInput{Dir: dir, Path: "../outside.go", Source: "package x\n"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a path outside dir, got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// FindOverexportedSymbols reports exported package-level funcs, types, vars and consts that are referenced
+// only from their own package, so that they could be unexported. Symbols without any reference are left to
+// getDeadCodeReport. Every finding carries an unexported name checked against the package and file scopes,
+// and the renameSymbol input applying it; renameSymbol still checks local shadowing when it runs.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, an optional package and the test, main and generated options
+//
+// Returns:
+//   - MCP tool call result
+//   - overexported symbols grouped by package, with reference counts and suggested renames
+//   - error if packages cannot be loaded
+func FindOverexportedSymbols(ctx context.Context, _ *mcp.CallToolRequest, input FindOverexportedSymbolsInput) (
+	*mcp.CallToolResult,
+	FindOverexportedSymbolsOutput,
+	error,
+) {
+	start := logStart("FindOverexportedSymbols", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := FindOverexportedSymbolsOutput{}
+
+	defer func() { logEnd("FindOverexportedSymbols", start, out.Total) }()
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	filtered, err := filterPackagesByRequest(pkgs, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	usage := packageLevelUsage(ctx, pkgs, input.IgnoreTestReferences)
+	if err := ctx.Err(); err != nil {
+		return fail(out, err)
+	}
+
+	declaredIn := packageLevelDeclarations(pkgs)
+
+	for _, pkg := range filtered {
+		// Test variants and external test packages are covered by the plain package.
+		if pkg.ID != pkg.PkgPath || pkg.Types == nil || pkg.TypesInfo == nil {
+			continue
+		}
+
+		if input.ExcludeMain && pkg.Name == "main" {
+			continue
+		}
+
+		symbols, suppressed := overexportedInPackage(pkg, input, usage, declaredIn)
+		out.Suppressed = append(out.Suppressed, suppressed...)
+
+		if len(symbols) > 0 {
+			out.Packages = append(out.Packages, OverexportedPackage{Package: pkg.PkgPath, Symbols: symbols})
+			out.Total += len(symbols)
+		}
+	}
+
+	sort.Slice(out.Packages, func(i, j int) bool { return out.Packages[i].Package < out.Packages[j].Package })
+	sortSuppressedFindings(out.Suppressed)
+
+	return nil, out, nil
+}
+
+// symbolUsage counts the references to a package-level object.
+type symbolUsage struct {
+	internal int  // references from the defining package, its test files included
+	external bool // referenced from another package
+}
+
+// packageLevelUsage indexes the references to package-level objects by objectKey. Test variants repeat the
+// files of their package, so a position is counted once. With ignoreTests, references from test files of
+// other packages are skipped.
+func packageLevelUsage(ctx context.Context, pkgs []*packages.Package, ignoreTests bool) map[string]*symbolUsage {
+	type position struct {
+		file   string
+		offset int
+	}
+
+	usage := make(map[string]*symbolUsage)
+	seen := make(map[position]struct{})
+
+	for _, pkg := range pkgs {
+		if shouldStop(ctx) || pkg.TypesInfo == nil {
+			continue
+		}
+
+		for ident, obj := range pkg.TypesInfo.Uses {
+			if obj == nil || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+				continue
+			}
+
+			posn := pkg.Fset.Position(ident.Pos())
+			if _, ok := seen[position{posn.Filename, posn.Offset}]; ok {
+				continue
+			}
+
+			seen[position{posn.Filename, posn.Offset}] = struct{}{}
+
+			external := pkg.PkgPath != obj.Pkg().Path()
+			if external && ignoreTests && strings.HasSuffix(posn.Filename, "_test.go") {
+				continue
+			}
+
+			u := usage[objectKey(obj)]
+			if u == nil {
+				u = &symbolUsage{}
+				usage[objectKey(obj)] = u
+			}
+
+			if external {
+				u.external = true
+			} else {
+				u.internal++
+			}
+		}
+	}
+
+	return usage
+}
+
+// packageLevelDeclarations maps the names declared at package level to the paths of the plain packages
+// declaring them.
+func packageLevelDeclarations(pkgs []*packages.Package) map[string][]string {
+	declaredIn := make(map[string][]string)
+
+	for _, pkg := range pkgs {
+		if pkg.ID != pkg.PkgPath || pkg.Types == nil {
+			continue
+		}
+
+		for _, name := range pkg.Types.Scope().Names() {
+			declaredIn[name] = append(declaredIn[name], pkg.PkgPath)
+		}
+	}
+
+	return declaredIn
+}
+
+// overexportedInPackage returns the overexported symbols of pkg in file and line order, and the findings
+// silenced by directives.
+func overexportedInPackage(
+	pkg *packages.Package,
+	input FindOverexportedSymbolsInput,
+	usage map[string]*symbolUsage,
+	declaredIn map[string][]string,
+) ([]OverexportedSymbol, []SuppressedFinding) {
+	generated := make(map[string]struct{})
+
+	for _, file := range pkg.Syntax {
+		if _, ok := generatedFileGenerator(file); ok {
+			generated[pkg.Fset.Position(file.Pos()).Filename] = struct{}{}
+		}
+	}
+
+	suppressions := packageSuppressions(pkg)
+	taken := make(map[string]struct{})
+
+	var (
+		symbols    []OverexportedSymbol
+		suppressed []SuppressedFinding
+		idents     []*ast.Ident
+	)
+
+	for ident, obj := range pkg.TypesInfo.Defs {
+		if obj != nil && isInternalExportedCandidate(ident, obj) {
+			idents = append(idents, ident)
+		}
+	}
+
+	sort.Slice(idents, func(i, j int) bool { return idents[i].Pos() < idents[j].Pos() })
+
+	for _, ident := range idents {
+		obj := pkg.TypesInfo.Defs[ident]
+
+		u := usage[objectKey(obj)]
+		if u == nil || u.external || u.internal == 0 {
+			continue
+		}
+
+		posn := pkg.Fset.Position(ident.Pos())
+		if _, ok := generated[posn.Filename]; ok && !input.IncludeGenerated {
+			continue
+		}
+
+		rel := relativePath(input.Dir, posn.Filename)
+
+		if d, ok := suppressedBy(suppressions[ident], "findOverexportedSymbols"); ok {
+			suppressed = append(suppressed, newSuppressedFinding(rel, posn.Line, ident.Name, d))
+
+			continue
+		}
+
+		sym := OverexportedSymbol{
+			Name:       ident.Name,
+			Kind:       objStringKind(obj),
+			File:       rel,
+			Line:       posn.Line,
+			References: u.internal,
+		}
+
+		newName := unexportedName(ident.Name)
+
+		switch conflict := unexportConflict(pkg, newName, taken); {
+		case conflict != "":
+			sym.Conflict = conflict
+		case len(declaredIn[ident.Name]) > 1:
+			// renameSymbol resolves the old name in the first package declaring it.
+			sym.SuggestedName = newName
+			sym.Conflict = fmt.Sprintf("%s is also declared in %s; renameSymbol cannot tell the declarations apart",
+				ident.Name, strings.Join(otherPackages(declaredIn[ident.Name], pkg.PkgPath), ", "))
+			taken[newName] = struct{}{}
+		default:
+			sym.SuggestedName = newName
+			sym.Rename = &RenameSymbolInput{Dir: input.Dir, OldName: ident.Name, NewName: newName, Kind: sym.Kind}
+			taken[newName] = struct{}{}
+		}
+
+		symbols = append(symbols, sym)
+	}
+
+	return symbols, suppressed
+}
+
+// unexportedName lowercases the leading capital of name, or its whole leading initialism: HTTPClient
+// becomes httpClient, ID becomes id.
+func unexportedName(name string) string {
+	runes := []rune(name)
+
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+
+	// The last capital of an initialism followed by a lowercase letter starts the next word.
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+
+	for i := range n {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
+
+// unexportConflict explains why name cannot replace an exported name of pkg, or returns "".
+func unexportConflict(pkg *packages.Package, name string, taken map[string]struct{}) string {
+	if token.IsKeyword(name) {
+		return fmt.Sprintf("%s is a Go keyword", name)
+	}
+
+	if obj := pkg.Types.Scope().Lookup(name); obj != nil {
+		return fmt.Sprintf("%s is already declared in package %s (%s)", name, pkg.Types.Name(), objStringKind(obj))
+	}
+
+	if _, ok := taken[name]; ok {
+		return fmt.Sprintf("%s is already suggested for another symbol of package %s", name, pkg.Types.Name())
+	}
+
+	for _, file := range pkg.Syntax {
+		if scope := pkg.TypesInfo.Scopes[file]; scope != nil && scope.Lookup(name) != nil {
+			return fmt.Sprintf("%s is an import name in %s", name, filepath.Base(pkg.Fset.Position(file.Pos()).Filename))
+		}
+	}
+
+	if types.Universe.Lookup(name) != nil {
+		return fmt.Sprintf("%s would shadow the predeclared identifier", name)
+	}
+
+	return ""
+}
+
+// otherPackages returns paths without self.
+func otherPackages(paths []string, self string) []string {
+	var others []string
+
+	for _, p := range paths {
+		if p != self {
+			others = append(others, p)
+		}
+	}
+
+	return others
+}
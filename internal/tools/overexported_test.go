@@ -0,0 +1,121 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const overexportedSource = `package lang
+
+import "strings"
+
+func Public() string { return helper() + Strings() }
+
+func Strings() string { return strings.ToUpper(Config) }
+
+var Config = "x"
+
+type HTTPClient struct{}
+
+func newClient() *HTTPClient { return &HTTPClient{} }
+
+func helper() string {
+	_ = newClient()
+	Parse()
+
+	return ""
+}
+
+const Unused = 1
+
+func Parse() {}
+
+func parse() {}
+
+var _ = Tested
+
+func Tested() int { return 1 }
+`
+
+func TestFindOverexportedSymbols(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"lang.go":      overexportedSource,
+		"lang_test.go": "package lang_test\n\nimport (\n\t\"testing\"\n\n\t\"lang\"\n)\n\nfunc TestTested(t *testing.T) { _ = lang.Tested() }\n",
+	})
+
+	for name, content := range map[string]string{
+		"app/app.go":  "package app\n\nimport \"lang\"\n\nvar V = lang.Public()\n",
+		"cmd/main.go": "package main\n\nfunc Run() {}\n\nfunc main() { Run() }\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.FindOverexportedSymbols(ctx, req, tools.FindOverexportedSymbolsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("FindOverexportedSymbols error: %v", err)
+	}
+
+	got := make(map[string]tools.OverexportedSymbol)
+
+	for _, pkg := range out.Packages {
+		for _, sym := range pkg.Symbols {
+			got[pkg.Package+"."+sym.Name] = sym
+		}
+	}
+
+	if len(got) != 5 || out.Total != 5 {
+		t.Fatalf("expected Strings, Config, HTTPClient, Parse and main's Run, got %+v", out.Packages)
+	}
+
+	client := got["lang.HTTPClient"]
+	if client.References != 2 || client.SuggestedName != "httpClient" || client.Rename == nil ||
+		!reflect.DeepEqual(*client.Rename, tools.RenameSymbolInput{Dir: dir, OldName: "HTTPClient", NewName: "httpClient", Kind: "type"}) {
+		t.Fatalf("expected HTTPClient -> httpClient with a rename payload, got %+v", client)
+	}
+
+	if cfg := got["lang.Config"]; cfg.SuggestedName != "config" || cfg.Kind != "var" || cfg.Rename == nil {
+		t.Fatalf("expected Config -> config, got %+v", cfg)
+	}
+
+	if s := got["lang.Strings"]; s.SuggestedName != "" || s.Rename != nil || s.Conflict == "" {
+		t.Fatalf("expected strings to collide with the import name, got %+v", s)
+	}
+
+	if p := got["lang.Parse"]; p.SuggestedName != "" || p.Rename != nil || p.Conflict == "" {
+		t.Fatalf("expected parse to collide with the package scope, got %+v", p)
+	}
+
+	if _, ok := got["lang/cmd.Run"]; !ok {
+		t.Fatalf("expected Run of the main package, got %+v", out.Packages)
+	}
+
+	_, out, err = tools.FindOverexportedSymbols(ctx, req, tools.FindOverexportedSymbolsInput{
+		Dir:                  dir,
+		ExcludeMain:          true,
+		IgnoreTestReferences: true,
+	})
+	if err != nil {
+		t.Fatalf("FindOverexportedSymbols error: %v", err)
+	}
+
+	if len(out.Packages) != 1 || out.Packages[0].Package != "lang" || out.Total != 5 {
+		t.Fatalf("expected only lang, with Tested counted once its test use is ignored, got %+v", out.Packages)
+	}
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"go/build"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// ResolvePackageDir maps import paths of module packages to their directories relative to dir, the
+// inputs tools taking a dir or a file need, without loading syntax or types.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and the import paths to resolve
+//
+// Returns:
+//   - MCP tool call result
+//   - directories of the requested packages (all packages when none are given) and the paths not found
+//   - error if packages failed to load
+func ResolvePackageDir(ctx context.Context, _ *mcp.CallToolRequest, input ResolvePackageDirInput) (
+	*mcp.CallToolResult,
+	ResolvePackageDirOutput,
+	error,
+) {
+	start := logStart("ResolvePackageDir", logFields(
+		input.Dir,
+		newLogField("packages", strconv.Itoa(len(input.Packages))),
+	))
+	out := ResolvePackageDirOutput{PackageDirs: map[string]string{}}
+
+	defer func() { logEnd("ResolvePackageDir", start, len(out.PackageDirs)) }()
+
+	pkgs, err := loadPackagesWithCache(ctx, input.Dir, loadModeBasic)
+	if err != nil {
+		logError("ResolvePackageDir", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	all := packageDirs(input.Dir, pkgs)
+	out.RootDir = input.Dir
+
+	if len(input.Packages) == 0 {
+		out.PackageDirs = all
+
+		return nil, out, nil
+	}
+
+	for _, path := range input.Packages {
+		if dir, ok := all[path]; ok {
+			out.PackageDirs[path] = dir
+		} else if !slices.Contains(out.Unresolved, path) {
+			out.Unresolved = append(out.Unresolved, path)
+		}
+	}
+
+	sort.Strings(out.Unresolved)
+
+	return nil, out, nil
+}
+
+// packageDirs maps the import path of every package of pkgs with source files to its directory relative
+// to root in slash form, "." for root itself.
+func packageDirs(root string, pkgs []*packages.Package) map[string]string {
+	dirs := make(map[string]string, len(pkgs))
+
+	for _, pkg := range pkgs {
+		dir := packageSourceDir(pkg)
+		if dir == "" {
+			continue
+		}
+
+		rel := relativePath(root, dir)
+		if rel == "" {
+			rel = "."
+		}
+
+		dirs[normalizePackagePath(pkg)] = rel
+	}
+
+	return dirs
+}
+
+// packageSourceDir returns the directory of the source files of pkg, or "" if none are known. GoFiles
+// are preferred: the compiled files of cgo packages live in the build cache.
+func packageSourceDir(pkg *packages.Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles} {
+		if len(files) > 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+
+	return ""
+}
+
+// countPackageFiles returns the numbers of non-test and test Go files in dir matching the current build
+// context, the files go list selects for the package.
+func countPackageFiles(dir string) (files, tests int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		if strings.HasSuffix(name, "_test.go") {
+			tests++
+		} else {
+			files++
+		}
+	}
+
+	return files, tests
+}
@@ -0,0 +1,77 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func writePackageDirsModule(t *testing.T) string {
+	t.Helper()
+
+	return writeLanguageModule(t, "1.22", map[string]string{
+		"root.go":                "package lang\n",
+		"root_test.go":           "package lang\n",
+		"a/b/c/c.go":             "package c\n",
+		"a/b/c/c_other.go":       "package c\n",
+		"a/b/c/c_test.go":        "package c\n",
+		"a/b/c/c_ext_test.go":    "package c_test\n",
+		"internal/db/storage.go": "package database\n",
+	})
+}
+
+func TestProjectSchema_PackageDirs(t *testing.T) {
+	t.Parallel()
+
+	dir := writePackageDirsModule(t)
+
+	for _, depth := range []string{"summary", "standard"} {
+		_, out, err := tools.ProjectSchema(context.Background(), &mcp.CallToolRequest{}, tools.ProjectSchemaInput{Dir: dir, Depth: depth})
+		if err != nil {
+			t.Fatalf("ProjectSchema %s: %v", depth, err)
+		}
+
+		want := map[string]string{"lang": ".", "lang/a/b/c": "a/b/c", "lang/internal/db": "internal/db"}
+		if fmt.Sprint(out.PackageDirs) != fmt.Sprint(want) {
+			t.Errorf("%s: packageDirs = %v, want %v", depth, out.PackageDirs, want)
+		}
+
+		var counts []string
+		for _, p := range out.Packages {
+			counts = append(counts, fmt.Sprintf("%s(%s) %d+%d", p.Path, p.Name, p.FileCount, p.TestFileCount))
+		}
+
+		wantCounts := []string{"lang(lang) 1+1", "lang/a/b/c(c) 2+2", "lang/internal/db(database) 1+0"}
+		if fmt.Sprint(counts) != fmt.Sprint(wantCounts) {
+			t.Errorf("%s: file counts = %v, want %v", depth, counts, wantCounts)
+		}
+	}
+}
+
+func TestResolvePackageDir(t *testing.T) {
+	t.Parallel()
+
+	dir := writePackageDirsModule(t)
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.ResolvePackageDir(ctx, req, tools.ResolvePackageDirInput{
+		Dir:      dir,
+		Packages: []string{"lang/internal/db", "lang/a/b/c", "lang/missing", "fmt"},
+	})
+	if err != nil {
+		t.Fatalf("ResolvePackageDir: %v", err)
+	}
+
+	want := map[string]string{"lang/a/b/c": "a/b/c", "lang/internal/db": "internal/db"}
+	if fmt.Sprint(out.PackageDirs) != fmt.Sprint(want) || fmt.Sprint(out.Unresolved) != "[fmt lang/missing]" || out.RootDir != dir {
+		t.Errorf("out = %+v, want %v and fmt, lang/missing unresolved", out, want)
+	}
+
+	_, out, err = tools.ResolvePackageDir(ctx, req, tools.ResolvePackageDirInput{Dir: dir})
+	if err != nil || len(out.PackageDirs) != 3 || out.PackageDirs["lang"] != "." {
+		t.Errorf("all packages: %+v, %v", out, err)
+	}
+}
@@ -0,0 +1,395 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	// defaultMaxParams is the parameter count above which a function is flagged.
+	defaultMaxParams = 5
+	// minParameterGroupSize is the number of parameters a shared group needs.
+	minParameterGroupSize = 3
+	// minParameterGroupFunctions is the number of signatures a shared group must appear in.
+	minParameterGroupFunctions = 3
+)
+
+// SuggestParameterObjects reports functions with more than maxParams parameters and, per package, groups
+// of at least three parameters with identical names and types shared by at least three signatures, each
+// with a suggested struct to replace them. context.Context, variadic and unnamed parameters are never
+// grouped. Only signatures are inspected, through go/types, so function bodies do not matter.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, an optional package and the maxParams threshold
+//
+// Returns:
+//   - MCP tool call result
+//   - flagged functions and parameter groups ordered by the number of functions they would simplify
+//   - error if packages cannot be loaded
+func SuggestParameterObjects(ctx context.Context, _ *mcp.CallToolRequest, input SuggestParameterObjectsInput) (
+	*mcp.CallToolResult,
+	SuggestParameterObjectsOutput,
+	error,
+) {
+	start := logStart("SuggestParameterObjects", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := SuggestParameterObjectsOutput{Functions: []FunctionParams{}, Groups: []ParameterGroup{}}
+
+	defer func() { logEnd("SuggestParameterObjects", start, len(out.Groups)) }()
+
+	if input.MaxParams < 0 {
+		return fail(out, invalidInput("maxParams must not be negative, got %d", input.MaxParams))
+	}
+
+	maxParams := input.MaxParams
+	if maxParams == 0 {
+		maxParams = defaultMaxParams
+	}
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "SuggestParameterObjects")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	signatures := make(map[string][]paramSignature)
+
+	err = walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if _, generated := generatedFileGenerator(file); generated {
+			return nil
+		}
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			fn, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			sig := newParamSignature(pkg, fd, fn, relPath)
+			signatures[pkg.PkgPath] = append(signatures[pkg.PkgPath], sig)
+
+			if sig.count > maxParams {
+				out.Functions = append(out.Functions, FunctionParams{
+					Name: sig.name, Package: pkg.PkgPath, File: relPath, Line: sig.line, Params: sig.count, ExceedsMax: true,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fail(out, err)
+	}
+
+	reported := make(map[string]struct{}, len(out.Functions))
+	for _, f := range out.Functions {
+		reported[fmt.Sprintf("%s:%d", f.File, f.Line)] = struct{}{}
+	}
+
+	for pkgPath, sigs := range signatures {
+		groups := parameterGroups(pkgPath, sigs)
+		out.Groups = append(out.Groups, groups...)
+
+		// Group members within the limit are reported too, so each affected function shows its count.
+		for _, sig := range sigs {
+			key := fmt.Sprintf("%s:%d", sig.file, sig.line)
+			if _, ok := reported[key]; ok || !inParameterGroup(groups, sig) {
+				continue
+			}
+
+			reported[key] = struct{}{}
+			out.Functions = append(out.Functions, FunctionParams{
+				Name: sig.name, Package: pkgPath, File: sig.file, Line: sig.line, Params: sig.count,
+			})
+		}
+	}
+
+	sort.Slice(out.Functions, func(i, j int) bool {
+		a, b := out.Functions[i], out.Functions[j]
+		if a.Params != b.Params {
+			return a.Params > b.Params
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	sort.Slice(out.Groups, func(i, j int) bool {
+		a, b := out.Groups[i], out.Groups[j]
+		if len(a.Functions) != len(b.Functions) {
+			return len(a.Functions) > len(b.Functions)
+		}
+
+		if len(a.Params) != len(b.Params) {
+			return len(a.Params) > len(b.Params)
+		}
+
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		return a.SuggestedName < b.SuggestedName
+	})
+
+	return nil, out, nil
+}
+
+// paramSignature is the groupable parameters of one function.
+type paramSignature struct {
+	name     string // Name or Recv.Name
+	exported bool
+	file     string
+	line     int
+	count    int
+	params   []ParameterField // groupable parameters in signature order
+}
+
+// newParamSignature collects the parameters of fn that can move into a struct.
+func newParamSignature(pkg *packages.Package, fd *ast.FuncDecl, fn *types.Func, relPath string) paramSignature {
+	sig := fn.Type().(*types.Signature)
+
+	s := paramSignature{
+		name:     fd.Name.Name,
+		exported: fn.Exported(),
+		file:     relPath,
+		line:     pkg.Fset.Position(fd.Name.Pos()).Line,
+		count:    sig.Params().Len(),
+	}
+
+	if recv := receiverName(fd); recv != "" {
+		s.name = recv + "." + s.name
+	}
+
+	qualifier := types.RelativeTo(pkg.Types)
+
+	for i := range sig.Params().Len() {
+		p := sig.Params().At(i)
+		if p.Name() == "" || p.Name() == "_" || isContextType(p.Type()) || (sig.Variadic() && i == sig.Params().Len()-1) {
+			continue
+		}
+
+		s.params = append(s.params, ParameterField{Name: p.Name(), Type: types.TypeString(p.Type(), qualifier)})
+	}
+
+	return s
+}
+
+// isContextType reports whether t is context.Context.
+func isContextType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// parameterGroups finds the maximal parameter groups of one package: every intersection of two signatures
+// with at least minParameterGroupSize parameters is a candidate, kept when at least
+// minParameterGroupFunctions signatures contain it and no larger kept group covers the same functions.
+func parameterGroups(pkgPath string, sigs []paramSignature) []ParameterGroup {
+	sets := make([]map[ParameterField]struct{}, len(sigs))
+	for i, sig := range sigs {
+		sets[i] = make(map[ParameterField]struct{}, len(sig.params))
+		for _, p := range sig.params {
+			sets[i][p] = struct{}{}
+		}
+	}
+
+	candidates := make(map[string][]ParameterField)
+
+	for i := range sigs {
+		for j := i + 1; j < len(sigs); j++ {
+			var shared []ParameterField
+
+			for _, p := range sigs[i].params {
+				if _, ok := sets[j][p]; ok {
+					shared = append(shared, p)
+				}
+			}
+
+			if len(shared) >= minParameterGroupSize {
+				candidates[parameterGroupKey(shared)] = shared
+			}
+		}
+	}
+
+	type group struct {
+		params  []ParameterField
+		members []int
+	}
+
+	var groups []group
+
+	for _, params := range candidates {
+		var members []int
+
+		for i := range sigs {
+			if containsParams(sets[i], params) {
+				members = append(members, i)
+			}
+		}
+
+		if len(members) >= minParameterGroupFunctions {
+			groups = append(groups, group{params: params, members: members})
+		}
+	}
+
+	// Larger groups first, so a subset with the same members is recognized as covered.
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].params) != len(groups[j].params) {
+			return len(groups[i].params) > len(groups[j].params)
+		}
+
+		return parameterGroupKey(groups[i].params) < parameterGroupKey(groups[j].params)
+	})
+
+	var (
+		result []ParameterGroup
+		kept   []group
+	)
+
+	for _, g := range groups {
+		covered := false
+
+		for _, k := range kept {
+			if len(k.members) == len(g.members) && coversParams(k.params, g.params) {
+				covered = true
+
+				break
+			}
+		}
+
+		if covered {
+			continue
+		}
+
+		kept = append(kept, g)
+
+		pg := ParameterGroup{Package: pkgPath, Params: g.params}
+		exported := false
+
+		for _, i := range g.members {
+			pg.Functions = append(pg.Functions, ParameterGroupFunction{Name: sigs[i].name, File: sigs[i].file, Line: sigs[i].line})
+			exported = exported || sigs[i].exported
+		}
+
+		pg.SuggestedName, pg.SuggestedStruct = suggestParameterStruct(g.params, exported)
+		result = append(result, pg)
+	}
+
+	return result
+}
+
+// inParameterGroup reports whether sig is one of the functions of groups.
+func inParameterGroup(groups []ParameterGroup, sig paramSignature) bool {
+	for _, g := range groups {
+		for _, f := range g.Functions {
+			if f.File == sig.file && f.Line == sig.line {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parameterGroupKey identifies a group independently of parameter order.
+func parameterGroupKey(params []ParameterField) string {
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		parts = append(parts, p.Name+" "+p.Type)
+	}
+
+	sort.Strings(parts)
+
+	return strings.Join(parts, ", ")
+}
+
+// containsParams reports whether set holds every parameter of params.
+func containsParams(set map[ParameterField]struct{}, params []ParameterField) bool {
+	for _, p := range params {
+		if _, ok := set[p]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// coversParams reports whether outer holds every parameter of inner.
+func coversParams(outer, inner []ParameterField) bool {
+	set := make(map[ParameterField]struct{}, len(outer))
+	for _, p := range outer {
+		set[p] = struct{}{}
+	}
+
+	return containsParams(set, inner)
+}
+
+// suggestParameterStruct names a struct after the first three parameters of the group and renders its
+// declaration; the struct is exported when one of the functions is.
+func suggestParameterStruct(params []ParameterField, exported bool) (string, string) {
+	var name strings.Builder
+
+	for i, p := range params {
+		if i == 3 {
+			break
+		}
+
+		name.WriteString(capitalize(p.Name))
+	}
+
+	name.WriteString("Params")
+
+	structName := name.String()
+	if !exported {
+		structName = string(unicode.ToLower(rune(structName[0]))) + structName[1:]
+	}
+
+	var src strings.Builder
+
+	fmt.Fprintf(&src, "type %s struct {\n", structName)
+
+	for _, p := range params {
+		fmt.Fprintf(&src, "\t%s %s\n", capitalize(p.Name), p.Type)
+	}
+
+	src.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return structName, src.String()
+	}
+
+	return structName, string(formatted)
+}
+
+// capitalize upper-cases the first letter of an identifier.
+func capitalize(name string) string {
+	if name == "" {
+		return name
+	}
+
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+
+	return string(r)
+}
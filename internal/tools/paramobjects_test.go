@@ -0,0 +1,111 @@
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const paramObjectsSource = `package lang
+
+import (
+	"context"
+	"time"
+)
+
+func Dial(ctx context.Context, host string, port int, timeout time.Duration) error { return nil }
+
+func Ping(ctx context.Context, host string, port int, timeout time.Duration, retries int) error {
+	return nil
+}
+
+func probe(host string, timeout time.Duration, port int, _ bool) {}
+
+func Render(title, body, footer string, width, height int, border bool) string { return "" }
+
+func twoShared(host string, port int) {}
+
+func logf(format string, host string, port int, args ...any) {}
+`
+
+func TestSuggestParameterObjects(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": paramObjectsSource})
+
+	_, out, err := tools.SuggestParameterObjects(context.Background(), &mcp.CallToolRequest{},
+		tools.SuggestParameterObjectsInput{Dir: dir, Package: "lang"})
+	if err != nil {
+		t.Fatalf("SuggestParameterObjects error: %v", err)
+	}
+
+	if len(out.Groups) != 1 {
+		t.Fatalf("expected the host/port/timeout group only, got %+v", out.Groups)
+	}
+
+	g := out.Groups[0]
+	if len(g.Params) != 3 || g.Params[0].Name != "host" || g.Params[2].Type != "time.Duration" {
+		t.Errorf("expected host string, port int, timeout time.Duration without ctx, got %+v", g.Params)
+	}
+
+	var names []string
+	for _, f := range g.Functions {
+		names = append(names, f.Name)
+	}
+
+	if strings.Join(names, ",") != "Dial,Ping,probe" {
+		t.Errorf("expected Dial, Ping and probe, got %v", names)
+	}
+
+	if g.SuggestedName != "HostPortTimeoutParams" ||
+		!strings.Contains(g.SuggestedStruct, "type HostPortTimeoutParams struct {") ||
+		!strings.Contains(g.SuggestedStruct, "Timeout time.Duration") {
+		t.Errorf("unexpected suggestion %s:\n%s", g.SuggestedName, g.SuggestedStruct)
+	}
+
+	if len(out.Functions) != 4 {
+		t.Fatalf("expected Render and the three group members, got %+v", out.Functions)
+	}
+
+	if f := out.Functions[0]; f.Name != "Render" || f.Params != 6 || !f.ExceedsMax {
+		t.Errorf("expected Render with 6 parameters flagged first, got %+v", f)
+	}
+
+	for _, f := range out.Functions[1:] {
+		if f.ExceedsMax {
+			t.Errorf("expected %s within the default maximum, got %+v", f.Name, f)
+		}
+	}
+}
+
+func TestSuggestParameterObjects_MaxParams(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": paramObjectsSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.SuggestParameterObjects(ctx, req, tools.SuggestParameterObjectsInput{Dir: dir, MaxParams: 4})
+	if err != nil {
+		t.Fatalf("SuggestParameterObjects error: %v", err)
+	}
+
+	flagged := 0
+
+	for _, f := range out.Functions {
+		if f.ExceedsMax {
+			flagged++
+		}
+	}
+
+	if flagged != 2 {
+		t.Errorf("expected Render and Ping above 4 parameters, got %+v", out.Functions)
+	}
+
+	_, _, err = tools.SuggestParameterObjects(ctx, req, tools.SuggestParameterObjectsInput{Dir: dir, MaxParams: -1})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a negative maxParams, got %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// positionFile is a loaded source file with the top-level declaration and comment line ranges used to
+// resolve positions by binary search.
+type positionFile struct {
+	pkg     *packages.Package
+	file    *ast.File
+	relPath string
+	absPath string
+
+	// built lazily on the first position resolved in the file
+	decls    []lineRange
+	comments []lineRange
+}
+
+// lineRange is the line span of a top-level declaration or a comment. Spans of one kind never overlap
+// and are sorted by start line.
+type lineRange struct {
+	start, end int
+	// column is the start column of a comment
+	column int
+	fn     *ast.FuncDecl
+	spec   *ast.TypeSpec
+}
+
+// ResolvePosition maps file:line positions, e.g. the frames of a stack trace or lines of a coverage
+// report, to their enclosing function and type declaration.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and the positions to resolve
+//
+// Returns:
+//   - MCP tool call result
+//   - one result per position, in input order; unresolvable positions carry an error message
+//   - error if no positions were given or packages cannot be loaded
+func ResolvePosition(ctx context.Context, _ *mcp.CallToolRequest, input ResolvePositionInput) (
+	*mcp.CallToolResult,
+	ResolvePositionOutput,
+	error,
+) {
+	start := logStart("ResolvePosition", logFields(
+		input.Dir,
+		newLogField("positions", strconv.Itoa(len(input.Positions))),
+	))
+	out := ResolvePositionOutput{}
+
+	defer func() { logEnd("ResolvePosition", start, out.Resolved) }()
+
+	if len(input.Positions) == 0 {
+		return nil, out, invalidInput("positions must not be empty")
+	}
+
+	mode := loadModeBasicSyntax
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		logError("ResolvePosition", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	files, err := positionFiles(ctx, pkgs, input.Dir)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	for _, pos := range input.Positions {
+		resolved := ResolvedPosition{File: pos.File, Line: pos.Line}
+
+		pf, err := matchPositionFile(files, pos.File)
+		if err != nil {
+			resolved.Error = err.Error()
+			out.Positions = append(out.Positions, resolved)
+
+			continue
+		}
+
+		if err := pf.resolve(pos.Line, &resolved); err != nil {
+			resolved.Error = err.Error()
+		} else {
+			out.Resolved++
+		}
+
+		out.Positions = append(out.Positions, resolved)
+	}
+
+	return nil, out, nil
+}
+
+// positionFiles lists the files of pkgs for matchPositionFile. Test variants contain the package's own
+// files again; every file is listed once.
+func positionFiles(ctx context.Context, pkgs []*packages.Package, dir string) ([]*positionFile, error) {
+	var files []*positionFile
+
+	seen := make(map[string]struct{})
+
+	err := walkPackageFiles(ctx, pkgs, dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if _, ok := seen[relPath]; ok || strings.HasSuffix(pkg.PkgPath, ".test") {
+			return nil
+		}
+
+		seen[relPath] = struct{}{}
+
+		absPath := relPath
+		if f := pkg.Fset.File(file.Pos()); f != nil {
+			absPath = filepath.ToSlash(f.Name())
+		}
+
+		files = append(files, &positionFile{pkg: pkg, file: file, relPath: relPath, absPath: absPath})
+
+		return nil
+	})
+
+	return files, err
+}
+
+// matchPositionFile finds the file a position refers to: by absolute path, by path relative to the
+// module directory, or by path suffix, so stack traces recorded on another machine resolve too.
+func matchPositionFile(files []*positionFile, name string) (*positionFile, error) {
+	name = path.Clean(filepath.ToSlash(name))
+
+	var matches []*positionFile
+
+	for _, pf := range files {
+		if pf.absPath == name || pf.relPath == name {
+			return pf, nil
+		}
+
+		if strings.HasSuffix(name, "/"+pf.relPath) || strings.HasSuffix(pf.relPath, "/"+name) {
+			matches = append(matches, pf)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("file %q not found in the module", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, pf := range matches {
+		names = append(names, pf.relPath)
+	}
+
+	sort.Strings(names)
+
+	return nil, fmt.Errorf("file %q is ambiguous: %s", name, strings.Join(names, ", "))
+}
+
+// resolve fills in the package, enclosing declarations and line classification of line.
+func (pf *positionFile) resolve(line int, resolved *ResolvedPosition) error {
+	tf := pf.pkg.Fset.File(pf.file.Pos())
+	if tf == nil {
+		return fmt.Errorf("no position information for %s", pf.relPath)
+	}
+
+	if line < 1 || line > tf.LineCount() {
+		return fmt.Errorf("line %d is out of range: %s has %d lines", line, pf.relPath, tf.LineCount())
+	}
+
+	if pf.decls == nil {
+		pf.index()
+	}
+
+	resolved.ResolvedFile = pf.relPath
+	resolved.Package = pf.pkg.PkgPath
+
+	if r, ok := findLineRange(pf.decls, line); ok {
+		switch {
+		case r.fn != nil:
+			resolved.Function = &EnclosingFunction{
+				Name:      qualifiedFuncName(r.fn),
+				Receiver:  receiverName(r.fn),
+				StartLine: r.start,
+				EndLine:   r.end,
+			}
+		case r.spec != nil:
+			resolved.Type = &EnclosingType{Name: r.spec.Name.Name, StartLine: r.start, EndLine: r.end}
+		}
+	}
+
+	text := ""
+	if lines := getFileLines(pf.pkg.Fset, pf.file); line <= len(lines) {
+		text = lines[line-1]
+	}
+
+	resolved.Blank = strings.TrimSpace(text) == ""
+
+	if r, ok := findLineRange(pf.comments, line); ok {
+		// A comment starting on the line covers it only when no code precedes the comment.
+		resolved.InComment = r.start < line || r.column-1 > len(text) || strings.TrimSpace(text[:r.column-1]) == ""
+	}
+
+	return nil
+}
+
+// index records the line ranges of the file's functions, type declarations and comments.
+func (pf *positionFile) index() {
+	fset := pf.pkg.Fset
+	span := func(node ast.Node) lineRange {
+		return lineRange{start: fset.Position(node.Pos()).Line, end: fset.Position(node.End()).Line}
+	}
+
+	pf.decls = []lineRange{}
+
+	for _, decl := range pf.file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			r := span(d)
+			r.fn = d
+			pf.decls = append(pf.decls, r)
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE || len(d.Specs) == 0 {
+				continue
+			}
+
+			if !d.Lparen.IsValid() {
+				r := span(d)
+				r.spec, _ = d.Specs[0].(*ast.TypeSpec)
+				pf.decls = append(pf.decls, r)
+
+				continue
+			}
+
+			for _, spec := range d.Specs {
+				r := span(spec)
+				r.spec, _ = spec.(*ast.TypeSpec)
+				pf.decls = append(pf.decls, r)
+			}
+		}
+	}
+
+	for _, group := range pf.file.Comments {
+		for _, c := range group.List {
+			r := span(c)
+			r.column = fset.Position(c.Pos()).Column
+			pf.comments = append(pf.comments, r)
+		}
+	}
+}
+
+// findLineRange returns the range of sorted, non-overlapping ranges that contains line.
+func findLineRange(ranges []lineRange, line int) (lineRange, bool) {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].end >= line })
+	if i < len(ranges) && ranges[i].start <= line {
+		return ranges[i], true
+	}
+
+	return lineRange{}, false
+}
@@ -0,0 +1,82 @@
+package tools_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestResolvePosition_StackTraceFrames(t *testing.T) {
+	t.Parallel()
+
+	dir := testDir()
+
+	_, out, err := tools.ResolvePosition(context.Background(), &mcp.CallToolRequest{}, tools.ResolvePositionInput{
+		Dir: dir,
+		Positions: []tools.SourcePosition{
+			{File: "/build/ci/sample/foo.go", Line: 13},
+			{File: filepath.Join(dir, "point.go"), Line: 10},
+			{File: "point.go", Line: 5},
+			{File: "point.go", Line: 3},
+			{File: "point.go", Line: 12},
+			{File: "foo_test.go", Line: 8},
+			{File: "point.go", Line: 999},
+			{File: "missing.go", Line: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ResolvePosition error: %v", err)
+	}
+
+	if len(out.Positions) != 8 || out.Resolved != 6 {
+		t.Fatalf("expected 8 results with 6 resolved, got %d/%d: %+v", out.Resolved, len(out.Positions), out.Positions)
+	}
+
+	method := out.Positions[0]
+	if method.ResolvedFile != "foo.go" || method.Package != "sample" || method.Function == nil ||
+		method.Function.Name != "Foo.DoSomething" || method.Function.Receiver != "Foo" || method.Function.StartLine != 12 {
+		t.Errorf("unexpected method frame: %+v (function %+v)", method, method.Function)
+	}
+
+	if fn := out.Positions[1].Function; fn == nil || fn.Name != "NewPoint" || fn.StartLine != 9 || fn.EndLine != 11 {
+		t.Errorf("expected NewPoint lines 9-11 for an absolute path, got %+v", fn)
+	}
+
+	if typ := out.Positions[2].Type; typ == nil || typ.Name != "Point" || out.Positions[2].Function != nil {
+		t.Errorf("expected the Point type declaration, got %+v", out.Positions[2])
+	}
+
+	if doc := out.Positions[3]; !doc.InComment || doc.Function != nil || doc.Type != nil {
+		t.Errorf("expected a doc comment line outside any declaration, got %+v", doc)
+	}
+
+	if blank := out.Positions[4]; !blank.Blank || blank.Function != nil {
+		t.Errorf("expected a blank line outside any declaration, got %+v", blank)
+	}
+
+	if test := out.Positions[5].Function; test == nil || test.Name != "TestFooDoSomething" {
+		t.Errorf("expected a frame in a test file to resolve, got %+v", out.Positions[5])
+	}
+
+	if e := out.Positions[6].Error; !strings.Contains(e, "out of range") {
+		t.Errorf("expected an out-of-range error, got %q", e)
+	}
+
+	if e := out.Positions[7].Error; !strings.Contains(e, "not found") {
+		t.Errorf("expected an unknown-file error, got %q", e)
+	}
+}
+
+func TestResolvePosition_RequiresPositions(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.ResolvePosition(context.Background(), &mcp.CallToolRequest{}, tools.ResolvePositionInput{Dir: testDir()})
+	if te := tools.AsToolError(err); te.Code != tools.CodeInvalidInput {
+		t.Fatalf("expected INVALID_INPUT, got %v", err)
+	}
+}
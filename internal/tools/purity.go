@@ -0,0 +1,472 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// purityClass orders side-effect classes from the weakest to the strongest.
+type purityClass int
+
+const (
+	purityPure purityClass = iota
+	purityReadsGlobal
+	purityWritesGlobal
+	purityPerformsIO
+)
+
+// maxPurityChainHops limits how many call hops are reported in a propagation chain.
+const maxPurityChainHops = 3
+
+func (c purityClass) String() string {
+	switch c {
+	case purityReadsGlobal:
+		return "reads-global"
+	case purityWritesGlobal:
+		return "writes-global"
+	case purityPerformsIO:
+		return "performs-IO"
+	default:
+		return "pure"
+	}
+}
+
+// ioPackages lists packages whose functions are treated as performing I/O.
+var ioPackages = map[string]struct{}{
+	"bufio":        {},
+	"database/sql": {},
+	"io":           {},
+	"io/fs":        {},
+	"io/ioutil":    {},
+	"log":          {},
+	"log/slog":     {},
+	"net":          {},
+	"net/http":     {},
+	"os":           {},
+	"os/exec":      {},
+	"syscall":      {},
+}
+
+// purePackages lists standard library packages whose functions have no observable side effects.
+var purePackages = map[string]struct{}{
+	"bytes":        {},
+	"cmp":          {},
+	"errors":       {},
+	"maps":         {},
+	"math":         {},
+	"math/bits":    {},
+	"path":         {},
+	"slices":       {},
+	"sort":         {},
+	"strconv":      {},
+	"strings":      {},
+	"unicode":      {},
+	"unicode/utf8": {},
+}
+
+// purityNode holds per-function facts used while propagating impurity through the call graph.
+type purityNode struct {
+	name    string
+	file    string
+	line    int
+	inScope bool
+	class   purityClass
+	reason  string
+	via     string
+	callees []string
+}
+
+// AnalyzePurity classifies functions by their side effects: pure, reads-global, writes-global or performs-IO.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional package filter
+//
+// Returns:
+//   - MCP tool call result
+//   - purity classification grouped by file
+//   - error if an error occurred while loading packages
+func AnalyzePurity(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzePurityInput) (
+	*mcp.CallToolResult,
+	AnalyzePurityOutput,
+	error,
+) {
+	start := logStart("AnalyzePurity", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := AnalyzePurityOutput{ByClass: make(map[string]int)}
+	resultCount := 0
+
+	defer func() { logEnd("AnalyzePurity", start, resultCount) }()
+
+	owners, err := newOwnership(input.Dir, input.OwnersFile, input.GroupByOwner)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzePurity")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	inScope := make(map[*packages.Package]bool, len(filteredPkgs))
+	for _, pkg := range filteredPkgs {
+		inScope[pkg] = true
+	}
+
+	nodes := make(map[string]*purityNode)
+
+	// The call graph spans the whole module so impurity can flow in from unfiltered packages.
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			node := &purityNode{
+				name:    qualifiedFuncName(fd),
+				file:    relPath,
+				line:    pkg.Fset.Position(fd.Pos()).Line,
+				inScope: inScope[pkg],
+			}
+			collectPurityFacts(pkg.TypesInfo, fd.Body, node)
+			nodes[funcKey(fn)] = node
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	propagatePurity(nodes)
+
+	functions := make(map[string][]FunctionPurityInfo)
+
+	for _, node := range nodes {
+		if !node.inScope {
+			continue
+		}
+
+		info := FunctionPurityInfo{
+			Name:  node.name,
+			Line:  node.line,
+			Class: node.class.String(),
+		}
+		if node.class != purityPure {
+			info.Reason, info.Chain = purityChain(nodes, node)
+		}
+
+		functions[node.file] = append(functions[node.file], info)
+		out.ByClass[info.Class]++
+		resultCount++
+	}
+
+	out.Functions = groupFunctionPurityByFile(functions)
+
+	for i := range out.Functions {
+		out.Functions[i].Owner = owners.owner(out.Functions[i].File)
+		owners.count(out.Functions[i].Owner, len(out.Functions[i].Functions))
+	}
+
+	out.ByOwner = owners.counts()
+
+	return nil, out, nil
+}
+
+// qualifiedFuncName returns "Type.Method" for methods and the plain name for functions.
+func qualifiedFuncName(fd *ast.FuncDecl) string {
+	if recv := receiverName(fd); recv != "" {
+		return recv + "." + fd.Name.Name
+	}
+
+	return fd.Name.Name
+}
+
+// funcKey returns a stable key for a function object shared by its declaration and its call sites.
+func funcKey(fn *types.Func) string {
+	return fn.Origin().FullName()
+}
+
+// collectPurityFacts records leaf impurity facts and static in-module callees found in a function body.
+func collectPurityFacts(info *types.Info, body *ast.BlockStmt, node *purityNode) {
+	record := func(class purityClass, reason string) {
+		if class > node.class {
+			node.class = class
+			node.reason = reason
+		}
+	}
+
+	written := make(map[*ast.Ident]struct{})
+	seenCallees := make(map[string]struct{})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			if stmt.Tok == token.DEFINE {
+				return true
+			}
+
+			for _, lhs := range stmt.Lhs {
+				if root := rootIdent(lhs); root != nil {
+					written[root] = struct{}{}
+
+					if isPackageLevelVar(info.Uses[root]) {
+						record(purityWritesGlobal, "assigns package-level var "+root.Name)
+					}
+				}
+			}
+		case *ast.IncDecStmt:
+			if root := rootIdent(stmt.X); root != nil {
+				written[root] = struct{}{}
+
+				if isPackageLevelVar(info.Uses[root]) {
+					record(purityWritesGlobal, "modifies package-level var "+root.Name)
+				}
+			}
+		case *ast.SendStmt:
+			record(purityPerformsIO, "sends on channel")
+		case *ast.Ident:
+			if _, ok := written[stmt]; ok {
+				return true
+			}
+
+			if isPackageLevelVar(info.Uses[stmt]) {
+				record(purityReadsGlobal, "reads package-level var "+stmt.Name)
+			}
+		case *ast.CallExpr:
+			if tv, ok := info.Types[stmt.Fun]; ok && tv.IsType() {
+				return true
+			}
+
+			classifyCall(info, stmt, node, record, seenCallees)
+		}
+
+		return true
+	})
+}
+
+// classifyCall records the effect of a single call expression.
+func classifyCall(
+	info *types.Info,
+	call *ast.CallExpr,
+	node *purityNode,
+	record func(purityClass, string),
+	seen map[string]struct{},
+) {
+	fun := ast.Unparen(call.Fun)
+	if _, ok := fun.(*ast.FuncLit); ok {
+		return // the literal body is inspected as part of the enclosing function
+	}
+
+	var obj types.Object
+
+	switch f := calleeExpr(fun).(type) {
+	case *ast.Ident:
+		obj = info.Uses[f]
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[f]; ok {
+			if sel.Kind() != types.MethodVal || types.IsInterface(sel.Recv()) {
+				record(purityPerformsIO, "dynamic call "+exprString(f))
+
+				return
+			}
+
+			obj = sel.Obj()
+		} else {
+			obj = info.Uses[f.Sel]
+		}
+	}
+
+	switch callee := obj.(type) {
+	case *types.Builtin:
+		switch callee.Name() {
+		case "print", "println":
+			record(purityPerformsIO, "calls builtin "+callee.Name())
+		case "delete", "clear":
+			if len(call.Args) > 0 {
+				if root := rootIdent(call.Args[0]); root != nil && isPackageLevelVar(info.Uses[root]) {
+					record(purityWritesGlobal, "modifies package-level var "+root.Name)
+				}
+			}
+		}
+	case *types.Func:
+		callee = callee.Origin()
+		pkgPath := ""
+
+		if callee.Pkg() != nil {
+			pkgPath = callee.Pkg().Path()
+		}
+
+		if _, ok := ioPackages[pkgPath]; ok {
+			record(purityPerformsIO, "calls "+pkgPath+"."+callee.Name())
+
+			return
+		}
+
+		if pkgPath == "fmt" {
+			if !strings.HasPrefix(callee.Name(), "Sprint") && callee.Name() != "Errorf" {
+				record(purityPerformsIO, "calls fmt."+callee.Name())
+			}
+
+			return
+		}
+
+		if _, ok := purePackages[pkgPath]; ok {
+			return
+		}
+
+		key := funcKey(callee)
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			node.callees = append(node.callees, key)
+		}
+	default:
+		record(purityPerformsIO, "dynamic call "+exprString(fun))
+	}
+}
+
+// calleeExpr strips generic instantiation from a call target.
+func calleeExpr(fun ast.Expr) ast.Expr {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		return ast.Unparen(f.X)
+	case *ast.IndexListExpr:
+		return ast.Unparen(f.X)
+	}
+
+	return fun
+}
+
+// rootIdent returns the variable ultimately written by an assignment target (x, x.f, x[i], *x).
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// isPackageLevelVar reports whether obj is a variable declared at package scope.
+func isPackageLevelVar(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	if !ok || v.IsField() || v.Pkg() == nil {
+		return false
+	}
+
+	return v.Parent() == v.Pkg().Scope()
+}
+
+// propagatePurity raises each function to the strongest class among its in-module callees.
+// Calls to functions outside the loaded module default to performs-IO.
+func propagatePurity(nodes map[string]*purityNode) {
+	for _, node := range nodes {
+		for _, key := range node.callees {
+			if _, ok := nodes[key]; !ok && node.class < purityPerformsIO {
+				node.class = purityPerformsIO
+				node.reason = "unknown external call " + key
+				node.via = ""
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, node := range nodes {
+			for _, key := range node.callees {
+				callee, ok := nodes[key]
+				if !ok || callee.class <= node.class {
+					continue
+				}
+
+				node.class = callee.class
+				node.reason = ""
+				node.via = key
+				changed = true
+			}
+		}
+	}
+}
+
+// purityChain returns the leaf reason and the call chain (up to maxPurityChainHops hops) that made a function impure.
+func purityChain(nodes map[string]*purityNode, node *purityNode) (string, []string) {
+	chain := []string{node.name}
+	visited := map[*purityNode]struct{}{node: {}}
+
+	current := node
+	for current.via != "" {
+		next, ok := nodes[current.via]
+		if !ok {
+			break
+		}
+
+		if _, loop := visited[next]; loop {
+			break
+		}
+
+		visited[next] = struct{}{}
+
+		if len(chain) <= maxPurityChainHops {
+			chain = append(chain, next.name)
+		}
+
+		current = next
+	}
+
+	if len(chain) == 1 {
+		return current.reason, nil
+	}
+
+	return current.reason, chain
+}
+
+// groupFunctionPurityByFile sorts files and functions for stable output.
+func groupFunctionPurityByFile(data map[string][]FunctionPurityInfo) []FunctionPurityGroupByFile {
+	if len(data) == 0 {
+		return nil
+	}
+
+	result := make([]FunctionPurityGroupByFile, 0, len(data))
+
+	for file, fns := range data {
+		sort.Slice(fns, func(i, j int) bool {
+			return fns[i].Line < fns[j].Line
+		})
+
+		result = append(result, FunctionPurityGroupByFile{File: file, Functions: fns})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].File < result[j].File
+	})
+
+	return result
+}
@@ -0,0 +1,74 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestAnalyzePurity(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzePurityInput{Dir: testDir()}
+
+	_, out, err := tools.AnalyzePurity(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("AnalyzePurity error: %v", err)
+	}
+
+	funcs := map[string]tools.FunctionPurityInfo{}
+
+	for _, group := range out.Functions {
+		for _, fn := range group.Functions {
+			funcs[fn.Name] = fn
+		}
+	}
+
+	expected := map[string]string{
+		"PureAdd":     "pure",
+		"ReadCounter": "reads-global",
+		"bumpCounter": "writes-global",
+		"Register":    "writes-global",
+		"writeReport": "performs-IO",
+		"SaveReport":  "performs-IO",
+	}
+
+	for name, class := range expected {
+		fn, ok := funcs[name]
+		if !ok {
+			t.Errorf("expected function %s in purity report", name)
+
+			continue
+		}
+
+		if fn.Class != class {
+			t.Errorf("expected %s to be %s, got %s (%s)", name, class, fn.Class, fn.Reason)
+		}
+	}
+
+	save := funcs["SaveReport"]
+	if len(save.Chain) < 2 || save.Chain[0] != "SaveReport" || save.Chain[1] != "writeReport" {
+		t.Errorf("expected chain SaveReport -> writeReport, got %v", save.Chain)
+	}
+
+	if save.Reason == "" {
+		t.Errorf("expected a leaf reason for SaveReport")
+	}
+
+	if out.ByClass["pure"] == 0 {
+		t.Errorf("expected at least one pure function, got %v", out.ByClass)
+	}
+}
+
+func TestAnalyzePurity_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.AnalyzePurityInput{Dir: "/nonexistent/directory"}
+
+	_, _, err := tools.AnalyzePurity(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatalf("expected error for non-existent directory, got nil")
+	}
+}
@@ -10,23 +10,27 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
 )
 
-// ReadFunc returns the source code and metadata of a specific function or method.
+// ReadFunc returns the source code and metadata of a specific function or method, or with names of
+// several of them, resolved in one pass over the package syntax trees.
 //
 // Parameters:
 //   - ctx: execution context
 //   - req: MCP tool request
-//   - input: input data specifying the directory and function name (possibly with receiver)
+//   - input: input data specifying the directory and function name (possibly with receiver) or names
 //
 // Returns:
 //   - MCP tool call result
-//   - function source code and its metadata
-//   - error if the function is not found or an error occurred during analysis
+//   - function source code and its metadata; with names, the found functions and the names not found
+//   - error if the function is not found (single name) or an error occurred during analysis
 func ReadFunc(ctx context.Context, _ *mcp.CallToolRequest, input ReadFuncInput) (
 	*mcp.CallToolResult,
 	ReadFuncOutput,
@@ -35,136 +39,225 @@ func ReadFunc(ctx context.Context, _ *mcp.CallToolRequest, input ReadFuncInput)
 	start := logStart("ReadFunc", logFields(
 		input.Dir,
 		newLogField("name", input.Name),
+		newLogField("names", strconv.Itoa(len(input.Names))),
 	))
 	out := ReadFuncOutput{}
-
-	defer func() { logEnd("ReadFunc", start, 1) }()
+	found := 0
+
+	defer func() { logEnd("ReadFunc", start, found) }()
+
+	switch {
+	case input.Name != "" && len(input.Names) > 0:
+		return fail(out, invalidInput("pass either name or names, not both"))
+	case input.Name == "" && len(input.Names) == 0:
+		return fail(out, invalidInput("name or names is required"))
+	case len(input.Names) > 0 && input.IncludeExamples:
+		return fail(out, invalidInput("includeExamples is only supported with a single name"))
+	case input.MaxTotalLines < 0:
+		return fail(out, invalidInput("maxTotalLines must not be negative, got %d", input.MaxTotalLines))
+	}
 
 	mode := loadModeSyntaxTypesNamed
 
-	pkgs, err := loadPackagesWithCache(ctx, input.Dir, mode)
+	pkgs, degraded, dependency, err := loadPackagesOrDependency(ctx, &input.Dir, mode, input.DependencyPackage)
 	if err != nil {
 		logError("ReadFunc", err, "failed to load packages")
 
 		return fail(out, err)
 	}
 
-	target := input.Name
+	degraded.apply(&out.Degraded, &out.LoadError)
+	dependency.apply(&out.External, &out.Dependency)
+
+	names := input.Names
+	if input.Name != "" {
+		names = []string{input.Name}
+	}
+
+	decls := findFuncDecls(pkgs, names)
+	budget := lineBudget{max: input.MaxTotalLines}
+
+	for _, name := range names {
+		d, ok := decls[name]
+		if !ok {
+			if !slices.Contains(out.NotFound, name) {
+				out.NotFound = append(out.NotFound, name)
+			}
+
+			continue
+		}
+
+		if d.done {
+			continue
+		}
+
+		d.done = true
 
-	var receiver, funcName string
+		fn, err := functionSource(input.Dir, d, input.WithFingerprints)
+		if err != nil {
+			logError("ReadFunc", err, "failed to format function")
 
-	// Поддержка формата "Type.Method"
-	if strings.Contains(target, ".") {
-		parts := strings.SplitN(target, ".", 2)
-		receiver, funcName = parts[0], parts[1]
-	} else {
-		funcName = target
+			return fail(out, err)
+		}
+
+		fn.SourceCode, fn.Truncated = budget.take(fn.SourceCode)
+		out.Functions = append(out.Functions, fn)
+		found++
 	}
 
-	for _, pkg := range pkgs {
-		for _, astFile := range pkg.Syntax {
-			fset := pkg.Fset
+	if input.Name == "" {
+		return nil, out, nil
+	}
 
-			ast.Inspect(astFile, func(n ast.Node) bool {
-				fd, ok := n.(*ast.FuncDecl)
-				if !ok {
-					return true
-				}
+	if found == 0 {
+		return nil, ReadFuncOutput{Degraded: out.Degraded, LoadError: out.LoadError, External: out.External, Dependency: out.Dependency},
+			missingSymbol(pkgs, input.Name, "func", "function %q not found", input.Name)
+	}
 
-				if fd.Name.Name != funcName {
-					return true
-				}
+	out.Function, out.Functions, out.NotFound = out.Functions[0], nil, nil
 
-				recv := receiverName(fd)
+	if input.IncludeExamples {
+		d := decls[input.Name]
+		target := exampleTarget{typ: out.Function.Receiver, fn: out.Function.Name}
+		out.Examples, out.HasMoreExamples = collectExamples(input.Dir, d.pkg, d.pkg.Fset.File(d.file.Pos()).Name(), target, input.MaxExamples)
+	}
 
-				// Если указан получатель, фильтруем
-				if receiver != "" && recv != receiver {
-					return true
-				}
+	return nil, out, nil
+}
 
-				// Вычисляем позицию найденной функции
-				startPos := fset.Position(fd.Pos())
-				endPos := fset.Position(fd.End())
+// funcDeclMatch is the declaration found for a requested function name.
+type funcDeclMatch struct {
+	pkg  *packages.Package
+	file *ast.File
+	fd   *ast.FuncDecl
+	done bool
+}
 
-				// Определяем абсолютный путь к файлу, в котором находится функция
-				// Сначала пытаемся получить имя файла из FileSet
-				abs := ""
+// findFuncDecls resolves names (Func or Type.Method) to the first matching top-level declaration in
+// package order, in one pass over the syntax trees. Unresolved names are missing from the result.
+func findFuncDecls(pkgs []*packages.Package, names []string) map[string]*funcDeclMatch {
+	type request struct{ name, receiver string }
 
-				funcFile := fset.File(fd.Pos())
-				if funcFile != nil {
-					abs = funcFile.Name()
-				}
+	byFunc := make(map[string][]request, len(names))
+	distinct := make(map[string]struct{}, len(names))
 
-				// Если имя файла не получено из FileSet, используем резервный способ
-				if abs == "" {
-					// Сопоставляем позицию функции с файлами в пакете
-					funcPos := fd.Pos()
-					for _, compiledGoFile := range pkg.CompiledGoFiles {
-						// Пытаемся открыть и разобрать каждый файл, чтобы проверить,
-						// содержится ли в нем функция с заданной позицией
-						if fset.File(funcPos).Name() == compiledGoFile {
-							abs = compiledGoFile
-
-							break
-						}
-					}
-				}
+	for _, name := range names {
+		if _, ok := distinct[name]; ok {
+			continue
+		}
 
-				// Если после всех попыток abs все еще пустой, используем первый файл из пакета как запасной вариант
-				if abs == "" && len(pkg.CompiledGoFiles) > 0 {
-					abs = pkg.CompiledGoFiles[0]
-				}
+		distinct[name] = struct{}{}
 
-				rel := relativePath(input.Dir, abs)
-				if rel == "" && abs != "" {
-					rel = filepath.ToSlash(abs)
+		receiver, funcName, ok := strings.Cut(name, ".")
+		if !ok {
+			receiver, funcName = "", name
+		}
+
+		byFunc[funcName] = append(byFunc[funcName], request{name: name, receiver: receiver})
+	}
+
+	result := make(map[string]*funcDeclMatch, len(names))
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
 				}
 
-				// Ensure rel is not empty - this is a fallback to prevent empty File field
-				if rel == "" {
-					// В крайнем случае, если все методы дали пустой результат, используем первый файл из пакета
-					if len(pkg.CompiledGoFiles) > 0 {
-						rel = pkg.CompiledGoFiles[0]
-					} else {
-						rel = abs
+				for _, req := range byFunc[fd.Name.Name] {
+					if _, seen := result[req.name]; seen || (req.receiver != "" && receiverName(fd) != req.receiver) {
+						continue
 					}
+
+					result[req.name] = &funcDeclMatch{pkg: pkg, file: file, fd: fd}
 				}
+			}
+		}
 
-				var buf bytes.Buffer
+		if len(result) == len(distinct) {
+			break
+		}
+	}
 
-				err = format.Node(&buf, fset, fd)
-				if err != nil {
-					logError("ReadFunc", err, "failed to format function")
+	return result
+}
 
-					return false
-				}
+// functionSource renders the declaration d with its metadata; file paths are relative to dir.
+func functionSource(dir string, d *funcDeclMatch, withFingerprints bool) (FunctionSource, error) {
+	fset, fd := d.pkg.Fset, d.fd
 
-				// Используем имя пакета из текущего файла как резервный вариант, если pkg.PkgPath пустой
-				packageName := pkg.PkgPath
-				if packageName == "" {
-					packageName = astFile.Name.Name
-				}
+	abs := ""
+	if f := fset.File(fd.Pos()); f != nil {
+		abs = f.Name()
+	}
 
-				out.Function = FunctionSource{
-					Name:       fd.Name.Name,
-					Receiver:   recv,
-					Package:    packageName,
-					File:       rel,
-					StartLine:  startPos.Line,
-					EndLine:    endPos.Line,
-					SourceCode: buf.String(),
-				}
+	if abs == "" && len(d.pkg.CompiledGoFiles) > 0 {
+		abs = d.pkg.CompiledGoFiles[0]
+	}
 
-				return false // нашли — прерываем обход
-			})
+	rel := relativePath(dir, abs)
+	if rel == "" {
+		rel = filepath.ToSlash(abs)
+	}
 
-			if out.Function.Name != "" {
-				return nil, out, nil
-			}
-		}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, fd); err != nil {
+		return FunctionSource{}, err
+	}
+
+	// Fall back to the package name of the file when the package path is unknown.
+	packageName := d.pkg.PkgPath
+	if packageName == "" {
+		packageName = d.file.Name.Name
 	}
 
-	return nil, out, fmt.Errorf("function %q not found", input.Name)
+	fn := FunctionSource{
+		Name:            fd.Name.Name,
+		SymbolID:        syntaxSymbolID(packageName, receiverName(fd), fd.Name.Name, "func"),
+		Receiver:        receiverName(fd),
+		TypeParams:      receiverTypeParams(fd),
+		Package:         packageName,
+		File:            rel,
+		StartLine:       fset.Position(fd.Pos()).Line,
+		EndLine:         fset.Position(fd.End()).Line,
+		SourceCode:      buf.String(),
+		BuildConstraint: fileBuildConstraint(abs, d.file),
+		ContentHash:     fileContentHash(abs),
+	}
+	if withFingerprints {
+		fn.Fingerprint = fingerprintNode(fset, fd)
+	}
+
+	return fn, nil
+}
+
+// lineBudget hands out the maxTotalLines budget of a ReadFunc call; a zero max is unlimited.
+type lineBudget struct {
+	max, used int
+}
+
+// take returns src cut to the lines left in the budget and whether it was cut. The doc comment and the
+// first line of the signature are always kept, so a function past the budget stays identifiable.
+func (b *lineBudget) take(src string) (string, bool) {
+	if b.max == 0 {
+		return src, false
+	}
+
+	lines := strings.Split(src, "\n")
+
+	signature := slices.IndexFunc(lines, func(l string) bool { return strings.HasPrefix(l, "func ") })
+	left := max(b.max-b.used, signature+1, 1)
+	if len(lines) <= left {
+		b.used += len(lines)
+
+		return src, false
+	}
+
+	b.used += left
+
+	return strings.Join(lines[:left], "\n"), true
 }
 
 // ReadGoFile reads and analyzes a Go source file.
@@ -199,6 +292,8 @@ func ReadGoFile(ctx context.Context, _ *mcp.CallToolRequest, input ReadGoFileInp
 		return fail(out, fmt.Errorf("failed to read file %q: %w", input.File, err))
 	}
 
+	out.ContentHash = hashContent(content)
+
 	if input.Options.WithSource {
 		out.Source = string(content)
 	}
@@ -247,132 +342,65 @@ func ReadStruct(ctx context.Context, _ *mcp.CallToolRequest, input ReadStructInp
 
 	mode := loadModeSyntaxTypesNamedFiles
 
-	pkgs, _, err := loadFilteredPackages(ctx, input.Dir, mode, "", "ReadStruct")
+	pkgs, degraded, dependency, err := loadPackagesOrDependency(ctx, &input.Dir, mode, input.DependencyPackage)
 	if err != nil {
+		logError("ReadStruct", err, "failed to load packages")
+
 		return fail(out, err)
 	}
 
-	target := input.Name
+	degraded.apply(&out.Degraded, &out.LoadError)
+	dependency.apply(&out.External, &out.Dependency)
 
-	var pkgName, structName string
+	match := findTypeSpec(pkgs, input.Dir, input.Name, func(ts *ast.TypeSpec) bool {
+		_, ok := ts.Type.(*ast.StructType)
 
-	// Поддержка формата models.User
-	if strings.Contains(target, ".") {
-		parts := strings.SplitN(target, ".", 2)
-		pkgName, structName = parts[0], parts[1]
-	} else {
-		structName = target
+		return ok
+	})
+	if match == nil {
+		return nil, out, missingSymbol(pkgs, input.Name, "type", "struct %q not found", input.Name)
 	}
 
-	for _, pkg := range pkgs {
-		if pkgName != "" && pkg.Name != pkgName {
-			continue
-		}
-
-		for i, file := range pkg.Syntax {
-			fset := pkg.Fset
-			relPath := resolveFilePath(pkg, input.Dir, i, file)
+	ts, fset := match.spec, match.pkg.Fset
 
-			ast.Inspect(file, func(n ast.Node) bool {
-				ts, ok := n.(*ast.TypeSpec)
-				if !ok {
-					return true
-				}
+	var buf bytes.Buffer
 
-				if ts.Name.Name != structName {
-					return true
-				}
+	_ = format.Node(&buf, fset, ts)
 
-				st, ok := ts.Type.(*ast.StructType)
-				if !ok {
-					return true
-				}
-
-				var buf bytes.Buffer
-
-				_ = format.Node(&buf, fset, ts)
-
-				info := StructInfo{
-					Name:     ts.Name.Name,
-					Package:  pkg.PkgPath,
-					File:     relPath,
-					Line:     fset.Position(ts.Pos()).Line,
-					Exported: ts.Name.IsExported(),
-					Source:   buf.String(),
-					Fields:   []StructField{},
-					Doc:      "",
-					Methods:  []string{},
-				}
-
-				// Doc-комментарий к структуре
-				if ts.Doc != nil {
-					info.Doc = strings.TrimSpace(ts.Doc.Text())
-				}
-
-				// Поля структуры
-				for _, field := range st.Fields.List {
-					fieldType := exprString(field.Type)
-
-					tag := ""
-					if field.Tag != nil {
-						tag = strings.Trim(field.Tag.Value, "`")
-					}
-
-					doc := ""
-					if field.Doc != nil {
-						doc = strings.TrimSpace(field.Doc.Text())
-					}
+	info := StructInfo{
+		Name:       ts.Name.Name,
+		SymbolID:   declSymbolID(match.pkg, ts.Name, "", "type"),
+		Package:    match.pkg.PkgPath,
+		File:       match.relPath,
+		Line:       fset.Position(ts.Pos()).Line,
+		Exported:   ts.Name.IsExported(),
+		TypeParams: typeSpecParams(ts),
+		Doc:        match.doc(),
+		Source:     buf.String(),
+		Fields:     structFields(ts.Type.(*ast.StructType)),
+		Methods:    []string{},
+	}
 
-					for _, name := range field.Names {
-						info.Fields = append(info.Fields, StructField{
-							Name: name.Name,
-							Type: fieldType,
-							Tag:  tag,
-							Doc:  doc,
-						})
-					}
+	info.ContentHash = fileContentHash(fset.File(ts.Pos()).Name())
 
-					// анонимные (embedded) поля
-					if len(field.Names) == 0 {
-						info.Fields = append(info.Fields, StructField{
-							Name: fieldType,
-							Type: fieldType,
-							Tag:  tag,
-							Doc:  doc,
-						})
-					}
-				}
-
-				// Методы
-				if input.IncludeMethods {
-					for _, f := range pkg.Syntax {
-						ast.Inspect(f, func(n ast.Node) bool {
-							fd, ok := n.(*ast.FuncDecl)
-							if !ok || fd.Recv == nil {
-								return true
-							}
-
-							if receiverName(fd) == structName {
-								info.Methods = append(info.Methods, fd.Name.Name)
-							}
-
-							return true
-						})
-					}
+	if input.WithFingerprints {
+		info.Fingerprint = fingerprintNode(fset, ts)
+	}
 
-					sort.Strings(info.Methods)
-				}
+	if input.IncludeMethods {
+		for _, fd := range typeMethodDecls(match.pkg, ts.Name.Name) {
+			info.Methods = append(info.Methods, fd.Name.Name)
+		}
 
-				out.Struct = info
+		sort.Strings(info.Methods)
+	}
 
-				return false // нашли нужную структуру
-			})
+	out.Struct = info
 
-			if out.Struct.Name != "" {
-				return nil, out, nil
-			}
-		}
+	if input.IncludeExamples {
+		target := exampleTarget{typ: ts.Name.Name}
+		out.Examples, out.HasMoreExamples = collectExamples(input.Dir, match.pkg, fset.File(ts.Pos()).Name(), target, input.MaxExamples)
 	}
 
-	return nil, out, fmt.Errorf("struct %q not found", input.Name)
+	return nil, out, nil
 }
@@ -113,6 +113,74 @@ func TestReadFunc_Method(t *testing.T) {
 	}
 }
 
+func TestReadFunc_Names(t *testing.T) {
+	t.Parallel()
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+	in := tools.ReadFuncInput{Dir: testDir(), Names: []string{"Foo.DoSomething", "Missing", "NewPoint", "Foo.DoSomething"}}
+
+	_, out, err := tools.ReadFunc(ctx, req, in)
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if len(out.Functions) != 2 || out.Functions[0].Receiver != "Foo" || out.Functions[1].Name != "NewPoint" {
+		t.Fatalf("expected Foo.DoSomething then NewPoint, got %+v", out.Functions)
+	}
+
+	if len(out.NotFound) != 1 || out.NotFound[0] != "Missing" || out.Function.Name != "" {
+		t.Errorf("expected only Missing in notFound and no single function, got %v, %+v", out.NotFound, out.Function)
+	}
+
+	in.MaxTotalLines = 4
+
+	_, out, err = tools.ReadFunc(ctx, req, in)
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if first := out.Functions[0]; first.Truncated || !strings.Contains(first.SourceCode, "strings.ToUpper") {
+		t.Errorf("expected DoSomething within the budget, got %+v", first)
+	}
+
+	if second := out.Functions[1]; !second.Truncated || !strings.HasSuffix(second.SourceCode, "\nfunc NewPoint(x, y int) *Point {") {
+		t.Errorf("expected NewPoint cut to its signature, got %+v", second)
+	}
+
+	for _, bad := range []tools.ReadFuncInput{
+		{Dir: testDir()},
+		{Dir: testDir(), Name: "NewPoint", Names: []string{"origin"}},
+		{Dir: testDir(), Names: []string{"NewPoint"}, IncludeExamples: true},
+	} {
+		_, _, err := tools.ReadFunc(ctx, req, bad)
+		if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+			t.Errorf("%+v: expected INVALID_INPUT, got %v", bad, err)
+		}
+	}
+}
+
+func TestReadFunc_BuildConstraint(t *testing.T) {
+	t.Parallel()
+
+	_, out, err := tools.ReadFunc(context.Background(), &mcp.CallToolRequest{}, tools.ReadFuncInput{Dir: testDir(), Name: "PlatformName"})
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if fn := out.Function; fn.BuildConstraint == "" || !strings.HasPrefix(fn.File, "platform_") {
+		t.Fatalf("expected a constrained platform variant, got file %q constraint %q", fn.File, fn.BuildConstraint)
+	}
+
+	_, out, err = tools.ReadFunc(context.Background(), &mcp.CallToolRequest{}, tools.ReadFuncInput{Dir: testDir(), Name: "Foo.DoSomething"})
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	if out.Function.BuildConstraint != "" {
+		t.Errorf("expected no build constraint for foo.go, got %q", out.Function.BuildConstraint)
+	}
+}
+
 func TestReadStruct_WithMethods(t *testing.T) {
 	t.Parallel()
 
@@ -148,3 +216,47 @@ func TestReadStruct_WithMethods(t *testing.T) {
 		t.Errorf("expected methods DoSomething and deadHelper, got %v", st.Methods)
 	}
 }
+
+func TestReadFunc_GenericReceiver(t *testing.T) {
+	t.Parallel()
+
+	in := tools.ReadFuncInput{Dir: testDir(), Name: "Store.Get"}
+
+	_, out, err := tools.ReadFunc(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ReadFunc error: %v", err)
+	}
+
+	fn := out.Function
+	if fn.Receiver != "Store" {
+		t.Errorf("expected receiver Store, got %s", fn.Receiver)
+	}
+
+	if len(fn.TypeParams) != 1 || fn.TypeParams[0] != "T" {
+		t.Errorf("expected type params [T], got %v", fn.TypeParams)
+	}
+
+	if fn.File != "generic_store.go" {
+		t.Errorf("expected file generic_store.go, got %s", fn.File)
+	}
+}
+
+func TestReadStruct_GenericMethods(t *testing.T) {
+	t.Parallel()
+
+	in := tools.ReadStructInput{Dir: testDir(), Name: "Store", IncludeMethods: true}
+
+	_, out, err := tools.ReadStruct(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ReadStruct error: %v", err)
+	}
+
+	st := out.Struct
+	if len(st.TypeParams) != 1 || st.TypeParams[0] != "T" {
+		t.Errorf("expected type params [T], got %v", st.TypeParams)
+	}
+
+	if !containsAll(st.Methods, "Get", "Put") {
+		t.Errorf("expected methods Get and Put, got %v", st.Methods)
+	}
+}
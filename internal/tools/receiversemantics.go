@@ -0,0 +1,403 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Categories of AnalyzeReceiverSemantics findings, in report order.
+const (
+	receiverValueMutation = "value-receiver-mutation"
+	receiverMixed         = "mixed-receivers"
+	receiverLockCopy      = "lock-copy"
+)
+
+var receiverCategories = []string{receiverValueMutation, receiverMixed, receiverLockCopy}
+
+// AnalyzeReceiverSemantics reports method receiver bugs: value-receiver methods assigning to a field of
+// their receiver, whose change is lost with the copy; named types declaring both pointer and value receiver
+// methods; and, like go vet's copylocks, receivers, parameters, assignments and range variables copying a
+// value whose type contains a lock (a type whose pointer has Lock and Unlock methods, such as sync.Mutex,
+// directly, in an array or in a field).
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional package filter
+//
+// Returns:
+//   - MCP tool call result
+//   - findings grouped by category
+//   - error if an error occurred while loading packages
+func AnalyzeReceiverSemantics(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeReceiverSemanticsInput) (
+	*mcp.CallToolResult,
+	AnalyzeReceiverSemanticsOutput,
+	error,
+) {
+	start := logStart("AnalyzeReceiverSemantics", logFields(input.Dir, newLogField("package", input.Package)))
+	out := AnalyzeReceiverSemanticsOutput{Categories: []ReceiverSemanticsCategory{}}
+
+	defer func() { logEnd("AnalyzeReceiverSemantics", start, out.Total) }()
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "AnalyzeReceiverSemantics")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	byCategory := make(map[string][]ReceiverFinding)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		s := &receiverScanner{info: pkg.TypesInfo}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Body == nil {
+					continue
+				}
+
+				first := len(s.findings)
+				s.scanFunc(d)
+
+				for i := first; i < len(s.findings); i++ {
+					s.findings[i].Function = qualifiedFuncName(d)
+				}
+			case *ast.GenDecl:
+				s.scanTypes(d)
+			}
+		}
+
+		for _, f := range s.findings {
+			f.File, f.Line = relPath, pkg.Fset.Position(f.pos).Line
+			byCategory[f.Category] = append(byCategory[f.Category], f.ReceiverFinding)
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, category := range receiverCategories {
+		findings := byCategory[category]
+		if len(findings) == 0 {
+			continue
+		}
+
+		sort.Slice(findings, func(i, j int) bool {
+			if findings[i].File != findings[j].File {
+				return findings[i].File < findings[j].File
+			}
+
+			return findings[i].Line < findings[j].Line
+		})
+
+		out.Categories = append(out.Categories, ReceiverSemanticsCategory{Category: category, Count: len(findings), Findings: findings})
+		out.Total += len(findings)
+	}
+
+	return nil, out, nil
+}
+
+// receiverFinding is a finding with the position its line is taken from.
+type receiverFinding struct {
+	ReceiverFinding
+
+	pos token.Pos
+}
+
+// receiverScanner collects the findings of one file.
+type receiverScanner struct {
+	info     *types.Info
+	findings []receiverFinding
+}
+
+func (s *receiverScanner) add(pos token.Pos, category, typ, expr, message string) {
+	s.findings = append(s.findings, receiverFinding{pos: pos, ReceiverFinding: ReceiverFinding{
+		Category: category, Type: typ, Expr: expr, Message: message,
+	}})
+}
+
+// scanTypes reports the named types declared in decl whose methods mix pointer and value receivers.
+func (s *receiverScanner) scanTypes(decl *ast.GenDecl) {
+	if decl.Tok != token.TYPE {
+		return
+	}
+
+	for _, spec := range decl.Specs {
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		obj, ok := s.info.Defs[ts.Name].(*types.TypeName)
+		if !ok {
+			continue
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+
+		var pointer, value []string
+
+		for i := range named.NumMethods() {
+			m := named.Method(i)
+
+			sig, ok := m.Type().(*types.Signature)
+			if !ok || sig.Recv() == nil {
+				continue
+			}
+
+			if _, ok := sig.Recv().Type().(*types.Pointer); ok {
+				pointer = append(pointer, m.Name())
+			} else {
+				value = append(value, m.Name())
+			}
+		}
+
+		if len(pointer) == 0 || len(value) == 0 {
+			continue
+		}
+
+		sort.Strings(pointer)
+		sort.Strings(value)
+
+		s.add(ts.Name.Pos(), receiverMixed, shortTypeString(named), "",
+			fmt.Sprintf("%s has pointer receivers (%s) and value receivers (%s); use one kind for all methods",
+				obj.Name(), strings.Join(pointer, ", "), strings.Join(value, ", ")))
+	}
+}
+
+// scanFunc checks the receiver, parameters and body of fd, function literals included.
+func (s *receiverScanner) scanFunc(fd *ast.FuncDecl) {
+	if fd.Recv != nil {
+		s.scanFields(fd.Recv, "receiver")
+		s.scanValueReceiver(fd)
+	}
+
+	s.scanFields(fd.Type.Params, "parameter")
+
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			s.scanFields(n.Type.Params, "parameter")
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				break
+			}
+
+			for i, rhs := range n.Rhs {
+				if id, ok := n.Lhs[i].(*ast.Ident); ok && id.Name == "_" {
+					continue // nothing keeps the copy
+				}
+
+				s.checkCopy(rhs, types.ExprString(n.Lhs[i]))
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) != len(n.Values) {
+				break
+			}
+
+			for i, value := range n.Values {
+				s.checkCopy(value, n.Names[i].Name)
+			}
+		case *ast.RangeStmt:
+			for _, v := range []ast.Expr{n.Key, n.Value} {
+				if v == nil {
+					continue
+				}
+
+				if path := lockPath(s.info.TypeOf(v), nil); path != nil {
+					s.add(v.Pos(), receiverLockCopy, path[0], types.ExprString(v),
+						"range variable copies lock: "+strings.Join(path, " contains "))
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// scanFields reports the receiver or parameters of fields whose type contains a lock.
+func (s *receiverScanner) scanFields(fields *ast.FieldList, role string) {
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		path := lockPath(s.info.TypeOf(field.Type), nil)
+		if path == nil {
+			continue
+		}
+
+		message := role + " passes lock by value: " + strings.Join(path, " contains ")
+		if len(field.Names) == 0 {
+			s.add(field.Type.Pos(), receiverLockCopy, path[0], "", message)
+
+			continue
+		}
+
+		for _, name := range field.Names {
+			s.add(name.Pos(), receiverLockCopy, path[0], name.Name, message)
+		}
+	}
+}
+
+// checkCopy reports rhs when assigning it to lhs copies a lock. Composite literals and call results are
+// new values, as in copylocks.
+func (s *receiverScanner) checkCopy(rhs ast.Expr, lhs string) {
+	switch x := ast.Unparen(rhs).(type) {
+	case *ast.CompositeLit, *ast.CallExpr:
+		return
+	case *ast.StarExpr:
+		if _, ok := ast.Unparen(x.X).(*ast.CallExpr); ok {
+			return
+		}
+	}
+
+	tv, ok := s.info.Types[rhs]
+	if !ok || !tv.IsValue() {
+		return
+	}
+
+	if path := lockPath(tv.Type, nil); path != nil {
+		s.add(rhs.Pos(), receiverLockCopy, path[0], lhs,
+			"assignment copies lock value to "+lhs+": "+strings.Join(path, " contains "))
+	}
+}
+
+// scanValueReceiver reports the assignments and increments of fd, a method, that change a field of its
+// value receiver.
+func (s *receiverScanner) scanValueReceiver(fd *ast.FuncDecl) {
+	recv := fd.Recv.List[0]
+	if len(recv.Names) == 0 || recv.Names[0].Name == "_" {
+		return
+	}
+
+	obj := s.info.Defs[recv.Names[0]]
+	if obj == nil {
+		return
+	}
+
+	if _, ok := obj.Type().(*types.Pointer); ok {
+		return
+	}
+
+	typeName := shortTypeString(obj.Type())
+
+	report := func(lhs ast.Expr) {
+		if receiverFieldOf(s.info, lhs, obj) {
+			field := types.ExprString(lhs)
+			s.add(lhs.Pos(), receiverValueMutation, typeName, field,
+				fmt.Sprintf("%s is assigned on a copy of the receiver and the change is lost; use a pointer receiver (*%s)", field, typeName))
+		}
+	}
+
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				for _, lhs := range n.Lhs {
+					report(lhs)
+				}
+			}
+		case *ast.IncDecStmt:
+			report(n.X)
+		}
+
+		return true
+	})
+}
+
+// receiverFieldOf reports whether expr selects a field stored in recv itself: a chain of field selections
+// rooted at recv without pointer indirection, so that assigning to it changes only the copy.
+func receiverFieldOf(info *types.Info, expr ast.Expr, recv types.Object) bool {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	for {
+		selection := info.Selections[sel]
+		if selection == nil || selection.Kind() != types.FieldVal || selection.Indirect() {
+			return false
+		}
+
+		switch x := ast.Unparen(sel.X).(type) {
+		case *ast.Ident:
+			return info.Uses[x] == recv
+		case *ast.SelectorExpr:
+			sel = x
+		default:
+			return false
+		}
+	}
+}
+
+// lockPath returns the chain of types from typ to the lock it contains by value, or nil. A lock is a
+// struct type whose pointer has Lock and Unlock methods while the value does not; arrays and struct fields
+// are followed, pointers, interfaces and type parameters are not.
+func lockPath(typ types.Type, seen map[types.Type]bool) []string {
+	if typ == nil {
+		return nil
+	}
+
+	if _, ok := types.Unalias(typ).(*types.TypeParam); ok {
+		return nil
+	}
+
+	for {
+		array, ok := typ.Underlying().(*types.Array)
+		if !ok {
+			break
+		}
+
+		typ = array.Elem()
+	}
+
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok || seen[typ] {
+		return nil
+	}
+
+	if seen == nil {
+		seen = make(map[types.Type]bool)
+	}
+
+	seen[typ] = true
+
+	name := shortTypeString(typ)
+
+	if hasLockMethods(types.NewPointer(typ)) && !hasLockMethods(typ) {
+		return []string{name}
+	}
+
+	for i := range st.NumFields() {
+		if sub := lockPath(st.Field(i).Type(), seen); sub != nil {
+			return append([]string{name}, sub...)
+		}
+	}
+
+	return nil
+}
+
+// shortTypeString renders typ with package names as qualifiers, e.g. "store.Cache".
+func shortTypeString(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string { return p.Name() })
+}
+
+// hasLockMethods reports whether the method set of typ has Lock and Unlock.
+func hasLockMethods(typ types.Type) bool {
+	ms := types.NewMethodSet(typ)
+
+	return ms.Lookup(nil, "Lock") != nil && ms.Lookup(nil, "Unlock") != nil
+}
@@ -0,0 +1,104 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// receiverSemanticsSource declares its own Mutex: a type whose pointer has Lock and Unlock is a lock.
+const receiverSemanticsSource = `package lang
+
+type Mutex struct{ state int }
+
+func (m *Mutex) Lock()   {}
+func (m *Mutex) Unlock() {}
+
+type Counter struct {
+	mu    Mutex
+	count int
+	inner struct{ hits int }
+	ref   *Counter
+}
+
+func (c Counter) Inc() {
+	c.count++
+	c.inner.hits = 1
+	c.ref.count = 2
+	local := c.count
+	local++
+}
+
+func (c *Counter) Reset() { c.count = 0 }
+
+type Pool struct{ counters [2]Counter }
+
+func Use(p Pool, pc *Pool) {
+	copied := *pc
+	_ = copied
+	fresh := Pool{}
+	_ = fresh
+	var again = p
+	_ = again
+	for _, c := range pc.counters {
+		_ = c
+	}
+	_ = func(m Mutex) {}
+}
+
+type Point struct{ X, Y int }
+
+func (p Point) Move(dx int) Point {
+	p.X += dx
+	return p
+}
+`
+
+func TestAnalyzeReceiverSemantics(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": receiverSemanticsSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeReceiverSemantics(ctx, req, tools.AnalyzeReceiverSemanticsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("AnalyzeReceiverSemantics: %v", err)
+	}
+
+	var got []string
+
+	for _, c := range out.Categories {
+		for _, f := range c.Findings {
+			got = append(got, fmt.Sprintf("%s %s:%d %s %s", f.Category, f.Function, f.Line, f.Type, f.Expr))
+		}
+	}
+
+	want := []string{
+		"value-receiver-mutation Counter.Inc:16 lang.Counter c.count",
+		"value-receiver-mutation Counter.Inc:17 lang.Counter c.inner.hits",
+		"value-receiver-mutation Point.Move:43 lang.Point p.X",
+		"mixed-receivers :8 lang.Counter ",
+		"lock-copy Counter.Inc:15 lang.Counter c",
+		"lock-copy Use:27 lang.Pool p",
+		"lock-copy Use:28 lang.Pool copied",
+		"lock-copy Use:32 lang.Pool again",
+		"lock-copy Use:34 lang.Counter c",
+		"lock-copy Use:37 lang.Mutex m",
+	}
+
+	if out.Total != len(want) || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("findings:\n%s\nwant:\n%s", got, want)
+	}
+
+	mixed := out.Categories[1].Findings[0]
+	if mixed.Message != "Counter has pointer receivers (Reset) and value receivers (Inc); use one kind for all methods" {
+		t.Errorf("mixed-receivers message = %q", mixed.Message)
+	}
+
+	if msg := out.Categories[2].Findings[1].Message; msg != "parameter passes lock by value: lang.Pool contains lang.Counter contains lang.Mutex" {
+		t.Errorf("lock-copy message = %q", msg)
+	}
+}
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// recursionInfo describes how a recursive function takes part in recursion within its package.
+type recursionInfo struct {
+	// cycle lists the functions of a mutual recursion cycle, the function included, sorted by name; it is
+	// nil for a function that only calls itself.
+	cycle []string
+}
+
+// packageRecursion returns the recursive functions of pkg. Calls are resolved through the type
+// information, so a call of a same-named method on another type or of a shadowing local is not mistaken
+// for recursion; calls inside function literals count as calls of the enclosing declaration. Mutual
+// recursion is found as strongly connected components of at least two functions in the package's static
+// call graph.
+func packageRecursion(pkg *packages.Package) map[*types.Func]recursionInfo {
+	if pkg.TypesInfo == nil {
+		return nil
+	}
+
+	names := make(map[*types.Func]string)
+	calls := make(map[*types.Func][]*types.Func)
+
+	var order []*types.Func
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			fn, ok := pkg.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			names[fn] = qualifiedFuncName(fd)
+			order = append(order, fn)
+
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if callee := calledFunc(pkg.TypesInfo, call); callee != nil && callee.Pkg() == pkg.Types {
+						calls[fn] = append(calls[fn], callee)
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	result := make(map[*types.Func]recursionInfo)
+
+	for _, scc := range stronglyConnected(order, calls) {
+		if len(scc) == 1 {
+			fn := scc[0]
+			for _, callee := range calls[fn] {
+				if callee == fn {
+					result[fn] = recursionInfo{}
+
+					break
+				}
+			}
+
+			continue
+		}
+
+		cycle := make([]string, 0, len(scc))
+		for _, fn := range scc {
+			cycle = append(cycle, names[fn])
+		}
+
+		sort.Strings(cycle)
+
+		for _, fn := range scc {
+			result[fn] = recursionInfo{cycle: cycle}
+		}
+	}
+
+	return result
+}
+
+// stronglyConnected returns the strongly connected components of the call graph restricted to nodes,
+// using Tarjan's algorithm. Callees outside nodes (declared without a body) are ignored.
+func stronglyConnected(nodes []*types.Func, calls map[*types.Func][]*types.Func) [][]*types.Func {
+	type state struct {
+		index, low int
+		onStack    bool
+	}
+
+	known := make(map[*types.Func]struct{}, len(nodes))
+	for _, fn := range nodes {
+		known[fn] = struct{}{}
+	}
+
+	var (
+		states = make(map[*types.Func]*state, len(nodes))
+		stack  []*types.Func
+		sccs   [][]*types.Func
+		visit  func(fn *types.Func)
+	)
+
+	visit = func(fn *types.Func) {
+		s := &state{index: len(states), low: len(states), onStack: true}
+		states[fn] = s
+		stack = append(stack, fn)
+
+		for _, callee := range calls[fn] {
+			if _, ok := known[callee]; !ok {
+				continue
+			}
+
+			if cs, seen := states[callee]; !seen {
+				visit(callee)
+				s.low = min(s.low, states[callee].low)
+			} else if cs.onStack {
+				s.low = min(s.low, cs.index)
+			}
+		}
+
+		if s.low != s.index {
+			return
+		}
+
+		var scc []*types.Func
+
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			states[top].onStack = false
+			scc = append(scc, top)
+
+			if top == fn {
+				break
+			}
+		}
+
+		sccs = append(sccs, scc)
+	}
+
+	for _, fn := range nodes {
+		if _, seen := states[fn]; !seen {
+			visit(fn)
+		}
+	}
+
+	return sccs
+}
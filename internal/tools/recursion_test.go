@@ -0,0 +1,126 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const recursionSource = `package lang
+
+type Tree struct{ kids []*Tree }
+
+// Size calls itself on the children.
+func (t *Tree) Size() int {
+	n := 1
+	for _, k := range t.kids {
+		n += k.Size()
+	}
+
+	return n
+}
+
+func factorial(n int) int {
+	if n <= 1 {
+		return 1
+	}
+
+	return n * factorial(n-1)
+}
+
+func isEven(n int) bool {
+	if n == 0 {
+		return true
+	}
+
+	return isOdd(n - 1)
+}
+
+func isOdd(n int) bool {
+	if n == 0 {
+		return false
+	}
+
+	return isEven(n - 1)
+}
+
+type List struct{ items []int }
+
+func (l List) Size() int { return len(l.items) }
+
+type Wrapper struct{ list List }
+
+// Size delegates to the same-named method of another type.
+func (w Wrapper) Size() int { return w.list.Size() }
+
+func shadowed() int {
+	shadowed := func() int { return 1 }
+
+	return shadowed()
+}
+`
+
+func TestAnalyzeComplexity_DetectRecursion(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": recursionSource})
+
+	_, out, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeComplexityInput{Dir: dir, Top: 20, DetectRecursion: true})
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	got := make(map[string]tools.FunctionComplexity)
+	for _, fn := range out.Ranked {
+		key := fn.Name
+		if fn.Receiver != "" {
+			key = fn.Receiver + "." + fn.Name
+		}
+
+		got[key] = fn
+	}
+
+	for _, name := range []string{"Tree.Size", "factorial"} {
+		if fn := got[name]; !fn.Recursive || fn.RecursionCycle != nil {
+			t.Errorf("expected %s to be directly recursive, got %+v", name, fn)
+		}
+	}
+
+	for _, name := range []string{"isEven", "isOdd"} {
+		if fn := got[name]; !fn.Recursive || !slices.Equal(fn.RecursionCycle, []string{"isEven", "isOdd"}) {
+			t.Errorf("expected %s in the isEven/isOdd cycle, got %+v", name, fn)
+		}
+	}
+
+	for _, name := range []string{"List.Size", "Wrapper.Size", "shadowed"} {
+		if fn, ok := got[name]; !ok || fn.Recursive {
+			t.Errorf("expected %s to be analyzed and not recursive, got %+v", name, fn)
+		}
+	}
+
+	if out.Summary == nil || out.Summary.RecursiveByPackage["lang"] != 4 {
+		t.Fatalf("expected 4 recursive functions in lang, got %+v", out.Summary)
+	}
+
+	// Grouped output carries the flags as well.
+	_, grouped, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeComplexityInput{Dir: dir, DetectRecursion: true})
+	if err != nil || len(grouped.Functions) != 1 {
+		t.Fatalf("unexpected grouped output: %+v (err %v)", grouped, err)
+	}
+
+	recursive := 0
+	for _, fn := range grouped.Functions[0].Functions {
+		if fn.Recursive {
+			recursive++
+		}
+	}
+
+	if recursive != 4 {
+		t.Fatalf("expected 4 recursive functions in the grouped output, got %d", recursive)
+	}
+}
@@ -12,24 +12,27 @@ import (
 	"go/types"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
 )
 
-// RenameSymbol performs a safe, scope-aware rename with dry-run diff preview.
+// RenameSymbol performs a safe, scope-aware rename with dry-run diff preview. A batch of renames is
+// resolved, checked for collisions and applied in one pass, and written all-or-nothing.
 //
 // Parameters:
 //   - ctx: execution context
 //   - req: MCP tool request
-//   - input: input data specifying the directory, old and new symbol names
+//   - input: input data specifying the directory and the old and new symbol names (or a batch of them)
 //
 // Returns:
 //   - MCP tool call result
 //   - rename result with information about changed files
-//   - error if an error occurred while loading packages or the symbol was not found
+//   - error if an error occurred while loading packages or a symbol was not found
 func RenameSymbol(ctx context.Context, _ *mcp.CallToolRequest, input RenameSymbolInput) (
 	*mcp.CallToolResult,
 	RenameSymbolOutput,
@@ -38,16 +41,44 @@ func RenameSymbol(ctx context.Context, _ *mcp.CallToolRequest, input RenameSymbo
 	start := logStart("RenameSymbol", logFields(
 		input.Dir,
 		newLogField("oldName", input.OldName),
+		newLogField("symbolId", input.SymbolID),
 		newLogField("newName", input.NewName),
+		newLogField("renames", strconv.Itoa(len(input.Renames))),
 		newLogField("dryRun", strconv.FormatBool(input.DryRun)),
+		newLogField("previewOnly", strconv.FormatBool(input.PreviewOnly)),
 	))
 	out := RenameSymbolOutput{}
 
 	defer func() { logEnd("RenameSymbol", start, len(out.ChangedFiles)) }()
 
-	if input.OldName == input.NewName {
-		out.Collisions = append(out.Collisions, fmt.Sprintf("cannot rename: %q == %q", input.OldName, input.NewName))
+	if err := validateDiffMode(input.DiffMode); err != nil {
+		return fail(out, err)
+	}
+
+	defer lockModuleForMutation(input.Dir)()
+
+	if err := checkExpectedHashes(input.Dir, input.ExpectedHashes); err != nil {
+		return fail(out, err)
+	}
+
+	pairs := input.Renames
+	if len(pairs) == 0 {
+		pairs = []RenamePair{{OldName: input.OldName, SymbolID: input.SymbolID, NewName: input.NewName, Kind: input.Kind}}
+	} else if input.OldName != "" || input.SymbolID != "" || input.NewName != "" || input.Kind != "" {
+		return nil, out, invalidInput("renames cannot be combined with oldName, symbolId, newName or kind")
+	}
+
+	for _, pair := range pairs {
+		if pair.SymbolID != "" && pair.OldName != "" {
+			return nil, out, invalidInput("symbolId %q cannot be combined with oldName %q", pair.SymbolID, pair.OldName)
+		}
 
+		if pair.SymbolID == "" && pair.OldName == pair.NewName {
+			out.Collisions = append(out.Collisions, fmt.Sprintf("cannot rename: %q == %q", pair.OldName, pair.NewName))
+		}
+	}
+
+	if len(out.Collisions) > 0 {
 		return nil, out, nil
 	}
 
@@ -60,91 +91,102 @@ func RenameSymbol(ctx context.Context, _ *mcp.CallToolRequest, input RenameSymbo
 		return fail(out, err)
 	}
 
-	// Find the target object to rename
-	var targetObj types.Object
+	statuses := newRenameStatusBuilder(input.Dir, pkgs)
+	renames := make([]*renameRequest, 0, len(pairs))
 
-	for _, pkg := range pkgs {
-		if shouldStop(ctx) {
-			return fail(out, context.Canceled)
-		}
+	for _, pair := range pairs {
+		var target types.Object
 
-		// Check if OldName is in the format "TypeName.MethodName"
-		var typeName, methodName string
+		if pair.SymbolID != "" {
+			if target, err = resolveSymbolID(ctx, pkgs, pair.SymbolID); err != nil {
+				return fail(out, err)
+			}
 
-		if strings.Contains(input.OldName, ".") {
-			parts := strings.SplitN(input.OldName, ".", 2)
-			typeName, methodName = parts[0], parts[1]
+			pair.OldName = target.Name()
+			if pair.OldName == pair.NewName {
+				out.Collisions = append(out.Collisions, fmt.Sprintf("cannot rename: %q == %q", pair.OldName, pair.NewName))
 
-			// Find the type in the package scope
-			typeObj := pkg.Types.Scope().Lookup(typeName)
-			if typeObj != nil {
-				// Get the underlying type
-				namedType, ok := typeObj.Type().(*types.Named)
-				if !ok {
-					// If it's not a Named type, try to get the type directly
-					// This can happen if the type alias is used or if typeObj.Type() is directly a concrete type
-					// But for methods, we need a Named type or a struct/interface
-					// Let's use the type we got
-					namedType = nil
-
-					if concreteType := typeObj.Type(); concreteType != nil {
-						// Use concreteType for LookupFieldOrMethod
-						// addressable=true to also find methods defined on pointer receivers
-						obj, _, _ := types.LookupFieldOrMethod(concreteType, true, pkg.Types, methodName)
-						if obj != nil {
-							if input.Kind == "" || objStringKind(obj) == input.Kind {
-								targetObj = obj
-
-								break
-							}
-						}
-					}
-				} else {
-					// Use namedType for LookupFieldOrMethod
-					// addressable=true to also find methods defined on pointer receivers
-					obj, _, _ := types.LookupFieldOrMethod(namedType, true, pkg.Types, methodName)
-					if obj != nil {
-						if input.Kind == "" || objStringKind(obj) == input.Kind {
-							targetObj = obj
-
-							break
-						}
-					}
-				}
+				return nil, out, nil
+			}
+		} else {
+			if target, err = findRenameTarget(ctx, pkgs, input.Dir, pair.OldName, pair.Kind, input.Package); err != nil {
+				return fail(out, err)
+			}
+
+			if target == nil {
+				return nil, out, missingSymbol(pkgs, pair.OldName, pair.Kind, "symbol %q not found", pair.OldName)
 			}
 		}
 
-		// If we haven't found the target object yet, use the existing logic
-		if targetObj == nil {
-			// Look in scope first
-			scope := pkg.Types.Scope()
-			if scope != nil {
-				if obj := scope.Lookup(input.OldName); obj != nil {
-					targetObj = obj
+		if pkg := statuses.skippedDeclaring(pkgs, target); pkg != nil {
+			be := firstPackageError(input.Dir, pkg)
 
-					break
-				}
+			return nil, out, NewToolError(CodeTypeErrorsPresent, fmt.Errorf(
+				"symbol %q is declared in package %s, which has errors (%s:%d: %s); fix them before renaming",
+				pair.OldName, be.Package, be.File, be.Line, be.Message))
+		}
+
+		// Renaming a generated declaration is undone by the next generator run.
+		if generator, ok := generatedFileGenerator(declaringFile(pkgs, target.Pos())); ok && !input.AllowGenerated {
+			if generator == "" {
+				generator = "its generator"
 			}
 
-			// Then look in defs
-			for _, def := range pkg.TypesInfo.Defs {
-				if def != nil && def.Name() == input.OldName {
-					if input.Kind == "" || objStringKind(def) == input.Kind {
-						targetObj = def
+			return nil, out, NewToolError(CodeGeneratedFile, fmt.Errorf(
+				"symbol %q is declared in a generated file; rename it in the source of %s and regenerate",
+				pair.OldName, generator))
+		}
 
-						break
-					}
-				}
-			}
+		match := pair.OldName
+		if _, method, ok := strings.Cut(pair.OldName, "."); ok {
+			// With the TypeName.MethodName format only the method name appears in the source.
+			match = method
 		}
 
-		if targetObj != nil {
-			break
+		renames = append(renames, &renameRequest{RenamePair: pair, target: target, match: match, exact: pair.SymbolID != ""})
+	}
+
+	out.Collisions = renameCollisions(pkgs, renames, input.Dir)
+	if len(out.Collisions) > 0 && !input.PreviewOnly {
+		return nil, out, nil
+	}
+
+	// A rename that looks complete but missed references in a package it could not analyze is the worst
+	// outcome, so writing one takes allowPartial.
+	if unanalyzed := statuses.unanalyzed(pkgs, renames); len(unanalyzed) > 0 {
+		out.Partial = true
+
+		if !input.AllowPartial && !input.DryRun && !input.PreviewOnly {
+			out.Packages = statuses.result()
+
+			return nil, out, NewToolError(CodeTypeErrorsPresent, fmt.Errorf(
+				"packages %s have errors and may reference the renamed symbols; fix them or pass allowPartial",
+				strings.Join(unanalyzed, ", ")))
 		}
 	}
 
-	if targetObj == nil {
-		return nil, out, fmt.Errorf("symbol %q not found", input.OldName)
+	var companions []renameCompanion
+
+	renameTests := input.RenameCompanions == nil || *input.RenameCompanions
+
+	for _, c := range findRenameCompanions(input.Dir, pkgs, renames) {
+		if c.Reason != "" || !renameTests {
+			out.CompanionsNeedingRename = append(out.CompanionsNeedingRename, c.RenameCompanion)
+
+			continue
+		}
+
+		companions = append(companions, c)
+		out.Companions = append(out.Companions, c.RenameCompanion)
+	}
+
+	var (
+		pending []pendingWrite
+		impact  *renameImpactBuilder
+	)
+
+	if input.PreviewOnly {
+		impact = newRenameImpactBuilder()
 	}
 
 	for _, pkg := range pkgs {
@@ -152,80 +194,428 @@ func RenameSymbol(ctx context.Context, _ *mcp.CallToolRequest, input RenameSymbo
 			return fail(out, context.Canceled)
 		}
 
+		if statuses.skipped(pkg) {
+			continue
+		}
+
 		for i, file := range pkg.Syntax {
 			if shouldStop(ctx) {
 				return fail(out, context.Canceled)
 			}
 
 			filename := pkg.CompiledGoFiles[i]
-			origBytes, _ := os.ReadFile(filename)
-			changed := false
-
-			nameToMatch := input.OldName
-			if strings.Contains(input.OldName, ".") {
-				// If using TypeName.MethodName format, extract just the method name
-				parts := strings.SplitN(input.OldName, ".", 2)
-				nameToMatch = parts[1] // Just the method name
-			}
+			generator, generated := generatedFileGenerator(file)
+
+			// Offsets of the identifiers that refer to one of the targets, each renamed at most once.
+			offsets := make(map[int]*renameRequest)
 
 			ast.Inspect(file, func(n ast.Node) bool {
 				if shouldStop(ctx) {
 					return false
 				}
 
-				// Only rename identifiers that refer to our target object
-				if ident, ok := n.(*ast.Ident); ok {
-					if ident.Name == nameToMatch {
-						obj := pkg.TypesInfo.ObjectOf(ident)
-						if obj != nil && sameObject(obj, targetObj) {
-							ident.Name = input.NewName
-							changed = true
-						}
+				ident, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+
+				for _, r := range renames {
+					if ident.Name != r.match {
+						continue
+					}
+
+					if obj := pkg.TypesInfo.ObjectOf(ident); obj != nil && r.refersTo(obj) {
+						offsets[pkg.Fset.Position(ident.Pos()).Offset] = r
+
+						break
 					}
 				}
 
 				return true
 			})
 
-			if !changed {
+			if len(offsets) == 0 {
+				continue
+			}
+
+			relPath := resolveFilePath(pkg, input.Dir, i, file)
+
+			if impact != nil {
+				impact.add(normalizePackagePath(pkg), relPath, len(offsets), generated, generated && !input.AllowGenerated)
+			}
+
+			if generated && !input.AllowGenerated {
+				out.SkippedGenerated = append(out.SkippedGenerated, GeneratedFile{File: relPath, Generator: generator})
+
 				continue
 			}
 
-			var buf bytes.Buffer
+			// The preview stops at the reference resolution: nothing is formatted or diffed.
+			if impact != nil {
+				out.ChangedFiles = append(out.ChangedFiles, relPath)
+				statuses.modified(pkg, relPath)
 
-			err := format.Node(&buf, pkg.Fset, file)
+				continue
+			}
+
+			origBytes, newContent, err := renameInFile(filename, offsets)
 			if err != nil {
-				logError("RenameSymbol", err, "failed to format file")
+				logError("RenameSymbol", err, "failed to rename in file")
 
 				return fail(out, err)
 			}
 
-			newContent := buf.Bytes()
-			if len(newContent) > 0 && newContent[len(newContent)-1] != '\n' {
-				newContent = append(newContent, '\n')
+			out.ChangedFiles = append(out.ChangedFiles, relPath)
+			statuses.modified(pkg, relPath)
+			pending = append(pending, pendingWrite{path: filename, relPath: relPath, before: origBytes, after: newContent})
+		}
+	}
+
+	// Test files are not part of the load, so no pending write touches the files of the companions.
+	offsetsByFile := companionOffsets(companions)
+	for _, c := range companions {
+		offsets, ok := offsetsByFile[c.path]
+		if !ok {
+			continue
+		}
+
+		delete(offsetsByFile, c.path)
+
+		out.ChangedFiles = append(out.ChangedFiles, c.File)
+		statuses.modified(c.pkg, c.File)
+
+		if impact != nil {
+			impact.add(normalizePackagePath(c.pkg), c.File, len(offsets), false, false)
+
+			continue
+		}
+
+		origBytes, newContent, err := renameInFile(c.path, offsets)
+		if err != nil {
+			logError("RenameSymbol", err, "failed to rename companion test functions")
+
+			return fail(out, err)
+		}
+
+		pending = append(pending, pendingWrite{path: c.path, relPath: c.File, before: origBytes, after: newContent})
+	}
+
+	out.Packages = statuses.result()
+
+	if impact != nil {
+		out.Impact = impact.result()
+
+		return nil, out, nil
+	}
+
+	if input.DryRun {
+		for _, w := range pending {
+			out.Diffs = append(out.Diffs, FileDiff{Path: w.relPath, Diff: diffFiles(w.before, w.after, w.relPath, input.DiffMode)})
+		}
+
+		return nil, out, nil
+	}
+
+	if err := writeAllOrNothing(pending, fileChange{tool: "renameSymbol", input: input}); err != nil {
+		logError("RenameSymbol", err, "failed to write files")
+
+		out.ChangedFiles, out.Packages = nil, nil
+
+		return fail(out, err)
+	}
+
+	out.Build = verifyAfterMutation(ctx, "RenameSymbol", input.Dir, input.VerifyBuild)
+
+	return nil, out, nil
+}
+
+// renameRequest is a rename pair resolved to the object it renames.
+type renameRequest struct {
+	RenamePair
+
+	target types.Object
+	// match is the identifier name the target appears under in the source.
+	match string
+	// exact is set for a target given by symbol ID, which selects it among same-named locals.
+	exact bool
+}
+
+// refersTo reports whether obj, the object of an identifier, is the target of the rename.
+func (r *renameRequest) refersTo(obj types.Object) bool {
+	if r.exact {
+		return sameDeclaration(obj, r.target)
+	}
+
+	return sameObject(obj, r.target)
+}
+
+// pendingWrite is a file content change computed before any file of a call is written.
+type pendingWrite struct {
+	path    string
+	relPath string
+	before  []byte
+	after   []byte
+	// created marks a file the call creates; restoring it removes the file.
+	created bool
+}
+
+// writeAllOrNothing writes every pending change; if one write fails, files already written are restored
+// to their previous content and files created by the call are removed.
+func writeAllOrNothing(pending []pendingWrite, change fileChange) error {
+	for i, w := range pending {
+		if err := safeWriteFile(w.path, w.after, change); err != nil {
+			for _, done := range pending[:i] {
+				if done.created {
+					if removeErr := os.Remove(done.path); removeErr != nil {
+						logError("RenameSymbol", removeErr, "failed to remove "+done.relPath)
+					}
+
+					invalidateCachesForFile(done.path)
+
+					continue
+				}
+
+				if restoreErr := safeWriteFile(done.path, done.before, change); restoreErr != nil {
+					logError("RenameSymbol", restoreErr, "failed to restore "+done.relPath)
+				}
 			}
 
-			relPath := resolveFilePath(pkg, input.Dir, i, file)
-			out.ChangedFiles = append(out.ChangedFiles, relPath)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renameInFile re-parses filename privately and renames the identifiers at the given offsets, which were
+// found in the cached syntax tree. It fails when the file changed since its packages were loaded.
+func renameInFile(filename string, offsets map[int]*renameRequest) ([]byte, []byte, error) {
+	fset, file, src, err := parseFileForMutation(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	renamed := 0
 
-			if input.DryRun {
-				diffText := diffFiles(origBytes, newContent, relPath)
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
 
-				out.Diffs = append(out.Diffs, FileDiff{Path: relPath, Diff: diffText})
+		if r, ok := offsets[fset.Position(ident.Pos()).Offset]; ok && ident.Name == r.match {
+			ident.Name = r.NewName
+			renamed++
+		}
 
+		return true
+	})
+
+	if renamed != len(offsets) {
+		return nil, nil, fmt.Errorf("%s changed since its packages were loaded; retry the rename", filepath.Base(filename))
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, nil, err
+	}
+
+	newContent := buf.Bytes()
+	if len(newContent) > 0 && newContent[len(newContent)-1] != '\n' {
+		newContent = append(newContent, '\n')
+	}
+
+	return src, newContent, nil
+}
+
+// findRenameTarget resolves a rename's old name, 'Name' or 'TypeName.MethodName', to the object it
+// denotes, applying kind and the package path pkgPath to both forms. Without kind, a name declared with
+// several kinds (a package var and a local type, say) is refused with an AMBIGUOUS error listing the
+// candidates, as is a name several packages declare. It returns nil when no object matches.
+func findRenameTarget(ctx context.Context, pkgs []*packages.Package, dir, oldName, kind, pkgPath string) (types.Object, error) {
+	if typeName, methodName, ok := strings.Cut(oldName, "."); ok {
+		byPos := make(map[token.Pos]targetCandidate)
+
+		for _, pkg := range pkgs {
+			if shouldStop(ctx) {
+				return nil, context.Canceled
+			}
+
+			if pkgPath != "" && pkg.Types.Path() != pkgPath {
 				continue
 			}
 
-			err = safeWriteFile(filename, newContent)
-			if err != nil {
-				logError("RenameSymbol", err, "failed to write file")
+			// Find the type in the package scope
+			if typeObj := pkg.Types.Scope().Lookup(typeName); typeObj != nil {
+				// LookupFieldOrMethod works on named and other types alike; addressable=true also finds
+				// methods declared on pointer receivers.
+				obj, _, _ := types.LookupFieldOrMethod(typeObj.Type(), true, pkg.Types, methodName)
+				if obj == nil || (kind != "" && objStringKind(obj) != kind) {
+					continue
+				}
 
-				return fail(out, err)
+				if prev, ok := byPos[obj.Pos()]; !ok || isTestVariant(prev.pkg) {
+					byPos[obj.Pos()] = targetCandidate{obj: obj, pkg: pkg}
+				}
 			}
 		}
+
+		candidates := make([]targetCandidate, 0, len(byPos))
+		for _, c := range byPos {
+			candidates = append(candidates, c)
+		}
+
+		switch len(candidates) {
+		case 0:
+			return nil, nil
+		case 1:
+			return candidates[0].obj, nil
+		}
+
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].obj.Pkg().Path() < candidates[j].obj.Pkg().Path() })
+
+		return nil, ambiguousTarget(dir, oldName, candidates)
 	}
 
-	return nil, out, nil
+	if kind == "" {
+		if kinds, candidates := declaredKinds(pkgs, dir, oldName); len(kinds) > 1 {
+			return nil, ambiguous(candidates, "%q is declared as %s; pass kind to choose one: %s",
+				oldName, strings.Join(kinds, " and "), strings.Join(candidates, ", "))
+		}
+	}
+
+	return findTargetObject(ctx, pkgs, dir, oldName, kind, pkgPath)
+}
+
+// renameCollisions reports conflicts of a set of renames before anything is changed: a target renamed
+// twice, a new name already declared in the target's scope or method set, a reference that the new
+// name would bind to another declaration, a local rename capturing a later use of an outer declaration,
+// and two renames giving the same name to declarations of one scope. Declarations renamed away by the
+// same call do not conflict.
+func renameCollisions(pkgs []*packages.Package, renames []*renameRequest, dir string) []string {
+	var collisions []string
+
+	seen := make(map[string]struct{})
+	report := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		if _, ok := seen[msg]; !ok {
+			seen[msg] = struct{}{}
+			collisions = append(collisions, msg)
+		}
+	}
+
+	renamedAway := func(obj types.Object) bool {
+		for _, r := range renames {
+			if r.refersTo(obj) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	where := func(pkg *packages.Package, pos token.Pos) string {
+		posn := pkg.Fset.Position(pos)
+
+		return fmt.Sprintf("%s:%d", relativePath(dir, posn.Filename), posn.Line)
+	}
+
+	scopes := make(map[string]*renameRequest)
+
+	for i, r := range renames {
+		for _, other := range renames[:i] {
+			if sameObject(r.target, other.target) {
+				report("cannot rename %q twice (%q and %q)", r.OldName, other.NewName, r.NewName)
+			}
+		}
+
+		scopeKey, existing := renameScope(r.target, r.NewName)
+		if existing != nil && !renamedAway(existing) {
+			report("cannot rename %q to %q: %q is already declared in the same scope", r.OldName, r.NewName, r.NewName)
+		}
+
+		if other, ok := scopes[scopeKey]; ok && scopeKey != "" {
+			report("cannot rename %q and %q both to %q in the same scope", other.OldName, r.OldName, r.NewName)
+		} else {
+			scopes[scopeKey] = r
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if !hasTypes(pkg) {
+			continue
+		}
+
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+
+				obj := pkg.TypesInfo.ObjectOf(ident)
+				if obj == nil {
+					return true
+				}
+
+				for _, r := range renames {
+					parent := r.target.Parent()
+					if parent == nil {
+						continue // fields and methods are selected, not looked up in scopes
+					}
+
+					// A reference to the target that the new name would bind to another declaration. References
+					// from other packages are qualified and cannot be shadowed.
+					if ident.Name == r.match && r.target.Pkg() == pkg.Types && r.refersTo(obj) {
+						if scope := pkg.Types.Scope().Innermost(ident.Pos()); scope != nil {
+							// Declarations in the target's own scope are reported by the scope check above.
+							if _, shadow := scope.LookupParent(r.NewName, ident.Pos()); shadow != nil &&
+								shadow.Parent() != parent && !renamedAway(shadow) {
+								report("cannot rename %q to %q: the reference at %s would refer to the %s declared at %s",
+									r.OldName, r.NewName, where(pkg, ident.Pos()), objStringKind(shadow), where(pkg, shadow.Pos()))
+							}
+						}
+					}
+
+					// A use of an outer declaration named like the new name, inside the scope of a local target.
+					if ident.Name == r.NewName && parent != pkg.Types.Scope() && r.target.Pkg() == pkg.Types &&
+						ident.Pos() > r.target.Pos() && parent.Contains(ident.Pos()) &&
+						!parent.Contains(obj.Pos()) && !renamedAway(obj) {
+						report("cannot rename %q to %q: the use of %q at %s would refer to the renamed symbol",
+							r.OldName, r.NewName, r.NewName, where(pkg, ident.Pos()))
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	return collisions
+}
+
+// renameScope returns a key identifying where newName is declared when target is renamed (its scope,
+// or the receiver type of a method) and the object already declared under newName there, if any.
+func renameScope(target types.Object, newName string) (string, types.Object) {
+	if fn, ok := target.(*types.Func); ok {
+		if recv := fn.Signature().Recv(); recv != nil {
+			t := recv.Type()
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+
+			existing, _, _ := types.LookupFieldOrMethod(t, true, fn.Pkg(), newName)
+
+			return "method " + types.TypeString(t, nil) + "." + newName, existing
+		}
+	}
+
+	parent := target.Parent()
+	if parent == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf("scope %p.%s", parent, newName), parent.Lookup(newName)
 }
 
 // ASTRewrite allows replacing AST nodes with type-aware understanding (e.g., 'pkg.Foo(x)' -> 'x.Foo()').
@@ -257,15 +647,25 @@ func ASTRewrite(ctx context.Context, _ *mcp.CallToolRequest, input ASTRewriteInp
 
 	defer func() { logEnd("ASTRewrite", start, out.TotalChanges) }()
 
+	if err := validateDiffMode(input.DiffMode); err != nil {
+		return fail(out, err)
+	}
+
+	defer lockModuleForMutation(input.Dir)()
+
+	if err := checkExpectedHashes(input.Dir, input.ExpectedHashes); err != nil {
+		return fail(out, err)
+	}
+
 	// Parse find and replace expressions once
 	findExpr, err := parser.ParseExpr(input.Find)
 	if err != nil {
-		return nil, out, fmt.Errorf("invalid find expression: %w", err)
+		return nil, out, invalidInput("invalid find expression: %w", err)
 	}
 
 	replaceExpr, err := parser.ParseExpr(input.Replace)
 	if err != nil {
-		return nil, out, fmt.Errorf("invalid replace expression: %w", err)
+		return nil, out, invalidInput("invalid replace expression: %w", err)
 	}
 
 	mode := loadModeSyntaxTypes
@@ -277,69 +677,146 @@ func ASTRewrite(ctx context.Context, _ *mcp.CallToolRequest, input ASTRewriteInp
 		return fail(out, err)
 	}
 
-	totalChanges := 0
+	var candidates []*rewriteCandidate
 
-	for _, pkg := range pkgs {
-		for i, file := range pkg.Syntax {
-			filename := pkg.CompiledGoFiles[i]
-			origBytes, _ := os.ReadFile(filename)
-			changesInFile := 0
-
-			rewriter := &ASTRewriteVisitor{
-				Fset:        pkg.Fset,
-				TypesInfo:   pkg.TypesInfo,
-				FindPattern: findExpr,
-				ReplaceWith: replaceExpr,
-				Changes:     &changesInFile,
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, _ string, i int) error {
+		filename := pkg.CompiledGoFiles[i]
+
+		generator, generated := generatedFileGenerator(file)
+		if generated && !input.AllowGenerated {
+			if countPatternMatches(file, findExpr) > 0 {
+				out.SkippedGenerated = append(out.SkippedGenerated, GeneratedFile{
+					File:      relativePath(input.Dir, filename),
+					Generator: generator,
+				})
 			}
 
-			newFile := rewriter.Rewrite(file)
+			return nil
+		}
 
-			if changesInFile == 0 {
-				continue
-			}
+		if countPatternMatches(file, findExpr) == 0 {
+			return nil
+		}
 
-			var buf bytes.Buffer
+		rel := relativePath(input.Dir, filename)
+		if rel == "" {
+			rel = filepath.ToSlash(filename)
+		}
 
-			err := format.Node(&buf, pkg.Fset, newFile)
-			if err != nil {
-				logError("ASTRewrite", err, "failed to format file")
+		// The rewrite edits a private parse of the file, never the cached tree.
+		candidate, err := newRewriteCandidate(filename, rel, findExpr)
+		if err != nil {
+			return err
+		}
 
-				return fail(out, err)
-			}
+		candidates = append(candidates, candidate)
 
-			newContent := buf.Bytes()
-			if len(newContent) > 0 && newContent[len(newContent)-1] != '\n' {
-				newContent = append(newContent, '\n')
-			}
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
 
-			rel := relativePath(input.Dir, filename)
-			if rel == "" {
-				rel = filepath.ToSlash(filename)
-			}
+	if (input.TypeCheck == nil || *input.TypeCheck) && len(candidates) > 0 {
+		if err := typeCheckRewrites(ctx, input.Dir, candidates, replaceExpr); err != nil {
+			logError("ASTRewrite", err, "failed to type-check the replacements")
 
-			out.ChangedFiles = append(out.ChangedFiles, rel)
-			totalChanges += changesInFile
+			return fail(out, err)
+		}
 
-			if input.DryRun {
-				diffText := diffFiles(origBytes, newContent, rel)
-				out.Diffs = append(out.Diffs, FileDiff{Path: rel, Diff: diffText})
-			} else {
-				err := safeWriteFile(filename, newContent)
-				if err != nil {
-					logError("ASTRewrite", err, "failed to write file")
+		out.Rejected = rejectedRewrites(candidates)
+		if input.Atomic && len(out.Rejected) > 0 {
+			return nil, out, nil
+		}
+	}
 
-					return fail(out, err)
-				}
-			}
+	totalChanges := 0
+
+	// Rewrites are computed for every file before the first write, so a failure changes nothing.
+	type rewrite struct {
+		filename string
+		content  []byte
+	}
+
+	var rewrites []rewrite
+
+	for _, c := range candidates {
+		changesInFile := 0
+
+		rewriter := &ASTRewriteVisitor{
+			Fset:        c.fset,
+			FindPattern: findExpr,
+			ReplaceWith: replaceExpr,
+			Changes:     &changesInFile,
+			Skip:        c.skipped(),
+		}
+
+		newFile := rewriter.Rewrite(c.file)
+
+		if changesInFile == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+
+		err = format.Node(&buf, c.fset, newFile)
+		if err != nil {
+			logError("ASTRewrite", err, "failed to format file")
+
+			return fail(out, err)
+		}
+
+		newContent := buf.Bytes()
+		if len(newContent) > 0 && newContent[len(newContent)-1] != '\n' {
+			newContent = append(newContent, '\n')
+		}
+
+		out.ChangedFiles = append(out.ChangedFiles, c.rel)
+		totalChanges += changesInFile
+
+		if input.DryRun {
+			diffText := diffFiles(c.src, newContent, c.rel, input.DiffMode)
+			out.Diffs = append(out.Diffs, FileDiff{Path: c.rel, Diff: diffText})
+		} else {
+			rewrites = append(rewrites, rewrite{filename: c.filename, content: newContent})
+		}
+	}
+
+	for _, rw := range rewrites {
+		err := safeWriteFile(rw.filename, rw.content, fileChange{tool: "rewriteAst", input: input})
+		if err != nil {
+			logError("ASTRewrite", err, "failed to write file")
+
+			return fail(out, err)
 		}
 	}
 
 	out.TotalChanges = totalChanges
 
+	if !input.DryRun && totalChanges > 0 {
+		out.Build = verifyAfterMutation(ctx, "ASTRewrite", input.Dir, input.VerifyBuild)
+	}
+
 	return nil, out, nil
 }
 
+// countPatternMatches counts the expressions Rewrite would replace, without modifying the tree.
+func countPatternMatches(node ast.Node, pattern ast.Expr) int {
+	count := 0
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		expr, ok := n.(ast.Expr)
+		if ok && astEqual(expr, pattern) {
+			count++
+
+			return false
+		}
+
+		return true
+	})
+
+	return count
+}
+
 // ASTRewriteVisitor traverses the AST and rewrites matching nodes.
 type ASTRewriteVisitor struct {
 	Fset        *token.FileSet
@@ -347,6 +824,10 @@ type ASTRewriteVisitor struct {
 	FindPattern ast.Expr
 	ReplaceWith ast.Expr
 	Changes     *int
+	// Skip holds the indexes of matches, in traversal order, to leave unchanged.
+	Skip map[int]bool
+
+	matches int
 }
 
 // Rewrite walks through the AST and replaces matching expressions.
@@ -365,6 +846,11 @@ func (v *ASTRewriteVisitor) Rewrite(node ast.Node) ast.Node {
 
 		// Сравниваем текущий узел с искомым паттерном
 		if astEqual(expr, v.FindPattern) {
+			v.matches++
+			if v.Skip[v.matches-1] {
+				return false
+			}
+
 			*v.Changes++
 			c.Replace(v.ReplaceWith)
 
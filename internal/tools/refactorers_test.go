@@ -1,11 +1,16 @@
 package tools_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -67,6 +72,17 @@ func TestRenameSymbol_WithInvalidDir(t *testing.T) {
 	}
 }
 
+func TestRenameSymbol_NoOfflineFallback(t *testing.T) {
+	dir := writeUnresolvableModule(t)
+
+	in := tools.RenameSymbolInput{Dir: dir, OldName: "Greet", NewName: "Hello", DryRun: true}
+
+	_, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatal("expected renameSymbol to keep failing when module resolution fails")
+	}
+}
+
 func TestRenameSymbol_WithSameNames(t *testing.T) {
 	t.Parallel()
 
@@ -153,6 +169,62 @@ func TestRenameSymbol_DryRun(t *testing.T) {
 	}
 }
 
+func TestRenameSymbol_DiffModes(t *testing.T) {
+	t.Parallel()
+
+	dir := copySample(t)
+
+	renderings := map[string]string{}
+
+	for _, mode := range []string{"", "unified", "minimal", "summary"} {
+		_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, tools.RenameSymbolInput{
+			Dir:      dir,
+			OldName:  "FormatGreeting",
+			NewName:  "FormatHello",
+			DryRun:   true,
+			DiffMode: mode,
+		})
+		if err != nil {
+			t.Fatalf("RenameSymbol (diffMode %q) error: %v", mode, err)
+		}
+
+		for _, d := range out.Diffs {
+			if d.Path == "greeting.go" {
+				renderings[mode] = d.Diff
+			}
+		}
+	}
+
+	if renderings[""] != renderings["unified"] {
+		t.Errorf("expected the default to be the unified diff:\n%s\nvs\n%s", renderings[""], renderings["unified"])
+	}
+
+	unified := "--- a/greeting.go\n+++ b/greeting.go\n@@ -1,7 +1,7 @@\n package sample\n \n" +
+		" // FormatGreeting renders the greeting for a name; the generated GreetingRequest calls it.\n" +
+		"-func FormatGreeting(name string) string {\n+func FormatHello(name string) string {\n" +
+		" \treturn \"hello, \" + name\n }\n \n"
+	if renderings["unified"] != unified {
+		t.Errorf("unexpected unified diff:\n%s", renderings["unified"])
+	}
+
+	minimal := "greeting.go:4: -func FormatGreeting(name string) string {\n" +
+		"greeting.go:4: +func FormatHello(name string) string {\n"
+	if renderings["minimal"] != minimal {
+		t.Errorf("unexpected minimal diff:\n%s", renderings["minimal"])
+	}
+
+	if summary := "greeting.go: +1 -1 lines 4\n"; renderings["summary"] != summary {
+		t.Errorf("unexpected summary: %q", renderings["summary"])
+	}
+
+	_, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, tools.RenameSymbolInput{
+		Dir: dir, OldName: "FormatGreeting", NewName: "FormatHello", DryRun: true, DiffMode: "context",
+	})
+	if te := tools.AsToolError(err); te.Code != tools.CodeInvalidInput || len(te.Details.Candidates) != 3 {
+		t.Fatalf("expected INVALID_INPUT listing the diff modes, got %v", err)
+	}
+}
+
 func TestRenameSymbol_WithMethodFormat(t *testing.T) {
 	t.Parallel()
 
@@ -304,6 +376,114 @@ func BenchmarkRenameSymbol(b *testing.B) {
 	}
 }
 
+// crlfSampleCopy copies the sample module and rewrites file with CRLF line endings and a UTF-8 BOM.
+func crlfSampleCopy(t *testing.T, file string) (string, []byte) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copyDir error: %v", err)
+	}
+
+	path := filepath.Join(dir, file)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", file, err)
+	}
+
+	data = append([]byte("\xEF\xBB\xBF"), bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write %s: %v", file, err)
+	}
+
+	return dir, data
+}
+
+// changedDiffLines returns the added and removed lines of a unified diff, without file headers.
+func changedDiffLines(diff string) []string {
+	var changed []string
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+") {
+			changed = append(changed, line)
+		}
+	}
+
+	return changed
+}
+
+func TestRenameSymbol_PreservesCRLFAndBOM(t *testing.T) {
+	t.Parallel()
+
+	dryDir, _ := crlfSampleCopy(t, "point.go")
+
+	in := tools.RenameSymbolInput{Dir: dryDir, OldName: "origin", NewName: "zeroPoint", DryRun: true}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol dry run error: %v", err)
+	}
+
+	if len(out.Diffs) != 1 {
+		t.Fatalf("expected one diff, got %+v", out.Diffs)
+	}
+
+	if changed := changedDiffLines(out.Diffs[0].Diff); len(changed) != 2 {
+		t.Errorf("expected the dry-run diff to touch one line, got %q", changed)
+	}
+
+	// Dry runs and writes use separate copies: the package cache is shared between calls.
+	dir, orig := crlfSampleCopy(t, "point.go")
+
+	in = tools.RenameSymbolInput{Dir: dir, OldName: "origin", NewName: "zeroPoint"}
+	if _, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in); err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "point.go"))
+	if err != nil {
+		t.Fatalf("read point.go: %v", err)
+	}
+
+	want := bytes.Replace(orig, []byte("func origin()"), []byte("func zeroPoint()"), 1)
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected only the renamed line to change with CRLF and BOM kept, got %q", got)
+	}
+}
+
+func TestASTRewrite_PreservesCRLF(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := crlfSampleCopy(t, "print.go")
+
+	in := tools.ASTRewriteInput{Dir: dir, Find: "fmt.Println(x)", Replace: "fmt.Print(x)"}
+	if _, _, err := tools.ASTRewrite(context.Background(), &mcp.CallToolRequest{}, in); err != nil {
+		t.Fatalf("ASTRewrite error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "print.go"))
+	if err != nil {
+		t.Fatalf("read print.go: %v", err)
+	}
+
+	if !bytes.HasPrefix(got, []byte("\xEF\xBB\xBF")) {
+		t.Errorf("expected the BOM to be kept, got %q", got)
+	}
+
+	if bytes.Count(got, []byte("\n")) != bytes.Count(got, []byte("\r\n")) {
+		t.Errorf("expected every line to end with CRLF, got %q", got)
+	}
+
+	if !bytes.Contains(got, []byte("fmt.Print(x)")) || bytes.Contains(got, []byte("fmt.Println")) {
+		t.Errorf("expected the call to be rewritten, got %q", got)
+	}
+}
+
 func copyDir(src, dst string) error {
 	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -355,3 +535,653 @@ func benchDir() string {
 	// укажем testdata/sample как тестовый проект
 	return filepath.Join(filepath.Dir(filename), "testdata", "sample")
 }
+
+// rewriteCheckSource has two matches of double(x): x.Double() type-checks only for the Num one.
+const rewriteCheckSource = `package lang
+
+type Num int
+
+func (n Num) Double() Num { return n * 2 }
+
+func double[T ~int](v T) T { return v * 2 }
+
+func plain() int {
+	x := 3
+	return double(x)
+}
+
+func named() Num {
+	x := Num(3)
+	return double(x)
+}
+`
+
+func TestASTRewrite_TypeCheckRejectsBrokenReplacements(t *testing.T) {
+	t.Parallel()
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+	disabled := false
+
+	cases := []struct {
+		name      string
+		in        tools.ASTRewriteInput
+		changes   int
+		rejected  int
+		rewritten []string
+	}{
+		{"default", tools.ASTRewriteInput{}, 1, 1, []string{"return double(x)", "return x.Double()"}},
+		{"atomic", tools.ASTRewriteInput{Atomic: true}, 0, 1, []string{"return double(x)\n}\n\nfunc named", "Num(3)\n\treturn double(x)"}},
+		{"unchecked", tools.ASTRewriteInput{TypeCheck: &disabled}, 2, 0, []string{"x := 3\n\treturn x.Double()", "Num(3)\n\treturn x.Double()"}},
+	}
+
+	for _, tc := range cases {
+		dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": rewriteCheckSource})
+
+		in := tc.in
+		in.Dir, in.Find, in.Replace = dir, "double(x)", "x.Double()"
+
+		_, out, err := tools.ASTRewrite(ctx, req, in)
+		if err != nil {
+			t.Fatalf("%s: ASTRewrite: %v", tc.name, err)
+		}
+
+		if out.TotalChanges != tc.changes || len(out.Rejected) != tc.rejected {
+			t.Errorf("%s: changes %d, rejected %+v; want %d and %d", tc.name, out.TotalChanges, out.Rejected, tc.changes, tc.rejected)
+		}
+
+		if tc.rejected > 0 {
+			r := out.Rejected[0]
+			if r.File != "lang.go" || r.Line != 11 || !strings.Contains(r.Error, "Double") {
+				t.Errorf("%s: rejected = %+v, want the int site at lang.go:11", tc.name, r)
+			}
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "lang.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, want := range tc.rewritten {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s: file lacks %q:\n%s", tc.name, want, data)
+			}
+		}
+	}
+}
+
+func TestASTRewrite_TypeCheckRejectsErrorsAwayFromSites(t *testing.T) {
+	t.Parallel()
+
+	// Replacing use(v) leaves v unused: the error is at its declaration, outside the replaced range.
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": `package lang
+
+func use(v int) int { return v }
+
+func f() int {
+	v := 2
+	return use(v)
+}
+`})
+
+	in := tools.ASTRewriteInput{Dir: dir, Find: "use(v)", Replace: "0", DryRun: true}
+
+	_, out, err := tools.ASTRewrite(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ASTRewrite: %v", err)
+	}
+
+	if out.TotalChanges != 0 || len(out.Rejected) != 1 || !strings.Contains(out.Rejected[0].Error, "not used") {
+		t.Errorf("expected the only replacement to be rejected for the unused variable, got %+v", out)
+	}
+}
+
+func TestRenameSymbol_SkipsGeneratedFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	if err := copyDir(testDir(), tmpDir); err != nil {
+		t.Fatalf("copyDir error: %v", err)
+	}
+
+	in := tools.RenameSymbolInput{Dir: tmpDir, OldName: "FormatGreeting", NewName: "RenderGreeting"}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if strings.Join(out.ChangedFiles, ",") != "greeting.go" {
+		t.Errorf("expected only greeting.go to change, got %v", out.ChangedFiles)
+	}
+
+	want := tools.GeneratedFile{File: "greeting.pb.go", Generator: "protoc-gen-go"}
+	if len(out.SkippedGenerated) != 1 || out.SkippedGenerated[0] != want {
+		t.Errorf("expected %+v to be skipped, got %+v", want, out.SkippedGenerated)
+	}
+
+	generated, err := os.ReadFile(filepath.Join(tmpDir, "greeting.pb.go"))
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+
+	if !strings.Contains(string(generated), "return FormatGreeting(x.GetName())") {
+		t.Errorf("expected the generated file to stay untouched:\n%s", generated)
+	}
+}
+
+func TestRenameSymbol_AllowGenerated(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	if err := copyDir(testDir(), tmpDir); err != nil {
+		t.Fatalf("copyDir error: %v", err)
+	}
+
+	in := tools.RenameSymbolInput{
+		Dir:            tmpDir,
+		OldName:        "FormatGreeting",
+		NewName:        "RenderGreeting",
+		DryRun:         true,
+		AllowGenerated: true,
+	}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if len(out.ChangedFiles) != 2 || len(out.SkippedGenerated) != 0 {
+		t.Errorf("expected both files to change, got %v (skipped %+v)", out.ChangedFiles, out.SkippedGenerated)
+	}
+}
+
+func TestRenameSymbol_PreviewOnly(t *testing.T) {
+	t.Parallel()
+
+	in := tools.RenameSymbolInput{Dir: testDir(), OldName: "Foo", NewName: "MyFoo", PreviewOnly: true}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if len(out.Diffs) != 0 || len(out.Collisions) != 0 || out.Impact == nil {
+		t.Fatalf("expected an impact summary without diffs or collisions, got %+v", out)
+	}
+
+	// foo.go declares Foo and two methods on *Foo, foo_usage.go takes a *Foo.
+	want := tools.RenameImpact{
+		Files:              2,
+		Packages:           1,
+		Occurrences:        4,
+		NonTestOccurrences: 4,
+		PerPackage:         []tools.RenamePackageImpact{{Package: "sample", Files: 2, References: 4}},
+	}
+	if !reflect.DeepEqual(*out.Impact, want) {
+		t.Errorf("impact = %+v, want %+v", *out.Impact, want)
+	}
+
+	if !containsAll(out.ChangedFiles, "foo.go", "foo_usage.go") {
+		t.Errorf("expected foo.go and foo_usage.go as changed files, got %v", out.ChangedFiles)
+	}
+}
+
+func TestRenameSymbol_PreviewOnlyGeneratedAndCollisions(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"lang.go":    "package lang\n\nfunc Format() string { return \"x\" }\n\nfunc Render() string { return Format() }\n",
+		"gen.go":     "// Code generated by stringer. DO NOT EDIT.\n\npackage lang\n\nvar generated = Format()\n",
+		"sub/sub.go": "package sub\n\nimport \"lang\"\n\nvar A, B = lang.Format(), lang.Format()\n",
+	})
+
+	in := tools.RenameSymbolInput{Dir: dir, OldName: "Format", NewName: "Render", PreviewOnly: true}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if len(out.Collisions) == 0 || out.Impact == nil {
+		t.Fatalf("expected collisions alongside the impact, got %+v", out)
+	}
+
+	impact := out.Impact
+	if impact.Files != 3 || impact.Packages != 2 || impact.Occurrences != 5 ||
+		impact.NonTestOccurrences != 4 || impact.GeneratedOccurrences != 1 || !impact.BlockedByGeneratedGuard {
+		t.Errorf("unexpected impact %+v", impact)
+	}
+
+	if len(impact.PerPackage) != 2 || impact.PerPackage[0].Package != "lang" || impact.PerPackage[0].References != 3 {
+		t.Errorf("expected lang first with 3 references, got %+v", impact.PerPackage)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "lang.go")); !strings.Contains(string(data), "func Format()") {
+		t.Error("previewOnly changed lang.go")
+	}
+}
+
+func TestRenameSymbol_RefusesGeneratedDeclaration(t *testing.T) {
+	t.Parallel()
+
+	in := tools.RenameSymbolInput{Dir: testDir(), OldName: "GreetingRequest", NewName: "HelloRequest", DryRun: true}
+
+	_, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Fatal("expected an error for a symbol declared in a generated file")
+	}
+
+	if te := tools.AsToolError(err); te.Code != tools.CodeGeneratedFile || !strings.Contains(te.Message, "protoc-gen-go") {
+		t.Errorf("expected GENERATED_FILE naming the generator, got %+v", te)
+	}
+}
+
+func TestASTRewrite_SkipsGeneratedFiles(t *testing.T) {
+	t.Parallel()
+
+	in := tools.ASTRewriteInput{
+		Dir:     testDir(),
+		Find:    "FormatGreeting(x.GetName())",
+		Replace: "FormatGreeting(x.Name)",
+		DryRun:  true,
+	}
+
+	_, out, err := tools.ASTRewrite(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("ASTRewrite error: %v", err)
+	}
+
+	if out.TotalChanges != 0 || len(out.ChangedFiles) != 0 {
+		t.Errorf("expected no changes outside generated files, got %+v", out)
+	}
+
+	if len(out.SkippedGenerated) != 1 || out.SkippedGenerated[0].File != "greeting.pb.go" {
+		t.Errorf("expected greeting.pb.go to be skipped, got %+v", out.SkippedGenerated)
+	}
+}
+
+const renameBatchSource = `package lang
+
+type Foo struct{ n int }
+
+func NewFoo() *Foo { return &Foo{} }
+
+type FooOption func(*Foo)
+
+func WithN(n int) FooOption { return func(f *Foo) { f.n = n } }
+
+func first() int { return 1 }
+
+func second() int { return first() + 1 }
+`
+
+func TestRenameSymbol_BatchInterdependentRenames(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.25", map[string]string{"foo.go": renameBatchSource})
+
+	in := tools.RenameSymbolInput{Dir: dir, DryRun: true, Renames: []tools.RenamePair{
+		{OldName: "Foo", NewName: "Bar"},
+		{OldName: "NewFoo", NewName: "NewBar"},
+		{OldName: "FooOption", NewName: "BarOption"},
+		// A swap only works when both renames are checked and applied together.
+		{OldName: "first", NewName: "second"},
+		{OldName: "second", NewName: "first"},
+	}}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol dry run error: %v", err)
+	}
+
+	if len(out.Collisions) > 0 || len(out.Diffs) != 1 || out.Diffs[0].Path != "foo.go" {
+		t.Fatalf("expected one combined diff for foo.go, got %+v", out)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "foo.go")); string(data) != renameBatchSource {
+		t.Fatal("dry run changed foo.go")
+	}
+
+	in.DryRun = false
+
+	if _, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in); err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "foo.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := string(data)
+	for _, want := range []string{
+		"type Bar struct",
+		"func NewBar() *Bar { return &Bar{} }",
+		"type BarOption func(*Bar)",
+		"func WithN(n int) BarOption { return func(f *Bar) { f.n = n } }",
+		"func second() int { return 1 }",
+		"func first() int { return second() + 1 }",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in renamed file:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "Foo") {
+		t.Errorf("expected no Foo left:\n%s", got)
+	}
+}
+
+func TestRenameSymbol_BatchCollisions(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.25", map[string]string{"foo.go": renameBatchSource})
+
+	in := tools.RenameSymbolInput{Dir: dir, Renames: []tools.RenamePair{
+		{OldName: "Foo", NewName: "Baz"},
+		{OldName: "FooOption", NewName: "Baz"},
+		{OldName: "NewFoo", NewName: "WithN"},
+	}}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if len(out.Collisions) != 2 || len(out.ChangedFiles) != 0 {
+		t.Fatalf("expected two collisions and no changes, got %+v", out)
+	}
+
+	if !strings.Contains(out.Collisions[0], `"Foo" and "FooOption" both to "Baz"`) ||
+		!strings.Contains(out.Collisions[1], `"WithN" is already declared`) {
+		t.Errorf("unexpected collisions: %q", out.Collisions)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "foo.go")); string(data) != renameBatchSource {
+		t.Fatal("a rename with collisions changed foo.go")
+	}
+}
+
+func TestRenameSymbol_LocalShadowingCollision(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.25", map[string]string{"calc.go": `package lang
+
+func limit() int { return 10 }
+
+func clamp(v int) int {
+	maxValue := 5
+	if v > limit() {
+		return maxValue
+	}
+
+	return v
+}
+`})
+
+	in := tools.RenameSymbolInput{Dir: dir, OldName: "limit", NewName: "maxValue", DryRun: true}
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if len(out.Collisions) != 1 || !strings.Contains(out.Collisions[0], "calc.go:7") {
+		t.Fatalf("expected the call inside clamp to be reported as shadowed, got %+v", out)
+	}
+}
+
+func TestRenameSymbol_KindSelectsAmongSameNames(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"lang.go": `package lang
+
+var config = 1
+
+func read() int { return config }
+`,
+		"store/store.go": `package store
+
+type config struct{ n int }
+
+func load() int { return config{n: 2}.n }
+`,
+	}
+
+	cases := []struct {
+		kind string
+		file string
+		want string
+	}{
+		{"var", "lang.go", "var settings = 1"},
+		{"type", "store/store.go", "type settings struct{ n int }"},
+	}
+
+	for _, tc := range cases {
+		dir := writeLanguageModule(t, "1.22", files)
+
+		in := tools.RenameSymbolInput{Dir: dir, OldName: "config", NewName: "settings", Kind: tc.kind}
+		if _, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in); err != nil {
+			t.Fatalf("RenameSymbol(kind %s): %v", tc.kind, err)
+		}
+
+		for name := range files {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, renamed := string(data), name == tc.file
+			if renamed && (!strings.Contains(got, tc.want) || strings.Count(got, "settings") != 2) ||
+				!renamed && strings.Contains(got, "settings") {
+				t.Errorf("kind %s renamed %s:\n%s", tc.kind, name, got)
+			}
+		}
+	}
+
+	dir := writeLanguageModule(t, "1.22", files)
+
+	_, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, tools.RenameSymbolInput{Dir: dir, OldName: "config", NewName: "settings"})
+
+	te := tools.AsToolError(err)
+	if te == nil || te.Code != tools.CodeAmbiguous || te.Details == nil {
+		t.Fatalf("expected AMBIGUOUS without kind, got %v", err)
+	}
+
+	want := []string{"type lang/store.config (store/store.go:3)", "var lang.config (lang.go:3)"}
+	if !reflect.DeepEqual(te.Details.Candidates, want) {
+		t.Errorf("candidates = %q, want %q", te.Details.Candidates, want)
+	}
+}
+
+func TestRenameSymbol_FieldsAndParamsDoNotMakeNameAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": `package lang
+
+type Config struct{ n int }
+
+type App struct {
+	Config Config
+}
+
+var limit = 1
+
+func clamp(limit int) int { return limit }
+`})
+
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	// The field Config and the parameter limit are not package-level, so the kind is not needed.
+	for _, pair := range []tools.RenamePair{{OldName: "Config", NewName: "Settings"}, {OldName: "limit", NewName: "maxItems"}} {
+		in := tools.RenameSymbolInput{Dir: dir, OldName: pair.OldName, NewName: pair.NewName}
+		if _, _, err := tools.RenameSymbol(ctx, req, in); err != nil {
+			t.Fatalf("RenameSymbol(%s): %v", pair.OldName, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "lang.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"type Settings struct", "var maxItems = 1"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("lang.go lacks %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestRenameSymbol_BatchExcludesSinglePair(t *testing.T) {
+	t.Parallel()
+
+	in := tools.RenameSymbolInput{
+		Dir:     testDir(),
+		OldName: "Foo",
+		Renames: []tools.RenamePair{{OldName: "Bar", NewName: "Baz"}},
+	}
+
+	_, _, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, in)
+	if te := tools.AsToolError(err); te.Code != tools.CodeInvalidInput {
+		t.Fatalf("expected INVALID_INPUT, got %v", err)
+	}
+}
+
+func TestRenameSymbol_ConcurrentWithReaders(t *testing.T) {
+	ctx := context.Background()
+	dir := copySample(t)
+
+	if _, _, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir}); err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	stop := make(chan struct{})
+	errs := make(chan error, 4)
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				if _, _, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir}); err != nil {
+					errs <- err
+
+					return
+				}
+			}
+		}()
+	}
+
+	renames := [][2]string{{"FormatGreeting", "FormatWelcome"}, {"FormatWelcome", "FormatGreeting"}, {"FormatGreeting", "FormatHello"}}
+
+	// The generated greeting.pb.go calls FormatGreeting: renamed along, package sample stays free of type
+	// errors, which would block the next rename of a symbol it declares.
+	for _, r := range renames {
+		in := tools.RenameSymbolInput{Dir: dir, OldName: r[0], NewName: r[1], AllowGenerated: true}
+
+		_, out, err := tools.RenameSymbol(ctx, &mcp.CallToolRequest{}, in)
+		if err != nil || len(out.Collisions) > 0 || len(out.ChangedFiles) == 0 {
+			close(stop)
+			t.Fatalf("RenameSymbol %s -> %s: err %v, output %+v", r[0], r[1], err, out)
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent ListSymbols error: %v", err)
+	}
+
+	// Writes invalidate the caches synchronously, so the next read sees the final name.
+	_, symbols, err := tools.ListSymbols(ctx, &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	var names []string
+
+	for _, group := range symbols.GroupedSymbols {
+		for _, file := range group.Files {
+			for _, sym := range file.Symbols {
+				names = append(names, sym.Name)
+			}
+		}
+	}
+
+	if !slices.Contains(names, "FormatHello") || slices.Contains(names, "FormatGreeting") {
+		t.Fatalf("expected the renamed symbol in a fresh listing, got %v", names)
+	}
+}
+
+func TestRenameSymbol_PartialWithBrokenPackage(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"core/core.go":     "package core\n\nfunc Greet() string { return \"hi\" }\n",
+		"app/app.go":       "package app\n\nimport \"lang/core\"\n\nfunc Run() string { return core.Greet() }\n",
+		"other/other.go":   "package other\n\nfunc Idle() {}\n",
+		"broken/use.go":    "package broken\n\nimport \"lang/core\"\n\nfunc Use() string { return core.Greet() }\n",
+		"broken/broken.go": "package broken\n\nfunc Broken( {\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+	in := tools.RenameSymbolInput{Dir: dir, OldName: "Greet", NewName: "Hello"}
+
+	_, _, err := tools.RenameSymbol(ctx, req, in)
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeTypeErrorsPresent || !strings.Contains(te.Message, "lang/broken") {
+		t.Fatalf("expected TYPE_ERRORS_PRESENT naming lang/broken, got %v", err)
+	}
+
+	if src, _ := os.ReadFile(filepath.Join(dir, "core", "core.go")); !strings.Contains(string(src), "func Greet()") {
+		t.Fatalf("a refused rename wrote files:\n%s", src)
+	}
+
+	in.DryRun = true
+
+	_, out, err := tools.RenameSymbol(ctx, req, in)
+	if err != nil || !out.Partial {
+		t.Fatalf("dry run: %+v, %v; want a partial result", out, err)
+	}
+
+	var statuses []string
+	for _, p := range out.Packages {
+		statuses = append(statuses, p.Package+"="+p.Status+fmt.Sprint(p.Files))
+	}
+
+	want := "lang/app=modified[app/app.go] lang/broken=skipped[] lang/core=modified[core/core.go] lang/other=clean[]"
+	if got := strings.Join(statuses, " "); got != want {
+		t.Errorf("statuses = %s, want %s", got, want)
+	}
+
+	if broken := out.Packages[1]; broken.Error == nil || broken.Error.File != "broken/broken.go" {
+		t.Errorf("skipped package error = %+v, want the syntax error of broken/broken.go", broken.Error)
+	}
+
+	in.DryRun, in.AllowPartial = false, true
+
+	_, out, err = tools.RenameSymbol(ctx, req, in)
+	if err != nil || !out.Partial || len(out.ChangedFiles) != 2 {
+		t.Fatalf("partial rename: %+v, %v", out, err)
+	}
+
+	if src, _ := os.ReadFile(filepath.Join(dir, "broken", "use.go")); !strings.Contains(string(src), "core.Greet()") {
+		t.Errorf("the skipped package was modified:\n%s", src)
+	}
+
+	_, _, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, OldName: "Use", NewName: "Apply", AllowPartial: true})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeTypeErrorsPresent {
+		t.Errorf("expected TYPE_ERRORS_PRESENT renaming a symbol of the broken package, got %v", err)
+	}
+}
@@ -0,0 +1,573 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Statuses of a release report and its sections, from best to worst; skip sections have no status.
+const (
+	releaseSkip = "skip"
+	releasePass = "pass"
+	releaseWarn = "warn"
+	releaseFail = "fail"
+)
+
+// Sections of a release report, in report order.
+const (
+	releaseSectionAPIDiff      = "api-diff"
+	releaseSectionUndocumented = "undocumented"
+	releaseSectionDeprecated   = "deprecated-usage"
+	releaseSectionTodos        = "todos"
+	releaseSectionDeadInternal = "dead-internal-exports"
+	releaseSectionCycles       = "dependency-cycles"
+)
+
+// Changes of an api-diff finding.
+const (
+	apiAdded   = "added"
+	apiRemoved = "removed"
+)
+
+// todoMarker matches a comment line starting with a TODO or FIXME marker.
+var todoMarker = regexp.MustCompile(`^\s*(TODO|FIXME)\b`)
+
+// ReleaseReport runs the static release checks over the module containing dir and returns one section per
+// check with a pass, warn or fail status: the public API added and removed since a baseline exportIndex
+// artifact, undocumented public symbols, deprecated symbols the module still uses, TODO and FIXME
+// comments, unused exported symbols of internal packages and import cycles. The public API is the exported
+// symbols of the importable, non-internal packages outside test files.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and the optional baseline artifact
+//
+// Returns:
+//   - MCP tool call result
+//   - the overall status and the sections with their findings
+//   - error if the baseline cannot be read or has no symbols, or an error occurred while loading packages
+func ReleaseReport(ctx context.Context, _ *mcp.CallToolRequest, input ReleaseReportInput) (
+	*mcp.CallToolResult,
+	ReleaseReportOutput,
+	error,
+) {
+	start := logStart("ReleaseReport", logFields(
+		input.Dir,
+		newLogField("baselineIndexFile", input.BaselineIndexFile),
+	))
+	out := ReleaseReportOutput{Sections: []ReleaseSection{}}
+
+	defer func() { logEnd("ReleaseReport", start, len(out.Sections)) }()
+
+	root := findModuleRoot(input.Dir)
+	if root == "" {
+		return nil, out, notFound(nil, "no go.mod found for %q", input.Dir)
+	}
+
+	out.Module, _ = readGoModInfo(root)
+
+	apiDiff, err := releaseAPIDiff(ctx, input, root, out.Module)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	pkgs, _, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, "", "ReleaseReport")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	undocumented, todos, err := releaseSyntaxSections(ctx, pkgs, input.Dir)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	_, dead, err := DeadCode(ctx, nil, DeadCodeInput{Dir: input.Dir})
+	if err != nil {
+		return fail(out, err)
+	}
+
+	_, deps, err := AnalyzeDependencies(ctx, nil, AnalyzeDependenciesInput{Dir: input.Dir})
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.Sections = append(out.Sections,
+		apiDiff,
+		undocumented,
+		releaseDeprecatedUsage(pkgs, input.Dir),
+		todos,
+		releaseDeadInternal(dead),
+		releaseCycles(deps),
+	)
+
+	out.Status = releasePass
+
+	for _, section := range out.Sections {
+		sortReleaseFindings(section.Findings)
+
+		if releaseRank(section.Status) > releaseRank(out.Status) {
+			out.Status = section.Status
+		}
+	}
+
+	return nil, out, nil
+}
+
+// releaseRank orders statuses from best to worst.
+func releaseRank(status string) int {
+	switch status {
+	case releasePass:
+		return 1
+	case releaseWarn:
+		return 2
+	case releaseFail:
+		return 3
+	}
+
+	return 0
+}
+
+// newReleaseSection returns a section of findings with the status pass when it has none and bad otherwise.
+func newReleaseSection(name string, findings []ReleaseFinding, bad, summary string) ReleaseSection {
+	if findings == nil {
+		findings = []ReleaseFinding{}
+	}
+
+	status := releasePass
+	if len(findings) > 0 {
+		status = bad
+	}
+
+	return ReleaseSection{Name: name, Status: status, Count: len(findings), Summary: summary, Findings: findings}
+}
+
+// sortReleaseFindings orders findings by file, line and symbol.
+func sortReleaseFindings(findings []ReleaseFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Symbol < b.Symbol
+	})
+}
+
+// releaseAPIDiff compares the public API of the module with that of the baseline artifact: removed symbols
+// fail the section, added ones only warn. Without a baseline the section is skipped.
+func releaseAPIDiff(ctx context.Context, input ReleaseReportInput, root, module string) (ReleaseSection, error) {
+	if input.BaselineIndexFile == "" {
+		return ReleaseSection{
+			Name: releaseSectionAPIDiff, Status: releaseSkip, Findings: []ReleaseFinding{},
+			Summary: "no baselineIndexFile given; export one with exportIndex at the previous release",
+		}, nil
+	}
+
+	baselineFile := input.BaselineIndexFile
+	if !filepath.IsAbs(baselineFile) {
+		baselineFile = filepath.Join(input.Dir, baselineFile)
+	}
+
+	artifact, err := readIndexArtifact(baselineFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ReleaseSection{}, notFound(nil, "baseline index %q not found", input.BaselineIndexFile)
+		}
+
+		return ReleaseSection{}, err
+	}
+
+	if !slices.Contains(artifact.Sections, indexSectionSymbols) {
+		return ReleaseSection{}, invalidInput("baseline index %q has no symbols section; export it with include [\"symbols\"]", input.BaselineIndexFile)
+	}
+
+	before := make(map[string]ReleaseFinding)
+	for _, file := range artifact.Files {
+		if file.Facts != nil {
+			publicAPI(before, module, file.Path, filepath.Join(root, filepath.FromSlash(file.Path)), input.Dir, file.Facts)
+		}
+	}
+
+	after := make(map[string]ReleaseFinding)
+
+	if err := walkModuleGoFiles(ctx, root, false, func(p string) error {
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		facts, err := computeFileFacts(p, content)
+		if err != nil {
+			return err
+		}
+
+		publicAPI(after, module, relativePath(root, p), p, input.Dir, facts)
+
+		return nil
+	}); err != nil {
+		return ReleaseSection{}, err
+	}
+
+	var findings []ReleaseFinding
+
+	added, removed := 0, 0
+
+	for key, f := range after {
+		if _, ok := before[key]; !ok {
+			f.Change, f.Message = apiAdded, "new "+f.Kind+" "+f.Symbol
+			findings = append(findings, f)
+			added++
+		}
+	}
+
+	for key, f := range before {
+		if _, ok := after[key]; !ok {
+			f.Change, f.Message = apiRemoved, f.Kind+" "+f.Symbol+" was removed, breaking importers"
+			findings = append(findings, f)
+			removed++
+		}
+	}
+
+	bad := releaseWarn
+	if removed > 0 {
+		bad = releaseFail
+	}
+
+	return newReleaseSection(releaseSectionAPIDiff, findings, bad,
+		fmt.Sprintf("%d public %s added, %d removed since %s", added, plural(added, "symbol"), removed, artifact.Created)), nil
+}
+
+// publicAPI adds the public symbols of a module file to api, keyed by qualified name. rel is the path of
+// the file relative to the module root, abs its absolute path and dir the directory findings are made
+// relative to.
+func publicAPI(api map[string]ReleaseFinding, module, rel, abs, dir string, facts *fileFacts) {
+	rel = filepath.ToSlash(rel)
+	pkgDir := path.Dir(rel)
+
+	importPath := module
+	if pkgDir != "." {
+		importPath = module + "/" + pkgDir
+	}
+
+	if strings.HasSuffix(rel, "_test.go") || facts.Package == "main" || isInternalPackagePath(importPath) {
+		return
+	}
+
+	for _, sym := range facts.Symbols {
+		// Syntax IDs are "<package name>.[<owner>.]<name>#<kind>"; locals have none.
+		id, _, _ := strings.Cut(sym.SymbolID, "#")
+		_, name, ok := strings.Cut(id, ".")
+
+		if !ok || !sym.Exported || !exportedPath(name) {
+			continue
+		}
+
+		kind := sym.Kind
+		if strings.Contains(name, ".") {
+			kind = "method"
+		}
+
+		api[importPath+"."+name] = ReleaseFinding{
+			File:   relativePath(dir, abs),
+			Line:   sym.Line,
+			Symbol: importPath + "." + name,
+			Kind:   kind,
+		}
+	}
+}
+
+// exportedPath reports whether every element of a dotted name such as "Widget.Close" is exported.
+func exportedPath(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if !token.IsExported(part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// releaseSyntaxSections returns the undocumented and todos sections, scanning the non-generated files of
+// pkgs.
+func releaseSyntaxSections(ctx context.Context, pkgs []*packages.Package, dir string) (ReleaseSection, ReleaseSection, error) {
+	var undocumented, todos []ReleaseFinding
+
+	fixmes := 0
+
+	if err := walkPackageFiles(ctx, pkgs, dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if ast.IsGenerated(file) {
+			return nil
+		}
+
+		if pkg.Name != "main" && !isInternalPackagePath(pkg.PkgPath) {
+			for _, decl := range file.Decls {
+				for _, sym := range undocumentedDecls(decl) {
+					undocumented = append(undocumented, ReleaseFinding{
+						File:    relPath,
+						Line:    pkg.Fset.Position(sym.pos).Line,
+						Symbol:  pkg.PkgPath + "." + sym.name,
+						Kind:    sym.kind,
+						Message: "exported " + sym.kind + " " + sym.name + " has no doc comment",
+					})
+				}
+			}
+		}
+
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				text := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(c.Text, "//"), "/*"), "*/")
+				line := pkg.Fset.Position(c.Pos()).Line
+
+				for i, l := range strings.Split(text, "\n") {
+					m := todoMarker.FindStringSubmatch(l)
+					if m == nil {
+						continue
+					}
+
+					if m[1] == "FIXME" {
+						fixmes++
+					}
+
+					todos = append(todos, ReleaseFinding{File: relPath, Line: line + i, Kind: m[1], Message: strings.TrimSpace(l)})
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return ReleaseSection{}, ReleaseSection{}, err
+	}
+
+	undocumentedSection := newReleaseSection(releaseSectionUndocumented, undocumented, releaseWarn,
+		fmt.Sprintf("%d public %s without a doc comment", len(undocumented), plural(len(undocumented), "symbol")))
+
+	// TODOs are tracked debt; a FIXME marks something known to be broken.
+	todosSection := newReleaseSection(releaseSectionTodos, todos, releasePass,
+		fmt.Sprintf("%d %s, %d %s", len(todos)-fixmes, plural(len(todos)-fixmes, "TODO"), fixmes, plural(fixmes, "FIXME")))
+	if fixmes > 0 {
+		todosSection.Status = releaseWarn
+	}
+
+	return undocumentedSection, todosSection, nil
+}
+
+// undocumentedDecl is an exported declaration without a doc comment.
+type undocumentedDecl struct {
+	name, kind string
+	pos        token.Pos
+}
+
+// undocumentedDecls returns the exported names declared by decl that have no doc comment. Methods count
+// when their receiver type is exported; a doc comment on a grouped declaration covers all its specs.
+func undocumentedDecls(decl ast.Decl) []undocumentedDecl {
+	var decls []undocumentedDecl
+
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		name, kind := d.Name.Name, "func"
+		if recv := receiverName(d); recv != "" {
+			name, kind = recv+"."+name, "method"
+		}
+
+		if d.Doc == nil && exportedPath(name) {
+			decls = append(decls, undocumentedDecl{name: name, kind: kind, pos: d.Name.Pos()})
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			return nil
+		}
+
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if s.Doc == nil && s.Name.IsExported() {
+					decls = append(decls, undocumentedDecl{name: s.Name.Name, kind: "type", pos: s.Name.Pos()})
+				}
+			case *ast.ValueSpec:
+				if s.Doc != nil {
+					continue
+				}
+
+				for _, id := range s.Names {
+					if id.IsExported() {
+						decls = append(decls, undocumentedDecl{name: id.Name, kind: strings.ToLower(d.Tok.String()), pos: id.Pos()})
+					}
+				}
+			}
+		}
+	}
+
+	return decls
+}
+
+// deprecatedDoc reports whether a doc comment has a "Deprecated:" paragraph.
+func deprecatedDoc(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if strings.HasPrefix(line, "Deprecated: ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// releaseDeprecatedUsage reports every use of a deprecated package-level symbol or method of the module
+// outside deprecated declarations, the methods of deprecated types included.
+func releaseDeprecatedUsage(pkgs []*packages.Package, dir string) ReleaseSection {
+	deprecated := make(map[string]string) // symbol ID -> name
+	exempt := make(map[*token.File][]ast.Node)
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+
+		deprecatedTypes := make(map[string]bool)
+		mark := func(file *ast.File, node ast.Node, ident *ast.Ident) {
+			if obj := pkg.TypesInfo.Defs[ident]; obj != nil {
+				deprecated[symbolID(pkg.TypesInfo, obj)] = pkg.PkgPath + "." + ident.Name
+				tf := pkg.Fset.File(file.Pos())
+				exempt[tf] = append(exempt[tf], node)
+			}
+		}
+
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+
+				for _, spec := range gd.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						if deprecatedDoc(s.Doc) || (len(gd.Specs) == 1 && deprecatedDoc(gd.Doc)) {
+							deprecatedTypes[s.Name.Name] = true
+							mark(file, s, s.Name)
+						}
+					case *ast.ValueSpec:
+						if deprecatedDoc(s.Doc) || (len(gd.Specs) == 1 && deprecatedDoc(gd.Doc)) {
+							for _, id := range s.Names {
+								mark(file, s, id)
+							}
+						}
+					}
+				}
+			}
+		}
+
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fd, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+
+				if deprecatedDoc(fd.Doc) {
+					mark(file, fd, fd.Name)
+				} else if recv := receiverName(fd); recv != "" && deprecatedTypes[recv] {
+					tf := pkg.Fset.File(file.Pos())
+					exempt[tf] = append(exempt[tf], fd)
+				}
+			}
+		}
+	}
+
+	var findings []ReleaseFinding
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil || len(deprecated) == 0 {
+			continue
+		}
+
+		for ident, obj := range pkg.TypesInfo.Uses {
+			if fn, ok := obj.(*types.Func); ok {
+				obj = fn.Origin()
+			}
+
+			name, ok := deprecated[symbolID(pkg.TypesInfo, obj)]
+			if !ok || withinAny(exempt[pkg.Fset.File(ident.Pos())], ident.Pos()) {
+				continue
+			}
+
+			pos := pkg.Fset.Position(ident.Pos())
+			findings = append(findings, ReleaseFinding{
+				File:    relativePath(dir, pos.Filename),
+				Line:    pos.Line,
+				Symbol:  name,
+				Kind:    objStringKind(obj),
+				Message: "uses deprecated " + name,
+			})
+		}
+	}
+
+	return newReleaseSection(releaseSectionDeprecated, findings, releaseWarn,
+		fmt.Sprintf("%d %s of deprecated symbols inside the module", len(findings), plural(len(findings), "use")))
+}
+
+// withinAny reports whether pos lies inside one of nodes.
+func withinAny(nodes []ast.Node, pos token.Pos) bool {
+	for _, n := range nodes {
+		if pos >= n.Pos() && pos < n.End() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// releaseDeadInternal reports the exported symbols of internal packages nothing in the module uses.
+func releaseDeadInternal(dead DeadCodeOutput) ReleaseSection {
+	var findings []ReleaseFinding
+
+	for _, sym := range dead.Unused {
+		if !sym.InternalExported {
+			continue
+		}
+
+		findings = append(findings, ReleaseFinding{
+			File:    sym.File,
+			Line:    sym.Line,
+			Symbol:  sym.Package + "." + sym.Name,
+			Kind:    sym.Kind,
+			Message: "exported from an internal package but unused in the module",
+		})
+	}
+
+	return newReleaseSection(releaseSectionDeadInternal, findings, releaseWarn,
+		fmt.Sprintf("%d unused exported %s in internal packages", len(findings), plural(len(findings), "symbol")))
+}
+
+// releaseCycles reports the import cycles between the packages of the module, which fail the release.
+func releaseCycles(deps AnalyzeDependenciesOutput) ReleaseSection {
+	var findings []ReleaseFinding
+
+	for _, cycle := range deps.Cycles {
+		findings = append(findings, ReleaseFinding{Symbol: cycle[0], Message: strings.Join(append(cycle, cycle[0]), " -> ")})
+	}
+
+	return newReleaseSection(releaseSectionCycles, findings, releaseFail,
+		fmt.Sprintf("%d import %s", len(findings), plural(len(findings), "cycle")))
+}
@@ -0,0 +1,133 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const releaseBaseline = `package lang
+
+// Old is removed before the release.
+func Old() {}
+
+// Keep stays.
+func Keep() {}
+
+type hidden struct{}
+
+// Close is not public: its receiver is unexported.
+func (hidden) Close() {}
+`
+
+const releaseCurrent = `package lang
+
+// Keep stays.
+func Keep() { Legacy() }
+
+func New() {}
+
+// Widget is new.
+type Widget struct{}
+
+func (Widget) Close() {}
+
+// Legacy is kept for old callers.
+//
+// Deprecated: use Keep.
+func Legacy() {}
+
+// TODO: add more widgets.
+// FIXME(io): Close leaks.
+`
+
+func TestReleaseReport(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"a.go":            releaseBaseline,
+		"internal/x/x.go": "package x\n\n// Helper is unused.\nfunc Helper() {}\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	if _, _, err := tools.ExportIndex(ctx, req, tools.ExportIndexInput{Dir: dir, OutFile: "base.json", Include: []string{"symbols"}}); err != nil {
+		t.Fatalf("ExportIndex: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(releaseCurrent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, out, err := tools.ReleaseReport(ctx, req, tools.ReleaseReportInput{Dir: dir, BaselineIndexFile: "base.json"})
+	if err != nil {
+		t.Fatalf("ReleaseReport: %v", err)
+	}
+
+	if out.Module != "lang" || out.Status != "fail" || len(out.Sections) != 6 {
+		t.Fatalf("out = %+v, want a failing report with 6 sections", out)
+	}
+
+	sections := make(map[string]tools.ReleaseSection)
+	for _, s := range out.Sections {
+		sections[s.Name] = s
+	}
+
+	api := sections["api-diff"]
+	if api.Status != "fail" || api.Count != 5 {
+		t.Fatalf("api-diff = %+v, want 4 added and 1 removed", api)
+	}
+
+	gotAPI := make([]string, 0, len(api.Findings))
+	for _, f := range api.Findings {
+		gotAPI = append(gotAPI, fmt.Sprintf("%s %s %s:%d", f.Change, f.Symbol, f.File, f.Line))
+	}
+
+	wantDiff := []string{
+		"removed lang.Old a.go:4",
+		"added lang.New a.go:6",
+		"added lang.Widget a.go:9",
+		"added lang.Widget.Close a.go:11",
+		"added lang.Legacy a.go:16",
+	}
+
+	if fmt.Sprint(gotAPI) != fmt.Sprint(wantDiff) {
+		t.Errorf("api-diff findings = %v, want %v", gotAPI, wantDiff)
+	}
+
+	undocumented := sections["undocumented"]
+	if undocumented.Status != "warn" || undocumented.Count != 2 ||
+		undocumented.Findings[0].Symbol != "lang.New" || undocumented.Findings[1].Symbol != "lang.Widget.Close" {
+		t.Errorf("undocumented = %+v, want New and Widget.Close", undocumented)
+	}
+
+	if s := sections["deprecated-usage"]; s.Status != "warn" || s.Count != 1 || s.Findings[0].Line != 4 {
+		t.Errorf("deprecated-usage = %+v, want the call in Keep", s)
+	}
+
+	if s := sections["todos"]; s.Status != "warn" || s.Count != 2 || s.Findings[1].Kind != "FIXME" {
+		t.Errorf("todos = %+v, want a TODO and a FIXME", s)
+	}
+
+	if s := sections["dead-internal-exports"]; s.Status != "warn" || s.Count != 1 || s.Findings[0].Symbol != "lang/internal/x.Helper" {
+		t.Errorf("dead-internal-exports = %+v, want Helper", s)
+	}
+
+	if s := sections["dependency-cycles"]; s.Status != "pass" {
+		t.Errorf("dependency-cycles = %+v, want pass", s)
+	}
+
+	_, out, err = tools.ReleaseReport(ctx, req, tools.ReleaseReportInput{Dir: dir})
+	if err != nil || out.Sections[0].Status != "skip" {
+		t.Errorf("without a baseline: %+v, %v", out.Sections, err)
+	}
+
+	_, _, err = tools.ReleaseReport(ctx, req, tools.ReleaseReportInput{Dir: dir, BaselineIndexFile: "a.go"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a baseline that is no artifact, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// companionPrefixes are the prefixes of the test functions tied to a declaration by their name alone.
+var companionPrefixes = []string{"Example", "Benchmark", "Fuzz"}
+
+// renameCompanion is a test function named after a renamed declaration, with its position for the edit.
+type renameCompanion struct {
+	RenameCompanion
+
+	pkg    *packages.Package
+	path   string
+	offset int
+}
+
+// findRenameCompanions returns the Example, Benchmark and Fuzz functions in the _test.go files next to the
+// renamed declarations whose names follow one of them (ExampleF, BenchmarkT_M_suffix, ...), with the names
+// the renames give them. The rename load leaves test files out, so they are parsed here. A companion that
+// cannot follow its declaration carries the reason in Reason.
+func findRenameCompanions(dir string, pkgs []*packages.Package, renames []*renameRequest) []renameCompanion {
+	var companions []renameCompanion
+
+	for _, pkg := range pkgs {
+		if isTestVariant(pkg) || len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		ids := exampleIDs(pkg)
+
+		newIDs := companionIDRenames(pkg, ids, renames)
+		if len(newIDs) == 0 {
+			continue
+		}
+
+		testFiles, err := filepath.Glob(filepath.Join(filepath.Dir(pkg.GoFiles[0]), "*_test.go"))
+		if err != nil {
+			continue
+		}
+
+		companions = append(companions, packageCompanions(dir, pkg, testFiles, ids, newIDs)...)
+	}
+
+	sort.Slice(companions, func(i, j int) bool {
+		if companions[i].File != companions[j].File {
+			return companions[i].File < companions[j].File
+		}
+
+		return companions[i].Line < companions[j].Line
+	})
+
+	return companions
+}
+
+// companionIDRenames maps the example identifiers of pkg ("F", "T", "T_M") that the renames change to
+// their new form. Renaming a type changes the identifiers of its methods too.
+func companionIDRenames(pkg *packages.Package, ids map[string]struct{}, renames []*renameRequest) map[string]string {
+	top := make(map[string]string)
+	methods := make(map[string]string)
+
+	for _, r := range renames {
+		if r.target.Pkg() != pkg.Types {
+			continue
+		}
+
+		switch obj := r.target.(type) {
+		case *types.Func:
+			if obj.Signature().Recv() == nil {
+				top[obj.Name()] = r.NewName
+			} else if named := receiverNamed(obj); named != nil {
+				methods[named.Obj().Name()+"_"+obj.Name()] = r.NewName
+			}
+		case *types.TypeName:
+			if obj.Parent() == pkg.Types.Scope() {
+				top[obj.Name()] = r.NewName
+			}
+		}
+	}
+
+	newIDs := make(map[string]string)
+
+	for id := range ids {
+		newID := id
+
+		if typ, method, ok := strings.Cut(id, "_"); ok {
+			if renamed, ok := top[typ]; ok {
+				typ = renamed
+			}
+
+			if renamed, ok := methods[id]; ok {
+				method = renamed
+			}
+
+			newID = typ + "_" + method
+		} else if renamed, ok := top[id]; ok {
+			newID = renamed
+		}
+
+		if newID != id {
+			newIDs[id] = newID
+		}
+	}
+
+	return newIDs
+}
+
+// packageCompanions returns the companions of the renamed identifiers in the test files of pkg.
+func packageCompanions(dir string, pkg *packages.Package, testFiles []string, ids map[string]struct{}, newIDs map[string]string) []renameCompanion {
+	var companions []renameCompanion
+
+	// declared holds the function names of each test package, to refuse a rename onto one of them.
+	declared := make(map[string]map[string]struct{})
+
+	for _, path := range testFiles {
+		fset := token.NewFileSet()
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil || (file.Name.Name != pkg.Name && file.Name.Name != pkg.Name+"_test") {
+			continue
+		}
+
+		if declared[file.Name.Name] == nil {
+			declared[file.Name.Name] = make(map[string]struct{})
+		}
+
+		_, generated := generatedFileGenerator(file)
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil {
+				continue
+			}
+
+			declared[file.Name.Name][fd.Name.Name] = struct{}{}
+
+			for _, prefix := range companionPrefixes {
+				id, ok := namedTestID(fd.Name.Name, prefix, ids)
+				if !ok || newIDs[id] == "" {
+					continue
+				}
+
+				pos := fset.Position(fd.Name.Pos())
+				c := renameCompanion{
+					RenameCompanion: RenameCompanion{
+						File:    relativePath(dir, path),
+						Line:    pos.Line,
+						Name:    fd.Name.Name,
+						NewName: prefix + newIDs[id] + strings.TrimPrefix(fd.Name.Name, prefix+id),
+						Package: file.Name.Name,
+					},
+					pkg:    pkg,
+					path:   path,
+					offset: pos.Offset,
+				}
+
+				switch {
+				case !exportedID(newIDs[id]):
+					c.Reason = "the new name is unexported, so no " + prefix + " function can be named after it"
+				case generated:
+					c.Reason = "generated file"
+				}
+
+				companions = append(companions, c)
+
+				break
+			}
+		}
+	}
+
+	renamedAway := make(map[string]struct{}, len(companions))
+	for _, c := range companions {
+		renamedAway[c.Package+"."+c.Name] = struct{}{}
+	}
+
+	for i, c := range companions {
+		_, taken := declared[c.Package][c.NewName]
+		if _, away := renamedAway[c.Package+"."+c.NewName]; taken && !away && c.Reason == "" {
+			companions[i].Reason = c.NewName + " is already declared in package " + c.Package
+		}
+	}
+
+	return companions
+}
+
+// exportedID reports whether every part of an example identifier ("F", "T_M") is exported, as an
+// identifier must be to follow a test function prefix.
+func exportedID(id string) bool {
+	for _, part := range strings.Split(id, "_") {
+		if !ast.IsExported(part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// companionOffsets groups the companions to rename by file, as renameInFile takes them.
+func companionOffsets(companions []renameCompanion) map[string]map[int]*renameRequest {
+	byFile := make(map[string]map[int]*renameRequest)
+
+	for _, c := range companions {
+		if byFile[c.path] == nil {
+			byFile[c.path] = make(map[int]*renameRequest)
+		}
+
+		byFile[c.path][c.offset] = &renameRequest{RenamePair: RenamePair{OldName: c.Name, NewName: c.NewName}, match: c.Name}
+	}
+
+	return byFile
+}
@@ -0,0 +1,132 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestRenameSymbolCompanions(t *testing.T) {
+	t.Parallel()
+
+	testFile := `package store
+
+import "testing"
+
+func ExampleStore() {}
+
+func ExampleStore_Save_retry() {}
+
+func BenchmarkStore_Save(b *testing.B) {}
+
+func ExampleOpen() {}
+
+func ExampleClose() {}
+
+func BenchmarkOpenAll(b *testing.B) {}
+`
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"store/store.go": `package store
+
+type Store struct{}
+
+func (s *Store) Save() error { return nil }
+
+func Open() *Store { return &Store{} }
+
+func OpenAll() []*Store { return nil }
+`,
+		"store/store_test.go": testFile,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	companions := func(cs []tools.RenameCompanion) string {
+		var names []string
+		for _, c := range cs {
+			names = append(names, fmt.Sprintf("%s:%d %s->%s %s", c.File, c.Line, c.Name, c.NewName, c.Reason))
+		}
+
+		return strings.Join(names, "\n")
+	}
+
+	// A method rename carries its example, with the _suffix form, and its benchmark along.
+	_, out, err := tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, OldName: "Store.Save", NewName: "Persist", DryRun: true})
+	if err != nil {
+		t.Fatalf("RenameSymbol: %v", err)
+	}
+
+	want := "store/store_test.go:7 ExampleStore_Save_retry->ExampleStore_Persist_retry \n" +
+		"store/store_test.go:9 BenchmarkStore_Save->BenchmarkStore_Persist "
+	if got := companions(out.Companions); got != want {
+		t.Errorf("companions:\n%s\nwant:\n%s", got, want)
+	}
+
+	if len(out.Diffs) != 2 || out.Diffs[1].Path != "store/store_test.go" || !strings.Contains(out.Diffs[1].Diff, "+func BenchmarkStore_Persist(b *testing.B) {}") {
+		t.Errorf("diffs = %+v, want the test file renamed too", out.Diffs)
+	}
+
+	// Without renameCompanions they are only reported.
+	off := false
+
+	_, out, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{
+		Dir: dir, OldName: "Store.Save", NewName: "Persist", DryRun: true, RenameCompanions: &off,
+	})
+	if err != nil {
+		t.Fatalf("RenameSymbol: %v", err)
+	}
+
+	if len(out.Companions) != 0 || len(out.CompanionsNeedingRename) != 2 || len(out.Diffs) != 1 {
+		t.Errorf("got companions %v, needing rename %v and %d diffs; want 2 reported and only store.go changed",
+			out.Companions, out.CompanionsNeedingRename, len(out.Diffs))
+	}
+
+	// An unexported new name or a name already declared in the tests cannot be followed.
+	_, out, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, OldName: "Open", NewName: "Close", DryRun: true})
+	if err != nil {
+		t.Fatalf("RenameSymbol: %v", err)
+	}
+
+	if got := companions(out.CompanionsNeedingRename); got != "store/store_test.go:11 ExampleOpen->ExampleClose ExampleClose is already declared in package store" {
+		t.Errorf("needing rename = %q", got)
+	}
+
+	_, out, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, OldName: "Open", NewName: "open", DryRun: true})
+	if err != nil {
+		t.Fatalf("RenameSymbol: %v", err)
+	}
+
+	if len(out.CompanionsNeedingRename) != 1 || !strings.Contains(out.CompanionsNeedingRename[0].Reason, "unexported") {
+		t.Errorf("needing rename = %+v, want ExampleOpen with an unexported new name", out.CompanionsNeedingRename)
+	}
+
+	// Renaming the type renames its examples and those of its methods; BenchmarkOpenAll stays.
+	_, out, err = tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, OldName: "Store", NewName: "Repo", Kind: "type"})
+	if err != nil {
+		t.Fatalf("RenameSymbol: %v", err)
+	}
+
+	if len(out.Companions) != 3 {
+		t.Errorf("companions = %v, want 3", out.Companions)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "store", "store_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFile := strings.NewReplacer(
+		"ExampleStore()", "ExampleRepo()",
+		"ExampleStore_Save_retry", "ExampleRepo_Save_retry",
+		"BenchmarkStore_Save", "BenchmarkRepo_Save",
+	).Replace(testFile)
+	if string(data) != wantFile {
+		t.Errorf("store_test.go:\n%s\nwant:\n%s", data, wantFile)
+	}
+}
@@ -0,0 +1,192 @@
+package tools
+
+import (
+	"bytes"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Statuses of a package in a rename.
+const (
+	renameStatusClean    = "clean"
+	renameStatusModified = "modified"
+	renameStatusSkipped  = "skipped"
+)
+
+// renameImpactBuilder accumulates the occurrences a previewOnly rename would change.
+type renameImpactBuilder struct {
+	impact   RenameImpact
+	packages map[string]*RenamePackageImpact
+}
+
+func newRenameImpactBuilder() *renameImpactBuilder {
+	return &renameImpactBuilder{packages: make(map[string]*RenamePackageImpact)}
+}
+
+// add records count occurrences in the file relPath of package pkgPath. guarded marks a generated file
+// the rename would leave untouched without allowGenerated.
+func (b *renameImpactBuilder) add(pkgPath, relPath string, count int, generated, guarded bool) {
+	b.impact.Files++
+	b.impact.Occurrences += count
+
+	switch {
+	case generated:
+		b.impact.GeneratedOccurrences += count
+	case strings.HasSuffix(relPath, "_test.go"):
+		b.impact.TestOccurrences += count
+	default:
+		b.impact.NonTestOccurrences += count
+	}
+
+	if guarded {
+		b.impact.BlockedByGeneratedGuard = true
+	}
+
+	p, ok := b.packages[pkgPath]
+	if !ok {
+		p = &RenamePackageImpact{Package: pkgPath}
+		b.packages[pkgPath] = p
+	}
+
+	p.Files++
+	p.References += count
+}
+
+// result returns the impact with packages ordered by reference count, then path.
+func (b *renameImpactBuilder) result() *RenameImpact {
+	impact := b.impact
+	impact.Packages = len(b.packages)
+	impact.PerPackage = make([]RenamePackageImpact, 0, len(b.packages))
+
+	for _, p := range b.packages {
+		impact.PerPackage = append(impact.PerPackage, *p)
+	}
+
+	sort.Slice(impact.PerPackage, func(i, j int) bool {
+		a, c := impact.PerPackage[i], impact.PerPackage[j]
+		if a.References != c.References {
+			return a.References > c.References
+		}
+
+		return a.Package < c.Package
+	})
+
+	return &impact
+}
+
+// renameStatusBuilder tracks the status of every package of the module in a rename. Packages with load,
+// parse or type errors are skipped: their references cannot be resolved reliably.
+type renameStatusBuilder struct {
+	dir      string
+	packages map[string]*RenamePackageStatus
+}
+
+func newRenameStatusBuilder(dir string, pkgs []*packages.Package) *renameStatusBuilder {
+	b := &renameStatusBuilder{dir: dir, packages: make(map[string]*RenamePackageStatus)}
+
+	for _, pkg := range pkgs {
+		path := normalizePackagePath(pkg)
+		if _, ok := b.packages[path]; ok {
+			continue
+		}
+
+		status := &RenamePackageStatus{Package: path, Status: renameStatusClean}
+		if len(pkg.Errors) > 0 {
+			be := firstPackageError(dir, pkg)
+			status.Status, status.Error = renameStatusSkipped, &be
+		}
+
+		b.packages[path] = status
+	}
+
+	return b
+}
+
+// skipped reports whether pkg is skipped for errors.
+func (b *renameStatusBuilder) skipped(pkg *packages.Package) bool {
+	return b.packages[normalizePackagePath(pkg)].Status == renameStatusSkipped
+}
+
+// skippedDeclaring returns the skipped package among pkgs declaring obj, nil when it was analyzed. Type
+// errors alone skip a package too: the expressions they break carry no type information, so a rename
+// would miss the references in them while the declaration changes.
+func (b *renameStatusBuilder) skippedDeclaring(pkgs []*packages.Package, obj types.Object) *packages.Package {
+	for _, pkg := range pkgs {
+		if pkg.Types == obj.Pkg() && b.skipped(pkg) {
+			return pkg
+		}
+	}
+
+	return nil
+}
+
+// unanalyzed returns the skipped packages among pkgs whose files mention the source name of a rename, in
+// text, so they may hold references the rename misses. Unreadable files count as mentions.
+func (b *renameStatusBuilder) unanalyzed(pkgs []*packages.Package, renames []*renameRequest) []string {
+	var paths []string
+
+	for _, pkg := range pkgs {
+		if !b.skipped(pkg) || !mentionsRenamed(pkg, renames) {
+			continue
+		}
+
+		if path := normalizePackagePath(pkg); !contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// modified records the changed file relPath of pkg.
+func (b *renameStatusBuilder) modified(pkg *packages.Package, relPath string) {
+	status := b.packages[normalizePackagePath(pkg)]
+	status.Status = renameStatusModified
+	status.Files = append(status.Files, relPath)
+}
+
+// result returns the statuses ordered by package path.
+func (b *renameStatusBuilder) result() []RenamePackageStatus {
+	statuses := make([]RenamePackageStatus, 0, len(b.packages))
+	for _, path := range sortedKeys(b.packages) {
+		statuses = append(statuses, *b.packages[path])
+	}
+
+	return statuses
+}
+
+// mentionsRenamed reports whether a file of pkg contains the source name of one of the renames.
+func mentionsRenamed(pkg *packages.Package, renames []*renameRequest) bool {
+	for _, filename := range pkg.GoFiles {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return true
+		}
+
+		for _, r := range renames {
+			if bytes.Contains(src, []byte(r.match)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// firstPackageError returns the first error of pkg that has a position, the go list summary of them
+// otherwise.
+func firstPackageError(dir string, pkg *packages.Package) BuildError {
+	for _, e := range pkg.Errors {
+		if be := newBuildError(dir, pkg, e); be.File != "" {
+			return be
+		}
+	}
+
+	return newBuildError(dir, pkg, pkg.Errors[0])
+}
@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"sort"
+)
+
+// maxRewriteCheckRounds bounds the overlay type checks of one rewriteAst call: each round rejects the
+// replacements that errors point into and checks the remaining ones again.
+const maxRewriteCheckRounds = 3
+
+// rewriteCandidate is a file with matches of the find pattern, parsed privately for the rewrite.
+type rewriteCandidate struct {
+	filename string
+	rel      string
+	src      []byte
+	fset     *token.FileSet
+	file     *ast.File
+	sites    []rewriteSite
+}
+
+// rewriteSite is a match of the find pattern: its byte range in the original file, its line and, once the
+// type check rejected it, the error it introduced.
+type rewriteSite struct {
+	start, end int
+	line       int
+	rejected   string
+}
+
+// newRewriteCandidate parses filename and records the matches of pattern in the order Rewrite visits them.
+func newRewriteCandidate(filename, rel string, pattern ast.Expr) (*rewriteCandidate, error) {
+	fset, file, src, err := parseFileForMutation(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &rewriteCandidate{filename: filename, rel: rel, src: src, fset: fset, file: file}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		expr, ok := n.(ast.Expr)
+		if !ok || !astEqual(expr, pattern) {
+			return true
+		}
+
+		start, end := fset.Position(expr.Pos()), fset.Position(expr.End())
+		c.sites = append(c.sites, rewriteSite{start: start.Offset, end: end.Offset, line: start.Line})
+
+		return false
+	})
+
+	return c, nil
+}
+
+// skipped returns the indexes of the rejected sites, for ASTRewriteVisitor.Skip.
+func (c *rewriteCandidate) skipped() map[int]bool {
+	skip := make(map[int]bool)
+
+	for i, site := range c.sites {
+		if site.rejected != "" {
+			skip[i] = true
+		}
+	}
+
+	return skip
+}
+
+// accepted returns the number of sites not rejected.
+func (c *rewriteCandidate) accepted() int {
+	return len(c.sites) - len(c.skipped())
+}
+
+// reject rejects every accepted site with msg.
+func (c *rewriteCandidate) reject(msg string) {
+	for i := range c.sites {
+		if c.sites[i].rejected == "" {
+			c.sites[i].rejected = msg
+		}
+	}
+}
+
+// splice returns the source with the accepted sites replaced by replacement, and the byte range of every
+// accepted site in the result by site index.
+func (c *rewriteCandidate) splice(replacement []byte) ([]byte, map[int][2]int) {
+	var buf bytes.Buffer
+
+	ranges := make(map[int][2]int)
+	last := 0
+
+	for i, site := range c.sites {
+		if site.rejected != "" {
+			continue
+		}
+
+		buf.Write(c.src[last:site.start])
+		ranges[i] = [2]int{buf.Len(), buf.Len() + len(replacement)}
+		buf.Write(replacement)
+		last = site.end
+	}
+
+	buf.Write(c.src[last:])
+
+	return buf.Bytes(), ranges
+}
+
+// typeCheckRewrites rejects the sites of candidates whose replacement does not type-check. The spliced
+// files are checked as an overlay of the module, so nothing is written, and only errors absent before the
+// rewrite count. An error inside a replaced range rejects that site; an error away from every site, such
+// as an import left unused, cannot be tied to one replacement and rejects the remaining sites of its file,
+// or of every file when it is reported in a file that was not rewritten.
+func typeCheckRewrites(ctx context.Context, dir string, candidates []*rewriteCandidate, replace ast.Expr) error {
+	var replacement bytes.Buffer
+
+	if err := format.Node(&replacement, token.NewFileSet(), replace); err != nil {
+		return err
+	}
+
+	baseline, err := verifyBuild(ctx, dir, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[[2]string]int)
+	for _, e := range baseline.Errors {
+		known[[2]string{e.File, e.Message}]++
+	}
+
+	for round := 1; ; round++ {
+		overlay := make(map[string][]byte)
+		spliced := make(map[string][]byte)
+		ranges := make(map[string]map[int][2]int)
+		byRel := make(map[string]*rewriteCandidate)
+
+		for _, c := range candidates {
+			if c.accepted() == 0 {
+				continue
+			}
+
+			content, r := c.splice(replacement.Bytes())
+			overlay[c.filename] = content
+			spliced[c.rel], ranges[c.rel], byRel[c.rel] = content, r, c
+		}
+
+		if len(overlay) == 0 {
+			return nil
+		}
+
+		checked, err := verifyBuild(ctx, dir, nil, overlay)
+		if err != nil {
+			return err
+		}
+
+		introduced := introducedBuildErrors(checked.Errors, known)
+		if len(introduced) == 0 {
+			return nil
+		}
+
+		var unattributed []BuildError
+
+		for _, e := range introduced {
+			c := byRel[e.File]
+			if c == nil {
+				unattributed = append(unattributed, e)
+
+				continue
+			}
+
+			off, hit := offsetOf(spliced[e.File], e.Line, e.Column), false
+
+			for i, r := range ranges[e.File] {
+				if off >= r[0] && off <= r[1] && c.sites[i].rejected == "" {
+					c.sites[i].rejected, hit = e.Message, true
+				}
+			}
+
+			if !hit {
+				unattributed = append(unattributed, e)
+			}
+		}
+
+		if len(unattributed) < len(introduced) && round < maxRewriteCheckRounds {
+			continue // the remaining errors may have been caused by the rejected sites
+		}
+
+		for _, e := range unattributed {
+			if c := byRel[e.File]; c != nil {
+				c.reject(e.Message)
+
+				continue
+			}
+
+			for _, c := range candidates {
+				c.reject(e.File + ": " + e.Message)
+			}
+		}
+
+		return nil
+	}
+}
+
+// introducedBuildErrors returns the errors of errs beyond the ones counted in known by file and message.
+func introducedBuildErrors(errs []BuildError, known map[[2]string]int) []BuildError {
+	left := make(map[[2]string]int, len(known))
+	for key, n := range known {
+		left[key] = n
+	}
+
+	var introduced []BuildError
+
+	for _, e := range errs {
+		key := [2]string{e.File, e.Message}
+		if left[key] > 0 {
+			left[key]--
+
+			continue
+		}
+
+		introduced = append(introduced, e)
+	}
+
+	return introduced
+}
+
+// offsetOf returns the byte offset of the 1-based line and column in src, -1 when the line is missing.
+func offsetOf(src []byte, line, column int) int {
+	off := 0
+
+	for l := 1; l < line; l++ {
+		i := bytes.IndexByte(src[off:], '\n')
+		if i < 0 {
+			return -1
+		}
+
+		off += i + 1
+	}
+
+	return off + max(column-1, 0)
+}
+
+// rejectedRewrites lists the rejected sites of candidates by file and line.
+func rejectedRewrites(candidates []*rewriteCandidate) []RejectedRewrite {
+	var rejected []RejectedRewrite
+
+	for _, c := range candidates {
+		for _, site := range c.sites {
+			if site.rejected != "" {
+				rejected = append(rejected, RejectedRewrite{File: c.rel, Line: site.line, Error: site.rejected})
+			}
+		}
+	}
+
+	sort.SliceStable(rejected, func(i, j int) bool {
+		if rejected[i].File != rejected[j].File {
+			return rejected[i].File < rejected[j].File
+		}
+
+		return rejected[i].Line < rejected[j].Line
+	})
+
+	return rejected
+}
@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// roots holds the directories registered with RegisterRoot, canonicalized, by name.
+var roots = struct {
+	sync.RWMutex
+
+	paths map[string]string
+}{
+	paths: make(map[string]string),
+}
+
+// RegisterRoot makes a module directory addressable by name through the root field of tool inputs.
+//
+// Parameters:
+//   - name: root name, unique among registered roots
+//   - path: existing directory, canonicalized with CanonicalDir
+//
+// Returns:
+//   - error if the name is empty or taken, or the path is not a directory
+func RegisterRoot(name, path string) error {
+	if name == "" {
+		return fmt.Errorf("root name must not be empty")
+	}
+
+	st, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("root %q: %w", name, err)
+	}
+
+	if !st.IsDir() {
+		return fmt.Errorf("root %q: %s is not a directory", name, path)
+	}
+
+	roots.Lock()
+	defer roots.Unlock()
+
+	if existing, ok := roots.paths[name]; ok {
+		return fmt.Errorf("root %q is already registered for %s", name, existing)
+	}
+
+	roots.paths[name] = CanonicalDir(path)
+
+	return nil
+}
+
+// CanonicalDir returns the absolute, clean form of dir with symlinks resolved, so that spellings of the
+// same directory ("./x/", a symlink to x) share cache entries. A directory that cannot be resolved, e.g.
+// because it does not exist, is only made absolute and cleaned.
+//
+// Parameters:
+//   - dir: directory as supplied by a client
+//
+// Returns:
+//   - the canonical directory
+func CanonicalDir(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.Clean(dir)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+
+	return abs
+}
+
+// ResolveDir determines the module directory of a tool call from its dir and root fields. A root name
+// selects a registered root; without either field, the only registered root is the default.
+//
+// Parameters:
+//   - dir: the dir field of the call, canonicalized when set
+//   - root: the root field of the call
+//
+// Returns:
+//   - the directory to analyze; empty when neither field is set and no root is registered
+//   - error if both fields are set, the root is unknown, or several roots make the default ambiguous
+func ResolveDir(dir, root string) (string, error) {
+	switch {
+	case dir != "" && root != "":
+		return "", invalidInput("pass either dir or root, not both")
+	case dir != "":
+		return CanonicalDir(dir), nil
+	}
+
+	roots.RLock()
+	defer roots.RUnlock()
+
+	if root != "" {
+		path, ok := roots.paths[root]
+		if !ok {
+			return "", notFound(nil, "root %q is not registered (registered: %s)", root, rootNames())
+		}
+
+		return path, nil
+	}
+
+	switch len(roots.paths) {
+	case 0:
+		return "", nil
+	case 1:
+		for _, path := range roots.paths {
+			return path, nil
+		}
+	}
+
+	return "", invalidInput("dir or root is required: %d roots are registered (%s)", len(roots.paths), rootNames())
+}
+
+// rootNames lists the registered root names; the caller holds the roots lock.
+func rootNames() string {
+	if len(roots.paths) == 0 {
+		return "none"
+	}
+
+	return strings.Join(sortedKeys(roots.paths), ", ")
+}
+
+// ListRoots returns the module directories registered at startup with --root.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: no parameters
+//
+// Returns:
+//   - MCP tool call result
+//   - registered roots sorted by name and the default root, if any
+//   - error (always nil)
+func ListRoots(_ context.Context, _ *mcp.CallToolRequest, _ ListRootsInput) (
+	*mcp.CallToolResult,
+	ListRootsOutput,
+	error,
+) {
+	start := logStart("ListRoots", nil)
+	out := ListRootsOutput{Roots: []RootInfo{}}
+
+	defer func() { logEnd("ListRoots", start, len(out.Roots)) }()
+
+	roots.RLock()
+	defer roots.RUnlock()
+
+	for name, path := range roots.paths {
+		out.Roots = append(out.Roots, RootInfo{Name: name, Path: path})
+	}
+
+	sort.Slice(out.Roots, func(i, j int) bool { return out.Roots[i].Name < out.Roots[j].Name })
+
+	if len(out.Roots) == 1 {
+		out.Default = out.Roots[0].Name
+	}
+
+	return nil, out, nil
+}
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// withRoots replaces the registered roots for the duration of a test.
+func withRoots(t *testing.T) {
+	t.Helper()
+
+	roots.Lock()
+	saved := roots.paths
+	roots.paths = make(map[string]string)
+	roots.Unlock()
+
+	t.Cleanup(func() {
+		roots.Lock()
+		roots.paths = saved
+		roots.Unlock()
+	})
+}
+
+func TestResolveDir_CanonicalizesVariants(t *testing.T) {
+	withRoots(t)
+
+	base := t.TempDir()
+	module := filepath.Join(base, "module")
+
+	if err := os.Mkdir(module, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	link := filepath.Join(base, "link")
+	if err := os.Symlink(module, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	want := CanonicalDir(module)
+
+	for _, variant := range []string{module, module + "/", module + "/./", filepath.Join(module, "..", "module"), link, link + "/"} {
+		got, err := ResolveDir(variant, "")
+		if err != nil || got != want {
+			t.Errorf("ResolveDir(%q) = %q, %v; want %q", variant, got, err, want)
+		}
+	}
+}
+
+func TestResolveDir_Roots(t *testing.T) {
+	withRoots(t)
+
+	first, second := t.TempDir(), t.TempDir()
+
+	if dir, err := ResolveDir("", ""); err != nil || dir != "" {
+		t.Fatalf("without roots an empty dir must pass through, got %q, %v", dir, err)
+	}
+
+	if err := RegisterRoot("api", first); err != nil {
+		t.Fatalf("RegisterRoot error: %v", err)
+	}
+
+	if dir, err := ResolveDir("", ""); err != nil || dir != CanonicalDir(first) {
+		t.Fatalf("expected the only root as default, got %q, %v", dir, err)
+	}
+
+	if err := RegisterRoot("api", second); err == nil {
+		t.Fatal("expected an error registering a duplicate root name")
+	}
+
+	if err := RegisterRoot("web", second); err != nil {
+		t.Fatalf("RegisterRoot error: %v", err)
+	}
+
+	if dir, err := ResolveDir("", "web"); err != nil || dir != CanonicalDir(second) {
+		t.Fatalf("expected root web, got %q, %v", dir, err)
+	}
+
+	if _, err := ResolveDir("", ""); AsToolError(err).Code != CodeInvalidInput {
+		t.Fatalf("expected INVALID_INPUT when several roots make the default ambiguous, got %v", err)
+	}
+
+	if _, err := ResolveDir("", "missing"); AsToolError(err).Code != CodeNotFound {
+		t.Fatalf("expected NOT_FOUND for an unknown root, got %v", err)
+	}
+
+	if _, err := ResolveDir(first, "api"); AsToolError(err).Code != CodeInvalidInput {
+		t.Fatalf("expected INVALID_INPUT for dir and root together, got %v", err)
+	}
+
+	_, out, err := ListRoots(context.Background(), &mcp.CallToolRequest{}, ListRootsInput{})
+	if err != nil || len(out.Roots) != 2 || out.Roots[0].Name != "api" || out.Roots[1].Path != CanonicalDir(second) || out.Default != "" {
+		t.Fatalf("unexpected roots: %+v (err %v)", out, err)
+	}
+}
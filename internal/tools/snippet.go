@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// Snippet modes of the snippetMode input.
+const (
+	snippetModeLine        = "line"
+	snippetModeStatement   = "statement"
+	snippetModeDeclaration = "declaration"
+)
+
+// defaultSnippetMaxLines caps statement and declaration snippets when snippetMaxLines is not set.
+const defaultSnippetMaxLines = 20
+
+// snippetSpec is the validated snippet rendering requested by snippetLines, snippetMode and
+// snippetMaxLines. The zero value renders the trimmed line of the hit, as extractSnippet does.
+type snippetSpec struct {
+	mode     string
+	lines    int
+	maxLines int
+}
+
+// newSnippetSpec validates the snippet inputs of a tool.
+func newSnippetSpec(lines int, mode string, maxLines int) (snippetSpec, error) {
+	switch mode {
+	case "", snippetModeLine, snippetModeStatement, snippetModeDeclaration:
+	default:
+		return snippetSpec{}, invalidInput("unknown snippetMode %q: use line, statement or declaration", mode)
+	}
+
+	if lines < 0 {
+		return snippetSpec{}, invalidInput("snippetLines must not be negative, got %d", lines)
+	}
+
+	if maxLines < 0 {
+		return snippetSpec{}, invalidInput("snippetMaxLines must not be negative, got %d", maxLines)
+	}
+
+	if maxLines == 0 {
+		maxLines = defaultSnippetMaxLines
+	}
+
+	return snippetSpec{mode: mode, lines: lines, maxLines: maxLines}, nil
+}
+
+// isDefault reports whether s renders the single trimmed line of the hit.
+func (s snippetSpec) isDefault() bool {
+	return (s.mode == "" || s.mode == snippetModeLine) && s.lines <= 1
+}
+
+// extract renders the snippet of the hit at pos. lines is the content of file; the statement and
+// declaration modes take their span from the syntax tree and fall back to the line mode without one.
+func (s snippetSpec) extract(lines []string, fset *token.FileSet, file *ast.File, pos token.Pos) string {
+	line := fset.Position(pos).Line
+	if s.isDefault() {
+		return extractSnippet(lines, line)
+	}
+
+	if file != nil && (s.mode == snippetModeStatement || s.mode == snippetModeDeclaration) {
+		if node := s.enclosing(file, pos); node != nil {
+			start, end := fset.Position(node.Pos()).Line, fset.Position(node.End()).Line
+			if fd, ok := node.(*ast.FuncDecl); ok && s.mode == snippetModeStatement && fd.Body != nil {
+				// The statement of a function definition is its signature.
+				end = fset.Position(fd.Body.Lbrace).Line
+			}
+
+			start, end = clampSnippetSpan(start, end, line, s.maxLines)
+
+			return joinSnippetLines(lines, start, end)
+		}
+	}
+
+	n := max(s.lines, 1)
+	start := line - (n-1)/2
+
+	return joinSnippetLines(lines, start, start+n-1)
+}
+
+// enclosing returns the node whose span the snippet shows: in statement mode the smallest statement,
+// spec or declaration around pos; in declaration mode the top-level declaration, or its spec when the
+// declaration is a parenthesized group.
+func (s snippetSpec) enclosing(file *ast.File, pos token.Pos) ast.Node {
+	path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+
+	if s.mode == snippetModeStatement {
+		for _, node := range path {
+			switch node.(type) {
+			case *ast.BlockStmt:
+			case ast.Stmt, ast.Spec, ast.Decl:
+				return node
+			}
+		}
+
+		return nil
+	}
+
+	// path ends with the file; the node before it is the top-level declaration.
+	if len(path) < 2 {
+		return nil
+	}
+
+	decl := path[len(path)-2]
+	if gd, ok := decl.(*ast.GenDecl); ok && gd.Lparen.IsValid() && len(path) >= 3 {
+		return path[len(path)-3]
+	}
+
+	return decl
+}
+
+// syntaxFileAt returns the syntax tree of pkg containing pos, or nil.
+func syntaxFileAt(pkg *packages.Package, pos token.Pos) *ast.File {
+	for _, file := range pkg.Syntax {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+
+	return nil
+}
+
+// clampSnippetSpan shortens the span start..end to at most maxLines lines, keeping the hit line and
+// as much of the beginning of the span as fits.
+func clampSnippetSpan(start, end, hit, maxLines int) (int, int) {
+	if end-start+1 <= maxLines {
+		return start, end
+	}
+
+	if hit-start+1 > maxLines {
+		start = min(hit-maxLines/2, end-maxLines+1)
+	}
+
+	return start, start + maxLines - 1
+}
+
+// joinSnippetLines returns lines start..end (1-based, clamped to the file) with their common
+// indentation and trailing whitespace removed.
+func joinSnippetLines(lines []string, start, end int) string {
+	start, end = max(start, 1), min(end, len(lines))
+	if start > end {
+		return ""
+	}
+
+	span := make([]string, 0, end-start+1)
+	for _, l := range lines[start-1 : end] {
+		span = append(span, strings.TrimRight(l, " \t\r"))
+	}
+
+	indent := -1
+
+	for _, l := range span {
+		if l == "" {
+			continue
+		}
+
+		n := len(l) - len(strings.TrimLeft(l, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	for i, l := range span {
+		if len(l) >= indent && indent > 0 {
+			span[i] = l[indent:]
+		}
+	}
+
+	return strings.Trim(strings.Join(span, "\n"), "\n")
+}
@@ -0,0 +1,99 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const snippetSource = `package lang
+
+func Join(
+	sep string,
+	parts ...string,
+) string {
+	out := ""
+	for i, p := range parts {
+		if i > 0 {
+			out += sep
+		}
+
+		out += p
+	}
+
+	return out
+}
+
+func Greeting(name string) string {
+	return Join(
+		" ",
+		"hello",
+		name,
+	)
+}
+`
+
+func TestFindReferences_SnippetModes(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": snippetSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	// The reference to name sits on line 23, inside the multi-line call.
+	cases := []struct {
+		name  string
+		input tools.FindReferencesInput
+		want  string
+	}{
+		{"line", tools.FindReferencesInput{}, "name,"},
+		{"lines", tools.FindReferencesInput{SnippetLines: 3}, "\t\"hello\",\n\tname,\n)"},
+		{"statement", tools.FindReferencesInput{SnippetMode: "statement"}, "return Join(\n\t\" \",\n\t\"hello\",\n\tname,\n)"},
+		{"declaration capped", tools.FindReferencesInput{SnippetMode: "declaration", SnippetMaxLines: 3}, "\t\"hello\",\n\tname,\n)"},
+	}
+
+	for _, tc := range cases {
+		tc.input.Dir, tc.input.Ident, tc.input.Kind = dir, "name", "var"
+
+		_, out, err := tools.FindReferences(ctx, req, tc.input)
+		if err != nil {
+			t.Fatalf("%s: FindReferences error: %v", tc.name, err)
+		}
+
+		var got []string
+
+		for _, g := range out.Groups {
+			for _, ref := range g.References {
+				if ref.Line == 23 {
+					got = append(got, ref.Snippet)
+				}
+			}
+		}
+
+		if len(got) != 1 || got[0] != tc.want {
+			t.Errorf("%s: expected snippet %q, got %q", tc.name, tc.want, got)
+		}
+	}
+
+	_, _, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "name", SnippetMode: "block"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for an unknown snippetMode, got %v", err)
+	}
+}
+
+func TestFindDefinitions_StatementSnippetShowsSignature(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": snippetSource})
+
+	_, out, err := tools.FindDefinitions(context.Background(), &mcp.CallToolRequest{},
+		tools.FindDefinitionsInput{Dir: dir, Ident: "Join", SnippetMode: "statement"})
+	if err != nil || len(out.Groups) != 1 || len(out.Groups[0].Definitions) != 1 {
+		t.Fatalf("expected one definition of Join, got %+v (err %v)", out, err)
+	}
+
+	if got, want := out.Groups[0].Definitions[0].Snippet, "func Join(\n\tsep string,\n\tparts ...string,\n) string {"; got != want {
+		t.Errorf("expected the multi-line signature %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,428 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultSQLCallPatterns are the database calls analyzeSQL inspects when the input names none.
+var defaultSQLCallPatterns = []string{
+	"(*database/sql.DB).Query",
+	"(*database/sql.DB).Exec",
+	"(*database/sql.DB).QueryRow",
+	"(*github.com/jmoiron/sqlx.DB)",
+}
+
+// How the SQL argument of a call is built, reported as SQLQuery.Source.
+const (
+	sqlSourceLiteral       = "literal"
+	sqlSourceConstant      = "constant"
+	sqlSourceVariable      = "variable"
+	sqlSourceConcatenation = "concatenation"
+	sqlSourceSprintf       = "sprintf"
+	sqlSourceDynamic       = "dynamic"
+)
+
+// sqlDDLKeywords are the leading keywords of statements reported as DDL.
+var sqlDDLKeywords = map[string]struct{}{"CREATE": {}, "ALTER": {}, "DROP": {}, "TRUNCATE": {}}
+
+// AnalyzeSQL inventories the raw SQL a module passes to database calls: every call matching the call
+// patterns with its SQL argument resolved through literals and constants, the statement type by leading
+// keyword, and a flag on queries concatenated or formatted from non-constant values.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter and call patterns
+//
+// Returns:
+//   - MCP tool call result
+//   - SQL calls grouped by package with counts per statement type
+//   - error if a call pattern is malformed or packages cannot be loaded
+func AnalyzeSQL(ctx context.Context, _ *mcp.CallToolRequest, input AnalyzeSQLInput) (
+	*mcp.CallToolResult,
+	AnalyzeSQLOutput,
+	error,
+) {
+	start := logStart("AnalyzeSQL", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("callPatterns", strings.Join(input.CallPatterns, ",")),
+	))
+	out := AnalyzeSQLOutput{Packages: []SQLPackage{}}
+
+	defer func() { logEnd("AnalyzeSQL", start, out.Total) }()
+
+	raw := input.CallPatterns
+	if len(raw) == 0 {
+		raw = defaultSQLCallPatterns
+	}
+
+	patterns := make([]string, 0, len(raw))
+
+	for _, p := range raw {
+		pattern, ok := sqlCallPattern(p)
+		if !ok {
+			return fail(out, invalidInput("invalid callPatterns entry %q: expected (*pkg.Type).Method, (*pkg.Type) or pkg.Func", p))
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, mode, input.Package, "AnalyzeSQL")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	byPackage := make(map[string]*SQLPackage)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		for _, decl := range file.Decls {
+			funcName := ""
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				funcName = qualifiedFuncName(fd)
+			}
+
+			for _, q := range collectSQLQueries(pkg, decl, patterns) {
+				q.File, q.Function = relPath, funcName
+
+				entry, ok := byPackage[pkg.PkgPath]
+				if !ok {
+					entry = &SQLPackage{Package: pkg.PkgPath, ByStatement: make(map[string]int)}
+					byPackage[pkg.PkgPath] = entry
+				}
+
+				entry.Queries = append(entry.Queries, q)
+				entry.Total++
+				entry.ByStatement[q.Statement]++
+
+				if q.InjectionRisk {
+					entry.InjectionRisks++
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for _, key := range sortedKeys(byPackage) {
+		entry := byPackage[key]
+
+		sort.SliceStable(entry.Queries, func(i, j int) bool {
+			if entry.Queries[i].File != entry.Queries[j].File {
+				return entry.Queries[i].File < entry.Queries[j].File
+			}
+
+			return entry.Queries[i].Line < entry.Queries[j].Line
+		})
+
+		out.Total += entry.Total
+		out.InjectionRisks += entry.InjectionRisks
+		out.Packages = append(out.Packages, *entry)
+	}
+
+	return nil, out, nil
+}
+
+// sqlCallPattern converts a call pattern to a path.Match pattern of qualifiedObjectName: (*pkg.Type).Method
+// and (pkg.Type).Method become pkg.Type.Method, (*pkg.Type) every method pkg.Type.*, pkg.Func stays.
+func sqlCallPattern(p string) (string, bool) {
+	pattern := p
+
+	if strings.HasPrefix(p, "(") {
+		typeName, method, ok := strings.Cut(p[1:], ")")
+		if !ok {
+			return "", false
+		}
+
+		typeName = strings.TrimPrefix(typeName, "*")
+
+		switch {
+		case method == "":
+			pattern = typeName + ".*"
+		case strings.HasPrefix(method, ".") && len(method) > 1:
+			pattern = typeName + method
+		default:
+			return "", false
+		}
+	}
+
+	if !strings.Contains(pattern, ".") {
+		return "", false
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", false
+	}
+
+	return pattern, true
+}
+
+// collectSQLQueries finds the calls of decl matching patterns and describes their SQL argument, the first
+// string argument of the call. Calls taking no string, such as Ping or Close, are not queries.
+func collectSQLQueries(pkg *packages.Package, decl ast.Decl, patterns []string) []SQLQuery {
+	info := pkg.TypesInfo
+
+	var result []SQLQuery
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		fn := calledFunc(info, call)
+		if fn == nil || fn.Pkg() == nil {
+			return true
+		}
+
+		name := qualifiedObjectName(fn)
+		if !matchesAnyPattern(patterns, name) {
+			return true
+		}
+
+		arg := sqlArgument(info, call)
+		if arg == nil {
+			return true
+		}
+
+		q := describeSQL(info, decl, arg)
+		q.Line = pkg.Fset.Position(call.Pos()).Line
+		q.Call = fn.Pkg().Name() + "." + strings.TrimPrefix(name, fn.Pkg().Path()+".")
+		result = append(result, q)
+
+		return true
+	})
+
+	return result
+}
+
+// matchesAnyPattern reports whether name matches one of the path.Match patterns.
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sqlArgument returns the first argument of call with a string type.
+func sqlArgument(info *types.Info, call *ast.CallExpr) ast.Expr {
+	for _, arg := range call.Args {
+		if t := info.TypeOf(arg); t != nil {
+			if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+				return arg
+			}
+		}
+	}
+
+	return nil
+}
+
+// describeSQL resolves the SQL argument arg of a call in decl: its text when it is constant or a local
+// assigned a single constant, otherwise how it is built.
+func describeSQL(info *types.Info, decl ast.Decl, arg ast.Expr) SQLQuery {
+	var q SQLQuery
+
+	if text, ok := constantString(info, arg); ok {
+		q.Source, q.SQL = sqlSourceConstant, text
+
+		switch e := ast.Unparen(arg).(type) {
+		case *ast.BasicLit:
+			q.Source = sqlSourceLiteral
+		case *ast.Ident:
+			if _, ok := info.Uses[e].(*types.Const); ok {
+				q.Constant = e.Name
+			}
+		case *ast.SelectorExpr:
+			if _, ok := info.Uses[e.Sel].(*types.Const); ok {
+				q.Constant = types.ExprString(e)
+			}
+		}
+
+		q.Statement = sqlStatement(q.SQL)
+
+		return q
+	}
+
+	q.Expr = types.ExprString(arg)
+	q.Source, q.SQL, q.InjectionRisk = builtSQL(info, arg)
+
+	if ident, ok := ast.Unparen(arg).(*ast.Ident); ok && q.Source == sqlSourceDynamic {
+		if v, ok := info.Uses[ident].(*types.Var); ok && v.Parent() != v.Pkg().Scope() {
+			q.Source, q.SQL, q.InjectionRisk = variableSQL(info, decl, v)
+		}
+	}
+
+	q.Statement = sqlStatement(q.SQL)
+
+	return q
+}
+
+// builtSQL classifies a non-constant SQL expression: a concatenation with its constant prefix, a
+// fmt.Sprintf call with its format, or dynamic. Either is a risk once a non-constant value goes into it.
+func builtSQL(info *types.Info, expr ast.Expr) (source, text string, risk bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			break
+		}
+
+		// The leftmost constant operand is the start of the statement.
+		left := ast.Expr(e)
+		for {
+			bin, ok := ast.Unparen(left).(*ast.BinaryExpr)
+			if !ok || bin.Op != token.ADD {
+				break
+			}
+
+			if prefix, ok := constantString(info, bin.X); ok {
+				return sqlSourceConcatenation, prefix, true
+			}
+
+			left = bin.X
+		}
+
+		return sqlSourceConcatenation, "", true
+	case *ast.CallExpr:
+		fn := calledFunc(info, e)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || fn.Name() != "Sprintf" || len(e.Args) == 0 {
+			break
+		}
+
+		format, _ := constantString(info, e.Args[0])
+
+		for _, arg := range e.Args[1:] {
+			if tv, ok := info.Types[arg]; !ok || tv.Value == nil {
+				risk = true
+			}
+		}
+
+		return sqlSourceSprintf, format, risk
+	}
+
+	return sqlSourceDynamic, "", false
+}
+
+// variableSQL classifies the local v passed as SQL by the values decl assigns to it: a risk when one of
+// them is built from non-constant values or a non-constant string is appended with +=, its text when it is
+// assigned a single constant.
+func variableSQL(info *types.Info, decl ast.Decl, v *types.Var) (source, text string, risk bool) {
+	var (
+		values   []ast.Expr
+		appended []ast.Expr
+	)
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.AssignStmt:
+			if len(s.Lhs) != len(s.Rhs) {
+				return true
+			}
+
+			for i, lhs := range s.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || info.ObjectOf(ident) != v {
+					continue
+				}
+
+				if s.Tok == token.ADD_ASSIGN {
+					appended = append(appended, s.Rhs[i])
+				} else {
+					values = append(values, s.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range s.Names {
+				if info.Defs[name] == v && len(s.Values) == len(s.Names) {
+					values = append(values, s.Values[i])
+				}
+			}
+		}
+
+		return true
+	})
+
+	for _, value := range values {
+		if _, ok := constantString(info, value); ok {
+			continue
+		}
+
+		if source, text, risk := builtSQL(info, value); risk {
+			return source, text, true
+		}
+	}
+
+	first := ""
+	if len(values) > 0 {
+		first, _ = constantString(info, values[0])
+	}
+
+	if len(appended) > 0 {
+		for _, value := range appended {
+			if _, ok := constantString(info, value); !ok {
+				return sqlSourceConcatenation, first, true
+			}
+		}
+
+		return sqlSourceConcatenation, first, false
+	}
+
+	if len(values) == 1 && first != "" {
+		return sqlSourceVariable, first, false
+	}
+
+	return sqlSourceDynamic, "", false
+}
+
+// sqlStatement classifies SQL by its leading keyword, skipping whitespace, comments and parentheses.
+func sqlStatement(sql string) string {
+	s := sql
+
+	for {
+		s = strings.TrimLeft(s, " \t\r\n(")
+
+		if rest, ok := strings.CutPrefix(s, "--"); ok {
+			_, s, _ = strings.Cut(rest, "\n")
+		} else if rest, ok := strings.CutPrefix(s, "/*"); ok {
+			_, s, _ = strings.Cut(rest, "*/")
+		} else {
+			break
+		}
+	}
+
+	end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end < 0 {
+		end = len(s)
+	}
+
+	keyword := strings.ToUpper(s[:end])
+
+	switch keyword {
+	case "":
+		return "UNKNOWN"
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+		return keyword
+	}
+
+	if _, ok := sqlDDLKeywords[keyword]; ok {
+		return "DDL"
+	}
+
+	return "OTHER"
+}
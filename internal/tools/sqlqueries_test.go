@@ -0,0 +1,107 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const sqlDBSource = `package db
+
+type DB struct{}
+
+func (*DB) Query(query string, args ...any) error { return nil }
+
+func (*DB) Exec(query string, args ...any) error { return nil }
+
+func (*DB) QueryRow(query string, args ...any) error { return nil }
+
+func (*DB) Close() error { return nil }
+`
+
+const sqlStoreSource = `package store
+
+import "lang/db"
+
+const selectUsers = "SELECT id FROM users"
+
+func List(d *db.DB) { d.Query(selectUsers) }
+
+func Add(d *db.DB, name string) { d.Exec("INSERT INTO users VALUES (?)", name) }
+
+func Find(d *db.DB, name string) {
+	d.QueryRow("SELECT id FROM users WHERE name = '" + name + "'")
+}
+
+func Filter(d *db.DB, order string) {
+	q := "SELECT id FROM users"
+	q += " ORDER BY " + order
+	d.Query(q)
+}
+
+func Migrate(d *db.DB) {
+	q := "  -- schema\n CREATE TABLE users (id int)"
+	d.Exec(q)
+	d.Close()
+}
+
+func Purge(d *db.DB) { d.Exec("delete from users") }
+`
+
+func TestAnalyzeSQL(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"db/db.go":       sqlDBSource,
+		"store/store.go": sqlStoreSource,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.AnalyzeSQL(ctx, req, tools.AnalyzeSQLInput{Dir: dir, CallPatterns: []string{"(*lang/db.DB)"}})
+	if err != nil {
+		t.Fatalf("AnalyzeSQL: %v", err)
+	}
+
+	if out.Total != 6 || out.InjectionRisks != 2 || len(out.Packages) != 1 {
+		t.Fatalf("out = %+v, want 6 queries with 2 risks in one package", out)
+	}
+
+	pkg := out.Packages[0]
+	if pkg.Package != "lang/store" || pkg.ByStatement["SELECT"] != 3 || pkg.ByStatement["DDL"] != 1 ||
+		pkg.ByStatement["INSERT"] != 1 || pkg.ByStatement["DELETE"] != 1 {
+		t.Errorf("package = %s %v", pkg.Package, pkg.ByStatement)
+	}
+
+	want := []string{
+		"List:7 db.DB.Query SELECT constant selectUsers risk=false",
+		"Add:9 db.DB.Exec INSERT literal  risk=false",
+		"Find:12 db.DB.QueryRow SELECT concatenation  risk=true",
+		"Filter:18 db.DB.Query SELECT concatenation  risk=true",
+		"Migrate:23 db.DB.Exec DDL variable  risk=false",
+		"Purge:27 db.DB.Exec DELETE literal  risk=false",
+	}
+
+	for i, q := range pkg.Queries {
+		got := fmt.Sprintf("%s:%d %s %s %s %s risk=%v", q.Function, q.Line, q.Call, q.Statement, q.Source, q.Constant, q.InjectionRisk)
+		if i >= len(want) || got != want[i] {
+			t.Errorf("query %d = %q", i, got)
+		}
+	}
+
+	if q := pkg.Queries[3]; q.SQL != "SELECT id FROM users" || q.Expr != "q" {
+		t.Errorf("built query = %+v, want its constant start and expression", q)
+	}
+
+	_, out, err = tools.AnalyzeSQL(ctx, req, tools.AnalyzeSQLInput{Dir: dir})
+	if err != nil || out.Total != 0 {
+		t.Errorf("default patterns: %+v, %v", out, err)
+	}
+
+	_, _, err = tools.AnalyzeSQL(ctx, req, tools.AnalyzeSQLInput{Dir: dir, CallPatterns: []string{"(*lang/db.DB"}})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a malformed pattern, got %v", err)
+	}
+}
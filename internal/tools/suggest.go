@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// suggestionLimit caps the nearest names attached to a NOT_FOUND error.
+const suggestionLimit = 5
+
+// missingSymbol returns the NOT_FOUND error of a lookup tool whose target name is not declared in pkgs.
+// The declarations of kind ("" for any) nearest to name by edit distance are listed as candidates and
+// appended to the message, so a caller can retry with a corrected name.
+//
+// Parameters:
+//   - pkgs: packages whose declarations are suggested
+//   - name: requested name, optionally written as Type.Method or pkg.Name
+//   - kind: declaration kind to suggest (func, type, var, const), empty for any
+//   - format, args: the error message
+//
+// Returns:
+//   - the NOT_FOUND error
+func missingSymbol(pkgs []*packages.Package, name, kind, format string, args ...any) error {
+	suggestions := nearestNames(name, declaredNames(pkgs, kind))
+
+	return notFound(suggestions, "%s%s", fmt.Sprintf(format, args...), didYouMean(suggestions))
+}
+
+// checkInterfaceFilter validates the optional interface filter of a finder: a name that is neither
+// predeclared ("error", "any"), an interface literal nor a type declared in or imported by pkgs is
+// NOT_FOUND rather than a filter that silently matches nothing.
+func checkInterfaceFilter(pkgs []*packages.Package, name string) error {
+	if name == "" || strings.Contains(name, "{") || types.Universe.Lookup(name) != nil {
+		return nil
+	}
+
+	if _, err := lookupTypeName(pkgs, name); err != nil {
+		if te := AsToolError(err); te.Code == CodeNotFound {
+			return missingSymbol(pkgs, name, "type", "interface %q not found", name)
+		}
+	}
+
+	return nil
+}
+
+// declaredNames lists the package-level declarations of pkgs, methods written as Type.Method.
+// A non-empty kind keeps only declarations of that kind.
+func declaredNames(pkgs []*packages.Package, kind string) []string {
+	names := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				owner := ""
+				if fd, ok := decl.(*ast.FuncDecl); ok {
+					owner = receiverName(fd)
+				}
+
+				for _, def := range topLevelNames(decl) {
+					if kind != "" && def.kind != kind {
+						continue
+					}
+
+					name := def.name.Name
+					if owner != "" {
+						name = owner + "." + name
+					}
+
+					names[name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return sortedKeys(names)
+}
+
+// nearestNames returns up to suggestionLimit candidates within a small edit distance of name, closest
+// first. Names are compared case-insensitively and by their last segment as well, so "Lod" suggests
+// "Store.Load" and "models.Usr" suggests "User". A name ending in '.' or '/' has nothing to compare and
+// gets no suggestions.
+func nearestNames(name string, candidates []string) []string {
+	segment := len(lastNameSegment(name))
+	if segment == 0 {
+		return nil
+	}
+
+	maxDistance := min(max(2, segment/3), segment-1)
+
+	return rankNames(name, candidates, maxDistance)
+}
+
+// rankNames orders candidates by their distance to name, then alphabetically, dropping those farther
+// than maxDistance (any distance when negative) and keeping at most suggestionLimit.
+func rankNames(name string, candidates []string, maxDistance int) []string {
+	type ranked struct {
+		name     string
+		distance int
+	}
+
+	var matches []ranked
+
+	for _, c := range candidates {
+		if d := nameDistance(name, c); maxDistance < 0 || d <= maxDistance {
+			matches = append(matches, ranked{name: c, distance: d})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+
+		return matches[i].name < matches[j].name
+	})
+
+	if len(matches) > suggestionLimit {
+		matches = matches[:suggestionLimit]
+	}
+
+	result := make([]string, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.name)
+	}
+
+	return result
+}
+
+// nameDistance is the smallest edit distance between name and candidate, either in full or by their
+// last '.' or '/' separated segments.
+func nameDistance(name, candidate string) int {
+	name, candidate = strings.ToLower(name), strings.ToLower(candidate)
+
+	return min(
+		editDistance(name, candidate),
+		editDistance(name, lastNameSegment(candidate)),
+		editDistance(lastNameSegment(name), lastNameSegment(candidate)),
+	)
+}
+
+// lastNameSegment returns the part of name after its last '.' or '/'.
+func lastNameSegment(name string) string {
+	return name[strings.LastIndexAny(name, "./")+1:]
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// didYouMean renders suggestions as a message suffix, empty when there are none.
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	return "; did you mean: " + strings.Join(suggestions, ", ")
+}
@@ -0,0 +1,242 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// TestZeroResultSemantics checks the convention shared by the finders: a missing target is NOT_FOUND with
+// the nearest declared names as candidates, a valid target without matches is an empty success.
+func TestZeroResultSemantics(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"shapes/shapes.go": `package shapes
+
+type Shape interface{ Area() float64 }
+
+type Unused interface{ Nothing() }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Lonely struct{}
+
+func NewSquare(side float64) Square { return Square{Side: side} }
+
+func Measure(s Shape) float64 { return s.Area() }
+
+func Orphan() {}
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	tests := []struct {
+		name      string
+		missing   func() error
+		candidate string
+		// empty queries a valid target without matches; nil when the tool has no such case
+		empty func() (int, error)
+	}{
+		{
+			name: "FindReferences",
+			missing: func() error {
+				_, _, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Orphn"})
+
+				return err
+			},
+			candidate: "Orphan",
+			empty: func() (int, error) {
+				_, out, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Orphan", OnlyFuncValues: true})
+
+				return out.Total, err
+			},
+		},
+		{
+			name: "FindDefinitions",
+			missing: func() error {
+				_, _, err := tools.FindDefinitions(ctx, req, tools.FindDefinitionsInput{Dir: dir, Ident: "Squar"})
+
+				return err
+			},
+			candidate: "Square",
+			empty: func() (int, error) {
+				_, out, err := tools.FindDefinitions(ctx, req, tools.FindDefinitionsInput{Dir: dir, Ident: "Square", File: "shapes/other.go"})
+
+				return out.Total, err
+			},
+		},
+		{
+			name: "FindImplementations",
+			missing: func() error {
+				_, _, err := tools.FindImplementations(ctx, req, tools.FindImplementationsInput{Dir: dir, Name: "Shap"})
+
+				return err
+			},
+			candidate: "Shape",
+			empty: func() (int, error) {
+				_, out, err := tools.FindImplementations(ctx, req, tools.FindImplementationsInput{Dir: dir, Name: "Unused"})
+
+				return out.Total + len(out.Implementations), err
+			},
+		},
+		{
+			name: "FindConstructions",
+			missing: func() error {
+				_, _, err := tools.FindConstructions(ctx, req, tools.FindConstructionsInput{Dir: dir, TypeName: "Lonly"})
+
+				return err
+			},
+			candidate: "Lonely",
+			empty: func() (int, error) {
+				_, out, err := tools.FindConstructions(ctx, req, tools.FindConstructionsInput{Dir: dir, TypeName: "Lonely"})
+
+				return out.Total, err
+			},
+		},
+		{
+			name: "FindInterfaceConversions",
+			missing: func() error {
+				_, _, err := tools.FindInterfaceConversions(ctx, req, tools.FindInterfaceConversionsInput{Dir: dir, TypeName: "Squre"})
+
+				return err
+			},
+			candidate: "Square",
+			empty: func() (int, error) {
+				_, out, err := tools.FindInterfaceConversions(ctx, req, tools.FindInterfaceConversionsInput{Dir: dir, TypeName: "Lonely"})
+
+				return out.Total, err
+			},
+		},
+		{
+			name: "FindTypeAssertions",
+			missing: func() error {
+				_, _, err := tools.FindTypeAssertions(ctx, req, tools.FindTypeAssertionsInput{Dir: dir, InterfaceName: "Shap"})
+
+				return err
+			},
+			candidate: "Shape",
+			empty: func() (int, error) {
+				_, out, err := tools.FindTypeAssertions(ctx, req, tools.FindTypeAssertionsInput{Dir: dir, InterfaceName: "Shape"})
+
+				return out.Total, err
+			},
+		},
+		{
+			name: "FindCallPath",
+			missing: func() error {
+				_, _, err := tools.FindCallPath(ctx, req, tools.FindCallPathInput{Dir: dir, From: "Orphn", To: "Measure"})
+
+				return err
+			},
+			candidate: "Orphan",
+			empty: func() (int, error) {
+				_, out, err := tools.FindCallPath(ctx, req, tools.FindCallPathInput{Dir: dir, From: "Orphan", To: "Measure"})
+
+				return out.Total, err
+			},
+		},
+		{
+			name: "ListSymbols",
+			missing: func() error {
+				_, _, err := tools.ListSymbols(ctx, req, tools.ListSymbolsInput{Dir: dir, Package: "lang/shape"})
+
+				return err
+			},
+			candidate: "lang/shapes",
+		},
+		{
+			name: "ReadFunc",
+			missing: func() error {
+				_, _, err := tools.ReadFunc(ctx, req, tools.ReadFuncInput{Dir: dir, Name: "NewSqare"})
+
+				return err
+			},
+			candidate: "NewSquare",
+		},
+		{
+			name: "ReadStruct",
+			missing: func() error {
+				_, _, err := tools.ReadStruct(ctx, req, tools.ReadStructInput{Dir: dir, Name: "Squar"})
+
+				return err
+			},
+			candidate: "Square",
+		},
+		{
+			name: "GetTypeInfo",
+			missing: func() error {
+				_, _, err := tools.GetTypeInfo(ctx, req, tools.GetTypeInfoInput{Dir: dir, Name: "Shap"})
+
+				return err
+			},
+			candidate: "Shape",
+		},
+		{
+			name: "ExplainImplements",
+			missing: func() error {
+				_, _, err := tools.ExplainImplements(ctx, req, tools.ExplainImplementsInput{Dir: dir, TypeName: "Squar", InterfaceName: "Shape"})
+
+				return err
+			},
+			candidate: "Square",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := tools.AsToolError(tt.missing())
+			if te == nil || te.Code != tools.CodeNotFound {
+				t.Fatalf("missing target: got %v, want NOT_FOUND", te)
+			}
+
+			if te.Details == nil || !slices.Contains(te.Details.Candidates, tt.candidate) {
+				t.Errorf("missing target: details %+v lack %q (%s)", te.Details, tt.candidate, te.Message)
+			}
+
+			if tt.empty == nil {
+				return
+			}
+
+			if total, err := tt.empty(); err != nil || total != 0 {
+				t.Errorf("valid target without matches: total %d, err %v; want an empty success", total, err)
+			}
+		})
+	}
+}
+
+// TestMissingSymbolEmptySegment checks that a name ending in a separator gets no suggestions rather than
+// arbitrary declared names.
+func TestMissingSymbolEmptySegment(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"store/store.go": `package store
+
+type Store struct{}
+
+func (s *Store) Load() {}
+
+func Open() *Store { return &Store{} }
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	for _, name := range []string{"Store.", "store/"} {
+		_, _, err := tools.ReadFunc(ctx, req, tools.ReadFuncInput{Dir: dir, Name: name})
+
+		te := tools.AsToolError(err)
+		if te == nil || te.Code != tools.CodeNotFound {
+			t.Fatalf("%s: got %v, want NOT_FOUND", name, err)
+		}
+
+		if te.Details != nil && len(te.Details.Candidates) > 0 {
+			t.Errorf("%s: candidates %v, want none", name, te.Details.Candidates)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// suppressDirective starts a comment suppressing the findings of one tool for a declaration:
+// "//gonav:ignore <tool> [reason]", where tool is the MCP tool name or "all".
+const suppressDirective = "//gonav:ignore"
+
+// suppressAllTools is the tool name of a directive suppressing every tool.
+const suppressAllTools = "all"
+
+// suppressDirectiveInfo is one parsed suppression directive.
+type suppressDirectiveInfo struct {
+	tool   string
+	reason string
+}
+
+// declSuppressions maps the identifiers declared by the top-level declarations of file to the directives
+// applying to them. A directive applies when it is in the comment group ending on the line directly above
+// the declaration, doc comment included, or on the declaration's first line. Directives attached to a
+// grouped const, var or type declaration apply to all of its specs; those attached to a spec only to the
+// names it declares. The result is nil when the file has no directive.
+func declSuppressions(fset *token.FileSet, file *ast.File) map[*ast.Ident][]suppressDirectiveInfo {
+	byEnd := make(map[int][]suppressDirectiveInfo)
+	byStart := make(map[int][]suppressDirectiveInfo)
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			d, ok := parseSuppressDirective(c.Text)
+			if !ok {
+				continue
+			}
+
+			end := fset.Position(group.End()).Line
+			byEnd[end] = append(byEnd[end], d)
+
+			line := fset.Position(c.Pos()).Line
+			byStart[line] = append(byStart[line], d)
+		}
+	}
+
+	if len(byEnd) == 0 {
+		return nil
+	}
+
+	// at returns the directives above and on the line a node starts at.
+	at := func(pos token.Pos) []suppressDirectiveInfo {
+		line := fset.Position(pos).Line
+
+		var dirs []suppressDirectiveInfo
+
+		dirs = append(dirs, byEnd[line-1]...)
+
+		return append(dirs, byStart[line]...)
+	}
+
+	result := make(map[*ast.Ident][]suppressDirectiveInfo)
+
+	add := func(ident *ast.Ident, dirs []suppressDirectiveInfo) {
+		if len(dirs) > 0 {
+			result[ident] = append(result[ident], dirs...)
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			add(d.Name, at(d.Pos()))
+		case *ast.GenDecl:
+			declDirs := at(d.Pos())
+
+			for _, spec := range d.Specs {
+				// An ungrouped declaration starts on the line of its only spec.
+				var specDirs []suppressDirectiveInfo
+				if d.Lparen.IsValid() {
+					specDirs = at(spec.Pos())
+				}
+
+				dirs := append(specDirs, declDirs...)
+
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name, dirs)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						add(name, dirs)
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// packageSuppressions merges the declaration suppressions of all files of pkg.
+func packageSuppressions(pkg *packages.Package) map[*ast.Ident][]suppressDirectiveInfo {
+	var result map[*ast.Ident][]suppressDirectiveInfo
+
+	for _, file := range pkg.Syntax {
+		for ident, dirs := range declSuppressions(pkg.Fset, file) {
+			if result == nil {
+				result = make(map[*ast.Ident][]suppressDirectiveInfo)
+			}
+
+			result[ident] = dirs
+		}
+	}
+
+	return result
+}
+
+// funcSuppressions returns the functions with a body in file whose directives suppress tool.
+func funcSuppressions(fset *token.FileSet, file *ast.File, relPath, tool string) []SuppressedFinding {
+	dirs := declSuppressions(fset, file)
+	if dirs == nil {
+		return nil
+	}
+
+	var findings []SuppressedFinding
+
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+
+		if d, ok := suppressedBy(dirs[fd.Name], tool); ok {
+			findings = append(findings, newSuppressedFinding(relPath, fset.Position(fd.Pos()).Line, fd.Name.Name, d))
+		}
+	}
+
+	return findings
+}
+
+// parseSuppressDirective parses one comment as a suppression directive.
+func parseSuppressDirective(text string) (suppressDirectiveInfo, bool) {
+	rest, ok := strings.CutPrefix(text, suppressDirective)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return suppressDirectiveInfo{}, false
+	}
+
+	tool, reason, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	if tool == "" {
+		return suppressDirectiveInfo{}, false
+	}
+
+	return suppressDirectiveInfo{tool: tool, reason: strings.TrimSpace(reason)}, true
+}
+
+// suppressedBy returns the first directive suppressing tool, if any.
+func suppressedBy(dirs []suppressDirectiveInfo, tool string) (suppressDirectiveInfo, bool) {
+	for _, d := range dirs {
+		if d.tool == tool || d.tool == suppressAllTools {
+			return d, true
+		}
+	}
+
+	return suppressDirectiveInfo{}, false
+}
+
+// newSuppressedFinding reports a declaration skipped because of d.
+func newSuppressedFinding(file string, line int, name string, d suppressDirectiveInfo) SuppressedFinding {
+	return SuppressedFinding{File: file, Line: line, Name: name, Tool: d.tool, Reason: d.reason}
+}
+
+// sortSuppressedFindings orders findings by file and line.
+func sortSuppressedFindings(findings []SuppressedFinding) {
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+
+		return findings[i].Line < findings[j].Line
+	})
+}
+
+// hasSuppressDirective reports whether src may contain a suppression directive, so that files without
+// one need not be parsed with their comments.
+func hasSuppressDirective(src []byte) bool {
+	return bytes.Contains(src, []byte(suppressDirective))
+}
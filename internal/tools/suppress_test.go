@@ -0,0 +1,134 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const suppressSource = `package lang
+
+//gonav:ignore getDeadCodeReport kept for the migration
+func legacyParse() {}
+
+// deadHelper is reported.
+func deadHelper() {}
+
+func spare() {} //gonav:ignore all generated
+
+//gonav:ignore getComplexityReport only complexity
+func otherTool() {}
+
+//gonav:ignore getDeadCodeReport whole group
+var (
+	groupedA = 1
+	groupedB = 2
+)
+
+const (
+	//gonav:ignore getDeadCodeReport
+	specOnly = 1
+	notSuppressed = 2
+)
+
+//gonav:ignore getDeadCodeReport
+
+func detached() {}
+`
+
+func TestDeadCode_Suppressed(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": suppressSource})
+
+	_, out, err := tools.DeadCode(context.Background(), &mcp.CallToolRequest{}, tools.DeadCodeInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	var unused []string
+	for _, d := range out.Unused {
+		unused = append(unused, d.Name)
+	}
+
+	slices.Sort(unused)
+
+	if want := []string{"deadHelper", "detached", "notSuppressed", "otherTool"}; !slices.Equal(unused, want) {
+		t.Fatalf("expected unused %v, got %v", want, unused)
+	}
+
+	if out.TotalCount != 4 {
+		t.Fatalf("suppressed symbols must not count as unused, got total %d", out.TotalCount)
+	}
+
+	want := []tools.SuppressedFinding{
+		{File: "lang.go", Line: 4, Name: "legacyParse", Tool: "getDeadCodeReport", Reason: "kept for the migration"},
+		{File: "lang.go", Line: 9, Name: "spare", Tool: "all", Reason: "generated"},
+		{File: "lang.go", Line: 16, Name: "groupedA", Tool: "getDeadCodeReport", Reason: "whole group"},
+		{File: "lang.go", Line: 17, Name: "groupedB", Tool: "getDeadCodeReport", Reason: "whole group"},
+		{File: "lang.go", Line: 22, Name: "specOnly", Tool: "getDeadCodeReport"},
+	}
+	if !slices.Equal(out.Suppressed, want) {
+		t.Fatalf("unexpected suppressed list:\n got %+v\nwant %+v", out.Suppressed, want)
+	}
+}
+
+func TestAnalyzeComplexity_TopSkipsSuppressed(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": `package lang
+
+//gonav:ignore getComplexityReport table-driven dispatch
+func Dispatch(n int) int {
+	switch n {
+	case 1:
+		return 1
+	case 2:
+		return 2
+	case 3:
+		return 3
+	}
+
+	return 0
+}
+
+func Simple(n int) int {
+	if n > 0 {
+		return n
+	}
+
+	return 0
+}
+`})
+
+	_, out, err := tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeComplexityInput{Dir: dir, Top: 5})
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity error: %v", err)
+	}
+
+	if len(out.Ranked) != 1 || out.Ranked[0].Name != "Simple" {
+		t.Fatalf("expected only Simple to be ranked, got %+v", out.Ranked)
+	}
+
+	if out.Summary == nil || out.Summary.FunctionCount != 2 {
+		t.Fatalf("suppressed functions must still count towards the summary, got %+v", out.Summary)
+	}
+
+	want := []tools.SuppressedFinding{
+		{File: "lang.go", Line: 4, Name: "Dispatch", Tool: "getComplexityReport", Reason: "table-driven dispatch"},
+	}
+	if !slices.Equal(out.Suppressed, want) {
+		t.Fatalf("unexpected suppressed list: %+v", out.Suppressed)
+	}
+
+	// Without top the report is unchanged.
+	_, out, err = tools.AnalyzeComplexity(context.Background(), &mcp.CallToolRequest{},
+		tools.AnalyzeComplexityInput{Dir: dir})
+	if err != nil || len(out.Functions) != 1 || len(out.Functions[0].Functions) != 2 || out.Suppressed != nil {
+		t.Fatalf("expected both functions without top, got %+v (err %v)", out, err)
+	}
+}
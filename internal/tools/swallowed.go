@@ -0,0 +1,313 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Contexts of a swallowed error; the tolerance for dropped errors differs between them.
+const (
+	swallowContextDefer     = "defer"
+	swallowContextGoroutine = "goroutine"
+)
+
+// FindSwallowedErrors reports call sites that drop an error result — as an unchecked call statement,
+// a blank assignment, or a deferred or go call — inside functions that do not return an error
+// themselves, so the failure cannot reach the caller.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter, test files and excluded callees
+//
+// Returns:
+//   - MCP tool call result
+//   - call sites with callee, form and context, and counts per package
+//   - error if an error occurred while loading packages
+func FindSwallowedErrors(ctx context.Context, _ *mcp.CallToolRequest, input FindSwallowedErrorsInput) (
+	*mcp.CallToolResult,
+	FindSwallowedErrorsOutput,
+	error,
+) {
+	start := logStart("FindSwallowedErrors", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("includeTests", strconv.FormatBool(input.IncludeTests)),
+	))
+	out := FindSwallowedErrorsOutput{}
+
+	defer func() { logEnd("FindSwallowedErrors", start, out.Total) }()
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCacheInternal(ctx, input.Dir, mode, input.IncludeTests)
+	if err != nil {
+		logError("FindSwallowedErrors", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	filtered, err := filterPackagesByRequest(pkgs, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	excluded := make(map[string]struct{}, len(input.ExcludeCallees))
+	for _, callee := range input.ExcludeCallees {
+		excluded[callee] = struct{}{}
+	}
+
+	counts := make(map[string]*SwallowedErrorPackage)
+	// Test variants contain the package's own files again; every file is scanned once.
+	seen := make(map[string]struct{})
+
+	if err := walkPackageFiles(ctx, filtered, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if _, ok := seen[relPath]; ok || strings.HasSuffix(pkg.PkgPath, ".test") {
+			return nil
+		}
+
+		seen[relPath] = struct{}{}
+		inTest := strings.HasSuffix(relPath, "_test.go")
+
+		for _, site := range swallowedErrorSites(pkg.TypesInfo, file) {
+			if _, ok := excluded[site.Callee]; ok {
+				out.Excluded++
+
+				continue
+			}
+
+			site.File = relPath
+			site.Line = pkg.Fset.Position(site.pos).Line
+			site.InTest = inTest
+			out.Findings = append(out.Findings, site.SwallowedError)
+
+			count, ok := counts[pkg.PkgPath]
+			if !ok {
+				count = &SwallowedErrorPackage{Package: pkg.PkgPath}
+				counts[pkg.PkgPath] = count
+			}
+
+			count.Total++
+
+			switch {
+			case inTest:
+				count.Test++
+			case site.Context == swallowContextDefer:
+				count.Defer++
+			case site.Context == swallowContextGoroutine:
+				count.Goroutine++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.Slice(out.Findings, func(i, j int) bool {
+		if out.Findings[i].File != out.Findings[j].File {
+			return out.Findings[i].File < out.Findings[j].File
+		}
+
+		return out.Findings[i].Line < out.Findings[j].Line
+	})
+
+	for _, count := range counts {
+		out.Packages = append(out.Packages, *count)
+	}
+
+	sort.Slice(out.Packages, func(i, j int) bool { return out.Packages[i].Package < out.Packages[j].Package })
+
+	out.Total = len(out.Findings)
+
+	return nil, out, nil
+}
+
+// swallowedErrorSite is a finding together with the position of the dropping call.
+type swallowedErrorSite struct {
+	SwallowedError
+
+	pos token.Pos
+}
+
+// swallowedErrorSites finds dropped error results in a file. Sites inside a function or closure that
+// itself returns an error are skipped, as are conversions and calls whose last result is not an error.
+func swallowedErrorSites(info *types.Info, file *ast.File) []swallowedErrorSite {
+	var (
+		sites []swallowedErrorSite
+		stack []ast.Node
+	)
+
+	report := func(call *ast.CallExpr, form string) {
+		if !lastResultIsError(info, call) {
+			return
+		}
+
+		fnName, returnsErr, siteContext := swallowScope(info, stack)
+		if returnsErr {
+			return
+		}
+
+		callee := exprString(call.Fun)
+		if fn := calledFunc(info, call); fn != nil {
+			callee = fn.FullName()
+		}
+
+		sites = append(sites, swallowedErrorSite{
+			SwallowedError: SwallowedError{Function: fnName, Callee: callee, Form: form, Context: siteContext},
+			pos:            call.Pos(),
+		})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+
+			return true
+		}
+
+		switch s := n.(type) {
+		case *ast.ExprStmt:
+			if call, ok := ast.Unparen(s.X).(*ast.CallExpr); ok {
+				report(call, "unchecked")
+			}
+		case *ast.DeferStmt:
+			stack = append(stack, n)
+			report(s.Call, "unchecked")
+			stack = stack[:len(stack)-1]
+		case *ast.GoStmt:
+			stack = append(stack, n)
+			report(s.Call, "unchecked")
+			stack = stack[:len(stack)-1]
+		case *ast.AssignStmt:
+			for _, call := range blankErrorCalls(info, s) {
+				report(call, "blank")
+			}
+		}
+
+		stack = append(stack, n)
+
+		return true
+	})
+
+	return sites
+}
+
+// blankErrorCalls returns the calls of an assignment whose error result is assigned to the blank identifier.
+func blankErrorCalls(info *types.Info, assign *ast.AssignStmt) []*ast.CallExpr {
+	isBlank := func(expr ast.Expr) bool {
+		ident, ok := expr.(*ast.Ident)
+
+		return ok && ident.Name == "_"
+	}
+
+	if len(assign.Rhs) == 1 {
+		if call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr); ok && isBlank(assign.Lhs[len(assign.Lhs)-1]) {
+			if sig := callSignature(info, call); sig != nil && sig.Results().Len() == len(assign.Lhs) {
+				return []*ast.CallExpr{call}
+			}
+		}
+
+		return nil
+	}
+
+	var calls []*ast.CallExpr
+
+	for i, rhs := range assign.Rhs {
+		if call, ok := ast.Unparen(rhs).(*ast.CallExpr); ok && i < len(assign.Lhs) && isBlank(assign.Lhs[i]) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// swallowScope returns the enclosing named function, whether the innermost enclosing function or
+// closure returns an error, and the defer/goroutine context of the stack's top.
+func swallowScope(info *types.Info, stack []ast.Node) (string, bool, string) {
+	var (
+		fnName      string
+		returnsErr  bool
+		siteContext string
+		innermost   = true
+	)
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit:
+			if innermost {
+				returnsErr = signatureReturnsError(info.TypeOf(n))
+				innermost = false
+			}
+		case *ast.FuncDecl:
+			if innermost {
+				if obj := info.Defs[n.Name]; obj != nil {
+					returnsErr = signatureReturnsError(obj.Type())
+				}
+
+				innermost = false
+			}
+
+			fnName = qualifiedFuncName(n)
+		case *ast.DeferStmt:
+			if siteContext == "" {
+				siteContext = swallowContextDefer
+			}
+		case *ast.GoStmt:
+			if siteContext == "" {
+				siteContext = swallowContextGoroutine
+			}
+		}
+	}
+
+	return fnName, returnsErr, siteContext
+}
+
+// callSignature returns the signature of the called function, or nil for conversions.
+func callSignature(info *types.Info, call *ast.CallExpr) *types.Signature {
+	if tv, ok := info.Types[call.Fun]; ok && tv.IsType() {
+		return nil
+	}
+
+	sig, _ := types.Unalias(info.TypeOf(call.Fun)).Underlying().(*types.Signature)
+
+	return sig
+}
+
+// lastResultIsError reports whether the call's last result has type error.
+func lastResultIsError(info *types.Info, call *ast.CallExpr) bool {
+	sig := callSignature(info, call)
+	if sig == nil || sig.Results().Len() == 0 {
+		return false
+	}
+
+	return isErrorType(sig.Results().At(sig.Results().Len() - 1).Type())
+}
+
+// signatureReturnsError reports whether t is a signature with a result of type error.
+func signatureReturnsError(t types.Type) bool {
+	sig, ok := t.(*types.Signature)
+	if !ok {
+		return false
+	}
+
+	for i := range sig.Results().Len() {
+		if isErrorType(sig.Results().At(i).Type()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
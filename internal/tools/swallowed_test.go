@@ -0,0 +1,100 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+// swallowedIn returns the findings of swallow.go and swallow_test.go keyed by "function callee".
+func swallowedIn(findings []tools.SwallowedError) map[string]tools.SwallowedError {
+	result := make(map[string]tools.SwallowedError)
+
+	for _, f := range findings {
+		if f.File == "swallow.go" || f.File == "swallow_test.go" {
+			result[f.Function+" "+f.Callee] = f
+		}
+	}
+
+	return result
+}
+
+func TestFindSwallowedErrors(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindSwallowedErrorsInput{
+		Dir:            testDir(),
+		IncludeTests:   true,
+		ExcludeCallees: []string{"(*strings.Builder).WriteString"},
+	}
+
+	_, out, err := tools.FindSwallowedErrors(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("FindSwallowedErrors error: %v", err)
+	}
+
+	got := swallowedIn(out.Findings)
+
+	want := map[string]tools.SwallowedError{
+		"closeQuietly (*os.File).Close": {Form: "unchecked"},
+		"removeTemp os.Remove":          {Form: "blank"},
+		"readQuietly io.ReadAll":        {Form: "blank"},
+		"cleanupLater os.Remove":        {Form: "unchecked", Context: "defer"},
+		"TestRemoveTemp os.Remove":      {Form: "unchecked", InTest: true},
+	}
+
+	for key, w := range want {
+		f, ok := got[key]
+		if !ok {
+			t.Errorf("expected finding %q, got %v", key, got)
+
+			continue
+		}
+
+		if f.Form != w.Form || f.InTest != w.InTest || (w.Context != "" && f.Context != w.Context) {
+			t.Errorf("%s: expected %+v, got %+v", key, w, f)
+		}
+	}
+
+	goroutines := 0
+
+	for _, f := range out.Findings {
+		if f.Function == "cleanupLater" && f.Context == "goroutine" {
+			goroutines++
+		}
+	}
+
+	if goroutines != 1 {
+		t.Errorf("expected the go statement to be reported in goroutine context, got %+v", out.Findings)
+	}
+
+	if _, ok := got["removeChecked os.Remove"]; ok {
+		t.Error("expected functions returning an error to be skipped")
+	}
+
+	if _, ok := got["writeName (*strings.Builder).WriteString"]; ok || out.Excluded == 0 {
+		t.Errorf("expected excluded callee to be skipped and counted, excluded=%d", out.Excluded)
+	}
+
+	if len(out.Packages) == 0 || out.Packages[0].Package != "sample" || out.Packages[0].Test == 0 || out.Packages[0].Defer == 0 {
+		t.Errorf("unexpected package counts %+v", out.Packages)
+	}
+}
+
+func TestFindSwallowedErrors_SkipsTestsByDefault(t *testing.T) {
+	t.Parallel()
+
+	_, out, err := tools.FindSwallowedErrors(context.Background(), &mcp.CallToolRequest{}, tools.FindSwallowedErrorsInput{Dir: testDir()})
+	if err != nil {
+		t.Fatalf("FindSwallowedErrors error: %v", err)
+	}
+
+	for _, f := range out.Findings {
+		if f.InTest {
+			t.Errorf("expected no test findings without includeTests, got %+v", f)
+		}
+	}
+}
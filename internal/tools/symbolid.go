@@ -0,0 +1,419 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Symbol IDs name a declared object independently of its position, so results of different calls can be
+// joined after edits shift lines. The forms are:
+//
+//	<package path>.<Name>#<kind>              package-level func, var, const or type
+//	<package path>.<Type>.<Name>#func         method, of a concrete type or an interface
+//	<package path>.<Type>.<Name>#field        field of a named struct type
+//	<package path>.<Type>.<Name>#type         type parameter of a generic type
+//	<package path>.<Func>.<Name>#<kind>[@N]   local of the function Func (Type.Method for methods, init for
+//	                                          init functions and package-level function literals)
+//
+// kind is one of func, var, const, type, field, package or label. Locals sharing owner, name and kind
+// (shadowed variables, fields of anonymous structs) and init functions get @N, their 1-based rank in
+// source order. IDs are derived from declarations only and are stable across runs and loads.
+
+// symbolIDKind returns the kind part of the symbol ID of obj.
+func symbolIDKind(obj types.Object) string {
+	switch o := obj.(type) {
+	case *types.Var:
+		if o.IsField() {
+			return "field"
+		}
+
+		return "var"
+	case *types.Func:
+		return "func"
+	case *types.Const:
+		return "const"
+	case *types.TypeName:
+		return "type"
+	case *types.PkgName:
+		return "package"
+	case *types.Label:
+		return "label"
+	}
+
+	return ""
+}
+
+// syntaxSymbolID builds a symbol ID from declaration syntax, for package-level declarations found without
+// type information; owner is the receiver or struct type, if any. init functions have none, as their rank
+// spans the files of the package.
+func syntaxSymbolID(pkgPath, owner, name, kind string) string {
+	if name == "init" && owner == "" && kind == "func" {
+		return ""
+	}
+
+	if owner != "" {
+		name = owner + "." + name
+	}
+
+	return pkgPath + "." + name + "#" + kind
+}
+
+// symbolID returns the symbol ID of obj, declared in the package whose type information is info. Objects
+// of no package (builtins, the universe) have none.
+func symbolID(info *types.Info, obj types.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+
+	owner, local := symbolOwner(info, obj)
+	id := obj.Pkg().Path() + "." + obj.Name() + "#" + symbolIDKind(obj)
+
+	if owner != "" {
+		id = obj.Pkg().Path() + "." + owner + "." + obj.Name() + "#" + symbolIDKind(obj)
+	}
+
+	if !local || info == nil {
+		return id
+	}
+
+	var peers []types.Object
+
+	for ident, def := range info.Defs {
+		if def == nil || ident.Name != obj.Name() || symbolIDKind(def) != symbolIDKind(obj) {
+			continue
+		}
+
+		if o, _ := symbolOwner(info, def); o == owner {
+			peers = append(peers, def)
+		}
+	}
+
+	if len(peers) < 2 {
+		return id
+	}
+
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Pos() < peers[j].Pos() })
+
+	for i, peer := range peers {
+		if peer.Pos() == obj.Pos() {
+			return fmt.Sprintf("%s@%d", id, i+1)
+		}
+	}
+
+	return id
+}
+
+// declSymbolID returns the symbol ID of the declaration named by ident in pkg, built from the syntax when
+// pkg has no type information; owner is the receiver or struct type of a package-level declaration.
+func declSymbolID(pkg *packages.Package, ident *ast.Ident, owner, kind string) string {
+	if hasTypes(pkg) {
+		if obj := pkg.TypesInfo.Defs[ident]; obj != nil {
+			return symbolID(pkg.TypesInfo, obj)
+		}
+	}
+
+	return syntaxSymbolID(normalizePackagePath(pkg), owner, ident.Name, kind)
+}
+
+// objectSymbolID returns the symbol ID of obj, using the type information of its package among pkgs.
+func objectSymbolID(pkgs []*packages.Package, obj types.Object) string {
+	for _, pkg := range pkgs {
+		if hasTypes(pkg) && pkg.Types == obj.Pkg() {
+			return symbolID(pkg.TypesInfo, obj)
+		}
+	}
+
+	return symbolID(nil, obj)
+}
+
+// sameDeclaration reports whether a and b are the same declared object, possibly seen from different
+// packages or instantiations. Unlike sameObject it never matches distinct objects of the same name and
+// type, so it selects one of several same-named locals.
+func sameDeclaration(a, b types.Object) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	return a == b || (a.Pos().IsValid() && a.Pos() == b.Pos() && a.Name() == b.Name())
+}
+
+// symbolOwner returns the owner part of the symbol ID of obj and whether obj is local, i.e. may share its
+// ID with other objects and needs a rank.
+func symbolOwner(info *types.Info, obj types.Object) (string, bool) {
+	pkgScope := obj.Pkg().Scope()
+
+	if fn, ok := obj.(*types.Func); ok && fn.Name() == "init" && fn.Signature().Recv() == nil {
+		return "", true // a package may have several init functions
+	}
+
+	if obj.Parent() == pkgScope {
+		return "", false
+	}
+
+	switch o := obj.(type) {
+	case *types.Func:
+		if sig, ok := o.Type().(*types.Signature); ok && sig.Recv() != nil {
+			if tn := namedTypeName(sig.Recv().Type()); tn != nil {
+				return typeOwner(info, tn)
+			}
+		}
+	case *types.Var:
+		if o.IsField() {
+			if tn := fieldOwner(info, o); tn != nil {
+				return typeOwner(info, tn)
+			}
+		}
+	case *types.TypeName:
+		if tn := typeParamOwner(o); tn != nil {
+			return tn.Name(), false
+		}
+	}
+
+	if _, ok := obj.(*types.PkgName); ok {
+		return "", true // imports are declared per file
+	}
+
+	// Fields of anonymous structs and methods of interface literals have no scope of their own, labels
+	// one apart from the other scopes.
+	owner := ""
+
+	if s := obj.Parent(); s != nil {
+		for s != nil && (s.Parent() == nil || s.Parent().Parent() != pkgScope) {
+			s = s.Parent()
+		}
+
+		owner = funcWithScope(pkgScope, s)
+	}
+
+	if owner == "" {
+		owner = funcContaining(pkgScope, obj.Pos())
+	}
+
+	if owner == "" {
+		// init functions are not declared in the package scope; function literals of package-level
+		// initializers run at initialization too.
+		owner = "init"
+	}
+
+	return owner, true
+}
+
+// typeParamOwner returns the generic type declaring the type parameter tn, nil for any other type name.
+func typeParamOwner(tn *types.TypeName) *types.TypeName {
+	tp, ok := tn.Type().(*types.TypeParam)
+	if !ok {
+		return nil
+	}
+
+	scope := tn.Pkg().Scope()
+	for _, name := range scope.Names() {
+		if generic, ok := scope.Lookup(name).(*types.TypeName); ok {
+			if named, ok := generic.Type().(*types.Named); ok {
+				for i := range named.TypeParams().Len() {
+					if named.TypeParams().At(i) == tp {
+						return generic
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// typeOwner returns the owner part for members of the type tn: its name, prefixed with the owner of a
+// local type.
+func typeOwner(info *types.Info, tn *types.TypeName) (string, bool) {
+	if tn.Parent() == tn.Pkg().Scope() {
+		return tn.Name(), false
+	}
+
+	owner, _ := symbolOwner(info, tn)
+	if owner == "" {
+		return tn.Name(), true
+	}
+
+	return owner + "." + tn.Name(), true
+}
+
+// namedTypeName returns the type name of t or *t when it is a named type.
+func namedTypeName(t types.Type) *types.TypeName {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		return named.Origin().Obj()
+	}
+
+	return nil
+}
+
+// fieldOwner returns the named struct type declaring the field v: a package-level type, or a local one
+// from info; nil for fields of anonymous structs.
+func fieldOwner(info *types.Info, v *types.Var) *types.TypeName {
+	declares := func(tn *types.TypeName) bool {
+		st, ok := tn.Type().Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+
+		for i := range st.NumFields() {
+			if st.Field(i) == v {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	scope := v.Pkg().Scope()
+	for _, name := range scope.Names() {
+		if tn, ok := scope.Lookup(name).(*types.TypeName); ok && declares(tn) {
+			return tn
+		}
+	}
+
+	if info == nil {
+		return nil
+	}
+
+	for _, def := range info.Defs {
+		if tn, ok := def.(*types.TypeName); ok && tn.Parent() != scope && declares(tn) {
+			return tn
+		}
+	}
+
+	return nil
+}
+
+// packageFuncs calls fn with every package-level function and method of pkgScope and its owner name.
+func packageFuncs(pkgScope *types.Scope, fn func(f *types.Func, name string) bool) {
+	for _, name := range pkgScope.Names() {
+		switch obj := pkgScope.Lookup(name).(type) {
+		case *types.Func:
+			if !fn(obj, obj.Name()) {
+				return
+			}
+		case *types.TypeName:
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+
+			for i := range named.NumMethods() {
+				if m := named.Method(i); !fn(m, obj.Name()+"."+m.Name()) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// funcWithScope returns the name of the function whose scope is s, "" when there is none (a function
+// literal in a package-level initializer).
+func funcWithScope(pkgScope, s *types.Scope) string {
+	owner := ""
+
+	packageFuncs(pkgScope, func(f *types.Func, name string) bool {
+		if f.Scope() == s {
+			owner = name
+
+			return false
+		}
+
+		return true
+	})
+
+	return owner
+}
+
+// funcContaining returns the name of the function whose scope contains pos, "" when there is none.
+func funcContaining(pkgScope *types.Scope, pos token.Pos) string {
+	owner := ""
+
+	packageFuncs(pkgScope, func(f *types.Func, name string) bool {
+		if s := f.Scope(); s != nil && s.Pos() <= pos && pos < s.End() {
+			owner = name
+
+			return false
+		}
+
+		return true
+	})
+
+	return owner
+}
+
+// findSymbolTarget resolves the symbol a lookup tool is asked about: the one with the given symbol ID when
+// there is one, the one named ident of kind in package pkgPath as findTargetObject finds it otherwise.
+// Symbols not found are remembered for pkgs for a short while (see negativeLookups).
+func findSymbolTarget(ctx context.Context, pkgs []*packages.Package, dir, symbolID, ident, kind, pkgPath string) (types.Object, error) {
+	key := negativeLookupKey(dir, symbolID, ident, kind, pkgPath)
+	if err := cachedNegativeLookup(key, pkgs); err != nil {
+		return nil, err
+	}
+
+	target, err := resolveSymbolTarget(ctx, pkgs, dir, symbolID, ident, kind, pkgPath)
+	if err != nil {
+		rememberNegativeLookup(key, pkgs, err)
+
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// resolveSymbolTarget resolves the symbol of findSymbolTarget without consulting remembered lookups.
+func resolveSymbolTarget(ctx context.Context, pkgs []*packages.Package, dir, symbolID, ident, kind, pkgPath string) (types.Object, error) {
+	if symbolID != "" {
+		return resolveSymbolID(ctx, pkgs, symbolID)
+	}
+
+	target, err := findTargetObject(ctx, pkgs, dir, ident, kind, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if target == nil {
+		return nil, missingSymbol(pkgs, ident, kind, "symbol %q not found", ident)
+	}
+
+	return target, nil
+}
+
+// resolveSymbolID returns the object whose symbol ID is id, looking only at packages whose path prefixes
+// it.
+func resolveSymbolID(ctx context.Context, pkgs []*packages.Package, id string) (types.Object, error) {
+	path, kind, ok := strings.Cut(id, "#")
+	if !ok || kind == "" || !strings.Contains(path, ".") {
+		return nil, invalidInput("symbolId %q is not of the form <package path>.<Name>#<kind>", id)
+	}
+
+	name := path[strings.LastIndexByte(path, '.')+1:]
+
+	for _, pkg := range pkgs {
+		if shouldStop(ctx) {
+			return nil, ctx.Err()
+		}
+
+		if !hasTypes(pkg) || !strings.HasPrefix(path, pkg.Types.Path()+".") {
+			continue
+		}
+
+		for ident, def := range pkg.TypesInfo.Defs {
+			if def != nil && ident.Name == name && symbolID(pkg.TypesInfo, def) == id {
+				return def, nil
+			}
+		}
+	}
+
+	return nil, notFound(nil, "symbol %q not found", id)
+}
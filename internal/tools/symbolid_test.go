@@ -0,0 +1,145 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const symbolIDSource = `package lang
+
+type Store struct{ items []int }
+
+func (s *Store) Add(v int) { s.items = append(s.items, v) }
+
+func Sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+
+	{
+		total := 1
+		_ = total
+	}
+
+	return total
+}
+`
+
+// listedSymbolIDs returns the symbol IDs of listSymbols by symbol name.
+func listedSymbolIDs(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	_, out, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, tools.ListSymbolsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("ListSymbols: %v", err)
+	}
+
+	ids := make(map[string]string)
+
+	for _, pkg := range out.GroupedSymbols {
+		for _, file := range pkg.Files {
+			for _, sym := range file.Symbols {
+				ids[sym.Name] = sym.SymbolID
+			}
+		}
+	}
+
+	return ids
+}
+
+func TestSymbolID_ListReferenceRenameRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": symbolIDSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	ids := listedSymbolIDs(t, dir)
+	if ids["Add"] != "lang.Store.Add#func" || ids["Store"] != "lang.Store#type" || ids["Sum"] != "lang.Sum#func" {
+		t.Fatalf("listed IDs = %v", ids)
+	}
+
+	_, refs, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, SymbolID: ids["Add"]})
+	if err != nil {
+		t.Fatalf("FindReferences: %v", err)
+	}
+
+	if refs.SymbolID != ids["Add"] || refs.Total != 1 || refs.Groups[0].References[0].Line != 5 {
+		t.Fatalf("references of %s = %+v", ids["Add"], refs)
+	}
+
+	_, best, err := tools.FindBestContext(ctx, req, tools.FindBestContextInput{Dir: dir, SymbolID: ids["Store"]})
+	if err != nil {
+		t.Fatalf("FindBestContext: %v", err)
+	}
+
+	if best.Symbol != "Store" || best.Definition == nil || best.Definition.SymbolID != ids["Store"] {
+		t.Fatalf("context of %s = %+v", ids["Store"], best)
+	}
+
+	_, renamed, err := tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, SymbolID: ids["Add"], NewName: "Push"})
+	if err != nil || len(renamed.Collisions) > 0 {
+		t.Fatalf("RenameSymbol: %+v, %v", renamed, err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, "lang.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(src), "func (s *Store) Push(v int)") {
+		t.Fatalf("Add was not renamed:\n%s", src)
+	}
+
+	if ids := listedSymbolIDs(t, dir); ids["Push"] != "lang.Store.Push#func" {
+		t.Errorf("renamed method ID = %q", ids["Push"])
+	}
+}
+
+func TestSymbolID_SelectsShadowedLocal(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": symbolIDSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, refs, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, SymbolID: "lang.Sum.total#var@2"})
+	if err != nil {
+		t.Fatalf("FindReferences: %v", err)
+	}
+
+	var lines []int
+	for _, group := range refs.Groups {
+		for _, ref := range group.References {
+			lines = append(lines, ref.Line)
+		}
+	}
+
+	if len(lines) != 2 || lines[0] != 14 || lines[1] != 15 {
+		t.Errorf("references of the inner total = %v, want lines 14 and 15", lines)
+	}
+
+	_, defs, err := tools.FindDefinitions(ctx, req, tools.FindDefinitionsInput{Dir: dir, Ident: "Add"})
+	if err != nil || defs.Total != 1 || defs.Groups[0].Definitions[0].SymbolID != "lang.Store.Add#func" {
+		t.Errorf("definitions of Add = %+v, %v", defs, err)
+	}
+
+	_, out, err := tools.RenameSymbol(ctx, req, tools.RenameSymbolInput{Dir: dir, SymbolID: "lang.Sum.total#var@1", NewName: "sum", DryRun: true})
+	if err != nil || len(out.Diffs) != 1 {
+		t.Fatalf("RenameSymbol: %+v, %v", out, err)
+	}
+
+	if diff := out.Diffs[0].Diff; strings.Count(diff, "+\tsum") != 1 || strings.Contains(diff, "sum := 1") {
+		t.Errorf("renaming the outer total touched the inner one:\n%s", diff)
+	}
+
+	_, _, err = tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, SymbolID: "lang.Sum.total#var@3"})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound {
+		t.Errorf("expected NOT_FOUND for an unknown symbol ID, got %v", err)
+	}
+}
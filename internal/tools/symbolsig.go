@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// funcDeclSignature returns the receiver type, the compact signature and whether a function or method
+// is generic (its own type parameters or those of its receiver). Type information is used when
+// available; otherwise both strings are rendered from the declaration.
+func funcDeclSignature(decl *ast.FuncDecl, info *types.Info) (string, string, bool) {
+	if info != nil {
+		if fn, ok := info.Defs[decl.Name].(*types.Func); ok {
+			sig := fn.Type().(*types.Signature)
+			qualifier := types.RelativeTo(fn.Pkg())
+
+			receiver := ""
+			if sig.Recv() != nil {
+				receiver = types.TypeString(sig.Recv().Type(), qualifier)
+			}
+
+			return receiver, signatureString(sig, qualifier), sig.TypeParams().Len() > 0 || sig.RecvTypeParams().Len() > 0
+		}
+	}
+
+	receiver := ""
+	generic := decl.Type.TypeParams != nil && len(decl.Type.TypeParams.List) > 0
+
+	if decl.Recv != nil && len(decl.Recv.List) > 0 {
+		recvType := decl.Recv.List[0].Type
+		receiver = exprString(recvType)
+
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			recvType = star.X
+		}
+
+		switch recvType.(type) {
+		case *ast.IndexExpr, *ast.IndexListExpr:
+			generic = true
+		}
+	}
+
+	return receiver, funcTypeString(decl.Type), generic
+}
+
+// interfaceMethodSignature returns the compact signature of an interface method.
+func interfaceMethodSignature(name *ast.Ident, field *ast.Field, info *types.Info) string {
+	if info != nil {
+		if fn, ok := info.Defs[name].(*types.Func); ok {
+			return signatureString(fn.Type().(*types.Signature), types.RelativeTo(fn.Pkg()))
+		}
+	}
+
+	if ft, ok := field.Type.(*ast.FuncType); ok {
+		return funcTypeString(ft)
+	}
+
+	return ""
+}
+
+// signatureString renders parameter and result types without names, e.g. "(string, ...int) (int, error)".
+func signatureString(sig *types.Signature, qualifier types.Qualifier) string {
+	params := make([]string, 0, sig.Params().Len())
+
+	for i := range sig.Params().Len() {
+		t := sig.Params().At(i).Type()
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			if slice, ok := t.(*types.Slice); ok {
+				params = append(params, "..."+types.TypeString(slice.Elem(), qualifier))
+
+				continue
+			}
+		}
+
+		params = append(params, types.TypeString(t, qualifier))
+	}
+
+	results := make([]string, 0, sig.Results().Len())
+	for i := range sig.Results().Len() {
+		results = append(results, types.TypeString(sig.Results().At(i).Type(), qualifier))
+	}
+
+	return joinSignature(params, results)
+}
+
+// funcTypeString is signatureString for a function type expression without type information.
+func funcTypeString(ft *ast.FuncType) string {
+	return joinSignature(fieldListTypes(ft.Params), fieldListTypes(ft.Results))
+}
+
+// fieldListTypes returns one type per declared name of a field list.
+func fieldListTypes(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+
+	var list []string
+
+	for _, field := range fields.List {
+		t := exprString(field.Type)
+
+		for range max(1, len(field.Names)) {
+			list = append(list, t)
+		}
+	}
+
+	return list
+}
+
+func joinSignature(params, results []string) string {
+	sig := "(" + strings.Join(params, ", ") + ")"
+
+	switch len(results) {
+	case 0:
+		return sig
+	case 1:
+		return sig + " " + results[0]
+	}
+
+	return sig + " (" + strings.Join(results, ", ") + ")"
+}
@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of CheckTagConsistency findings.
+const (
+	tagNameMismatch = "name-mismatch"
+	tagMissingKey   = "missing-key"
+	tagSkipConflict = "skip-conflict"
+)
+
+// defaultTagKeys are the tag keys compared when the input names none.
+var defaultTagKeys = []string{"json", "yaml"}
+
+// CheckTagConsistency compares the struct tags of several serialization formats field by field: names that
+// differ between keys once options are stripped (json:"userID" yaml:"user_id"), keys missing on a field
+// while other keys are present, and fields one key skips with "-" while another names them. Without
+// requireAll a key is required on a field only when another field of the same struct uses it. Fields
+// without any of the keys and tags with an empty name (the library default) take no part in the
+// comparison of names. It works on syntax alone.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter, tag keys and requireAll
+//
+// Returns:
+//   - MCP tool call result
+//   - findings grouped by struct with a count per package
+//   - error if the keys are invalid or an error occurred while loading packages
+func CheckTagConsistency(ctx context.Context, _ *mcp.CallToolRequest, input CheckTagConsistencyInput) (
+	*mcp.CallToolResult,
+	CheckTagConsistencyOutput,
+	error,
+) {
+	keys := input.Keys
+	if len(keys) == 0 {
+		keys = defaultTagKeys
+	}
+
+	start := logStart("CheckTagConsistency", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+		newLogField("keys", strings.Join(keys, ",")),
+	))
+	out := CheckTagConsistencyOutput{Keys: keys, Structs: []TagStructReport{}, Packages: []PackageTagSummary{}}
+
+	defer func() { logEnd("CheckTagConsistency", start, out.Total) }()
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key == "" || strings.ContainsAny(key, " :\"`") || seen[key] {
+			return fail(out, invalidInput("invalid or duplicate tag key %q", key))
+		}
+
+		seen[key] = true
+	}
+
+	if len(keys) < 2 {
+		return fail(out, invalidInput("keys needs at least two tag keys to compare, got %q", keys[0]))
+	}
+
+	_, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeBasicSyntax, input.Package, "CheckTagConsistency")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	perPackage := make(map[string]int)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		pkgPath := normalizePackagePath(pkg)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			findings := checkStructTags(pkg.Fset, st, keys, input.RequireAll)
+			if len(findings) == 0 {
+				return true
+			}
+
+			out.Structs = append(out.Structs, TagStructReport{
+				Package:  pkgPath,
+				Struct:   ts.Name.Name,
+				File:     relPath,
+				Line:     pkg.Fset.Position(ts.Pos()).Line,
+				Findings: findings,
+			})
+			perPackage[pkgPath] += len(findings)
+			out.Total += len(findings)
+
+			return true
+		})
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	sort.SliceStable(out.Structs, func(i, j int) bool {
+		a, b := out.Structs[i], out.Structs[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		return a.Line < b.Line
+	})
+
+	for _, pkgPath := range sortedKeys(perPackage) {
+		out.Packages = append(out.Packages, PackageTagSummary{Package: pkgPath, Count: perPackage[pkgPath]})
+	}
+
+	return nil, out, nil
+}
+
+// fieldTags holds the values of the compared keys on one field.
+type fieldTags struct {
+	field  *ast.Field
+	name   string
+	values map[string]string // key -> full tag value, only for keys present
+}
+
+// checkStructTags returns the findings of the fields of st, in field order. A key is used by st when a
+// field names itself with it; skipping a field with "-" does not count.
+func checkStructTags(fset *token.FileSet, st *ast.StructType, keys []string, requireAll bool) []TagFinding {
+	var fields []fieldTags
+
+	used := make(map[string]bool)
+
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		raw, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		tag := reflect.StructTag(raw)
+		ft := fieldTags{field: field, name: fieldDisplayName(field), values: make(map[string]string)}
+
+		for _, key := range keys {
+			if value, ok := tag.Lookup(key); ok {
+				ft.values[key] = value
+				used[key] = used[key] || !isSkipTag(value)
+			}
+		}
+
+		if len(ft.values) > 0 {
+			fields = append(fields, ft)
+		}
+	}
+
+	var findings []TagFinding
+
+	for _, ft := range fields {
+		add := func(kind, message string) {
+			findings = append(findings, TagFinding{
+				Field:   ft.name,
+				Line:    fset.Position(ft.field.Pos()).Line,
+				Kind:    kind,
+				Tags:    ft.values,
+				Message: message,
+			})
+		}
+
+		var missing, skipped, named []string
+
+		for _, key := range keys {
+			value, ok := ft.values[key]
+
+			switch {
+			case !ok:
+				if requireAll || used[key] {
+					missing = append(missing, key)
+				}
+			case isSkipTag(value):
+				skipped = append(skipped, key)
+			default:
+				named = append(named, key)
+			}
+		}
+
+		if len(missing) > 0 {
+			add(tagMissingKey, fmt.Sprintf("has %s but no %s tag", strings.Join(presentKeys(keys, ft.values), ", "), strings.Join(missing, ", ")))
+		}
+
+		if len(skipped) > 0 && len(named) > 0 {
+			add(tagSkipConflict, fmt.Sprintf("skipped by %s (\"-\") but serialized by %s", strings.Join(skipped, ", "), strings.Join(named, ", ")))
+		}
+
+		var first string
+
+		for _, key := range named {
+			name := tagName(ft.values[key])
+			if name == "" {
+				continue
+			}
+
+			if first == "" {
+				first = key
+
+				continue
+			}
+
+			if name != tagName(ft.values[first]) {
+				add(tagNameMismatch, fmt.Sprintf("%s name %q differs from %s name %q", first, tagName(ft.values[first]), key, name))
+
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// tagName returns the name part of a tag value, without options such as omitempty.
+func tagName(value string) string {
+	name, _, _ := strings.Cut(value, ",")
+
+	return name
+}
+
+// isSkipTag reports whether a tag value leaves the field out: "-" alone, as "-," names it "-".
+func isSkipTag(value string) bool {
+	return value == "-"
+}
+
+// presentKeys returns the keys of keys present in values, in keys order.
+func presentKeys(keys []string, values map[string]string) []string {
+	var present []string
+
+	for _, key := range keys {
+		if _, ok := values[key]; ok {
+			present = append(present, key)
+		}
+	}
+
+	return present
+}
+
+// fieldDisplayName returns the names of field joined by commas, or its type for an embedded field.
+func fieldDisplayName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return types.ExprString(field.Type)
+	}
+
+	return joinIdentNames(field.Names)
+}
@@ -0,0 +1,91 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const tagConsistencySource = "package lang\n\n" +
+	"type Config struct {\n" +
+	"\tUserID  string `json:\"userID\" yaml:\"user_id\"`\n" +
+	"\tName    string `json:\"name,omitempty\" yaml:\"name\"`\n" +
+	"\tSecret  string `json:\"-\" yaml:\"secret\"`\n" +
+	"\tPort    int    `json:\"port\"`\n" +
+	"\tDefault string `json:\",omitempty\" yaml:\"default\"`\n" +
+	"\tDash    string `json:\"-,\" yaml:\"-,\"`\n" +
+	"\tplain   int\n" +
+	"}\n\n" +
+	"type JSONOnly struct {\n" +
+	"\tA string `json:\"a\"`\n" +
+	"\tB string `json:\"b\" mapstructure:\"bee\"`\n" +
+	"}\n"
+
+func TestCheckTagConsistency(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"config.go":     tagConsistencySource,
+		"other/ok.go":   "package other\n\ntype OK struct {\n\tA int `json:\"a\" yaml:\"a\"`\n}\n",
+		"other/bad.go":  "package other\n\ntype Bad struct {\n\tA int `json:\"a\" yaml:\"A\"`\n}\n",
+		"other/none.go": "package other\n\ntype None struct{ A int }\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.CheckTagConsistency(ctx, req, tools.CheckTagConsistencyInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("CheckTagConsistency: %v", err)
+	}
+
+	var got []string
+
+	for _, s := range out.Structs {
+		for _, f := range s.Findings {
+			got = append(got, fmt.Sprintf("%s.%s %s:%d %s: %s", s.Package, s.Struct, s.File, f.Line, f.Kind, f.Message))
+		}
+	}
+
+	want := []string{
+		`lang.Config config.go:4 name-mismatch: json name "userID" differs from yaml name "user_id"`,
+		`lang.Config config.go:6 skip-conflict: skipped by json ("-") but serialized by yaml`,
+		`lang.Config config.go:7 missing-key: has json but no yaml tag`,
+		`lang/other.Bad other/bad.go:4 name-mismatch: json name "a" differs from yaml name "A"`,
+	}
+
+	if out.Total != len(want) || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("findings:\n%s\nwant:\n%s", got, want)
+	}
+
+	if fmt.Sprintf("%+v", out.Packages) != "[{Package:lang Count:3} {Package:lang/other Count:1}]" {
+		t.Errorf("packages = %+v", out.Packages)
+	}
+
+	_, out, err = tools.CheckTagConsistency(ctx, req, tools.CheckTagConsistencyInput{
+		Dir: dir, Package: "lang", Keys: []string{"json", "mapstructure"}, RequireAll: true,
+	})
+	if err != nil {
+		t.Fatalf("CheckTagConsistency with keys: %v", err)
+	}
+
+	var jsonOnly []string
+
+	for _, s := range out.Structs {
+		if s.Struct == "JSONOnly" {
+			for _, f := range s.Findings {
+				jsonOnly = append(jsonOnly, f.Field+" "+f.Kind)
+			}
+		}
+	}
+
+	if fmt.Sprint(jsonOnly) != "[A missing-key B name-mismatch]" {
+		t.Errorf("JSONOnly with requireAll = %v", jsonOnly)
+	}
+
+	_, _, err = tools.CheckTagConsistency(ctx, req, tools.CheckTagConsistencyInput{Dir: dir, Keys: []string{"json"}})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a single key, got %v", err)
+	}
+}
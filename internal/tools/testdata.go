@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"go/build"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// testdataKey marks contexts whose package loads include the testdata directories below dir.
+type testdataKey struct{}
+
+// withTestdata returns ctx with the testdata directories below dir added to its package loads when
+// include is set. Like the go command, loads skip them otherwise: they hold fixtures, often broken or
+// dead code on purpose.
+func withTestdata(ctx context.Context, include bool) context.Context {
+	if !include {
+		return ctx
+	}
+
+	return context.WithValue(ctx, testdataKey{}, true)
+}
+
+// includesTestdata reports whether loads for ctx include the testdata directories below dir.
+func includesTestdata(ctx context.Context) bool {
+	include, _ := ctx.Value(testdataKey{}).(bool)
+
+	return include
+}
+
+// loadPatterns returns the package patterns of a load of dir: "./..." and, when ctx includes testdata,
+// one pattern per testdata package, which "./..." never matches.
+func loadPatterns(ctx context.Context, dir string) []string {
+	patterns := []string{"./..."}
+
+	if includesTestdata(ctx) {
+		for _, rel := range testdataPackageDirs(dir) {
+			patterns = append(patterns, "./"+rel)
+		}
+	}
+
+	return patterns
+}
+
+// skippedTestdataPackages returns the number of testdata packages below dir a load for ctx leaves out.
+func skippedTestdataPackages(ctx context.Context, dir string) int {
+	if includesTestdata(ctx) {
+		return 0
+	}
+
+	return len(testdataPackageDirs(dir))
+}
+
+// testdataPackageDirs returns the directories inside testdata trees below dir, relative to dir in slash
+// form and sorted, that hold non-test Go files of the current build. Nested modules, vendor, hidden and
+// _ directories are skipped as by "./...".
+func testdataPackageDirs(dir string) []string {
+	root := CanonicalDir(dir)
+
+	var dirs []string
+
+	_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+
+		name := d.Name()
+		if p != root {
+			if name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir // nested module
+			}
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+
+		rel = filepath.ToSlash(rel)
+		if slices.Contains(strings.Split(rel, "/"), "testdata") && hasBuildableGoFiles(p) {
+			dirs = append(dirs, rel)
+		}
+
+		return nil
+	})
+
+	slices.Sort(dirs)
+
+	return dirs
+}
+
+// hasBuildableGoFiles reports whether dir holds a non-test Go file matching the current build context.
+func hasBuildableGoFiles(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); err == nil && match {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package sample
+
+import (
+	"fmt"
+	"strings"
+)
+
+type allocNode struct {
+	name string
+}
+
+func newAllocNode(name string) *allocNode {
+	return &allocNode{name: name}
+}
+
+func joinGrid(rows [][]string) string {
+	var cells []string
+
+	sized := make([]string, 0, len(rows))
+
+	out := ""
+
+	for _, row := range rows {
+		sized = append(sized, strings.Join(row, ","))
+
+		for _, cell := range row {
+			cells = append(cells, cell)
+			out += cell
+		}
+	}
+
+	return out + strings.Join(cells, ";") + strings.Join(sized, ";")
+}
+
+func indexByKey(items []allocNode, counts map[string]int, seen map[string]bool) {
+	for i, item := range items {
+		counts[fmt.Sprintf("%s-%d", item.name, i)]++
+
+		raw := []byte(item.name)
+		if seen[string(raw)] || string(raw) == "skip" {
+			continue
+		}
+
+		seen[item.name] = len(string(raw)) > 0
+	}
+}
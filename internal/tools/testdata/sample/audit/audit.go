@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	. "log"
+	stdlog "log"
+)
+
+// Record writes an audit entry using both the standard logger and fmt.
+func Record(event string) error {
+	if event == "" {
+		err := errors.New("empty event")
+		stdlog.Printf("audit failed: %v", err)
+
+		return err
+	}
+
+	Println("audit:", event)
+	fmt.Println("recorded", event)
+
+	return nil
+}
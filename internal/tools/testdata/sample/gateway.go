@@ -0,0 +1,31 @@
+package sample
+
+// Fetcher is embedded by Gateway, whose callers reach Fetch through the promoted field.
+type Fetcher interface {
+	Fetch(key string) (string, error)
+}
+
+// Gateway embeds Fetcher.
+type Gateway struct {
+	Fetcher
+}
+
+// Proxy reaches Fetch through two levels of embedding.
+type Proxy struct {
+	*Gateway
+}
+
+// Lookup calls the promoted method.
+func (g Gateway) Lookup(key string) (string, error) {
+	return g.Fetch(key)
+}
+
+// Forward calls the method promoted through Gateway.
+func (p Proxy) Forward(key string) (string, error) {
+	return p.Fetch(key)
+}
+
+// FetchDirect calls the method on the interface itself.
+func FetchDirect(f Fetcher, key string) (string, error) {
+	return f.Fetch(key)
+}
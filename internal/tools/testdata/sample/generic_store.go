@@ -0,0 +1,22 @@
+package sample
+
+// Store is a generic in-memory key/value store.
+type Store[T any] struct {
+	items map[string]T
+}
+
+// Get returns the value stored under key.
+func (s *Store[T]) Get(key string) (T, bool) {
+	v, ok := s.items[key]
+
+	return v, ok
+}
+
+// Put stores v under key.
+func (s *Store[T]) Put(key string, v T) {
+	if s.items == nil {
+		s.items = make(map[string]T)
+	}
+
+	s.items[key] = v
+}
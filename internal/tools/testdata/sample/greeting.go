@@ -0,0 +1,6 @@
+package sample
+
+// FormatGreeting renders the greeting for a name; the generated GreetingRequest calls it.
+func FormatGreeting(name string) string {
+	return "hello, " + name
+}
@@ -0,0 +1,23 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: greeting.proto
+
+package sample
+
+// Greeting renders the greeting of the request.
+func (x *GreetingRequest) Greeting() string {
+	return FormatGreeting(x.GetName())
+}
+
+// GetName returns the requested name.
+func (x *GreetingRequest) GetName() string {
+	if x == nil {
+		return ""
+	}
+
+	return x.Name
+}
+
+// GreetingRequest is the request message of greeting.proto.
+type GreetingRequest struct {
+	Name string
+}
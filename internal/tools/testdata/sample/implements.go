@@ -0,0 +1,40 @@
+package sample
+
+import "io"
+
+// MemStore satisfies Storage only through its pointer receivers.
+type MemStore struct {
+	data map[string]string
+}
+
+func (s *MemStore) Save(key string, value string) error {
+	s.data[key] = value
+
+	return nil
+}
+
+func (s *MemStore) Load(key string) (string, error) {
+	return s.data[key], nil
+}
+
+// DraftStore is an incomplete Storage: Save has the wrong signature and Load is missing.
+type DraftStore struct{}
+
+func (d DraftStore) Save(key string) error {
+	return nil
+}
+
+// Job satisfies Bar with a value receiver but is not an io.Writer.
+type Job struct{}
+
+func (Job) Run(x int) error {
+	return nil
+}
+
+var _ io.Reader = (*nopReader)(nil)
+
+type nopReader struct{}
+
+func (*nopReader) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
@@ -0,0 +1,22 @@
+package textutil
+
+import "strings"
+
+// Title upper-cases the first letter of s.
+func Title(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// Reverse returns s with its runes in reverse order. Nothing in the module calls it.
+func Reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+
+	return string(runes)
+}
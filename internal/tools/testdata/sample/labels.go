@@ -0,0 +1,8 @@
+package sample
+
+import "sample/internal/textutil"
+
+// Label returns a display label for a widget name.
+func Label(name string) string {
+	return textutil.Title(name)
+}
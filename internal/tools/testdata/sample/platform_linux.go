@@ -0,0 +1,6 @@
+package sample
+
+// PlatformName reports the platform the package was built for.
+func PlatformName() string {
+	return "linux"
+}
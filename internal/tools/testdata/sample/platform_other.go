@@ -0,0 +1,8 @@
+//go:build !linux && !windows
+
+package sample
+
+// PlatformName reports the platform the package was built for.
+func PlatformName() string {
+	return "other"
+}
@@ -0,0 +1,25 @@
+package sample
+
+// Point is a 2D coordinate constructed in several ways.
+type Point struct {
+	X, Y int
+}
+
+// NewPoint returns a pointer to a new Point.
+func NewPoint(x, y int) *Point {
+	return &Point{X: x, Y: y}
+}
+
+func origin() Point {
+	return Point{0, 0}
+}
+
+func corners() []Point {
+	return []Point{{0, 0}, {X: 1, Y: 1}}
+}
+
+func blankPoint() *Point {
+	return new(Point)
+}
+
+var defaultPoint = NewPoint(1, 2)
@@ -5,3 +5,8 @@ import "fmt"
 func show(x string) {
 	fmt.Println(x)
 }
+
+// Logf prints a formatted message.
+func Logf(format string, args ...any) (int, error) {
+	return fmt.Printf(format, args...)
+}
@@ -0,0 +1,33 @@
+package sample
+
+import "os"
+
+var counter int
+
+var registry = map[string]int{}
+
+func PureAdd(a, b int) int {
+	return a + b
+}
+
+func ReadCounter() int {
+	return counter
+}
+
+func bumpCounter() {
+	counter++
+}
+
+func Register(name string) {
+	registry[name] = len(name)
+}
+
+func writeReport(path string) error {
+	return os.WriteFile(path, nil, 0o644)
+}
+
+func SaveReport(path string) error {
+	bumpCounter()
+
+	return writeReport(path)
+}
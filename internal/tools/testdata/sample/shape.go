@@ -0,0 +1,38 @@
+package sample
+
+// Audit carries bookkeeping fields embedded into API payloads.
+type Audit struct {
+	ID        int    `json:"id"`
+	CreatedBy string `json:"created_by,omitempty"`
+	Note      string
+}
+
+// Meta carries descriptive fields embedded into API payloads.
+type Meta struct {
+	ID   string   `json:"id"`
+	Note string   `yaml:"note"`
+	Tags []string `json:"tags"`
+}
+
+type traceInfo struct {
+	Trace string `json:"trace"`
+}
+
+// Payload is serialized to JSON; its shape depends on embedding and tags.
+type Payload struct {
+	Audit
+	*Meta
+	traceInfo
+	Name     string   `json:"name"`
+	Labels   []string `json:"labels" yaml:",omitempty"`
+	Secret   string   `json:"-" yaml:"-"`
+	Dash     string   `json:"-,"`
+	Count    int      `json:",string"`
+	password string
+}
+
+// InlinePayload flattens Audit into its YAML mapping.
+type InlinePayload struct {
+	Audit `yaml:",inline"`
+	Note  string `yaml:"note"`
+}
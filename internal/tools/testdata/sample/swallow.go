@@ -0,0 +1,39 @@
+package sample
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+func closeQuietly(f *os.File) {
+	f.Close()
+}
+
+func removeTemp(path string) {
+	_ = os.Remove(path)
+}
+
+func readQuietly(r io.Reader) []byte {
+	data, _ := io.ReadAll(r)
+
+	return data
+}
+
+func writeName(b *strings.Builder, name string) {
+	b.WriteString(name)
+}
+
+func cleanupLater(path string) {
+	go os.Remove(path)
+
+	defer func() {
+		os.Remove(path)
+	}()
+}
+
+func removeChecked(path string) error {
+	os.Remove(path)
+
+	return nil
+}
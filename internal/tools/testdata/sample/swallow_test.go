@@ -0,0 +1,11 @@
+package sample
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRemoveTemp(t *testing.T) {
+	os.Remove("missing")
+	removeTemp("missing")
+}
@@ -0,0 +1,43 @@
+package sample
+
+import "strings"
+
+// Celsius is a temperature in degrees Celsius.
+type Celsius float64
+
+const (
+	Freezing Celsius = 0
+	Boiling  Celsius = 100
+)
+
+// String formats the temperature.
+func (c Celsius) String() string {
+	return "celsius"
+}
+
+// Level is a logging level.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+)
+
+// Handlers maps route names to handlers.
+type Handlers map[string]func() error
+
+// Names returns the route names in a comma separated list.
+func (h Handlers) Names() string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Register adds a handler.
+func (h *Handlers) Register(name string, fn func() error) {
+	(*h)[name] = fn
+}
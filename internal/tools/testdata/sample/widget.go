@@ -0,0 +1,19 @@
+package sample
+
+func (w *Widget) reset() {
+	w.size = 0
+}
+
+// Widget is a sized thing.
+type Widget struct {
+	size int
+}
+
+func (w *Widget) Size() int {
+	return w.size
+}
+
+// NewWidget returns a widget of the given size.
+func NewWidget(size int) *Widget {
+	return &Widget{size: size}
+}
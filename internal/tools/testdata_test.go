@@ -0,0 +1,53 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestDeadCode_SkipsTestdata(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"a.go":                       "package lang\n\nfunc A() { used() }\n\nfunc used() {}\n",
+		"testdata/fixture/f.go":      "package fixture\n\nfunc fixtureDead() {}\n",
+		"testdata/fixture/f_test.go": "package fixture\n",
+		"testdata/notes/README.md":   "not go\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("DeadCode: %v", err)
+	}
+
+	if out.TotalCount != 0 || out.SkippedTestdataPackages != 1 {
+		t.Errorf("default: %d dead, %d skipped; want 0 dead and the fixture package skipped: %+v",
+			out.TotalCount, out.SkippedTestdataPackages, out.Unused)
+	}
+
+	_, out, err = tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, IncludeTestdata: true})
+	if err != nil {
+		t.Fatalf("DeadCode: %v", err)
+	}
+
+	if out.TotalCount != 1 || out.Unused[0].Name != "fixtureDead" || out.Unused[0].File != "testdata/fixture/f.go" ||
+		out.SkippedTestdataPackages != 0 {
+		t.Errorf("includeTestdata: %+v, want fixtureDead", out)
+	}
+
+	// The cached load without testdata must not answer the request with it, nor the other way round.
+	_, list, err := tools.ListPackages(ctx, req, tools.ListPackagesInput{Dir: dir})
+	if err != nil || slices.Contains(list.Packages, "lang/testdata/fixture") || list.SkippedTestdataPackages != 1 {
+		t.Errorf("listPackages: %+v, %v", list, err)
+	}
+
+	_, list, err = tools.ListPackages(ctx, req, tools.ListPackagesInput{Dir: dir, IncludeTestdata: true})
+	if err != nil || !slices.Contains(list.Packages, "lang/testdata/fixture") {
+		t.Errorf("listPackages with testdata: %+v, %v", list, err)
+	}
+}
@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of FindTypeAssertions sites.
+const (
+	assertionKindAssert = "assertion"
+	assertionKindSwitch = "switch"
+)
+
+// FindTypeAssertions lists the type assertions x.(T) and type switches whose operand has an interface as
+// its static type: the coupling points between an interface and the concrete types its users expect.
+// interfaceName restricts the report to one interface; without it every interface is reported. Assertions
+// in the single-value form, which panic on a mismatch, are marked panicking, and type switches list all
+// case types and whether a default case exists.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional interface and package filters
+//
+// Returns:
+//   - MCP tool call result
+//   - sites grouped by interface and file
+//   - error if an error occurred while loading packages
+func FindTypeAssertions(ctx context.Context, _ *mcp.CallToolRequest, input FindTypeAssertionsInput) (
+	*mcp.CallToolResult,
+	FindTypeAssertionsOutput,
+	error,
+) {
+	start := logStart("FindTypeAssertions", logFields(
+		input.Dir,
+		newLogField("interfaceName", input.InterfaceName),
+		newLogField("package", input.Package),
+	))
+	out := FindTypeAssertionsOutput{Interfaces: []TypeAssertionInterface{}}
+
+	defer func() { logEnd("FindTypeAssertions", start, out.Total) }()
+
+	pkgs, filteredPkgs, err := loadFilteredPackages(ctx, input.Dir, loadModeSyntaxTypesNamed, input.Package, "FindTypeAssertions")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if err := checkInterfaceFilter(pkgs, input.InterfaceName); err != nil {
+		return fail(out, err)
+	}
+
+	// interface -> file -> sites
+	sites := make(map[string]map[string][]TypeAssertionSite)
+
+	if err := walkPackageFiles(ctx, filteredPkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		qualifier := (*types.Package).Name
+
+		for _, decl := range file.Decls {
+			function := ""
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				function = qualifiedFuncName(fd)
+			}
+
+			for _, site := range collectTypeAssertions(pkg.TypesInfo, decl, qualifier) {
+				if input.InterfaceName != "" && !matchesInterfaceName(site.iface, input.InterfaceName) {
+					continue
+				}
+
+				site.Line = pkg.Fset.Position(site.pos).Line
+				site.Function = function
+
+				if sites[site.iface] == nil {
+					sites[site.iface] = make(map[string][]TypeAssertionSite)
+				}
+
+				sites[site.iface][relPath] = append(sites[site.iface][relPath], site.TypeAssertionSite)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	for iface, byFile := range sites {
+		group := TypeAssertionInterface{Interface: iface}
+
+		for file, list := range byFile {
+			sort.Slice(list, func(i, j int) bool { return list[i].Line < list[j].Line })
+
+			group.Count += len(list)
+			group.Files = append(group.Files, TypeAssertionFile{File: file, Sites: list})
+		}
+
+		sort.Slice(group.Files, func(i, j int) bool { return group.Files[i].File < group.Files[j].File })
+
+		out.Interfaces = append(out.Interfaces, group)
+		out.Total += group.Count
+	}
+
+	sort.Slice(out.Interfaces, func(i, j int) bool {
+		if out.Interfaces[i].Count != out.Interfaces[j].Count {
+			return out.Interfaces[i].Count > out.Interfaces[j].Count
+		}
+
+		return out.Interfaces[i].Interface < out.Interfaces[j].Interface
+	})
+
+	return nil, out, nil
+}
+
+// typeAssertionSite is a TypeAssertionSite with the position and operand interface it was found at.
+type typeAssertionSite struct {
+	TypeAssertionSite
+
+	iface string
+	pos   token.Pos
+}
+
+// collectTypeAssertions returns the assertions and type switches in node whose operand is an interface.
+// Types are rendered with qualifier. Lines and functions are left to the caller.
+func collectTypeAssertions(info *types.Info, node ast.Node, qualifier types.Qualifier) []typeAssertionSite {
+	var (
+		found   []typeAssertionSite
+		commaOK = make(map[*ast.TypeAssertExpr]bool)
+		inCase  = make(map[*ast.TypeAssertExpr]bool)
+	)
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 && len(n.Rhs) == 1 {
+				if ta, ok := ast.Unparen(n.Rhs[0]).(*ast.TypeAssertExpr); ok {
+					commaOK[ta] = true
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 2 && len(n.Values) == 1 {
+				if ta, ok := ast.Unparen(n.Values[0]).(*ast.TypeAssertExpr); ok {
+					commaOK[ta] = true
+				}
+			}
+		case *ast.TypeSwitchStmt:
+			ta := typeSwitchAssert(n)
+			if ta == nil {
+				return true
+			}
+
+			inCase[ta] = true
+
+			iface, ok := interfaceOperand(info, ta.X, qualifier)
+			if !ok {
+				return true
+			}
+
+			site := typeAssertionSite{iface: iface, pos: n.Pos()}
+			site.Kind = assertionKindSwitch
+			site.Operand = types.ExprString(ta.X)
+			site.NoDefault = true
+
+			for _, stmt := range n.Body.List {
+				clause, ok := stmt.(*ast.CaseClause)
+				if !ok {
+					continue
+				}
+
+				if clause.List == nil {
+					site.NoDefault = false
+				}
+
+				for _, expr := range clause.List {
+					site.Types = append(site.Types, caseTypeString(info, expr, qualifier))
+				}
+			}
+
+			found = append(found, site)
+		case *ast.TypeAssertExpr:
+			if n.Type == nil || inCase[n] {
+				return true
+			}
+
+			iface, ok := interfaceOperand(info, n.X, qualifier)
+			if !ok {
+				return true
+			}
+
+			site := typeAssertionSite{iface: iface, pos: n.Pos()}
+			site.Kind = assertionKindAssert
+			site.Operand = types.ExprString(n.X)
+			site.Types = []string{caseTypeString(info, n.Type, qualifier)}
+			site.Panicking = !commaOK[n]
+
+			found = append(found, site)
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// typeSwitchAssert returns the x.(type) expression of a type switch.
+func typeSwitchAssert(stmt *ast.TypeSwitchStmt) *ast.TypeAssertExpr {
+	var expr ast.Expr
+
+	switch assign := stmt.Assign.(type) {
+	case *ast.ExprStmt:
+		expr = assign.X
+	case *ast.AssignStmt:
+		if len(assign.Rhs) == 1 {
+			expr = assign.Rhs[0]
+		}
+	}
+
+	ta, _ := ast.Unparen(expr).(*ast.TypeAssertExpr)
+
+	return ta
+}
+
+// interfaceOperand returns the rendered static type of x when it is an interface.
+func interfaceOperand(info *types.Info, x ast.Expr, qualifier types.Qualifier) (string, bool) {
+	tv, ok := info.Types[x]
+	if !ok || tv.Type == nil || !types.IsInterface(tv.Type) {
+		return "", false
+	}
+
+	return types.TypeString(tv.Type, qualifier), true
+}
+
+// caseTypeString renders the type of an asserted type or case expression; nil stays "nil".
+func caseTypeString(info *types.Info, expr ast.Expr, qualifier types.Qualifier) string {
+	if tv, ok := info.Types[expr]; ok && tv.Type != nil && !tv.IsNil() {
+		return types.TypeString(tv.Type, qualifier)
+	}
+
+	return types.ExprString(expr)
+}
+
+// matchesInterfaceName reports whether the rendered interface iface is name, with or without its
+// package qualifier.
+func matchesInterfaceName(iface, name string) bool {
+	if iface == name {
+		return true
+	}
+
+	_, bare, ok := strings.Cut(iface, ".")
+
+	return ok && !strings.ContainsAny(bare, ".{") && bare == name
+}
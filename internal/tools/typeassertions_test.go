@@ -0,0 +1,99 @@
+package tools_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const typeAssertionsSource = `package lang
+
+type Shape interface{ Area() float64 }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Circle struct{ R float64 }
+
+func (c *Circle) Area() float64 { return 3 * c.R * c.R }
+
+func Side(s Shape) float64 {
+	return s.(Square).Side
+}
+
+func Radius(s Shape) (float64, bool) {
+	c, ok := s.(*Circle)
+	if !ok {
+		return 0, false
+	}
+
+	return c.R, true
+}
+
+func Describe(s Shape) string {
+	switch v := s.(type) {
+	case Square, *Circle:
+		_ = v
+		return "known"
+	case nil:
+		return "nil"
+	}
+
+	return "unknown"
+}
+
+func Kind(v any) string {
+	switch v.(type) {
+	case int:
+		return "int"
+	default:
+		return "other"
+	}
+}
+
+func Concrete(sq Square) float64 { return sq.Area() }
+`
+
+func TestFindTypeAssertions(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": typeAssertionsSource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.FindTypeAssertions(ctx, req, tools.FindTypeAssertionsInput{Dir: dir, InterfaceName: "Shape"})
+	if err != nil {
+		t.Fatalf("FindTypeAssertions error: %v", err)
+	}
+
+	if out.Total != 3 || len(out.Interfaces) != 1 || out.Interfaces[0].Interface != "lang.Shape" {
+		t.Fatalf("expected 3 sites over lang.Shape, got %+v", out)
+	}
+
+	want := []tools.TypeAssertionSite{
+		{Kind: "assertion", Line: 14, Function: "Side", Operand: "s", Types: []string{"lang.Square"}, Panicking: true},
+		{Kind: "assertion", Line: 18, Function: "Radius", Operand: "s", Types: []string{"*lang.Circle"}},
+		{Kind: "switch", Line: 27, Function: "Describe", Operand: "s", Types: []string{"lang.Square", "*lang.Circle", "nil"}, NoDefault: true},
+	}
+
+	files := out.Interfaces[0].Files
+	if len(files) != 1 || files[0].File != "lang.go" || !reflect.DeepEqual(files[0].Sites, want) {
+		t.Errorf("unexpected sites:\n got %+v\nwant %+v", files, want)
+	}
+
+	_, all, err := tools.FindTypeAssertions(ctx, req, tools.FindTypeAssertionsInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("FindTypeAssertions (all) error: %v", err)
+	}
+
+	if all.Total != 4 || len(all.Interfaces) != 2 || all.Interfaces[1].Interface != "any" {
+		t.Fatalf("expected lang.Shape and any, got %+v", all)
+	}
+
+	if site := all.Interfaces[1].Files[0].Sites[0]; site.Kind != "switch" || site.NoDefault || !reflect.DeepEqual(site.Types, []string{"int"}) {
+		t.Errorf("expected the switch over any with a default case, got %+v", site)
+	}
+}
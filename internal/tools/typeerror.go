@@ -0,0 +1,546 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// maxTypeErrorStatementLines caps the statement source returned by explainTypeError.
+const maxTypeErrorStatementLines = 20
+
+// ExplainTypeError bundles what fixing one type error needs: the offending statement, the expression the
+// error is reported at with its actual and expected types, the definitions of the named types and
+// functions involved, and for assignability failures the missing methods, mismatched fields or the
+// conversion to use. The error's package is type-checked again from the cached syntax to get the errors
+// and the partial type information of the ill-typed code.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and the file, line and column of the error
+//
+// Returns:
+//   - MCP tool call result
+//   - the error and its explanation bundle
+//   - error if the position is invalid, has no type error or packages cannot be loaded
+func ExplainTypeError(ctx context.Context, _ *mcp.CallToolRequest, input ExplainTypeErrorInput) (
+	*mcp.CallToolResult,
+	ExplainTypeErrorOutput,
+	error,
+) {
+	start := logStart("ExplainTypeError", logFields(
+		input.Dir,
+		newLogField("file", input.File),
+		newLogField("line", strconv.Itoa(input.Line)),
+		newLogField("column", strconv.Itoa(input.Column)),
+	))
+	out := ExplainTypeErrorOutput{}
+
+	defer func() { logEnd("ExplainTypeError", start, len(out.Definitions)) }()
+
+	if input.File == "" {
+		return fail(out, invalidInput("file is required"))
+	}
+
+	if input.Line < 1 || input.Column < 0 {
+		return fail(out, invalidInput("line must be positive and column must not be negative"))
+	}
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, loadModeSyntaxTypesNamed)
+	if err != nil {
+		logError("ExplainTypeError", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	files, err := positionFiles(ctx, pkgs, input.Dir)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	pf, err := matchPositionFile(files, input.File)
+	if err != nil {
+		return fail(out, notFound(nil, "%v", err))
+	}
+
+	if !hasTypes(pf.pkg) {
+		return fail(out, fmt.Errorf("%w for package %s", errTypesNotLoaded, pf.pkg.ID))
+	}
+
+	// The copy keeps the cached package intact; only its Types and TypesInfo are replaced.
+	checked := *pf.pkg
+	errs := recheckPackage(&checked, nil)
+
+	be, err := selectTypeError(input, pf.relPath, &checked, errs)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	out.Error = be
+
+	lines := getFileLines(checked.Fset, pf.file)
+
+	pos, err := pf.pos(lines, be.Line, be.Column)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	path, _ := astutil.PathEnclosingInterval(pf.file, pos, pos)
+	info := checked.TypesInfo
+	pkgPath := checked.Types.Path()
+	qualifier := func(p *types.Package) string {
+		if p.Path() == pkgPath {
+			return ""
+		}
+
+		return p.Name()
+	}
+
+	if stmt := enclosingStatementPath(path)[0]; stmt != nil {
+		out.Statement, out.StatementLine = statementSource(checked.Fset, lines, stmt)
+	}
+
+	expr, parents := offendingExpr(path)
+	if expr == nil {
+		return nil, out, nil
+	}
+
+	out.Expression = compactSource(lines, checked.Fset.Position(expr.Pos()), checked.Fset.Position(expr.End()))
+
+	actual := info.TypeOf(expr)
+	if actual != nil && actual != types.Typ[types.Invalid] {
+		out.Actual = types.TypeString(actual, qualifier)
+	}
+
+	expected, where, callee := expectedType(info, expr, parents)
+	if expected != nil {
+		out.Expected = types.TypeString(expected, qualifier)
+		out.Context = where
+	}
+
+	objects := newTypeErrorObjects()
+	objects.addType(actual)
+	objects.addType(expected)
+	objects.add(callee)
+
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if fn := calledFunc(info, call); fn != nil {
+			objects.add(fn)
+		}
+	}
+
+	for _, obj := range objects.list {
+		out.Definitions = append(out.Definitions, typeErrorDefinition(input.Dir, pkgs, &checked, obj, qualifier))
+	}
+
+	if actual != nil && expected != nil && !types.AssignableTo(actual, expected) {
+		explainAssignability(&out, actual, expected, qualifier)
+	}
+
+	return nil, out, nil
+}
+
+// selectTypeError picks the type error of file at the requested line: the one at the requested column,
+// else the one whose message contains the requested message, else the first. A line without type
+// errors is NOT_FOUND, with the file's type errors as candidates.
+func selectTypeError(input ExplainTypeErrorInput, relPath string, pkg *packages.Package, errs []packages.Error) (BuildError, error) {
+	var (
+		inFile []string
+		onLine []BuildError
+	)
+
+	for _, e := range errs {
+		be := newBuildError(input.Dir, pkg, e)
+		if be.File != relPath || be.Line == 0 {
+			continue
+		}
+
+		inFile = append(inFile, fmt.Sprintf("%d:%d: %s", be.Line, be.Column, be.Message))
+
+		if be.Line == input.Line {
+			onLine = append(onLine, be)
+		}
+	}
+
+	if len(onLine) == 0 {
+		return BuildError{}, notFound(inFile, "no type error at %s:%d", relPath, input.Line)
+	}
+
+	for _, be := range onLine {
+		if input.Column > 0 && be.Column == input.Column {
+			return be, nil
+		}
+	}
+
+	for _, be := range onLine {
+		if input.Message != "" && strings.Contains(be.Message, input.Message) {
+			return be, nil
+		}
+	}
+
+	return onLine[0], nil
+}
+
+// statementSource returns the source of stmt, capped at maxTypeErrorStatementLines lines, and its
+// first line.
+func statementSource(fset *token.FileSet, lines []string, stmt ast.Node) (string, int) {
+	start, end := fset.Position(stmt.Pos()).Line, fset.Position(stmt.End()).Line
+	end = min(end, start+maxTypeErrorStatementLines-1, len(lines))
+
+	if start < 1 || start > end {
+		return "", 0
+	}
+
+	return strings.Join(lines[start-1:end], "\n"), start
+}
+
+// offendingExpr returns the expression a type error is reported at, given the enclosing path of its
+// position (innermost first), and the path above it. Errors are reported at the start of an expression,
+// so the innermost node is widened to the largest expression starting at the same position (Square in
+// Square{} to the composite literal, f in f() to the call).
+func offendingExpr(path []ast.Node) (ast.Expr, []ast.Node) {
+	i := 0
+	for i < len(path) {
+		if _, ok := path[i].(ast.Expr); ok {
+			break
+		}
+
+		i++
+	}
+
+	if i == len(path) {
+		return nil, nil
+	}
+
+	for i+1 < len(path) {
+		parent, ok := path[i+1].(ast.Expr)
+		if _, kv := parent.(*ast.KeyValueExpr); !ok || kv || parent.Pos() != path[i].Pos() {
+			break
+		}
+
+		i++
+	}
+
+	return path[i].(ast.Expr), path[i+1:]
+}
+
+// expectedType returns the type the context of expr requires (parameter, assignment or declared
+// variable, result, field, element or channel element), a description of that context and the called
+// function when expr is an argument.
+func expectedType(info *types.Info, expr ast.Expr, parents []ast.Node) (types.Type, string, types.Object) {
+	if len(parents) == 0 {
+		return nil, "", nil
+	}
+
+	switch p := parents[0].(type) {
+	case *ast.CallExpr:
+		sig, _ := underlyingOf(info.TypeOf(p.Fun)).(*types.Signature)
+		i := exprIndex(p.Args, expr)
+
+		if sig == nil || i < 0 {
+			return nil, "", nil
+		}
+
+		var callee types.Object
+		if fn := calledFunc(info, p); fn != nil {
+			callee = fn
+		}
+
+		name := types.ExprString(p.Fun)
+
+		params := sig.Params()
+		switch {
+		case sig.Variadic() && i >= params.Len()-1 && !p.Ellipsis.IsValid():
+			elem := params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+
+			return elem, fmt.Sprintf("argument %d of %s", i+1, name), callee
+		case i < params.Len():
+			return params.At(i).Type(), fmt.Sprintf("argument %d of %s", i+1, name), callee
+		}
+	case *ast.AssignStmt:
+		if i := exprIndex(p.Rhs, expr); i >= 0 && len(p.Lhs) == len(p.Rhs) {
+			return info.TypeOf(p.Lhs[i]), "assignment to " + types.ExprString(p.Lhs[i]), nil
+		}
+	case *ast.ValueSpec:
+		if i := exprIndex(p.Values, expr); i >= 0 && p.Type != nil && i < len(p.Names) {
+			return info.TypeOf(p.Type), "declaration of " + p.Names[i].Name, nil
+		}
+	case *ast.ReturnStmt:
+		if i := exprIndex(p.Results, expr); i >= 0 {
+			if sig := enclosingSignature(info, parents); sig != nil && i < sig.Results().Len() {
+				return sig.Results().At(i).Type(), fmt.Sprintf("result %d", i+1), nil
+			}
+		}
+	case *ast.SendStmt:
+		if p.Value == expr {
+			if ch, ok := underlyingOf(info.TypeOf(p.Chan)).(*types.Chan); ok {
+				return ch.Elem(), "send to " + types.ExprString(p.Chan), nil
+			}
+		}
+	case *ast.KeyValueExpr:
+		if p.Value == expr && len(parents) > 1 {
+			if lit, ok := parents[1].(*ast.CompositeLit); ok {
+				return compositeValueType(info, lit, p.Key)
+			}
+		}
+	case *ast.CompositeLit:
+		return compositeValueType(info, p, nil)
+	}
+
+	return nil, "", nil
+}
+
+// compositeValueType returns the type of the value of a composite literal element with the given key
+// (nil for an element without one): the field type of a struct, the value type of a map, the element
+// type of a slice or array.
+func compositeValueType(info *types.Info, lit *ast.CompositeLit, key ast.Expr) (types.Type, string, types.Object) {
+	t := info.TypeOf(lit)
+	if t == nil {
+		return nil, "", nil
+	}
+
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		if ident, ok := key.(*ast.Ident); ok {
+			for i := range u.NumFields() {
+				if f := u.Field(i); f.Name() == ident.Name {
+					return f.Type(), "field " + f.Name(), nil
+				}
+			}
+		}
+	case *types.Map:
+		return u.Elem(), "map value", nil
+	case *types.Slice:
+		return u.Elem(), "element", nil
+	case *types.Array:
+		return u.Elem(), "element", nil
+	}
+
+	return nil, "", nil
+}
+
+// enclosingSignature returns the signature of the innermost function declaration or literal in path.
+func enclosingSignature(info *types.Info, path []ast.Node) *types.Signature {
+	for _, node := range path {
+		switch fn := node.(type) {
+		case *ast.FuncLit:
+			sig, _ := info.TypeOf(fn).(*types.Signature)
+
+			return sig
+		case *ast.FuncDecl:
+			if obj, ok := info.Defs[fn.Name].(*types.Func); ok {
+				return obj.Signature()
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// underlyingOf returns the underlying type of t, nil for nil.
+func underlyingOf(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+
+	return t.Underlying()
+}
+
+// exprIndex returns the index of expr in list, -1 if it is not there.
+func exprIndex(list []ast.Expr, expr ast.Expr) int {
+	for i, e := range list {
+		if e == expr {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// typeErrorObjects collects the named types and functions involved in a type error, in order of first
+// appearance and once each.
+type typeErrorObjects struct {
+	list []types.Object
+	seen map[types.Object]bool
+}
+
+func newTypeErrorObjects() *typeErrorObjects {
+	return &typeErrorObjects{seen: make(map[types.Object]bool)}
+}
+
+// add records obj unless it is nil, predeclared or already recorded.
+func (o *typeErrorObjects) add(obj types.Object) {
+	if obj == nil || obj.Pkg() == nil || o.seen[obj] {
+		return
+	}
+
+	o.seen[obj] = true
+	o.list = append(o.list, obj)
+}
+
+// addType records the named types t is built from: itself, the element of a pointer, slice, array or
+// channel, the key and value of a map.
+func (o *typeErrorObjects) addType(t types.Type) {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		o.add(t.Obj())
+	case *types.Pointer:
+		o.addType(t.Elem())
+	case *types.Slice:
+		o.addType(t.Elem())
+	case *types.Array:
+		o.addType(t.Elem())
+	case *types.Chan:
+		o.addType(t.Elem())
+	case *types.Map:
+		o.addType(t.Key())
+		o.addType(t.Elem())
+	}
+}
+
+// typeErrorDefinition describes obj: its name, kind, signature, doc comment and declaration.
+func typeErrorDefinition(dir string, pkgs []*packages.Package, checked *packages.Package, obj types.Object, qualifier types.Qualifier) TypeErrorDefinition {
+	name := obj.Name()
+	if fn, ok := obj.(*types.Func); ok {
+		if named := receiverNamed(fn); named != nil {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+
+	if q := qualifier(obj.Pkg()); q != "" {
+		name = q + "." + name
+	}
+
+	def := TypeErrorDefinition{
+		Name:      name,
+		Kind:      objStringKind(obj),
+		Signature: types.ObjectString(obj, qualifier),
+	}
+
+	posn := checked.Fset.Position(obj.Pos())
+	if !posn.IsValid() {
+		return def
+	}
+
+	def.File, def.Line = relativePath(dir, posn.Filename), posn.Line
+
+	for _, pkg := range append([]*packages.Package{checked}, pkgs...) {
+		if file := syntaxFileAt(pkg, obj.Pos()); file != nil {
+			def.Doc = strings.TrimSpace(declarationDoc(file, obj.Pos()).Text())
+
+			break
+		}
+	}
+
+	return def
+}
+
+// declarationDoc returns the doc comment of the declaration at pos: that of its spec in a grouped
+// declaration, of the declaration otherwise.
+func declarationDoc(file *ast.File, pos token.Pos) *ast.CommentGroup {
+	path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+
+	for _, node := range path {
+		switch n := node.(type) {
+		case *ast.TypeSpec:
+			if n.Doc != nil {
+				return n.Doc
+			}
+		case *ast.ValueSpec:
+			if n.Doc != nil {
+				return n.Doc
+			}
+		case ast.Decl:
+			return declDoc(n)
+		}
+	}
+
+	return nil
+}
+
+// explainAssignability fills the explanation of why actual is not assignable to expected: missing and
+// mismatched methods for an interface, mismatched fields for two struct types, and hints for conversions
+// and pointer mistakes.
+func explainAssignability(out *ExplainTypeErrorOutput, actual, expected types.Type, qualifier types.Qualifier) {
+	actualStr, expectedStr := types.TypeString(actual, qualifier), types.TypeString(expected, qualifier)
+
+	if iface, ok := expected.Underlying().(*types.Interface); ok && iface.IsMethodSet() {
+		out.Missing, out.Mismatched = compareMethodSet(actual, iface, qualifier)
+
+		if _, isPtr := actual.Underlying().(*types.Pointer); !isPtr && types.Implements(types.NewPointer(actual), iface) {
+			out.Hints = append(out.Hints, fmt.Sprintf("*%s implements %s: pass a pointer", actualStr, expectedStr))
+		}
+
+		return
+	}
+
+	if ptr, ok := actual.Underlying().(*types.Pointer); ok && types.AssignableTo(ptr.Elem(), expected) {
+		out.Hints = append(out.Hints, "dereference the pointer: *"+out.Expression)
+	}
+
+	if types.AssignableTo(types.NewPointer(actual), expected) {
+		out.Hints = append(out.Hints, "take the address: &"+out.Expression)
+	}
+
+	as, aok := actual.Underlying().(*types.Struct)
+	es, eok := expected.Underlying().(*types.Struct)
+
+	if aok && eok && !types.Identical(as, es) {
+		out.Fields = compareStructFields(as, es, qualifier)
+	}
+
+	if types.ConvertibleTo(actual, expected) {
+		out.Hints = append(out.Hints, fmt.Sprintf("%s and %s are distinct types with convertible underlying types: convert with %s(%s)",
+			actualStr, expectedStr, expectedStr, out.Expression))
+	}
+}
+
+// compareStructFields lists the fields of two struct types that differ by type or exist in one only.
+func compareStructFields(actual, expected *types.Struct, qualifier types.Qualifier) []FieldMismatch {
+	var diffs []FieldMismatch
+
+	have := make(map[string]*types.Var, actual.NumFields())
+	for i := range actual.NumFields() {
+		have[actual.Field(i).Name()] = actual.Field(i)
+	}
+
+	for i := range expected.NumFields() {
+		want := expected.Field(i)
+		got := have[want.Name()]
+		delete(have, want.Name())
+
+		switch {
+		case got == nil:
+			diffs = append(diffs, FieldMismatch{Name: want.Name(), Want: types.TypeString(want.Type(), qualifier)})
+		case !types.Identical(got.Type(), want.Type()):
+			diffs = append(diffs, FieldMismatch{
+				Name: want.Name(),
+				Have: types.TypeString(got.Type(), qualifier),
+				Want: types.TypeString(want.Type(), qualifier),
+			})
+		}
+	}
+
+	for i := range actual.NumFields() {
+		if got := have[actual.Field(i).Name()]; got != nil {
+			diffs = append(diffs, FieldMismatch{Name: got.Name(), Have: types.TypeString(got.Type(), qualifier)})
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,123 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestExplainTypeError(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"temp/temp.go": `package temp
+
+// Celsius is a temperature in degrees Celsius.
+type Celsius float64
+
+// Fahrenheit is a temperature in degrees Fahrenheit.
+type Fahrenheit float64
+
+// Warm reports whether c is above room temperature.
+func Warm(c Celsius) bool { return c > 20 }
+
+// Shape has an area and a perimeter.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Point struct {
+	X, Y int
+}
+
+type Pixel struct {
+	X, Y  int
+	Color string
+}
+`,
+		"app/app.go": `package app
+
+import "lang/temp"
+
+func Check() bool {
+	var f temp.Fahrenheit = 70
+	return temp.Warm(f)
+}
+
+func Build() temp.Shape {
+	var s temp.Shape = temp.Square{Side: 2}
+	return s
+}
+
+func Convert(p temp.Pixel) temp.Point {
+	return p
+}
+`,
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.ExplainTypeError(ctx, req, tools.ExplainTypeErrorInput{Dir: dir, File: "app/app.go", Line: 7})
+	if err != nil {
+		t.Fatalf("ExplainTypeError: %v", err)
+	}
+
+	if out.Error.Line != 7 || out.Error.Column != 19 || !strings.Contains(out.Error.Message, "cannot use f") {
+		t.Errorf("error = %+v, want the argument of Warm", out.Error)
+	}
+
+	if out.Statement != "\treturn temp.Warm(f)" || out.StatementLine != 7 || out.Expression != "f" ||
+		out.Actual != "temp.Fahrenheit" || out.Expected != "temp.Celsius" || out.Context != "argument 1 of temp.Warm" {
+		t.Errorf("got %q@%d %q: %q vs %q (%q)", out.Statement, out.StatementLine, out.Expression, out.Actual, out.Expected, out.Context)
+	}
+
+	var defs []string
+	for _, d := range out.Definitions {
+		defs = append(defs, fmt.Sprintf("%s %s %s:%d %q", d.Kind, d.Name, d.File, d.Line, d.Doc))
+	}
+
+	wantDefs := []string{
+		`type temp.Fahrenheit temp/temp.go:7 "Fahrenheit is a temperature in degrees Fahrenheit."`,
+		`type temp.Celsius temp/temp.go:4 "Celsius is a temperature in degrees Celsius."`,
+		`func temp.Warm temp/temp.go:10 "Warm reports whether c is above room temperature."`,
+	}
+	if strings.Join(defs, "\n") != strings.Join(wantDefs, "\n") {
+		t.Errorf("definitions:\n%s\nwant:\n%s", strings.Join(defs, "\n"), strings.Join(wantDefs, "\n"))
+	}
+
+	if len(out.Hints) != 1 || !strings.Contains(out.Hints[0], "convert with temp.Celsius(f)") {
+		t.Errorf("hints = %v, want the conversion", out.Hints)
+	}
+
+	_, out, err = tools.ExplainTypeError(ctx, req, tools.ExplainTypeErrorInput{Dir: dir, File: "app/app.go", Line: 11, Column: 21})
+	if err != nil {
+		t.Fatalf("ExplainTypeError: %v", err)
+	}
+
+	if out.Expression != "temp.Square{Side: 2}" || out.Context != "declaration of s" ||
+		fmt.Sprint(out.Missing) != "[{Perimeter Perimeter() float64}]" {
+		t.Errorf("got %q (%q), missing %v; want Perimeter missing", out.Expression, out.Context, out.Missing)
+	}
+
+	_, out, err = tools.ExplainTypeError(ctx, req, tools.ExplainTypeErrorInput{Dir: dir, File: "app/app.go", Line: 16, Message: "cannot use"})
+	if err != nil {
+		t.Fatalf("ExplainTypeError: %v", err)
+	}
+
+	if out.Context != "result 1" || fmt.Sprint(out.Fields) != "[{Color string }]" {
+		t.Errorf("context %q, fields %v; want the extra Color field", out.Context, out.Fields)
+	}
+
+	_, _, err = tools.ExplainTypeError(ctx, req, tools.ExplainTypeErrorInput{Dir: dir, File: "app/app.go", Line: 5})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeNotFound || te.Details == nil || len(te.Details.Candidates) != 3 {
+		t.Errorf("expected NOT_FOUND listing the 3 errors of the file, got %+v", te)
+	}
+}
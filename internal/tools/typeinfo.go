@@ -0,0 +1,324 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// GetTypeInfo describes any named type: its underlying kind and type, methods split by receiver kind,
+// fields for structs and, for types defined on a basic type, the constants declared with that type.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and type name ('T' or 'pkg.T')
+//
+// Returns:
+//   - MCP tool call result
+//   - the type description
+//   - error if the type is not found or an error occurred during analysis
+func GetTypeInfo(ctx context.Context, _ *mcp.CallToolRequest, input GetTypeInfoInput) (
+	*mcp.CallToolResult,
+	GetTypeInfoOutput,
+	error,
+) {
+	start := logStart("GetTypeInfo", logFields(
+		input.Dir,
+		newLogField("name", input.Name),
+	))
+	out := GetTypeInfoOutput{}
+
+	defer func() { logEnd("GetTypeInfo", start, 1) }()
+
+	mode := loadModeSyntaxTypesNamedFiles
+
+	pkgs, _, degraded, err := loadFilteredPackagesWithFallback(ctx, input.Dir, mode, "", "GetTypeInfo")
+	if err != nil {
+		return fail(out, err)
+	}
+
+	degraded.apply(&out.Degraded, &out.LoadError)
+
+	match := findTypeSpec(pkgs, input.Dir, input.Name, func(*ast.TypeSpec) bool { return true })
+	if match == nil {
+		return nil, out, missingSymbol(pkgs, input.Name, "type", "type %q not found", input.Name)
+	}
+
+	ts, pkg := match.spec, match.pkg
+
+	info := TypeInfo{
+		Name:       ts.Name.Name,
+		Package:    pkg.PkgPath,
+		File:       match.relPath,
+		Line:       pkg.Fset.Position(ts.Pos()).Line,
+		Exported:   ts.Name.IsExported(),
+		Alias:      ts.Assign.IsValid(),
+		TypeParams: typeSpecParams(ts),
+		Doc:        match.doc(),
+		Kind:       astTypeKind(ts.Type),
+		Underlying: exprString(ts.Type),
+	}
+
+	if st, ok := ts.Type.(*ast.StructType); ok {
+		info.Fields = structFields(st)
+	}
+
+	for _, fd := range typeMethodDecls(pkg, ts.Name.Name) {
+		sig := fd.Name.Name + strings.TrimPrefix(exprString(fd.Type), "func")
+
+		if _, pointer := fd.Recv.List[0].Type.(*ast.StarExpr); pointer {
+			info.PointerMethods = append(info.PointerMethods, sig)
+		} else {
+			info.ValueMethods = append(info.ValueMethods, sig)
+		}
+	}
+
+	sort.Strings(info.ValueMethods)
+	sort.Strings(info.PointerMethods)
+
+	// Type information resolves kinds hidden behind other named types and the values of iota constants;
+	// syntax-only loads keep the declared type expression.
+	if hasTypes(pkg) {
+		if tn, ok := pkg.TypesInfo.Defs[ts.Name].(*types.TypeName); ok {
+			qualifier := types.RelativeTo(pkg.Types)
+			info.Kind = typeKind(tn.Type().Underlying())
+			info.Underlying = types.TypeString(tn.Type().Underlying(), qualifier)
+
+			if info.Kind == "basic" {
+				info.Constants = typedConstants(pkg, tn, input.Dir)
+			}
+		}
+	}
+
+	out.Type = info
+
+	return nil, out, nil
+}
+
+// typeSpecMatch is a type declaration found by findTypeSpec.
+type typeSpecMatch struct {
+	pkg     *packages.Package
+	relPath string
+	decl    *ast.GenDecl
+	spec    *ast.TypeSpec
+}
+
+// doc returns the comment of the type spec, or of its declaration when the type is declared on its own
+// (the parser attaches the comment of `// T ...\ntype T ...` to the GenDecl).
+func (m *typeSpecMatch) doc() string {
+	switch {
+	case m.spec.Doc != nil:
+		return strings.TrimSpace(m.spec.Doc.Text())
+	case m.decl != nil && m.decl.Doc != nil && !m.decl.Lparen.IsValid():
+		return strings.TrimSpace(m.decl.Doc.Text())
+	}
+
+	return ""
+}
+
+// findTypeSpec returns the first type declaration named name ('T' or 'pkg.T', matched against the
+// package name) accepted by accept, including types declared inside functions, or nil.
+func findTypeSpec(pkgs []*packages.Package, dir, name string, accept func(*ast.TypeSpec) bool) *typeSpecMatch {
+	pkgName, typeName := "", name
+	if before, after, ok := strings.Cut(name, "."); ok {
+		pkgName, typeName = before, after
+	}
+
+	for _, pkg := range pkgs {
+		if pkgName != "" && pkg.Name != pkgName {
+			continue
+		}
+
+		for i, file := range pkg.Syntax {
+			var match *typeSpecMatch
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				if match != nil {
+					return false
+				}
+
+				decl, ok := n.(*ast.GenDecl)
+				if !ok || decl.Tok != token.TYPE {
+					return true
+				}
+
+				for _, spec := range decl.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if ok && ts.Name.Name == typeName && accept(ts) {
+						match = &typeSpecMatch{pkg: pkg, relPath: resolveFilePath(pkg, dir, i, file), decl: decl, spec: ts}
+
+						return false
+					}
+				}
+
+				return true
+			})
+
+			if match != nil {
+				return match
+			}
+		}
+	}
+
+	return nil
+}
+
+// typeSpecParams returns the type parameter names of a generic type declaration.
+func typeSpecParams(ts *ast.TypeSpec) []string {
+	if ts.TypeParams == nil {
+		return nil
+	}
+
+	var params []string
+
+	for _, field := range ts.TypeParams.List {
+		for _, name := range field.Names {
+			params = append(params, name.Name)
+		}
+	}
+
+	return params
+}
+
+// structFields lists the fields of a struct type; embedded fields are named after their type.
+func structFields(st *ast.StructType) []StructField {
+	fields := []StructField{}
+
+	for _, field := range st.Fields.List {
+		fieldType := exprString(field.Type)
+
+		tag := ""
+		if field.Tag != nil {
+			tag = strings.Trim(field.Tag.Value, "`")
+		}
+
+		doc := ""
+		if field.Doc != nil {
+			doc = strings.TrimSpace(field.Doc.Text())
+		}
+
+		for _, name := range field.Names {
+			fields = append(fields, StructField{Name: name.Name, Type: fieldType, Tag: tag, Doc: doc})
+		}
+
+		if len(field.Names) == 0 {
+			fields = append(fields, StructField{Name: fieldType, Type: fieldType, Tag: tag, Doc: doc})
+		}
+	}
+
+	return fields
+}
+
+// typeMethodDecls returns the method declarations of the package whose receiver base type is typeName.
+func typeMethodDecls(pkg *packages.Package, typeName string) []*ast.FuncDecl {
+	var methods []*ast.FuncDecl
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && receiverName(fd) == typeName {
+				methods = append(methods, fd)
+			}
+		}
+	}
+
+	return methods
+}
+
+// typedConstants returns the package-level constants whose type is tn, in declaration order.
+func typedConstants(pkg *packages.Package, tn *types.TypeName, dir string) []TypeConstant {
+	var consts []TypeConstant
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), tn.Type()) {
+			continue
+		}
+
+		pos := pkg.Fset.Position(c.Pos())
+		consts = append(consts, TypeConstant{
+			Name:  c.Name(),
+			Value: c.Val().ExactString(),
+			File:  relativePath(dir, pos.Filename),
+			Line:  pos.Line,
+		})
+	}
+
+	sort.Slice(consts, func(i, j int) bool {
+		if consts[i].File != consts[j].File {
+			return consts[i].File < consts[j].File
+		}
+
+		return consts[i].Line < consts[j].Line
+	})
+
+	return consts
+}
+
+// typeKind names the kind of an underlying type.
+func typeKind(t types.Type) string {
+	switch t.(type) {
+	case *types.Struct:
+		return "struct"
+	case *types.Map:
+		return "map"
+	case *types.Slice:
+		return "slice"
+	case *types.Array:
+		return "array"
+	case *types.Basic:
+		return "basic"
+	case *types.Signature:
+		return "func"
+	case *types.Chan:
+		return "chan"
+	case *types.Interface:
+		return "interface"
+	case *types.Pointer:
+		return "pointer"
+	}
+
+	return "other"
+}
+
+// astTypeKind names the kind of a type expression without type information; types defined on
+// another named type report "named".
+func astTypeKind(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StructType:
+		return "struct"
+	case *ast.MapType:
+		return "map"
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "slice"
+		}
+
+		return "array"
+	case *ast.FuncType:
+		return "func"
+	case *ast.ChanType:
+		return "chan"
+	case *ast.InterfaceType:
+		return "interface"
+	case *ast.StarExpr:
+		return "pointer"
+	case *ast.ParenExpr:
+		return astTypeKind(e.X)
+	case *ast.Ident:
+		if obj, ok := types.Universe.Lookup(e.Name).(*types.TypeName); ok {
+			if _, basic := obj.Type().(*types.Basic); basic {
+				return "basic"
+			}
+		}
+	}
+
+	return "named"
+}
@@ -0,0 +1,91 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func getTypeInfo(t *testing.T, name string) tools.TypeInfo {
+	t.Helper()
+
+	_, out, err := tools.GetTypeInfo(context.Background(), &mcp.CallToolRequest{}, tools.GetTypeInfoInput{Dir: testDir(), Name: name})
+	if err != nil {
+		t.Fatalf("GetTypeInfo(%s) error: %v", name, err)
+	}
+
+	return out.Type
+}
+
+func TestGetTypeInfo_MapTypeSplitsReceivers(t *testing.T) {
+	t.Parallel()
+
+	info := getTypeInfo(t, "Handlers")
+
+	if info.Kind != "map" || info.Underlying != "map[string]func() error" {
+		t.Errorf("unexpected kind %q / underlying %q", info.Kind, info.Underlying)
+	}
+
+	if !slices.Equal(info.ValueMethods, []string{"Names() string"}) {
+		t.Errorf("unexpected value methods %v", info.ValueMethods)
+	}
+
+	if !slices.Equal(info.PointerMethods, []string{"Register(name string, fn func() error)"}) {
+		t.Errorf("unexpected pointer methods %v", info.PointerMethods)
+	}
+
+	if info.Doc != "Handlers maps route names to handlers." || info.File != "typeinfo.go" {
+		t.Errorf("unexpected doc %q or file %q", info.Doc, info.File)
+	}
+
+	if len(info.Constants) != 0 || len(info.Fields) != 0 {
+		t.Errorf("expected no constants or fields, got %+v", info)
+	}
+}
+
+func TestGetTypeInfo_BasicTypeConstants(t *testing.T) {
+	t.Parallel()
+
+	info := getTypeInfo(t, "sample.Level")
+
+	if info.Kind != "basic" || info.Underlying != "int" {
+		t.Errorf("unexpected kind %q / underlying %q", info.Kind, info.Underlying)
+	}
+
+	var got []string
+	for _, c := range info.Constants {
+		got = append(got, c.Name+"="+c.Value)
+	}
+
+	if !slices.Equal(got, []string{"LevelDebug=0", "LevelInfo=1", "LevelWarn=2"}) {
+		t.Errorf("unexpected constants %v", got)
+	}
+
+	celsius := getTypeInfo(t, "Celsius")
+	if len(celsius.Constants) != 2 || !slices.Equal(celsius.ValueMethods, []string{"String() string"}) {
+		t.Errorf("unexpected Celsius info %+v", celsius)
+	}
+}
+
+func TestGetTypeInfo_Struct(t *testing.T) {
+	t.Parallel()
+
+	info := getTypeInfo(t, "Point")
+
+	if info.Kind != "struct" || len(info.Fields) == 0 {
+		t.Errorf("expected struct fields, got %+v", info)
+	}
+}
+
+func TestGetTypeInfo_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.GetTypeInfo(context.Background(), &mcp.CallToolRequest{}, tools.GetTypeInfoInput{Dir: testDir(), Name: "Missing"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown type")
+	}
+}
@@ -6,12 +6,20 @@ package tools
 type ListPackagesInput struct {
 	// Dir - root directory to scan for Go packages
 	Dir string `json:"dir" jsonschema:"Root directory to scan for Go packages"`
+	// IncludeTestdata - if true, also analyze the packages in testdata directories
+	IncludeTestdata bool `json:"includeTestdata,omitempty" jsonschema:"If true, also load the packages in testdata directories below dir, which are skipped by default like go list ./... does (fixtures, often broken or dead on purpose)"`
 }
 
 // ListPackagesOutput contains results from the ListPackages tool.
 type ListPackagesOutput struct {
 	// Packages - list of discovered Go packages
 	Packages []string `json:"packages" jsonschema:"List of discovered Go package import paths"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// SkippedTestdataPackages - number of testdata packages left out
+	SkippedTestdataPackages int `json:"skippedTestdataPackages,omitempty" jsonschema:"Number of packages in testdata directories below dir left out of the results (see includeTestdata)"`
 }
 
 // ------------------ list symbols ------------------
@@ -20,8 +28,14 @@ type ListPackagesOutput struct {
 type ListSymbolsInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// DependencyPackage - import path of a dependency to analyze instead of the module's own packages
+	DependencyPackage string `json:"dependencyPackage,omitempty" jsonschema:"Import path of a dependency (standard library, module cache, replace target or vendor/) to load in the module's context and analyze instead of the module's own packages"`
 	// Package - path to package to find symbols in
 	Package string `json:"package" jsonschema:"Package path to inspect for symbols"`
+	// WithFingerprints - if true, include the normalized source fingerprint
+	WithFingerprints bool `json:"withFingerprints,omitempty" jsonschema:"If true, include a SHA-256 fingerprint of the normalized declaration source"`
+	// WithSignatures - if true, include receivers, signatures and the generic flag of functions and methods
+	WithSignatures bool `json:"withSignatures,omitempty" jsonschema:"If true, include receivers, signatures and the generic flag of functions and methods"`
 }
 
 // Symbol represents a symbol (function, struct, interface, etc.) in Go code.
@@ -30,6 +44,8 @@ type Symbol struct {
 	Kind string `json:"kind" jsonschema:"Symbol type (func, struct, interface, method, etc.)"`
 	// Name - symbol name
 	Name string `json:"name" jsonschema:"Symbol name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Package - package where the symbol is defined
 	Package string `json:"package" jsonschema:"Package where the symbol is defined"`
 	// File - file where the symbol is defined
@@ -38,6 +54,16 @@ type Symbol struct {
 	Line int `json:"line" jsonschema:"Line number in the file"`
 	// Exported - true if the symbol is exported (starts with capital letter)
 	Exported bool `json:"exported" jsonschema:"True if the symbol is exported (starts with capital letter)"`
+	// Fingerprint - hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)
+	Fingerprint string `json:"fingerprint,omitempty" jsonschema:"Hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)"`
+	// Receiver - receiver type of a method (e.g. '*Widget'), or the interface of an interface method
+	Receiver string `json:"receiver,omitempty" jsonschema:"Receiver type of a method (e.g. '*Widget'), or the interface of an interface method"`
+	// Signature - parameter and result types of a function or method (e.g. '(string, ...int) (int, error)')
+	Signature string `json:"signature,omitempty" jsonschema:"Parameter and result types of a function or method (e.g. '(string, ...int) (int, error)')"`
+	// Generic - true if the symbol or its receiver type has type parameters
+	Generic bool `json:"generic,omitempty" jsonschema:"True if the symbol or its receiver type has type parameters"`
+	// BuildConstraint - build constraint of the declaring file (go:build expression and GOOS/GOARCH file name suffix)
+	BuildConstraint string `json:"buildConstraint,omitempty" jsonschema:"Build constraint of the declaring file (go:build expression and GOOS/GOARCH file name suffix), e.g. 'linux'"`
 }
 
 // SymbolGroupByFile represents symbols grouped by file within a package.
@@ -62,16 +88,62 @@ type SymbolInfo struct {
 	Kind string `json:"kind" jsonschema:"Symbol type (func, struct, interface, method, etc.)"`
 	// Name - symbol name
 	Name string `json:"name" jsonschema:"Symbol name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Line - line number in the file
 	Line int `json:"line" jsonschema:"Line number in the file"`
 	// Exported - true if the symbol is exported (starts with capital letter)
 	Exported bool `json:"exported" jsonschema:"True if the symbol is exported (starts with capital letter)"`
+	// Fingerprint - hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)
+	Fingerprint string `json:"fingerprint,omitempty" jsonschema:"Hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)"`
+	// Receiver - receiver type of a method (e.g. '*Widget'), or the interface of an interface method
+	Receiver string `json:"receiver,omitempty" jsonschema:"Receiver type of a method (e.g. '*Widget'), or the interface of an interface method"`
+	// Signature - parameter and result types of a function or method (e.g. '(string, ...int) (int, error)')
+	Signature string `json:"signature,omitempty" jsonschema:"Parameter and result types of a function or method (e.g. '(string, ...int) (int, error)')"`
+	// Generic - true if the symbol or its receiver type has type parameters
+	Generic bool `json:"generic,omitempty" jsonschema:"True if the symbol or its receiver type has type parameters"`
+	// BuildConstraint - build constraint of the declaring file (go:build expression and GOOS/GOARCH file name suffix)
+	BuildConstraint string `json:"buildConstraint,omitempty" jsonschema:"Build constraint of the declaring file (go:build expression and GOOS/GOARCH file name suffix), e.g. 'linux'"`
 }
 
 // ListSymbolsOutput contains results from the ListSymbols tool.
 type ListSymbolsOutput struct {
 	// GroupedSymbols - symbols found, grouped by package and file (alternative format for token efficiency)
 	GroupedSymbols []SymbolGroupByPackage `json:"groupedSymbols,omitempty" jsonschema:"Symbols grouped by package and file"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// External - true when the results come from a dependency package (dependencyPackage)
+	External bool `json:"external,omitempty" jsonschema:"True when the results come from a dependency package (dependencyPackage)"`
+	// Dependency - analyzed dependency package and its module version (only with dependencyPackage)
+	Dependency *DependencyInfo `json:"dependency,omitempty" jsonschema:"Analyzed dependency package and its module version; file paths are relative to its dir (only with dependencyPackage)"`
+}
+
+// ------------------ lsp locations ------------------
+
+// LSPPosition is a zero-based position in a text document as defined by the Language Server Protocol.
+type LSPPosition struct {
+	// Line - zero-based line
+	Line int `json:"line" jsonschema:"Zero-based line"`
+	// Character - zero-based character offset in UTF-16 code units
+	Character int `json:"character" jsonschema:"Zero-based character offset in UTF-16 code units"`
+}
+
+// LSPRange is a range in a text document as defined by the Language Server Protocol.
+type LSPRange struct {
+	// Start - position of the first character of the identifier
+	Start LSPPosition `json:"start" jsonschema:"Position of the first character of the identifier"`
+	// End - position just past the identifier
+	End LSPPosition `json:"end" jsonschema:"Position just past the identifier (exclusive)"`
+}
+
+// LSPLocation is an LSP Location of an identifier (only with lspLocations).
+type LSPLocation struct {
+	// URI - absolute file:// URI of the file
+	URI string `json:"uri" jsonschema:"Absolute file:// URI of the file"`
+	// Range - range of the identifier
+	Range LSPRange `json:"range" jsonschema:"Range of the identifier"`
 }
 
 // ------------------ find references ------------------
@@ -81,15 +153,31 @@ type FindReferencesInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
 	// Ident - name of the symbol to find references for
-	Ident string `json:"ident" jsonschema:"Name of the symbol to find references for"`
-	// File - optional relative file path to restrict the search
-	File string `json:"file,omitempty" jsonschema:"Optional relative file path to restrict the search"`
+	Ident string `json:"ident,omitempty" jsonschema:"Name of the symbol to find references for; required unless symbolId is given"`
+	// SymbolID - stable symbol ID from a prior result; selects the symbol exactly instead of ident and kind
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Symbol ID from a prior result (listSymbols, getDefinitions, ...), e.g. 'example.com/app/store.Store.Save#func'; selects exactly that symbol, same-named locals included, instead of ident and kind"`
+	// File - optional module-relative or absolute file path to restrict the search
+	File string `json:"file,omitempty" jsonschema:"Optional file path, relative to dir (pkg/foo.go) or absolute, to restrict the search; the whole path must match"`
 	// Kind - filter by symbol type (e.g. func, type, var, const)
 	Kind string `json:"kind,omitempty" jsonschema:"Filter by symbol kind (e.g. func, type, var, const)"`
+	// Package - import path of the package declaring the symbol, to choose among same-named declarations
+	Package string `json:"package,omitempty" jsonschema:"Import path of the package declaring the symbol (e.g. example.com/app/config); required when several packages declare the name, which otherwise fails with AMBIGUOUS listing them"`
 	// Limit - maximum number of references to return (0 means no limit)
 	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of references to return (0 means no limit)"`
 	// Offset - number of references to skip before returning results
 	Offset int `json:"offset,omitempty" jsonschema:"Number of references to skip before returning results"`
+	// SnippetLines - total lines of each snippet in line mode, centered on the hit
+	SnippetLines int `json:"snippetLines,omitempty" jsonschema:"Total lines of each snippet in line mode, centered on the hit (default 1: the trimmed line of the hit)"`
+	// SnippetMode - snippet extent: line, statement or declaration
+	SnippetMode string `json:"snippetMode,omitempty" jsonschema:"Snippet extent: 'line' (default, snippetLines lines around the hit), 'statement' (the smallest enclosing statement, spec or function signature) or 'declaration' (the enclosing top-level declaration, or its spec in a grouped declaration)"`
+	// SnippetMaxLines - maximum lines of statement and declaration snippets
+	SnippetMaxLines int `json:"snippetMaxLines,omitempty" jsonschema:"Maximum lines of statement and declaration snippets; longer spans are cut around the hit (default 20)"`
+	// LSPLocations - if true, add an LSP location with the identifier range to every entry
+	LSPLocations bool `json:"lspLocations,omitempty" jsonschema:"If true, add lspLocation (file URI and zero-based UTF-16 range of the identifier) to every entry for LSP clients"`
+	// Compact - if true, return references in files as tuples instead of groups
+	Compact bool `json:"compact,omitempty" jsonschema:"If true, return references in files as [line, snippet] tuples instead of groups of objects; the payload is much smaller for large result sets. Cannot be combined with lspLocations"`
+	// OnlyFuncValues - if true, return only references using a function or method as a value
+	OnlyFuncValues bool `json:"onlyFuncValues,omitempty" jsonschema:"If true, return only references using the function or method as a value (usage funcValue: passed, assigned, stored, returned, or run by go/defer), for signature-change impact analysis"`
 }
 
 // ReferenceEntry represents a reference occurrence within a file.
@@ -98,6 +186,14 @@ type ReferenceEntry struct {
 	Line int `json:"line" jsonschema:"Line number of the reference"`
 	// Snippet - code context showing the reference usage
 	Snippet string `json:"snippet" jsonschema:"Code context showing the reference usage"`
+	// Indirect - the interface method is reached through an embedded field of the receiver
+	Indirect bool `json:"indirect,omitempty" jsonschema:"The interface method is reached through an embedded field of the receiver"`
+	// LSPLocation - identifier location for LSP clients (only with lspLocations)
+	LSPLocation *LSPLocation `json:"lspLocation,omitempty" jsonschema:"Identifier location for LSP clients (only with lspLocations)"`
+	// Usage - funcValue for functions and methods used as values
+	Usage string `json:"usage,omitempty" jsonschema:"'funcValue' when a function or method is used as a value or run by a go or defer statement; absent for direct calls, declarations and other symbols"`
+	// Destination - where a function value goes
+	Destination string `json:"destination,omitempty" jsonschema:"Where a funcValue goes, e.g. 'argument 2 of http.Handle', 'converted to http.HandlerFunc', 'assigned to h', 'map value for key \"/a\"', 'field OnClose', 'returned', 'go statement', 'defer statement'"`
 }
 
 // ReferenceGroup groups references by file.
@@ -112,12 +208,27 @@ type ReferenceGroup struct {
 type FindReferencesOutput struct {
 	// Total - total number of references that were found (before pagination)
 	Total int `json:"total" jsonschema:"Total number of references found before pagination"`
+	// SymbolID - stable ID of the symbol whose references were found
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID of the symbol whose references were found"`
 	// Offset - number of references skipped before returning results
 	Offset int `json:"offset" jsonschema:"Number of references skipped before returning results"`
 	// Limit - maximum number of references returned (0 when no limit was applied)
 	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of references returned (0 when no limit was applied)"`
 	// Groups - references grouped by file
 	Groups []ReferenceGroup `json:"groups,omitempty" jsonschema:"References grouped by file"`
+	// Files - references grouped by file as tuples (only with compact)
+	Files []CompactReferenceGroup `json:"files,omitempty" jsonschema:"References grouped by file as tuples (only with compact, replacing groups)"`
+}
+
+// CompactReference is a reference as a [line, snippet] tuple; a third element true marks an indirect reference.
+type CompactReference []any
+
+// CompactReferenceGroup groups references by file in the compact wire format.
+type CompactReferenceGroup struct {
+	// File - relative path to the file containing the references
+	File string `json:"file" jsonschema:"Relative path to the file containing the references"`
+	// Refs - references within the file as tuples
+	Refs []CompactReference `json:"refs" jsonschema:"References within the file as [line, snippet] tuples; [line, snippet, true] marks a call of an interface method through an embedded field (indirect)"`
 }
 
 // ------------------ find definitions ------------------
@@ -128,22 +239,38 @@ type FindDefinitionsInput struct {
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
 	// Ident - name of the symbol to locate its definition
 	Ident string `json:"ident" jsonschema:"Name of the symbol to locate its definition"`
-	// File - optional relative file path to restrict the search
-	File string `json:"file,omitempty" jsonschema:"Optional relative file path to restrict the search"`
+	// File - optional module-relative or absolute file path to restrict the search
+	File string `json:"file,omitempty" jsonschema:"Optional file path, relative to dir (pkg/foo.go) or absolute, to restrict the search; the whole path must match"`
 	// Kind - filter by symbol type (e.g. func, type, var, const)
 	Kind string `json:"kind,omitempty" jsonschema:"Filter by symbol kind (e.g. func, type, var, const)"`
+	// Package - import path of the package declaring the symbol, to choose among same-named declarations
+	Package string `json:"package,omitempty" jsonschema:"Import path of the package declaring the symbol (e.g. example.com/app/config); required when several packages declare the name, which otherwise fails with AMBIGUOUS listing them"`
 	// Limit - maximum number of definitions to return (0 means no limit)
 	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of definitions to return (0 means no limit)"`
 	// Offset - number of definitions to skip before returning results
 	Offset int `json:"offset,omitempty" jsonschema:"Number of definitions to skip before returning results"`
+	// SnippetLines - total lines of each snippet in line mode, centered on the hit
+	SnippetLines int `json:"snippetLines,omitempty" jsonschema:"Total lines of each snippet in line mode, centered on the hit (default 1: the trimmed line of the hit)"`
+	// SnippetMode - snippet extent: line, statement or declaration
+	SnippetMode string `json:"snippetMode,omitempty" jsonschema:"Snippet extent: 'line' (default, snippetLines lines around the hit), 'statement' (the smallest enclosing statement, spec or function signature) or 'declaration' (the enclosing top-level declaration, or its spec in a grouped declaration)"`
+	// SnippetMaxLines - maximum lines of statement and declaration snippets
+	SnippetMaxLines int `json:"snippetMaxLines,omitempty" jsonschema:"Maximum lines of statement and declaration snippets; longer spans are cut around the hit (default 20)"`
+	// LSPLocations - if true, add an LSP location with the identifier range to every entry
+	LSPLocations bool `json:"lspLocations,omitempty" jsonschema:"If true, add lspLocation (file URI and zero-based UTF-16 range of the identifier) to every entry for LSP clients"`
 }
 
 // DefinitionEntry represents a definition occurrence within a file.
 type DefinitionEntry struct {
 	// Line - line number of the definition
 	Line int `json:"line" jsonschema:"Line number of the definition"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Snippet - code snippet showing the definition line
 	Snippet string `json:"snippet" jsonschema:"Code snippet showing the definition line"`
+	// BuildConstraint - build constraint of the declaring file; platform variants of one symbol differ in it
+	BuildConstraint string `json:"buildConstraint,omitempty" jsonschema:"Build constraint of the declaring file; platform variants of one symbol (stat_linux.go, stat_windows.go) differ in it"`
+	// LSPLocation - identifier location for LSP clients (only with lspLocations)
+	LSPLocation *LSPLocation `json:"lspLocation,omitempty" jsonschema:"Identifier location for LSP clients (only with lspLocations)"`
 }
 
 // DefinitionGroup groups symbol definitions by file.
@@ -173,15 +300,27 @@ type FindBestContextInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
 	// Ident - name of the symbol to analyse
-	Ident string `json:"ident" jsonschema:"Name of the symbol to analyse"`
+	Ident string `json:"ident,omitempty" jsonschema:"Name of the symbol to analyse; required unless symbolId is given"`
+	// SymbolID - stable symbol ID from a prior result; selects the symbol exactly instead of ident and kind
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Symbol ID from a prior result (listSymbols, getDefinitions, ...), e.g. 'example.com/app/store.Store.Save#func'; selects exactly that symbol, same-named locals included, instead of ident and kind"`
 	// Kind - optional filter by symbol kind (func, type, var, const, etc.)
 	Kind string `json:"kind,omitempty" jsonschema:"Optional filter by symbol kind (func, type, var, const, etc.)"`
+	// Package - import path of the package declaring the symbol, to choose among same-named declarations
+	Package string `json:"package,omitempty" jsonschema:"Import path of the package declaring the symbol (e.g. example.com/app/config); required when several packages declare the name, which otherwise fails with AMBIGUOUS listing them"`
 	// MaxUsages - maximum number of non-test usages to return (defaults to 3 when <= 0)
 	MaxUsages int `json:"maxUsages,omitempty" jsonschema:"Maximum number of non-test usages to return (defaults to 3 when <= 0)"`
 	// MaxTestUsages - maximum number of test usages to return (defaults to 2 when <= 0)
 	MaxTestUsages int `json:"maxTestUsages,omitempty" jsonschema:"Maximum number of test usages to return (defaults to 2 when <= 0)"`
 	// MaxDependencies - maximum number of dependency imports to return (defaults to 5 when <= 0)
 	MaxDependencies int `json:"maxDependencies,omitempty" jsonschema:"Maximum number of dependency imports to return (defaults to 5 when <= 0)"`
+	// SnippetLines - total lines of each snippet in line mode, centered on the hit
+	SnippetLines int `json:"snippetLines,omitempty" jsonschema:"Total lines of each snippet in line mode, centered on the hit (default 1: the trimmed line of the hit)"`
+	// SnippetMode - snippet extent: line, statement or declaration
+	SnippetMode string `json:"snippetMode,omitempty" jsonschema:"Snippet extent: 'line' (default, snippetLines lines around the hit), 'statement' (the smallest enclosing statement, spec or function signature) or 'declaration' (the enclosing top-level declaration, or its spec in a grouped declaration)"`
+	// SnippetMaxLines - maximum lines of statement and declaration snippets
+	SnippetMaxLines int `json:"snippetMaxLines,omitempty" jsonschema:"Maximum lines of statement and declaration snippets; longer spans are cut around the hit (default 20)"`
+	// LSPLocations - if true, add an LSP location with the identifier range to every entry
+	LSPLocations bool `json:"lspLocations,omitempty" jsonschema:"If true, add lspLocation (file URI and zero-based UTF-16 range of the identifier) to every entry for LSP clients"`
 }
 
 // ContextLocation represents a code location relevant to a symbol.
@@ -190,8 +329,12 @@ type ContextLocation struct {
 	File string `json:"file" jsonschema:"Relative path to the file containing the location"`
 	// Line - line number where the symbol appears
 	Line int `json:"line" jsonschema:"Line number where the symbol appears"`
-	// Snippet - trimmed line of code providing quick context
-	Snippet string `json:"snippet,omitempty" jsonschema:"Trimmed line of code providing quick context"`
+	// SymbolID - stable ID of the symbol (definitions only), accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID of a definition, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
+	// Snippet - code around the location, a trimmed line by default (see snippetMode)
+	Snippet string `json:"snippet,omitempty" jsonschema:"Code around the location: the trimmed line by default, or the lines selected by snippetLines/snippetMode"`
+	// LSPLocation - identifier location for LSP clients (only with lspLocations)
+	LSPLocation *LSPLocation `json:"lspLocation,omitempty" jsonschema:"Identifier location for LSP clients (only with lspLocations)"`
 }
 
 // ContextDependency captures an import that the symbol's definition relies on.
@@ -228,6 +371,8 @@ type ListImportsInput struct {
 	Dir string `json:"dir" jsonschema:"Root directory to scan for Go files"`
 	// Package - optional package path to restrict results
 	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// Aggregate - if true, summarize imports per external module instead of listing them per file
+	Aggregate bool `json:"aggregate,omitempty" jsonschema:"If true, summarize imports per external module (version, import paths used, importing files and packages) instead of listing them per file; module-internal and standard library imports are only counted"`
 }
 
 // Import represents an import of a package in a Go file.
@@ -260,6 +405,70 @@ type ImportGroupByFile struct {
 type ListImportsOutput struct {
 	// Imports - imports grouped by file (token efficiency)
 	Imports []ImportGroupByFile `json:"imports,omitempty" jsonschema:"Imports grouped by file"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// Aggregate - per-module summary of the imports (only with aggregate)
+	Aggregate *ImportAggregate `json:"aggregate,omitempty" jsonschema:"Per-module summary of the imports (only with aggregate)"`
+}
+
+// ImportAggregate summarizes the imports of the scanned packages by module.
+type ImportAggregate struct {
+	// Modules - external modules, most-importing files first
+	Modules []ImportedModule `json:"modules" jsonschema:"External modules, the module imported by most files first"`
+	// TopPaths - most-imported external import paths
+	TopPaths []ImportedPath `json:"topPaths,omitempty" jsonschema:"Most-imported external import paths, at most 10"`
+	// Internal - imports of the module's own packages
+	Internal ImportCount `json:"internal" jsonschema:"Imports of the module's own packages"`
+	// Stdlib - imports of the standard library
+	Stdlib ImportCount `json:"stdlib" jsonschema:"Imports of the standard library"`
+	// Unresolved - import paths no module of the build list provides
+	Unresolved []string `json:"unresolved,omitempty" jsonschema:"Import paths no module of the build list provides"`
+}
+
+// ImportedModule aggregates the imports of one external module.
+type ImportedModule struct {
+	// Module - module path
+	Module string `json:"module" jsonschema:"Module path"`
+	// Version - selected module version, or the replacement's version
+	Version string `json:"version,omitempty" jsonschema:"Selected module version, or the replacement's version; empty for local replacements"`
+	// Replace - replacement module path or local directory from a replace directive
+	Replace string `json:"replace,omitempty" jsonschema:"Replacement module path or local directory from a replace directive"`
+	// Paths - import paths of the module in use, most-imported first
+	Paths []ImportedPath `json:"paths" jsonschema:"Import paths of the module in use, most-imported first"`
+	// Files - number of files importing the module
+	Files int `json:"files" jsonschema:"Number of files importing the module"`
+	// Packages - number of packages importing the module
+	Packages int `json:"packages" jsonschema:"Number of packages importing the module"`
+	// TopImporters - packages importing the module from most files
+	TopImporters []ImporterCount `json:"topImporters" jsonschema:"Packages importing the module from most files, at most 5"`
+}
+
+// ImportedPath counts the importers of one import path.
+type ImportedPath struct {
+	// Path - import path
+	Path string `json:"path" jsonschema:"Import path"`
+	// Files - number of files importing the path
+	Files int `json:"files" jsonschema:"Number of files importing the path"`
+	// Packages - number of packages importing the path
+	Packages int `json:"packages" jsonschema:"Number of packages importing the path"`
+}
+
+// ImporterCount is a package importing a module, with its number of importing files.
+type ImporterCount struct {
+	// Package - importing package path
+	Package string `json:"package" jsonschema:"Importing package path"`
+	// Files - number of its files importing the module
+	Files int `json:"files" jsonschema:"Number of its files importing the module"`
+}
+
+// ImportCount counts the imports of a category of packages.
+type ImportCount struct {
+	// Paths - number of distinct import paths
+	Paths int `json:"paths" jsonschema:"Number of distinct import paths"`
+	// Files - number of importing files
+	Files int `json:"files" jsonschema:"Number of importing files"`
 }
 
 // ------------------ list interfaces ------------------
@@ -268,8 +477,16 @@ type ListImportsOutput struct {
 type ListInterfacesInput struct {
 	// Dir - root directory to scan for Go files
 	Dir string `json:"dir" jsonschema:"Root directory to scan for Go files"`
+	// DependencyPackage - import path of a dependency to analyze instead of the module's own packages
+	DependencyPackage string `json:"dependencyPackage,omitempty" jsonschema:"Import path of a dependency (standard library, module cache, replace target or vendor/) to load in the module's context and analyze instead of the module's own packages"`
 	// Package - optional package path to restrict results
 	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// MinImplementations - only list interfaces with at least this many implementations in the module
+	MinImplementations int `json:"minImplementations,omitempty" jsonschema:"Only list interfaces with at least this many implementing types or extending interfaces in the module"`
+	// UsedAsParameter - only list interfaces used as a parameter or struct field type in the module
+	UsedAsParameter bool `json:"usedAsParameter,omitempty" jsonschema:"Only list interfaces used as a parameter or struct field type somewhere in the module"`
+	// ExportedOnly - only list exported interfaces
+	ExportedOnly bool `json:"exportedOnly,omitempty" jsonschema:"Only list exported interfaces"`
 }
 
 // InterfaceMethod represents an interface method.
@@ -290,6 +507,10 @@ type InterfaceInfo struct {
 	Line int `json:"line" jsonschema:"Line number of the interface declaration"`
 	// Methods - list of methods defined in the interface
 	Methods []InterfaceMethod `json:"methods" jsonschema:"List of methods defined in the interface"`
+	// ImplementationCount - implementing types and extending interfaces, computed when minImplementations or usedAsParameter is set
+	ImplementationCount int `json:"implementationCount,omitempty" jsonschema:"Implementing types and extending interfaces in the module; computed when minImplementations or usedAsParameter is set"`
+	// UsageCount - parameters and struct fields of the interface type, computed when minImplementations or usedAsParameter is set
+	UsageCount int `json:"usageCount,omitempty" jsonschema:"Parameters and struct fields of the interface type in the module; computed when minImplementations or usedAsParameter is set"`
 }
 
 // InterfaceGroupByPackage groups interfaces by package.
@@ -304,6 +525,14 @@ type InterfaceGroupByPackage struct {
 type ListInterfacesOutput struct {
 	// Interfaces - interfaces grouped by package
 	Interfaces []InterfaceGroupByPackage `json:"interfaces,omitempty" jsonschema:"Interfaces grouped by package"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// External - true when the results come from a dependency package (dependencyPackage)
+	External bool `json:"external,omitempty" jsonschema:"True when the results come from a dependency package (dependencyPackage)"`
+	// Dependency - analyzed dependency package and its module version (only with dependencyPackage)
+	Dependency *DependencyInfo `json:"dependency,omitempty" jsonschema:"Analyzed dependency package and its module version; file paths are relative to its dir (only with dependencyPackage)"`
 }
 
 // ------------------ analyze complexity ------------------
@@ -314,6 +543,23 @@ type AnalyzeComplexityInput struct {
 	Dir string `json:"dir" jsonschema:"Root directory to scan for Go files"`
 	// Package - optional package path to restrict results
 	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// SortBy - ranking metric: cyclomatic (default), cognitive, lines or nesting
+	SortBy string `json:"sortBy,omitempty" jsonschema:"Ranking metric: cyclomatic (default), cognitive, lines or nesting"`
+	// Order - ranking order: desc (default) or asc
+	Order string `json:"order,omitempty" jsonschema:"Ranking order: desc (default) or asc"`
+	// Top - when set, return a flat ranked list of the N worst functions instead of per-file groups
+	Top int `json:"top,omitempty" jsonschema:"When set, return a flat ranked list of the N worst functions instead of per-file groups"`
+
+	// DetectRecursion - mark directly and mutually recursive functions (needs type information)
+	DetectRecursion bool `json:"detectRecursion,omitempty" jsonschema:"Mark directly and mutually recursive functions, resolving calls with type information; the summary then counts them per package"`
+	// WithHints - if true, add nextSteps suggesting follow-up tool calls
+	WithHints bool `json:"withHints,omitempty" jsonschema:"If true, add nextSteps suggesting follow-up tool calls: getFunctionSource for the worst ranked function (only with top and descending order)"`
+	// IncludeTestdata - if true, also analyze the packages in testdata directories
+	IncludeTestdata bool `json:"includeTestdata,omitempty" jsonschema:"If true, also load the packages in testdata directories below dir, which are skipped by default like go list ./... does (fixtures, often broken or dead on purpose)"`
+	// OwnersFile - CODEOWNERS-format file resolving the owner of every reported file
+	OwnersFile string `json:"ownersFile,omitempty" jsonschema:"CODEOWNERS-format file, relative to dir unless absolute, resolving the owner of every reported file (default: the --owners-file of the server; no owners without either)"`
+	// GroupByOwner - if true, count the analyzed functions per owner
+	GroupByOwner bool `json:"groupByOwner,omitempty" jsonschema:"If true, add byOwner counting the analyzed functions per owner, '(unowned)' for files no rule matches; needs an ownersFile"`
 }
 
 // FunctionComplexityGroupByFile represents symbols grouped by file within a package.
@@ -322,12 +568,18 @@ type FunctionComplexityGroupByFile struct {
 	File string `json:"file" jsonschema:"File where the symbols are defined"`
 	// Functions - list of functions in this file
 	Functions []FunctionComplexityInfo `json:"functions" jsonschema:"Calculated complexity metrics for all functions"`
+	// Owner - owners of the file from the ownersFile, space-separated
+	Owner string `json:"owner,omitempty" jsonschema:"Owners of the file resolved from the ownersFile, space-separated as written there (only with an ownersFile; empty for an unowned file)"`
 }
 
 // FunctionComplexity represents function complexity metrics.
 type FunctionComplexity struct {
 	// Name - function name
 	Name string `json:"name" jsonschema:"Function name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
+	// Receiver - receiver type name if this is a method
+	Receiver string `json:"receiver,omitempty" jsonschema:"Receiver type name if this is a method"`
 	// File - file where the function is defined
 	File string `json:"file" jsonschema:"File where the function is defined"`
 	// Line - line number of the function
@@ -338,11 +590,24 @@ type FunctionComplexity struct {
 	Nesting int `json:"nesting" jsonschema:"Maximum nesting depth"`
 	// Cyclomatic - cyclomatic complexity
 	Cyclomatic int `json:"cyclomatic" jsonschema:"Cyclomatic complexity value"`
+	// Cognitive - cognitive complexity
+	Cognitive int `json:"cognitive" jsonschema:"Cognitive complexity value"`
+	// Closure - true for a function literal, named after its enclosing function (F.func1, F.func1.1)
+	Closure bool `json:"closure,omitempty" jsonschema:"True for a function literal, named after its enclosing function as the runtime does (F.func1, F.func1.1); its statements are not counted in the enclosing function"`
+
+	// Recursive - true if the function takes part in recursion within its package (only with DetectRecursion)
+	Recursive bool `json:"recursive,omitempty" jsonschema:"True if the function calls itself or is part of a mutual recursion cycle in its package (only with detectRecursion)"`
+	// RecursionCycle - functions of the mutual recursion cycle, this one included, sorted by name
+	RecursionCycle []string `json:"recursionCycle,omitempty" jsonschema:"Functions of the mutual recursion cycle the function belongs to, itself included, sorted by name"`
+	// Owner - owners of the file from the ownersFile, space-separated
+	Owner string `json:"owner,omitempty" jsonschema:"Owners of the file resolved from the ownersFile, space-separated as written there (only with an ownersFile; empty for an unowned file)"`
 }
 
 type FunctionComplexityInfo struct {
 	// Name - function name
 	Name string `json:"name" jsonschema:"Function name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Line - line number of the function
 	Line int `json:"line" jsonschema:"Line number of the function"`
 	// Lines - total number of lines in the function
@@ -351,12 +616,80 @@ type FunctionComplexityInfo struct {
 	Nesting int `json:"nesting" jsonschema:"Maximum nesting depth"`
 	// Cyclomatic - cyclomatic complexity
 	Cyclomatic int `json:"cyclomatic" jsonschema:"Cyclomatic complexity value"`
+	// Cognitive - cognitive complexity
+	Cognitive int `json:"cognitive" jsonschema:"Cognitive complexity value"`
+	// Closure - true for a function literal, named after its enclosing function (F.func1, F.func1.1)
+	Closure bool `json:"closure,omitempty" jsonschema:"True for a function literal, named after its enclosing function as the runtime does (F.func1, F.func1.1); its statements are not counted in the enclosing function"`
+
+	// Recursive - true if the function takes part in recursion within its package (only with DetectRecursion)
+	Recursive bool `json:"recursive,omitempty" jsonschema:"True if the function calls itself or is part of a mutual recursion cycle in its package (only with detectRecursion)"`
+	// RecursionCycle - functions of the mutual recursion cycle, this one included, sorted by name
+	RecursionCycle []string `json:"recursionCycle,omitempty" jsonschema:"Functions of the mutual recursion cycle the function belongs to, itself included, sorted by name"`
+}
+
+// ComplexitySummary aggregates complexity metrics over all analyzed functions.
+type ComplexitySummary struct {
+	// FunctionCount - number of analyzed functions
+	FunctionCount int `json:"functionCount" jsonschema:"Number of analyzed functions"`
+	// AverageCyclomatic - average cyclomatic complexity
+	AverageCyclomatic float64 `json:"averageCyclomatic" jsonschema:"Average cyclomatic complexity"`
+	// AverageCognitive - average cognitive complexity
+	AverageCognitive float64 `json:"averageCognitive" jsonschema:"Average cognitive complexity"`
+	// MaxCyclomatic - highest cyclomatic complexity
+	MaxCyclomatic int `json:"maxCyclomatic" jsonschema:"Highest cyclomatic complexity"`
+	// MaxCognitive - highest cognitive complexity
+	MaxCognitive int `json:"maxCognitive" jsonschema:"Highest cognitive complexity"`
+	// TotalLines - total number of lines in all functions
+	TotalLines int `json:"totalLines" jsonschema:"Total number of lines in all functions"`
+
+	// RecursiveByPackage - number of recursive functions per package (only with DetectRecursion)
+	RecursiveByPackage map[string]int `json:"recursiveByPackage,omitempty" jsonschema:"Number of recursive functions per package (only with detectRecursion)"`
 }
 
 // AnalyzeComplexityOutput contains results from the AnalyzeComplexity tool.
 type AnalyzeComplexityOutput struct {
-	// Functions - calculated complexity metrics for all functions
-	Functions []FunctionComplexityGroupByFile `json:"functions" jsonschema:"Calculated complexity metrics for functions"`
+	// Functions - calculated complexity metrics for all functions (omitted when Top is set)
+	Functions []FunctionComplexityGroupByFile `json:"functions,omitempty" jsonschema:"Calculated complexity metrics for functions, grouped by file (omitted when top is set)"`
+	// Ranked - the N worst functions in ranking order (only when Top is set)
+	Ranked []FunctionComplexity `json:"ranked,omitempty" jsonschema:"The N worst functions in ranking order (only when top is set)"`
+	// Summary - module-wide aggregates (only when Top is set)
+	Summary *ComplexitySummary `json:"summary,omitempty" jsonschema:"Module-wide aggregates (only when top is set)"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// Suppressed - functions left out of the ranking by a //gonav:ignore directive (only when Top is set)
+	Suppressed []SuppressedFinding `json:"suppressed,omitempty" jsonschema:"Functions left out of the ranking by a //gonav:ignore directive (only when top is set)"`
+	// NextSteps - suggested follow-up tool calls (only with withHints)
+	NextSteps []NextStep `json:"nextSteps,omitempty" jsonschema:"Suggested follow-up tool calls (only with withHints)"`
+	// SkippedTestdataPackages - number of testdata packages left out
+	SkippedTestdataPackages int `json:"skippedTestdataPackages,omitempty" jsonschema:"Number of packages in testdata directories below dir left out of the results (see includeTestdata)"`
+	// ByOwner - number of analyzed functions per owner (only with GroupByOwner)
+	ByOwner map[string]int `json:"byOwner,omitempty" jsonschema:"Number of analyzed functions per owner, '(unowned)' for files no rule matches (only with groupByOwner)"`
+}
+
+// SuppressedFinding is a declaration an analyzer skipped because of a //gonav:ignore directive.
+type SuppressedFinding struct {
+	// File - file of the suppressed declaration
+	File string `json:"file" jsonschema:"File of the suppressed declaration"`
+	// Line - line of the suppressed declaration
+	Line int `json:"line" jsonschema:"Line of the suppressed declaration"`
+	// Name - name of the suppressed declaration
+	Name string `json:"name" jsonschema:"Name of the suppressed declaration"`
+	// Tool - tool named by the directive, or 'all'
+	Tool string `json:"tool" jsonschema:"Tool named by the directive, or 'all'"`
+	// Reason - reason given by the directive
+	Reason string `json:"reason,omitempty" jsonschema:"Reason given by the directive"`
+}
+
+// NextStep is a follow-up tool call suggested by an analysis, returned with withHints.
+type NextStep struct {
+	// Tool - name of the tool to call
+	Tool string `json:"tool" jsonschema:"Name of the tool to call"`
+	// InputJSON - JSON input of the call, ready to send
+	InputJSON string `json:"inputJSON" jsonschema:"JSON input of the call, ready to send"`
+	// Reason - why the call is suggested, derived from the analysis result
+	Reason string `json:"reason" jsonschema:"Why the call is suggested, derived from the analysis result"`
 }
 
 // ------------------ dead code ------------------
@@ -371,22 +704,40 @@ type DeadCodeInput struct {
 	Limit int `json:"limit,omitempty" jsonschema:"Optional maximum number of unused symbols to include in the response (0 means no limit)"`
 	// Package - optional package path to restrict the scan
 	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// CheckInternalExported - report unused exported symbols of internal/ packages (default true)
+	CheckInternalExported *bool `json:"checkInternalExported,omitempty" jsonschema:"Report unused exported symbols of internal/ packages, which are invisible outside the module subtree (default true)"`
+	// WithHints - if true, add nextSteps suggesting follow-up tool calls
+	WithHints bool `json:"withHints,omitempty" jsonschema:"If true, add nextSteps suggesting follow-up tool calls: previewDelete for the first reported symbols"`
+	// IncludeWriteOnly - if true, also report variables that are assigned but never read
+	IncludeWriteOnly bool `json:"includeWriteOnly,omitempty" jsonschema:"If true, also report local and package-level variables that are assigned at least once but never read, as kind write-only-var; blank variables, named results and variables whose address is taken are excluded"`
+	// IncludeTestdata - if true, also analyze the packages in testdata directories
+	IncludeTestdata bool `json:"includeTestdata,omitempty" jsonschema:"If true, also load the packages in testdata directories below dir, which are skipped by default like go list ./... does (fixtures, often broken or dead on purpose)"`
+	// OwnersFile - CODEOWNERS-format file resolving the owner of every reported file
+	OwnersFile string `json:"ownersFile,omitempty" jsonschema:"CODEOWNERS-format file, relative to dir unless absolute, resolving the owner of every reported file (default: the --owners-file of the server; no owners without either)"`
+	// GroupByOwner - if true, count the unused symbols per owner
+	GroupByOwner bool `json:"groupByOwner,omitempty" jsonschema:"If true, add byOwner counting the unused symbols per owner, '(unowned)' for files no rule matches; needs an ownersFile"`
 }
 
 // DeadSymbol represents an unused symbol in Go code.
 type DeadSymbol struct {
 	// Name - symbol name
 	Name string `json:"name" jsonschema:"Symbol name"`
-	// Kind - symbol kind (func, var, const, type)
-	Kind string `json:"kind" jsonschema:"Symbol kind (func, var, const, type)"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
+	// Kind - symbol kind (func, var, const, type, write-only-var)
+	Kind string `json:"kind" jsonschema:"Symbol kind (func, var, const, type), or write-only-var for a variable assigned but never read (only with includeWriteOnly)"`
 	// File - file where the unused symbol is declared
 	File string `json:"file" jsonschema:"File where the unused symbol is declared"`
 	// Line - line number of the symbol
 	Line int `json:"line" jsonschema:"Line number of the symbol"`
 	// IsExported - true if the symbol is exported (starts with capital letter)
 	IsExported bool `json:"isExported" jsonschema:"True if the symbol is exported (starts with capital letter)"`
+	// InternalExported - true if the symbol is exported from an internal/ package and has no uses in the module
+	InternalExported bool `json:"internalExported,omitempty" jsonschema:"True if the symbol is exported from an internal/ package and has no uses in the module"`
 	// Package - package where the symbol is defined
 	Package string `json:"package" jsonschema:"Package where the symbol is defined"`
+	// Owner - owners of the file from the ownersFile, space-separated
+	Owner string `json:"owner,omitempty" jsonschema:"Owners of the file resolved from the ownersFile, space-separated as written there (only with an ownersFile; empty for an unowned file)"`
 }
 
 // DeadCodeOutput contains results from the DeadCode tool.
@@ -398,11 +749,19 @@ type DeadCodeOutput struct {
 	// ExportedCount - number of unused exported symbols
 	ExportedCount int `json:"exportedCount" jsonschema:"Number of exported symbols that are unused"`
 	// ByPackage - count of unused symbols grouped by package
-	ByPackage map[string]int `json:"byPackage" jsonschema:"Count of unused symbols grouped by package"`
+	ByPackage map[string]int `json:"byPackage,omitempty" jsonschema:"Count of unused symbols grouped by package"`
 	// ByKind - count of unused symbols grouped by symbol kind (func, var, const, type)
 	ByKind map[string]int `json:"byKind,omitempty" jsonschema:"Count of unused symbols grouped by symbol kind (func, var, const, type)"`
 	// HasMore - true when the response was limited and more results are available
 	HasMore bool `json:"hasMore,omitempty" jsonschema:"True if more unused symbols exist beyond the returned list"`
+	// Suppressed - unused symbols left out of the report by a //gonav:ignore directive
+	Suppressed []SuppressedFinding `json:"suppressed,omitempty" jsonschema:"Unused symbols left out of the report by a //gonav:ignore directive"`
+	// NextSteps - suggested follow-up tool calls (only with withHints)
+	NextSteps []NextStep `json:"nextSteps,omitempty" jsonschema:"Suggested follow-up tool calls (only with withHints)"`
+	// SkippedTestdataPackages - number of testdata packages left out
+	SkippedTestdataPackages int `json:"skippedTestdataPackages,omitempty" jsonschema:"Number of packages in testdata directories below dir left out of the results (see includeTestdata)"`
+	// ByOwner - number of unused symbols per owner (only with GroupByOwner)
+	ByOwner map[string]int `json:"byOwner,omitempty" jsonschema:"Number of unused symbols per owner, '(unowned)' for files no rule matches (only with groupByOwner)"`
 }
 
 // ------------------ rename symbol ------------------
@@ -412,21 +771,119 @@ type RenameSymbolInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
 	// OldName - current symbol name to rename; supports format 'TypeName.MethodName' for methods
-	OldName string `json:"oldName" jsonschema:"Current symbol name to rename; supports format 'TypeName.MethodName' for methods"`
+	OldName string `json:"oldName,omitempty" jsonschema:"Current symbol name to rename; supports format 'TypeName.MethodName' for methods"`
+	// SymbolID - stable symbol ID from a prior result; selects the symbol exactly instead of oldName and kind
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Symbol ID from a prior result (listSymbols, getDefinitions, ...), e.g. 'example.com/app/store.Store.Save#func'; selects exactly that symbol, same-named locals included, instead of oldName and kind"`
 	// NewName - new symbol name to apply
-	NewName string `json:"newName" jsonschema:"New symbol name to apply"`
+	NewName string `json:"newName,omitempty" jsonschema:"New symbol name to apply"`
 	// Kind - symbol kind: func, var, const, type, package
 	Kind string `json:"kind,omitempty" jsonschema:"Symbol kind: func, var, const, type, package"`
+	// Package - import path of the package declaring the symbol, to choose among same-named declarations
+	Package string `json:"package,omitempty" jsonschema:"Import path of the package declaring the symbol (e.g. example.com/app/config); required when several packages declare the name, which otherwise fails with AMBIGUOUS listing them"`
+	// Renames - batch of renames applied together; mutually exclusive with oldName, symbolId, newName and kind
+	Renames []RenamePair `json:"renames,omitempty" jsonschema:"Batch of renames applied together; mutually exclusive with oldName, symbolId, newName and kind"`
 	// DryRun - if true, returns only a preview of changes without writing files
 	DryRun bool `json:"dryRun,omitempty" jsonschema:"If true, only return a diff preview without writing files"`
+	// PreviewOnly - if true, return only the impact summary: no diffs, no writes
+	PreviewOnly bool `json:"previewOnly,omitempty" jsonschema:"If true, resolve the references only and return the impact summary (files, packages, per-package counts, test/non-test/generated occurrences) with collisions, without formatting, diffing or writing anything"`
+	// DiffMode - rendering of diffs: 'unified' (default), 'minimal' or 'summary'
+	DiffMode string `json:"diffMode,omitempty" jsonschema:"Rendering of diffs: 'unified' (default, 3 context lines), 'minimal' (changed lines only, prefixed with file:line) or 'summary' (per-file insertion/deletion counts and changed line numbers)"`
+	// AllowGenerated - if true, also edit files carrying a 'Code generated ... DO NOT EDIT.' header
+	AllowGenerated bool `json:"allowGenerated,omitempty" jsonschema:"If true, also edit files carrying a 'Code generated ... DO NOT EDIT.' header"`
+	// VerifyBuild - if true, type-check the module after writing, as the verifyBuild tool does
+	VerifyBuild bool `json:"verifyBuild,omitempty" jsonschema:"If true, reload and type-check the module after writing the files, as the verifyBuild tool does"`
+	// ExpectedHashes - content hashes by relative file path from prior reads; a mismatch refuses the change
+	ExpectedHashes map[string]string `json:"expectedHashes,omitempty" jsonschema:"contentHash by file path relative to dir, as returned by prior reads (getFunctionSource, getFileInfo, getStructInfo, navigateFile); if any listed file changed since, the call fails with CONFLICT naming the stale files and writes nothing"`
+	// AllowPartial - if true, write the rename although packages that may reference the symbol have errors
+	AllowPartial bool `json:"allowPartial,omitempty" jsonschema:"If true, write the rename although packages mentioning the old name could not be analyzed for load, parse or type errors; they are left untouched and the output is marked partial. Without it such a rename fails with TYPE_ERRORS_PRESENT; dry runs and previews never need it"`
+	// RenameCompanions - rename the Example, Benchmark and Fuzz functions named after a renamed declaration (default true)
+	RenameCompanions *bool `json:"renameCompanions,omitempty" jsonschema:"Rename the Example, Benchmark and Fuzz functions of the package's tests named after a renamed declaration (ExampleF, BenchmarkT_M, ExampleT_M_suffix) along with it, so examples stay attached in godoc (default true); when false they are listed in companionsNeedingRename"`
+}
+
+// RenamePair is one rename of a batch renameSymbol call.
+type RenamePair struct {
+	// OldName - current symbol name; supports format 'TypeName.MethodName' for methods
+	OldName string `json:"oldName,omitempty" jsonschema:"Current symbol name, unless symbolId is given; supports format 'TypeName.MethodName' for methods"`
+	// SymbolID - stable symbol ID from a prior result; selects the symbol exactly instead of oldName and kind
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Symbol ID from a prior result (listSymbols, getDefinitions, ...), e.g. 'example.com/app/store.Store.Save#func'; selects exactly that symbol, same-named locals included, instead of oldName and kind"`
+	// NewName - new symbol name to apply
+	NewName string `json:"newName" jsonschema:"New symbol name to apply"`
+	// Kind - symbol kind: func, var, const, type, package
+	Kind string `json:"kind,omitempty" jsonschema:"Symbol kind: func, var, const, type, package"`
+}
+
+// RenameCompanion is an Example, Benchmark or Fuzz function named after a renamed declaration.
+type RenameCompanion struct {
+	// File - test file declaring the function
+	File string `json:"file" jsonschema:"Test file declaring the function"`
+	// Line - line of the function
+	Line int `json:"line" jsonschema:"Line of the function"`
+	// Name - current name of the function
+	Name string `json:"name" jsonschema:"Current name of the function, e.g. ExampleStore_Save_retry"`
+	// NewName - name that follows the renamed declaration
+	NewName string `json:"newName" jsonschema:"Name that keeps the function attached to the renamed declaration"`
+	// Package - package clause of the test file
+	Package string `json:"package" jsonschema:"Package clause of the test file (the package or its _test package)"`
+	// Reason - why the function cannot be renamed along with the declaration
+	Reason string `json:"reason,omitempty" jsonschema:"Why the function cannot be renamed along with the declaration (unexported new name, name already declared, generated file)"`
 }
 
 // FileDiff represents delta of changes in a file.
 type FileDiff struct {
 	// Path - file path where changes occurred
 	Path string `json:"path" jsonschema:"File path where changes occurred"`
-	// Diff - unified diff showing changes
-	Diff string `json:"diff" jsonschema:"Unified diff showing changes"`
+	// Diff - changes rendered according to the diffMode input (unified diff by default)
+	Diff string `json:"diff" jsonschema:"Changes rendered according to the diffMode input (unified diff by default)"`
+}
+
+// GeneratedFile is a generated file a mutating tool left untouched.
+type GeneratedFile struct {
+	// File - relative path to the generated file
+	File string `json:"file" jsonschema:"Relative path to the generated file"`
+	// Generator - generator named in the file header, empty if the header names none
+	Generator string `json:"generator,omitempty" jsonschema:"Generator named in the file header, empty if the header names none"`
+}
+
+// RenamePackageImpact counts the occurrences a rename changes in one package.
+type RenamePackageImpact struct {
+	// Package - import path of the package
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// Files - files with occurrences
+	Files int `json:"files" jsonschema:"Files with occurrences"`
+	// References - occurrences, the declaration included
+	References int `json:"references" jsonschema:"Occurrences, the declaration included"`
+}
+
+// RenamePackageStatus is what a rename did in one package of the module.
+type RenamePackageStatus struct {
+	// Package - import path of the package
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// Status - clean, modified or skipped
+	Status string `json:"status" jsonschema:"clean (analyzed, no occurrences), modified (occurrences renamed, or to be renamed in a dry run or preview) or skipped (not analyzed for load, parse or type errors; left untouched)"`
+	// Files - files changed in the package
+	Files []string `json:"files,omitempty" jsonschema:"Files changed in the package (status modified)"`
+	// Error - first error of a skipped package
+	Error *BuildError `json:"error,omitempty" jsonschema:"First error of a skipped package"`
+}
+
+// RenameImpact summarizes what a rename would change before any diff is computed.
+type RenameImpact struct {
+	// Files - affected files
+	Files int `json:"files" jsonschema:"Affected files, generated ones included"`
+	// Packages - affected packages
+	Packages int `json:"packages" jsonschema:"Affected packages"`
+	// Occurrences - identifiers that would be renamed
+	Occurrences int `json:"occurrences" jsonschema:"Identifiers that would be renamed, the declaration included"`
+	// NonTestOccurrences - occurrences in non-test, non-generated files
+	NonTestOccurrences int `json:"nonTestOccurrences" jsonschema:"Occurrences in non-test files that are not generated"`
+	// TestOccurrences - occurrences in _test.go files that are not generated
+	TestOccurrences int `json:"testOccurrences" jsonschema:"Occurrences in _test.go files that are not generated"`
+	// GeneratedOccurrences - occurrences in generated files
+	GeneratedOccurrences int `json:"generatedOccurrences" jsonschema:"Occurrences in files with a 'Code generated ... DO NOT EDIT.' header"`
+	// BlockedByGeneratedGuard - some occurrence is in a generated file the rename would skip
+	BlockedByGeneratedGuard bool `json:"blockedByGeneratedGuard,omitempty" jsonschema:"True when some occurrence is in a generated file the rename would leave untouched (see skippedGenerated); pass allowGenerated to include them"`
+	// PerPackage - occurrences per package, most affected first
+	PerPackage []RenamePackageImpact `json:"perPackage" jsonschema:"Occurrences per package, most affected first"`
 }
 
 // RenameSymbolOutput contains results from the RenameSymbol tool.
@@ -437,6 +894,20 @@ type RenameSymbolOutput struct {
 	Diffs []FileDiff `json:"diffs,omitempty" jsonschema:"Diff results if dry run was used"`
 	// Collisions - list of name conflicts preventing rename
 	Collisions []string `json:"collisions,omitempty" jsonschema:"List of name conflicts preventing rename"`
+	// SkippedGenerated - generated files that would have changed but were left untouched
+	SkippedGenerated []GeneratedFile `json:"skippedGenerated,omitempty" jsonschema:"Generated files that would have changed but were left untouched"`
+	// Build - result of the type check requested with verifyBuild
+	Build *VerifyBuildOutput `json:"build,omitempty" jsonschema:"Result of the type check requested with verifyBuild"`
+	// Impact - impact summary of the rename (only with previewOnly)
+	Impact *RenameImpact `json:"impact,omitempty" jsonschema:"Impact summary of the rename (only with previewOnly)"`
+	// Packages - status of every package of the module
+	Packages []RenamePackageStatus `json:"packages,omitempty" jsonschema:"Status of every package of the module: clean, modified or skipped, sorted by path"`
+	// Partial - some skipped package mentions the old name, so references may have been missed
+	Partial bool `json:"partial,omitempty" jsonschema:"True when a skipped package mentions the old name, so the rename may have missed references there"`
+	// Companions - Example, Benchmark and Fuzz functions renamed along with their declarations
+	Companions []RenameCompanion `json:"companions,omitempty" jsonschema:"Example, Benchmark and Fuzz functions renamed (or to be renamed in a dry run or preview) along with the declarations they are named after"`
+	// CompanionsNeedingRename - companion functions left with a name that no longer follows their declaration
+	CompanionsNeedingRename []RenameCompanion `json:"companionsNeedingRename,omitempty" jsonschema:"Example, Benchmark and Fuzz functions left untouched (renameCompanions false, or see reason) whose names no longer follow the renamed declaration, so their godoc linkage breaks"`
 }
 
 // ------------------ analyze dependencies ------------------.
@@ -447,6 +918,12 @@ type AnalyzeDependenciesInput struct {
 	Dir string `json:"dir" jsonschema:"Root directory to scan for package dependencies"`
 	// Package - optional package path to restrict results
 	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// WithHints - if true, add nextSteps suggesting follow-up tool calls
+	WithHints bool `json:"withHints,omitempty" jsonschema:"If true, add nextSteps suggesting follow-up tool calls: listImports for the package whose import closes each cycle"`
+	// IncludeTestdata - if true, also analyze the packages in testdata directories
+	IncludeTestdata bool `json:"includeTestdata,omitempty" jsonschema:"If true, also load the packages in testdata directories below dir, which are skipped by default like go list ./... does (fixtures, often broken or dead on purpose)"`
+	// SortBy - order of the packages: instability, fanIn or depth
+	SortBy string `json:"sortBy,omitempty" jsonschema:"Order of the packages, highest first: 'instability', 'fanIn' or 'depth' (layerDepth); default is the load order"`
 }
 
 // PackageDependency represents information about package dependencies.
@@ -459,6 +936,22 @@ type PackageDependency struct {
 	FanIn int `json:"fanIn" jsonschema:"Number of other packages that import this package"`
 	// FanOut - number of packages this package imports
 	FanOut int `json:"fanOut" jsonschema:"Number of packages this package imports"`
+	// Instability - internal fanOut / (fanIn + fanOut)
+	Instability float64 `json:"instability" jsonschema:"Instability fanOut/(fanIn+fanOut) counting only imports between internal packages: 0 is maximally stable, 1 maximally unstable"`
+	// LayerDepth - longest internal import chain down to a package importing no internal package
+	LayerDepth int `json:"layerDepth" jsonschema:"Length of the longest chain of internal imports from this package down to one importing no internal package (0 for those)"`
+}
+
+// DependencyViolation is an import of a less stable package by a more stable one.
+type DependencyViolation struct {
+	// From - importing package
+	From string `json:"from" jsonschema:"Importing package"`
+	// To - imported, less stable package
+	To string `json:"to" jsonschema:"Imported, less stable package"`
+	// FromInstability - instability of the importing package
+	FromInstability float64 `json:"fromInstability" jsonschema:"Instability of the importing package"`
+	// ToInstability - instability of the imported package
+	ToInstability float64 `json:"toInstability" jsonschema:"Instability of the imported package"`
 }
 
 // AnalyzeDependenciesOutput contains results from the AnalyzeDependencies tool.
@@ -467,6 +960,12 @@ type AnalyzeDependenciesOutput struct {
 	Dependencies []PackageDependency `json:"dependencies" jsonschema:"List of packages and their dependencies"`
 	// Cycles - list of dependency cycles found in the project
 	Cycles [][]string `json:"cycles" jsonschema:"List of dependency cycles found in the project"`
+	// Violations - imports of less stable internal packages by more stable ones
+	Violations []DependencyViolation `json:"violations" jsonschema:"Imports of less stable internal packages by more stable ones (stable-dependencies principle), largest instability gap first"`
+	// NextSteps - suggested follow-up tool calls (only with withHints)
+	NextSteps []NextStep `json:"nextSteps,omitempty" jsonschema:"Suggested follow-up tool calls (only with withHints)"`
+	// SkippedTestdataPackages - number of testdata packages left out
+	SkippedTestdataPackages int `json:"skippedTestdataPackages,omitempty" jsonschema:"Number of packages in testdata directories below dir left out of the results (see includeTestdata)"`
 }
 
 // ------------------ find implementations ------------------.
@@ -475,14 +974,22 @@ type AnalyzeDependenciesOutput struct {
 type FindImplementationsInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// DependencyPackage - import path of a dependency to analyze instead of the module's own packages
+	DependencyPackage string `json:"dependencyPackage,omitempty" jsonschema:"Import path of a dependency (standard library, module cache, replace target or vendor/) to load in the module's context and analyze instead of the module's own packages"`
 	// Name - name of the interface or type to find implementations for
 	Name string `json:"name" jsonschema:"Name of the interface or type to find implementations for"`
+	// WithHints - if true, add nextSteps suggesting follow-up tool calls
+	WithHints bool `json:"withHints,omitempty" jsonschema:"If true, add nextSteps suggesting follow-up tool calls: explainImplements for the closest type when nothing implements the interface"`
+	// LSPLocations - if true, add an LSP location with the identifier range to every entry
+	LSPLocations bool `json:"lspLocations,omitempty" jsonschema:"If true, add lspLocation (file URI and zero-based UTF-16 range of the identifier) to every entry for LSP clients"`
 }
 
 // Implementation represents an interface implementation.
 type Implementation struct {
 	// Type - implementing type name
 	Type string `json:"type" jsonschema:"Implementing type name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Interface - interface being implemented
 	Interface string `json:"interface" jsonschema:"Interface being implemented"`
 	// File - file where the implementation is defined
@@ -490,13 +997,83 @@ type Implementation struct {
 	// Line - line number of the implementation
 	Line int `json:"line" jsonschema:"Line number of the implementation"`
 	// IsType - true if this is a type implementing an interface, false for interface-to-interface embedding
-	IsType bool `json:"isType" jsonschema:"True if this is a type implementing an interface, false for interface-to-interface embedding"`
+	IsType bool `json:"isType,omitempty" jsonschema:"True if this is a type implementing an interface; omitted (false) for interface-to-interface embedding"`
+	// LSPLocation - identifier location for LSP clients (only with lspLocations)
+	LSPLocation *LSPLocation `json:"lspLocation,omitempty" jsonschema:"Identifier location for LSP clients (only with lspLocations)"`
 }
 
 // FindImplementationsOutput contains results from the FindImplementations tool.
 type FindImplementationsOutput struct {
 	// Implementations - list of found implementations
 	Implementations []Implementation `json:"implementations" jsonschema:"List of found implementations"`
+	// Total - number of implementations found
+	Total int `json:"total" jsonschema:"Number of implementations found"`
+	// External - true when the results come from a dependency package (dependencyPackage)
+	External bool `json:"external,omitempty" jsonschema:"True when the results come from a dependency package (dependencyPackage)"`
+	// Dependency - analyzed dependency package and its module version (only with dependencyPackage)
+	Dependency *DependencyInfo `json:"dependency,omitempty" jsonschema:"Analyzed dependency package and its module version; file paths are relative to its dir (only with dependencyPackage)"`
+	// NextSteps - suggested follow-up tool calls (only with withHints)
+	NextSteps []NextStep `json:"nextSteps,omitempty" jsonschema:"Suggested follow-up tool calls (only with withHints)"`
+}
+
+// ------------------ find constructions ------------------
+
+// FindConstructionsInput contains input data for the FindConstructions tool.
+type FindConstructionsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// TypeName - name of the named type whose construction sites are searched, optionally package-qualified
+	TypeName string `json:"typeName" jsonschema:"Name of the named type whose construction sites are searched (Type, pkg.Type or import/path.Type)"`
+	// IncludeNew - also report new(T) calls
+	IncludeNew bool `json:"includeNew,omitempty" jsonschema:"Also report new(T) calls"`
+	// IncludeConstructors - also list functions returning T or *T with their call-site counts
+	IncludeConstructors bool `json:"includeConstructors,omitempty" jsonschema:"Also list functions returning T or *T with their call-site counts"`
+}
+
+// ConstructionSite represents a single place where the type is constructed.
+type ConstructionSite struct {
+	// Line - line number of the construction
+	Line int `json:"line" jsonschema:"Line number of the construction"`
+	// Kind - construction kind: literal or new
+	Kind string `json:"kind" jsonschema:"Construction kind: literal or new"`
+	// Positional - true for un-keyed struct literals, which break when fields change
+	Positional bool `json:"positional" jsonschema:"True for un-keyed struct literals, which break when fields change"`
+	// Snippet - source line of the construction
+	Snippet string `json:"snippet" jsonschema:"Source line of the construction"`
+}
+
+// ConstructionGroup groups construction sites by file.
+type ConstructionGroup struct {
+	// File - relative path to the file
+	File string `json:"file" jsonschema:"Relative path to the file"`
+	// Sites - construction sites within the file
+	Sites []ConstructionSite `json:"sites" jsonschema:"Construction sites within the file"`
+}
+
+// ConstructorFunc describes a function that returns the type.
+type ConstructorFunc struct {
+	// Name - function name
+	Name string `json:"name" jsonschema:"Function name"`
+	// File - relative path to the file where the function is defined
+	File string `json:"file" jsonschema:"Relative path to the file where the function is defined"`
+	// Line - line number of the function
+	Line int `json:"line" jsonschema:"Line number of the function"`
+	// Pointer - true if the function returns *T
+	Pointer bool `json:"pointer" jsonschema:"True if the function returns *T"`
+	// CallSites - number of calls to the function in the module
+	CallSites int `json:"callSites" jsonschema:"Number of calls to the function in the module"`
+}
+
+// FindConstructionsOutput contains results from the FindConstructions tool.
+type FindConstructionsOutput struct {
+	// Total - total number of construction sites
+	Total int `json:"total" jsonschema:"Total number of construction sites"`
+	// PositionalCount - number of positional (un-keyed) literals
+	PositionalCount int `json:"positionalCount" jsonschema:"Number of positional (un-keyed) literals"`
+	// Groups - construction sites grouped by file
+	Groups []ConstructionGroup `json:"groups,omitempty" jsonschema:"Construction sites grouped by file"`
+	// Constructors - functions returning T or *T (when includeConstructors is set)
+	Constructors []ConstructorFunc `json:"constructors,omitempty" jsonschema:"Functions returning T or *T (when includeConstructors is set)"`
 }
 
 // ------------------ metrics summary ------------------.
@@ -507,6 +1084,10 @@ type MetricsSummaryInput struct {
 	Dir string `json:"dir" jsonschema:"Root directory to scan for project metrics"`
 	// Package - optional package path to restrict metrics aggregation
 	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict metrics aggregation"`
+	// ExcludeHeaderComments - if true, leave the header comment of each file out of lineCount
+	ExcludeHeaderComments bool `json:"excludeHeaderComments,omitempty" jsonschema:"If true, do not count the leading comment block before the package clause (license headers) toward lineCount"`
+	// IncludeTestdata - if true, also analyze the packages in testdata directories
+	IncludeTestdata bool `json:"includeTestdata,omitempty" jsonschema:"If true, also load the packages in testdata directories below dir, which are skipped by default like go list ./... does (fixtures, often broken or dead on purpose)"`
 }
 
 // MetricsSummaryOutput contains results from the MetricsSummary tool.
@@ -529,6 +1110,8 @@ type MetricsSummaryOutput struct {
 	LineCount int `json:"lineCount" jsonschema:"Total lines of code"`
 	// FileCount - total number of Go files
 	FileCount int `json:"fileCount" jsonschema:"Total number of Go files"`
+	// SkippedTestdataPackages - number of testdata packages left out
+	SkippedTestdataPackages int `json:"skippedTestdataPackages,omitempty" jsonschema:"Number of packages in testdata directories below dir left out of the results (see includeTestdata)"`
 }
 
 // ------------------ ast rewrite ------------------.
@@ -543,6 +1126,28 @@ type ASTRewriteInput struct {
 	Replace string `json:"replace" jsonschema:"Pattern to replace with (e.g., 'x.Method()')"`
 	// DryRun - if true, returns only a preview of changes without writing files
 	DryRun bool `json:"dryRun" jsonschema:"If true, only return a diff preview without writing files"`
+	// DiffMode - rendering of diffs: 'unified' (default), 'minimal' or 'summary'
+	DiffMode string `json:"diffMode,omitempty" jsonschema:"Rendering of diffs: 'unified' (default, 3 context lines), 'minimal' (changed lines only, prefixed with file:line) or 'summary' (per-file insertion/deletion counts and changed line numbers)"`
+	// AllowGenerated - if true, also rewrite files carrying a 'Code generated ... DO NOT EDIT.' header
+	AllowGenerated bool `json:"allowGenerated,omitempty" jsonschema:"If true, also rewrite files carrying a 'Code generated ... DO NOT EDIT.' header"`
+	// VerifyBuild - if true, type-check the module after writing, as the verifyBuild tool does
+	VerifyBuild bool `json:"verifyBuild,omitempty" jsonschema:"If true, reload and type-check the module after writing the files, as the verifyBuild tool does"`
+	// ExpectedHashes - content hashes by relative file path from prior reads; a mismatch refuses the change
+	ExpectedHashes map[string]string `json:"expectedHashes,omitempty" jsonschema:"contentHash by file path relative to dir, as returned by prior reads (getFunctionSource, getFileInfo, getStructInfo, navigateFile); if any listed file changed since, the call fails with CONFLICT naming the stale files and writes nothing"`
+	// TypeCheck - type-check the replacements in memory and reject the ones introducing errors (default true)
+	TypeCheck *bool `json:"typeCheck,omitempty" jsonschema:"Type-check the rewritten files in memory before applying and reject each replacement that introduces type errors, listing it in rejected (default true)"`
+	// Atomic - apply nothing when any replacement is rejected
+	Atomic bool `json:"atomic,omitempty" jsonschema:"If true, apply no replacement at all when the type check rejects any of them"`
+}
+
+// RejectedRewrite is a replacement of rewriteAst left out because it does not type-check.
+type RejectedRewrite struct {
+	// File - file of the replacement, relative to dir
+	File string `json:"file" jsonschema:"File of the replacement, relative to dir"`
+	// Line - line of the matched expression
+	Line int `json:"line" jsonschema:"Line of the matched expression"`
+	// Error - type error the replacement introduced
+	Error string `json:"error" jsonschema:"Type error the replacement introduced"`
 }
 
 // ASTRewriteOutput contains results from the ASTRewrite tool.
@@ -553,6 +1158,12 @@ type ASTRewriteOutput struct {
 	Diffs []FileDiff `json:"diffs,omitempty" jsonschema:"Diff of changes if dry run was used"`
 	// TotalChanges - total number of changes made
 	TotalChanges int `json:"totalChanges" jsonschema:"Total number of changes made"`
+	// SkippedGenerated - generated files that would have changed but were left untouched
+	SkippedGenerated []GeneratedFile `json:"skippedGenerated,omitempty" jsonschema:"Generated files that would have changed but were left untouched"`
+	// Build - result of the type check requested with verifyBuild
+	Build *VerifyBuildOutput `json:"build,omitempty" jsonschema:"Result of the type check requested with verifyBuild"`
+	// Rejected - replacements left out because they introduce type errors
+	Rejected []RejectedRewrite `json:"rejected,omitempty" jsonschema:"Replacements left out because they introduce type errors; with atomic, nothing was applied"`
 }
 
 // ------------------ read func ------------------
@@ -561,16 +1172,32 @@ type ASTRewriteOutput struct {
 type ReadFuncInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// DependencyPackage - import path of a dependency to analyze instead of the module's own packages
+	DependencyPackage string `json:"dependencyPackage,omitempty" jsonschema:"Import path of a dependency (standard library, module cache, replace target or vendor/) to load in the module's context and analyze instead of the module's own packages"`
 	// Name - function or method name (e.g., 'List' or 'TaskService.List')
-	Name string `json:"name" jsonschema:"Function or method name (e.g., 'List' or 'TaskService.List')"`
+	Name string `json:"name,omitempty" jsonschema:"Function or method name (e.g., 'List' or 'TaskService.List'); required unless names is set"`
+	// Names - several function or method names to return in one call instead of name
+	Names []string `json:"names,omitempty" jsonschema:"Several function or method names (each optionally Type.Method) to return in one call instead of name; results go to functions and notFound"`
+	// MaxTotalLines - budget of source lines across the returned functions
+	MaxTotalLines int `json:"maxTotalLines,omitempty" jsonschema:"Budget of source lines across the returned functions, in request order; bodies beyond it are cut and flagged truncated (default unlimited)"`
+	// WithFingerprints - if true, include the normalized source fingerprint
+	WithFingerprints bool `json:"withFingerprints,omitempty" jsonschema:"If true, include a SHA-256 fingerprint of the normalized declaration source"`
+	// IncludeExamples - if true, also return Example functions documenting the function and tests named after it
+	IncludeExamples bool `json:"includeExamples,omitempty" jsonschema:"If true, also return the Example functions documenting the function (go doc naming) and the tests named after it, from the _test.go files of its package"`
+	// MaxExamples - maximum number of examples and tests to return (default 5)
+	MaxExamples int `json:"maxExamples,omitempty" jsonschema:"Maximum number of examples and tests to return with includeExamples (default 5)"`
 }
 
 // FunctionSource represents source code of a function or method in Go code.
 type FunctionSource struct {
 	// Name - function name
 	Name string `json:"name" jsonschema:"Function name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Receiver - receiver type name if this is a method (e.g., 'TaskService')
 	Receiver string `json:"receiver,omitempty" jsonschema:"Receiver type name if this is a method (e.g., 'TaskService')"`
+	// TypeParams - type parameters of a generic receiver (e.g., ['T'] for 'Store[T]')
+	TypeParams []string `json:"typeParams,omitempty" jsonschema:"Type parameters of a generic receiver (e.g., ['T'] for 'Store[T]')"`
 	// Package - package path where the function is defined
 	Package string `json:"package" jsonschema:"Package path where the function is defined"`
 	// File - relative path to the file where the function is defined
@@ -581,12 +1208,36 @@ type FunctionSource struct {
 	EndLine int `json:"endLine" jsonschema:"Ending line number of the function"`
 	// SourceCode - full source code of the function
 	SourceCode string `json:"sourceCode" jsonschema:"Full source code of the function or method"`
+	// Fingerprint - hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)
+	Fingerprint string `json:"fingerprint,omitempty" jsonschema:"Hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)"`
+	// BuildConstraint - build constraint of the declaring file (go:build expression and GOOS/GOARCH file name suffix)
+	BuildConstraint string `json:"buildConstraint,omitempty" jsonschema:"Build constraint of the declaring file (go:build expression and GOOS/GOARCH file name suffix), e.g. 'linux'"`
+	// ContentHash - hex SHA-256 of the file content, to pass as expectedHash(es) to mutating tools
+	ContentHash string `json:"contentHash,omitempty" jsonschema:"Hex SHA-256 of the current content of the declaring file; pass it as expectedHash or in expectedHashes to mutating tools so they refuse to write if the file changed since this read"`
+	// Truncated - true when sourceCode was cut by maxTotalLines
+	Truncated bool `json:"truncated,omitempty" jsonschema:"True when sourceCode was cut to fit maxTotalLines"`
 }
 
 // ReadFuncOutput contains results from the ReadFunc tool.
 type ReadFuncOutput struct {
 	// Function - found function with metadata and source code
-	Function FunctionSource `json:"function" jsonschema:"Extracted function with metadata and source code"`
+	Function FunctionSource `json:"function,omitzero" jsonschema:"Extracted function with metadata and source code (single name)"`
+	// Functions - found functions in request order (only with Names)
+	Functions []FunctionSource `json:"functions,omitempty" jsonschema:"Found functions in request order (only with names)"`
+	// NotFound - requested names without a declaration (only with Names)
+	NotFound []string `json:"notFound,omitempty" jsonschema:"Requested names without a declaration (only with names)"`
+	// Examples - Example functions documenting the function, then tests named after it (only with IncludeExamples)
+	Examples []FunctionSource `json:"examples,omitempty" jsonschema:"Example functions documenting the function, then tests named after it (only with includeExamples)"`
+	// HasMoreExamples - true when more examples were found than maxExamples allows
+	HasMoreExamples bool `json:"hasMoreExamples,omitempty" jsonschema:"True when more examples were found than maxExamples allows"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// External - true when the results come from a dependency package (dependencyPackage)
+	External bool `json:"external,omitempty" jsonschema:"True when the results come from a dependency package (dependencyPackage)"`
+	// Dependency - analyzed dependency package and its module version (only with dependencyPackage)
+	Dependency *DependencyInfo `json:"dependency,omitempty" jsonschema:"Analyzed dependency package and its module version; file paths are relative to its dir (only with dependencyPackage)"`
 }
 
 // ------------------ read go file ------------------
@@ -648,6 +1299,8 @@ type ReadGoFileOutput struct {
 	Symbols []Symbol `json:"symbols,omitempty" jsonschema:"List of declared symbols within the file"`
 	// Source - source code of the file (if requested mode is raw or ast)
 	Source string `json:"source,omitempty" jsonschema:"Full source code of the file if requested"`
+	// ContentHash - hex SHA-256 of the file content, to pass as expectedHash(es) to mutating tools
+	ContentHash string `json:"contentHash,omitempty" jsonschema:"Hex SHA-256 of the current content of the file; pass it as expectedHash or in expectedHashes to mutating tools so they refuse to write if the file changed since this read"`
 }
 
 // ------------------ read struct ------------------
@@ -656,10 +1309,18 @@ type ReadGoFileOutput struct {
 type ReadStructInput struct {
 	// Dir - root directory of the Go module
 	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// DependencyPackage - import path of a dependency to analyze instead of the module's own packages
+	DependencyPackage string `json:"dependencyPackage,omitempty" jsonschema:"Import path of a dependency (standard library, module cache, replace target or vendor/) to load in the module's context and analyze instead of the module's own packages"`
 	// Name - name of the struct to read (e.g., 'User' or 'models.User')
 	Name string `json:"name" jsonschema:"Name of the struct to read (e.g., 'User' or 'models.User')"`
 	// IncludeMethods - if true, also returns methods of the struct
 	IncludeMethods bool `json:"includeMethods,omitempty" jsonschema:"If true, also include methods of the struct"`
+	// WithFingerprints - if true, include the normalized source fingerprint
+	WithFingerprints bool `json:"withFingerprints,omitempty" jsonschema:"If true, include a SHA-256 fingerprint of the normalized declaration source"`
+	// IncludeExamples - if true, also return Example functions documenting the struct and tests named after it
+	IncludeExamples bool `json:"includeExamples,omitempty" jsonschema:"If true, also return the Example functions documenting the struct (go doc naming) and the tests named after it, from the _test.go files of its package"`
+	// MaxExamples - maximum number of examples and tests to return (default 5)
+	MaxExamples int `json:"maxExamples,omitempty" jsonschema:"Maximum number of examples and tests to return with includeExamples (default 5)"`
 }
 
 // StructField represents a single field of a struct.
@@ -678,6 +1339,8 @@ type StructField struct {
 type StructInfo struct {
 	// Name - struct name
 	Name string `json:"name" jsonschema:"Struct name"`
+	// SymbolID - stable ID of the symbol, accepted as symbolId by the tools that take one
+	SymbolID string `json:"symbolId,omitempty" jsonschema:"Stable symbol ID, <package path>.[<owner>.]<name>#<kind>, accepted as symbolId by getReferences, getSymbolContext and renameSymbol"`
 	// Package - package name where the struct is defined
 	Package string `json:"package" jsonschema:"Package where the struct is defined"`
 	// File - relative path to the file where the struct is defined
@@ -686,6 +1349,8 @@ type StructInfo struct {
 	Line int `json:"line" jsonschema:"Line number where the struct is declared"`
 	// Exported - true if the struct is exported
 	Exported bool `json:"exported" jsonschema:"True if the struct is exported"`
+	// TypeParams - type parameters of a generic struct
+	TypeParams []string `json:"typeParams,omitempty" jsonschema:"Type parameters of a generic struct"`
 	// Doc - documentation above the struct (comment)
 	Doc string `json:"doc,omitempty" jsonschema:"Struct documentation comment"`
 	// Fields - list of struct fields
@@ -694,12 +1359,28 @@ type StructInfo struct {
 	Methods []string `json:"methods,omitempty" jsonschema:"List of methods belonging to the struct"`
 	// Source - source code of struct declaration
 	Source string `json:"source" jsonschema:"Full struct source code"`
+	// Fingerprint - hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)
+	Fingerprint string `json:"fingerprint,omitempty" jsonschema:"Hex SHA-256 of the normalized declaration source (gofmt tokens, comments stripped)"`
+	// ContentHash - hex SHA-256 of the file content, to pass as expectedHash(es) to mutating tools
+	ContentHash string `json:"contentHash,omitempty" jsonschema:"Hex SHA-256 of the current content of the declaring file; pass it as expectedHash or in expectedHashes to mutating tools so they refuse to write if the file changed since this read"`
 }
 
 // ReadStructOutput contains results from the ReadStruct tool.
 type ReadStructOutput struct {
 	// Struct - description of the found struct
 	Struct StructInfo `json:"struct" jsonschema:"Description of the found struct"`
+	// Examples - Example functions documenting the struct, then tests named after it (only with IncludeExamples)
+	Examples []FunctionSource `json:"examples,omitempty" jsonschema:"Example functions documenting the struct, then tests named after it (only with includeExamples)"`
+	// HasMoreExamples - true when more examples were found than maxExamples allows
+	HasMoreExamples bool `json:"hasMoreExamples,omitempty" jsonschema:"True when more examples were found than maxExamples allows"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+	// External - true when the results come from a dependency package (dependencyPackage)
+	External bool `json:"external,omitempty" jsonschema:"True when the results come from a dependency package (dependencyPackage)"`
+	// Dependency - analyzed dependency package and its module version (only with dependencyPackage)
+	Dependency *DependencyInfo `json:"dependency,omitempty" jsonschema:"Analyzed dependency package and its module version; file paths are relative to its dir (only with dependencyPackage)"`
 }
 
 // ------------------ project schema ------------------
@@ -711,6 +1392,9 @@ type ProjectSchemaInput struct {
 
 	// Depth - level of analysis detail: "summary", "standard", or "deep"
 	Depth string `json:"depth,omitempty" jsonschema:"Level of analysis detail: summary, standard, or deep"`
+
+	// MaxDepth - import levels of internal packages listed per entry point
+	MaxDepth int `json:"maxDepth,omitempty" jsonschema:"Import levels of internal packages listed in the wiring of each entry point (default 2)"`
 }
 
 // ProjectPackageSymbols represents exported symbols within a package.
@@ -731,6 +1415,10 @@ type ProjectPackage struct {
 	Path string `json:"path" jsonschema:"Full import path of the package"`
 	// Name - short package name
 	Name string `json:"name" jsonschema:"Short package name"`
+	// FileCount - number of non-test Go files
+	FileCount int `json:"fileCount" jsonschema:"Number of non-test Go files of the package in the current build context"`
+	// TestFileCount - number of _test.go files
+	TestFileCount int `json:"testFileCount,omitempty" jsonschema:"Number of _test.go files in the package directory in the current build context"`
 	// Imports - list of imported package paths
 	Imports []string `json:"imports,omitempty" jsonschema:"List of imported package paths"`
 	// Symbols - exported symbols defined in the package
@@ -747,6 +1435,40 @@ type ProjectInterface struct {
 	DefinedIn string `json:"definedIn" jsonschema:"Package path where the interface is defined"`
 }
 
+// ProjectFlag is a command-line flag defined by a main package.
+type ProjectFlag struct {
+	// Name - flag name
+	Name string `json:"name" jsonschema:"Flag name"`
+	// Func - defining function (e.g., 'flag.String' or 'flag.FlagSet.DurationVar')
+	Func string `json:"func" jsonschema:"Defining function, e.g. 'flag.String' or 'flag.FlagSet.DurationVar'"`
+	// File - file of the definition
+	File string `json:"file" jsonschema:"File of the definition"`
+	// Line - line of the definition
+	Line int `json:"line" jsonschema:"Line of the definition"`
+}
+
+// ProjectWiredPackage is a module package an entry point imports.
+type ProjectWiredPackage struct {
+	// Path - import path
+	Path string `json:"path" jsonschema:"Import path"`
+	// Depth - 1 for direct imports, 2 for imports of those, and so on
+	Depth int `json:"depth" jsonschema:"1 for direct imports, 2 for imports of those, and so on"`
+}
+
+// ProjectEntryPoint describes a main package and what it wires together.
+type ProjectEntryPoint struct {
+	// Package - import path of the main package
+	Package string `json:"package" jsonschema:"Import path of the main package"`
+	// Dir - directory of the package relative to the module root
+	Dir string `json:"dir" jsonschema:"Directory of the package relative to the analyzed dir"`
+	// Calls - functions called from main() and init(), qualified pkg.Func or pkg.Type.Method
+	Calls []string `json:"calls" jsonschema:"Functions and methods called from main() and init(), qualified pkg.Func or pkg.Type.Method, in source order"`
+	// Flags - flags defined with constant names through flag or pflag
+	Flags []ProjectFlag `json:"flags" jsonschema:"Flags defined with constant names through the flag or pflag package or a FlagSet"`
+	// Wiring - module packages imported directly or transitively up to maxDepth
+	Wiring []ProjectWiredPackage `json:"wiring" jsonschema:"Module packages imported directly (depth 1) or transitively up to maxDepth"`
+}
+
 // ProjectDependencyGraph represents inter-package dependencies.
 type ProjectDependencyGraph map[string][]string
 
@@ -778,6 +1500,2846 @@ type ProjectSchemaOutput struct {
 	ExternalDeps []string `json:"externalDeps,omitempty" jsonschema:"List of external module dependencies"`
 	// DependencyGraph - package-to-package import graph
 	DependencyGraph ProjectDependencyGraph `json:"dependencyGraph,omitempty" jsonschema:"Package-to-package import graph"`
+	// PackageDirs - import path to directory relative to RootDir
+	PackageDirs map[string]string `json:"packageDirs,omitempty" jsonschema:"Import path of every package to its directory relative to rootDir ('.' for the root), for dir and file inputs of other tools"`
 	// Summary - aggregated counts of key code entities
 	Summary ProjectSummary `json:"summary,omitempty" jsonschema:"Aggregated counts of key code entities"`
+	// EntryPoints - main packages with their calls, flags and wiring (standard and deep depth)
+	EntryPoints []ProjectEntryPoint `json:"entryPoints,omitempty" jsonschema:"Main packages with the functions main/init call, the flags they define and the module packages they wire together (standard and deep depth)"`
+}
+
+// ------------------ analyze purity ------------------
+
+// AnalyzePurityInput contains input data for the AnalyzePurity tool.
+type AnalyzePurityInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// OwnersFile - CODEOWNERS-format file resolving the owner of every reported file
+	OwnersFile string `json:"ownersFile,omitempty" jsonschema:"CODEOWNERS-format file, relative to dir unless absolute, resolving the owner of every reported file (default: the --owners-file of the server; no owners without either)"`
+	// GroupByOwner - if true, count the functions per owner
+	GroupByOwner bool `json:"groupByOwner,omitempty" jsonschema:"If true, add byOwner counting the functions per owner, '(unowned)' for files no rule matches; needs an ownersFile"`
+}
+
+// FunctionPurityInfo describes the side-effect class of a single function.
+type FunctionPurityInfo struct {
+	// Name - function name ('Type.Method' for methods)
+	Name string `json:"name" jsonschema:"Function name ('Type.Method' for methods)"`
+	// Line - line number of the function
+	Line int `json:"line" jsonschema:"Line number of the function"`
+	// Class - side-effect class: pure, reads-global, writes-global or performs-IO
+	Class string `json:"class" jsonschema:"Side-effect class: pure, reads-global, writes-global or performs-IO"`
+	// Reason - leaf fact that made the function impure
+	Reason string `json:"reason,omitempty" jsonschema:"Leaf fact that made the function impure"`
+	// Chain - call chain (up to 3 hops) through which impurity was propagated
+	Chain []string `json:"chain,omitempty" jsonschema:"Call chain (up to 3 hops) through which impurity was propagated"`
+}
+
+// FunctionPurityGroupByFile groups purity results by file.
+type FunctionPurityGroupByFile struct {
+	// File - file where the functions are defined
+	File string `json:"file" jsonschema:"File where the functions are defined"`
+	// Functions - purity results for functions in this file
+	Functions []FunctionPurityInfo `json:"functions" jsonschema:"Purity results for functions in this file"`
+	// Owner - owners of the file from the ownersFile, space-separated
+	Owner string `json:"owner,omitempty" jsonschema:"Owners of the file resolved from the ownersFile, space-separated as written there (only with an ownersFile; empty for an unowned file)"`
+}
+
+// AnalyzePurityOutput contains results from the AnalyzePurity tool.
+type AnalyzePurityOutput struct {
+	// Functions - purity results grouped by file
+	Functions []FunctionPurityGroupByFile `json:"functions,omitempty" jsonschema:"Purity results grouped by file"`
+	// ByClass - number of functions per side-effect class
+	ByClass map[string]int `json:"byClass" jsonschema:"Number of functions per side-effect class"`
+	// ByOwner - number of functions per owner (only with GroupByOwner)
+	ByOwner map[string]int `json:"byOwner,omitempty" jsonschema:"Number of functions per owner, '(unowned)' for files no rule matches (only with groupByOwner)"`
+}
+
+// ------------------ analyze logging ------------------
+
+// AnalyzeLoggingInput contains input data for the AnalyzeLogging tool.
+type AnalyzeLoggingInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// Loggers - qualified prefixes identifying logger families
+	Loggers []string `json:"loggers,omitempty" jsonschema:"Qualified prefixes identifying logger families (default: log, github.com/rs/zerolog, go.uber.org/zap, fmt.Print)"`
+	// OwnersFile - CODEOWNERS-format file resolving the owner of every reported file
+	OwnersFile string `json:"ownersFile,omitempty" jsonschema:"CODEOWNERS-format file, relative to dir unless absolute, resolving the owner of every reported file (default: the --owners-file of the server; no owners without either)"`
+	// GroupByOwner - if true, count the logging calls per owner
+	GroupByOwner bool `json:"groupByOwner,omitempty" jsonschema:"If true, add byOwner counting the logging calls per owner, '(unowned)' for files no rule matches; needs an ownersFile"`
+}
+
+// LogCall describes a single logging call.
+type LogCall struct {
+	// Line - line number of the call
+	Line int `json:"line" jsonschema:"Line number of the call"`
+	// Function - enclosing function ('Type.Method' for methods)
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function ('Type.Method' for methods)"`
+	// Logger - resolved package of the logging function
+	Logger string `json:"logger" jsonschema:"Resolved package of the logging function"`
+	// Family - logger prefix the call was matched by
+	Family string `json:"family" jsonschema:"Logger prefix the call was matched by"`
+	// Level - level or method name of the call (e.g. Printf, Error, Info)
+	Level string `json:"level" jsonschema:"Level or method name of the call (e.g. Printf, Error, Info)"`
+	// HasError - true if an error value is passed to the call
+	HasError bool `json:"hasError,omitempty" jsonschema:"True if an error value is passed to the call"`
+}
+
+// LogCallGroupByFile groups logging calls by file.
+type LogCallGroupByFile struct {
+	// File - file containing the calls
+	File string `json:"file" jsonschema:"File containing the calls"`
+	// Calls - logging calls in this file
+	Calls []LogCall `json:"calls" jsonschema:"Logging calls in this file"`
+	// Owner - owners of the file from the ownersFile, space-separated
+	Owner string `json:"owner,omitempty" jsonschema:"Owners of the file resolved from the ownersFile, space-separated as written there (only with an ownersFile; empty for an unowned file)"`
+}
+
+// LoggingPackageSummary describes which logger families a package uses.
+type LoggingPackageSummary struct {
+	// Package - package path
+	Package string `json:"package" jsonschema:"Package path"`
+	// Families - logger families used in the package
+	Families []string `json:"families" jsonschema:"Logger families used in the package"`
+	// Calls - number of logging calls in the package
+	Calls int `json:"calls" jsonschema:"Number of logging calls in the package"`
+	// Mixed - true if the package uses more than one logger family
+	Mixed bool `json:"mixed,omitempty" jsonschema:"True if the package uses more than one logger family"`
+}
+
+// LogFinding describes a fmt.Print* call outside a main package.
+type LogFinding struct {
+	// File - file containing the call
+	File string `json:"file" jsonschema:"File containing the call"`
+	// Line - line number of the call
+	Line int `json:"line" jsonschema:"Line number of the call"`
+	// Function - enclosing function
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function"`
+	// Call - qualified name of the called function
+	Call string `json:"call" jsonschema:"Qualified name of the called function"`
+}
+
+// AnalyzeLoggingOutput contains results from the AnalyzeLogging tool.
+type AnalyzeLoggingOutput struct {
+	// Total - total number of logging calls
+	Total int `json:"total" jsonschema:"Total number of logging calls"`
+	// Calls - logging calls grouped by file
+	Calls []LogCallGroupByFile `json:"calls,omitempty" jsonschema:"Logging calls grouped by file"`
+	// Packages - per-package logger family summary
+	Packages []LoggingPackageSummary `json:"packages,omitempty" jsonschema:"Per-package logger family summary"`
+	// MixedPackages - packages using more than one logger family
+	MixedPackages []string `json:"mixedPackages,omitempty" jsonschema:"Packages using more than one logger family"`
+	// FmtPrintFindings - fmt.Print* calls outside main packages
+	FmtPrintFindings []LogFinding `json:"fmtPrintFindings,omitempty" jsonschema:"fmt.Print* calls outside main packages"`
+	// ByOwner - number of logging calls per owner (only with GroupByOwner)
+	ByOwner map[string]int `json:"byOwner,omitempty" jsonschema:"Number of logging calls per owner, '(unowned)' for files no rule matches (only with groupByOwner)"`
+}
+
+// ------------------ declaration order ------------------
+
+// ReorderDeclarationsInput contains input data for the ReorderDeclarations tool.
+type ReorderDeclarationsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - relative path to the Go source file to reorder
+	File string `json:"file" jsonschema:"Relative path to the Go source file to reorder"`
+	// Policy - ordering policy: std, visibility or custom
+	Policy string `json:"policy,omitempty" jsonschema:"Ordering policy: std (default), visibility or custom"`
+	// CustomOrder - category order for the custom policy (const, var, type, func)
+	CustomOrder []string `json:"customOrder,omitempty" jsonschema:"Category order for the custom policy (const, var, type, func)"`
+	// DryRun - if true, returns only a preview of changes without writing files
+	DryRun bool `json:"dryRun,omitempty" jsonschema:"If true, only return a diff preview without writing files"`
+	// DiffMode - rendering of diffs: 'unified' (default), 'minimal' or 'summary'
+	DiffMode string `json:"diffMode,omitempty" jsonschema:"Rendering of diffs: 'unified' (default, 3 context lines), 'minimal' (changed lines only, prefixed with file:line) or 'summary' (per-file insertion/deletion counts and changed line numbers)"`
+	// AllowGenerated - if true, also reorder a file carrying a 'Code generated ... DO NOT EDIT.' header
+	AllowGenerated bool `json:"allowGenerated,omitempty" jsonschema:"If true, also reorder a file carrying a 'Code generated ... DO NOT EDIT.' header"`
+	// VerifyBuild - if true, type-check the module after writing, as the verifyBuild tool does
+	VerifyBuild bool `json:"verifyBuild,omitempty" jsonschema:"If true, reload and type-check the module after writing the files, as the verifyBuild tool does"`
+	// ExpectedHash - content hash of the file from a prior read; a mismatch refuses the change
+	ExpectedHash string `json:"expectedHash,omitempty" jsonschema:"contentHash of the file returned by a prior read (getFunctionSource, getFileInfo, getStructInfo, navigateFile); if the file changed since, the call fails with CONFLICT and writes nothing"`
+}
+
+// ReorderDeclarationsOutput contains results from the ReorderDeclarations tool.
+type ReorderDeclarationsOutput struct {
+	// File - file that was analyzed
+	File string `json:"file" jsonschema:"File that was analyzed"`
+	// Changed - true if the declaration order differs from the policy
+	Changed bool `json:"changed" jsonschema:"True if the declaration order differs from the policy"`
+	// Order - top-level declarations in the resulting order
+	Order []string `json:"order,omitempty" jsonschema:"Top-level declarations in the resulting order"`
+	// Diff - diff of the reordering, rendered according to diffMode
+	Diff string `json:"diff,omitempty" jsonschema:"Diff of the reordering, rendered according to diffMode"`
+	// SkippedGenerated - the file, if it is generated and was left untouched
+	SkippedGenerated []GeneratedFile `json:"skippedGenerated,omitempty" jsonschema:"The file, if it is generated and was left untouched"`
+	// Build - result of the type check requested with verifyBuild
+	Build *VerifyBuildOutput `json:"build,omitempty" jsonschema:"Result of the type check requested with verifyBuild"`
+}
+
+// CheckDeclarationOrderInput contains input data for the CheckDeclarationOrder tool.
+type CheckDeclarationOrderInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - relative path to the Go source file to check
+	File string `json:"file" jsonschema:"Relative path to the Go source file to check"`
+	// Policy - ordering policy: std, visibility or custom
+	Policy string `json:"policy,omitempty" jsonschema:"Ordering policy: std (default), visibility or custom"`
+	// CustomOrder - category order for the custom policy (const, var, type, func)
+	CustomOrder []string `json:"customOrder,omitempty" jsonschema:"Category order for the custom policy (const, var, type, func)"`
+}
+
+// DeclOrderViolation describes a declaration placed out of policy order.
+type DeclOrderViolation struct {
+	// Name - declaration name
+	Name string `json:"name" jsonschema:"Declaration name"`
+	// Kind - declaration category (const, var, type, func)
+	Kind string `json:"kind" jsonschema:"Declaration category (const, var, type, func)"`
+	// Line - line number of the declaration
+	Line int `json:"line" jsonschema:"Line number of the declaration"`
+	// Message - explanation of the expected placement
+	Message string `json:"message" jsonschema:"Explanation of the expected placement"`
+}
+
+// CheckDeclarationOrderOutput contains results from the CheckDeclarationOrder tool.
+type CheckDeclarationOrderOutput struct {
+	// File - file that was analyzed
+	File string `json:"file" jsonschema:"File that was analyzed"`
+	// Ordered - true if the file already follows the policy
+	Ordered bool `json:"ordered" jsonschema:"True if the file already follows the policy"`
+	// Violations - declarations placed out of order
+	Violations []DeclOrderViolation `json:"violations" jsonschema:"Declarations placed out of order"`
+}
+
+// ------------------ watchProject / unwatchProject ------------------
+
+// WatchProjectInput contains input data for the WatchProject tool.
+type WatchProjectInput struct {
+	// Dir - root directory of the Go module to watch
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module to watch"`
+}
+
+// WatchProjectOutput contains results from the WatchProject tool.
+type WatchProjectOutput struct {
+	// Dir - absolute path of the watched directory
+	Dir string `json:"dir" jsonschema:"Absolute path of the watched directory"`
+	// Watching - all directories the current client is subscribed to
+	Watching []string `json:"watching" jsonschema:"All directories the current client is subscribed to"`
+}
+
+// UnwatchProjectInput contains input data for the UnwatchProject tool.
+type UnwatchProjectInput struct {
+	// Dir - root directory previously passed to watchProject
+	Dir string `json:"dir" jsonschema:"Root directory previously passed to watchProject"`
+}
+
+// UnwatchProjectOutput contains results from the UnwatchProject tool.
+type UnwatchProjectOutput struct {
+	// Dir - absolute path of the directory
+	Dir string `json:"dir" jsonschema:"Absolute path of the directory"`
+	// Removed - true if a subscription existed and was removed
+	Removed bool `json:"removed" jsonschema:"True if a subscription existed and was removed"`
+	// Watching - directories the current client is still subscribed to
+	Watching []string `json:"watching" jsonschema:"Directories the current client is still subscribed to"`
+}
+
+// ProjectChange describes changes detected in one watched directory.
+type ProjectChange struct {
+	// Dir - absolute path of the watched directory
+	Dir string `json:"dir" jsonschema:"Absolute path of the watched directory"`
+	// Files - changed files relative to Dir
+	Files []string `json:"files" jsonschema:"Changed files relative to the watched directory"`
+	// Invalidated - cached analyses dropped because of the change (by load mode)
+	Invalidated []string `json:"invalidated,omitempty" jsonschema:"Cached analyses dropped because of the change (by load mode)"`
+}
+
+// ProjectChangeNotification is the payload of the logging notification sent to watching clients.
+type ProjectChangeNotification struct {
+	// Event - notification kind, always 'projectChanged'
+	Event string `json:"event" jsonschema:"Notification kind, always 'projectChanged'"`
+	// Changes - changes grouped by watched directory
+	Changes []ProjectChange `json:"changes" jsonschema:"Changes grouped by watched directory"`
+}
+
+// ------------------ server status ------------------
+
+// GetServerStatusInput contains input data for the GetServerStatus tool.
+type GetServerStatusInput struct{}
+
+// CacheHydration describes the background warm-up of the in-memory cache for a directory.
+type CacheHydration struct {
+	// Dir - analyzed directory
+	Dir string `json:"dir" jsonschema:"Analyzed directory"`
+	// Mode - load mode being warmed
+	Mode string `json:"mode" jsonschema:"Load mode being warmed"`
+	// State - warming, warm or failed
+	State string `json:"state" jsonschema:"Hydration state: warming, warm or failed"`
+}
+
+// CacheStats describes the in-memory package cache and the persistent on-disk facts cache.
+type CacheStats struct {
+	// InMemoryLoads - number of package loads held in memory
+	InMemoryLoads int `json:"inMemoryLoads" jsonschema:"Number of package loads held in memory"`
+	// DiskEnabled - true if the on-disk cache is configured (--cache-dir)
+	DiskEnabled bool `json:"diskEnabled" jsonschema:"True if the on-disk cache is configured (--cache-dir)"`
+	// DiskDir - on-disk cache directory
+	DiskDir string `json:"diskDir,omitempty" jsonschema:"On-disk cache directory"`
+	// FactsInMemory - number of file fact sets kept in memory
+	FactsInMemory int `json:"factsInMemory" jsonschema:"Number of file fact sets kept in memory"`
+	// FactsHits - file facts served from memory or disk
+	FactsHits int `json:"factsHits" jsonschema:"File facts served from memory or disk"`
+	// FactsMisses - file facts computed by parsing
+	FactsMisses int `json:"factsMisses" jsonschema:"File facts computed by parsing"`
+	// FactsWrites - file facts written to disk
+	FactsWrites int `json:"factsWrites" jsonschema:"File facts written to disk"`
+	// IndexAnswers - tool calls answered from the persisted index
+	IndexAnswers int `json:"indexAnswers" jsonschema:"Tool calls answered from the persisted index"`
+	// Hydration - background warm-ups of the in-memory cache
+	Hydration []CacheHydration `json:"hydration,omitempty" jsonschema:"Background warm-ups of the in-memory cache"`
+	// MemoryHits - package loads answered from memory
+	MemoryHits int `json:"memoryHits" jsonschema:"Package loads answered from memory"`
+	// MemoryMisses - package loads that ran packages.Load
+	MemoryMisses int `json:"memoryMisses" jsonschema:"Package loads that ran packages.Load"`
+	// Loads - package loads held in memory
+	Loads []CacheLoad `json:"loads,omitempty" jsonschema:"Package loads held in memory"`
+	// BudgetBytes - memory budget of the package cache (--max-cache-mb)
+	BudgetBytes int64 `json:"budgetBytes,omitempty" jsonschema:"Memory budget of the package cache in bytes (--max-cache-mb), absent if unbounded"`
+	// EstimatedBytes - estimated memory held by the package loads in memory
+	EstimatedBytes int64 `json:"estimatedBytes" jsonschema:"Estimated memory held by the package loads in memory, in bytes"`
+	// Evictions - package loads dropped to fit the budget
+	Evictions int `json:"evictions" jsonschema:"Package loads dropped to fit the budget"`
+	// ForcedCleanups - times the heap outgrew the budget during a load, evicting every unpinned load
+	ForcedCleanups int `json:"forcedCleanups" jsonschema:"Times the heap outgrew the budget during a load, which evicts every unpinned load"`
+	// HeapAllocBytes - bytes of allocated heap objects
+	HeapAllocBytes uint64 `json:"heapAllocBytes" jsonschema:"Bytes of allocated heap objects (runtime.MemStats.HeapAlloc)"`
+	// CoalescedLoads - package load requests that waited for an identical load already running
+	CoalescedLoads int `json:"coalescedLoads" jsonschema:"Package load requests that waited for an identical load already running instead of starting their own"`
+	// NegativeHits - symbol lookups answered from remembered not-found results
+	NegativeHits int `json:"negativeHits" jsonschema:"Symbol lookups answered from remembered not-found results"`
+	// NegativeLookups - not-found symbol lookups currently remembered
+	NegativeLookups int `json:"negativeLookups" jsonschema:"Not-found symbol lookups currently remembered"`
+}
+
+// CacheLoad describes one package load held in the in-memory cache.
+type CacheLoad struct {
+	// Dir - analyzed directory
+	Dir string `json:"dir" jsonschema:"Analyzed directory"`
+	// Mode - load mode name, with '+tests' for test variants
+	Mode string `json:"mode" jsonschema:"Load mode name, with '+tests' for test variants"`
+	// Hits - requests answered from this load
+	Hits int `json:"hits" jsonschema:"Requests answered from this load"`
+	// Pinned - true if the load is exempt from age-based cleanup (warmup)
+	Pinned bool `json:"pinned,omitempty" jsonschema:"True if the load is exempt from age-based cleanup (warmup)"`
+	// EstimatedBytes - estimated memory held by the load
+	EstimatedBytes int64 `json:"estimatedBytes" jsonschema:"Estimated memory held by the load, in bytes"`
+}
+
+// PreloadStatus describes the startup preload requested with --preload-dir.
+type PreloadStatus struct {
+	// Dir - preloaded directory
+	Dir string `json:"dir" jsonschema:"Preloaded directory"`
+	// State - running, ready or failed
+	State string `json:"state" jsonschema:"Preload state: running, ready or failed"`
+	// Packages - number of packages loaded
+	Packages int `json:"packages,omitempty" jsonschema:"Number of packages loaded"`
+	// DurationMs - load duration in milliseconds
+	DurationMs int64 `json:"durationMs,omitempty" jsonschema:"Load duration in milliseconds"`
+	// Error - load error if the preload failed
+	Error string `json:"error,omitempty" jsonschema:"Load error if the preload failed"`
+}
+
+// GetServerStatusOutput contains results from the GetServerStatus tool.
+type GetServerStatusOutput struct {
+	// GoAvailable - true if the go command is found in PATH
+	GoAvailable bool `json:"goAvailable" jsonschema:"True if the go command is found in PATH"`
+	// CacheStats - cache statistics and hydration state
+	CacheStats CacheStats `json:"cacheStats" jsonschema:"Cache statistics and hydration state"`
+	// Preload - startup preload status (--preload-dir), absent if not requested
+	Preload *PreloadStatus `json:"preload,omitempty" jsonschema:"Startup preload status (--preload-dir), absent if not requested"`
+}
+
+// ------------------ find untested symbols ------------------
+
+// FindUntestedSymbolsInput contains input data for the FindUntestedSymbols tool.
+type FindUntestedSymbolsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// Kinds - symbol kinds to check: func (functions and methods), type, var, const
+	Kinds []string `json:"kinds,omitempty" jsonschema:"Symbol kinds to check: func (functions and methods, default), type, var, const"`
+	// IncludeMain - if true, main packages are checked as well
+	IncludeMain bool `json:"includeMain,omitempty" jsonschema:"If true, main packages are checked as well"`
+	// IncludeGenerated - if true, generated files are checked as well
+	IncludeGenerated bool `json:"includeGenerated,omitempty" jsonschema:"If true, generated files are checked as well"`
+}
+
+// UntestedSymbol describes an exported symbol that no test file references.
+type UntestedSymbol struct {
+	// Name - symbol name ('Type.Method' for methods)
+	Name string `json:"name" jsonschema:"Symbol name ('Type.Method' for methods)"`
+	// Kind - symbol kind: func, type, var or const
+	Kind string `json:"kind" jsonschema:"Symbol kind: func, type, var or const"`
+	// File - file where the symbol is declared
+	File string `json:"file" jsonschema:"File where the symbol is declared"`
+	// Line - line number of the declaration
+	Line int `json:"line" jsonschema:"Line number of the declaration"`
+}
+
+// UntestedPackageGroup groups untested symbols of a package with its tested ratio.
+type UntestedPackageGroup struct {
+	// Package - package path
+	Package string `json:"package" jsonschema:"Package path"`
+	// Tested - number of checked exported symbols referenced from tests
+	Tested int `json:"tested" jsonschema:"Number of checked exported symbols referenced from tests"`
+	// Total - number of checked exported symbols
+	Total int `json:"total" jsonschema:"Number of checked exported symbols"`
+	// Ratio - tested / total
+	Ratio float64 `json:"ratio" jsonschema:"Tested / total"`
+	// Symbols - exported symbols without any test reference
+	Symbols []UntestedSymbol `json:"symbols,omitempty" jsonschema:"Exported symbols without any test reference"`
+}
+
+// FindUntestedSymbolsOutput contains results from the FindUntestedSymbols tool.
+type FindUntestedSymbolsOutput struct {
+	// Total - total number of untested symbols
+	Total int `json:"total" jsonschema:"Total number of untested symbols"`
+	// Packages - untested symbols per package, least tested first
+	Packages []UntestedPackageGroup `json:"packages,omitempty" jsonschema:"Untested symbols per package, least tested first"`
+}
+
+// ------------------ fingerprints ------------------
+
+// GetFingerprintsInput contains input data for the GetFingerprints tool.
+type GetFingerprintsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// FileFingerprints contains fingerprints of a file and of its top-level declarations.
+type FileFingerprints struct {
+	// File - relative path to the file
+	File string `json:"file" jsonschema:"Relative path to the file"`
+	// Package - package path of the file
+	Package string `json:"package" jsonschema:"Package path of the file"`
+	// Fingerprint - fingerprint of the whole file
+	Fingerprint string `json:"fingerprint" jsonschema:"Fingerprint of the whole file"`
+	// Symbols - declaration name ('Type.Method' for methods) to fingerprint
+	Symbols map[string]string `json:"symbols,omitempty" jsonschema:"Declaration name ('Type.Method' for methods) to fingerprint"`
+}
+
+// GetFingerprintsOutput contains results from the GetFingerprints tool.
+type GetFingerprintsOutput struct {
+	// Files - fingerprints per file, sorted by path
+	Files []FileFingerprints `json:"files,omitempty" jsonschema:"Fingerprints per file, sorted by path"`
+}
+
+// ------------------ describe json shape ------------------
+
+// DescribeJSONShapeInput contains input data for the DescribeJSONShape tool.
+type DescribeJSONShapeInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// TypeName - struct type name (e.g., 'User' or 'models.User')
+	TypeName string `json:"typeName" jsonschema:"Struct type name (e.g., 'User' or 'models.User')"`
+	// Format - serialization format: json (default) or yaml
+	Format string `json:"format,omitempty" jsonschema:"Serialization format: json (default) or yaml"`
+}
+
+// ShapeField describes a field present in the serialized output.
+type ShapeField struct {
+	// Name - serialized key
+	Name string `json:"name" jsonschema:"Serialized key"`
+	// Type - Go type of the field
+	Type string `json:"type" jsonschema:"Go type of the field"`
+	// Path - source field path, including embedded structs (e.g., 'Audit.ID')
+	Path string `json:"path" jsonschema:"Source field path, including embedded structs (e.g., 'Audit.ID')"`
+	// OmitEmpty - true if the field has the omitempty option
+	OmitEmpty bool `json:"omitEmpty,omitempty" jsonschema:"True if the field has the omitempty option"`
+	// String - true if the value is encoded as a JSON string (',string' option)
+	String bool `json:"string,omitempty" jsonschema:"True if the value is encoded as a JSON string (',string' option)"`
+}
+
+// ShapeDroppedField describes a struct field that does not appear in the serialized output.
+type ShapeDroppedField struct {
+	// Name - serialized key the field would have had (empty for unexported or ignored fields)
+	Name string `json:"name,omitempty" jsonschema:"Serialized key the field would have had"`
+	// Path - source field path
+	Path string `json:"path" jsonschema:"Source field path"`
+	// Reason - unexported, ignored (tag '-'), ambiguous, shadowed or duplicate (yaml marshal error)
+	Reason string `json:"reason" jsonschema:"Why the field is dropped: unexported, ignored, ambiguous, shadowed or duplicate (yaml marshal error)"`
+}
+
+// DescribeJSONShapeOutput contains results from the DescribeJSONShape tool.
+type DescribeJSONShapeOutput struct {
+	// Type - fully qualified struct type
+	Type string `json:"type" jsonschema:"Fully qualified struct type"`
+	// Format - serialization format used
+	Format string `json:"format" jsonschema:"Serialization format used"`
+	// Fields - serialized fields in output order
+	Fields []ShapeField `json:"fields,omitempty" jsonschema:"Serialized fields in output order"`
+	// Dropped - fields that are silently skipped, with reasons
+	Dropped []ShapeDroppedField `json:"dropped,omitempty" jsonschema:"Fields that are silently skipped, with reasons"`
+}
+
+// ------------------ explain implements ------------------
+
+// ExplainImplementsInput represents the input for the ExplainImplements tool.
+type ExplainImplementsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// TypeName - concrete type to check (e.g., 'MemStore' or 'store.MemStore')
+	TypeName string `json:"typeName" jsonschema:"Concrete type to check (e.g., 'MemStore' or 'store.MemStore')"`
+	// InterfaceName - interface the type should implement (e.g., 'Storage' or 'io.Writer')
+	InterfaceName string `json:"interfaceName" jsonschema:"Interface the type should implement (e.g., 'Storage' or 'io.Writer')"`
+}
+
+// MethodSignature describes an interface method the type lacks.
+type MethodSignature struct {
+	// Name - method name
+	Name string `json:"name" jsonschema:"Method name"`
+	// Signature - full wanted signature (e.g., 'Load(key string) (string, error)')
+	Signature string `json:"signature" jsonschema:"Full wanted signature (e.g., 'Load(key string) (string, error)')"`
+}
+
+// MethodMismatch describes a method that exists with the right name but the wrong signature.
+type MethodMismatch struct {
+	// Name - method name
+	Name string `json:"name" jsonschema:"Method name"`
+	// Want - signature required by the interface
+	Want string `json:"want" jsonschema:"Signature required by the interface"`
+	// Have - signature of the type's method (or 'field Name T' when a field has that name)
+	Have string `json:"have" jsonschema:"Signature of the type's method (or 'field Name T' when a field has that name)"`
+}
+
+// ExplainImplementsOutput contains results from the ExplainImplements tool.
+type ExplainImplementsOutput struct {
+	// Type - fully qualified type
+	Type string `json:"type" jsonschema:"Fully qualified type"`
+	// Interface - fully qualified interface
+	Interface string `json:"interface" jsonschema:"Fully qualified interface"`
+	// Implements - true if the type or a pointer to it implements the interface
+	Implements bool `json:"implements" jsonschema:"True if the type or a pointer to it implements the interface"`
+	// Receiver - 'value' if T implements the interface, 'pointer' if only *T does
+	Receiver string `json:"receiver,omitempty" jsonschema:"'value' if T implements the interface, 'pointer' if only *T does"`
+	// Missing - interface methods the type does not have
+	Missing []MethodSignature `json:"missing,omitempty" jsonschema:"Interface methods the type does not have"`
+	// Mismatched - methods with the right name but the wrong signature
+	Mismatched []MethodMismatch `json:"mismatched,omitempty" jsonschema:"Methods with the right name but the wrong signature"`
+	// Stubs - ready-to-paste method skeletons for the missing methods
+	Stubs string `json:"stubs,omitempty" jsonschema:"Ready-to-paste method skeletons for the missing methods"`
+}
+
+// ------------------ warmup ------------------
+
+// WarmupInput represents the input for the Warmup tool.
+type WarmupInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Packages - optional packages that must be present after loading (import path or name)
+	Packages []string `json:"packages,omitempty" jsonschema:"Optional packages that must be present after loading (import path or name)"`
+	// IncludeTests - also load test variants used by reference and test-aware tools
+	IncludeTests bool `json:"includeTests,omitempty" jsonschema:"Also load test variants used by reference and test-aware tools"`
+}
+
+// WarmupOutput contains results from the Warmup tool.
+type WarmupOutput struct {
+	// Mode - load mode that was warmed
+	Mode string `json:"mode" jsonschema:"Load mode that was warmed"`
+	// Cached - true if everything was already in memory
+	Cached bool `json:"cached" jsonschema:"True if everything was already in memory"`
+	// Packages - number of packages loaded
+	Packages int `json:"packages" jsonschema:"Number of packages loaded"`
+	// TestPackages - number of packages loaded including test variants
+	TestPackages int `json:"testPackages,omitempty" jsonschema:"Number of packages loaded including test variants"`
+	// Files - number of compiled Go files
+	Files int `json:"files" jsonschema:"Number of compiled Go files"`
+	// DurationMs - time spent loading in milliseconds
+	DurationMs int64 `json:"durationMs" jsonschema:"Time spent loading in milliseconds"`
+}
+
+// ------------------ analyze allocations ------------------
+
+// AnalyzeAllocationsInput contains input data for the AnalyzeAllocations tool.
+type AnalyzeAllocationsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// AllocationFinding describes a syntactic allocation hotspot.
+type AllocationFinding struct {
+	// Pattern - return-local-pointer, append-without-prealloc, string-concat-in-loop, sprintf-key or bytes-string-conversion
+	Pattern string `json:"pattern" jsonschema:"Pattern name: return-local-pointer, append-without-prealloc, string-concat-in-loop, sprintf-key or bytes-string-conversion"`
+	// File - file containing the finding
+	File string `json:"file" jsonschema:"File containing the finding"`
+	// Line - line number
+	Line int `json:"line" jsonschema:"Line number"`
+	// Function - enclosing function ('Type.Method' for methods)
+	Function string `json:"function" jsonschema:"Enclosing function ('Type.Method' for methods)"`
+	// LoopDepth - number of enclosing loops
+	LoopDepth int `json:"loopDepth" jsonschema:"Number of enclosing loops"`
+	// Score - pattern weight × (loopDepth + 1)²; findings are ranked by it
+	Score int `json:"score" jsonschema:"Pattern weight × (loopDepth + 1)²; findings are ranked by it"`
+	// Hint - short remediation hint
+	Hint string `json:"hint" jsonschema:"Short remediation hint"`
+}
+
+// AnalyzeAllocationsOutput contains results from the AnalyzeAllocations tool.
+type AnalyzeAllocationsOutput struct {
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Findings - findings ranked by score
+	Findings []AllocationFinding `json:"findings,omitempty" jsonschema:"Findings ranked by score"`
+	// ByPattern - number of findings per pattern
+	ByPattern map[string]int `json:"byPattern" jsonschema:"Number of findings per pattern"`
+}
+
+// ------------------ check architecture ------------------
+
+// ArchitectureRule constrains what the packages matched by From may import inside the module.
+type ArchitectureRule struct {
+	// From - glob for the importing packages (module-relative dir or import path, '**' spans segments)
+	From string `json:"from" jsonschema:"Glob for the importing packages, matched against the module-relative directory or import path; '**' spans segments (e.g. internal/handlers/**)"`
+	// Allow - globs the packages may import; empty allows everything not denied
+	Allow []string `json:"allow,omitempty" jsonschema:"Globs the packages may import; empty allows everything not denied"`
+	// Deny - globs the packages must not import; deny wins over allow
+	Deny []string `json:"deny,omitempty" jsonschema:"Globs the packages must not import; deny wins over allow"`
+}
+
+// CheckArchitectureInput contains input data for the CheckArchitecture tool.
+type CheckArchitectureInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Rules - dependency rules; when omitted they are read from go-navigator.rules.json in the module root
+	Rules []ArchitectureRule `json:"rules,omitempty" jsonschema:"Dependency rules; when omitted they are read from go-navigator.rules.json in the module root"`
+}
+
+// ArchitectureViolation describes an import spec that breaks a rule.
+type ArchitectureViolation struct {
+	// Rule - index of the broken rule
+	Rule int `json:"rule" jsonschema:"Index of the broken rule"`
+	// From - importing package
+	From string `json:"from" jsonschema:"Importing package"`
+	// Imports - imported package
+	Imports string `json:"imports" jsonschema:"Imported package"`
+	// File - file containing the import spec
+	File string `json:"file" jsonschema:"File containing the import spec"`
+	// Line - line of the import spec
+	Line int `json:"line" jsonschema:"Line of the import spec"`
+	// Reason - which deny pattern matched, or that the import is not in the allow list
+	Reason string `json:"reason" jsonschema:"Which deny pattern matched, or that the import is not in the allow list"`
+}
+
+// ArchitectureRuleResult summarizes the evaluation of one rule.
+type ArchitectureRuleResult struct {
+	// From - the rule's From glob
+	From string `json:"from" jsonschema:"The rule's From glob"`
+	// Packages - number of packages matched by From
+	Packages int `json:"packages" jsonschema:"Number of packages matched by From"`
+	// Edges - number of internal package-to-package imports checked
+	Edges int `json:"edges" jsonschema:"Number of internal package-to-package imports checked"`
+	// Violations - number of import specs breaking the rule
+	Violations int `json:"violations" jsonschema:"Number of import specs breaking the rule"`
+}
+
+// CheckArchitectureOutput contains results from the CheckArchitecture tool.
+type CheckArchitectureOutput struct {
+	// Passed - true if no import breaks a rule
+	Passed bool `json:"passed" jsonschema:"True if no import breaks a rule"`
+	// RulesSource - 'input' or the path of the rules file
+	RulesSource string `json:"rulesSource" jsonschema:"'input' or the path of the rules file"`
+	// Violations - offending import specs ordered by file and line
+	Violations []ArchitectureViolation `json:"violations,omitempty" jsonschema:"Offending import specs ordered by file and line"`
+	// Rules - counts per rule, in input order
+	Rules []ArchitectureRuleResult `json:"rules" jsonschema:"Counts per rule, in input order"`
+}
+
+// ------------------ get type info ------------------
+
+// GetTypeInfoInput contains input data for the GetTypeInfo tool.
+type GetTypeInfoInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Name - name of the type (e.g., 'Celsius' or 'models.Celsius')
+	Name string `json:"name" jsonschema:"Name of the type (e.g., 'Celsius' or 'models.Celsius')"`
+}
+
+// TypeConstant describes a constant declared with the type.
+type TypeConstant struct {
+	// Name - constant name
+	Name string `json:"name" jsonschema:"Constant name"`
+	// Value - exact constant value
+	Value string `json:"value" jsonschema:"Exact constant value"`
+	// File - file where the constant is declared
+	File string `json:"file" jsonschema:"File where the constant is declared"`
+	// Line - line number of the constant
+	Line int `json:"line" jsonschema:"Line number of the constant"`
+}
+
+// TypeInfo describes a named type declaration.
+type TypeInfo struct {
+	// Name - type name
+	Name string `json:"name" jsonschema:"Type name"`
+	// Package - package where the type is defined
+	Package string `json:"package" jsonschema:"Package where the type is defined"`
+	// File - file where the type is defined
+	File string `json:"file" jsonschema:"File where the type is defined"`
+	// Line - line number of the type declaration
+	Line int `json:"line" jsonschema:"Line number where the type is declared"`
+	// Exported - true if the type is exported
+	Exported bool `json:"exported" jsonschema:"True if the type is exported"`
+	// Alias - true for alias declarations (type A = B)
+	Alias bool `json:"alias,omitempty" jsonschema:"True for alias declarations (type A = B)"`
+	// TypeParams - type parameters of a generic type
+	TypeParams []string `json:"typeParams,omitempty" jsonschema:"Type parameters of a generic type"`
+	// Doc - documentation comment of the type
+	Doc string `json:"doc,omitempty" jsonschema:"Documentation comment of the type"`
+	// Kind - underlying kind: struct, map, slice, array, basic, func, chan, interface, pointer (named without type information)
+	Kind string `json:"kind" jsonschema:"Underlying kind: struct, map, slice, array, basic, func, chan, interface or pointer ('named' when only syntax is available)"`
+	// Underlying - underlying type (the declared type expression without type information)
+	Underlying string `json:"underlying" jsonschema:"Underlying type (the declared type expression when only syntax is available)"`
+	// Fields - fields of a struct type
+	Fields []StructField `json:"fields,omitempty" jsonschema:"Fields of a struct type"`
+	// ValueMethods - methods with a value receiver, as 'Name(params) results'
+	ValueMethods []string `json:"valueMethods,omitempty" jsonschema:"Methods with a value receiver, as 'Name(params) results'"`
+	// PointerMethods - methods with a pointer receiver, as 'Name(params) results'
+	PointerMethods []string `json:"pointerMethods,omitempty" jsonschema:"Methods with a pointer receiver, as 'Name(params) results'"`
+	// Constants - package-level constants of a type defined on a basic type
+	Constants []TypeConstant `json:"constants,omitempty" jsonschema:"Package-level constants of a type defined on a basic type"`
+}
+
+// GetTypeInfoOutput contains results from the GetTypeInfo tool.
+type GetTypeInfoOutput struct {
+	// Type - description of the found type
+	Type TypeInfo `json:"type" jsonschema:"Description of the found type"`
+	// Degraded - set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load
+	Degraded string `json:"degraded,omitempty" jsonschema:"Set to 'syntax-only' when module resolution failed and results come from an offline syntax-only load"`
+	// LoadError - original load error that caused the degradation
+	LoadError string `json:"loadError,omitempty" jsonschema:"Original load error that caused the degradation"`
+}
+
+// ------------------ find swallowed errors ------------------
+
+// FindSwallowedErrorsInput contains input data for the FindSwallowedErrors tool.
+type FindSwallowedErrorsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// IncludeTests - also scan _test.go files
+	IncludeTests bool `json:"includeTests,omitempty" jsonschema:"Also scan _test.go files"`
+	// ExcludeCallees - callees known to be safe to ignore, e.g. 'fmt.Fprintf' or '(*strings.Builder).WriteString'
+	ExcludeCallees []string `json:"excludeCallees,omitempty" jsonschema:"Callees known to be safe to ignore, e.g. 'fmt.Fprintf' or '(*strings.Builder).WriteString'"`
+}
+
+// SwallowedError describes a call site whose error result is dropped.
+type SwallowedError struct {
+	// File - file containing the call
+	File string `json:"file" jsonschema:"File containing the call"`
+	// Line - line number of the call
+	Line int `json:"line" jsonschema:"Line number of the call"`
+	// Function - enclosing function ('Type.Method' for methods)
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function ('Type.Method' for methods)"`
+	// Callee - called function, e.g. 'os.Remove' or '(*os.File).Close'
+	Callee string `json:"callee" jsonschema:"Called function, e.g. 'os.Remove' or '(*os.File).Close'"`
+	// Form - 'unchecked' (result ignored) or 'blank' (assigned to _)
+	Form string `json:"form" jsonschema:"'unchecked' (result ignored) or 'blank' (assigned to _)"`
+	// Context - 'defer' or 'goroutine' when the call runs deferred or in a go statement
+	Context string `json:"context,omitempty" jsonschema:"'defer' or 'goroutine' when the call runs deferred or in a go statement"`
+	// InTest - true if the call is in a _test.go file
+	InTest bool `json:"inTest,omitempty" jsonschema:"True if the call is in a _test.go file"`
+}
+
+// SwallowedErrorPackage counts swallowed errors of a package.
+type SwallowedErrorPackage struct {
+	// Package - package path
+	Package string `json:"package" jsonschema:"Package path"`
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Defer - findings in deferred calls (outside tests)
+	Defer int `json:"defer,omitempty" jsonschema:"Findings in deferred calls (outside tests)"`
+	// Goroutine - findings in go statements (outside tests)
+	Goroutine int `json:"goroutine,omitempty" jsonschema:"Findings in go statements (outside tests)"`
+	// Test - findings in _test.go files
+	Test int `json:"test,omitempty" jsonschema:"Findings in _test.go files"`
+}
+
+// FindSwallowedErrorsOutput contains results from the FindSwallowedErrors tool.
+type FindSwallowedErrorsOutput struct {
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Excluded - number of call sites skipped by excludeCallees
+	Excluded int `json:"excluded,omitempty" jsonschema:"Number of call sites skipped by excludeCallees"`
+	// Findings - findings ordered by file and line
+	Findings []SwallowedError `json:"findings,omitempty" jsonschema:"Findings ordered by file and line"`
+	// Packages - counts per package
+	Packages []SwallowedErrorPackage `json:"packages,omitempty" jsonschema:"Counts per package"`
+}
+
+// ------------------ audit log ------------------
+
+// GetAuditLogInput contains input data for the GetAuditLog tool.
+type GetAuditLogInput struct {
+	// Limit - maximum number of most recent entries to return (0 for all)
+	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of most recent entries to return (0 for all)"`
+	// SinceTimestamp - optional RFC 3339 timestamp; older entries are skipped
+	SinceTimestamp string `json:"sinceTimestamp,omitempty" jsonschema:"Optional RFC 3339 timestamp; older entries are skipped"`
+}
+
+// AuditEntry records the mutation of one file by one tool call.
+type AuditEntry struct {
+	// Timestamp - time of the mutation (RFC 3339, UTC)
+	Timestamp string `json:"timestamp" jsonschema:"Time of the mutation (RFC 3339, UTC)"`
+	// Tool - name of the tool that changed the file
+	Tool string `json:"tool" jsonschema:"Name of the tool that changed the file"`
+	// Input - tool input as compact JSON
+	Input string `json:"input" jsonschema:"Tool input as compact JSON"`
+	// File - absolute path of the changed file
+	File string `json:"file" jsonschema:"Absolute path of the changed file"`
+	// Before - SHA-256 of the file content before the change
+	Before string `json:"beforeSha256" jsonschema:"SHA-256 of the file content before the change"`
+	// After - SHA-256 of the file content after the change
+	After string `json:"afterSha256" jsonschema:"SHA-256 of the file content after the change"`
+	// Hunks - number of changed hunks
+	Hunks int `json:"hunks" jsonschema:"Number of changed hunks"`
+}
+
+// GetAuditLogOutput contains results from the GetAuditLog tool.
+type GetAuditLogOutput struct {
+	// Enabled - true if the server was started with --audit-log
+	Enabled bool `json:"enabled" jsonschema:"True if the server was started with --audit-log"`
+	// Total - number of entries matching sinceTimestamp before the limit was applied
+	Total int `json:"total" jsonschema:"Number of entries matching sinceTimestamp before the limit was applied"`
+	// Entries - most recent entries, oldest first
+	Entries []AuditEntry `json:"entries" jsonschema:"Most recent entries, oldest first"`
+}
+
+// ------------------ analyze external usage ------------------
+
+// AnalyzeExternalUsageInput contains input data for the AnalyzeExternalUsage tool.
+type AnalyzeExternalUsageInput struct {
+	// LibraryDir - root directory of the library module
+	LibraryDir string `json:"libraryDir" jsonschema:"Root directory of the library module"`
+	// ConsumerDirs - root directories of the modules that import the library
+	ConsumerDirs []string `json:"consumerDirs" jsonschema:"Root directories of the modules that import the library"`
+}
+
+// ExternalSymbolUsage describes how consumers use an exported library symbol.
+type ExternalSymbolUsage struct {
+	// Package - library package path
+	Package string `json:"package" jsonschema:"Library package path"`
+	// Name - symbol name, 'Type.Method' for methods
+	Name string `json:"name" jsonschema:"Symbol name, 'Type.Method' for methods"`
+	// Kind - symbol kind: func, var, const, type or method
+	Kind string `json:"kind" jsonschema:"Symbol kind: func, var, const, type or method"`
+	// File - file of the declaration, relative to the library directory
+	File string `json:"file" jsonschema:"File of the declaration, relative to the library directory"`
+	// Line - line of the declaration
+	Line int `json:"line" jsonschema:"Line of the declaration"`
+	// Consumers - number of consumers that reference the symbol
+	Consumers int `json:"consumers" jsonschema:"Number of consumers that reference the symbol"`
+	// References - number of references across all consumers
+	References int `json:"references" jsonschema:"Number of references across all consumers"`
+	// Status - 'used', 'unused', or 'interface' for methods only reachable through a consumer interface
+	Status string `json:"status" jsonschema:"'used', 'unused', or 'interface' for methods only reachable through a consumer interface"`
+	// Interfaces - consumer interfaces through which an unreferenced method may be called
+	Interfaces []string `json:"interfaces,omitempty" jsonschema:"Consumer interfaces through which an unreferenced method may be called"`
+}
+
+// ExternalConsumer summarizes the library usage of one consumer module.
+type ExternalConsumer struct {
+	// Dir - consumer directory as given in the input
+	Dir string `json:"dir" jsonschema:"Consumer directory as given in the input"`
+	// Packages - consumer packages that reference the library
+	Packages int `json:"packages" jsonschema:"Consumer packages that reference the library"`
+	// References - references to exported library symbols
+	References int `json:"references" jsonschema:"References to exported library symbols"`
+}
+
+// AnalyzeExternalUsageOutput contains results from the AnalyzeExternalUsage tool.
+type AnalyzeExternalUsageOutput struct {
+	// Total - number of exported library symbols
+	Total int `json:"total" jsonschema:"Number of exported library symbols"`
+	// Unused - symbols without references and without an interface through which they may be called
+	Unused int `json:"unused" jsonschema:"Symbols without references and without an interface through which they may be called"`
+	// ViaInterface - unreferenced methods that satisfy an interface used by a consumer
+	ViaInterface int `json:"viaInterface" jsonschema:"Unreferenced methods that satisfy an interface used by a consumer"`
+	// Symbols - exported library symbols ordered by package and name
+	Symbols []ExternalSymbolUsage `json:"symbols,omitempty" jsonschema:"Exported library symbols ordered by package and name"`
+	// Consumers - usage summary per consumer
+	Consumers []ExternalConsumer `json:"consumers,omitempty" jsonschema:"Usage summary per consumer"`
+}
+
+// ------------------ check language level ------------------
+
+// CheckLanguageLevelInput contains input data for the CheckLanguageLevel tool.
+type CheckLanguageLevelInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+}
+
+// LanguageFeatureUse is a use of a construct that needs a newer Go version than its file may use.
+type LanguageFeatureUse struct {
+	// Construct - language construct, builtin or standard library package
+	Construct string `json:"construct" jsonschema:"Language construct, builtin or standard library package"`
+	// Version - Go version that introduced the construct
+	Version string `json:"version" jsonschema:"Go version that introduced the construct"`
+	// File - relative file path
+	File string `json:"file" jsonschema:"Relative file path"`
+	// Line - line of the use
+	Line int `json:"line" jsonschema:"Line of the use"`
+}
+
+// LanguageFeatureCount counts the uses of a construct.
+type LanguageFeatureCount struct {
+	// Construct - language construct, builtin or standard library package
+	Construct string `json:"construct" jsonschema:"Language construct, builtin or standard library package"`
+	// Version - Go version that introduced the construct
+	Version string `json:"version" jsonschema:"Go version that introduced the construct"`
+	// Count - number of uses
+	Count int `json:"count" jsonschema:"Number of uses"`
+}
+
+// CheckLanguageLevelOutput contains results from the CheckLanguageLevel tool.
+type CheckLanguageLevelOutput struct {
+	// Declared - version of the go directive in go.mod
+	Declared string `json:"declared" jsonschema:"Version of the go directive in go.mod"`
+	// Required - newest version required by a detected construct (1.17 if none is used)
+	Required string `json:"required" jsonschema:"Newest version required by a detected construct (1.17 if none is used)"`
+	// Passed - true if no use needs a newer version than its file may use
+	Passed bool `json:"passed" jsonschema:"True if no use needs a newer version than its file may use"`
+	// DirectiveAhead - true if the go directive is more than four minor releases newer than required
+	DirectiveAhead bool `json:"directiveAhead" jsonschema:"True if the go directive is more than four minor releases newer than required"`
+	// Violations - uses above the declared version (or the file's //go:build version), ordered by file and line
+	Violations []LanguageFeatureUse `json:"violations,omitempty" jsonschema:"Uses above the declared version (or the file's //go:build version), ordered by file and line"`
+	// Features - detected constructs with use counts, ordered by version
+	Features []LanguageFeatureCount `json:"features,omitempty" jsonschema:"Detected constructs with use counts, ordered by version"`
+}
+
+// ------------------ export index ------------------
+
+// ExportIndexInput contains input data for the ExportIndex tool.
+type ExportIndexInput struct {
+	// Dir - directory inside the Go module to index
+	Dir string `json:"dir" jsonschema:"Directory inside the Go module to index"`
+	// OutFile - artifact path, relative to dir unless absolute; a .gz suffix writes gzip-compressed JSON
+	OutFile string `json:"outFile" jsonschema:"Artifact path, relative to dir unless absolute; a .gz suffix writes gzip-compressed JSON"`
+	// Include - sections to export: symbols, references, dependencies, interfaces, complexity (default all)
+	Include []string `json:"include,omitempty" jsonschema:"Sections to export: symbols, references, dependencies, interfaces, complexity (default all)"`
+}
+
+// ExportIndexOutput contains results from the ExportIndex tool.
+type ExportIndexOutput struct {
+	// File - absolute path of the written artifact
+	File string `json:"file" jsonschema:"Absolute path of the written artifact"`
+	// Version - artifact format version
+	Version int `json:"version" jsonschema:"Artifact format version"`
+	// Module - module path of the indexed module
+	Module string `json:"module" jsonschema:"Module path of the indexed module"`
+	// Sections - sections written to the artifact
+	Sections []string `json:"sections" jsonschema:"Sections written to the artifact"`
+	// Files - number of source files with content hashes
+	Files int `json:"files" jsonschema:"Number of source files with content hashes"`
+	// References - number of identifiers with stored references
+	References int `json:"references,omitempty" jsonschema:"Number of identifiers with stored references"`
+	// Interfaces - number of interfaces with stored implementations
+	Interfaces int `json:"interfaces,omitempty" jsonschema:"Number of interfaces with stored implementations"`
+	// Bytes - size of the written artifact
+	Bytes int `json:"bytes" jsonschema:"Size of the written artifact in bytes"`
+}
+
+// ------------------ import index ------------------
+
+// ImportIndexInput contains input data for the ImportIndex tool.
+type ImportIndexInput struct {
+	// File - path of an artifact written by exportIndex
+	File string `json:"file" jsonschema:"Path of an artifact written by exportIndex"`
+	// Dir - optional directory of the module the artifact applies to (default: the root recorded in it)
+	Dir string `json:"dir,omitempty" jsonschema:"Optional directory of the module the artifact applies to (default: the root recorded in it)"`
+}
+
+// ImportIndexOutput contains results from the ImportIndex tool.
+type ImportIndexOutput struct {
+	// Root - module root the artifact was registered for
+	Root string `json:"root" jsonschema:"Module root the artifact was registered for"`
+	// Module - module path recorded in the artifact
+	Module string `json:"module" jsonschema:"Module path recorded in the artifact"`
+	// Version - artifact format version
+	Version int `json:"version" jsonschema:"Artifact format version"`
+	// Created - export time of the artifact (RFC 3339)
+	Created string `json:"created" jsonschema:"Export time of the artifact (RFC 3339)"`
+	// Sections - sections contained in the artifact
+	Sections []string `json:"sections" jsonschema:"Sections contained in the artifact"`
+	// Files - number of source files recorded in the artifact
+	Files int `json:"files" jsonschema:"Number of source files recorded in the artifact"`
+	// Matching - recorded files whose content hash still matches
+	Matching int `json:"matching" jsonschema:"Recorded files whose content hash still matches"`
+	// Stale - recorded files that changed or were removed; they are analyzed live
+	Stale []string `json:"stale,omitempty" jsonschema:"Recorded files that changed or were removed; they are analyzed live"`
+	// Untracked - source files of the module missing from the artifact
+	Untracked int `json:"untracked,omitempty" jsonschema:"Source files of the module missing from the artifact"`
+	// Current - every source file matches, so stored references and implementations are used too
+	Current bool `json:"current" jsonschema:"Every source file matches, so stored references and implementations are used too"`
+}
+
+// ------------------ find magic values ------------------
+
+// FindMagicValuesInput contains input data for the FindMagicValues tool.
+type FindMagicValuesInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// MinOccurrences - minimum number of occurrences of a value within a package (default 3)
+	MinOccurrences int `json:"minOccurrences,omitempty" jsonschema:"Minimum number of occurrences of a value within a package (default 3)"`
+	// MinStringLength - minimum length in characters of reported strings (default 4)
+	MinStringLength int `json:"minStringLength,omitempty" jsonschema:"Minimum length in characters of reported strings (default 4)"`
+	// IgnoreTests - skip _test.go files
+	IgnoreTests bool `json:"ignoreTests,omitempty" jsonschema:"Skip _test.go files"`
+	// IgnoreValues - values never reported, as Go literals (default 0, 1, -1 and \"\")
+	IgnoreValues []string `json:"ignoreValues,omitempty" jsonschema:"Values never reported, as Go literals such as 0x10 or \"GET\" (default 0, 1, -1 and the empty string)"`
+}
+
+// MagicValueOccurrence is one use of a repeated literal value.
+type MagicValueOccurrence struct {
+	// File - file containing the literal
+	File string `json:"file" jsonschema:"File containing the literal"`
+	// Line - line number of the literal
+	Line int `json:"line" jsonschema:"Line number of the literal"`
+	// Function - enclosing function ('Type.Method' for methods), empty at package level
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function ('Type.Method' for methods), empty at package level"`
+}
+
+// MagicValue is a literal value repeated within a package.
+type MagicValue struct {
+	// Package - package path
+	Package string `json:"package" jsonschema:"Package path"`
+	// Value - the value as a Go literal (strings quoted, numbers in canonical form)
+	Value string `json:"value" jsonschema:"The value as a Go literal (strings quoted, numbers in canonical form)"`
+	// Kind - 'string' or 'number'
+	Kind string `json:"kind" jsonschema:"'string' or 'number'"`
+	// Count - number of occurrences
+	Count int `json:"count" jsonschema:"Number of occurrences"`
+	// ExistingConstant - package-level constant that already has this value
+	ExistingConstant string `json:"existingConstant,omitempty" jsonschema:"Package-level constant that already has this value"`
+	// Suggestion - 'extract a constant' or 'reuse constant <name>'
+	Suggestion string `json:"suggestion" jsonschema:"'extract a constant' or 'reuse constant <name>'"`
+	// Occurrences - uses ordered by file and line
+	Occurrences []MagicValueOccurrence `json:"occurrences" jsonschema:"Uses ordered by file and line"`
+}
+
+// FindMagicValuesOutput contains results from the FindMagicValues tool.
+type FindMagicValuesOutput struct {
+	// Total - number of repeated values
+	Total int `json:"total" jsonschema:"Number of repeated values"`
+	// Values - repeated values, most frequent first
+	Values []MagicValue `json:"values,omitempty" jsonschema:"Repeated values, most frequent first"`
+}
+
+// ------------------ resolve position ------------------
+
+// SourcePosition is a file:line pair, e.g. a stack trace frame.
+type SourcePosition struct {
+	// File - file path: absolute, relative to dir, or any path ending in a module file
+	File string `json:"file" jsonschema:"File path: absolute, relative to dir, or any path ending in a module file (as in stack traces from another machine)"`
+	// Line - 1-based line number
+	Line int `json:"line" jsonschema:"1-based line number"`
+}
+
+// ResolvePositionInput contains input data for the ResolvePosition tool.
+type ResolvePositionInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Positions - positions to resolve
+	Positions []SourcePosition `json:"positions" jsonschema:"Positions to resolve, e.g. all frames of a stack trace"`
+}
+
+// EnclosingFunction is the top-level function or method containing a position.
+type EnclosingFunction struct {
+	// Name - function name ('Type.Method' for methods)
+	Name string `json:"name" jsonschema:"Function name ('Type.Method' for methods)"`
+	// Receiver - receiver type name of a method
+	Receiver string `json:"receiver,omitempty" jsonschema:"Receiver type name of a method"`
+	// StartLine - first line of the declaration
+	StartLine int `json:"startLine" jsonschema:"First line of the declaration"`
+	// EndLine - last line of the declaration
+	EndLine int `json:"endLine" jsonschema:"Last line of the declaration"`
+}
+
+// EnclosingType is the type declaration containing a position.
+type EnclosingType struct {
+	// Name - type name
+	Name string `json:"name" jsonschema:"Type name"`
+	// StartLine - first line of the declaration
+	StartLine int `json:"startLine" jsonschema:"First line of the declaration"`
+	// EndLine - last line of the declaration
+	EndLine int `json:"endLine" jsonschema:"Last line of the declaration"`
+}
+
+// ResolvedPosition is the result for one input position.
+type ResolvedPosition struct {
+	// File - file as given in the input
+	File string `json:"file" jsonschema:"File as given in the input"`
+	// Line - line as given in the input
+	Line int `json:"line" jsonschema:"Line as given in the input"`
+	// ResolvedFile - matched file relative to dir
+	ResolvedFile string `json:"resolvedFile,omitempty" jsonschema:"Matched file relative to dir"`
+	// Package - package path of the file
+	Package string `json:"package,omitempty" jsonschema:"Package path of the file"`
+	// Function - enclosing top-level function or method
+	Function *EnclosingFunction `json:"function,omitempty" jsonschema:"Enclosing top-level function or method"`
+	// Type - enclosing type declaration
+	Type *EnclosingType `json:"type,omitempty" jsonschema:"Enclosing type declaration"`
+	// InComment - the line is inside a comment
+	InComment bool `json:"inComment,omitempty" jsonschema:"The line is inside a comment"`
+	// Blank - the line is empty
+	Blank bool `json:"blank,omitempty" jsonschema:"The line is empty"`
+	// Error - why the position could not be resolved
+	Error string `json:"error,omitempty" jsonschema:"Why the position could not be resolved (unknown file, line out of range)"`
+}
+
+// ResolvePositionOutput contains results from the ResolvePosition tool.
+type ResolvePositionOutput struct {
+	// Resolved - number of positions resolved to a file line
+	Resolved int `json:"resolved" jsonschema:"Number of positions resolved to a file line"`
+	// Positions - one result per input position, in input order
+	Positions []ResolvedPosition `json:"positions" jsonschema:"One result per input position, in input order"`
+}
+
+// ------------------ list generators ------------------
+
+// ListGeneratorsInput contains input data for the ListGenerators tool.
+type ListGeneratorsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// GenerateDirective is one go:generate directive.
+type GenerateDirective struct {
+	// File - file containing the directive
+	File string `json:"file" jsonschema:"File containing the directive"`
+	// Line - line of the directive
+	Line int `json:"line" jsonschema:"Line of the directive"`
+	// Command - program run by the directive, after -command alias substitution
+	Command string `json:"command,omitempty" jsonschema:"Program run by the directive, after -command alias substitution"`
+	// Args - arguments of the program as go generate splits them (quotes removed)
+	Args []string `json:"args,omitempty" jsonschema:"Arguments of the program as go generate splits them (quotes removed)"`
+	// Tool - generator name: the program, the package of 'go run pkg' or the tool of 'go tool name'
+	Tool string `json:"tool,omitempty" jsonschema:"Generator name: the program, the package of 'go run pkg' or the tool of 'go tool name'"`
+	// Alias - name defined by a '-command' directive
+	Alias string `json:"alias,omitempty" jsonschema:"Name defined by a '-command' directive"`
+	// Resolvable - the program was found in the package directory, PATH, GOBIN or GOPATH/bin
+	Resolvable bool `json:"resolvable" jsonschema:"The program was found in the package directory, PATH, GOBIN or GOPATH/bin (nothing is executed)"`
+	// BinaryPath - where the program was found
+	BinaryPath string `json:"binaryPath,omitempty" jsonschema:"Where the program was found"`
+	// Output - generated file named by the arguments (-o, -output, -destination, stringer's default)
+	Output string `json:"output,omitempty" jsonschema:"Generated file named by the arguments (-o, -output, -destination, stringer's default)"`
+	// OutputStatus - 'fresh', 'stale' (older than the directive's file) or 'missing'
+	OutputStatus string `json:"outputStatus,omitempty" jsonschema:"'fresh', 'stale' (older than the directive's file) or 'missing'"`
+	// Error - why the directive could not be parsed
+	Error string `json:"error,omitempty" jsonschema:"Why the directive could not be parsed"`
+}
+
+// GeneratorPackage lists the directives of one package.
+type GeneratorPackage struct {
+	// Package - package path
+	Package string `json:"package" jsonschema:"Package path"`
+	// Directives - directives ordered by file and line
+	Directives []GenerateDirective `json:"directives" jsonschema:"Directives ordered by file and line"`
+}
+
+// GeneratorToolCount counts the uses of one generator across the module.
+type GeneratorToolCount struct {
+	// Tool - generator name
+	Tool string `json:"tool" jsonschema:"Generator name"`
+	// Directives - number of directives running it
+	Directives int `json:"directives" jsonschema:"Number of directives running it"`
+	// Packages - number of packages using it
+	Packages int `json:"packages" jsonschema:"Number of packages using it"`
+}
+
+// ListGeneratorsOutput contains results from the ListGenerators tool.
+type ListGeneratorsOutput struct {
+	// Total - number of directives
+	Total int `json:"total" jsonschema:"Number of directives"`
+	// Unresolvable - directives whose program was not found
+	Unresolvable int `json:"unresolvable,omitempty" jsonschema:"Directives whose program was not found"`
+	// Tools - generators by number of directives
+	Tools []GeneratorToolCount `json:"tools,omitempty" jsonschema:"Generators by number of directives"`
+	// Packages - directives grouped by package
+	Packages []GeneratorPackage `json:"packages,omitempty" jsonschema:"Directives grouped by package"`
+}
+
+// ------------------ suggestFileSplit / applyFileSplit ------------------
+
+// SuggestFileSplitInput represents input parameters for the SuggestFileSplit tool.
+type SuggestFileSplitInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - relative path to the Go source file to split
+	File string `json:"file" jsonschema:"Relative path to the Go source file to split"`
+	// TargetMaxLines - maximum size of the resulting files
+	TargetMaxLines int `json:"targetMaxLines,omitempty" jsonschema:"Maximum number of lines of the resulting files (default 800)"`
+}
+
+// FileSplitPart is one file of a proposed or applied split.
+type FileSplitPart struct {
+	// File - file name in the directory of the original file
+	File string `json:"file" jsonschema:"File name in the directory of the original file"`
+	// Original - the part stays in the original file
+	Original bool `json:"original,omitempty" jsonschema:"The part stays in the original file"`
+	// Lines - approximate number of lines of the file after the split
+	Lines int `json:"lines" jsonschema:"Approximate number of lines of the file after the split"`
+	// Declarations - top-level declarations of the file, named as in reorderDeclarations ('type T', 'T.Method', 'F'); repeated names get a '#N' suffix
+	Declarations []string `json:"declarations" jsonschema:"Top-level declarations of the file, named as in reorderDeclarations ('type T', 'T.Method', 'F'); repeated names get a '#N' suffix"`
+	// Imports - import specs the file needs
+	Imports []string `json:"imports,omitempty" jsonschema:"Import specs the file needs"`
+	// Reason - what holds the declarations together
+	Reason string `json:"reason,omitempty" jsonschema:"What holds the declarations together"`
+}
+
+// SuggestFileSplitOutput contains results from the SuggestFileSplit tool.
+type SuggestFileSplitOutput struct {
+	// File - file that was analyzed
+	File string `json:"file" jsonschema:"File that was analyzed"`
+	// Lines - current number of lines
+	Lines int `json:"lines" jsonschema:"Current number of lines"`
+	// TargetMaxLines - target size used
+	TargetMaxLines int `json:"targetMaxLines" jsonschema:"Target size used"`
+	// SplitNeeded - the file is larger than the target
+	SplitNeeded bool `json:"splitNeeded" jsonschema:"The file is larger than the target"`
+	// Parts - proposed files, the one keeping the original name first
+	Parts []FileSplitPart `json:"parts" jsonschema:"Proposed files, the one keeping the original name first"`
+}
+
+// FileSplitPlan assigns declarations to one file of a split.
+type FileSplitPlan struct {
+	// File - new file name in the directory of the original file, or the original name to keep declarations there
+	File string `json:"file" jsonschema:"New file name in the directory of the original file, or the original name to keep declarations there"`
+	// Declarations - declaration names as reported by suggestFileSplit
+	Declarations []string `json:"declarations" jsonschema:"Declaration names as reported by suggestFileSplit"`
+}
+
+// ApplyFileSplitInput represents input parameters for the ApplyFileSplit tool.
+type ApplyFileSplitInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - relative path to the Go source file to split
+	File string `json:"file" jsonschema:"Relative path to the Go source file to split"`
+	// TargetMaxLines - maximum size of the resulting files when no parts are given
+	TargetMaxLines int `json:"targetMaxLines,omitempty" jsonschema:"Maximum number of lines of the resulting files when no parts are given (default 800)"`
+	// Parts - explicit split plan; declarations not listed stay in the original file
+	Parts []FileSplitPlan `json:"parts,omitempty" jsonschema:"Explicit split plan; declarations not listed stay in the original file. Defaults to the suggestFileSplit proposal"`
+	// DryRun - if true, returns only a preview of changes without writing files
+	DryRun bool `json:"dryRun,omitempty" jsonschema:"If true, only return a diff preview without writing files"`
+	// DiffMode - rendering of diffs: 'unified' (default), 'minimal' or 'summary'
+	DiffMode string `json:"diffMode,omitempty" jsonschema:"Rendering of diffs: 'unified' (default, 3 context lines), 'minimal' (changed lines only, prefixed with file:line) or 'summary' (per-file insertion/deletion counts and changed line numbers)"`
+	// VerifyBuild - if true, type-check the module after writing, as the verifyBuild tool does
+	VerifyBuild bool `json:"verifyBuild,omitempty" jsonschema:"If true, reload and type-check the module after writing the files, as the verifyBuild tool does"`
+	// ExpectedHash - content hash of the file from a prior read; a mismatch refuses the change
+	ExpectedHash string `json:"expectedHash,omitempty" jsonschema:"contentHash of the file returned by a prior read (getFunctionSource, getFileInfo, getStructInfo, navigateFile); if the file changed since, the call fails with CONFLICT and writes nothing"`
+}
+
+// ApplyFileSplitOutput contains results from the ApplyFileSplit tool.
+type ApplyFileSplitOutput struct {
+	// File - file that was split
+	File string `json:"file" jsonschema:"File that was split"`
+	// Changed - true if declarations were moved
+	Changed bool `json:"changed" jsonschema:"True if declarations were moved"`
+	// Files - resulting files, the original first
+	Files []FileSplitPart `json:"files" jsonschema:"Resulting files, the original first"`
+	// Diff - diff of the original and the new files
+	Diff string `json:"diff,omitempty" jsonschema:"Diff of the original and the new files"`
+	// Build - result of the type check requested with verifyBuild
+	Build *VerifyBuildOutput `json:"build,omitempty" jsonschema:"Result of the type check requested with verifyBuild"`
+}
+
+// ------------------ verify build ------------------
+
+// VerifyBuildInput contains input data for the VerifyBuild tool.
+type VerifyBuildInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Packages - optional package patterns to check instead of the whole module
+	Packages []string `json:"packages,omitempty" jsonschema:"Optional package paths or patterns to check (e.g. ./internal/...); the whole module by default"`
+}
+
+// BuildError is one error found while loading, parsing or type-checking a package.
+type BuildError struct {
+	// Package - import path of the package
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// Kind - list, parse, type or unknown
+	Kind string `json:"kind" jsonschema:"Kind of the error: list, parse, type or unknown"`
+	// File - relative path of the file, empty when the error has no position
+	File string `json:"file,omitempty" jsonschema:"Relative path of the file, empty when the error has no position"`
+	// Line - line of the error
+	Line int `json:"line,omitempty" jsonschema:"Line of the error"`
+	// Column - column of the error
+	Column int `json:"column,omitempty" jsonschema:"Column of the error"`
+	// Message - error message
+	Message string `json:"message" jsonschema:"Error message"`
+}
+
+// VerifyBuildOutput contains results from the VerifyBuild tool.
+type VerifyBuildOutput struct {
+	// OK - true if every package loaded, parsed and type-checked without errors
+	OK bool `json:"ok" jsonschema:"True if every package loaded, parsed and type-checked without errors"`
+	// Packages - number of packages checked
+	Packages int `json:"packages" jsonschema:"Number of packages checked, test files included"`
+	// Errors - errors sorted by file and position
+	Errors []BuildError `json:"errors,omitempty" jsonschema:"Errors sorted by file and position"`
+	// ElapsedMs - duration of the check in milliseconds
+	ElapsedMs int64 `json:"elapsedMs" jsonschema:"Duration of the check in milliseconds"`
+	// LoadError - set when a check run by a mutating tool could not load the module
+	LoadError string `json:"loadError,omitempty" jsonschema:"Set when a check requested from a mutating tool could not load the module; the files stay written"`
+}
+
+// ------------------ navigate file ------------------
+
+// NavigateFileInput contains input data for the NavigateFile tool.
+type NavigateFileInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - path of the Go file, relative to dir or absolute
+	File string `json:"file" jsonschema:"Path of the Go file, relative to dir or absolute"`
+	// Line - reference line for the at, next and prev directions
+	Line int `json:"line,omitempty" jsonschema:"Reference line (1-based); required for the at, next and prev directions"`
+	// Direction - at, next, prev, first or last
+	Direction string `json:"direction,omitempty" jsonschema:"at (default): declaration containing line; next/prev: declaration after/before it; first/last"`
+	// Kind - optional filter: func, method, type, const or var
+	Kind string `json:"kind,omitempty" jsonschema:"Only consider declarations of this kind: func, method, type, const or var"`
+}
+
+// FileDeclaration is a top-level declaration of a file with its line span.
+type FileDeclaration struct {
+	// Kind - func, method, type, const or var
+	Kind string `json:"kind" jsonschema:"Declaration kind: func, method, type, const or var"`
+	// Name - declared name, Type.Method for methods, the first name of a group
+	Name string `json:"name" jsonschema:"Declared name; Type.Method for methods and the first name of a grouped declaration"`
+	// Names - every name of a grouped const, var or type declaration
+	Names []string `json:"names,omitempty" jsonschema:"Every name of a grouped const, var or type declaration"`
+	// DocLine - first line of the doc comment, 0 without one
+	DocLine int `json:"docLine,omitempty" jsonschema:"First line of the doc comment; absent without one"`
+	// StartLine - first line of the declaration itself
+	StartLine int `json:"startLine" jsonschema:"First line of the declaration, after its doc comment"`
+	// EndLine - last line of the declaration
+	EndLine int `json:"endLine" jsonschema:"Last line of the declaration"`
+}
+
+// NavigateFileOutput contains results from the NavigateFile tool.
+type NavigateFileOutput struct {
+	// File - file that was navigated
+	File string `json:"file" jsonschema:"File that was navigated"`
+	// Lines - number of lines of the file
+	Lines int `json:"lines" jsonschema:"Number of lines of the file"`
+	// Total - declarations matching the kind filter
+	Total int `json:"total" jsonschema:"Number of declarations in the file matching the kind filter"`
+	// Declaration - selected declaration, absent when there is none in that direction
+	Declaration *FileDeclaration `json:"declaration,omitempty" jsonschema:"Selected declaration; absent when there is none in that direction"`
+	// Previous - declaration before the selected one or the line
+	Previous *FileDeclaration `json:"previous,omitempty" jsonschema:"Declaration before the selected one (or before the line when none contains it)"`
+	// Next - declaration after the selected one or the line
+	Next *FileDeclaration `json:"next,omitempty" jsonschema:"Declaration after the selected one (or after the line when none contains it)"`
+	// ParseError - syntax error of the file; declarations then come from the partial parse
+	ParseError string `json:"parseError,omitempty" jsonschema:"Syntax error of the file; declarations then come from the partial parse"`
+	// ContentHash - hex SHA-256 of the file content, to pass as expectedHash(es) to mutating tools
+	ContentHash string `json:"contentHash,omitempty" jsonschema:"Hex SHA-256 of the current content of the file; pass it as expectedHash or in expectedHashes to mutating tools so they refuse to write if the file changed since this read"`
+}
+
+// ------------------ config surface ------------------
+
+// AnalyzeConfigSurfaceInput contains input data for the AnalyzeConfigSurface tool.
+type AnalyzeConfigSurfaceInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// ExtraFunctions - qualified name patterns of additional configuration reads
+	ExtraFunctions []string `json:"extraFunctions,omitempty" jsonschema:"Additional configuration reads as path.Match patterns of qualified names: pkg/path.Func or pkg/path.Type.Method (e.g. example.com/app/config.Lookup*); the key is the first string constant argument"`
+}
+
+// ConfigRead is one call reading configuration.
+type ConfigRead struct {
+	// File - relative path of the file
+	File string `json:"file" jsonschema:"Relative path of the file"`
+	// Line - line of the call
+	Line int `json:"line" jsonschema:"Line of the call"`
+	// Function - enclosing function ('Type.Method' for methods), empty at package level
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function ('Type.Method' for methods); empty at package level"`
+	// Source - env, flag, viper or custom
+	Source string `json:"source" jsonschema:"Kind of read: env, flag, viper or custom (extraFunctions)"`
+	// Call - called function, e.g. os.Getenv or flag.FlagSet.Int
+	Call string `json:"call" jsonschema:"Called function, e.g. os.Getenv or flag.FlagSet.Int"`
+	// Type - Go type the value is parsed into or read as, when known
+	Type string `json:"type,omitempty" jsonschema:"Go type the value ends up as: the result of an immediate strconv/time.ParseDuration call, otherwise the type the read yields"`
+	// KeyExpr - key expression of a read whose key is not a constant
+	KeyExpr string `json:"keyExpr,omitempty" jsonschema:"Key expression of a read whose key is not a constant"`
+}
+
+// ConfigKey is a configuration key with every place it is read.
+type ConfigKey struct {
+	// Key - environment variable, flag or viper key name
+	Key string `json:"key" jsonschema:"Environment variable, flag or viper key name"`
+	// Sources - distinct sources reading the key
+	Sources []string `json:"sources" jsonschema:"Distinct sources reading the key"`
+	// Types - distinct Go types the key is read as
+	Types []string `json:"types,omitempty" jsonschema:"Distinct Go types the key is read as"`
+	// Reads - read locations sorted by file and line
+	Reads []ConfigRead `json:"reads" jsonschema:"Read locations sorted by file and line"`
+}
+
+// AnalyzeConfigSurfaceOutput contains results from the AnalyzeConfigSurface tool.
+type AnalyzeConfigSurfaceOutput struct {
+	// Total - number of configuration reads
+	Total int `json:"total" jsonschema:"Number of configuration reads"`
+	// Keys - keys sorted by name
+	Keys []ConfigKey `json:"keys,omitempty" jsonschema:"Configuration keys sorted by name"`
+	// DynamicReads - reads whose key is not a constant
+	DynamicReads []ConfigRead `json:"dynamicReads,omitempty" jsonschema:"Reads whose key is not a constant, with the key expression"`
+}
+
+// ------------------ list roots ------------------
+
+// ListRootsInput contains input data for the ListRoots tool; it takes no parameters.
+type ListRootsInput struct{}
+
+// RootInfo is a module directory registered with --root.
+type RootInfo struct {
+	// Name - root name accepted by the root field of tool inputs
+	Name string `json:"name" jsonschema:"Root name accepted by the root field of tool inputs"`
+	// Path - canonical directory of the root
+	Path string `json:"path" jsonschema:"Canonical directory of the root (absolute, symlinks resolved)"`
+}
+
+// ListRootsOutput contains results from the ListRoots tool.
+type ListRootsOutput struct {
+	// Roots - registered roots sorted by name
+	Roots []RootInfo `json:"roots" jsonschema:"Registered roots sorted by name"`
+	// Default - name of the root used when a call passes neither dir nor root (set when exactly one is registered)
+	Default string `json:"default,omitempty" jsonschema:"Name of the root used when a call passes neither dir nor root; set when exactly one root is registered"`
+}
+
+// ------------------ preview delete ------------------
+
+// PreviewDeleteInput contains input data for the PreviewDelete tool.
+type PreviewDeleteInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Symbol - name of the symbol to delete; methods are written as Type.Method
+	Symbol string `json:"symbol" jsonschema:"Name of the symbol to delete; methods are written as Type.Method"`
+	// Kind - optional symbol kind (func, type, var, const) to disambiguate the name
+	Kind string `json:"kind,omitempty" jsonschema:"Optional symbol kind (func, type, var, const) to disambiguate the name"`
+	// Package - import path of the package declaring the symbol, to choose among same-named declarations
+	Package string `json:"package,omitempty" jsonschema:"Import path of the package declaring the symbol (e.g. example.com/app/config); required when several packages declare the name, which otherwise fails with AMBIGUOUS listing them"`
+}
+
+// DeleteImpactPackage lists the references that would break in one package.
+type DeleteImpactPackage struct {
+	// Package - import path of the package (test variants keep their own path)
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// References - number of references in the package
+	References int `json:"references" jsonschema:"Number of references in the package"`
+	// Groups - references grouped by file with snippets
+	Groups []ReferenceGroup `json:"groups" jsonschema:"References grouped by file with snippets"`
+}
+
+// BrokenImplementation is a type that would stop implementing an interface once the method is deleted.
+type BrokenImplementation struct {
+	// Type - implementing type (pointer when only the pointer implements the interface)
+	Type string `json:"type" jsonschema:"Implementing type; a pointer when only the pointer implements the interface"`
+	// Interface - interface that requires the method
+	Interface string `json:"interface" jsonschema:"Interface that requires the method"`
+	// File - relative file path of the type declaration
+	File string `json:"file" jsonschema:"Relative file path of the type declaration"`
+	// Line - line number of the type declaration
+	Line int `json:"line" jsonschema:"Line number of the type declaration"`
+}
+
+// PreviewDeleteOutput contains results from the PreviewDelete tool.
+type PreviewDeleteOutput struct {
+	// Symbol - resolved symbol with its signature
+	Symbol string `json:"symbol" jsonschema:"Resolved symbol with its signature"`
+	// Kind - kind of the resolved symbol
+	Kind string `json:"kind" jsonschema:"Kind of the resolved symbol"`
+	// File - relative file path of the declaration
+	File string `json:"file" jsonschema:"Relative file path of the declaration"`
+	// Line - line number of the declaration
+	Line int `json:"line" jsonschema:"Line number of the declaration"`
+	// Verdict - one-sentence summary: safe, or what breaks
+	Verdict string `json:"verdict" jsonschema:"One-sentence summary, e.g. 'safe' or 'breaks 3 references in 2 packages'"`
+	// Safe - true when nothing in the module would break
+	Safe bool `json:"safe" jsonschema:"True when nothing in the module would break"`
+	// AlreadyDead - true when the symbol is unused dead code per getDeadCodeReport
+	AlreadyDead bool `json:"alreadyDead,omitempty" jsonschema:"True when the symbol is unused dead code per getDeadCodeReport"`
+	// References - total number of references that would break
+	References int `json:"references" jsonschema:"Total number of references that would break"`
+	// Packages - breaking references grouped by package and file
+	Packages []DeleteImpactPackage `json:"packages,omitempty" jsonschema:"Breaking references grouped by package and file"`
+	// BrokenImplementations - types that would stop implementing a module interface (methods only)
+	BrokenImplementations []BrokenImplementation `json:"brokenImplementations,omitempty" jsonschema:"Types that would stop implementing a module interface; methods only"`
+	// TestFiles - test files referencing the symbol
+	TestFiles []string `json:"testFiles,omitempty" jsonschema:"Test files referencing the symbol"`
+}
+
+// ------------------ dependency packages ------------------
+
+// DependencyInfo describes a dependency package analyzed with dependencyPackage.
+type DependencyInfo struct {
+	// Package - import path of the package
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// Dir - absolute directory of the package; file paths in the result are relative to it
+	Dir string `json:"dir" jsonschema:"Absolute directory of the package; file paths in the result are relative to it"`
+	// Module - path of the module providing the package (empty for the standard library)
+	Module string `json:"module,omitempty" jsonschema:"Path of the module providing the package; empty for the standard library"`
+	// Version - module version, or the replacement's version (empty for the standard library and local replacements)
+	Version string `json:"version,omitempty" jsonschema:"Module version, or the replacement's version; empty for the standard library and local replacements"`
+	// Replace - replacement module path or local directory from a replace directive
+	Replace string `json:"replace,omitempty" jsonschema:"Replacement module path or local directory from a replace directive"`
+}
+
+// ------------------ overexported symbols ------------------
+
+// FindOverexportedSymbolsInput contains input data for the FindOverexportedSymbols tool.
+type FindOverexportedSymbolsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict the report
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the report"`
+	// ExcludeMain - if true, skip main packages, whose exported symbols cannot be imported anyway
+	ExcludeMain bool `json:"excludeMain,omitempty" jsonschema:"If true, skip main packages, whose exported symbols cannot be imported anyway"`
+	// IgnoreTestReferences - if true, references from test files of other packages do not keep a symbol exported
+	IgnoreTestReferences bool `json:"ignoreTestReferences,omitempty" jsonschema:"If true, references from test files of other packages (e.g. external pkg_test packages) do not keep a symbol exported"`
+	// IncludeGenerated - if true, also report symbols declared in generated files
+	IncludeGenerated bool `json:"includeGenerated,omitempty" jsonschema:"If true, also report symbols declared in files carrying a 'Code generated ... DO NOT EDIT.' header"`
+}
+
+// OverexportedSymbol is an exported symbol referenced only from its own package.
+type OverexportedSymbol struct {
+	// Name - symbol name
+	Name string `json:"name" jsonschema:"Symbol name"`
+	// Kind - symbol kind (func, var, const, type)
+	Kind string `json:"kind" jsonschema:"Symbol kind (func, var, const, type)"`
+	// File - file where the symbol is declared
+	File string `json:"file" jsonschema:"File where the symbol is declared"`
+	// Line - line number of the declaration
+	Line int `json:"line" jsonschema:"Line number of the declaration"`
+	// References - number of references, all from the defining package
+	References int `json:"references" jsonschema:"Number of references, all from the defining package"`
+	// SuggestedName - unexported name free in the package scope; empty when the natural choice collides
+	SuggestedName string `json:"suggestedName,omitempty" jsonschema:"Unexported name free in the package scope; empty when the natural choice collides"`
+	// Conflict - why no rename is emitted, e.g. the lowercased name is already declared in the package
+	Conflict string `json:"conflict,omitempty" jsonschema:"Why no rename is emitted, e.g. the lowercased name is already declared in the package"`
+	// Rename - renameSymbol input applying the suggestion
+	Rename *RenameSymbolInput `json:"rename,omitempty" jsonschema:"renameSymbol input applying the suggestion"`
+}
+
+// OverexportedPackage groups the overexported symbols of one package.
+type OverexportedPackage struct {
+	// Package - package import path
+	Package string `json:"package" jsonschema:"Package import path"`
+	// Symbols - overexported symbols ordered by file and line
+	Symbols []OverexportedSymbol `json:"symbols" jsonschema:"Overexported symbols ordered by file and line"`
+}
+
+// FindOverexportedSymbolsOutput represents the result of FindOverexportedSymbols.
+type FindOverexportedSymbolsOutput struct {
+	// Total - number of overexported symbols
+	Total int `json:"total" jsonschema:"Number of overexported symbols"`
+	// Packages - overexported symbols grouped by package
+	Packages []OverexportedPackage `json:"packages,omitempty" jsonschema:"Overexported symbols grouped by package"`
+	// Suppressed - symbols left out of the report by a //gonav:ignore directive
+	Suppressed []SuppressedFinding `json:"suppressed,omitempty" jsonschema:"Symbols left out of the report by a //gonav:ignore directive"`
+}
+
+// ------------------ field usage ------------------
+
+// AnalyzeFieldUsageInput contains input data for the AnalyzeFieldUsage tool.
+type AnalyzeFieldUsageInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// TypeName - struct to analyze; all structs of package when omitted
+	TypeName string `json:"typeName,omitempty" jsonschema:"Struct to analyze (e.g. 'Config', 'config.Config' or 'example.com/app/config.Config'); when omitted, every struct declared in package is analyzed"`
+	// Package - package path declaring the structs, required without typeName
+	Package string `json:"package,omitempty" jsonschema:"Go package path declaring the structs; required when typeName is omitted, narrows the typeName lookup otherwise"`
+	// MaxSamples - sample locations kept per field and access kind
+	MaxSamples int `json:"maxSamples,omitempty" jsonschema:"Sample locations kept per field for reads, writes and literals (default 3)"`
+}
+
+// FieldUsage counts the accesses to one struct field.
+type FieldUsage struct {
+	// Name - field name
+	Name string `json:"name" jsonschema:"Field name"`
+	// Type - field type
+	Type string `json:"type" jsonschema:"Field type"`
+	// Embedded - true for an embedded field
+	Embedded bool `json:"embedded,omitempty" jsonschema:"True for an embedded field"`
+	// JSONTag - name from the json struct tag
+	JSONTag string `json:"jsonTag,omitempty" jsonschema:"Name from the json struct tag, '-' when the field is excluded from encoding/json"`
+	// Reads - selections reading the field
+	Reads int `json:"reads" jsonschema:"Selections reading the field"`
+	// Writes - selections assigning, incrementing or taking the address of the field
+	Writes int `json:"writes" jsonschema:"Selections assigning, incrementing or taking the address of the field"`
+	// Literals - composite literals initializing the field
+	Literals int `json:"literals" jsonschema:"Composite literals initializing the field, keyed or positional"`
+	// Unread - true if no selection reads the field
+	Unread bool `json:"unread,omitempty" jsonschema:"True if no selection reads the field: a candidate for removal"`
+	// Untouched - true if the field is neither read, written nor initialized
+	Untouched bool `json:"untouched,omitempty" jsonschema:"True if the field is neither read, written nor initialized"`
+	// Serialized - true if the field is unread but carries a json name
+	Serialized bool `json:"serialized,omitempty" jsonschema:"True if the field is unread but carries a json name, so encoding/json may be its only reader"`
+	// ReadSamples - sample read locations
+	ReadSamples []ContextLocation `json:"readSamples,omitempty" jsonschema:"Sample read locations"`
+	// WriteSamples - sample write locations
+	WriteSamples []ContextLocation `json:"writeSamples,omitempty" jsonschema:"Sample write locations"`
+	// LiteralSamples - sample composite literal locations
+	LiteralSamples []ContextLocation `json:"literalSamples,omitempty" jsonschema:"Sample composite literal locations"`
+}
+
+// StructFieldUsage holds the field usage of one struct.
+type StructFieldUsage struct {
+	// Struct - struct name
+	Struct string `json:"struct" jsonschema:"Struct name"`
+	// Package - package path declaring the struct
+	Package string `json:"package" jsonschema:"Package path declaring the struct"`
+	// File - file declaring the struct
+	File string `json:"file" jsonschema:"Relative path of the file declaring the struct"`
+	// Line - line of the declaration
+	Line int `json:"line" jsonschema:"Line of the declaration"`
+	// Fields - fields in declaration order
+	Fields []FieldUsage `json:"fields" jsonschema:"Fields in declaration order"`
+}
+
+// AnalyzeFieldUsageOutput contains results from the AnalyzeFieldUsage tool.
+type AnalyzeFieldUsageOutput struct {
+	// Structs - analyzed structs ordered by package path and name
+	Structs []StructFieldUsage `json:"structs" jsonschema:"Analyzed structs ordered by package path and name"`
+	// UnreadFields - number of fields no selection reads
+	UnreadFields int `json:"unreadFields" jsonschema:"Number of fields no selection reads"`
+}
+
+// ------------------ parameter objects ------------------
+
+// SuggestParameterObjectsInput contains input data for the SuggestParameterObjects tool.
+type SuggestParameterObjectsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict the analysis
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the analysis"`
+	// MaxParams - parameter count above which a function is flagged
+	MaxParams int `json:"maxParams,omitempty" jsonschema:"Parameter count above which a function is flagged (default 5)"`
+}
+
+// FunctionParams reports the parameter count of a function.
+type FunctionParams struct {
+	// Name - function name, Type.Method for methods
+	Name string `json:"name" jsonschema:"Function name, Type.Method for methods"`
+	// Package - package path of the function
+	Package string `json:"package" jsonschema:"Package path of the function"`
+	// File - relative path of the declaring file
+	File string `json:"file" jsonschema:"Relative path of the declaring file"`
+	// Line - line of the declaration
+	Line int `json:"line" jsonschema:"Line of the declaration"`
+	// Params - number of parameters
+	Params int `json:"params" jsonschema:"Number of parameters"`
+	// ExceedsMax - true if the function takes more than maxParams parameters
+	ExceedsMax bool `json:"exceedsMax,omitempty" jsonschema:"True if the function takes more than maxParams parameters"`
+}
+
+// ParameterField is a parameter of a shared group and the field replacing it.
+type ParameterField struct {
+	// Name - parameter name
+	Name string `json:"name" jsonschema:"Parameter name"`
+	// Type - parameter type, qualified relative to the package
+	Type string `json:"type" jsonschema:"Parameter type, qualified relative to the package"`
+}
+
+// ParameterGroupFunction is a function whose signature contains a parameter group.
+type ParameterGroupFunction struct {
+	// Name - function name, Type.Method for methods
+	Name string `json:"name" jsonschema:"Function name, Type.Method for methods"`
+	// File - relative path of the declaring file
+	File string `json:"file" jsonschema:"Relative path of the declaring file"`
+	// Line - line of the declaration
+	Line int `json:"line" jsonschema:"Line of the declaration"`
+}
+
+// ParameterGroup is a set of parameters that travel together through several signatures.
+type ParameterGroup struct {
+	// Package - package path of the functions
+	Package string `json:"package" jsonschema:"Package path of the functions"`
+	// Params - the shared parameters, in the order of the first signature
+	Params []ParameterField `json:"params" jsonschema:"Shared parameters with identical names and types, in the order of the first signature"`
+	// Functions - functions whose signatures contain every parameter of the group
+	Functions []ParameterGroupFunction `json:"functions" jsonschema:"Functions whose signatures contain every parameter of the group"`
+	// SuggestedName - name of the suggested parameter struct
+	SuggestedName string `json:"suggestedName" jsonschema:"Name of the suggested parameter struct, exported when one of the functions is"`
+	// SuggestedStruct - gofmt-ed declaration of the suggested struct
+	SuggestedStruct string `json:"suggestedStruct" jsonschema:"gofmt-ed declaration of the suggested parameter struct"`
+}
+
+// SuggestParameterObjectsOutput contains results from the SuggestParameterObjects tool.
+type SuggestParameterObjectsOutput struct {
+	// Functions - functions above maxParams or in a group, by parameter count
+	Functions []FunctionParams `json:"functions" jsonschema:"Functions taking more than maxParams parameters or containing a parameter group, by descending parameter count"`
+	// Groups - parameter groups ordered by the number of functions they would simplify
+	Groups []ParameterGroup `json:"groups" jsonschema:"Parameter groups of 3+ parameters shared by 3+ signatures, ordered by the number of functions they would simplify"`
+}
+
+// ------------------ analyze defers ------------------
+
+// AnalyzeDefersInput contains input data for the AnalyzeDefers tool.
+type AnalyzeDefersInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// DeferFinding describes a suspicious defer statement.
+type DeferFinding struct {
+	// Category - defer-in-loop, unchecked-close, loop-var-capture or nil-func-value
+	Category string `json:"category" jsonschema:"Finding category: defer-in-loop, unchecked-close, loop-var-capture or nil-func-value"`
+	// File - file containing the defer
+	File string `json:"file" jsonschema:"File containing the defer"`
+	// Line - line of the defer statement
+	Line int `json:"line" jsonschema:"Line of the defer statement"`
+	// Function - enclosing function ('Type.Method' for methods)
+	Function string `json:"function" jsonschema:"Enclosing function declaration ('Type.Method' for methods), also for defers inside its function literals"`
+	// Message - what is wrong with the defer
+	Message string `json:"message" jsonschema:"What is wrong with the defer"`
+	// Suggestion - how to fix it
+	Suggestion string `json:"suggestion" jsonschema:"How to fix it"`
+}
+
+// DeferCategory groups the findings of one category.
+type DeferCategory struct {
+	// Category - finding category
+	Category string `json:"category" jsonschema:"Finding category"`
+	// Count - number of findings
+	Count int `json:"count" jsonschema:"Number of findings"`
+	// Findings - findings ordered by file and line
+	Findings []DeferFinding `json:"findings" jsonschema:"Findings ordered by file and line"`
+}
+
+// AnalyzeDefersOutput contains results from the AnalyzeDefers tool.
+type AnalyzeDefersOutput struct {
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Categories - findings grouped by category, empty categories omitted
+	Categories []DeferCategory `json:"categories" jsonschema:"Findings grouped by category in the order defer-in-loop, unchecked-close, loop-var-capture, nil-func-value; empty categories are omitted"`
+}
+
+// ------------------ find type assertions ------------------
+
+// FindTypeAssertionsInput contains input data for the FindTypeAssertions tool.
+type FindTypeAssertionsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// InterfaceName - optional interface whose assertions are reported (all interfaces when empty)
+	InterfaceName string `json:"interfaceName,omitempty" jsonschema:"Optional interface, e.g. 'Shape' or 'lang.Shape', whose assertions and type switches are reported; all interfaces when empty"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// TypeAssertionSite is a type assertion or type switch over an interface value.
+type TypeAssertionSite struct {
+	// Kind - 'assertion' for x.(T), 'switch' for a type switch
+	Kind string `json:"kind" jsonschema:"'assertion' for x.(T), 'switch' for a type switch"`
+	// Line - line of the assertion or switch statement
+	Line int `json:"line" jsonschema:"Line of the assertion or switch statement"`
+	// Function - enclosing function ('Type.Method' for methods, empty at package level)
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function declaration ('Type.Method' for methods), empty for package-level initializers"`
+	// Operand - the asserted expression
+	Operand string `json:"operand" jsonschema:"The asserted expression"`
+	// Types - asserted type, or every case type of a switch in source order
+	Types []string `json:"types" jsonschema:"Asserted type, or every case type of a type switch in source order"`
+	// Panicking - single-value assertion that panics when the type does not match
+	Panicking bool `json:"panicking,omitempty" jsonschema:"Single-value assertion x.(T) that panics when the dynamic type does not match (assertions only)"`
+	// NoDefault - type switch without a default case
+	NoDefault bool `json:"noDefault,omitempty" jsonschema:"Type switch without a default case (switches only)"`
+}
+
+// TypeAssertionFile groups the sites of one file.
+type TypeAssertionFile struct {
+	// File - relative path of the file
+	File string `json:"file" jsonschema:"Relative path of the file"`
+	// Sites - assertions and switches ordered by line
+	Sites []TypeAssertionSite `json:"sites" jsonschema:"Assertions and type switches ordered by line"`
+}
+
+// TypeAssertionInterface groups the sites asserting on one interface.
+type TypeAssertionInterface struct {
+	// Interface - static type of the operands, package-qualified
+	Interface string `json:"interface" jsonschema:"Static interface type of the operands, package-qualified (e.g. 'lang.Shape', 'error', 'any')"`
+	// Count - number of sites
+	Count int `json:"count" jsonschema:"Number of assertions and type switches"`
+	// Files - sites grouped by file
+	Files []TypeAssertionFile `json:"files" jsonschema:"Sites grouped by file"`
+}
+
+// FindTypeAssertionsOutput contains results from the FindTypeAssertions tool.
+type FindTypeAssertionsOutput struct {
+	// Total - number of sites
+	Total int `json:"total" jsonschema:"Number of assertions and type switches"`
+	// Interfaces - sites grouped by interface, most asserted first
+	Interfaces []TypeAssertionInterface `json:"interfaces" jsonschema:"Sites grouped by interface, most asserted first"`
+}
+
+// ------------------ find interface conversions ------------------
+
+// FindInterfaceConversionsInput contains input data for the FindInterfaceConversions tool.
+type FindInterfaceConversionsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// TypeName - name of the concrete type whose conversions to interfaces are searched
+	TypeName string `json:"typeName" jsonschema:"Name of the concrete (non-interface) named type whose conversions to interfaces are searched; values of T and *T both count"`
+	// InterfaceName - optional target interface (any interface when empty)
+	InterfaceName string `json:"interfaceName,omitempty" jsonschema:"Optional target interface, e.g. 'Shape', 'lang.Shape', 'error' or 'any'; any interface when empty"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// InterfaceConversion is a place where a value of the type is implicitly converted to an interface.
+type InterfaceConversion struct {
+	// Line - line of the converted expression
+	Line int `json:"line" jsonschema:"Line of the converted expression"`
+	// Kind - arg, assign, return or composite
+	Kind string `json:"kind" jsonschema:"How the value reaches the interface: 'arg' (call argument, append included), 'assign' (assignment or typed var declaration), 'return' (function result) or 'composite' (element, field value, map key or value of a composite literal)"`
+	// Interface - target interface type, package-qualified
+	Interface string `json:"interface" jsonschema:"Target interface type, package-qualified (e.g. 'lang.Shape', 'error', 'any')"`
+	// Pointer - the converted value is a *T rather than a T
+	Pointer bool `json:"pointer,omitempty" jsonschema:"The converted value is a *T rather than a T"`
+	// Function - enclosing function ('Type.Method' for methods, empty at package level)
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function declaration ('Type.Method' for methods), empty for package-level declarations"`
+	// Snippet - trimmed source line
+	Snippet string `json:"snippet" jsonschema:"Trimmed source line"`
+}
+
+// InterfaceConversionGroup groups conversion sites by file.
+type InterfaceConversionGroup struct {
+	// File - relative path of the file
+	File string `json:"file" jsonschema:"Relative path of the file"`
+	// Conversions - conversion sites ordered by line
+	Conversions []InterfaceConversion `json:"conversions" jsonschema:"Conversion sites ordered by line"`
+}
+
+// FindInterfaceConversionsOutput contains results from the FindInterfaceConversions tool.
+type FindInterfaceConversionsOutput struct {
+	// Total - number of conversion sites
+	Total int `json:"total" jsonschema:"Number of conversion sites"`
+	// Groups - conversion sites grouped by file
+	Groups []InterfaceConversionGroup `json:"groups" jsonschema:"Conversion sites grouped by file"`
+}
+
+// ------------------ find call path ------------------
+
+// FindCallPathInput contains input data for the FindCallPath tool.
+type FindCallPathInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// From - function the chains start at
+	From string `json:"from" jsonschema:"Function the chains start at: 'Func' or 'Type.Method'"`
+	// To - function the chains end at
+	To string `json:"to" jsonschema:"Function the chains end at: 'Func' or 'Type.Method'"`
+	// MaxDepth - maximum number of calls in a chain
+	MaxDepth int `json:"maxDepth,omitempty" jsonschema:"Maximum number of calls in a chain (default 8)"`
+	// MaxPaths - maximum number of chains returned
+	MaxPaths int `json:"maxPaths,omitempty" jsonschema:"Maximum number of shortest chains returned (default 3)"`
+}
+
+// CallPathStep is a function of a call chain.
+type CallPathStep struct {
+	// Function - function name, Type.Method for methods
+	Function string `json:"function" jsonschema:"Function name, Type.Method for methods"`
+	// Package - import path of the function's package
+	Package string `json:"package" jsonschema:"Import path of the function's package"`
+	// File - file declaring the function
+	File string `json:"file" jsonschema:"File declaring the function, relative to dir"`
+	// Line - line of the call into the next step; the declaration line for the last step
+	Line int `json:"line" jsonschema:"Line of the call into the next step; the declaration line for the last step"`
+	// Dynamic - the call into the next step goes through an interface method
+	Dynamic bool `json:"dynamic,omitempty" jsonschema:"True when the call into the next step is an interface method call, resolved to the next step as one of its in-module implementations"`
+}
+
+// CallPath is one call chain.
+type CallPath struct {
+	// Length - number of calls in the chain
+	Length int `json:"length" jsonschema:"Number of calls in the chain"`
+	// Dynamic - the chain has at least one dynamic step
+	Dynamic bool `json:"dynamic,omitempty" jsonschema:"True when at least one call goes through an interface"`
+	// Steps - functions from 'from' to 'to'
+	Steps []CallPathStep `json:"steps" jsonschema:"Functions from 'from' to 'to', in call order"`
+}
+
+// FindCallPathOutput contains the result of the FindCallPath tool.
+type FindCallPathOutput struct {
+	// From - requested start function
+	From string `json:"from" jsonschema:"Requested start function"`
+	// To - requested end function
+	To string `json:"to" jsonschema:"Requested end function"`
+	// Paths - shortest call chains
+	Paths []CallPath `json:"paths" jsonschema:"Up to maxPaths shortest call chains"`
+	// Total - number of call chains returned
+	Total int `json:"total" jsonschema:"Number of call chains returned"`
+	// Message - set when no chain exists within maxDepth
+	Message string `json:"message,omitempty" jsonschema:"Set when no static chain exists within maxDepth"`
+}
+
+// ------------------ analyze sql ------------------
+
+// AnalyzeSQLInput contains input data for the AnalyzeSQL tool.
+type AnalyzeSQLInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path filter
+	Package string `json:"package,omitempty" jsonschema:"Optional package path filter (go list path)"`
+	// CallPatterns - database calls taking SQL
+	CallPatterns []string `json:"callPatterns,omitempty" jsonschema:"Calls taking SQL: '(*pkg.Type).Method', '(*pkg.Type)' for every method of the type, or 'pkg.Func'; path.Match wildcards allowed (default: (*database/sql.DB).Query, Exec and QueryRow, and every method of (*github.com/jmoiron/sqlx.DB))"`
+}
+
+// SQLQuery is a call passing SQL to the database.
+type SQLQuery struct {
+	// File - file of the call
+	File string `json:"file" jsonschema:"File of the call, relative to dir"`
+	// Line - line of the call
+	Line int `json:"line" jsonschema:"Line of the call"`
+	// Function - enclosing function, Type.Method for methods
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function, Type.Method for methods; empty at package level"`
+	// Call - called function, e.g. sql.DB.Query
+	Call string `json:"call" jsonschema:"Called function, e.g. sql.DB.Query"`
+	// Statement - SELECT, INSERT, UPDATE, DELETE, DDL, OTHER or UNKNOWN
+	Statement string `json:"statement" jsonschema:"Statement type by leading keyword: SELECT, INSERT, UPDATE, DELETE, DDL (CREATE, ALTER, DROP, TRUNCATE), OTHER, or UNKNOWN when no SQL text is known"`
+	// Source - how the SQL argument is built
+	Source string `json:"source" jsonschema:"How the SQL argument is built: literal, constant (a named or computed constant), variable (a local assigned one constant), concatenation, sprintf or dynamic (anything else)"`
+	// SQL - SQL text, or its constant prefix or format for built queries
+	SQL string `json:"sql,omitempty" jsonschema:"SQL text; for concatenation its constant prefix and for sprintf its format"`
+	// Constant - named constant holding the SQL
+	Constant string `json:"constant,omitempty" jsonschema:"Named constant holding the SQL (source constant)"`
+	// Expr - SQL argument expression when it is not constant
+	Expr string `json:"expr,omitempty" jsonschema:"SQL argument expression when it is not a constant"`
+	// InjectionRisk - the SQL is built from non-constant strings
+	InjectionRisk bool `json:"injectionRisk,omitempty" jsonschema:"True when the SQL is concatenated or formatted with fmt.Sprintf from non-constant values, directly or through the local variable passed; use query parameters instead"`
+}
+
+// SQLPackage groups the SQL calls of a package.
+type SQLPackage struct {
+	// Package - import path of the package
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// Total - number of SQL calls
+	Total int `json:"total" jsonschema:"Number of SQL calls"`
+	// ByStatement - number of calls per statement type
+	ByStatement map[string]int `json:"byStatement" jsonschema:"Number of calls per statement type"`
+	// InjectionRisks - number of calls flagged as injection risks
+	InjectionRisks int `json:"injectionRisks,omitempty" jsonschema:"Number of calls flagged as injection risks"`
+	// Queries - SQL calls sorted by file and line
+	Queries []SQLQuery `json:"queries" jsonschema:"SQL calls sorted by file and line"`
+}
+
+// AnalyzeSQLOutput contains results from the AnalyzeSQL tool.
+type AnalyzeSQLOutput struct {
+	// Total - number of SQL calls
+	Total int `json:"total" jsonschema:"Number of SQL calls"`
+	// InjectionRisks - number of calls flagged as injection risks
+	InjectionRisks int `json:"injectionRisks" jsonschema:"Number of calls flagged as injection risks"`
+	// Packages - SQL calls grouped by package, sorted by import path
+	Packages []SQLPackage `json:"packages" jsonschema:"SQL calls grouped by package, sorted by import path"`
+}
+
+// ------------------ check file headers ------------------
+
+// CheckFileHeadersInput contains input data for the CheckFileHeaders tool.
+type CheckFileHeadersInput struct {
+	// Dir - root directory of the module
+	Dir string `json:"dir" jsonschema:"Root directory of the module"`
+	// Package - optional package path to restrict the check
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the check"`
+	// RequiredPattern - literal prefix or regular expression the header must match
+	RequiredPattern string `json:"requiredPattern" jsonschema:"Literal prefix or regular expression the header text (comment markers stripped) must match, e.g. 'Copyright (\\d{4}) (Acme Inc)'"`
+	// Exclude - glob patterns of files to skip
+	Exclude []string `json:"exclude,omitempty" jsonschema:"Glob patterns of files to skip, matched against the relative path ('**' for any number of directories) and the base name, e.g. 'third_party/**' or '*.pb.go'"`
+	// Expected - expected values of capture groups of requiredPattern
+	Expected map[string]string `json:"expected,omitempty" jsonschema:"Expected values of capture groups, by group name or 1-based index; groups without one are expected to have the value most files have"`
+}
+
+// HeaderIssue is a file whose header is missing, does not match or has unexpected captured values.
+type HeaderIssue struct {
+	// File - file path relative to dir
+	File string `json:"file" jsonschema:"File path relative to dir"`
+	// Problem - missing, mismatch or unexpected
+	Problem string `json:"problem" jsonschema:"missing (no header comment), mismatch (header does not match requiredPattern) or unexpected (captured values differ from the expected ones)"`
+	// Header - first line of the header comment
+	Header string `json:"header,omitempty" jsonschema:"First line of the header comment"`
+	// Captures - values of the capture groups of requiredPattern
+	Captures map[string]string `json:"captures,omitempty" jsonschema:"Values of the capture groups of requiredPattern, by group name or 1-based index"`
+	// Expected - expected values of the groups that differ
+	Expected map[string]string `json:"expected,omitempty" jsonschema:"Expected values of the capture groups that differ"`
+}
+
+// CheckFileHeadersOutput contains results from the CheckFileHeaders tool.
+type CheckFileHeadersOutput struct {
+	// Checked - number of files checked
+	Checked int `json:"checked" jsonschema:"Number of files checked"`
+	// Compliant - number of files whose header matches
+	Compliant int `json:"compliant" jsonschema:"Number of files whose header matches with the expected values"`
+	// Issues - non-compliant files sorted by path
+	Issues []HeaderIssue `json:"issues" jsonschema:"Non-compliant files sorted by path"`
+}
+
+// ------------------ analyze lifecycles ------------------
+
+// AnalyzeLifecyclesInput contains input data for the AnalyzeLifecycles tool.
+type AnalyzeLifecyclesInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// ExtraLeakyFunctions - wrappers of time.After reported inside loops too
+	ExtraLeakyFunctions []string `json:"extraLeakyFunctions,omitempty" jsonschema:"Functions that create a timer per call like time.After, reported inside loops too: path.Match patterns of pkg/path.Func or pkg/path.Type.Method, e.g. 'example.com/app/clock.After*'"`
+}
+
+// LifecycleFinding describes a goroutine or timer that may outlive its use.
+type LifecycleFinding struct {
+	// Category - unstoppable-goroutine, time-tick or after-in-loop
+	Category string `json:"category" jsonschema:"Finding category: unstoppable-goroutine, time-tick or after-in-loop"`
+	// File - file containing the finding
+	File string `json:"file" jsonschema:"File containing the finding"`
+	// Line - line of the go statement or call
+	Line int `json:"line" jsonschema:"Line of the go statement or call"`
+	// Function - enclosing function ('Type.Method' for methods)
+	Function string `json:"function" jsonschema:"Enclosing function declaration ('Type.Method' for methods), also for findings inside its function literals"`
+	// Resource - leaked resource: goroutine, ticker or timer
+	Resource string `json:"resource" jsonschema:"Leaked resource: goroutine, ticker or timer"`
+	// Call - called function, or the channel the goroutine sends on
+	Call string `json:"call" jsonschema:"Called function (e.g. 'time.After'), or the returned channel the goroutine sends on"`
+	// Message - why the resource leaks
+	Message string `json:"message" jsonschema:"Why the resource leaks"`
+	// Suggestion - name of the fix
+	Suggestion string `json:"suggestion" jsonschema:"Name of the fix: 'accept ctx and select', 'use NewTicker + Stop' or 'use NewTimer + Stop'"`
+}
+
+// LifecycleCategory groups the findings of one category.
+type LifecycleCategory struct {
+	// Category - finding category
+	Category string `json:"category" jsonschema:"Finding category"`
+	// Count - number of findings
+	Count int `json:"count" jsonschema:"Number of findings"`
+	// Findings - findings ordered by file and line
+	Findings []LifecycleFinding `json:"findings" jsonschema:"Findings ordered by file and line"`
+}
+
+// AnalyzeLifecyclesOutput contains results from the AnalyzeLifecycles tool.
+type AnalyzeLifecyclesOutput struct {
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Categories - findings grouped by category, empty categories omitted
+	Categories []LifecycleCategory `json:"categories" jsonschema:"Findings grouped by category in the order unstoppable-goroutine, time-tick, after-in-loop; empty categories are omitted"`
+}
+
+// ------------------ inspect node ------------------
+
+// InspectNodeInput contains input data for the InspectNode tool.
+type InspectNodeInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - file path: absolute, relative to dir or a unique path suffix
+	File string `json:"file" jsonschema:"File path: absolute, relative to dir or a unique path suffix"`
+	// Line - 1-based line
+	Line int `json:"line" jsonschema:"1-based line"`
+	// Column - 1-based byte column, 0 for the first non-blank character of the line
+	Column int `json:"column,omitempty" jsonschema:"1-based byte column (as in go/token positions); 0 or omitted for the first non-blank character of the line"`
+	// Depth - maximum number of nodes, innermost kept
+	Depth int `json:"depth,omitempty" jsonschema:"Maximum number of nodes returned; the innermost ones are kept (0 = the whole chain)"`
+	// ParentOf - if true, return only the enclosing statement or declaration
+	ParentOf bool `json:"parentOf,omitempty" jsonschema:"If true, return only the smallest statement, spec or declaration (blocks excluded) enclosing the position"`
+}
+
+// ASTNodeInfo is one syntax node of the chain at a position.
+type ASTNodeInfo struct {
+	// Kind - go/ast node type, e.g. FuncDecl or CallExpr
+	Kind string `json:"kind" jsonschema:"go/ast node type, e.g. FuncDecl or CallExpr"`
+	// Label - distinguishing detail, e.g. Name=Load or Fun=os.Stat
+	Label string `json:"label,omitempty" jsonschema:"Distinguishing detail, e.g. 'Name=Load' for declarations, 'Fun=os.Stat' for calls, 'Tok=:=' for assignments"`
+	// StartLine - first line of the node
+	StartLine int `json:"startLine" jsonschema:"First line of the node"`
+	// StartColumn - 1-based byte column where the node starts
+	StartColumn int `json:"startColumn" jsonschema:"1-based byte column where the node starts"`
+	// EndLine - last line of the node
+	EndLine int `json:"endLine" jsonschema:"Last line of the node"`
+	// EndColumn - 1-based byte column just after the node
+	EndColumn int `json:"endColumn" jsonschema:"1-based byte column just after the node"`
+	// Text - source of the node with white space collapsed, capped at 120 characters
+	Text string `json:"text" jsonschema:"Source of the node with white space collapsed, cut at 120 characters with '...'; 'package name' for the file"`
+}
+
+// InspectNodeOutput contains results from the InspectNode tool.
+type InspectNodeOutput struct {
+	// File - resolved file path relative to dir
+	File string `json:"file" jsonschema:"Resolved file path relative to dir"`
+	// Path - the chain as one line
+	Path string `json:"path" jsonschema:"The chain as one line, e.g. 'File(Name=main) > FuncDecl(Name=Load) > BlockStmt > IfStmt > CallExpr(Fun=os.Stat)'"`
+	// Nodes - nodes from the outermost to the innermost
+	Nodes []ASTNodeInfo `json:"nodes" jsonschema:"Nodes from the outermost (the file, unless cut by depth) to the innermost at the position"`
+	// Truncated - depth cut off outer nodes
+	Truncated bool `json:"truncated,omitempty" jsonschema:"True when depth cut off outer nodes"`
+}
+
+// ------------------ release report ------------------
+
+// ReleaseReportInput contains input data for the ReleaseReport tool.
+type ReleaseReportInput struct {
+	// Dir - directory inside the Go module
+	Dir string `json:"dir" jsonschema:"Directory inside the Go module"`
+	// BaselineIndexFile - exportIndex artifact of the previous release to diff the public API against
+	BaselineIndexFile string `json:"baselineIndexFile,omitempty" jsonschema:"exportIndex artifact of the previous release (with the symbols section), absolute or relative to dir, to diff the public API against; without it the api-diff section is skipped"`
+}
+
+// ReleaseFinding is one finding of a release report section.
+type ReleaseFinding struct {
+	// File - file of the finding, relative to dir
+	File string `json:"file,omitempty" jsonschema:"File of the finding, relative to dir (for removed symbols, where the baseline declared them)"`
+	// Line - line of the finding
+	Line int `json:"line,omitempty" jsonschema:"Line of the finding"`
+	// Symbol - qualified symbol, or the first package of an import cycle
+	Symbol string `json:"symbol,omitempty" jsonschema:"Qualified symbol, e.g. 'example.com/app.Widget.Close', or the first package of an import cycle"`
+	// Kind - symbol kind, or TODO / FIXME for todos
+	Kind string `json:"kind,omitempty" jsonschema:"Symbol kind (func, method, struct, interface, type, const, var), or TODO / FIXME in the todos section"`
+	// Change - added or removed (api-diff only)
+	Change string `json:"change,omitempty" jsonschema:"added or removed (api-diff section only)"`
+	// Message - human-readable description
+	Message string `json:"message" jsonschema:"Human-readable description: the comment of a todo, the cycle of dependency-cycles"`
+}
+
+// ReleaseSection is one check of a release report.
+type ReleaseSection struct {
+	// Name - section name
+	Name string `json:"name" jsonschema:"Section: api-diff, undocumented, deprecated-usage, todos, dead-internal-exports or dependency-cycles"`
+	// Status - pass, warn, fail or skip
+	Status string `json:"status" jsonschema:"pass, warn or fail; skip for api-diff without a baseline"`
+	// Summary - one-line summary
+	Summary string `json:"summary" jsonschema:"One-line summary of the section"`
+	// Count - number of findings
+	Count int `json:"count" jsonschema:"Number of findings"`
+	// Findings - findings ordered by file, line and symbol
+	Findings []ReleaseFinding `json:"findings" jsonschema:"Findings ordered by file, line and symbol"`
+}
+
+// ReleaseReportOutput contains results from the ReleaseReport tool.
+type ReleaseReportOutput struct {
+	// Module - module path
+	Module string `json:"module" jsonschema:"Module path"`
+	// Status - worst status of the sections
+	Status string `json:"status" jsonschema:"Worst status of the sections: pass, warn or fail"`
+	// Sections - the checks in a fixed order
+	Sections []ReleaseSection `json:"sections" jsonschema:"The checks in a fixed order: api-diff, undocumented, deprecated-usage, todos, dead-internal-exports, dependency-cycles"`
+}
+
+// ------------------ analyze receiver semantics ------------------
+
+// AnalyzeReceiverSemanticsInput contains input data for the AnalyzeReceiverSemantics tool.
+type AnalyzeReceiverSemanticsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+}
+
+// ReceiverFinding describes a receiver or lock copying problem.
+type ReceiverFinding struct {
+	// Category - value-receiver-mutation, mixed-receivers or lock-copy
+	Category string `json:"category" jsonschema:"Finding category: value-receiver-mutation, mixed-receivers or lock-copy"`
+	// File - file containing the finding
+	File string `json:"file" jsonschema:"File containing the finding"`
+	// Line - line of the assignment, type declaration, parameter or copied expression
+	Line int `json:"line" jsonschema:"Line of the assignment, type declaration, receiver or parameter, or copied expression"`
+	// Function - enclosing function ('Type.Method' for methods), empty for mixed-receivers
+	Function string `json:"function,omitempty" jsonschema:"Enclosing function declaration ('Type.Method' for methods), also for findings inside its function literals; empty for mixed-receivers"`
+	// Type - receiver type, or the type containing the lock
+	Type string `json:"type" jsonschema:"Receiver type (value-receiver-mutation, mixed-receivers) or the copied type containing the lock (lock-copy), e.g. 'store.Cache'"`
+	// Expr - assigned field, or the receiver, parameter or variable copying the lock
+	Expr string `json:"expr,omitempty" jsonschema:"Assigned field (value-receiver-mutation), or the receiver, parameter, assigned or range variable copying the lock (lock-copy)"`
+	// Message - what is wrong, with the lock path for lock-copy
+	Message string `json:"message" jsonschema:"What is wrong; for lock-copy the path to the lock, e.g. 'store.Cache contains sync.Mutex'"`
+}
+
+// ReceiverSemanticsCategory groups the findings of one category.
+type ReceiverSemanticsCategory struct {
+	// Category - finding category
+	Category string `json:"category" jsonschema:"Finding category"`
+	// Count - number of findings
+	Count int `json:"count" jsonschema:"Number of findings"`
+	// Findings - findings ordered by file and line
+	Findings []ReceiverFinding `json:"findings" jsonschema:"Findings ordered by file and line"`
+}
+
+// AnalyzeReceiverSemanticsOutput contains results from the AnalyzeReceiverSemantics tool.
+type AnalyzeReceiverSemanticsOutput struct {
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Categories - findings grouped by category, empty categories omitted
+	Categories []ReceiverSemanticsCategory `json:"categories" jsonschema:"Findings grouped by category in the order value-receiver-mutation, mixed-receivers, lock-copy; empty categories are omitted"`
+}
+
+// ------------------ analyze import weight ------------------
+
+// AnalyzeImportWeightInput contains input data for the AnalyzeImportWeight tool.
+type AnalyzeImportWeightInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// MainPackage - import path of a main package whose binary to analyze
+	MainPackage string `json:"mainPackage,omitempty" jsonschema:"Import path of a main package (e.g. example.com/app/cmd/server); only it and the module packages it imports directly or indirectly are reported. Default: every package of the module"`
+	// Top - heaviest direct imports reported per package
+	Top int `json:"top,omitempty" jsonschema:"Heaviest direct imports reported per package (default 3)"`
+}
+
+// PackageImportWeight describes the import closure of a package.
+type PackageImportWeight struct {
+	// Package - import path of the package
+	Package string `json:"package" jsonschema:"Import path of the package"`
+	// DirectImports - number of direct imports
+	DirectImports int `json:"directImports" jsonschema:"Number of direct imports"`
+	// ExternalPackages - transitive imports from other modules
+	ExternalPackages int `json:"externalPackages" jsonschema:"Packages of other modules imported directly or indirectly"`
+	// StdlibPackages - transitive standard library imports
+	StdlibPackages int `json:"stdlibPackages" jsonschema:"Standard library packages imported directly or indirectly"`
+	// TransitivePackages - all packages imported directly or indirectly
+	TransitivePackages int `json:"transitivePackages" jsonschema:"All packages imported directly or indirectly, module packages included"`
+	// TransitiveFiles - Go files of the transitive imports
+	TransitiveFiles int `json:"transitiveFiles" jsonschema:"Go files of the packages imported directly or indirectly"`
+}
+
+// ImportEdgeWeight describes what a single direct import pulls in that no other import of the package does.
+type ImportEdgeWeight struct {
+	// From - importing package
+	From string `json:"from" jsonschema:"Importing package"`
+	// Import - imported package
+	Import string `json:"import" jsonschema:"Imported package"`
+	// UniqueExternalPackages - packages of other modules reached only through this import
+	UniqueExternalPackages int `json:"uniqueExternalPackages" jsonschema:"Packages of other modules reached only through this import (the import itself included); dropping the import removes them"`
+	// UniquePackages - packages reached only through this import
+	UniquePackages int `json:"uniquePackages" jsonschema:"Packages of any kind reached only through this import, the import itself included"`
+	// UniqueFiles - Go files of the packages reached only through this import
+	UniqueFiles int `json:"uniqueFiles" jsonschema:"Go files of the packages reached only through this import"`
+}
+
+// AnalyzeImportWeightOutput contains results from the AnalyzeImportWeight tool.
+type AnalyzeImportWeightOutput struct {
+	// MainPackage - analyzed main package (only with mainPackage)
+	MainPackage string `json:"mainPackage,omitempty" jsonschema:"Analyzed main package (only with mainPackage)"`
+	// Packages - weights of the packages, heaviest first
+	Packages []PackageImportWeight `json:"packages" jsonschema:"Packages ordered by external, then all transitive packages, heaviest first"`
+	// Edges - heaviest direct imports of every package, ranked across packages
+	Edges []ImportEdgeWeight `json:"edges" jsonschema:"The top heaviest direct imports of every package, ranked across packages by unique external packages, then unique files and packages; imports reaching nothing the other imports do not are left out"`
+}
+
+// ------------------ resolve package dir ------------------
+
+// ResolvePackageDirInput contains input data for the ResolvePackageDir tool.
+type ResolvePackageDirInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Packages - import paths to resolve
+	Packages []string `json:"packages,omitempty" jsonschema:"Import paths to resolve (e.g. example.com/app/internal/store); all packages of the module when empty"`
+}
+
+// ResolvePackageDirOutput contains results from the ResolvePackageDir tool.
+type ResolvePackageDirOutput struct {
+	// RootDir - directory the paths are relative to
+	RootDir string `json:"rootDir" jsonschema:"Directory the package directories are relative to (the dir input)"`
+	// PackageDirs - import path to directory relative to RootDir
+	PackageDirs map[string]string `json:"packageDirs" jsonschema:"Import path to directory relative to rootDir in slash form ('.' for the root)"`
+	// Unresolved - requested import paths that are not packages of the module
+	Unresolved []string `json:"unresolved,omitempty" jsonschema:"Requested import paths that are not packages of the module, sorted"`
+}
+
+// ------------------ check tag consistency ------------------
+
+// CheckTagConsistencyInput contains input data for the CheckTagConsistency tool.
+type CheckTagConsistencyInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// Keys - struct tag keys to compare
+	Keys []string `json:"keys,omitempty" jsonschema:"Struct tag keys to compare field by field, at least two (default [json yaml]), e.g. [json yaml mapstructure]"`
+	// RequireAll - if true, every key is required on every field carrying one of them
+	RequireAll bool `json:"requireAll,omitempty" jsonschema:"If true, every key is required on every field carrying one of them; by default a key is required only when another field of the same struct uses it"`
+}
+
+// TagFinding describes a field whose tags disagree.
+type TagFinding struct {
+	// Field - field name (the type for embedded fields)
+	Field string `json:"field" jsonschema:"Field name; the type for embedded fields"`
+	// Line - line of the field
+	Line int `json:"line" jsonschema:"Line of the field"`
+	// Kind - name-mismatch, missing-key or skip-conflict
+	Kind string `json:"kind" jsonschema:"Finding kind: name-mismatch (names differ across keys once options are stripped), missing-key (a required key is absent) or skip-conflict (one key skips the field with '-' while another names it)"`
+	// Tags - values of the compared keys present on the field
+	Tags map[string]string `json:"tags" jsonschema:"Values of the compared keys present on the field, options included"`
+	// Message - what disagrees
+	Message string `json:"message" jsonschema:"What disagrees, e.g. 'json name \"userID\" differs from yaml name \"user_id\"'"`
+}
+
+// TagStructReport groups the findings of one struct type.
+type TagStructReport struct {
+	// Package - package declaring the struct
+	Package string `json:"package" jsonschema:"Package declaring the struct"`
+	// Struct - struct type name
+	Struct string `json:"struct" jsonschema:"Struct type name"`
+	// File - file declaring the struct
+	File string `json:"file" jsonschema:"File declaring the struct"`
+	// Line - line of the type declaration
+	Line int `json:"line" jsonschema:"Line of the type declaration"`
+	// Findings - findings in field order
+	Findings []TagFinding `json:"findings" jsonschema:"Findings in field order"`
+}
+
+// PackageTagSummary counts the findings of one package.
+type PackageTagSummary struct {
+	// Package - package import path
+	Package string `json:"package" jsonschema:"Package import path"`
+	// Count - number of findings
+	Count int `json:"count" jsonschema:"Number of findings in the package"`
+}
+
+// CheckTagConsistencyOutput contains results from the CheckTagConsistency tool.
+type CheckTagConsistencyOutput struct {
+	// Keys - compared tag keys
+	Keys []string `json:"keys" jsonschema:"Compared tag keys"`
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
+	// Structs - structs with findings
+	Structs []TagStructReport `json:"structs" jsonschema:"Structs with findings, ordered by package, file and line"`
+	// Packages - number of findings per package
+	Packages []PackageTagSummary `json:"packages" jsonschema:"Number of findings per package, sorted by package"`
+}
+
+// ------------------ suggest identifier renames ------------------
+
+// SuggestIdentifierRenamesInput contains input data for the SuggestIdentifierRenames tool.
+type SuggestIdentifierRenamesInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Package - optional package path to restrict results
+	Package string `json:"package,omitempty" jsonschema:"Optional Go package path to restrict the scan"`
+	// Rules - naming rules to check
+	Rules []string `json:"rules,omitempty" jsonschema:"Naming rules to check (default all): initialisms (GetUserId -> GetUserID), underscore (user_name -> userName), hungarian (strPrefix string -> prefix), stutter (user.UserService -> user.Service)"`
+}
+
+// IdentifierRenameSuggestion describes an identifier breaking naming rules and the name fixing it.
+type IdentifierRenameSuggestion struct {
+	// Package - package declaring the identifier
+	Package string `json:"package" jsonschema:"Package declaring the identifier"`
+	// File - file declaring the identifier
+	File string `json:"file" jsonschema:"File declaring the identifier"`
+	// Line - line of the declaration
+	Line int `json:"line" jsonschema:"Line of the declaration"`
+	// Name - current name
+	Name string `json:"name" jsonschema:"Current name"`
+	// NewName - name fixing every violated rule
+	NewName string `json:"newName" jsonschema:"Name fixing every violated rule"`
+	// Kind - symbol kind: func, var, const, type or field
+	Kind string `json:"kind" jsonschema:"Symbol kind: func, var, const, type or field"`
+	// Rules - violated rules
+	Rules []string `json:"rules" jsonschema:"Violated rules, in the order they were applied"`
+	// Reason - why the name violates them
+	Reason string `json:"reason" jsonschema:"Why the name violates the rules, one clause per rule"`
+	// Rename - renameSymbol input applying the suggestion
+	Rename *RenameSymbolInput `json:"rename,omitempty" jsonschema:"renameSymbol input applying the suggestion; absent when there is a conflict or a warning"`
+	// Conflict - why the new name cannot be applied
+	Conflict string `json:"conflict,omitempty" jsonschema:"Why the new name cannot be applied, e.g. it is already declared in the same scope"`
+	// Warning - why the rename is left to a human
+	Warning string `json:"warning,omitempty" jsonschema:"Why the rename is left to a human, e.g. a json tag repeats the field name"`
+}
+
+// SuggestIdentifierRenamesOutput contains results from the SuggestIdentifierRenames tool.
+type SuggestIdentifierRenamesOutput struct {
+	// Rules - checked rules
+	Rules []string `json:"rules" jsonschema:"Checked rules"`
+	// Total - number of suggestions ready to apply
+	Total int `json:"total" jsonschema:"Number of suggestions carrying a rename"`
+	// Suggestions - violations found
+	Suggestions []IdentifierRenameSuggestion `json:"suggestions" jsonschema:"Violations ordered by package, file and line"`
+}
+
+// ------------------ validate new file ------------------
+
+// ValidateNewFileInput contains input data for the ValidateNewFile tool.
+type ValidateNewFileInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Path - intended path of the new file, relative to dir
+	Path string `json:"path" jsonschema:"Intended path of the new file relative to dir, e.g. internal/store/cache.go"`
+	// Source - full content of the new file
+	Source string `json:"source" jsonschema:"Full Go source of the new file"`
+}
+
+// NewFileImportCycle describes an import of the new file that would close an import cycle.
+type NewFileImportCycle struct {
+	// Import - import path of the new file closing the cycle
+	Import string `json:"import" jsonschema:"Import path of the new file closing the cycle"`
+	// Cycle - packages of the cycle
+	Cycle []string `json:"cycle" jsonschema:"Packages of the cycle, starting and ending at the package of the new file"`
+}
+
+// UndefinedIdentifier describes an identifier the new file uses but nothing declares.
+type UndefinedIdentifier struct {
+	// Name - identifier
+	Name string `json:"name" jsonschema:"Identifier"`
+	// Line - line of its first use in the source
+	Line int `json:"line" jsonschema:"Line of its first use in the source"`
+	// Column - column of its first use
+	Column int `json:"column" jsonschema:"Column of its first use"`
+}
+
+// ValidateNewFileOutput contains results from the ValidateNewFile tool.
+type ValidateNewFileOutput struct {
+	// Valid - true if no problem was found
+	Valid bool `json:"valid" jsonschema:"True if no problem was found and the file can be written as is"`
+	// Problems - one line per problem
+	Problems []string `json:"problems,omitempty" jsonschema:"One line per problem found, in the order of the checks"`
+	// Path - path of the new file relative to dir
+	Path string `json:"path" jsonschema:"Path of the new file relative to dir"`
+	// Exists - true if a file already exists at the path
+	Exists bool `json:"exists,omitempty" jsonschema:"True if a file already exists at the path; it was validated as a replacement"`
+	// Package - import path of the package the file joins
+	Package string `json:"package" jsonschema:"Import path of the package the file joins"`
+	// PackageName - package name declared by the source
+	PackageName string `json:"packageName" jsonschema:"Package name declared by the source"`
+	// ExpectedPackageName - name of the package in the directory
+	ExpectedPackageName string `json:"expectedPackageName" jsonschema:"Name of the package in the directory, or the conventional name (last path element) for a new package"`
+	// NewPackage - true if the directory holds no Go files yet
+	NewPackage bool `json:"newPackage,omitempty" jsonschema:"True if the directory holds no Go files yet; any package name is then accepted"`
+	// PackageMismatch - true if the package clause does not match the directory's package
+	PackageMismatch bool `json:"packageMismatch,omitempty" jsonschema:"True if the package clause does not match the directory's package (test files may use the _test suffix)"`
+	// SyntaxErrors - parse errors with positions in the source
+	SyntaxErrors []BuildError `json:"syntaxErrors" jsonschema:"Parse errors with line and column in the source"`
+	// ImportCycles - imports that would close an import cycle
+	ImportCycles []NewFileImportCycle `json:"importCycles" jsonschema:"Imports that would close an import cycle with the module's packages"`
+	// Undefined - identifiers nothing declares
+	Undefined []UndefinedIdentifier `json:"undefined" jsonschema:"Identifiers not declared by the file, its imports, the other files of its package or the language; best-effort, syntax only"`
+	// UndefinedSkipped - true if the undefined check was skipped because of a dot import
+	UndefinedSkipped bool `json:"undefinedSkipped,omitempty" jsonschema:"True if the undefined check was skipped because of a dot import"`
+}
+
+// ------------------ analyze interface cohesion ------------------
+
+// AnalyzeInterfaceCohesionInput contains input data for the AnalyzeInterfaceCohesion tool.
+type AnalyzeInterfaceCohesionInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// InterfaceName - optional interface to analyze regardless of its size
+	InterfaceName string `json:"interfaceName,omitempty" jsonschema:"Optional interface name to analyze regardless of its method count; by default every interface with at least 4 methods"`
+}
+
+// MethodCluster is a group of methods consumers call together but never with methods of another group.
+type MethodCluster struct {
+	// Methods - methods of the cluster
+	Methods []string `json:"methods" jsonschema:"Methods of the cluster, sorted"`
+	// Consumers - functions calling them
+	Consumers []string `json:"consumers" jsonschema:"Functions calling them through the interface, as 'pkgpath.Func' or 'pkgpath.Type.Method'"`
+}
+
+// MethodSubset is a method set that several consumers use and nothing more.
+type MethodSubset struct {
+	// Methods - methods of the subset
+	Methods []string `json:"methods" jsonschema:"Methods of the subset, sorted"`
+	// Consumers - functions using only these methods
+	Consumers []string `json:"consumers" jsonschema:"Functions using only these methods of the interface"`
+	// Message - the finding in words
+	Message string `json:"message" jsonschema:"The finding in words, e.g. '5 of 7 consumers only use Load/Save; ...'"`
+}
+
+// InterfaceCohesion describes how the consumers of one interface use its methods.
+type InterfaceCohesion struct {
+	// Interface - interface name
+	Interface string `json:"interface" jsonschema:"Interface name"`
+	// Package - package declaring the interface
+	Package string `json:"package" jsonschema:"Package declaring the interface"`
+	// File - file declaring the interface
+	File string `json:"file" jsonschema:"File declaring the interface"`
+	// Line - line of the declaration
+	Line int `json:"line" jsonschema:"Line of the declaration"`
+	// Methods - methods of the interface, embedded ones included
+	Methods []string `json:"methods" jsonschema:"Methods of the interface, embedded ones included, sorted"`
+	// Consumers - number of functions calling methods through the interface
+	Consumers int `json:"consumers" jsonschema:"Number of functions calling methods through the interface"`
+	// Clusters - methods grouped by co-usage
+	Clusters []MethodCluster `json:"clusters" jsonschema:"Methods grouped by co-usage: no consumer calls methods of two clusters"`
+	// Subsets - method sets shared by several consumers
+	Subsets []MethodSubset `json:"subsets" jsonschema:"Method sets smaller than the interface that at least two consumers use and nothing more, most consumers first (at most 3)"`
+	// Unused - methods no consumer calls through the interface
+	Unused []string `json:"unused,omitempty" jsonschema:"Methods no consumer calls through the interface"`
+	// Suggestion - suggested split
+	Suggestion string `json:"suggestion,omitempty" jsonschema:"Suggested split, absent when every consumer needs the whole interface"`
+}
+
+// AnalyzeInterfaceCohesionOutput contains results from the AnalyzeInterfaceCohesion tool.
+type AnalyzeInterfaceCohesionOutput struct {
+	// Total - number of interfaces with a suggestion
+	Total int `json:"total" jsonschema:"Number of interfaces with a suggestion"`
+	// Interfaces - analyzed interfaces with consumers
+	Interfaces []InterfaceCohesion `json:"interfaces" jsonschema:"Analyzed interfaces with at least one consumer (or the named one), sorted by package and name"`
+}
+
+// ------------------ apply migrations ------------------
+
+// MigrationRule maps a deprecated function, method or variable to its replacement.
+type MigrationRule struct {
+	// FindCall - qualified name of the deprecated object
+	FindCall string `json:"findCall" jsonschema:"Qualified name of the deprecated function, variable or method: <import path>.<Name> (io/ioutil.ReadFile) or <import path>.<Type>.<Method>"`
+	// ReplaceWith - qualified name of the replacement
+	ReplaceWith string `json:"replaceWith,omitempty" jsonschema:"Qualified name of the replacement (os.ReadFile), a method name for methods; empty to only report the uses"`
+	// ArgTransform - same, dropFirst or wrapContext
+	ArgTransform string `json:"argTransform,omitempty" jsonschema:"Argument transform of calls: 'same' (default), 'dropFirst' (remove the first argument) or 'wrapContext' (pass ctx, or context.TODO() without one in scope, as the new first argument)"`
+}
+
+// ApplyMigrationsInput contains input data for the ApplyMigrations tool.
+type ApplyMigrationsInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// Preset - built-in migration spec
+	Preset string `json:"preset,omitempty" jsonschema:"Built-in migration spec to apply: 'ioutil' (io/ioutil to io and os)"`
+	// Spec - migration rules, applied after the preset and overriding its rules for the same findCall
+	Spec []MigrationRule `json:"spec,omitempty" jsonschema:"Migration rules, applied with the preset; a rule overrides the preset rule for the same findCall"`
+	// DryRun - if true, returns only a preview of changes without writing files
+	DryRun bool `json:"dryRun" jsonschema:"If true, only return a diff preview without writing files"`
+	// DiffMode - rendering of diffs: 'unified' (default), 'minimal' or 'summary'
+	DiffMode string `json:"diffMode,omitempty" jsonschema:"Rendering of diffs: 'unified' (default, 3 context lines), 'minimal' (changed lines only, prefixed with file:line) or 'summary' (per-file insertion/deletion counts and changed line numbers)"`
+	// AllowGenerated - if true, also migrate files carrying a 'Code generated ... DO NOT EDIT.' header
+	AllowGenerated bool `json:"allowGenerated,omitempty" jsonschema:"If true, also migrate files carrying a 'Code generated ... DO NOT EDIT.' header"`
+	// VerifyBuild - if true, type-check the module after writing, as the verifyBuild tool does
+	VerifyBuild bool `json:"verifyBuild,omitempty" jsonschema:"If true, reload and type-check the module after writing the files, as the verifyBuild tool does"`
+	// ExpectedHashes - content hashes by relative file path from prior reads; a mismatch refuses the change
+	ExpectedHashes map[string]string `json:"expectedHashes,omitempty" jsonschema:"contentHash by file path relative to dir, as returned by prior reads (getFunctionSource, getFileInfo, getStructInfo, navigateFile); if any listed file changed since, the call fails with CONFLICT naming the stale files and writes nothing"`
+}
+
+// MigrationSite is a use of a deprecated object.
+type MigrationSite struct {
+	// File - file of the use, relative to dir
+	File string `json:"file" jsonschema:"File of the use, relative to dir"`
+	// Line - line of the use
+	Line int `json:"line" jsonschema:"Line of the use"`
+	// Column - column of the use
+	Column int `json:"column" jsonschema:"Column of the use"`
+	// FindCall - deprecated object used
+	FindCall string `json:"findCall" jsonschema:"Deprecated object used"`
+	// ReplaceWith - replacement of the rule
+	ReplaceWith string `json:"replaceWith,omitempty" jsonschema:"Replacement of the rule"`
+	// Reason - why the use was not migrated automatically
+	Reason string `json:"reason,omitempty" jsonschema:"Why the use was not migrated automatically"`
+}
+
+// ApplyMigrationsOutput contains results from the ApplyMigrations tool.
+type ApplyMigrationsOutput struct {
+	// Rules - rules applied, preset rules first
+	Rules []MigrationRule `json:"rules" jsonschema:"Rules applied, preset rules first"`
+	// ChangedFiles - files migrated
+	ChangedFiles []string `json:"changedFiles" jsonschema:"Files migrated"`
+	// Diffs - diff of changes if dry run was used
+	Diffs []FileDiff `json:"diffs,omitempty" jsonschema:"Diff of changes if dry run was used"`
+	// TotalChanges - number of uses migrated
+	TotalChanges int `json:"totalChanges" jsonschema:"Number of uses migrated"`
+	// Migrated - uses migrated
+	Migrated []MigrationSite `json:"migrated" jsonschema:"Uses migrated"`
+	// Manual - uses left for a manual migration, with the reason
+	Manual []MigrationSite `json:"manual" jsonschema:"Uses left for a manual migration, with the reason"`
+	// SkippedGenerated - generated files with uses that were left untouched
+	SkippedGenerated []GeneratedFile `json:"skippedGenerated,omitempty" jsonschema:"Generated files with uses that were left untouched"`
+	// Build - result of the type check requested with verifyBuild
+	Build *VerifyBuildOutput `json:"build,omitempty" jsonschema:"Result of the type check requested with verifyBuild"`
+}
+
+// ------------------ update file ------------------
+
+// UpdateFileInput represents the input for the UpdateFile tool.
+type UpdateFileInput struct {
+	// Dir - module directory
+	Dir string `json:"dir" jsonschema:"Module directory"`
+	// File - changed file, relative to dir or absolute
+	File string `json:"file" jsonschema:"Changed Go file, relative to dir or absolute"`
+	// NewContent - optional new content, written atomically before the update
+	NewContent string `json:"newContent,omitempty" jsonschema:"Optional new content of the file; when set it is written atomically (audited, line endings kept) before the caches are patched. Without it the file is taken as already edited on disk"`
+	// AllowGenerated - if true, also write files carrying a 'Code generated ... DO NOT EDIT.' header
+	AllowGenerated bool `json:"allowGenerated,omitempty" jsonschema:"If true, also write newContent to files carrying a 'Code generated ... DO NOT EDIT.' header"`
+	// ExpectedHashes - content hashes by relative file path from prior reads; a mismatch refuses the write
+	ExpectedHashes map[string]string `json:"expectedHashes,omitempty" jsonschema:"contentHash by file path relative to dir, as returned by prior reads; if any listed file changed since, newContent is not written and the call fails with CONFLICT"`
+}
+
+// UpdateFileOutput contains results from the UpdateFile tool.
+type UpdateFileOutput struct {
+	// File - changed file relative to dir
+	File string `json:"file" jsonschema:"Changed file relative to dir"`
+	// Written - whether newContent was written
+	Written bool `json:"written,omitempty" jsonschema:"Whether newContent was written"`
+	// Patched - cached loads patched in place
+	Patched []string `json:"patched" jsonschema:"Cached loads patched in place, by analysis (e.g. syntaxTypesNamed+tests); queries on them need no reload"`
+	// Rechecked - packages type-checked again
+	Rechecked []string `json:"rechecked" jsonschema:"IDs of the packages type-checked again: the package of the file and its direct importers"`
+	// Dropped - cached loads left to a full reload, with the reason
+	Dropped []string `json:"dropped" jsonschema:"Cached loads that could not be patched and are reloaded by the next query, with the reason (imports changed, other files changed, ...)"`
+	// Errors - parse and type errors of the packages type-checked again
+	Errors []BuildError `json:"errors" jsonschema:"Parse and type errors of the packages type-checked again"`
+}
+
+// ------------------ analyze http surface ------------------
+
+// AnalyzeHTTPSurfaceInput represents the input for the AnalyzeHTTPSurface tool.
+type AnalyzeHTTPSurfaceInput struct {
+	// Dir - module directory
+	Dir string `json:"dir" jsonschema:"Module directory"`
+	// Frameworks - router packages whose registration calls are matched
+	Frameworks []string `json:"frameworks,omitempty" jsonschema:"Import paths of the router packages whose registration calls are matched, subpackages and major versions included (default net/http, github.com/go-chi/chi, github.com/gin-gonic/gin, github.com/gorilla/mux)"`
+}
+
+// HTTPRoute is one route of the route table.
+type HTTPRoute struct {
+	// Method - HTTP method, ANY when the registration serves every method
+	Method string `json:"method" jsonschema:"HTTP method; ANY when the registration serves every method"`
+	// Path - route path with the prefixes of enclosing groups
+	Path string `json:"path" jsonschema:"Route path including the prefixes of enclosing Route/Group/PathPrefix calls; non-constant parts appear as {expression}"`
+	// Dynamic - whether part of the path is not a constant
+	Dynamic bool `json:"dynamic,omitempty" jsonschema:"Whether part of the path is not a constant"`
+	// Handler - function serving the route
+	Handler string `json:"handler" jsonschema:"Function serving the route: pkgpath.Func, pkgpath.Type.Method, 'func literal', or pkgpath.Func() for a handler returned by a factory call"`
+	// Middlewares - middlewares applied, outermost first
+	Middlewares []string `json:"middlewares,omitempty" jsonschema:"Middlewares applied to the route as written at the registration: Use and With calls of enclosing routers, leading gin handlers and wrapping calls, outermost first"`
+	// Framework - router package matched
+	Framework string `json:"framework" jsonschema:"Router package the registration belongs to"`
+	// File - relative path of the file
+	File string `json:"file" jsonschema:"Relative path of the file"`
+	// Line - line of the registration
+	Line int `json:"line" jsonschema:"Line of the registration"`
+}
+
+// UnresolvedRoute is a registration whose handler could not be resolved.
+type UnresolvedRoute struct {
+	// Method - HTTP method
+	Method string `json:"method" jsonschema:"HTTP method"`
+	// Path - route path
+	Path string `json:"path" jsonschema:"Route path"`
+	// Registration - registration call, e.g. r.Get
+	Registration string `json:"registration" jsonschema:"Registration call, e.g. r.Get"`
+	// Reason - why the handler could not be resolved
+	Reason string `json:"reason" jsonschema:"Why the handler could not be resolved"`
+	// File - relative path of the file
+	File string `json:"file" jsonschema:"Relative path of the file"`
+	// Line - line of the registration
+	Line int `json:"line" jsonschema:"Line of the registration"`
+}
+
+// AnalyzeHTTPSurfaceOutput contains results from the AnalyzeHTTPSurface tool.
+type AnalyzeHTTPSurfaceOutput struct {
+	// Frameworks - router packages matched
+	Frameworks []string `json:"frameworks" jsonschema:"Router packages matched"`
+	// Routes - route table sorted by path and method
+	Routes []HTTPRoute `json:"routes" jsonschema:"Route table sorted by path and method"`
+	// Unresolved - registrations whose handler could not be resolved
+	Unresolved []UnresolvedRoute `json:"unresolved" jsonschema:"Registrations whose handler could not be resolved, with the reason"`
+	// Total - number of routes
+	Total int `json:"total" jsonschema:"Number of routes"`
+}
+
+// ------------------ get module info ------------------
+
+// GetModuleInfoInput contains input data for the GetModuleInfo tool.
+type GetModuleInfoInput struct {
+	// Dir - directory inside the Go module
+	Dir string `json:"dir" jsonschema:"Directory inside the Go module; the nearest go.mod at or above it is read"`
+}
+
+// ModuleRequire is a require directive of go.mod.
+type ModuleRequire struct {
+	// Path - module path
+	Path string `json:"path" jsonschema:"Module path"`
+	// Version - required version
+	Version string `json:"version" jsonschema:"Required version"`
+	// Indirect - true when marked // indirect
+	Indirect bool `json:"indirect,omitempty" jsonschema:"True when the requirement is marked // indirect"`
+}
+
+// ModuleReplace is a replace directive of go.mod.
+type ModuleReplace struct {
+	// Old - replaced module path
+	Old string `json:"old" jsonschema:"Replaced module path"`
+	// OldVersion - replaced version, empty for every version
+	OldVersion string `json:"oldVersion,omitempty" jsonschema:"Replaced version; empty when every version is replaced"`
+	// New - replacement module path or local directory
+	New string `json:"new" jsonschema:"Replacement module path or local directory"`
+	// NewVersion - replacement version, empty for a local directory
+	NewVersion string `json:"newVersion,omitempty" jsonschema:"Replacement version; empty for a local directory"`
+	// Local - true when the replacement is a local directory
+	Local bool `json:"local,omitempty" jsonschema:"True when the replacement is a local directory"`
+}
+
+// ModuleExclude is an exclude directive of go.mod.
+type ModuleExclude struct {
+	// Path - module path
+	Path string `json:"path" jsonschema:"Module path"`
+	// Version - excluded version
+	Version string `json:"version" jsonschema:"Excluded version"`
+}
+
+// ModuleRetract is a retract directive of go.mod.
+type ModuleRetract struct {
+	// Low - lowest retracted version
+	Low string `json:"low" jsonschema:"Lowest retracted version"`
+	// High - highest retracted version, equal to low for a single version
+	High string `json:"high" jsonschema:"Highest retracted version; equal to low for a single version"`
+	// Rationale - comment explaining the retraction
+	Rationale string `json:"rationale,omitempty" jsonschema:"Comment explaining the retraction"`
+}
+
+// GetModuleInfoOutput contains results from the GetModuleInfo tool.
+type GetModuleInfoOutput struct {
+	// GoMod - go.mod that was read, relative to dir
+	GoMod string `json:"goMod" jsonschema:"go.mod that was read, relative to dir"`
+	// Module - module path
+	Module string `json:"module" jsonschema:"Module path"`
+	// GoVersion - go directive
+	GoVersion string `json:"goVersion,omitempty" jsonschema:"Go directive"`
+	// Toolchain - toolchain directive
+	Toolchain string `json:"toolchain,omitempty" jsonschema:"Toolchain directive, e.g. go1.22.3"`
+	// Requires - require directives in file order
+	Requires []ModuleRequire `json:"requires" jsonschema:"Require directives in file order"`
+	// Replaces - replace directives in file order
+	Replaces []ModuleReplace `json:"replaces" jsonschema:"Replace directives in file order"`
+	// Excludes - exclude directives
+	Excludes []ModuleExclude `json:"excludes,omitempty" jsonschema:"Exclude directives"`
+	// Retracts - retract directives
+	Retracts []ModuleRetract `json:"retracts,omitempty" jsonschema:"Retract directives"`
+	// GoSumEntries - number of hash lines in go.sum
+	GoSumEntries int `json:"goSumEntries" jsonschema:"Number of hash lines in go.sum; 0 when there is no go.sum"`
+	// GoSumIncomplete - true when a required module has no go.sum entry
+	GoSumIncomplete bool `json:"goSumIncomplete,omitempty" jsonschema:"True when a required module has no go.sum entry"`
+	// MissingSums - required modules (path@version, after replacements) without a go.sum entry
+	MissingSums []string `json:"missingSums,omitempty" jsonschema:"Required modules (path@version, after replacements) without a go.sum entry; local replacements need none"`
+}
+
+// ------------------ explain type error ------------------
+
+// ExplainTypeErrorInput contains input data for the ExplainTypeError tool.
+type ExplainTypeErrorInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+	// File - file of the error, relative to dir or absolute
+	File string `json:"file" jsonschema:"File of the error, relative to dir or absolute"`
+	// Line - 1-based line of the error
+	Line int `json:"line" jsonschema:"1-based line of the error"`
+	// Column - 1-based column of the error
+	Column int `json:"column,omitempty" jsonschema:"1-based column of the error; 0 picks by message, else the first error on the line"`
+	// Message - error message, or part of it
+	Message string `json:"message,omitempty" jsonschema:"Error message, or part of it, picking among several errors on the line"`
+}
+
+// TypeErrorDefinition is a named type or function involved in a type error.
+type TypeErrorDefinition struct {
+	// Name - name, Type.Method for methods, package-qualified outside the error's package
+	Name string `json:"name" jsonschema:"Name, Type.Method for methods, package-qualified outside the error's package"`
+	// Kind - object kind
+	Kind string `json:"kind" jsonschema:"Object kind: type, func, method, var or const"`
+	// Signature - declaration of the object as go/types writes it
+	Signature string `json:"signature" jsonschema:"Declaration as go/types writes it, e.g. 'func Warm(c Celsius) bool' or 'type Celsius float64'"`
+	// Doc - doc comment
+	Doc string `json:"doc,omitempty" jsonschema:"Doc comment"`
+	// File - relative path of the declaring file
+	File string `json:"file,omitempty" jsonschema:"Relative path of the declaring file; empty for objects without source"`
+	// Line - line of the declaration
+	Line int `json:"line,omitempty" jsonschema:"Line of the declaration"`
+}
+
+// FieldMismatch is a field that differs between two struct types.
+type FieldMismatch struct {
+	// Name - field name
+	Name string `json:"name" jsonschema:"Field name"`
+	// Have - field type in the actual struct, empty when it lacks the field
+	Have string `json:"have,omitempty" jsonschema:"Field type in the actual struct; empty when it lacks the field"`
+	// Want - field type in the expected struct, empty when it has no such field
+	Want string `json:"want,omitempty" jsonschema:"Field type in the expected struct; empty when it has no such field"`
+}
+
+// ExplainTypeErrorOutput contains results from the ExplainTypeError tool.
+type ExplainTypeErrorOutput struct {
+	// Error - the type error explained
+	Error BuildError `json:"error" jsonschema:"The type error explained"`
+	// Statement - source of the enclosing statement
+	Statement string `json:"statement,omitempty" jsonschema:"Source of the enclosing statement, at most 20 lines"`
+	// StatementLine - first line of the statement
+	StatementLine int `json:"statementLine,omitempty" jsonschema:"First line of the statement"`
+	// Expression - expression the error is reported at
+	Expression string `json:"expression,omitempty" jsonschema:"Expression the error is reported at"`
+	// Actual - type of the expression
+	Actual string `json:"actual,omitempty" jsonschema:"Type of the expression"`
+	// Expected - type its context requires
+	Expected string `json:"expected,omitempty" jsonschema:"Type its context requires"`
+	// Context - where the expected type comes from
+	Context string `json:"context,omitempty" jsonschema:"Where the expected type comes from, e.g. 'argument 1 of Warm' or 'declaration of s'"`
+	// Definitions - named types and functions involved
+	Definitions []TypeErrorDefinition `json:"definitions,omitempty" jsonschema:"Named types of the actual and expected types and the called function"`
+	// Missing - interface methods the actual type lacks
+	Missing []MethodSignature `json:"missing,omitempty" jsonschema:"Interface methods the actual type lacks"`
+	// Mismatched - interface methods the actual type has with another signature
+	Mismatched []MethodMismatch `json:"mismatched,omitempty" jsonschema:"Interface methods the actual type has with another signature"`
+	// Fields - fields that differ between the actual and expected struct types
+	Fields []FieldMismatch `json:"fields,omitempty" jsonschema:"Fields that differ between the actual and expected struct types"`
+	// Hints - conversions and pointer fixes that would type-check
+	Hints []string `json:"hints,omitempty" jsonschema:"Conversions and pointer fixes that would type-check"`
+}
+
+// ------------------ analyze init dependencies ------------------
+
+// AnalyzeInitDependenciesInput contains input data for the AnalyzeInitDependencies tool.
+type AnalyzeInitDependenciesInput struct {
+	// Dir - root directory of the Go module
+	Dir string `json:"dir" jsonschema:"Root directory of the Go module"`
+}
+
+// InitDependencyFinding is a read of another package's variable during package initialization.
+type InitDependencyFinding struct {
+	// Kind - read-before-write or initializer-read
+	Kind string `json:"kind" jsonschema:"read-before-write (the variable is written during the initialization of a package initialized later) or initializer-read (a package-level var initializer reads another package's variable)"`
+	// Var - variables whose initializer reads the symbol, or 'init'
+	Var string `json:"var" jsonschema:"Variables whose initializer reads the symbol, or 'init' for an init function"`
+	// Package - package of the reading code
+	Package string `json:"package" jsonschema:"Import path of the package of the reading code"`
+	// Position - initialization position of Package
+	Position int `json:"position" jsonschema:"1-based position of package in the initialization order"`
+	// File - file of the read
+	File string `json:"file" jsonschema:"File of the read"`
+	// Line - line of the read
+	Line int `json:"line" jsonschema:"Line of the read"`
+	// Symbol - foreign variable read, as pkg.Name
+	Symbol string `json:"symbol" jsonschema:"Variable of another package read, as pkg.Name"`
+	// SymbolPackage - package declaring Symbol
+	SymbolPackage string `json:"symbolPackage" jsonschema:"Import path of the package declaring symbol"`
+	// SymbolPosition - initialization position of SymbolPackage
+	SymbolPosition int `json:"symbolPosition" jsonschema:"1-based position of symbolPackage in the initialization order"`
+	// Writer - package writing Symbol during its initialization (read-before-write only)
+	Writer string `json:"writer,omitempty" jsonschema:"Import path of the package that writes symbol during its initialization, after the read (read-before-write only)"`
+	// WriterPosition - initialization position of Writer
+	WriterPosition int `json:"writerPosition,omitempty" jsonschema:"1-based position of writer in the initialization order"`
+	// WriterFile - file of the write
+	WriterFile string `json:"writerFile,omitempty" jsonschema:"File of the first write by writer"`
+	// WriterLine - line of the write
+	WriterLine int `json:"writerLine,omitempty" jsonschema:"Line of the first write by writer"`
+	// WriterVia - function of the symbol's package whose call makes the write
+	WriterVia string `json:"writerVia,omitempty" jsonschema:"Function of symbolPackage whose call makes the write (e.g. registry.Register), empty for a direct assignment"`
+	// Message - human-readable description
+	Message string `json:"message" jsonschema:"Human-readable description of the finding"`
+}
+
+// AnalyzeInitDependenciesOutput contains results from the AnalyzeInitDependencies tool.
+type AnalyzeInitDependenciesOutput struct {
+	// Order - module packages in initialization order
+	Order []string `json:"order" jsonschema:"Import paths of the module packages in initialization order (positions are 1-based indexes into it)"`
+	// Findings - reads of foreign package state during initialization, hazards first
+	Findings []InitDependencyFinding `json:"findings" jsonschema:"Reads of other packages' variables during initialization, read-before-write hazards first, then by package position, file and line"`
+	// Total - number of findings
+	Total int `json:"total" jsonschema:"Number of findings"`
 }
@@ -0,0 +1,288 @@
+package tools
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// untestedKinds lists the symbol kinds accepted by FindUntestedSymbols.
+var untestedKinds = map[string]struct{}{
+	"func":  {},
+	"type":  {},
+	"var":   {},
+	"const": {},
+}
+
+// FindUntestedSymbols reports exported symbols that are never referenced from any _test.go file in the module.
+// It is a static proxy for test coverage that does not require running the tests.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional package filter and symbol kinds
+//
+// Returns:
+//   - MCP tool call result
+//   - untested exported symbols grouped by package with per-package tested ratios
+//   - error if the kinds are invalid or an error occurred while loading packages
+func FindUntestedSymbols(ctx context.Context, _ *mcp.CallToolRequest, input FindUntestedSymbolsInput) (
+	*mcp.CallToolResult,
+	FindUntestedSymbolsOutput,
+	error,
+) {
+	start := logStart("FindUntestedSymbols", logFields(
+		input.Dir,
+		newLogField("package", input.Package),
+	))
+	out := FindUntestedSymbolsOutput{}
+
+	defer func() { logEnd("FindUntestedSymbols", start, out.Total) }()
+
+	kinds := make(map[string]struct{})
+	for _, kind := range input.Kinds {
+		if _, ok := untestedKinds[kind]; !ok {
+			return fail(out, invalidInput("invalid kind %q: expected func, type, var or const", kind))
+		}
+
+		kinds[kind] = struct{}{}
+	}
+
+	if len(kinds) == 0 {
+		kinds["func"] = struct{}{}
+	}
+
+	mode := loadModeSyntaxTypesNamed
+
+	pkgs, err := loadPackagesWithCacheIncludeTests(ctx, input.Dir, mode)
+	if err != nil {
+		logError("FindUntestedSymbols", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	// Test variants recompile the package, so references are matched by key rather than by object identity.
+	tested := make(map[string]struct{})
+
+	if err := walkPackageFiles(ctx, pkgs, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if !strings.HasSuffix(relPath, "_test.go") {
+			return nil
+		}
+
+		// Only functions, methods and package-level objects can be reported; a field or local sharing the
+		// name of one does not test it.
+		ast.Inspect(file, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				obj := pkg.TypesInfo.Uses[ident]
+				if obj == nil || obj.Pkg() == nil {
+					return true
+				}
+
+				if _, isFunc := obj.(*types.Func); isFunc || obj.Parent() == obj.Pkg().Scope() {
+					tested[testReferenceKey(obj)] = struct{}{}
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	// Definitions come from the plain package variants only; their IDs equal their package paths.
+	var plain []*packages.Package
+
+	for _, pkg := range pkgs {
+		if pkg.ID == pkg.PkgPath && (pkg.Name != "main" || input.IncludeMain) {
+			plain = append(plain, pkg)
+		}
+	}
+
+	filtered, err := filterPackagesByRequest(plain, input.Package)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	groups := make(map[string]*UntestedPackageGroup)
+
+	if err := walkPackageFiles(ctx, filtered, input.Dir, func(pkg *packages.Package, file *ast.File, relPath string, _ int) error {
+		if ast.IsGenerated(file) && !input.IncludeGenerated {
+			return nil
+		}
+
+		group, ok := groups[pkg.PkgPath]
+		if !ok {
+			group = &UntestedPackageGroup{Package: pkg.PkgPath}
+			groups[pkg.PkgPath] = group
+		}
+
+		for _, ident := range declaredIdents(file) {
+			obj := pkg.TypesInfo.Defs[ident]
+
+			kind, ok := untestedCandidateKind(obj)
+			if !ok {
+				continue
+			}
+
+			if _, ok := kinds[kind]; !ok {
+				continue
+			}
+
+			group.Total++
+
+			if _, ok := tested[testReferenceKey(obj)]; ok {
+				group.Tested++
+
+				continue
+			}
+
+			group.Symbols = append(group.Symbols, UntestedSymbol{
+				Name: untestedSymbolName(obj),
+				Kind: kind,
+				File: relPath,
+				Line: pkg.Fset.Position(ident.Pos()).Line,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return fail(out, err)
+	}
+
+	out.Packages = sortUntestedGroups(groups)
+	for _, group := range out.Packages {
+		out.Total += len(group.Symbols)
+	}
+
+	return nil, out, nil
+}
+
+// declaredIdents returns the names declared by top-level declarations of a file, including methods.
+func declaredIdents(file *ast.File) []*ast.Ident {
+	var idents []*ast.Ident
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			idents = append(idents, d.Name)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					idents = append(idents, s.Name)
+				case *ast.ValueSpec:
+					idents = append(idents, s.Names...)
+				}
+			}
+		}
+	}
+
+	return idents
+}
+
+// untestedCandidateKind returns the kind of an exported top-level symbol or method.
+func untestedCandidateKind(obj types.Object) (string, bool) {
+	if obj == nil || !obj.Exported() {
+		return "", false
+	}
+
+	switch o := obj.(type) {
+	case *types.Func:
+		// Methods count only when their receiver type is exported as well.
+		if o.Signature().Recv() != nil {
+			named := receiverNamed(o)
+			if named == nil || !named.Obj().Exported() {
+				return "", false
+			}
+		}
+
+		return "func", true
+	case *types.TypeName:
+		return "type", true
+	case *types.Var:
+		return "var", true
+	case *types.Const:
+		return "const", true
+	}
+
+	return "", false
+}
+
+// testReferenceKey identifies an object across package variants; methods include their receiver.
+func testReferenceKey(obj types.Object) string {
+	if fn, ok := obj.(*types.Func); ok {
+		return funcKey(fn)
+	}
+
+	return objectKey(obj)
+}
+
+// untestedSymbolName returns "Type.Method" for methods and the plain name otherwise.
+func untestedSymbolName(obj types.Object) string {
+	if fn, ok := obj.(*types.Func); ok {
+		if named := receiverNamed(fn); named != nil {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+	}
+
+	return obj.Name()
+}
+
+// receiverNamed returns the named receiver type of a method (T for both T and *T), or nil for functions.
+func receiverNamed(fn *types.Func) *types.Named {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return nil
+	}
+
+	t := recv.Type()
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	return namedTypeOf(t)
+}
+
+// sortUntestedGroups orders packages by tested ratio (least tested first) and symbols by file and line.
+func sortUntestedGroups(groups map[string]*UntestedPackageGroup) []UntestedPackageGroup {
+	result := make([]UntestedPackageGroup, 0, len(groups))
+
+	for _, group := range groups {
+		if group.Total == 0 {
+			continue
+		}
+
+		group.Ratio = float64(group.Tested) / float64(group.Total)
+
+		sort.Slice(group.Symbols, func(i, j int) bool {
+			if group.Symbols[i].File != group.Symbols[j].File {
+				return group.Symbols[i].File < group.Symbols[j].File
+			}
+
+			return group.Symbols[i].Line < group.Symbols[j].Line
+		})
+
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Ratio != result[j].Ratio {
+			return result[i].Ratio < result[j].Ratio
+		}
+
+		return result[i].Package < result[j].Package
+	})
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,143 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func TestFindUntestedSymbols(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindUntestedSymbolsInput{Dir: testDir(), Package: "sample"}
+
+	_, out, err := tools.FindUntestedSymbols(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("FindUntestedSymbols error: %v", err)
+	}
+
+	if len(out.Packages) != 1 {
+		t.Fatalf("expected only the sample package, got %+v", out.Packages)
+	}
+
+	group := out.Packages[0]
+	untested := make(map[string]string)
+
+	for _, sym := range group.Symbols {
+		untested[sym.Name] = sym.Kind
+	}
+
+	if _, ok := untested["Foo.DoSomething"]; ok {
+		t.Errorf("Foo.DoSomething is called from foo_test.go and must not be reported")
+	}
+
+	if untested["NewPoint"] != "func" {
+		t.Errorf("expected NewPoint to be reported as untested func, got %+v", group.Symbols)
+	}
+
+	if _, ok := untested["Point"]; ok {
+		t.Errorf("types must not be reported with the default kinds")
+	}
+
+	if group.Tested == 0 || group.Tested+len(group.Symbols) != group.Total {
+		t.Errorf("inconsistent counts: %+v", group)
+	}
+
+	if want := float64(group.Tested) / float64(group.Total); group.Ratio != want {
+		t.Errorf("expected ratio %v, got %v", want, group.Ratio)
+	}
+}
+
+func TestFindUntestedSymbols_Types(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindUntestedSymbolsInput{Dir: testDir(), Package: "sample", Kinds: []string{"type"}}
+
+	_, out, err := tools.FindUntestedSymbols(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("FindUntestedSymbols error: %v", err)
+	}
+
+	for _, group := range out.Packages {
+		for _, sym := range group.Symbols {
+			if sym.Kind != "type" {
+				t.Errorf("expected only types, got %+v", sym)
+			}
+
+			if sym.Name == "Foo" {
+				t.Errorf("Foo is referenced from foo_test.go and must not be reported")
+			}
+		}
+	}
+}
+
+func TestFindUntestedSymbols_FieldsAndLocalsSharingNames(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"config/config.go": `package config
+
+type Config struct{ Timeout int }
+
+func Timeout() int { return 30 }
+
+var Name = "config"
+
+func Load() Config { return Config{Timeout: Timeout()} }
+`,
+		"config/config_test.go": `package config
+
+func check() int {
+	cfg := Load()
+	Name := "local"
+
+	return cfg.Timeout + len(Name)
+}
+`,
+	})
+
+	in := tools.FindUntestedSymbolsInput{Dir: dir, Kinds: []string{"func", "var"}}
+
+	_, out, err := tools.FindUntestedSymbols(context.Background(), &mcp.CallToolRequest{}, in)
+	if err != nil {
+		t.Fatalf("FindUntestedSymbols error: %v", err)
+	}
+
+	untested := make(map[string]bool)
+
+	for _, group := range out.Packages {
+		for _, sym := range group.Symbols {
+			untested[sym.Name] = true
+		}
+	}
+
+	// The field cfg.Timeout and the local Name do not test the package-level Timeout and Name.
+	if !untested["Timeout"] || !untested["Name"] || untested["Load"] {
+		t.Errorf("expected Timeout and Name untested and Load tested, got %+v", out.Packages)
+	}
+}
+
+func TestFindUntestedSymbols_InvalidKind(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindUntestedSymbolsInput{Dir: testDir(), Kinds: []string{"macro"}}
+
+	_, _, err := tools.FindUntestedSymbols(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Error("expected error for invalid kind")
+	}
+}
+
+func TestFindUntestedSymbols_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.FindUntestedSymbolsInput{Dir: "/nonexistent/directory"}
+
+	_, _, err := tools.FindUntestedSymbols(context.Background(), &mcp.CallToolRequest{}, in)
+	if err == nil {
+		t.Error("expected error for invalid directory")
+	}
+}
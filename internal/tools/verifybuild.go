@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of the errors reported by verifyBuild, after packages.ErrorKind.
+const (
+	buildErrorList    = "list"
+	buildErrorParse   = "parse"
+	buildErrorType    = "type"
+	buildErrorUnknown = "unknown"
+)
+
+// VerifyBuild reloads packages from disk, bypassing the package cache, and reports whether they still
+// parse and type-check, test files included. It is the cheap authoritative check after a chain of
+// mutations.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory and optional packages to check
+//
+// Returns:
+//   - MCP tool call result
+//   - ok flag, errors with positions and the time the check took
+//   - error if the go command itself fails
+func VerifyBuild(ctx context.Context, _ *mcp.CallToolRequest, input VerifyBuildInput) (
+	*mcp.CallToolResult,
+	VerifyBuildOutput,
+	error,
+) {
+	start := logStart("VerifyBuild", logFields(
+		input.Dir,
+		newLogField("packages", strings.Join(input.Packages, ",")),
+	))
+	out := VerifyBuildOutput{}
+
+	defer func() { logEnd("VerifyBuild", start, len(out.Errors)) }()
+
+	out, err := verifyBuild(ctx, input.Dir, input.Packages, nil)
+	if err != nil {
+		logError("VerifyBuild", err, "failed to load packages")
+
+		return fail(out, err)
+	}
+
+	return nil, out, nil
+}
+
+// verifyBuild type-checks the packages matching patterns, the whole module when there are none, with a
+// fresh load: the cache may still hold packages loaded before the last write and must never turn a
+// broken module into a reported success. overlay replaces the content of the files it maps by absolute
+// path, so unwritten edits can be checked.
+func verifyBuild(ctx context.Context, dir string, patterns []string, overlay map[string][]byte) (VerifyBuildOutput, error) {
+	began := time.Now()
+	out := VerifyBuildOutput{}
+
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, _, err := loadPackagesUncached(ctx, dir, loadModeSyntaxTypesNamedFiles, true, overlay, patterns...)
+	if err != nil {
+		return out, err
+	}
+
+	// Test variants repeat the errors of the package's own files.
+	seen := make(map[BuildError]struct{})
+	checked := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+
+		checked[pkg.PkgPath] = struct{}{}
+
+		for _, e := range pkg.Errors {
+			be := newBuildError(dir, pkg, e)
+			if _, ok := seen[be]; ok {
+				continue
+			}
+
+			seen[be] = struct{}{}
+			out.Errors = append(out.Errors, be)
+		}
+	}
+
+	out.Errors = dropRestatedListErrors(dir, out.Errors)
+
+	sort.SliceStable(out.Errors, func(i, j int) bool {
+		a, b := out.Errors[i], out.Errors[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Column < b.Column
+	})
+
+	out.OK = len(out.Errors) == 0
+	out.Packages = len(checked)
+	out.ElapsedMs = time.Since(began).Milliseconds()
+
+	return out, nil
+}
+
+// verifyAfterMutation runs verifyBuild over the module once a mutating tool has written its files, if
+// the caller asked for it. The files stay written either way, so a failed check is reported in the
+// result rather than as a tool error.
+func verifyAfterMutation(ctx context.Context, tool, dir string, requested bool) *VerifyBuildOutput {
+	if !requested {
+		return nil
+	}
+
+	out, err := verifyBuild(ctx, dir, nil, nil)
+	if err != nil {
+		logError(tool, err, "failed to verify the build")
+
+		out.LoadError = err.Error()
+	}
+
+	return &out
+}
+
+// dropRestatedListErrors drops the positionless list errors of go list -export ("# pkg" followed by
+// "file:line:column: message" lines) whose every line restates a positioned error of the same package.
+// The compiler words messages differently from go/types, so the file and line are compared.
+func dropRestatedListErrors(dir string, errs []BuildError) []BuildError {
+	positioned := make(map[string]struct{})
+
+	for _, be := range errs {
+		if be.File != "" {
+			positioned[be.Package+"\x00"+be.File+":"+strconv.Itoa(be.Line)] = struct{}{}
+		}
+	}
+
+	return slices.DeleteFunc(errs, func(be BuildError) bool {
+		header, body, ok := strings.Cut(be.Message, "\n")
+		if be.Kind != buildErrorList || be.File != "" || !ok || !strings.HasPrefix(header, "# ") {
+			return false
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+			pos, _, ok := strings.Cut(strings.TrimSpace(line), ": ")
+			if !ok {
+				return false
+			}
+
+			restated := newBuildError(dir, &packages.Package{PkgPath: be.Package}, packages.Error{Pos: listErrorPath(dir, pos)})
+			if _, ok := positioned[be.Package+"\x00"+restated.File+":"+strconv.Itoa(restated.Line)]; !ok || restated.Line == 0 {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// listErrorPath resolves a "file:line:column" position of go list output, whose file is relative to dir.
+func listErrorPath(dir, pos string) string {
+	if filepath.IsAbs(pos) {
+		return pos
+	}
+
+	return filepath.Join(dir, pos)
+}
+
+// newBuildError converts a package error, whose position is "file:line:column", "file:line" or empty.
+func newBuildError(dir string, pkg *packages.Package, e packages.Error) BuildError {
+	be := BuildError{Package: pkg.PkgPath, Message: e.Msg}
+
+	switch e.Kind {
+	case packages.ListError:
+		be.Kind = buildErrorList
+	case packages.ParseError:
+		be.Kind = buildErrorParse
+	case packages.TypeError:
+		be.Kind = buildErrorType
+	default:
+		be.Kind = buildErrorUnknown
+	}
+
+	pos := e.Pos
+	if pos == "" || pos == "-" {
+		return be
+	}
+
+	var nums []int
+
+	for range 2 {
+		i := strings.LastIndexByte(pos, ':')
+		if i < 0 {
+			break
+		}
+
+		n, err := strconv.Atoi(pos[i+1:])
+		if err != nil {
+			break
+		}
+
+		nums = append([]int{n}, nums...)
+		pos = pos[:i]
+	}
+
+	be.File = relativePath(dir, pos)
+
+	if len(nums) > 0 {
+		be.Line = nums[0]
+	}
+
+	if len(nums) > 1 {
+		be.Column = nums[1]
+	}
+
+	return be
+}
@@ -0,0 +1,35 @@
+package tools
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDropRestatedListErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	typeErr := BuildError{Package: "lang", Kind: buildErrorType, File: "double_test.go", Line: 5, Column: 44, Message: "cannot use \"one\""}
+	parseErr := BuildError{Package: "lang/sub", Kind: buildErrorParse, File: "sub/sub.go", Line: 3, Column: 14, Message: "expected ')', found '{'"}
+
+	errs := []BuildError{
+		// Restates the type error, in the compiler's words.
+		{Package: "lang", Kind: buildErrorList, Message: "# lang [lang.test]\n./double_test.go:5:44: cannot use \"one\" (untyped string constant) as int value"},
+		typeErr,
+		{Package: "lang/sub", Kind: buildErrorList, Message: "# lang/sub\nsub/sub.go:3:14: syntax error: unexpected {, expected )"},
+		parseErr,
+		// A line without a positioned counterpart keeps the list error.
+		{Package: "lang/sub", Kind: buildErrorList, Message: "# lang/sub\nsub/sub.go:3:14: syntax error\nsub/sub.go:9:1: missing return"},
+		// The same position in another package is not a restatement.
+		{Package: "lang/other", Kind: buildErrorList, Message: "# lang/other\n./double_test.go:5:44: cannot use"},
+		// A list error without a "# pkg" body stays.
+		{Package: "lang", Kind: buildErrorList, Message: "no Go files in lang/empty"},
+	}
+
+	want := []BuildError{typeErr, parseErr, errs[4], errs[5], errs[6]}
+
+	got := dropRestatedListErrors(dir, errs)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("errors:\n%v\nwant:\n%v", got, want)
+	}
+}
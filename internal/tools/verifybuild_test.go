@@ -0,0 +1,109 @@
+package tools_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const verifySource = `package lang
+
+// Double returns twice n.
+func Double(n int) int {
+	return n * 2
+}
+`
+
+func TestVerifyBuild_ReportsErrorsAfterCachedLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"double.go":      verifySource,
+		"double_test.go": "package lang\n\nimport \"testing\"\n\nfunc TestDouble(t *testing.T) { _ = Double(1) }\n",
+	})
+
+	_, out, err := tools.VerifyBuild(context.Background(), &mcp.CallToolRequest{}, tools.VerifyBuildInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("VerifyBuild error: %v", err)
+	}
+
+	if !out.OK || out.Packages != 1 || len(out.Errors) != 0 {
+		t.Fatalf("expected a clean build, got %+v", out)
+	}
+
+	// Warm the package cache, then break a test file without telling it.
+	if _, _, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{},
+		tools.ListSymbolsInput{Dir: dir, Package: "lang"}); err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	broken := "package lang\n\nimport \"testing\"\n\nfunc TestDouble(t *testing.T) { _ = Double(\"one\") }\n"
+	if err := os.WriteFile(filepath.Join(dir, "double_test.go"), []byte(broken), 0o644); err != nil {
+		t.Fatalf("write double_test.go: %v", err)
+	}
+
+	_, out, err = tools.VerifyBuild(context.Background(), &mcp.CallToolRequest{}, tools.VerifyBuildInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("VerifyBuild error: %v", err)
+	}
+
+	if out.OK || len(out.Errors) != 1 {
+		t.Fatalf("expected one error, got %+v", out)
+	}
+
+	got := out.Errors[0]
+	if got.Kind != "type" || got.File != "double_test.go" || got.Line != 5 || got.Column != 44 || got.Package != "lang" {
+		t.Fatalf("unexpected error: %+v", got)
+	}
+}
+
+func TestVerifyBuild_PackagesAndParseErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"double.go": verifySource})
+
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("mkdir sub: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(sub, "sub.go"), []byte("package sub\n\nfunc Broken( {\n"), 0o644); err != nil {
+		t.Fatalf("write sub.go: %v", err)
+	}
+
+	_, out, err := tools.VerifyBuild(context.Background(), &mcp.CallToolRequest{},
+		tools.VerifyBuildInput{Dir: dir, Packages: []string{"."}})
+	if err != nil || !out.OK || out.Packages != 1 {
+		t.Fatalf("expected the root package to build, got %+v (err %v)", out, err)
+	}
+
+	_, out, err = tools.VerifyBuild(context.Background(), &mcp.CallToolRequest{}, tools.VerifyBuildInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("VerifyBuild error: %v", err)
+	}
+
+	if out.OK || len(out.Errors) == 0 || out.Errors[0].Kind != "parse" || out.Errors[0].File != "sub/sub.go" {
+		t.Fatalf("expected a parse error in sub/sub.go, got %+v", out)
+	}
+}
+
+func TestRenameSymbol_VerifyBuild(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"double.go": verifySource})
+
+	_, out, err := tools.RenameSymbol(context.Background(), &mcp.CallToolRequest{}, tools.RenameSymbolInput{
+		Dir: dir, OldName: "Double", NewName: "Twice", Kind: "func", VerifyBuild: true,
+	})
+	if err != nil {
+		t.Fatalf("RenameSymbol error: %v", err)
+	}
+
+	if out.Build == nil || !out.Build.OK {
+		t.Fatalf("expected a verified build after the rename, got %+v", out.Build)
+	}
+}
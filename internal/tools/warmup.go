@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Preload states reported by getServerStatus.
+const (
+	preloadRunning = "running"
+	preloadReady   = "ready"
+	preloadFailed  = "failed"
+)
+
+// preloadState holds the status of the --preload-dir warm-up.
+var preloadState = struct {
+	sync.Mutex
+
+	status *PreloadStatus
+}{}
+
+// Warmup loads a module with the strongest load mode, pins the result in the package cache and
+// reports timing and counts, so that later tool calls do not pay the packages.Load cost.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request
+//   - input: input data specifying the directory, optional packages to verify and whether to load tests
+//
+// Returns:
+//   - MCP tool call result
+//   - load timing, package and file counts
+//   - error if loading fails or a requested package is not found
+func Warmup(ctx context.Context, _ *mcp.CallToolRequest, input WarmupInput) (
+	*mcp.CallToolResult,
+	WarmupOutput,
+	error,
+) {
+	start := logStart("Warmup", logFields(
+		input.Dir,
+		newLogField("includeTests", strconv.FormatBool(input.IncludeTests)),
+	))
+	out := WarmupOutput{}
+
+	defer func() { logEnd("Warmup", start, out.Packages) }()
+
+	out, err := warmup(ctx, input.Dir, input.Packages, input.IncludeTests)
+	if err != nil {
+		logError("Warmup", err, "failed to warm up packages")
+
+		return fail(out, err)
+	}
+
+	return nil, out, nil
+}
+
+// Preload warms dir like the warmup tool and records the outcome for getServerStatus.
+// It is used by the --preload-dir flag before the server starts serving requests.
+//
+// Parameters:
+//   - ctx: execution context
+//   - dir: module directory to load
+//
+// Returns:
+//   - error if loading failed
+func Preload(ctx context.Context, dir string) error {
+	setPreloadStatus(PreloadStatus{Dir: dir, State: preloadRunning})
+
+	out, err := warmup(ctx, dir, nil, false)
+
+	status := PreloadStatus{Dir: dir, State: preloadReady, Packages: out.Packages, DurationMs: out.DurationMs}
+	if err != nil {
+		status.State = preloadFailed
+		status.Error = err.Error()
+	}
+
+	setPreloadStatus(status)
+
+	return err
+}
+
+// warmup loads dir with loadModeAll (and its test variants if requested) and pins the cache entries.
+func warmup(ctx context.Context, dir string, requested []string, includeTests bool) (WarmupOutput, error) {
+	began := time.Now()
+	out := WarmupOutput{Mode: loadModeName(loadModeAll), Cached: true}
+
+	variants := []bool{false}
+	if includeTests {
+		variants = append(variants, true)
+	}
+
+	for _, tests := range variants {
+		if len(cacheKeysFor(dir, loadModeAll, tests, false)) == 0 {
+			out.Cached = false
+		}
+
+		pkgs, err := loadPackagesWithCacheInternal(ctx, dir, loadModeAll, tests)
+		if err != nil {
+			return out, err
+		}
+
+		pinCacheEntry(makeCacheKey(dir, loadModeAll, tests, false))
+
+		if tests {
+			out.TestPackages = len(pkgs)
+
+			continue
+		}
+
+		for _, name := range requested {
+			if _, err := filterPackagesByRequest(pkgs, name); err != nil {
+				return out, err
+			}
+		}
+
+		out.Packages = len(pkgs)
+		for _, pkg := range pkgs {
+			out.Files += len(pkg.CompiledGoFiles)
+		}
+	}
+
+	out.DurationMs = time.Since(began).Milliseconds()
+
+	return out, nil
+}
+
+// pinCacheEntry exempts a package cache entry from age-based cleanup.
+func pinCacheEntry(cacheKey string) {
+	packageCache.Lock()
+	defer packageCache.Unlock()
+
+	if item, ok := packageCache.pkgs[cacheKey]; ok {
+		item.Pinned = true
+		packageCache.pkgs[cacheKey] = item
+	}
+}
+
+// setPreloadStatus replaces the recorded preload status.
+func setPreloadStatus(status PreloadStatus) {
+	preloadState.Lock()
+	defer preloadState.Unlock()
+
+	preloadState.status = &status
+}
+
+// currentPreloadStatus returns a copy of the preload status, or nil if no preload was requested.
+func currentPreloadStatus() *PreloadStatus {
+	preloadState.Lock()
+	defer preloadState.Unlock()
+
+	if preloadState.status == nil {
+		return nil
+	}
+
+	status := *preloadState.status
+
+	return &status
+}
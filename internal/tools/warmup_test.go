@@ -0,0 +1,107 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-navigator/internal/tools"
+)
+
+func cacheLoadFor(t *testing.T, dir string) tools.CacheLoad {
+	t.Helper()
+
+	_, status, err := tools.GetServerStatus(context.Background(), &mcp.CallToolRequest{}, tools.GetServerStatusInput{})
+	if err != nil {
+		t.Fatalf("GetServerStatus error: %v", err)
+	}
+
+	for _, load := range status.CacheStats.Loads {
+		if load.Dir == dir && load.Mode == "all" {
+			return load
+		}
+	}
+
+	t.Fatalf("no warmed load for %s in %+v", dir, status.CacheStats.Loads)
+
+	return tools.CacheLoad{}
+}
+
+func TestWarmup_ListSymbolsHitsCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy testdata: %v", err)
+	}
+
+	_, out, err := tools.Warmup(context.Background(), &mcp.CallToolRequest{}, tools.WarmupInput{
+		Dir:      dir,
+		Packages: []string{"sample"},
+	})
+	if err != nil {
+		t.Fatalf("Warmup error: %v", err)
+	}
+
+	if out.Cached || out.Packages == 0 || out.Files == 0 {
+		t.Errorf("expected a cold load with packages and files, got %+v", out)
+	}
+
+	before := cacheLoadFor(t, dir)
+	if !before.Pinned {
+		t.Errorf("expected warmed load to be pinned, got %+v", before)
+	}
+
+	in := tools.ListSymbolsInput{Dir: dir, Package: "sample"}
+	if _, _, err := tools.ListSymbols(context.Background(), &mcp.CallToolRequest{}, in); err != nil {
+		t.Fatalf("ListSymbols error: %v", err)
+	}
+
+	if after := cacheLoadFor(t, dir); after.Hits != before.Hits+1 {
+		t.Errorf("expected listSymbols to hit the warmed load (%d hits before, %d after)", before.Hits, after.Hits)
+	}
+
+	_, again, err := tools.Warmup(context.Background(), &mcp.CallToolRequest{}, tools.WarmupInput{Dir: dir})
+	if err != nil || !again.Cached {
+		t.Errorf("expected second warmup to be cached, got %+v, %v", again, err)
+	}
+}
+
+func TestWarmup_UnknownPackage(t *testing.T) {
+	t.Parallel()
+
+	in := tools.WarmupInput{Dir: testDir(), Packages: []string{"example.com/nosuchpackage"}}
+	if _, _, err := tools.Warmup(context.Background(), &mcp.CallToolRequest{}, in); err == nil {
+		t.Fatalf("expected error for unknown package")
+	}
+}
+
+func TestWarmup_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	in := tools.WarmupInput{Dir: "/nonexistent/directory"}
+	if _, _, err := tools.Warmup(context.Background(), &mcp.CallToolRequest{}, in); err == nil {
+		t.Fatalf("expected error for non-existent directory")
+	}
+}
+
+func TestPreload_ReportedByServerStatus(t *testing.T) {
+	dir := t.TempDir()
+	if err := copyDir(testDir(), dir); err != nil {
+		t.Fatalf("copy testdata: %v", err)
+	}
+
+	if err := tools.Preload(context.Background(), dir); err != nil {
+		t.Fatalf("Preload error: %v", err)
+	}
+
+	_, status, err := tools.GetServerStatus(context.Background(), &mcp.CallToolRequest{}, tools.GetServerStatusInput{})
+	if err != nil {
+		t.Fatalf("GetServerStatus error: %v", err)
+	}
+
+	if status.Preload == nil || status.Preload.Dir != dir || status.Preload.State != "ready" || status.Preload.Packages == 0 {
+		t.Errorf("expected ready preload for %s, got %+v", dir, status.Preload)
+	}
+}
@@ -0,0 +1,271 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// watchDebounce is the minimum interval between two change notifications sent to one client.
+const watchDebounce = time.Second
+
+// watchLogger is the logger name used for change notifications.
+const watchLogger = "go-navigator.watch"
+
+// pendingChange accumulates changed files and invalidated analyses for one watched directory.
+type pendingChange struct {
+	files       map[string]struct{}
+	invalidated map[string]struct{}
+}
+
+// watchSubscription holds the watched directories and pending changes of one client session.
+type watchSubscription struct {
+	session *mcp.ServerSession
+	dirs    map[string]struct{}
+	pending map[string]*pendingChange
+	timer   *time.Timer
+}
+
+var projectWatches = struct {
+	sync.Mutex
+
+	subs map[*mcp.ServerSession]*watchSubscription
+}{subs: make(map[*mcp.ServerSession]*watchSubscription)}
+
+// WatchProject subscribes the calling client to change notifications for a project directory.
+// Changes are delivered as MCP logging notifications, at most one per second.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request carrying the client session
+//   - input: input data specifying the directory to watch
+//
+// Returns:
+//   - MCP tool call result
+//   - the watched directory and all directories watched by the client
+//   - error if the directory cannot be loaded or the request has no client session
+func WatchProject(ctx context.Context, req *mcp.CallToolRequest, input WatchProjectInput) (
+	*mcp.CallToolResult,
+	WatchProjectOutput,
+	error,
+) {
+	start := logStart("WatchProject", logFields(input.Dir))
+	out := WatchProjectOutput{}
+
+	defer func() { logEnd("WatchProject", start, len(out.Watching)) }()
+
+	dir, err := filepath.Abs(input.Dir)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	// Loading registers every package directory with the file watcher.
+	if _, err := loadPackagesWithCache(ctx, dir, loadModeBasic); err != nil {
+		return fail(out, err)
+	}
+
+	if req == nil || req.Session == nil {
+		return fail(out, errors.New("watchProject requires an active client session"))
+	}
+
+	out.Dir = dir
+	out.Watching = addProjectWatch(req.Session, dir)
+
+	return nil, out, nil
+}
+
+// UnwatchProject removes the calling client's change subscription for a project directory.
+//
+// Parameters:
+//   - ctx: execution context
+//   - req: MCP tool request carrying the client session
+//   - input: input data specifying the directory to stop watching
+//
+// Returns:
+//   - MCP tool call result
+//   - whether a subscription was removed and the remaining watched directories
+//   - error if the request has no client session
+func UnwatchProject(_ context.Context, req *mcp.CallToolRequest, input UnwatchProjectInput) (
+	*mcp.CallToolResult,
+	UnwatchProjectOutput,
+	error,
+) {
+	start := logStart("UnwatchProject", logFields(input.Dir))
+	out := UnwatchProjectOutput{}
+
+	defer func() { logEnd("UnwatchProject", start, len(out.Watching)) }()
+
+	dir, err := filepath.Abs(input.Dir)
+	if err != nil {
+		return fail(out, err)
+	}
+
+	if req == nil || req.Session == nil {
+		return fail(out, errors.New("unwatchProject requires an active client session"))
+	}
+
+	out.Dir = dir
+	out.Removed, out.Watching = removeProjectWatch(req.Session, dir)
+
+	return nil, out, nil
+}
+
+// addProjectWatch registers dir for the session and returns all directories it watches.
+// Subscriptions of a session are dropped once the client disconnects.
+func addProjectWatch(session *mcp.ServerSession, dir string) []string {
+	projectWatches.Lock()
+	defer projectWatches.Unlock()
+
+	sub, ok := projectWatches.subs[session]
+	if !ok {
+		sub = &watchSubscription{
+			session: session,
+			dirs:    make(map[string]struct{}),
+			pending: make(map[string]*pendingChange),
+		}
+		projectWatches.subs[session] = sub
+
+		go func() {
+			_ = session.Wait()
+
+			dropProjectWatches(session)
+		}()
+	}
+
+	sub.dirs[dir] = struct{}{}
+
+	return sub.watchedDirs()
+}
+
+// removeProjectWatch unregisters dir for the session and returns the remaining directories.
+func removeProjectWatch(session *mcp.ServerSession, dir string) (bool, []string) {
+	projectWatches.Lock()
+	defer projectWatches.Unlock()
+
+	sub, ok := projectWatches.subs[session]
+	if !ok {
+		return false, []string{}
+	}
+
+	_, removed := sub.dirs[dir]
+	delete(sub.dirs, dir)
+	delete(sub.pending, dir)
+
+	return removed, sub.watchedDirs()
+}
+
+// dropProjectWatches removes all subscriptions of a disconnected session.
+func dropProjectWatches(session *mcp.ServerSession) {
+	projectWatches.Lock()
+	defer projectWatches.Unlock()
+
+	if sub, ok := projectWatches.subs[session]; ok {
+		if sub.timer != nil {
+			sub.timer.Stop()
+		}
+
+		delete(projectWatches.subs, session)
+	}
+}
+
+// notifyProjectWatchers queues a file change for every subscription watching a directory that contains it.
+func notifyProjectWatchers(filePath string, invalidated []string) {
+	projectWatches.Lock()
+	defer projectWatches.Unlock()
+
+	for _, sub := range projectWatches.subs {
+		for dir := range sub.dirs {
+			rel, err := filepath.Rel(dir, filePath)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+
+			change, ok := sub.pending[dir]
+			if !ok {
+				change = &pendingChange{
+					files:       make(map[string]struct{}),
+					invalidated: make(map[string]struct{}),
+				}
+				sub.pending[dir] = change
+			}
+
+			change.files[filepath.ToSlash(rel)] = struct{}{}
+			for _, label := range invalidated {
+				change.invalidated[label] = struct{}{}
+			}
+
+			if sub.timer == nil {
+				sub.timer = time.AfterFunc(watchDebounce, func() { flushProjectWatch(sub) })
+			}
+		}
+	}
+}
+
+// flushProjectWatch sends the accumulated changes of a subscription as a single logging notification.
+func flushProjectWatch(sub *watchSubscription) {
+	projectWatches.Lock()
+	pending := sub.pending
+	sub.pending = make(map[string]*pendingChange)
+	sub.timer = nil
+	projectWatches.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	notification := ProjectChangeNotification{Event: "projectChanged"}
+
+	for dir, change := range pending {
+		notification.Changes = append(notification.Changes, ProjectChange{
+			Dir:         dir,
+			Files:       sortedKeys(change.files),
+			Invalidated: sortedKeys(change.invalidated),
+		})
+	}
+
+	sort.Slice(notification.Changes, func(i, j int) bool {
+		return notification.Changes[i].Dir < notification.Changes[j].Dir
+	})
+
+	err := sub.session.Log(context.Background(), &mcp.LoggingMessageParams{
+		Level:  "info",
+		Logger: watchLogger,
+		Data:   notification,
+	})
+	if err != nil {
+		log.Debug().Err(err).Msg("failed to send project change notification")
+	}
+}
+
+// watchedDirs returns the sorted list of directories watched by the subscription.
+func (sub *watchSubscription) watchedDirs() []string {
+	dirs := sortedKeys(sub.dirs)
+	if dirs == nil {
+		return []string{}
+	}
+
+	return dirs
+}
+
+// sortedKeys returns the keys of a map in sorted order, or nil for an empty map.
+func sortedKeys[V any](set map[string]V) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,153 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+// connectWatchClient starts an in-memory server exposing the watch tools and returns a connected client
+// together with a channel receiving project change notifications.
+func connectWatchClient(t *testing.T) (*mcp.ClientSession, <-chan tools.ProjectChangeNotification) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "go-navigator-test", Version: "v0.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "watchProject"}, tools.WatchProject)
+	mcp.AddTool(server, &mcp.Tool{Name: "unwatchProject"}, tools.UnwatchProject)
+
+	notifications := make(chan tools.ProjectChangeNotification, 8)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.0"}, &mcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, req *mcp.LoggingMessageRequest) {
+			data, err := json.Marshal(req.Params.Data)
+			if err != nil {
+				return
+			}
+
+			var n tools.ProjectChangeNotification
+			if json.Unmarshal(data, &n) == nil && n.Event == "projectChanged" {
+				notifications <- n
+			}
+		},
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect failed: %v", err)
+	}
+
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = session.Close()
+		_ = serverSession.Wait()
+	})
+
+	if err := session.SetLoggingLevel(ctx, &mcp.SetLoggingLevelParams{Level: "info"}); err != nil {
+		t.Fatalf("SetLoggingLevel failed: %v", err)
+	}
+
+	return session, notifications
+}
+
+func TestWatchProject_NotifiesOnChange(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	if err := copyDir(testDir(), tmp); err != nil {
+		t.Fatalf("copyDir failed: %v", err)
+	}
+
+	session, notifications := connectWatchClient(t)
+	ctx := context.Background()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "watchProject",
+		Arguments: map[string]any{"dir": tmp},
+	})
+	if err != nil {
+		t.Fatalf("watchProject call failed: %v", err)
+	}
+
+	if res.IsError {
+		t.Fatalf("watchProject returned error: %+v", res.Content)
+	}
+
+	target := filepath.Join(tmp, "bar.go")
+
+	src, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	if err := os.WriteFile(target, append(src, []byte("\n// edited\n")...), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	select {
+	case n := <-notifications:
+		if len(n.Changes) != 1 {
+			t.Fatalf("expected changes for one directory, got %+v", n.Changes)
+		}
+
+		if !containsAll(n.Changes[0].Files, "bar.go") {
+			t.Errorf("expected bar.go among changed files, got %v", n.Changes[0].Files)
+		}
+
+		if len(n.Changes[0].Invalidated) == 0 {
+			t.Errorf("expected invalidated analyses to be reported")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for change notification")
+	}
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "unwatchProject",
+		Arguments: map[string]any{"dir": tmp},
+	})
+	if err != nil {
+		t.Fatalf("unwatchProject call failed: %v", err)
+	}
+
+	var out tools.UnwatchProjectOutput
+
+	data, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("decode unwatchProject output: %v", err)
+	}
+
+	if !out.Removed || len(out.Watching) != 0 {
+		t.Errorf("expected subscription to be removed, got %+v", out)
+	}
+}
+
+func TestWatchProject_WithoutSession(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.WatchProject(context.Background(), &mcp.CallToolRequest{}, tools.WatchProjectInput{Dir: testDir()})
+	if err == nil {
+		t.Fatal("expected error without a client session")
+	}
+}
+
+func TestWatchProject_WithInvalidDir(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tools.WatchProject(context.Background(), &mcp.CallToolRequest{}, tools.WatchProjectInput{Dir: "/nonexistent/path"})
+	if err == nil {
+		t.Error("expected error for invalid directory")
+	}
+}
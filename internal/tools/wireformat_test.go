@@ -0,0 +1,105 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+func TestFindReferences_CompactWireFormat(t *testing.T) {
+	t.Parallel()
+
+	var calls strings.Builder
+	for range 40 {
+		calls.WriteString("\tTarget()\n")
+	}
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"a.go": "package lang\n\nfunc Target() {}\n",
+		"b.go": "package lang\n\nfunc use() {\n" + calls.String() + "}\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, def, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Target"})
+	if err != nil {
+		t.Fatalf("FindReferences: %v", err)
+	}
+
+	_, compact, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Target", Compact: true})
+	if err != nil {
+		t.Fatalf("FindReferences compact: %v", err)
+	}
+
+	defJSON, err := json.Marshal(def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compactJSON, err := json.Marshal(compact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The default form is unchanged: groups of {line, snippet} objects and no files.
+	if def.Files != nil || len(def.Groups) != 2 ||
+		!strings.Contains(string(defJSON), `{"file":"b.go","references":[{"line":4,"snippet":"Target()"},`) {
+		t.Errorf("default payload = %s", defJSON)
+	}
+
+	if compact.Groups != nil || compact.Total != def.Total || len(compact.Files) != 2 ||
+		!strings.Contains(string(compactJSON), `{"file":"b.go","refs":[[4,"Target()"],[5,"Target()"],`) {
+		t.Errorf("compact payload = %s", compactJSON)
+	}
+
+	t.Logf("size report: default %d bytes, compact %d bytes", len(defJSON), len(compactJSON))
+
+	if len(compactJSON)*10 > len(defJSON)*6 {
+		t.Errorf("compact payload is %d bytes, want at least 40%% below the default %d bytes", len(compactJSON), len(defJSON))
+	}
+
+	_, _, err = tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "Target", Compact: true, LSPLocations: true})
+	if te := tools.AsToolError(err); te == nil || te.Code != tools.CodeInvalidInput {
+		t.Errorf("expected INVALID_INPUT for compact with lspLocations, got %v", err)
+	}
+}
+
+func TestOutputs_OmitZeroValues(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{
+		"a.go": "package lang\n\nfunc A() { b() }\n\nfunc b() {}\n",
+	})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, dead, err := tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("DeadCode: %v", err)
+	}
+
+	_, refs, err := tools.FindReferences(ctx, req, tools.FindReferencesInput{Dir: dir, Ident: "b"})
+	if err != nil {
+		t.Fatalf("FindReferences: %v", err)
+	}
+
+	for name, v := range map[string]any{"deadCode": dead, "references": refs} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, key := range []string{`"byPackage"`, `"limit"`, `"files"`} {
+			if strings.Contains(string(data), key) {
+				t.Errorf("%s payload %s has the empty %s", name, data, key)
+			}
+		}
+	}
+
+	if got := fmt.Sprint(refs.Total, len(refs.Groups)); got != "2 1" {
+		t.Errorf("references = %+v, want 2 in one file", refs)
+	}
+}
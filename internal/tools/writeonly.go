@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// deadKindWriteOnlyVar is the DeadSymbol kind of variables that are assigned but never read.
+const deadKindWriteOnlyVar = "write-only-var"
+
+// varAccess counts the reads and writes of one variable.
+type varAccess struct {
+	reads, writes int
+	addressTaken  bool
+}
+
+// writeOnlyVar is a variable that is assigned but never read.
+type writeOnlyVar struct {
+	ident *ast.Ident
+	obj   *types.Var
+}
+
+// writeOnlyVars returns the local and package-level variables of pkg that are written at least once and
+// never read. Blank variables, named results, struct fields and variables whose address is taken
+// (explicitly or by calling a pointer method) are left out, since they may be read through another name.
+// foreignUses holds the objectKey of objects referenced from other packages; exported variables in it
+// are read or written elsewhere and are skipped too.
+func writeOnlyVars(pkg *packages.Package, foreignUses map[string]struct{}) []writeOnlyVar {
+	info := pkg.TypesInfo
+	writes := make(map[*ast.Ident]struct{})
+	access := make(map[*types.Var]*varAccess)
+	namedResults := make(map[types.Object]struct{})
+
+	accessOf := func(id *ast.Ident) *varAccess {
+		v, ok := info.Uses[id].(*types.Var)
+		if !ok || v.IsField() {
+			return nil
+		}
+
+		if access[v] == nil {
+			access[v] = &varAccess{}
+		}
+
+		return access[v]
+	}
+
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncType:
+				if n.Results != nil {
+					for _, field := range n.Results.List {
+						for _, name := range field.Names {
+							namedResults[info.Defs[name]] = struct{}{}
+						}
+					}
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if id := writtenVar(info, lhs); id != nil {
+						writes[id] = struct{}{}
+					}
+				}
+			case *ast.IncDecStmt:
+				if id := writtenVar(info, n.X); id != nil {
+					writes[id] = struct{}{}
+				}
+			case *ast.RangeStmt:
+				if n.Tok == token.ASSIGN {
+					for _, e := range []ast.Expr{n.Key, n.Value} {
+						if id := writtenVar(info, e); id != nil {
+							writes[id] = struct{}{}
+						}
+					}
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND {
+					if id := addressedVar(info, n.X); id != nil {
+						if a := accessOf(id); a != nil {
+							a.addressTaken = true
+						}
+					}
+				}
+			case *ast.SelectorExpr:
+				if sel := info.Selections[n]; sel != nil && sel.Kind() == types.MethodVal {
+					if sig, ok := sel.Obj().Type().(*types.Signature); ok && sig.Recv() != nil {
+						_, ptrRecv := sig.Recv().Type().(*types.Pointer)
+						if _, ptrBase := sel.Recv().Underlying().(*types.Pointer); ptrRecv && !ptrBase {
+							if id := addressedVar(info, n.X); id != nil {
+								if a := accessOf(id); a != nil {
+									a.addressTaken = true
+								}
+							}
+						}
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	for id := range info.Uses {
+		a := accessOf(id)
+		if a == nil {
+			continue
+		}
+
+		if _, ok := writes[id]; ok {
+			a.writes++
+		} else {
+			a.reads++
+		}
+	}
+
+	var result []writeOnlyVar
+
+	for id, obj := range info.Defs {
+		v, ok := obj.(*types.Var)
+		if !ok || v.IsField() || id.Name == "_" {
+			continue
+		}
+
+		a := access[v]
+		if a == nil || a.writes == 0 || a.reads > 0 || a.addressTaken {
+			continue
+		}
+
+		if _, ok := namedResults[v]; ok {
+			continue
+		}
+
+		if v.Exported() && v.Parent() == v.Pkg().Scope() {
+			if _, ok := foreignUses[objectKey(v)]; ok {
+				continue
+			}
+		}
+
+		result = append(result, writeOnlyVar{ident: id, obj: v})
+	}
+
+	return result
+}
+
+// writtenVar returns the variable an assignment to lhs writes: lhs itself, or the root of a field
+// selection or array index of a variable stored by value (s.f = 1 writes s, p.f = 1 reads p).
+func writtenVar(info *types.Info, lhs ast.Expr) *ast.Ident {
+	for {
+		switch e := ast.Unparen(lhs).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			if isQualifiedIdent(info, e) {
+				return e.Sel
+			}
+
+			sel := info.Selections[e]
+			if sel == nil || sel.Kind() != types.FieldVal || sel.Indirect() {
+				return nil
+			}
+
+			lhs = e.X
+		case *ast.IndexExpr:
+			if _, ok := info.TypeOf(e.X).Underlying().(*types.Array); !ok {
+				return nil
+			}
+
+			lhs = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// addressedVar returns the variable whose storage &x, &x.f or &x[i] points into.
+func addressedVar(info *types.Info, x ast.Expr) *ast.Ident {
+	for {
+		switch e := ast.Unparen(x).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			if isQualifiedIdent(info, e) {
+				return e.Sel
+			}
+
+			x = e.X
+		case *ast.IndexExpr:
+			x = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// isQualifiedIdent reports whether sel is an imported package member such as pkg.Var.
+func isQualifiedIdent(info *types.Info, sel *ast.SelectorExpr) bool {
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, ok = info.Uses[id].(*types.PkgName)
+
+	return ok
+}
+
+// foreignObjectKeys collects the objectKey of objects each package references from another package.
+func foreignObjectKeys(pkgs []*packages.Package) map[string]struct{} {
+	keys := make(map[string]struct{})
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil || pkg.Types == nil {
+			continue
+		}
+
+		for _, obj := range pkg.TypesInfo.Uses {
+			if obj != nil && obj.Pkg() != nil && obj.Pkg().Path() != pkg.Types.Path() {
+				keys[objectKey(obj)] = struct{}{}
+			}
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,97 @@
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go-navigator/internal/tools"
+)
+
+const writeOnlySource = `package lang
+
+var hits int
+
+var Exported int
+
+var read int
+
+type stats struct{ n int }
+
+func Track(xs []int) (total int) {
+	hits++
+	Exported = len(xs)
+	read = 1
+
+	count := 0
+	for range xs {
+		count++
+	}
+
+	var s stats
+	s.n = len(xs)
+
+	addressed := 0
+	addressed = 2
+	_ = &addressed
+
+	total = read
+
+	return total
+}
+
+func Named() (n int) {
+	n = 1
+
+	return
+}
+`
+
+func TestDeadCode_WriteOnly(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLanguageModule(t, "1.22", map[string]string{"lang.go": writeOnlySource})
+	ctx, req := context.Background(), &mcp.CallToolRequest{}
+
+	_, out, err := tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, IncludeWriteOnly: true})
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	var got []string
+
+	for _, sym := range out.Unused {
+		if sym.Kind == "write-only-var" {
+			got = append(got, sym.Name)
+		}
+	}
+
+	slices.Sort(got)
+
+	if want := []string{"count", "hits", "s"}; !slices.Equal(got, want) {
+		t.Fatalf("expected write-only variables %v, got %v", want, got)
+	}
+
+	if out.ByKind["write-only-var"] != 3 {
+		t.Errorf("expected byKind to count 3 write-only variables, got %v", out.ByKind)
+	}
+
+	_, out, err = tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir, IncludeWriteOnly: true, IncludeExported: true})
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	if !slices.ContainsFunc(out.Unused, func(s tools.DeadSymbol) bool { return s.Name == "Exported" && s.Kind == "write-only-var" }) {
+		t.Errorf("expected the exported write-only variable with includeExported, got %+v", out.Unused)
+	}
+
+	_, out, err = tools.DeadCode(ctx, req, tools.DeadCodeInput{Dir: dir})
+	if err != nil {
+		t.Fatalf("DeadCode error: %v", err)
+	}
+
+	if slices.ContainsFunc(out.Unused, func(s tools.DeadSymbol) bool { return s.Kind == "write-only-var" }) {
+		t.Errorf("expected no write-only variables without includeWriteOnly, got %+v", out.Unused)
+	}
+}